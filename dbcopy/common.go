@@ -4,8 +4,12 @@
 package main
 
 import (
+	"database/sql"
+	"errors"
 	"os"
+	"strconv"
 
+	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
@@ -29,3 +33,152 @@ func dirDeleteAndLog(path string) bool {
 	}
 	return true // OK: deleted successfully
 }
+
+// Open source database connection and return close function.
+// If -dbcopy.Snapshot specified then open read-only snapshot copy of SQLite database, snapshot file deleted by close function.
+func openSrcDb(dbConnStr, dbDriver string) (*sql.DB, func(), error) {
+
+	if theCfg.isSnapshot {
+		return db.OpenSnapshot(dbConnStr, dbDriver, "")
+	}
+
+	srcDb, _, err := db.Open(dbConnStr, dbDriver, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return srcDb, func() { db.Close(srcDb) }, nil
+}
+
+// import conflict policies if workset or model run already exist in destination database
+const (
+	ifExistsDefault = ""        // default: replace existing workset and skip existing model run
+	ifExistsSkip    = "skip"    // do not import workset or model run if it already exist
+	ifExistsReplace = "replace" // replace existing workset, delete existing model run and import it again
+	ifExistsMerge   = "merge"   // replace only workset parameters with different values, merge model run text
+	ifExistsRename  = "rename"  // import workset or model run under new unique name
+)
+
+// prepareDstWorkset apply -dbcopy.IfExists policy to destination workset before import.
+// If destination workset exists then it is made read-write and by default all existing parameters deleted from workset.
+// If policy is rename then workset name is changed to unique name, e.g.: Default_1.
+// It return true if workset import must be skipped and true if workset parameters must be merged.
+func prepareDstWorkset(dstDb *sql.DB, modelDef *db.ModelMeta, ws *db.WorksetMeta) (bool, bool, error) {
+
+	wsRow, err := db.GetWorksetByName(dstDb, modelDef.Model.ModelId, ws.Set.Name)
+	if err != nil {
+		return false, false, err
+	}
+	if wsRow == nil {
+		return false, false, nil // workset not exist: import as new workset
+	}
+
+	switch theCfg.ifExists {
+	case ifExistsSkip:
+		omppLog.Log("Workset ", ws.Set.Name, " already exists as ", wsRow.SetId, ", skip")
+		ws.Set.SetId = wsRow.SetId
+		return true, false, nil
+
+	case ifExistsRename:
+		for n := 1; ; n++ {
+			nm := ws.Set.Name + "_" + strconv.Itoa(n)
+
+			r, err := db.GetWorksetByName(dstDb, modelDef.Model.ModelId, nm)
+			if err != nil {
+				return false, false, err
+			}
+			if r == nil {
+				omppLog.Log("Workset ", ws.Set.Name, " already exists as ", wsRow.SetId, ", import as: ", nm)
+				ws.Set.Name = nm
+				return false, false, nil
+			}
+		}
+	}
+
+	// replace or merge: make destination workset read-write
+	err = db.UpdateWorksetReadonly(dstDb, wsRow.SetId, false)
+	if err != nil {
+		return false, false, errors.New("failed to clear workset read-only status: " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name + " " + err.Error())
+	}
+	if theCfg.ifExists == ifExistsMerge {
+		return false, true, nil
+	}
+
+	// replace: delete all parameters from workset
+	err = db.DeleteWorksetAllParameters(dstDb, wsRow.SetId)
+	if err != nil {
+		return false, false, errors.New("failed to delete workset " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name + " " + err.Error())
+	}
+	return false, false, nil
+}
+
+// updateDstRun apply -dbcopy.IfExists policy and save model run metadata in destination database.
+// Model run already exist if destination database contains model run with the same run digest.
+// It return true if model run created and run values must be imported
+// or false if model run already exist and import of values must be skipped.
+func updateDstRun(dstDb *sql.DB, modelDef *db.ModelMeta, langDef *db.LangMeta, meta *db.RunMeta) (bool, error) {
+
+	// rename: if it is a new model run and run name already exist then use unique run name
+	if theCfg.ifExists == ifExistsRename {
+
+		r, err := db.GetRunByDigest(dstDb, meta.Run.RunDigest)
+		if err != nil {
+			return false, err
+		}
+		if r == nil {
+			for n := 0; ; n++ {
+				nm := meta.Run.Name
+				if n > 0 {
+					nm = meta.Run.Name + "_" + strconv.Itoa(n)
+				}
+				r, err = db.GetRunByName(dstDb, modelDef.Model.ModelId, nm)
+				if err != nil {
+					return false, err
+				}
+				if r == nil {
+					if n > 0 {
+						omppLog.Log("Model run ", meta.Run.Name, " already exists, import as: ", nm)
+						meta.Run.Name = nm
+					}
+					break
+				}
+			}
+		}
+	}
+
+	isExist, err := meta.UpdateRun(dstDb, modelDef, langDef, theCfg.doubleFmt)
+	if err != nil {
+		return false, err
+	}
+	if !isExist {
+		return true, nil // new model run created
+	}
+	dstId := meta.Run.RunId
+
+	switch theCfg.ifExists {
+	case ifExistsReplace:
+		omppLog.Log("Model run ", meta.Run.Name, " already exists as ", dstId, ", replace")
+
+		if err = db.DeleteRun(dstDb, dstId); err != nil {
+			return false, errors.New("failed to delete model run: " + strconv.Itoa(dstId) + " " + meta.Run.Name + " " + err.Error())
+		}
+		isExist, err = meta.UpdateRun(dstDb, modelDef, langDef, theCfg.doubleFmt)
+		if err != nil {
+			return false, err
+		}
+		if isExist {
+			return false, errors.New("failed to replace model run: " + strconv.Itoa(dstId) + " " + meta.Run.Name)
+		}
+		return true, nil
+
+	case ifExistsMerge:
+		omppLog.Log("Model run ", meta.Run.Name, " already exists as ", dstId, ", merge model run text")
+
+		if err = meta.UpdateRunText(dstDb, modelDef, dstId, langDef); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	omppLog.Log("Model run ", meta.Run.Name, " already exists as ", dstId)
+	return false, nil
+}
@@ -22,7 +22,7 @@ func dbDeleteModel(modelName string, modelDigest string, runOpts *config.RunOpti
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -57,7 +57,7 @@ func dbDeleteRun(modelName string, modelDigest string, runOpts *config.RunOption
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -134,7 +134,7 @@ func dbDeleteWorkset(modelName string, modelDigest string, runOpts *config.RunOp
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -216,7 +216,7 @@ func dbDeleteTask(modelName string, modelDigest string, runOpts *config.RunOptio
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
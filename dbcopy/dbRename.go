@@ -28,7 +28,7 @@ func dbRenameRun(modelName string, modelDigest string, runOpts *config.RunOption
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -114,7 +114,7 @@ func dbRenameWorkset(modelName string, modelDigest string, runOpts *config.RunOp
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -201,7 +201,7 @@ func dbRenameTask(modelName string, modelDigest string, runOpts *config.RunOptio
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer db.Close(srcDb)
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
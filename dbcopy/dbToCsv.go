@@ -5,7 +5,6 @@ package main
 
 import (
 	"database/sql"
-	"encoding/csv"
 	"errors"
 	"os"
 	"path/filepath"
@@ -27,11 +26,11 @@ func dbToCsv(modelName string, modelDigest string, isAllInOne bool, runOpts *con
 	// open source database connection and check is it valid
 	cs, dn := db.IfEmptyMakeDefaultReadOnly(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
 
-	srcDb, _, err := db.Open(cs, dn, false)
+	srcDb, closeSrc, err := openSrcDb(cs, dn)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -751,15 +750,10 @@ func toCsvFile(
 	}
 	defer f.Close()
 
-	if theCfg.isWriteUtf8Bom { // if required then write utf-8 bom
-		if _, err = f.Write(helper.Utf8bom); err != nil {
-			return err
-		}
-	}
-
-	wr := csv.NewWriter(f)
-	if theCfg.isTsv {
-		wr.Comma = '\t'
+	// if required then write utf-8 bom
+	wr, err := helper.NewCsvWriter(f, helper.CsvOptions{IsTsv: theCfg.isTsv, IsBom: theCfg.isWriteUtf8Bom})
+	if err != nil {
+		return err
 	}
 
 	// write header line: column names, if provided
@@ -9,7 +9,6 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"time"
 
 	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/helper"
@@ -82,7 +81,7 @@ func toRunCsv(
 	// write all parameters into csv file
 	nP := len(modelDef.Param)
 	omppLog.Log("  Parameters: ", nP)
-	logT := time.Now().Unix()
+	tw := newTableWorkers(false) // parallel workers to write parameters, output tables and microdata csv files
 
 	for j := 0; j < nP; j++ {
 
@@ -97,13 +96,17 @@ func toRunCsv(
 			FromId: runId,
 		}}
 
-		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nP, ": ", paramLt.Name)
+		tw.logIfTime("    ", j, " of ", nP, ": ", paramLt.Name)
 
-		err := toCellCsvFile(dbConn, modelDef, paramLt, cvtParam, fileCreated, paramCsvDir, firstCol, firstVal)
-		if err != nil {
-			return err
+		if !tw.do(func() error {
+			return toCellCsvFile(dbConn, modelDef, paramLt, cvtParam, fileCreated, paramCsvDir, firstCol, firstVal)
+		}) {
+			break
 		}
 	}
+	if err := tw.wait(); err != nil {
+		return err
+	}
 
 	// write each run parameter value notes into parameterName.LANG.md file
 	if !isAllInOne {
@@ -173,38 +176,46 @@ func toRunCsv(
 		cvtAcc := &db.CellAccConverter{CellTableConverter: ctc}
 		cvtAll := &db.CellAllAccConverter{CellTableConverter: ctc}
 
-		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name)
+		tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name)
 
-		err := toCellCsvFile(dbConn, modelDef, tblLt, cvtExpr, fileCreated, tableCsvDir, firstCol, firstVal)
-		if err != nil {
-			return err
+		if !tw.do(func() error {
+			return toCellCsvFile(dbConn, modelDef, tblLt, cvtExpr, fileCreated, tableCsvDir, firstCol, firstVal)
+		}) {
+			break
 		}
 
 		// write output table accumulators into csv file
 		if !theCfg.isNoAccCsv {
 
-			tblLt.IsAccum = true
-			tblLt.IsAllAccum = false
+			accLt := tblLt
+			accLt.IsAccum = true
+			accLt.IsAllAccum = false
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name, " accumulators")
+			tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name, " accumulators")
 
-			err = toCellCsvFile(dbConn, modelDef, tblLt, cvtAcc, fileCreated, tableCsvDir, firstCol, firstVal)
-			if err != nil {
-				return err
+			if !tw.do(func() error {
+				return toCellCsvFile(dbConn, modelDef, accLt, cvtAcc, fileCreated, tableCsvDir, firstCol, firstVal)
+			}) {
+				break
 			}
 
 			// write all accumulators view into csv file
-			tblLt.IsAccum = true
-			tblLt.IsAllAccum = true
+			allLt := tblLt
+			allLt.IsAccum = true
+			allLt.IsAllAccum = true
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name, " all accumulators")
+			tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name, " all accumulators")
 
-			err = toCellCsvFile(dbConn, modelDef, tblLt, cvtAll, fileCreated, tableCsvDir, firstCol, firstVal)
-			if err != nil {
-				return err
+			if !tw.do(func() error {
+				return toCellCsvFile(dbConn, modelDef, allLt, cvtAll, fileCreated, tableCsvDir, firstCol, firstVal)
+			}) {
+				break
 			}
 		}
 	}
+	if err := tw.wait(); err != nil {
+		return err
+	}
 
 	// write microdata into csv file, if there is any microdata for that model run and microadata write enabled
 	if !theCfg.isNoMicrodata && nMd > 0 {
@@ -236,13 +247,17 @@ func toRunCsv(
 				GenDigest: meta.EntityGen[j].GenDigest,
 			}
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nMd, ": ", microLt.Name)
+			tw.logIfTime("    ", j, " of ", nMd, ": ", microLt.Name)
 
-			err := toCellCsvFile(dbConn, modelDef, microLt, cvtMicro, fileCreated, microCsvDir, firstCol, firstVal)
-			if err != nil {
-				return err
+			if !tw.do(func() error {
+				return toCellCsvFile(dbConn, modelDef, microLt, cvtMicro, fileCreated, microCsvDir, firstCol, firstVal)
+			}) {
+				break
 			}
 		}
+		if err := tw.wait(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
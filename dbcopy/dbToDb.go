@@ -24,11 +24,11 @@ func dbToDb(modelName string, modelDigest string, runOpts *config.RunOptions) er
 	}
 
 	// open source database connection and check is it valid
-	srcDb, _, err := db.Open(csInp, dnInp, false)
+	srcDb, closeSrc, err := openSrcDb(csInp, dnInp)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -39,7 +39,7 @@ func dbToDb(modelName string, modelDigest string, runOpts *config.RunOptions) er
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -110,6 +110,11 @@ func copyDbToDb(
 	if _, err = db.UpdateModel(dstDb, dbFacet, dstModel); err != nil {
 		return err
 	}
+	if theCfg.isMapViews {
+		if err = db.CreateModelMapViews(dstDb); err != nil {
+			return err
+		}
+	}
 
 	// destination: insert or update language list
 	if err = db.UpdateLanguage(dstDb, dstLang); err != nil {
@@ -27,11 +27,11 @@ func dbToDbRun(modelName string, modelDigest string, runOpts *config.RunOptions)
 	}
 
 	// open source database connection and check is it valid
-	srcDb, _, err := db.Open(csInp, dnInp, false)
+	srcDb, closeSrc, err := openSrcDb(csInp, dnInp)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -42,7 +42,7 @@ func dbToDbRun(modelName string, modelDigest string, runOpts *config.RunOptions)
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -165,14 +165,13 @@ func copyRunDbToDb(
 		return 0, err
 	}
 
-	// destination: save model run metadata
-	isExist, err := dstRun.UpdateRun(dstDb, dstModel, dstLang, theCfg.doubleFmt)
+	// destination: save model run metadata, if model run already exist then skip, replace, merge or rename it
+	isNew, err := updateDstRun(dstDb, dstModel, dstLang, dstRun)
 	if err != nil {
 		return 0, err
 	}
 	dstId := dstRun.Run.RunId
-	if isExist { // exit if model run already exist
-		omppLog.Log("Model run ", srcId, " ", pub.Name, " already exists as ", dstId)
+	if !isNew { // exit if model run already exist
 		return dstId, nil
 	}
 
@@ -181,6 +180,7 @@ func copyRunDbToDb(
 	nP := len(srcModel.Param)
 	omppLog.Log("  Parameters: ", nP)
 	logT := time.Now().Unix()
+	lnkStat := runLinkStat{}
 
 	// copy all parameters values for that model run
 	for j := range srcModel.Param {
@@ -194,9 +194,29 @@ func copyRunDbToDb(
 		}
 		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nP, ": ", paramLt.Name)
 
+		// destination: insert parameter values in model run
+		dstParamLt := db.WriteParamLayout{
+			WriteLayout: db.WriteLayout{
+				Name: dstModel.Param[j].Name,
+				ToId: dstId,
+			},
+			SubCount:  dstRun.Param[j].SubCount,
+			IsToRun:   true,
+			DoubleFmt: theCfg.doubleFmt,
+		}
+
+		// if the same parameter values already exist in destination database then link to it instead of copy
+		isLnk, err := linkRunParam(dstDb, dstModel, &dstParamLt, paramLt.Name, pub, &lnkStat)
+		if err != nil {
+			return 0, err
+		}
+		if isLnk {
+			continue
+		}
+
 		cLst := list.New()
 
-		_, err := db.ReadParameterTo(srcDb, srcModel, &paramLt, func(src interface{}) (bool, error) {
+		_, err = db.ReadParameterTo(srcDb, srcModel, &paramLt, func(src interface{}) (bool, error) {
 			cLst.PushBack(src)
 			return true, nil
 		})
@@ -207,21 +227,11 @@ func copyRunDbToDb(
 			return 0, errors.New("missing run parameter values " + paramLt.Name + " run id: " + strconv.Itoa(paramLt.FromId))
 		}
 
-		// destination: insert parameter values in model run
-		dstParamLt := db.WriteParamLayout{
-			WriteLayout: db.WriteLayout{
-				Name: dstModel.Param[j].Name,
-				ToId: dstId,
-			},
-			SubCount:  dstRun.Param[j].SubCount,
-			IsToRun:   true,
-			DoubleFmt: theCfg.doubleFmt,
-		}
-
 		if err = db.WriteParameterFrom(dstDb, dstModel, &dstParamLt, makeFromList(cLst)); err != nil {
 			return 0, err
 		}
 	}
+	lnkStat.log()
 
 	// copy all output tables values for that model run, if the table included in run results
 	nT := len(srcModel.Table)
@@ -44,11 +44,11 @@ func dbToDbTask(modelName string, modelDigest string, runOpts *config.RunOptions
 	}
 
 	// open source database connection and check is it valid
-	srcDb, _, err := db.Open(csInp, dnInp, false)
+	srcDb, closeSrc, err := openSrcDb(csInp, dnInp)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -59,7 +59,7 @@ func dbToDbTask(modelName string, modelDigest string, runOpts *config.RunOptions
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -46,11 +46,11 @@ func dbToDbWorkset(modelName string, modelDigest string, runOpts *config.RunOpti
 	}
 
 	// open source database connection and check is it valid
-	srcDb, _, err := db.Open(csInp, dnInp, false)
+	srcDb, closeSrc, err := openSrcDb(csInp, dnInp)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -61,7 +61,7 @@ func dbToDbWorkset(modelName string, modelDigest string, runOpts *config.RunOpti
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -181,7 +181,7 @@ func copyWorksetDbToDb(
 
 	// save workset metadata as "read-write" and after importing all parameters set it as "readonly"
 	// save workset metadata parameters list, make it empty and use add parameters to update metadata and values from csv
-	isReadonly := pub.IsReadonly
+	isReadonly := pub.IsReadonly && !theCfg.isSetReadWrite
 	pub.IsReadonly = false
 	paramLst := append([]db.ParamRunSetPub{}, pub.Param...)
 	pub.Param = []db.ParamRunSetPub{}
@@ -196,24 +196,17 @@ func copyWorksetDbToDb(
 		omppLog.Log("Warning: workset ", dstWs.Set.Name, ", base run not found by digest ", pub.BaseRunDigest)
 	}
 
-	// if destination workset exists then make it read-write and delete all existing parameters from workset
-	wsRow, err := db.GetWorksetByName(dstDb, dstModel.Model.ModelId, pub.Name)
+	// if destination workset exists then skip, rename or make it read-write and delete or merge existing parameters
+	isSkip, isMerge, err := prepareDstWorkset(dstDb, dstModel, dstWs)
 	if err != nil {
 		return 0, err
 	}
-	if wsRow != nil {
-		err = db.UpdateWorksetReadonly(dstDb, wsRow.SetId, false) // make destination workset read-write
-		if err != nil {
-			return 0, errors.New("failed to clear workset read-only status: " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name + " " + err.Error())
-		}
-		err = db.DeleteWorksetAllParameters(dstDb, wsRow.SetId) // delete all parameters from workset
-		if err != nil {
-			return 0, errors.New("failed to delete workset " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name + " " + err.Error())
-		}
+	if isSkip {
+		return dstWs.Set.SetId, nil
 	}
 
 	// create empty workset metadata or update existing workset metadata
-	err = dstWs.UpdateWorkset(dstDb, dstModel, true, dstLang)
+	err = dstWs.UpdateWorkset(dstDb, dstModel, !isMerge, dstLang)
 	if err != nil {
 		return 0, err
 	}
@@ -224,6 +217,7 @@ func copyWorksetDbToDb(
 	nP := len(paramLst)
 	omppLog.Log("  Parameters: ", nP)
 	logT := time.Now().Unix()
+	nSame := 0
 
 	paramLt := &db.ReadParamLayout{ReadLayout: db.ReadLayout{FromId: srcId}, IsFromSet: true}
 
@@ -247,12 +241,25 @@ func copyWorksetDbToDb(
 			return 0, errors.New("missing workset parameter values " + paramLt.Name + " set id: " + strconv.Itoa(paramLt.FromId))
 		}
 
-		// destination: insert or update parameter values in workset
+		// destination: insert or update parameter values in workset, on merge update only if values are different
+		if isMerge {
+			_, isUpd, err := dstWs.MergeWorksetParameterFrom(dstDb, dstModel, &paramLst[j], dstLang, makeFromList(cLst))
+			if err != nil {
+				return 0, err
+			}
+			if !isUpd {
+				nSame++
+			}
+			continue
+		}
 		_, err = dstWs.UpdateWorksetParameterFrom(dstDb, dstModel, true, &paramLst[j], dstLang, makeFromList(cLst))
 		if err != nil {
 			return 0, err
 		}
 	}
+	if isMerge {
+		omppLog.Log("  Parameters unchanged: ", nSame)
+	}
 
 	// update workset readonly status with actual value
 	err = db.UpdateWorksetReadonly(dstDb, dstId, isReadonly)
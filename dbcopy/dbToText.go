@@ -5,7 +5,6 @@ package main
 
 import (
 	"database/sql"
-	"encoding/csv"
 	"errors"
 	"os"
 	"path/filepath"
@@ -23,11 +22,11 @@ func dbToText(modelName string, modelDigest string, runOpts *config.RunOptions)
 	// open source database connection and check is it valid
 	cs, dn := db.IfEmptyMakeDefaultReadOnly(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
 
-	srcDb, _, err := db.Open(cs, dn, false)
+	srcDb, closeSrc, err := openSrcDb(cs, dn)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -179,7 +178,10 @@ func toCellCsvFile(
 		fn = fn[:len(fn)-4] + ".tsv"
 	}
 	p := filepath.Join(csvDir, fn)
+	fileCreatedLock.Lock()
 	_, isAppend := fileCreated[p]
+	fileCreated[p] = true
+	fileCreatedLock.Unlock()
 
 	flag := os.O_CREATE | os.O_TRUNC | os.O_WRONLY
 	if isAppend {
@@ -190,18 +192,12 @@ func toCellCsvFile(
 	if err != nil {
 		return err
 	}
-	fileCreated[p] = true
 	defer f.Close()
 
-	if theCfg.isWriteUtf8Bom { // if required then write utf-8 bom
-		if _, err = f.Write(helper.Utf8bom); err != nil {
-			return err
-		}
-	}
-
-	wr := csv.NewWriter(f)
-	if theCfg.isTsv {
-		wr.Comma = '\t'
+	// if required then write utf-8 bom, do not write it in the middle of file on append
+	wr, err := helper.NewCsvWriter(f, helper.CsvOptions{IsTsv: theCfg.isTsv, IsBom: theCfg.isWriteUtf8Bom && !isAppend})
+	if err != nil {
+		return err
 	}
 
 	// if not append to already existing csv file then write header line: column names
@@ -9,7 +9,6 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"time"
 
 	"github.com/openmpp/go/ompp/config"
 	"github.com/openmpp/go/ompp/db"
@@ -25,11 +24,11 @@ func dbToTextRun(modelName string, modelDigest string, runOpts *config.RunOption
 	// open source database connection and check is it valid
 	cs, dn := db.IfEmptyMakeDefaultReadOnly(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
 
-	srcDb, _, err := db.Open(cs, dn, false)
+	srcDb, closeSrc, err := openSrcDb(cs, dn)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -217,7 +216,7 @@ func toRunText(
 			return err
 		}
 	}
-	logT := time.Now().Unix()
+	tw := newTableWorkers(false) // parallel workers to write parameters, output tables and microdata csv files
 
 	// write all parameters into csv files
 	nP := len(modelDef.Param)
@@ -236,13 +235,17 @@ func toRunText(
 			FromId: runId,
 		}}
 
-		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nP, ": ", paramLt.Name)
+		tw.logIfTime("    ", j, " of ", nP, ": ", paramLt.Name)
 
-		err = toCellCsvFile(dbConn, modelDef, paramLt, cvtParam, fileCreated, paramCsvDir, "", "")
-		if err != nil {
-			return err
+		if !tw.do(func() error {
+			return toCellCsvFile(dbConn, modelDef, paramLt, cvtParam, fileCreated, paramCsvDir, "", "")
+		}) {
+			break
 		}
 	}
+	if err := tw.wait(); err != nil {
+		return err
+	}
 
 	// write output tables into csv files, if the table included in run results
 	nT := len(modelDef.Table)
@@ -281,38 +284,46 @@ func toRunText(
 		cvtAcc := &db.CellAccConverter{CellTableConverter: ctc}
 		cvtAll := &db.CellAllAccConverter{CellTableConverter: ctc}
 
-		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name)
+		tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name)
 
-		err = toCellCsvFile(dbConn, modelDef, tblLt, cvtExpr, fileCreated, tableCsvDir, "", "")
-		if err != nil {
-			return err
+		if !tw.do(func() error {
+			return toCellCsvFile(dbConn, modelDef, tblLt, cvtExpr, fileCreated, tableCsvDir, "", "")
+		}) {
+			break
 		}
 
 		// write output table accumulators into csv file
 		if !theCfg.isNoAccCsv {
 
-			tblLt.IsAccum = true
-			tblLt.IsAllAccum = false
+			accLt := tblLt
+			accLt.IsAccum = true
+			accLt.IsAllAccum = false
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name, " accumulators")
+			tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name, " accumulators")
 
-			err = toCellCsvFile(dbConn, modelDef, tblLt, cvtAcc, fileCreated, tableCsvDir, "", "")
-			if err != nil {
-				return err
+			if !tw.do(func() error {
+				return toCellCsvFile(dbConn, modelDef, accLt, cvtAcc, fileCreated, tableCsvDir, "", "")
+			}) {
+				break
 			}
 
 			// write all accumulators view into csv file
-			tblLt.IsAccum = true
-			tblLt.IsAllAccum = true
+			allLt := tblLt
+			allLt.IsAccum = true
+			allLt.IsAllAccum = true
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name, " all accumulators")
+			tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name, " all accumulators")
 
-			err = toCellCsvFile(dbConn, modelDef, tblLt, cvtAll, fileCreated, tableCsvDir, "", "")
-			if err != nil {
-				return err
+			if !tw.do(func() error {
+				return toCellCsvFile(dbConn, modelDef, allLt, cvtAll, fileCreated, tableCsvDir, "", "")
+			}) {
+				break
 			}
 		}
 	}
+	if err := tw.wait(); err != nil {
+		return err
+	}
 
 	// write microdata into csv file, if there is any microdata for that model run and microadata write enabled
 	if !theCfg.isNoMicrodata && nMd > 0 {
@@ -344,13 +355,17 @@ func toRunText(
 				GenDigest: meta.EntityGen[j].GenDigest,
 			}
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nMd, ": ", microLt.Name)
+			tw.logIfTime("    ", j, " of ", nMd, ": ", microLt.Name)
 
-			err = toCellCsvFile(dbConn, modelDef, microLt, cvtMicro, fileCreated, microCsvDir, "", "")
-			if err != nil {
-				return err
+			if !tw.do(func() error {
+				return toCellCsvFile(dbConn, modelDef, microLt, cvtMicro, fileCreated, microCsvDir, "", "")
+			}) {
+				break
 			}
 		}
+		if err := tw.wait(); err != nil {
+			return err
+		}
 	}
 
 	// save model run metadata into json
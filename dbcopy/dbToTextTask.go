@@ -41,11 +41,11 @@ func dbToTextTask(modelName string, modelDigest string, runOpts *config.RunOptio
 	// open source database connection and check is it valid
 	cs, dn := db.IfEmptyMakeDefaultReadOnly(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
 
-	srcDb, _, err := db.Open(cs, dn, false)
+	srcDb, closeSrc, err := openSrcDb(cs, dn)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
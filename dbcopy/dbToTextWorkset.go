@@ -42,11 +42,11 @@ func dbToTextWorkset(modelName string, modelDigest string, runOpts *config.RunOp
 	// open source database connection and check is it valid
 	cs, dn := db.IfEmptyMakeDefaultReadOnly(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
 
-	srcDb, _, err := db.Open(cs, dn, false)
+	srcDb, closeSrc, err := openSrcDb(cs, dn)
 	if err != nil {
 		return err
 	}
-	defer srcDb.Close()
+	defer closeSrc()
 
 	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
 		return err
@@ -4,13 +4,14 @@
 /*
 dbcopy is command line tool for import-export OpenM++ model metadata, input parameters and run results.
 
-Dbcopy support 5 possible -dbcopy.To directions:
+Dbcopy support 6 possible -dbcopy.To directions:
 
 	"text":    copy from database to .json and .csv or .tsv files (this is default)
 	"db":      copy from .json and .csv files to database
 	"db2db":   copy from one database to other
 	"csv":     copy from databse to .csv or .tsv files
 	"csv-all": copy from databse to .csv or .tsv files
+	"import-from-upstream": create new input set of downstream model parameters from upstream model runs
 
 Dbcopy also can delete entire model or model run results, set of input parameters or modeling task from database (see dbcopy.Delete below).
 Dbcopy also can rename model run results, set of input parameters or modeling task in database (see dbcopy.Rename below).
@@ -22,6 +23,18 @@ Arguments for dbcopy can be specified on command line or through .ini file:
 
 Command line arguments take precedence over ini-file options.
 
+Ini-file can contain named profiles: [profile.name] sections with full option names, selected by -profile name:
+
+	[profile.to-csv]
+	dbcopy.To                = csv
+	dbcopy.OutputDir         = archive/csv
+	dbcopy.NoAccumulatorsCsv = true
+
+	dbcopy -ini my.ini -m modelOne -profile to-csv
+	dbcopy -ini my.ini -m modelOne -OpenM.Profile to-csv
+
+Command line arguments take precedence over profile options and profile options take precedence over other ini-file options.
+
 Only model argument does not have default value and must be specified explicitly:
 
 	dbcopy -m modelOne
@@ -149,6 +162,12 @@ It is often accumulators or microdata not required and you can suppress by using
 	dbcopy -m modelOne -dbcopy.NoMicrodata=true
 	dbcopy -m modelOne -dbcopy.NoAccumulatorsCsv -dbcopy.NoMicrodata
 
+If model run contains run option Microdata.Pack.EntityName, e.g.: Microdata.Pack.Person = Sex,Region,
+then microdata written into SQLite database is stored in packed form:
+each unique combination of Sex and Region attribute values stored once in dictionary db table.
+Packed microdata is a db view with the same columns as regular microdata table and read as usual.
+Only non-float attributes can be packed. Packing applies when microdata db table created and ignored for other databases.
+
 By default parameters and output results .csv files contain codes in dimension column(s), e.g.: Sex=[Male,Female].
 If you want to create csv files with numeric id's Sex=[0,1] instead then use IdCsv=true option:
 
@@ -196,11 +215,77 @@ To rename model run results, input set of parameters or modeling task:
 	dbcopy -m modelOne -dbcopy.Rename -dbcopy.TaskName taskOne -dbcopy.ToTaskName "New Task Name"
 	dbcopy -m modelOne -dbcopy.Rename -dbcopy.TaskId 1 -dbcopy.ToTaskName "New Task Name"
 
+Copy to "import-from-upstream": create new input set of parameters (workset) by importing parameters from upstream model runs.
+Downstream model parameters are imported from upstream model parameters or output tables as it is defined in the model source code.
+Upstream model runs can be specified by run digest, run stamp or run name:
+
+	dbcopy -m downModel -s FromUpstream -dbcopy.To import-from-upstream -dbcopy.UpstreamRuns "My Upstream Run"
+	dbcopy -m downModel -s FromUpstream -dbcopy.To import-from-upstream -dbcopy.UpstreamRuns d722febf683992aa624ce9844a2e597d,2024_01_31_12_00_00_000
+
+For each downstream parameter first upstream run of upstream model is used.
+Upstream output table values are imported from the first output table expression.
+New input set must not exist in database, it is created as read-write and can be edited before the model run.
+If upstream runs are in different database then use -dbcopy.FromSqlite or -dbcopy.Database for upstream runs database
+and -dbcopy.ToSqlite or -dbcopy.ToDatabase for downstream model database:
+
+	dbcopy -m downModel -s FromUpstream -dbcopy.To import-from-upstream -dbcopy.UpstreamRuns "My Upstream Run" -dbcopy.FromSqlite upModel.sqlite -dbcopy.ToSqlite downModel.sqlite
+
+By default, if workset already exist in destination database then workset is replaced by imported workset
+and if model run already exist (same run digest) then import of model run is skipped.
+Use -dbcopy.IfExists to specify different import conflict policy for worksets and model runs on copy to "db" or "db2db":
+
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.IfExists skip
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.IfExists replace
+	dbcopy -m modelOne -dbcopy.To db -s Default -dbcopy.IfExists merge
+	dbcopy -m modelOne -dbcopy.To db2db -dbcopy.ToSqlite dst.sqlite -dbcopy.IfExists rename
+
+	skip:    do not import workset or model run if it already exist
+	replace: replace existing workset by imported workset, delete existing model run and import it again
+	merge:   keep existing workset parameters and replace only parameters with different values, merge model run text
+	rename:  import workset under new unique name, e.g.: Default_1, import model run under new name if run name already exist
+
+Merge compare imported and existing workset parameter values by value digest:
+if values are the same then existing parameter values and parameter value notes are preserved.
+Model run with the same digest always have the same values, merge only update model run description and notes.
+
+Worksets are imported with read-only status from source. To import worksets as read-write and edit it after import:
+
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.SetReadWrite
+	dbcopy -m modelOne -dbcopy.To db -s Default -dbcopy.SetReadWrite -dbcopy.IfExists rename
+
+Model run parameters are not copied if the same parameter values already exist in destination database.
+If source parameter value digest is the same as value digest of parameter in other destination model run
+then parameter is linked to that base run values, number of linked parameters and rows not copied is logged.
+Source digest is taken from source database or from model run .json metadata file.
+If model run parameter .csv files were edited after export then use NoValueLink to always copy parameter values:
+
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.NoValueLink
+	dbcopy -m modelOne -dbcopy.To db2db -dbcopy.ToSqlite dst.sqlite -dbcopy.NoValueLink
+
+Tools which are joining database tables directly can use optional views to map model id and parameter or output table name
+into parameter or output table Hid and db table names: model_parameter_map and model_table_map.
+Use MapViews to create views in destination database, views are refreshed on each model insert or delete:
+
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.MapViews
+	dbcopy -m modelOne -dbcopy.To db2db -dbcopy.ToSqlite dst.sqlite -dbcopy.MapViews
+
 By default float and double values converted into csv text with "%.15g" format.
 It is possible to specify other format for float values values:
 
 	dbcopy -m modelOne -dbcopy.DoubleFormat "%.7G"
 
+Special float values NaN, +Inf and -Inf are written into csv as: NaN, +Inf, -Inf.
+Some databases, e.g. MSSQL or MySQL, do not support such values and insert fails.
+Use -dbcopy.FloatSpecial to write special float values into database as NULL, as sentinel value or to report an error:
+
+	dbcopy -m modelOne -dbcopy.To db2db -dbcopy.ToDatabase "..." -dbcopy.FloatSpecial null
+	dbcopy -m modelOne -dbcopy.To db2db -dbcopy.ToDatabase "..." -dbcopy.FloatSpecial sentinel:-9999
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.FloatSpecial error
+
+If it is a sentinel then NaN written as sentinel value, +Inf as max float and -Inf as min float
+and on read from database such values converted back to NaN, +Inf, -Inf.
+Default sentinel is -1.0e308, default policy is keep: write special float values as is.
+
 You can suppress zero values and / or NULL (missing) values in output tables or microdata CSV files:
 
 	dbcopy -m modelOne -dbcopy.To csv -dbcopy.NoZeroCsv
@@ -219,6 +304,12 @@ If you want to write utf-8 BOM into output csv file then:
 	dbcopy -m modelOne -dbcopy.Utf8BomIntoCsv
 	dbcopy -m modelOne -dbcopy.Utf8BomIntoCsv -dbcopy.To csv
 
+On import from csv files dbcopy skip leading comment lines before csv header, e.g. run metadata comments written by dbget -dbget.HeaderComments.
+By default comment line starts with # character, use other character or empty value to disable comments:
+
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.CommentChar ";"
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.CommentChar ""
+
 By default dbcopy using SQLite database connection:
 
 	dbcopy -m modelOne
@@ -245,6 +336,28 @@ Other supported database drivers are "sqlite3" and "odbc":
 
 ODBC dbcopy tested with MySQL (MariaDB), PostgreSQL, Microsoft SQL, Oracle and DB2.
 
+By default dbcopy read and write parameters, output tables and microdata one by one.
+To speed up copy of large model runs it is possible to process multiple tables in parallel:
+
+	dbcopy -m modelOne -dbcopy.Threads 4
+	dbcopy -m modelOne -dbcopy.To csv -dbcopy.Threads 4
+	dbcopy -m modelOne -dbcopy.To db -dbcopy.Threads 4
+
+Threads are used to write model run values into .csv files (copy to "text", "csv", "csv-all")
+and to read model run values from .csv files (copy to "db").
+Each thread is using separate database connection and streams rows of one table, memory usage is limited by number of threads.
+Model run is still copied as one unit: if any table failed then entire model run is deleted from output database.
+
+If source SQLite database is updated by model run at the same time then long copy may see inconsistent data.
+To avoid it use -dbcopy.Snapshot option to make temporary consistent copy of source database and read from that copy:
+
+	dbcopy -m modelOne -dbcopy.Snapshot
+	dbcopy -m modelOne -dbcopy.To csv -dbcopy.Snapshot
+	dbcopy -m modelOne -dbcopy.To db2db -dbcopy.ToSqlite dst.sqlite -dbcopy.Snapshot
+
+Snapshot is created by SQLite online backup API in OS temporary directory and deleted at the end of copy.
+It can be used only for SQLite source database and only to copy from database (copy to "text", "csv", "csv-all", "db2db").
+
 If dbcopy used for massive database copy it may be convinient to control it from shell script by procerss ID:
 
 	dbcopy -dbcopy.PidSaveTo some/dir/dbcopy.pid.txt
@@ -317,9 +430,18 @@ const (
 	noZeroArgKey        = "dbcopy.NoZeroCsv"         // if true then do not write zero values into output tables or microdata csv
 	noNullArgKey        = "dbcopy.NoNullCsv"         // if true then do not write NULL values into output tables or microdata csv
 	doubleFormatArgKey  = "dbcopy.DoubleFormat"      // convert to string format for float and double
+	floatSpecialArgKey  = "dbcopy.FloatSpecial"      // special float values policy: keep, null, error, sentinel or sentinel:value
 	encodingArgKey      = "dbcopy.CodePage"          // code page for converting source files, e.g. windows-1252
 	useUtf8CsvArgKey    = "dbcopy.Utf8BomIntoCsv"    // if true then write utf-8 BOM into csv file
+	commentCharArgKey   = "dbcopy.CommentChar"       // comment character: skip leading comment lines of input csv files, default: #
 	pidFileArgKey       = "dbcopy.PidSaveTo"         // file path to save dbcopy processs ID
+	threadsArgKey       = "dbcopy.Threads"           // number of parallel threads to read or write model run tables
+	upstreamRunsArgKey  = "dbcopy.UpstreamRuns"      // list of upstream model run digests, stamps or names to import parameters from
+	snapshotArgKey      = "dbcopy.Snapshot"          // if true then read from temporary consistent snapshot copy of source SQLite database
+	ifExistsArgKey      = "dbcopy.IfExists"          // import conflict policy if workset or model run already exist: skip, replace, merge, rename
+	setReadWriteArgKey  = "dbcopy.SetReadWrite"      // if true then import worksets as read-write
+	noValueLinkArgKey   = "dbcopy.NoValueLink"       // if true then always copy run parameters, do not link to existing values by digest
+	mapViewsArgKey      = "dbcopy.MapViews"          // if true then create model_parameter_map and model_table_map views in output database
 )
 
 // useIdNames is type to define how to make run and set directory and file names
@@ -344,9 +466,18 @@ var theCfg = struct {
 	doubleFmt       string // format to convert float or double value to string
 	encodingName    string // code page for converting source files, e.g. windows-1252
 	isWriteUtf8Bom  bool   // if true then write utf-8 BOM into csv file
+	commentChar     rune   // if not zero then skip leading comment lines of input csv files
+	threadCount     int    // number of parallel threads to read or write model run tables
+	isSnapshot      bool   // if true then read from temporary consistent snapshot copy of source SQLite database
+	ifExists        string // import conflict policy if workset or model run already exist: skip, replace, merge, rename
+	isSetReadWrite  bool   // if true then import worksets as read-write
+	isNoValueLink   bool   // if true then always copy run parameters, do not link to existing values by digest
+	isMapViews      bool   // if true then create model_parameter_map and model_table_map views in output database
 }{
 	doubleFmt:    "%.15g", // default format to convert float or double values to string
 	encodingName: "",      // by default detect utf-8 encoding or use OS-specific default: windows-1252 on Windowds and utf-8 outside
+	commentChar:  '#',     // by default skip leading # comment lines of input csv files
+	threadCount:  1,       // by default read or write tables one by one
 }
 
 func main() {
@@ -403,9 +534,18 @@ func mainBody(args []string) error {
 	_ = flag.Bool(noZeroArgKey, theCfg.isNoZeroCsv, "if true then do not write zero values into output tables .csv files")
 	_ = flag.Bool(noNullArgKey, theCfg.isNoNullCsv, "if true then do not write NULL values into output tables .csv files")
 	_ = flag.String(doubleFormatArgKey, theCfg.doubleFmt, "convert to string format for float and double")
+	_ = flag.String(floatSpecialArgKey, "", "special float values NaN, +Inf, -Inf policy: keep, null, error, sentinel or sentinel:value")
 	_ = flag.String(encodingArgKey, theCfg.encodingName, "code page to convert source file into utf-8, e.g.: windows-1252")
 	_ = flag.Bool(useUtf8CsvArgKey, theCfg.isWriteUtf8Bom, "if true then write utf-8 BOM into csv file")
+	_ = flag.String(commentCharArgKey, string(theCfg.commentChar), "comment character to skip leading comment lines of input csv files, if empty then no comments")
 	_ = flag.String(pidFileArgKey, "", "file path to save dbcopy process ID")
+	_ = flag.Int(threadsArgKey, theCfg.threadCount, "number of parallel threads to read or write model run tables")
+	_ = flag.String(upstreamRunsArgKey, "", "list of upstream model run digests, stamps or names to import parameters from")
+	_ = flag.Bool(snapshotArgKey, false, "if true then read from temporary consistent snapshot copy of source SQLite database")
+	_ = flag.String(ifExistsArgKey, "", "if workset or model run already exist: skip, replace, merge or rename, default: replace workset and skip model run")
+	_ = flag.Bool(setReadWriteArgKey, false, "if true then import worksets as read-write")
+	_ = flag.Bool(noValueLinkArgKey, theCfg.isNoValueLink, "if true then always copy run parameters, do not link to existing values by digest")
+	_ = flag.Bool(mapViewsArgKey, theCfg.isMapViews, "if true then create model_parameter_map and model_table_map views in output database")
 
 	// pairs of full and short argument names to map short name to full name
 	var optFs = []config.FullShort{
@@ -452,6 +592,27 @@ func mainBody(args []string) error {
 	theCfg.doubleFmt = runOpts.String(doubleFormatArgKey)
 	theCfg.encodingName = runOpts.String(encodingArgKey)
 	theCfg.isWriteUtf8Bom = runOpts.Bool(useUtf8CsvArgKey)
+	theCfg.threadCount = runOpts.Int(threadsArgKey, theCfg.threadCount)
+	theCfg.isSnapshot = runOpts.Bool(snapshotArgKey)
+	theCfg.ifExists = strings.ToLower(runOpts.String(ifExistsArgKey))
+	theCfg.isSetReadWrite = runOpts.Bool(setReadWriteArgKey)
+	theCfg.isNoValueLink = runOpts.Bool(noValueLinkArgKey)
+	theCfg.isMapViews = runOpts.Bool(mapViewsArgKey)
+
+	fs, err := db.ParseFloatSpecial(runOpts.String(floatSpecialArgKey))
+	if err != nil {
+		return errors.New("dbcopy invalid arguments: " + floatSpecialArgKey + ": " + err.Error())
+	}
+	db.SetFloatSpecial(fs)
+
+	switch cc := []rune(runOpts.String(commentCharArgKey)); {
+	case len(cc) == 0:
+		theCfg.commentChar = 0
+	case len(cc) == 1 && cc[0] != '"' && cc[0] != '\r' && cc[0] != '\n':
+		theCfg.commentChar = cc[0]
+	default:
+		return errors.New("dbcopy invalid arguments: " + commentCharArgKey + " " + string(cc) + ", expected single character or empty value")
+	}
 
 	// minimal validation of run options
 	//
@@ -462,10 +623,31 @@ func mainBody(args []string) error {
 	if (isDel || isRename) && runOpts.IsExist(copyToArgKey) {
 		return errors.New("dbcopy invalid arguments: " + deleteArgKey + " or " + renameArgKey + " cannot be used with " + copyToArgKey)
 	}
-	// to-database can be used only with "db" or "db2db"
-	if copyToArg != "db" && copyToArg != "db2db" &&
+	// to-database can be used only with "db" or "db2db" or "import-from-upstream"
+	if copyToArg != "db" && copyToArg != "db2db" && copyToArg != "import-from-upstream" &&
 		(runOpts.IsExist(toDbConnStrArgKey) || runOpts.IsExist(toDbDriverArgKey) || runOpts.IsExist(toSqliteArgKey)) {
-		return errors.New("dbcopy invalid arguments: output database can be specified only if " + copyToArgKey + "=db or =db2db")
+		return errors.New("dbcopy invalid arguments: output database can be specified only if " + copyToArgKey + "=db or =db2db or =import-from-upstream")
+	}
+	// upstream runs can be used only to import from upstream
+	if copyToArg != "import-from-upstream" && runOpts.IsExist(upstreamRunsArgKey) {
+		return errors.New("dbcopy invalid arguments: " + upstreamRunsArgKey + " can be used only if " + copyToArgKey + "=import-from-upstream")
+	}
+	// snapshot of source database can be used only to copy from database
+	if theCfg.isSnapshot &&
+		(isDel || isRename || copyToArg != "text" && copyToArg != "csv" && copyToArg != "csv-all" && copyToArg != "db2db") {
+		return errors.New("dbcopy invalid arguments: " + snapshotArgKey + " can be used only if " + copyToArgKey + "=text or =csv or =csv-all or =db2db")
+	}
+	// import conflict policy can be used only to copy into database
+	if theCfg.ifExists != ifExistsDefault &&
+		theCfg.ifExists != ifExistsSkip && theCfg.ifExists != ifExistsReplace && theCfg.ifExists != ifExistsMerge && theCfg.ifExists != ifExistsRename {
+		return errors.New("dbcopy invalid arguments: " + ifExistsArgKey + " must be one of: skip, replace, merge, rename")
+	}
+	if (theCfg.ifExists != ifExistsDefault || theCfg.isSetReadWrite) && (isDel || isRename || copyToArg != "db" && copyToArg != "db2db") {
+		return errors.New("dbcopy invalid arguments: " + ifExistsArgKey + " and " + setReadWriteArgKey + " can be used only if " + copyToArgKey + "=db or =db2db")
+	}
+	// number of threads must be positive
+	if theCfg.threadCount < 1 {
+		return errors.New("dbcopy invalid arguments: " + threadsArgKey + " must be a positive number of threads")
 	}
 	// id csv is only for output
 	if copyToArg != "text" && copyToArg != "csv" && copyToArg != "csv-all" && runOpts.IsExist(useIdCsvArgKey) {
@@ -539,6 +721,10 @@ func mainBody(args []string) error {
 			return errors.New("dbcopy invalid argument(s) for rename operation")
 		}
 
+	// create new workset from upstream model runs
+	case copyToArg == "import-from-upstream":
+		err = dbImportFromUpstream(modelName, modelDigest, runOpts)
+
 	// copy model run
 	case !isDel && !isRename &&
 		(runOpts.IsExist(runNameArgKey) || runOpts.IsExist(runIdArgKey) || runOpts.IsExist(runDigestArgKey) || runOpts.IsExist(runFirstArgKey) || runOpts.IsExist(runLastArgKey)):
@@ -0,0 +1,199 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// create new workset of downstream model and import parameters from upstream model runs.
+// Upstream runs are in source database and downstream model is in destination database, it can be the same database.
+// Parameters are imported as it is defined by model_parameter_import rows of downstream model.
+func dbImportFromUpstream(modelName string, modelDigest string, runOpts *config.RunOptions) error {
+
+	// new workset name and list of upstream runs are required
+	setName := runOpts.String(setNameArgKey)
+	if setName == "" {
+		return errors.New("dbcopy invalid (empty) workset name, use " + setNameArgKey + " to specify new workset name")
+	}
+	rdsnLst := helper.ParseCsvLine(runOpts.String(upstreamRunsArgKey), ',')
+	if len(rdsnLst) <= 0 {
+		return errors.New("dbcopy invalid (empty) list of upstream model runs, use " + upstreamRunsArgKey + " to specify run digests, stamps or names")
+	}
+
+	// open source database with upstream runs and destination database with downstream model
+	// if output database not specified then upstream and downstream models are in the same database
+	isSameDb := !runOpts.IsExist(toSqliteArgKey) && !runOpts.IsExist(toDbConnStrArgKey)
+
+	csInp, dnInp := db.IfEmptyMakeDefaultReadOnly(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
+	csOut, dnOut := db.IfEmptyMakeDefault(modelName, runOpts.String(toSqliteArgKey), runOpts.String(toDbConnStrArgKey), runOpts.String(toDbDriverArgKey))
+	if isSameDb {
+		csOut, dnOut = db.IfEmptyMakeDefault(modelName, runOpts.String(fromSqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
+	}
+
+	dstDb, _, err := db.Open(csOut, dnOut, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close(dstDb)
+
+	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
+		return err
+	}
+
+	srcDb := dstDb
+	if !isSameDb {
+
+		srcDb, _, err = db.Open(csInp, dnInp, false)
+		if err != nil {
+			return err
+		}
+		defer db.Close(srcDb)
+
+		if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
+			return err
+		}
+	}
+
+	// destination: get downstream model metadata and list of languages
+	dstModel, err := db.GetModel(dstDb, modelName, modelDigest)
+	if err != nil {
+		return err
+	}
+	dstLang, err := db.GetLanguages(dstDb)
+	if err != nil {
+		return err
+	}
+
+	// find upstream runs
+	upLst, err := findUpstreamRuns(srcDb, dstModel, rdsnLst)
+	if err != nil {
+		return err
+	}
+
+	// resolve parameters import from upstream runs
+	imLst, err := db.ResolveParamImport(dstModel, upLst)
+	if err != nil {
+		return err
+	}
+	if len(imLst) <= 0 {
+		return errors.New("there are no parameters to import from upstream model runs into: " + dstModel.Model.Name)
+	}
+
+	// create new empty workset, it is an error if workset already exist
+	wsRow, err := db.GetWorksetByName(dstDb, dstModel.Model.ModelId, setName)
+	if err != nil {
+		return err
+	}
+	if wsRow != nil {
+		return errors.New("workset already exist: " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name)
+	}
+
+	ws := db.WorksetMeta{Set: db.WorksetRow{ModelId: dstModel.Model.ModelId, Name: setName}}
+
+	if err = ws.UpdateWorkset(dstDb, dstModel, true, dstLang); err != nil {
+		return err
+	}
+	omppLog.Log("Workset ", ws.Set.Name, " id ", ws.Set.SetId)
+	omppLog.Log("  Parameters: ", len(imLst))
+
+	// import parameters, on error delete new workset
+	for k := range imLst {
+
+		im := &imLst[k]
+		omppLog.Log("    ", im.ParamName, " <- ", im.FromModel, ".", im.FromName, " run: ", im.Upstream.Run.RunId, " ", im.Upstream.Run.Name)
+
+		if err = ws.ImportParameterFromUpstream(dstDb, dstModel, dstLang, im); err != nil {
+
+			if e := db.DeleteWorkset(dstDb, ws.Set.SetId); e != nil {
+				omppLog.Log("Error at delete workset: ", ws.Set.SetId, " ", ws.Set.Name, ": ", e.Error())
+			}
+			return errors.New("failed to import parameter: " + im.ParamName + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// return list of upstream runs by run digest, stamp or name.
+// Upstream models are models from downstream model_parameter_import, run is searched by digest, stamp or name in each upstream model.
+func findUpstreamRuns(srcDb *sql.DB, dstModel *db.ModelMeta, rdsnLst []string) ([]db.UpstreamRun, error) {
+
+	// collect upstream model names
+	nameLst := []string{}
+	for j := range dstModel.Param {
+		for _, pi := range dstModel.Param[j].Import {
+
+			isFound := false
+			for k := 0; !isFound && k < len(nameLst); k++ {
+				isFound = nameLst[k] == pi.FromModel
+			}
+			if !isFound {
+				nameLst = append(nameLst, pi.FromModel)
+			}
+		}
+	}
+	if len(nameLst) <= 0 {
+		return nil, errors.New("model does not import any parameters from upstream models: " + dstModel.Model.Name)
+	}
+
+	// get upstream models metadata
+	mdLst, err := db.GetModelList(srcDb)
+	if err != nil {
+		return nil, err
+	}
+	upModels := []*db.ModelMeta{}
+
+	for k := range mdLst {
+		for _, mn := range nameLst {
+			if mdLst[k].Name == mn {
+
+				m, err := db.GetModelById(srcDb, mdLst[k].ModelId)
+				if err != nil {
+					return nil, err
+				}
+				upModels = append(upModels, m)
+				break
+			}
+		}
+	}
+	if len(upModels) <= 0 {
+		return nil, errors.New("upstream models not found in source database, expected any of: " + strings.Join(nameLst, ", "))
+	}
+
+	// find each upstream run in upstream models
+	upLst := []db.UpstreamRun{}
+
+	for _, rdsn := range rdsnLst {
+
+		var up *db.UpstreamRun
+		for _, m := range upModels {
+
+			r, err := db.GetRunByDigestStampName(srcDb, m.Model.ModelId, rdsn)
+			if err != nil {
+				return nil, err
+			}
+			if r != nil && r.ModelId == m.Model.ModelId {
+				up = &db.UpstreamRun{DbConn: srcDb, ModelDef: m, Run: r}
+				break
+			}
+		}
+		if up == nil {
+			return nil, errors.New("upstream model run not found: " + rdsn)
+		}
+		omppLog.Log("Upstream model run: ", up.ModelDef.Model.Name, " ", up.Run.RunId, " ", up.Run.Name)
+
+		upLst = append(upLst, *up)
+	}
+
+	return upLst, nil
+}
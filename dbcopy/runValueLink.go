@@ -0,0 +1,62 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// statistics of model run parameters linked to existing values instead of copy
+type runLinkStat struct {
+	lock       sync.Mutex
+	paramCount int   // number of parameters linked to existing values
+	rowCount   int64 // number of parameter rows not copied
+}
+
+// linkRunParam insert run parameter as a link to existing values in destination database if source value digest is known,
+// return true if parameter values linked to other model run and must not be copied.
+func linkRunParam(dstDb *sql.DB, dstModel *db.ModelMeta, layout *db.WriteParamLayout, srcName string, pub *db.RunPub, stat *runLinkStat) (bool, error) {
+
+	if theCfg.isNoValueLink || pub == nil {
+		return false, nil
+	}
+
+	// find source parameter value digest
+	dgst := ""
+	for k := range pub.Param {
+		if pub.Param[k].Name == srcName {
+			dgst = pub.Param[k].ValueDigest
+			break
+		}
+	}
+	if dgst == "" {
+		return false, nil // source value digest unknown
+	}
+
+	nBase, nRow, err := db.LinkRunParameter(dstDb, dstModel, layout, dgst)
+	if err != nil || nBase <= 0 {
+		return false, err
+	}
+
+	stat.lock.Lock()
+	stat.paramCount++
+	stat.rowCount += nRow
+	stat.lock.Unlock()
+
+	return true, nil
+}
+
+// log number of parameters linked to existing values and number of rows not copied
+func (stat *runLinkStat) log() {
+	stat.lock.Lock()
+	defer stat.lock.Unlock()
+
+	if stat.paramCount > 0 {
+		omppLog.Log("  Parameters linked to existing values: ", stat.paramCount, ", rows not copied: ", stat.rowCount)
+	}
+}
@@ -33,7 +33,7 @@ func textToDb(modelName string, runOpts *config.RunOptions) error {
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -112,6 +112,11 @@ func fromModelJsonToDb(dbConn *sql.DB, dbFacet db.Facet, inpDir string, modelNam
 	if _, err = db.UpdateModel(dbConn, dbFacet, modelDef); err != nil {
 		return nil, err
 	}
+	if theCfg.isMapViews {
+		if err = db.CreateModelMapViews(dbConn); err != nil {
+			return nil, err
+		}
+	}
 
 	// insert, update or delete model default profile
 	var modelProfile db.ProfileMeta
@@ -5,12 +5,9 @@ package main
 
 import (
 	"database/sql"
-	"encoding/csv"
 	"errors"
-	"io"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/helper"
@@ -41,7 +38,6 @@ func writeParamFromCsvFile(
 	if err != nil {
 		return errors.New("Error at building csv parameter header " + layout.Name + ": " + err.Error())
 	}
-	ch := strings.Join(chs, ",")
 
 	f, err := os.Open(filepath.Join(csvDir, fn))
 	if err != nil {
@@ -49,11 +45,17 @@ func writeParamFromCsvFile(
 	}
 	defer f.Close()
 
-	from, err := makeFromCsvReader(fn, f, ch, cvt)
+	from, err := makeFromCsvReader(fn, f, chs, cvt)
 	if err != nil {
 		return errors.New("fail to create parameter csv reader: " + err.Error())
 	}
 
+	// validate parameter values against type constraints and collect all invalid rows
+	from, err = db.ValidateParamFrom(modelDef, layout.Name, from)
+	if err != nil {
+		return err
+	}
+
 	// write each csv row into parameter table
 	err = db.WriteParameterFrom(dbConn, modelDef, &layout, from)
 	if err != nil {
@@ -87,7 +89,6 @@ func writeTableFromCsvFiles(
 	if err != nil {
 		return errors.New("Error at building csv accumulators header " + layout.Name + ": " + err.Error())
 	}
-	ah := strings.Join(ahs, ",")
 
 	accFile, err := os.Open(filepath.Join(csvDir, aFn))
 	if err != nil {
@@ -95,7 +96,7 @@ func writeTableFromCsvFiles(
 	}
 	defer accFile.Close()
 
-	accFrom, err := makeFromCsvReader(aFn, accFile, ah, aToCell)
+	accFrom, err := makeFromCsvReader(aFn, accFile, ahs, aToCell)
 	if err != nil {
 		return errors.New("fail to create accumulators csv reader: " + err.Error())
 	}
@@ -115,7 +116,6 @@ func writeTableFromCsvFiles(
 	if err != nil {
 		return errors.New("Error at building csv expressions header " + layout.Name + ": " + err.Error())
 	}
-	eh := strings.Join(ehs, ",")
 
 	exprFile, err := os.Open(filepath.Join(csvDir, eFn))
 	if err != nil {
@@ -123,7 +123,7 @@ func writeTableFromCsvFiles(
 	}
 	defer exprFile.Close()
 
-	exprFrom, err := makeFromCsvReader(eFn, exprFile, eh, eToCell)
+	exprFrom, err := makeFromCsvReader(eFn, exprFile, ehs, eToCell)
 	if err != nil {
 		return errors.New("fail to create expressions csv reader: " + err.Error())
 	}
@@ -165,7 +165,6 @@ func writeMicroFromCsvFile(
 	if err != nil {
 		return errors.New("Error at building csv microdata header " + layout.Name + ": " + err.Error())
 	}
-	ch := strings.Join(chs, ",")
 
 	f, err := os.Open(filepath.Join(csvDir, fn))
 	if err != nil {
@@ -173,7 +172,7 @@ func writeMicroFromCsvFile(
 	}
 	defer f.Close()
 
-	from, err := makeFromCsvReader(fn, f, ch, cvt)
+	from, err := makeFromCsvReader(fn, f, chs, cvt)
 	if err != nil {
 		return errors.New("fail to create microdata csv reader: " + err.Error())
 	}
@@ -189,7 +188,7 @@ func writeMicroFromCsvFile(
 
 // return closure to iterate over csv file rows
 func makeFromCsvReader(
-	fileName string, csvFile *os.File, csvHeader string, csvToCell func(row []string) (interface{}, error),
+	fileName string, csvFile *os.File, csvHeader []string, csvToCell func(row []string) (interface{}, error),
 ) (func() (interface{}, error), error) {
 
 	// create csv reader from utf-8 line
@@ -198,43 +197,7 @@ func makeFromCsvReader(
 		return nil, errors.New("fail to create utf-8 converter: " + err.Error())
 	}
 
-	csvRd := csv.NewReader(uRd)
-	csvRd.TrimLeadingSpace = true
-	csvRd.ReuseRecord = true
-
-	// skip header line
-	fhs, e := csvRd.Read()
-	switch {
-	case e == io.EOF:
-		return nil, errors.New("invalid (empty) csv file: " + fileName)
-	case err != nil:
-		return nil, errors.New("csv file read error: " + fileName + ": " + err.Error())
-	}
-	fh := strings.Join(fhs, ",")
-	if strings.HasPrefix(fh, string(helper.Utf8bom)) {
-		fh = fh[len(helper.Utf8bom):]
-	}
-	if fh != csvHeader {
-		return nil, errors.New("Invalid csv file header " + fileName + ": " + fh + " expected: " + csvHeader)
-	}
-
-	// convert each csv line into cell (id cell)
+	// validate header line and convert each csv line into cell (id cell)
 	// reading from .id.csv files not supported by converters
-	from := func() (interface{}, error) {
-		row, err := csvRd.Read()
-		switch {
-		case err == io.EOF:
-			return nil, nil // eof
-		case err != nil:
-			return nil, errors.New("csv file read error: " + fileName + ": " + err.Error())
-		}
-
-		// convert csv line to cell and return from reader
-		c, err := csvToCell(row)
-		if err != nil {
-			return nil, errors.New("csv file row convert error: " + fileName + ": " + err.Error())
-		}
-		return c, nil
-	}
-	return from, nil
+	return helper.CsvFrom(helper.NewCsvReader(uRd, helper.CsvOptions{Comment: theCfg.commentChar}), fileName, csvHeader, csvToCell)
 }
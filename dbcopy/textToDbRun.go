@@ -10,7 +10,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/openmpp/go/ompp/config"
 	"github.com/openmpp/go/ompp/db"
@@ -120,7 +119,7 @@ func textToDbRun(modelName string, modelDigest string, runOpts *config.RunOption
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -255,28 +254,29 @@ func fromRunTextToDb(
 		return 0, err
 	}
 
-	// save model run
-	isExist, err = meta.UpdateRun(dbConn, modelDef, langDef, theCfg.doubleFmt)
+	// save model run, if model run already exist then skip, replace, merge or rename it
+	isNew, err := updateDstRun(dbConn, modelDef, langDef, meta)
 	if err != nil {
 		return 0, err
 	}
 	dstId := meta.Run.RunId
-	if isExist { // exit if model run already exist
-		omppLog.Log("Model run ", srcName, " already exists as ", dstId)
+	if !isNew { // exit if model run already exist
 		return dstId, nil
 	}
 
 	omppLog.Log("Model run from ", srcName, " into id: ", dstId)
 
 	// restore run parameters: all model parameters must be included in the run
+	// parameters and output tables can be written in parallel, SQLite database allow only one writer
 	nP := len(modelDef.Param)
 	omppLog.Log("  Parameters: ", nP)
-	logT := time.Now().Unix()
+	tw := newTableWorkers(dbFacet == db.SqliteFacet)
+	lnkStat := runLinkStat{}
 
 	for j := range modelDef.Param {
 
 		// read parameter values from csv file
-		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nP, ": ", modelDef.Param[j].Name)
+		tw.logIfTime("    ", j, " of ", nP, ": ", modelDef.Param[j].Name)
 
 		// insert parameter values in model run
 		paramLt := db.WriteParamLayout{
@@ -295,17 +295,24 @@ func fromRunTextToDb(
 			DoubleFmt: theCfg.doubleFmt,
 		}
 
-		err = writeParamFromCsvFile(dbConn, modelDef, paramLt, paramCsvDir, cvtParam)
-		if err != nil {
-			omppLog.Log("Error at: ", paramLt.Name, ": ", err.Error())
-			omppLog.Log("Cleanup on error: delete model run ", srcName, " ", dstId)
+		if !tw.do(func() error {
 
-			// delete model run on error to rollback results of UpdateRun() call above
-			e := db.DeleteRun(dbConn, dstId)
+			// if the same parameter values already exist in database then link to it instead of insert from csv
+			isLnk, e := linkRunParam(dbConn, modelDef, &paramLt, paramLt.Name, &pub, &lnkStat)
 			if e != nil {
-				omppLog.Log("Failed to delete model run: ", srcName, " id: ", dstId, ": ", e.Error())
+				omppLog.Log("Error at: ", paramLt.Name, ": ", e.Error())
+				return e
+			}
+			if isLnk {
+				return nil
+			}
+			if e := writeParamFromCsvFile(dbConn, modelDef, paramLt, paramCsvDir, cvtParam); e != nil {
+				omppLog.Log("Error at: ", paramLt.Name, ": ", e.Error())
+				return e
 			}
-			return 0, err // return original error
+			return nil
+		}) {
+			break
 		}
 	}
 
@@ -315,6 +322,10 @@ func fromRunTextToDb(
 
 	for j := range modelDef.Table {
 
+		if tw.firstError() != nil {
+			break // parameter write failed
+		}
+
 		// check if table exist in model run results
 		var isFound bool
 		for k := range meta.Table {
@@ -345,23 +356,31 @@ func fromRunTextToDb(
 		cvtExpr := db.CellExprConverter{CellTableConverter: ctc}
 		cvtAcc := db.CellAccConverter{CellTableConverter: ctc}
 
-		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", tblLt.Name)
+		tw.logIfTime("    ", j, " of ", nT, ": ", tblLt.Name)
 
-		err := writeTableFromCsvFiles(dbConn, modelDef, tblLt, tableCsvDir, cvtExpr, cvtAcc)
-		if err != nil {
-			if err != nil {
-				omppLog.Log("Error at: ", tblLt.Name, ": ", err.Error())
-				omppLog.Log("Cleanup on error: delete model run ", srcName, " ", dstId)
-
-				// delete model run on error to rollback results of UpdateRun() call above
-				e := db.DeleteRun(dbConn, dstId)
-				if e != nil {
-					omppLog.Log("Failed to delete model run: ", srcName, " id: ", dstId, ": ", e.Error())
-				}
-				return 0, err // return original error
+		if !tw.do(func() error {
+			if e := writeTableFromCsvFiles(dbConn, modelDef, tblLt, tableCsvDir, cvtExpr, cvtAcc); e != nil {
+				omppLog.Log("Error at: ", tblLt.Name, ": ", e.Error())
+				return e
 			}
+			return nil
+		}) {
+			break
+		}
+	}
+
+	// wait until all parameters and output tables are written, on error delete model run
+	if err = tw.wait(); err != nil {
+		omppLog.Log("Cleanup on error: delete model run ", srcName, " ", dstId)
+
+		// delete model run on error to rollback results of UpdateRun() call above
+		e := db.DeleteRun(dbConn, dstId)
+		if e != nil {
+			omppLog.Log("Failed to delete model run: ", srcName, " id: ", dstId, ": ", e.Error())
 		}
+		return 0, err // return original error
 	}
+	lnkStat.log()
 
 	// update model run digest
 	if meta.Run.ValueDigest == "" {
@@ -396,7 +415,7 @@ func fromRunTextToDb(
 				DoubleFmt: theCfg.doubleFmt,
 			}}
 
-			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nMd, ": ", microLt.Name)
+			tw.logIfTime("    ", j, " of ", nMd, ": ", microLt.Name)
 
 			err := writeMicroFromCsvFile(dbConn, dbFacet, modelDef, meta, microLt, microCsvDir, cvtMicro)
 			if err != nil {
@@ -93,7 +93,7 @@ func textToDbTask(modelName string, modelDigest string, runOpts *config.RunOptio
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
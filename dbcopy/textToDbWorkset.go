@@ -154,7 +154,7 @@ func textToDbWorkset(modelName string, modelDigest string, runOpts *config.RunOp
 	if err != nil {
 		return err
 	}
-	defer dstDb.Close()
+	defer db.Close(dstDb)
 
 	if err := db.CheckOpenmppSchemaVersion(dstDb); err != nil {
 		return err
@@ -305,7 +305,7 @@ func fromWorksetTextToDb(
 
 	// save workset metadata as "read-write" and after importing all parameters set it as "readonly"
 	// save workset metadata parameters list, make it empty and use add parameters to update metadata and values from csv
-	isReadonly := pub.IsReadonly
+	isReadonly := pub.IsReadonly && !theCfg.isSetReadWrite
 	pub.IsReadonly = false
 	paramLst := append([]db.ParamRunSetPub{}, pub.Param...)
 	pub.Param = []db.ParamRunSetPub{}
@@ -325,24 +325,17 @@ func fromWorksetTextToDb(
 		omppLog.Log("Warning: workset ", ws.Set.Name, ", base run not found by digest ", pub.BaseRunDigest)
 	}
 
-	// if destination workset exists then make it read-write and delete all existing parameters from workset
-	wsRow, err := db.GetWorksetByName(dbConn, modelDef.Model.ModelId, ws.Set.Name)
+	// if destination workset exists then skip, rename or make it read-write and delete or merge existing parameters
+	isSkip, isMerge, err := prepareDstWorkset(dbConn, modelDef, ws)
 	if err != nil {
 		return 0, err
 	}
-	if wsRow != nil {
-		err = db.UpdateWorksetReadonly(dbConn, wsRow.SetId, false) // make destination workset read-write
-		if err != nil {
-			return 0, errors.New("failed to clear workset read-only status: " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name + " " + err.Error())
-		}
-		err = db.DeleteWorksetAllParameters(dbConn, wsRow.SetId) // delete all parameters from workset
-		if err != nil {
-			return 0, errors.New("failed to delete workset " + strconv.Itoa(wsRow.SetId) + " " + wsRow.Name + " " + err.Error())
-		}
+	if isSkip {
+		return ws.Set.SetId, nil
 	}
 
 	// create empty workset metadata or update existing workset metadata
-	err = ws.UpdateWorkset(dbConn, modelDef, true, langDef)
+	err = ws.UpdateWorkset(dbConn, modelDef, !isMerge, langDef)
 	if err != nil {
 		return 0, err
 	}
@@ -353,6 +346,7 @@ func fromWorksetTextToDb(
 	nP := len(paramLst)
 	omppLog.Log("  Parameters: ", nP)
 	logT := time.Now().Unix()
+	nSame := 0
 
 	// read all workset parameters from csv files
 	for j := range paramLst {
@@ -367,10 +361,16 @@ func fromWorksetTextToDb(
 			DoubleFmt: theCfg.doubleFmt,
 		}
 
-		err = updateWorksetParamFromCsvFile(dbConn, modelDef, ws, &paramLst[j], csvDir, langDef, cvtParam)
+		isUpd, err := updateWorksetParamFromCsvFile(dbConn, modelDef, ws, &paramLst[j], csvDir, langDef, cvtParam, isMerge)
 		if err != nil {
 			return 0, err
 		}
+		if !isUpd {
+			nSame++
+		}
+	}
+	if isMerge {
+		omppLog.Log("  Parameters unchanged: ", nSame)
 	}
 
 	// update workset readonly status with actual value
@@ -382,7 +382,9 @@ func fromWorksetTextToDb(
 	return dstId, nil
 }
 
-// updateWorksetParamFromCsvFile read parameter csv file values insert it into db parameter value table and update workset parameter metadata
+// updateWorksetParamFromCsvFile read parameter csv file values insert it into db parameter value table and update workset parameter metadata.
+// If this is a merge then parameter updated only if csv values are different from existing workset parameter values.
+// It return true if parameter inserted or updated and false if merge found existing parameter values unchanged.
 func updateWorksetParamFromCsvFile(
 	dbConn *sql.DB,
 	modelDef *db.ModelMeta,
@@ -391,42 +393,52 @@ func updateWorksetParamFromCsvFile(
 	csvDir string,
 	langDef *db.LangMeta,
 	csvCvt db.CellParamConverter,
-) error {
+	isMerge bool,
+) (bool, error) {
 
 	// converter from csv row []string to db cell
 	cvt, err := csvCvt.ToCell()
 	if err != nil {
-		return errors.New("invalid converter from csv row: " + err.Error())
+		return false, errors.New("invalid converter from csv row: " + err.Error())
 	}
 
 	// open csv file, convert to utf-8 and parse csv into db cells
 	// reading from .id.csv files not supported by converters
 	fn, err := csvCvt.CsvFileName()
 	if err != nil {
-		return errors.New("invalid csv file name: " + err.Error())
+		return false, errors.New("invalid csv file name: " + err.Error())
 	}
 	chs, err := csvCvt.CsvHeader()
 	if err != nil {
-		return errors.New("Error at building csv parameter header " + paramPub.Name + ": " + err.Error())
+		return false, errors.New("Error at building csv parameter header " + paramPub.Name + ": " + err.Error())
 	}
-	ch := strings.Join(chs, ",")
 
 	f, err := os.Open(filepath.Join(csvDir, fn))
 	if err != nil {
-		return errors.New("csv file open error: " + fn + ": " + err.Error())
+		return false, errors.New("csv file open error: " + fn + ": " + err.Error())
 	}
 	defer f.Close()
 
-	from, err := makeFromCsvReader(fn, f, ch, cvt)
+	from, err := makeFromCsvReader(fn, f, chs, cvt)
 	if err != nil {
-		return errors.New("fail to create expressions csv reader: " + err.Error())
+		return false, errors.New("fail to create expressions csv reader: " + err.Error())
+	}
+
+	// validate parameter values against type constraints and collect all invalid rows
+	from, err = db.ValidateParamFrom(modelDef, paramPub.Name, from)
+	if err != nil {
+		return false, err
 	}
 
 	// write each csv row into parameter or output table
+	if isMerge {
+		_, isUpd, err := wsMeta.MergeWorksetParameterFrom(dbConn, modelDef, paramPub, langDef, from)
+		return isUpd, err
+	}
 	_, err = wsMeta.UpdateWorksetParameterFrom(dbConn, modelDef, true, paramPub, langDef, from)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// tableWorkers is a bounded pool of workers to read or write parameters, output tables and microdata of model run.
+//
+// Number of workers is limited by dbcopy.Threads option, each worker is using its own connection from database pool.
+// SQLite database allow only one writer at a time and for SQLite output database tables are written one by one.
+// Rows are streamed by each worker, memory usage is bounded by number of tables processed at the same time.
+// If number of threads is 1 (default) then each job is done immediately in the caller goroutine.
+// After first error no new jobs started and wait() return that first error.
+type tableWorkers struct {
+	wg   sync.WaitGroup
+	sem  chan struct{} // semaphore to limit number of running jobs
+	lock sync.Mutex    // lock to update first error and last log time
+	err  error         // first error, if any
+	logT int64         // last progress log time
+}
+
+// guard access to csv created files map from table workers
+var fileCreatedLock sync.Mutex
+
+// create new pool of table workers, number of workers is dbcopy.Threads option value or 1 if isSingle is true
+func newTableWorkers(isSingle bool) *tableWorkers {
+
+	n := theCfg.threadCount
+	if isSingle {
+		n = 1
+	}
+	return &tableWorkers{
+		sem:  make(chan struct{}, max(1, n)),
+		logT: time.Now().Unix(),
+	}
+}
+
+// do start new job in separate goroutine, it returns false if any previous job failed.
+// If number of threads is 1 then job is done in the caller goroutine.
+func (tw *tableWorkers) do(job func() error) bool {
+
+	if tw.firstError() != nil {
+		return false // skip job: previous job failed
+	}
+
+	if cap(tw.sem) <= 1 {
+		tw.setError(job())
+		return tw.firstError() == nil
+	}
+
+	tw.sem <- struct{}{}
+	tw.wg.Add(1)
+
+	go func() {
+		defer func() {
+			<-tw.sem
+			tw.wg.Done()
+		}()
+		if tw.firstError() == nil {
+			tw.setError(job())
+		}
+	}()
+	return true
+}
+
+// wait until all jobs completed and return first error, if any
+func (tw *tableWorkers) wait() error {
+	tw.wg.Wait()
+	return tw.firstError()
+}
+
+// log progress message if log period expired since last message
+func (tw *tableWorkers) logIfTime(msg ...interface{}) {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	tw.logT = omppLog.LogIfTime(tw.logT, logPeriod, msg...)
+}
+
+// return first error, if any
+func (tw *tableWorkers) firstError() error {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	return tw.err
+}
+
+// store error if it is a first error
+func (tw *tableWorkers) setError(err error) {
+	if err == nil {
+		return
+	}
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	if tw.err == nil {
+		tw.err = err
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// batch step: dbget action and run options of that action
+type batchStep struct {
+	name   string             // step name: action name or ini-file profile name
+	action string             // dbget action, e.g.: all-runs
+	opts   *config.RunOptions // run options of the step: common run options and step profile options
+}
+
+// return list of batch steps or empty list if it is not a batch.
+// Batch steps are comma separated list of -dbget.Do actions or ini-file [batch] Do option.
+// Each step is an action name or ini-file profile name: [profile.name] options and profile dbget.Do action.
+func batchSteps(runOpts *config.RunOptions) ([]batchStep, error) {
+
+	src := runOpts.String(cmdArgKey)
+	isBatch := runOpts.IsExist(batchArgKey)
+
+	if isBatch {
+		if runOpts.IsExist(cmdArgKey) {
+			return nil, errors.New("invalid arguments: " + batchArgKey + " cannot be combined with " + cmdArgKey)
+		}
+		src = runOpts.String(batchArgKey)
+	}
+
+	nameLst := []string{}
+	for _, s := range helper.ParseCsvLine(src, ',') {
+		if s = strings.TrimSpace(s); s != "" {
+			nameLst = append(nameLst, s)
+		}
+	}
+	if !isBatch && len(nameLst) <= 1 {
+		return []batchStep{}, nil // it is not a batch: single action
+	}
+	if len(nameLst) <= 0 {
+		return nil, errors.New("invalid (empty) list of batch actions: " + batchArgKey)
+	}
+
+	steps := make([]batchStep, len(nameLst))
+
+	for k, name := range nameLst {
+
+		// step run options: common options and step profile options, if profile exists
+		kv := make(map[string]string, len(runOpts.KeyValue))
+		for key, val := range runOpts.KeyValue {
+			kv[key] = val
+		}
+		delete(kv, batchArgKey)
+
+		act := name
+		if pkv, ok := runOpts.ProfileKeyValue(name); ok {
+			for key, val := range pkv {
+				kv[key] = val
+			}
+			if a, ok := pkv[cmdArgKey]; ok {
+				act = a
+			}
+		}
+		if act == "" || strings.Contains(act, ",") {
+			return nil, errors.New("invalid batch action: " + name + ": " + act)
+		}
+		if act == "import-words" || isLangAction(act) {
+			return nil, errors.New("invalid batch action: " + name + ": " + act + " cannot be combined with other actions")
+		}
+		kv[cmdArgKey] = act
+
+		steps[k] = batchStep{
+			name:   name,
+			action: act,
+			opts:   &config.RunOptions{KeyValue: kv, DefaultKeyValue: runOpts.DefaultKeyValue},
+		}
+	}
+	return steps, nil
+}
+
+// do batch steps sequentially using the same database connection and model.
+// If step failed and -dbget.KeepGoing specified then continue with next step, else stop.
+// At the end log status of each batch step.
+func runBatch(srcDb *sql.DB, modelId int, sqlitePath string, steps []batchStep) error {
+
+	nStep := len(steps)
+	status := make([]string, nStep)
+	for k := range status {
+		status[k] = "Skipped"
+	}
+
+	// log status of each batch step
+	logStatus := func() {
+		omppLog.Log("Batch steps: ", nStep)
+		for k := range steps {
+			omppLog.Log("  ", k+1, " ", steps[k].name, ": ", status[k])
+		}
+	}
+
+	for k := range steps {
+
+		// action specific run options
+		theCfg.action = steps[k].action
+		theCfg.fileName = helper.CleanFileName(steps[k].opts.String(outputFileArgKey))
+		theCfg.layout = strings.ToLower(steps[k].opts.String(layoutArgKey))
+		theCfg.runDirName = strings.ToLower(steps[k].opts.String(runDirNameArgKey))
+
+		omppLog.Log("Batch step ", k+1, " of ", nStep, ": ", steps[k].name)
+
+		err := doActionRetry(srcDb, modelId, sqlitePath, steps[k].opts)
+		if err == nil {
+			status[k] = "OK"
+			continue
+		}
+		status[k] = "Failed: " + err.Error()
+
+		if err = keepGoing("batch step "+strconv.Itoa(k+1)+" "+steps[k].name, err); err != nil {
+			logStatus()
+			return err
+		}
+	}
+	logStatus()
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// chunked output file: list of chunk files and total number of rows, written into -dbget.StatusFile
+type chunkPub struct {
+	Path     string   // output file path without chunk number, e.g.: modelOne/run.Default/parameters/ageSex.csv
+	Parts    []string // chunk files: ageSex.part0001.csv, ageSex.part0002.csv,...
+	RowCount int64    // total number of rows in all chunks, excluding header lines
+}
+
+// list of chunked output files
+var theChunks = struct {
+	sync.Mutex
+	lst []chunkPub
+}{lst: []chunkPub{}}
+
+// return copy of the list of chunked output files
+func chunkList() []chunkPub {
+	theChunks.Lock()
+	defer theChunks.Unlock()
+	return append([]chunkPub{}, theChunks.lst...)
+}
+
+// return chunk file path: ageSex.csv => ageSex.part0001.csv
+func chunkPath(path string, nPart int) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + fmt.Sprintf(".part%04d", nPart) + ext
+}
+
+// create csv or sql output writer, if -dbget.MaxRowsPerFile specified then split output file into chunks.
+// Each chunk file starts with header line, first row written must be a header line.
+// If output is written into the console or -dbget.MaxRowsPerFile not specified then it is the same as createRowWriter().
+// Chunked writer owns the chunk files: it returns nil file and chunk files closed at Flush().
+func createChunkWriter(path string, name string) (*os.File, rowWriter, error) {
+
+	if theCfg.maxRowsPerFile <= 0 || path == "" {
+		return createRowWriter(path, name)
+	}
+	return nil, &chunkWriter{path: path, name: name, maxRows: theCfg.maxRowsPerFile}, nil
+}
+
+// chunkWriter split output rows into chunk files of max rows each, header line repeated in each chunk
+type chunkWriter struct {
+	path    string    // output file path without chunk number
+	name    string    // output name, e.g. parameter name
+	maxRows int64     // max number of rows in each chunk, excluding header line
+	hdr     []string  // header line, it is a first row written
+	nRow    int64     // number of rows in current chunk
+	total   int64     // total number of rows in all chunks
+	f       *os.File  // current chunk file
+	wr      rowWriter // current chunk writer
+	parts   []string  // chunk files
+	isDone  bool      // if true then output completed
+	err     error     // first error
+}
+
+// Write header line or row: start next chunk if current chunk is full
+func (cw *chunkWriter) Write(row []string) error {
+
+	if cw.err != nil {
+		return cw.err
+	}
+	if cw.hdr == nil {
+		cw.hdr = slices.Clone(row)
+		return cw.nextChunk()
+	}
+	if cw.nRow >= cw.maxRows {
+		if err := cw.nextChunk(); err != nil {
+			return err
+		}
+	}
+
+	if err := cw.wr.Write(row); err != nil {
+		cw.err = err
+		return err
+	}
+	cw.nRow++
+	cw.total++
+	return nil
+}
+
+// close current chunk and create next chunk file, write header line into the new chunk
+func (cw *chunkWriter) nextChunk() error {
+
+	if err := cw.closeChunk(); err != nil {
+		return err
+	}
+
+	p := chunkPath(cw.path, len(cw.parts)+1)
+
+	f, wr, err := createRowWriter(p, cw.name)
+	if err != nil {
+		cw.err = err
+		return err
+	}
+	cw.f = f
+	cw.wr = wr
+	cw.nRow = 0
+	cw.parts = append(cw.parts, p)
+
+	if err = cw.wr.Write(cw.hdr); err != nil {
+		cw.err = err
+	}
+	return cw.err
+}
+
+// flush and close current chunk file
+func (cw *chunkWriter) closeChunk() error {
+
+	if cw.wr == nil {
+		return nil
+	}
+	cw.wr.Flush()
+	if err := cw.wr.Error(); err != nil && cw.err == nil {
+		cw.err = err
+	}
+	if cw.f != nil {
+		if err := cw.f.Close(); err != nil && cw.err == nil {
+			cw.err = err
+		}
+	}
+	cw.f = nil
+	cw.wr = nil
+	return cw.err
+}
+
+// Flush close last chunk file and append chunks to the list of chunked outputs
+func (cw *chunkWriter) Flush() {
+
+	if cw.isDone {
+		return
+	}
+	cw.isDone = true
+
+	if cw.closeChunk() != nil || len(cw.parts) <= 0 {
+		return
+	}
+
+	theChunks.Lock()
+	defer theChunks.Unlock()
+	theChunks.lst = append(theChunks.lst, chunkPub{Path: cw.path, Parts: cw.parts, RowCount: cw.total})
+}
+
+// Error return first error of chunks output
+func (cw *chunkWriter) Error() error {
+	return cw.err
+}
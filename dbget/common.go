@@ -4,14 +4,16 @@
 package main
 
 import (
-	"encoding/csv"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
@@ -20,14 +22,23 @@ import (
 type rowConverter func() (isEof bool, row []string, err error)
 
 // write into outputDir/file.json if jsonPath is "" empty then write into stdout
+// json is indented unless -dbget.Pretty=false
 func toJsonOutput(jsonPath string, src interface{}) error {
 
 	if jsonPath != "" {
+		if err := checkOutputFile(jsonPath); err != nil {
+			return err
+		}
+		if !theCfg.isPretty {
+			return helper.ToJsonFile(jsonPath, src)
+		}
 		return helper.ToJsonIndentFile(jsonPath, src)
 	}
 	// else output to console
 	ce := json.NewEncoder(os.Stdout)
-	ce.SetIndent("", "  ")
+	if theCfg.isPretty {
+		ce.SetIndent("", "  ")
+	}
 	if err := ce.Encode(src); err != nil {
 		return errors.New("json encode error: " + err.Error())
 	}
@@ -37,8 +48,8 @@ func toJsonOutput(jsonPath string, src interface{}) error {
 // write into outputDir/file.csv if csvPath is "" empty then write into stdout
 func toCsvOutput(csvPath string, columnNames []string, lineCvt rowConverter) error {
 
-	// create csv file
-	f, wr, err := createCsvWriter(csvPath)
+	// create csv file or sql output
+	f, wr, err := createRowWriter(csvPath, "")
 	if err != nil {
 		return err
 	}
@@ -76,8 +87,8 @@ func toCsvOutput(csvPath string, columnNames []string, lineCvt rowConverter) err
 	return wr.Error()
 }
 
-// create csv or tsv output writer
-func createCsvWriter(csvPath string) (*os.File, *csv.Writer, error) {
+// create csv or tsv output writer using dialect options: delimiter, quoting, line endings and NULL value
+func createCsvWriter(csvPath string) (*os.File, rowWriter, error) {
 
 	// create csv file
 	isFile := csvPath != ""
@@ -86,6 +97,9 @@ func createCsvWriter(csvPath string) (*os.File, *csv.Writer, error) {
 	isClose := false
 
 	if isFile {
+		if err = checkOutputFile(csvPath); err != nil {
+			return nil, nil, err
+		}
 		f, err = os.OpenFile(csvPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
 		if err != nil {
 			return nil, nil, err
@@ -98,38 +112,78 @@ func createCsvWriter(csvPath string) (*os.File, *csv.Writer, error) {
 		}
 	}()
 
-	if isFile && theCfg.isWriteUtf8Bom { // if required then write utf-8 bom
-		if _, err = f.Write(helper.Utf8bom); err != nil {
+	// create csv writes to file and/or to console
+	// if required then write utf-8 bom into file
+	// by default use \n line terminator for files and OS-specific for console
+	var w io.Writer = os.Stdout
+	isBom := false
+	isCrlf := runtime.GOOS == "windows"
+
+	if isFile {
+		w = f
+		isBom = theCfg.isWriteUtf8Bom
+		isCrlf = false
+	}
+	if theCfg.csvEol != "" {
+		isCrlf = theCfg.csvEol == "crlf"
+	}
+
+	// if required then write header comments after utf-8 bom
+	if theCfg.isHdrComments {
+		if isBom {
+			if _, err = w.Write(helper.Utf8bom); err != nil {
+				return nil, nil, err
+			}
+			isBom = false
+		}
+		eol := "\n"
+		if isCrlf {
+			eol = "\r\n"
+		}
+		if err = writeHeaderComments(w, eol); err != nil {
 			return nil, nil, err
 		}
 	}
 
-	// create csv writes to file and/or to console
-	var csvWr *csv.Writer
-	if isFile {
-		csvWr = csv.NewWriter(f)
-	} else {
-		csvWr = csv.NewWriter(os.Stdout)
-		if runtime.GOOS == "windows" {
-			csvWr.UseCRLF = true
-		}
+	wr, err := newCsvDialectWriter(w, isBom, isCrlf)
+	if err != nil {
+		return nil, nil, err
 	}
-	if theCfg.kind == asTsv {
-		csvWr.Comma = '\t'
+
+	// verify output file if required and replace "null" values by NULL token if required
+	wr = newVerifyWriter(wr, csvPath)
+
+	if theCfg.isCsvNull {
+		wr = &csvNullWriter{rowWriter: wr, nullValue: theCfg.csvNullValue}
 	}
 
 	isClose = false // return open file to upper level
 
-	return f, csvWr, nil
+	return f, wr, nil
 }
 
-// if directory path not empty then create output directory if not already exists, remove existing directory if required
+// if directory path not empty then create output directory if not already exists, remove existing directory if required.
+// If existing directory must be removed and -dbget.NoClobber specified then return error,
+// if -dbget.Backup specified then rename existing directory with timestamp suffix instead of delete.
 func makeOutputDir(path string, isKeep bool) error {
 
 	if path != "" {
 		if !isKeep {
-			if isOk := dirDeleteAndLog(path); !isOk {
-				return errors.New("Error: unable to delete: " + path)
+			isExist, err := helper.IsDirExist(path)
+			if err != nil {
+				return withExitCode(exitIo, errors.New("Error: unable to access: "+path))
+			}
+			switch {
+			case isExist && theCfg.isNoClobber:
+				return withExitCode(exitIo, errors.New("Error: output directory already exist: "+path))
+			case isExist && theCfg.backupStamp != "":
+				if err = backupOutput(path, path+"."+theCfg.backupStamp); err != nil {
+					return err
+				}
+			default:
+				if isOk := dirDeleteAndLog(path); !isOk {
+					return withExitCode(exitIo, errors.New("Error: unable to delete: "+path))
+				}
 			}
 		}
 		if err := os.MkdirAll(path, 0750); err != nil {
@@ -139,6 +193,48 @@ func makeOutputDir(path string, isKeep bool) error {
 	return nil
 }
 
+// check if output file already exist: return error if -dbget.NoClobber specified,
+// rename existing file with timestamp suffix if -dbget.Backup specified or log a warning.
+// Return interrupt error if dbget interrupted by SIGINT or SIGTERM, it is checked before each output file.
+func checkOutputFile(path string) error {
+
+	if err := outputBegin(path); err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // OK: file not exist
+		}
+		return withExitCode(exitIo, errors.New("Error: unable to access: "+path))
+	}
+	if fi.IsDir() {
+		return withExitCode(exitIo, errors.New("Error: output file path is a directory: "+path))
+	}
+
+	switch {
+	case theCfg.isNoClobber:
+		return withExitCode(exitIo, errors.New("Error: output file already exist: "+path))
+	case theCfg.backupStamp != "":
+		ext := filepath.Ext(path)
+		return backupOutput(path, strings.TrimSuffix(path, ext)+"."+theCfg.backupStamp+ext)
+	}
+	logWarning("overwrite", "overwrite existing file: ", path)
+	return nil
+}
+
+// rename existing output file or directory to backup path and log it
+func backupOutput(path, backupPath string) error {
+
+	omppLog.Log("Backup: ", path, " to: ", backupPath)
+
+	if err := os.Rename(path, backupPath); err != nil {
+		return withExitCode(exitIo, errors.New("Error: unable to rename: "+path+" to: "+backupPath+": "+err.Error()))
+	}
+	return nil
+}
+
 // Delete directory and log path, return false on delete error.
 func dirDeleteAndLog(path string) bool {
 
@@ -159,18 +255,31 @@ func dirDeleteAndLog(path string) bool {
 	return true // OK: deleted successfully
 }
 
-// return file extension by output kind: .csv .tsv or .json
+// if it is an error in calculation expression then log expression and marker line under error position
+func logExprError(err error) {
+
+	if e, ok := db.AsExprError(err); ok {
+		if src, m := e.Marker(); m != "" {
+			omppLog.Log(src)
+			omppLog.Log(m)
+		}
+	}
+}
+
+// return file extension by output kind: .csv .tsv .json or .sql
 func extByKind() string {
 	switch theCfg.kind {
 	case asTsv:
 		return ".tsv"
 	case asJson:
 		return ".json"
+	case asSql:
+		return ".sql"
 	}
 	return ".csv" // by default
 }
 
-// return kind of by file extension: .csv .tsv or .json,
+// return kind of by file extension: .csv .tsv .json or .sql,
 // if file path is empty or extension is unknown then return csv by default
 func kindByExt(path string) outputAs {
 	if path != "" {
@@ -179,7 +288,24 @@ func kindByExt(path string) outputAs {
 			return asTsv
 		case ".json":
 			return asJson
+		case ".sql":
+			return asSql
 		}
 	}
 	return asCsv // csv by default
 }
+
+// open source database connection and return close function.
+// If isSnapshot is true then open read-only snapshot copy of SQLite database, snapshot file deleted by close function.
+func openSrcDb(dbConnStr, dbDriver string, isSnapshot bool) (*sql.DB, func(), error) {
+
+	if isSnapshot {
+		return db.OpenSnapshot(dbConnStr, dbDriver, "")
+	}
+
+	srcDb, _, err := db.Open(dbConnStr, dbDriver, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return srcDb, func() { db.Close(srcDb) }, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// csvQuoteWriter write csv rows where all values are "quoted" or none of values are quoted.
+// It is used for -dbget.Quote always or -dbget.Quote none, minimal quoting is done by standard csv writer.
+type csvQuoteWriter struct {
+	wr      *bufio.Writer // output file or console writer
+	comma   string        // values delimiter
+	isQuote bool          // if true then quote all values else do not quote any value
+	eol     string        // line terminator: \r\n or \n
+	err     error         // first write error, if any
+}
+
+// csvNullWriter replace "null" values by -dbget.NullValue token and write rows into csv writer
+type csvNullWriter struct {
+	rowWriter          // csv or tsv writer
+	nullValue string   // NULL value token
+	row       []string // row buffer, values copy where "null" replaced by NULL token
+}
+
+// create csv writer using dialect options: delimiter, quoting and line terminator, write utf-8 BOM if required
+func newCsvDialectWriter(w io.Writer, isBom, isCrlf bool) (rowWriter, error) {
+
+	if theCfg.csvQuote == "minimal" {
+
+		csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom, IsCrlf: isCrlf})
+		if err != nil {
+			return nil, err
+		}
+		csvWr.Comma = theCfg.csvDelimiter
+		return csvWr, nil
+	}
+
+	if isBom {
+		if _, err := w.Write(helper.Utf8bom); err != nil {
+			return nil, err
+		}
+	}
+	cw := &csvQuoteWriter{
+		wr:      bufio.NewWriter(w),
+		comma:   string(theCfg.csvDelimiter),
+		isQuote: theCfg.csvQuote == "always",
+		eol:     "\n",
+	}
+	if isCrlf {
+		cw.eol = "\r\n"
+	}
+	return cw, nil
+}
+
+// Write row values separated by delimiter, if quoting is enabled then all values are "quoted"
+func (cw *csvQuoteWriter) Write(row []string) error {
+
+	if cw.err != nil {
+		return cw.err
+	}
+	for k := range row {
+		if k > 0 {
+			cw.wr.WriteString(cw.comma)
+		}
+		if cw.isQuote {
+			cw.wr.WriteString("\"" + strings.ReplaceAll(row[k], "\"", "\"\"") + "\"")
+		} else {
+			cw.wr.WriteString(row[k])
+		}
+	}
+	_, cw.err = cw.wr.WriteString(cw.eol)
+	return cw.err
+}
+
+// Flush write buffered rows into output
+func (cw *csvQuoteWriter) Flush() {
+	if cw.err == nil {
+		cw.err = cw.wr.Flush()
+	}
+}
+
+// Error return first write error, if any
+func (cw *csvQuoteWriter) Error() error {
+	return cw.err
+}
+
+// Write row where "null" values replaced by NULL token
+func (nw *csvNullWriter) Write(row []string) error {
+
+	nw.row = append(nw.row[:0], row...)
+	for k := range nw.row {
+		if nw.row[k] == helper.CsvNull {
+			nw.row[k] = nw.nullValue
+		}
+	}
+	return nw.rowWriter.Write(nw.row)
+}
+
+// parse values delimiter option: single character or tab, default is comma for csv and tab for tsv output
+func parseCsvDelimiter(src string, isTsv bool) (rune, error) {
+
+	switch src {
+	case "":
+		if isTsv {
+			return '\t', nil
+		}
+		return ',', nil
+	case "tab", "\\t":
+		return '\t', nil
+	}
+
+	r, n := utf8.DecodeRuneInString(src)
+	if n != len(src) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, errors.New("invalid delimiter: " + src + ", expected single character or tab")
+	}
+	return r, nil
+}
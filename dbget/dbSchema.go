@@ -0,0 +1,91 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strconv"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// write list of database tables and views into text csv, tsv or json file, it does not write any values.
+// Each row is a db table or view: name, kind, row count and, for SQLite, page count and DDL.
+func dbSchema(srcDb *sql.DB) error {
+
+	siLst, err := db.GetDbSchema(srcDb)
+	if err != nil {
+		return err
+	}
+	if len(siLst) <= 0 {
+		omppLog.Log("Database is empty, tables and views not found")
+		return nil
+	}
+
+	// use specified file name or make default
+	fp := ""
+
+	if theCfg.isConsole {
+		omppLog.Log("Do db-schema")
+	} else {
+
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = "db-schema" + extByKind()
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do db-schema: " + fp)
+	}
+
+	// write json output into file or console
+	if theCfg.kind == asJson {
+		return toJsonOutput(fp, siLst) // save results
+	}
+	// else write csv or tsv output into file or console
+
+	hdr := []string{"name", "is_view", "kind", "row_count", "page_count", "ddl"}
+	row := make([]string, len(hdr))
+
+	idx := 0
+	err = toCsvOutput(
+		fp,
+		hdr,
+		func() (bool, []string, error) {
+			if 0 <= idx && idx < len(siLst) {
+				row[0] = siLst[idx].Name
+				row[1] = strconv.FormatBool(siLst[idx].IsView)
+				row[2] = siLst[idx].Kind
+				row[3] = ""
+				if siLst[idx].RowCount >= 0 {
+					row[3] = strconv.FormatInt(siLst[idx].RowCount, 10)
+				}
+				row[4] = ""
+				if siLst[idx].PageCount >= 0 {
+					row[4] = strconv.FormatInt(siLst[idx].PageCount, 10)
+				}
+				row[5] = siLst[idx].Ddl
+				idx++
+				return false, row, nil
+			}
+			return true, row, nil // end of rows
+		})
+	if err != nil {
+		return errors.New("failed to write db schema into csv " + err.Error())
+	}
+
+	// log number of tables and views
+	nView := 0
+	for k := range siLst {
+		if siLst[k].IsView {
+			nView++
+		}
+	}
+	omppLog.Log("Db tables: ", len(siLst)-nView, " views: ", nView)
+
+	return nil
+}
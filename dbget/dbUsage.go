@@ -0,0 +1,91 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// write list of parameters and output tables values db tables shared between models into text csv, tsv or json file.
+// Each row is a db table: kind, parameter or output table name and digest, db table name, row count, size and models digests.
+func dbUsage(srcDb *sql.DB) error {
+
+	tuLst, err := db.GetDbUsage(srcDb)
+	if err != nil {
+		return err
+	}
+	if len(tuLst) <= 0 {
+		omppLog.Log("Database is empty, parameters and output tables not found")
+		return nil
+	}
+
+	// use specified file name or make default
+	fp := ""
+
+	if theCfg.isConsole {
+		omppLog.Log("Do db-usage")
+	} else {
+
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = "db-usage" + extByKind()
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do db-usage: " + fp)
+	}
+
+	// write json output into file or console
+	if theCfg.kind == asJson {
+		return toJsonOutput(fp, tuLst) // save results
+	}
+	// else write csv or tsv output into file or console
+
+	hdr := []string{"kind", "name", "digest", "db_table", "row_count", "db_table_size", "model_count", "model_digests"}
+	row := make([]string, len(hdr))
+
+	idx := 0
+	err = toCsvOutput(
+		fp,
+		hdr,
+		func() (bool, []string, error) {
+			if 0 <= idx && idx < len(tuLst) {
+				row[0] = tuLst[idx].Kind
+				row[1] = tuLst[idx].Name
+				row[2] = tuLst[idx].Digest
+				row[3] = tuLst[idx].DbTable
+				row[4] = strconv.FormatInt(tuLst[idx].RowCount, 10)
+				row[5] = ""
+				if tuLst[idx].Size >= 0 {
+					row[5] = strconv.FormatInt(tuLst[idx].Size, 10)
+				}
+				row[6] = strconv.Itoa(len(tuLst[idx].ModelDigest))
+				row[7] = strings.Join(tuLst[idx].ModelDigest, " ")
+				idx++
+				return false, row, nil
+			}
+			return true, row, nil // end of rows
+		})
+	if err != nil {
+		return errors.New("failed to write db usage into csv " + err.Error())
+	}
+
+	// log number of db tables shared by multiple models
+	nShared := 0
+	for k := range tuLst {
+		if len(tuLst[k].ModelDigest) > 1 {
+			nShared++
+		}
+	}
+	omppLog.Log("Db tables: ", len(tuLst), " shared by multiple models: ", nShared)
+
+	return nil
+}
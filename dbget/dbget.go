@@ -27,6 +27,31 @@ and located in current directory then it is enough to specify model name only:
 As result of above command dbget will open modelOne.sqlite database file in current directory
 and do "run-list" output list of model runs into CSV file.
 
+Use -dbget.ModelDir to search directory tree for SQLite database file which contains the model:
+
+	dbget -m modelOne -do run-list -dbget.ModelDir models/bin
+	dbget -dbget.ModelDigest 90bd6b8c2cd07c53f4ae1e5c0a6d4d0b -do run-list -dbget.ModelDir models/bin
+
+Each .sqlite file found in that directory and sub-directories is checked by reading model_dic table only.
+If model found in multiple files then dbget does report list of candidate files,
+use -dbget.ModelDigest to select model version or -dbget.Sqlite to select database file.
+
+To list models from multiple SQLite databases use directory or glob pattern as -dbget.Sqlite:
+
+	dbget -do model-list -db models/bin
+	dbget -do model-list -db "models/bin/*.sqlite" -json
+
+If it is a directory then all .sqlite files found in that directory and sub-directories are included.
+Output has additional source_file column with path to database file, files which are not openM++ databases are skipped.
+
+Use -dbget.Extended to include model runs count, completed runs count, input scenarios (worksets) count,
+newest model run date-time and database file size. It does additional aggregate queries for each model:
+
+	dbget -do model-list -db modelOne.sqlite -dbget.Extended
+	dbget -do model-list -db models/bin -dbget.Extended -json
+
+Database file size is available only for SQLite database file, otherwise db_file_size column is empty.
+
 Most often used options of dbget do have a short form to reduce typing on command line.
 For example: -db is a short version of: -dbget.Sqlite option and -do is a short of -dbget.Do.
 Longer version of options can be used on command line and ini files.
@@ -44,6 +69,40 @@ then commands below are equal:
 	dbget -do       model-list -db           some/dir/model.sqlite
 	dbget -dbget.Do model-list -dbget.Sqlite some/dir/model.sqlite
 
+Ini file can contain named profiles to avoid typing the same options repeatedly.
+Profile is an ini file section [profile.name] with full option names, selected by -profile name:
+
+	[profile.archive]
+	dbget.Do           = all-runs
+	dbget.Layout       = table
+	dbget.RunDirName   = stamp
+	dbget.KeepGoing    = true
+
+	dbget -ini my.ini -m modelOne -profile archive
+	dbget -ini my.ini -m modelOne -OpenM.Profile archive
+
+Command line options take precedence over profile and profile options take precedence over other ini file options.
+If -profile is not specified and there is a profile with the same name as dbget action then it is used by default,
+for example: dbget -ini my.ini -m modelOne -do all-runs is using [profile.all-runs], if it exists.
+
+To do multiple actions using the same database connection and model metadata use batch mode.
+Batch is a comma separated list of -dbget.Do actions or ini file [batch] Do option:
+
+	dbget -m modelOne -do model,run-list,all-runs
+
+	[batch]
+	Do = model, run-list, archive
+
+	dbget -ini my.ini -m modelOne -dbget.KeepGoing
+
+Each item in batch is an action name or profile name, e.g.: archive is [profile.archive] from example above.
+Profile dbget.Do is an action and profile options are used for that action only,
+it is recommended to specify output file name in profile if there is the same action more than once in the batch.
+Actions are done sequentially, at the end dbget log status of each action.
+By default batch stops at the first failed action, use -dbget.KeepGoing to continue with next action.
+Output directory and output format are the same for all actions of the batch,
+import-words and language actions: lang-add, lang-copy, lang-delete cannot be used in batch.
+
 By default dbget produce .csv output file(s), e.g. commands above will create model-list.csv file.
 It is also possible to produce .tsv output and, for some commands, .json output:
 
@@ -55,6 +114,87 @@ It is also possible to produce .tsv output and, for some commands, .json output:
 	dbget -db modelOne.sqlite -do model-list -dbget.As tsv
 	dbget -db modelOne.sqlite -do model-list -dbget.As json
 
+Parameters, output tables and microdata values can be written as SQL INSERT statements to load it into other database:
+
+	dbget -m modelOne -do parameter -dbget.Parameter ageSex -dbget.As sql
+	dbget -m modelOne -do table -dbget.Table ageSexIncome -dbget.As sql -dbget.SqlDialect postgres
+	dbget -m modelOne -do micro -dbget.Entity Person -dbget.As sql -dbget.SqlDialect mysql -dbget.SqlBatchSize 1000
+	dbget -m modelOne -do all-runs -dbget.As sql -dbget.SqlCreateTable
+
+SQL dialect can be sqlite (default), postgres or mysql, each INSERT statement contains up to -dbget.SqlBatchSize rows, default: 100.
+Destination table name is output file name without extension, e.g.: ageSex, and columns are csv header columns.
+If -dbget.SqlCreateTable specified then output starts from CREATE TABLE statement of flat table,
+column types are inferred from the first batch of rows: integer, float or text.
+Special float values NaN, +Inf, -Inf are written as NULL, except of postgres where it is 'NaN', 'Infinity', '-Infinity'.
+
+Use -dbget.MaxRowsPerFile to split large parameter, output table and microdata csv or tsv files into chunks
+of max rows each, for example, to open output in applications which cannot read more than 1M rows:
+
+	dbget -m modelOne -do all-runs -dbget.MaxRowsPerFile 1000000
+
+Chunk files are numbered and each chunk starts from header line: ageSex.part0001.csv, ageSex.part0002.csv,...
+If -dbget.StatusFile specified then list of chunk files of each output and total row count written into status file.
+
+Use -dbget.Verify to read back each csv or tsv output file after it is written
+and check row count and values digest against rows retrieved from database:
+
+	dbget -m modelOne -do all-runs -dbget.Verify
+	dbget -m modelOne -do all-runs -dbget.Verify -tsv
+
+Files are verified concurrently while next output is written.
+At the end dbget log list of mismatched files, e.g. truncated because disk is full, and exit with input or output error exit code.
+Verification is not done for json and sql output or if output is written to console.
+
+CSV or TSV dialect can be customized by values delimiter, quoting, line endings and NULL value token:
+
+	dbget -m modelOne -do all-runs -dbget.Delimiter ";" -dbget.NullValue NULL
+	dbget -m modelOne -do all-runs -dbget.Quote always -dbget.Eol crlf
+	dbget -m modelOne -do all-runs -tsv -dbget.Quote none
+
+Delimiter is a single character or tab, default: comma for csv and tab for tsv output.
+Quoting can be: minimal (default) to quote values only if required, always to quote all values or none to never quote values.
+Line endings can be crlf or lf, by default it is lf for output files and OS-specific for console output.
+By default NULL values written as null, use -dbget.NullValue to replace it by any other token, e.g. empty string.
+Dialect options are applied to all actions and can be used only with csv or tsv output.
+
+Use -dbget.HeaderComments to write run metadata as comment lines before csv header row, e.g. to archive csv files:
+
+	dbget -m modelOne -do all-runs -dbget.HeaderComments
+	dbget -m modelOne -do run -r Default -dbget.HeaderComments -dbget.CommentChar ";"
+
+Comment lines contain model name and digest, model run name and digest or workset name,
+date-time of output, language, dbget version and command line. Default comment character is #.
+Dbcopy skip leading comment lines on import from csv files, use -dbcopy.CommentChar if comment character is not #.
+Header comments can be used only with csv or tsv output.
+
+If SQLite database is locked by running model then read may fail with "database is locked" error.
+Use -dbget.WaitLocked to retry read operations with increasing delay until database unlocked, in seconds:
+
+	dbget -m modelOne -do all-runs -dbget.WaitLocked 3600
+
+On retry action restarted from the beginning and output files overwritten,
+that is why it cannot be combined with -dbget.NoClobber or -dbget.Backup.
+Run-copy, table-recalc, import-words and language actions are not retried, default: 0, no retry.
+
+Use -dbget.Threads to read output table values by multiple threads, e.g. to export very large table:
+
+	dbget -m modelOne -do table -dbget.Table ageSexIncome -dbget.Threads 4
+	dbget -m modelOne -do all-runs -dbget.Threads 4
+
+Table is split into partitions by expressions or accumulators, sub-values and ranges of first dimension items,
+partitions are read in parallel and written into output in the same order as by single thread.
+It is applied to table, sub-table and sub-table-all actions and to output tables of run and all-runs actions.
+Read by threads is not used if dimension total items computed by -dbget.Margin.
+Each thread use its own database connection and keep the partition in memory, default: 1 thread.
+
+If database is updated by model run at the same time then long output may see inconsistent data.
+Use -dbget.Snapshot to make temporary consistent copy of SQLite database and read from that copy:
+
+	dbget -m modelOne -do all-runs -dbget.Snapshot
+
+Snapshot is created by SQLite online backup API in OS temporary directory and deleted at exit.
+It can be used only for SQLite database and cannot be used with run-copy, table-recalc, import-words and language actions.
+
 By default dbget write results into the file and user can redirect it to console:
 
 	dbget -db modelOne.sqlite -do model-list -dbget.ToConsole
@@ -67,6 +207,103 @@ to produce output suitable for command pipes.
 By using -pipe you are suppressing any console error message output and therefore you must check dbget exit code
 or enable additonal log output to file by using -OpenM.LogToFile option.
 
+dbget exit codes:
+
+	0  completed successfully
+	1  error
+	2  fatal error (panic)
+	3  invalid command line arguments or ini-file options
+	4  model not found
+	5  model run or input scenario (workset) not found
+	6  partial failure: some of outputs failed, see -dbget.KeepGoing below
+	7  input or output error, e.g.: unable to open database, database is locked, not an openM++ database or create output file
+	8  interrupted by Ctrl+C (SIGINT) or SIGTERM, output is incomplete, see list of completed outputs in dbget.manifest.txt
+
+Use -quiet to suppress console output of progress messages, only errors and warnings are displayed,
+or use -verbose to display additional debug messages. Log file output is not affected by -quiet:
+
+	dbget -m modelOne -do all-runs -quiet
+	dbget -m modelOne -do all-runs -dbget.Quiet
+	dbget -m modelOne -do all-runs -verbose
+
+Warnings, e.g. unable to match user language or overwrite existing file, are collected
+and at the end dbget log summary of all warnings at any log level.
+Use -dbget.StatusFile to write completion status, exit code, error message and list of warnings into json file:
+
+	dbget -m modelOne -do all-runs -quiet -dbget.StatusFile dbget.status.json
+
+Output directory can be S3 or S3 compatible object storage, e.g. MinIO, bucket url: s3://bucket/prefix.
+Output files are written into local staging directory in OS temporary directory and uploaded at the end,
+large files are uploaded by multipart upload, part size is -dbget.S3PartSize MiB, default: 16.
+Failed requests are retried -dbget.S3Retry times, default: 3.
+Output is uploaded if dbget completed successfully, partially failed or interrupted:
+
+	dbget -m modelOne -do all-runs -dir s3://my-bucket/openmpp/modelOne
+	dbget -m modelOne -do all-runs -dir s3://my-bucket/modelOne -dbget.S3Endpoint http://localhost:9000
+
+Credentials, region and endpoint are from command line or ini-file options, if not specified then from environment variables:
+AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION or AWS_DEFAULT_REGION, AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL.
+Existing objects are overwritten, -dbget.NoClobber and -dbget.Backup cannot be used with object storage output.
+Use -dbget.WatermarkFile with all-runs output into object storage, default watermark file is not preserved.
+
+By default dbget stops at the first error.
+If you are doing output of multiple runs, worksets, parameters or tables then use -dbget.KeepGoing option
+to continue after error. At the end dbget log list of failed outputs and exit with partial failure exit code:
+
+	dbget -m modelOne -do all-runs -dbget.KeepGoing
+	dbget -m modelOne -do all-sets -dbget.KeepGoing
+
+If dbget interrupted by Ctrl+C (SIGINT) or SIGTERM then it completes current output file and stops before next output file.
+At the end dbget write list of completed output files into dbget.manifest.txt in output directory and exit with interrupted exit code.
+If dbget interrupted second time then it deletes current incomplete output file, write dbget.manifest.txt and exit immediately.
+
+By default dbget delete existing output directory and overwrite existing output files, with a warning in the log.
+Use -dbget.NoClobber to fail if output directory or file already exist
+or -dbget.Backup to rename existing output directory or file with timestamp suffix before writing:
+
+	dbget -m modelOne -do all-runs -dbget.NoClobber
+	dbget -m modelOne -do all-runs -dbget.Backup
+	dbget -m modelOne -do model-list -dbget.Backup
+
+By default float and double values are formatted using -dbget.DoubleFormat %.15g.
+Use -dbget.UseDecimals to format output table expression values by number of decimals of each expression,
+as it is declared in model source code, other values are still formatted by -dbget.DoubleFormat.
+Use -dbget.Round N to round all float and double values to N decimals, it does override both options above:
+
+	dbget -m modelOne -do all-runs -dbget.UseDecimals
+	dbget -m modelOne -do all-runs -dbget.Round 2
+
+Special float values NaN, +Inf and -Inf are written into csv output as: NaN, +Inf, -Inf.
+Use -dbget.FloatSpecial sentinel or sentinel:value if database contains sentinel values instead of NaN, +Inf, -Inf,
+for example, database created by dbcopy -dbcopy.FloatSpecial sentinel:-9999:
+
+	dbget -m modelOne -do all-runs -dbget.FloatSpecial sentinel:-9999
+
+By default it is an error if model run contains enum id which is not found in model metadata, e.g. enum deleted from the model.
+Use -dbget.OnUnknownEnum empty to write empty enum code (or label) or -dbget.OnUnknownEnum id to write enum id as is:
+
+	dbget -m modelOne -do all-runs -dbget.OnUnknownEnum empty
+	dbget -m modelOne -do all-runs -dbget.OnUnknownEnum id
+
+At the end dbget log number of unknown enum id's for each parameter, output table or entity dimension or attribute.
+It is not used for -dbget.IdCsv output, because enum id's written as is.
+
+Use -dbget.LabelMap to replace model enum labels by custom labels, e.g. publication labels of dimension items.
+Label map is a csv (or tsv) file with header: type,enum_code,new_label, for range types enum_code is an item value, e.g. 25:
+
+	dbget -m modelOne -do all-runs -dbget.LabelMap labels.csv
+
+Custom labels are used for language-specific output of parameters, output tables and microdata.
+It is not used for -dbget.NoLanguage or -dbget.IdCsv output, because enum codes or id's written as is.
+All types and enum codes of label map must exist in the model, otherwise it is an error.
+
+Use -dbget.QueryWarnTime and -dbget.QueryMaxTime to log or cancel database queries which take too long, in seconds:
+
+	dbget -m modelOne -do all-runs -dbget.QueryWarnTime 60 -dbget.QueryMaxTime 600
+
+Slow or cancelled queries are logged with SQL digest and duration, use -OpenM.LogSql to find query text by SQL digest.
+If query cancelled then output fails with error, default: 0, no limits.
+
 By default dbget produces language specific output based on match of user OS language to model languages.
 For example, if user OS language is fr-CA then output will be created from model FR language, if it is exists in the model database.
 If there are no laguage matched then output created in default model language.
@@ -101,11 +338,23 @@ In that case dimension items will be M, F codes instead of Male, Female lables.
 **dbget commands (actions)**
 
 	model-list       list of the models in database
+	db-usage         parameters and output tables db tables shared between models, row count and size
+	db-schema        database tables and views: metadata and model values tables, row count, SQLite page count and DDL
 	model            model metadata
+	model-doc        model documentation: parameters, tables, entities and groups in Markdown or HTML
+	model-words      model words and language words: translation strings
+	import-words     update model words and language words from csv or json file
+	lang-add         add new language into database, copy translation strings from other language
+	lang-copy        copy translation strings from one language to another, existing translations not changed
+	lang-delete      delete language and all translation strings of that language
 	run-list         list of model runs
+	run-options      run options of model runs as wide table: option key and value for each run
+	run-progress     run status and sub-values run progress of model runs: timestamps and durations
 	set-list         list of model input scenarios (a.k.a. "input set" or workset)
 	run              model run results: all parameters, output tables and microdata
 	all-runs         all model runs, all parameters, output tables and microdata
+	run-copy         copy model run in the same database, values are shared with source run
+	table-recalc     recalculate output table expressions of model run from accumulators
 	set              input scenario parameters
 	all-sets         all input scenarios, all parameter values
 	parameter        model run parameter values
@@ -116,9 +365,10 @@ In that case dimension items will be M, F codes instead of Male, Female lables.
 	micro            microdata values from model run results
 	micro-compare    compare or aggregate microdata between model runs
 	old-model        model metadata in Modgen compatible form
-	old-run          first model run results in Modgen compatible form
+	old-run          model run results in Modgen compatible form, by default first model run
 	old-parameter    parameter values in Modgen compatible form
-	old-table        output table values in Modgen compatible form
+	old-table        output table values in Modgen compatible form, one or all output tables
+	old-set          input scenario parameters as Modgen .dat file
 
 Get list of the models from database:
 
@@ -149,6 +399,29 @@ Get list of the models from database:
 	  -dbget.Database "Database=model.sqlite; Timeout=86400; OpenMode=ReadOnly;"
 	  -dbget.DatabaseDriver SQLite
 
+Get list of parameters and output tables db tables shared between models in database, row count and size of each db table:
+
+	dbget -db modelOne.sqlite -do db-usage
+	dbget -db modelOne.sqlite -do db-usage -tsv
+	dbget -db modelOne.sqlite -do db-usage -json
+	dbget -db modelOne.sqlite -do db-usage -pipe
+
+Parameters and output tables with the same digest are shared between models in the same database,
+values of such parameter or output table are stored in the same db table for all models.
+Each parameter produce two rows: run values (kind p) and input scenario values (kind w) db tables,
+each output table also produce two rows: expressions (kind v) and accumulators (kind a) db tables.
+Db table size is empty if it is not available, e.g. for SQLite database without dbstat virtual table.
+
+Get structure of database: list of openM++ metadata tables, model values db tables and views, row count of each table:
+
+	dbget -db modelOne.sqlite -do db-schema
+	dbget -db modelOne.sqlite -do db-schema -json
+
+It does not output any values. For SQLite database it also output DDL (CREATE TABLE or CREATE VIEW statement)
+and page count of each table if SQLite dbstat virtual table is available.
+Kind of each table or view is: m=metadata or other, p=parameter run values, w=workset values,
+v=output table expressions, a=accumulators, d=all accumulators view, g=microdata.
+
 Get model metadata from database:
 
 	dbget -m modelOne -do model
@@ -160,12 +433,55 @@ Get model metadata from database:
 	dbget -m modelOne -do model -lang fr-CA
 	dbget -m modelOne -do model -lang isl
 	dbget -m modelOne -do model -lang fr-CA -dbget.Notes
+	dbget -m modelOne -do model -dbget.NotesTree
 	dbget -m modelOne -do model -dbget.NoLanguage
 	dbget -m modelOne -do model -dir my/output/dir
 	dbget -m modelOne -do model -f my-model.csv
 
 	dbget -dbget.ModelName modelOne -dbget.Do model -dbget.As csv -dbget.ToConsole -dbget.Language FR
 
+Get model documentation: model description, parameters, output tables, entity attributes and groups.
+Output is Markdown, or HTML if output file extension is .html or .htm:
+
+	dbget -m modelOne -do model-doc
+	dbget -m modelOne -do model-doc -lang fr-CA
+	dbget -m modelOne -do model-doc -f modelOne.md
+	dbget -m modelOne -do model-doc -f modelOne.html
+	dbget -m modelOne -do model-doc -dir my/output/dir
+	dbget -m modelOne -do model-doc -pipe
+
+Get model words and language words, translation strings of the model, for all languages or only for selected languages.
+Output file contains word kind (lang or model), language code, word code and word value:
+
+	dbget -m modelOne -do model-words
+	dbget -m modelOne -do model-words -dbget.Languages EN,FR
+	dbget -m modelOne -do model-words -f modelOne.words.json
+
+Update model words and language words from csv or json file, for example, after translation strings corrected.
+Words from the file are merged with existing words: words not in the file are not changed and empty values are skipped.
+Languages must already exist in database. Model database is opened in read-write mode to do the update:
+
+	dbget -m modelOne -do import-words -f modelOne.words.csv
+	dbget -m modelOne -do import-words -f modelOne.words.json
+
+Add new language into database, it does not require any SQL statement to be done manually.
+Language words (lang_word rows) are copied from other language, by default from the first language in database.
+If -dbget.LangFrom specified then all translation strings are also copied from that language as placeholders:
+model words, descriptions and notes of model, parameters, output tables, model runs, input scenarios, etc.
+Model database is opened in read-write mode to do the update:
+
+	dbget -m modelOne -do lang-add -dbget.LangCode DE -dbget.LangName Deutsch
+	dbget -m modelOne -do lang-add -dbget.LangCode DE -dbget.LangName Deutsch -dbget.LangFrom EN
+
+Copy translation strings which does not exist in destination language, existing translations are not changed:
+
+	dbget -m modelOne -do lang-copy -dbget.LangFrom EN -dbget.LangCode FR
+
+Delete language and all translation strings of that language.
+Language cannot be deleted if it is a default language of any model in database:
+
+	dbget -m modelOne -do lang-delete -dbget.LangCode DE
+
 Get list of model runs:
 
 	dbget -m modelOne -do run-list
@@ -175,6 +491,26 @@ Get list of model runs:
 	dbget -m modelOne -do run-list -lang fr-CA
 	dbget -m modelOne -do run-list -dbget.NoLanguage
 	dbget -m modelOne -do run-list -dir my/output/dir
+
+Get run options of model runs as wide table: option key and option value for each run.
+By default options of all completed runs are written, use run selection options to get only some of the runs:
+
+	dbget -m modelOne -do run-options
+	dbget -m modelOne -do run-options -dbget.DiffOnly
+	dbget -m modelOne -do run-options -dbget.WithRunIds 101,102,103
+	dbget -m modelOne -do run-options -r Default -dbget.WithRuns "Default-4,Sub-values 2"
+	dbget -m modelOne -do run-options -dbget.FirstRun -dbget.WithLastRun -dbget.DiffOnly -tsv
+
+If -dbget.DiffOnly specified then only options which are different between runs are written.
+
+Get run status and sub-values run progress: sub-value status, progress count, start and end date-time, duration in seconds.
+By default all model runs are written, including failed or not completed runs, use run selection options to get only one run:
+
+	dbget -m modelOne -do run-progress
+	dbget -m modelOne -do run-progress -json
+	dbget -m modelOne -do run-progress -dbget.LastRun -tsv
+	dbget -m modelOne -do run-progress -r Default-4
+
 	dbget -m modelOne -do run-list -f my-runs.csv
 	dbget -m modelOne -do run-list -pipe
 	dbget -m modelOne -do run-list -lang fr-CA -dbget.Notes
@@ -196,6 +532,52 @@ Get all model runs parameters and output table values:
 
 	dbget -dbget.ModelName modelOne -dbget.Do all-runs
 
+By default all-runs output is using run directories: modelOne/run.Default/parameters/ageSex.csv.
+If run name is not unique then run id is added to directory name: modelOne/run.11.Default/parameters/ageSex.csv.
+Use -dbget.Layout to write all runs into flat directories or into per-table directories:
+
+	dbget -m modelOne -do all-runs -dbget.Layout flat
+	dbget -m modelOne -do all-runs -dbget.Layout table
+
+	flat:  modelOne/parameters/run.Default.ageSex.csv
+	table: modelOne/parameters/ageSex/run.Default.csv
+
+Use -dbget.RunDirName to name run directories or files by run name (default), run digest, run stamp or run id and name:
+
+	dbget -m modelOne -do all-runs -dbget.RunDirName digest
+	dbget -m modelOne -do all-runs -dbget.RunDirName stamp
+	dbget -m modelOne -do all-runs -dbget.RunDirName id
+	dbget -m modelOne -do all-runs -dbget.Layout table -dbget.RunDirName stamp
+	dbget -m modelOne -do run -r Default-4 -dbget.RunDirName digest
+
+Use -dbget.Since to do incremental all-runs output, only runs completed after watermark run digest or date-time:
+
+	dbget -m modelOne -do all-runs -dbget.Since 2024-05-01
+	dbget -m modelOne -do all-runs -dbget.Since "2024-05-01 12:00:00.123"
+	dbget -m modelOne -do all-runs -dbget.Since 2024_05_01_12_00_00_123
+	dbget -m modelOne -do all-runs -dbget.Since 6a1cb9c4b5d2dc4f0f8a7e6aa3d6fe12
+
+At the end completion date-time of the newest run is saved into watermark file, by default: modelOne.watermark.txt
+or my/output/dir.watermark.txt if -dir my/output/dir specified.
+If -dbget.WatermarkFile specified and -dbget.Since is not then watermark is read from that file, if file exists.
+Use it for nightly archival to output only new model runs:
+
+	dbget -m modelOne -do all-runs -dbget.WatermarkFile modelOne.watermark.txt
+
+Watermark file is not updated if any output failed, e.g. if -dbget.KeepGoing used and some runs failed.
+
+Use -dbget.RunWhere to select model runs by run option values instead of run names,
+it is a comma separated list of OptionKey=Value, runs must have all run options equal to those values.
+Option keys are compared case-insensitive and values are compared as is.
+It can be used with run-list, all-runs, table-compare and micro-compare actions:
+
+	dbget -m modelOne -do run-list -dbget.RunWhere OpenM.SubValues=16
+	dbget -m modelOne -do all-runs -dbget.RunWhere "OpenM.SubValues=16,OpenM.Threads=4"
+	dbget -m modelOne -do table-compare -dbget.FirstRun -dbget.RunWhere OpenM.SubValues=16 -dbget.Table salarySex -calc "expr0[variant] - expr0[base]"
+
+For table-compare and micro-compare all selected runs are used as variant runs,
+if base run is not specified then first selected run is used as base run.
+
 Get model run parameters and output table values:
 
 	dbget -m modelOne -do run -dbget.FirstRun
@@ -212,6 +594,20 @@ Get model run parameters and output table values:
 
 	dbget -dbget.ModelName modelOne -dbget.Do run -dbget.Run Default
 
+Copy model run in the same database, for example to keep a snapshot of the run.
+Run metadata is copied and parameters, output tables and microdata values are shared with source run, values are not copied.
+Model database is opened in read-write mode to do the copy:
+
+	dbget -m modelOne -do run-copy -r Default-4
+	dbget -m modelOne -do run-copy -r Default-4 -dbget.CopyName "Default-4 before calibration"
+	dbget -m modelOne -do run-copy -dbget.LastRun -dbget.CopyName snapshot
+
+Recalculate output table expression values of model run from accumulators, for example after table_expr metadata edited.
+Expression values are calculated by expression sql of the table and replaced in a single transaction.
+If output table values are shared with other runs then expressions are recalculated for all those runs:
+
+	dbget -m modelOne -do table-recalc -r Default-4 -dbget.Table ageSexIncome
+
 Get parameter run values:
 
 	dbget -m modelOne -r Default -parameter ageSex
@@ -236,12 +632,22 @@ Get output table values:
 	dbget -m modelOne -r Default -table ageSexIncome -pipe
 	dbget -m modelOne -r Default -table ageSexIncome -dbget.NoZeroCsv
 	dbget -m modelOne -r Default -table ageSexIncome -dbget.NoNullCsv
+	dbget -m modelOne -r Default -table ageSexIncome -dbget.UseDecimals
+	dbget -m modelOne -r Default -table ageSexIncome -dbget.Round 2
 
 	dbget -m modelOne -dbget.FirstRun -table ageSexIncome
 	dbget -m modelOne -dbget.LastRun  -table ageSexIncome
 
 	dbget -dbget.ModelName modelOne -dbget.Do table -dbget.Run Default -dbget.Table ageSexIncome
 
+If model did not write output table dimension total items then dbget can compute it on read.
+Dimension must have total item enabled, by default total item is a sum of other items:
+
+	dbget -m modelOne -r Default -table ageSexIncome -dbget.Margin dim0
+	dbget -m modelOne -r Default -table ageSexIncome -dbget.Margin dim0,dim1
+	dbget -m modelOne -r Default -table ageSexIncome -dbget.Margin dim0 -dbget.MarginAggr avg
+	dbget -m modelOne -r Default -sub-table ageSexIncome -dbget.Margin dim1
+
 Get output table sub-values (get accumulators):
 
 	dbget -m modelOne -r Default -sub-table ageSexIncome
@@ -301,6 +707,15 @@ Get all parameters from all input sets (a.k.a. input scenarios or worksets):
 
 	dbget -dbget.ModelName modelOne -dbget.Do all-sets
 
+Each input set directory also contains workset metadata file set.Name.meta.json:
+set description and notes, base run digest, update date-time, parameters list with digests, sub-values count and value notes.
+Use -dbget.SetMeta csv to write metadata in output format, e.g.: set.Name.meta.csv or set.Name.meta.tsv,
+one row for each parameter, without descriptions and notes.
+Use -dbget.SetMeta none to write only parameter values:
+
+	dbget -m modelOne -do all-sets -dbget.SetMeta csv
+	dbget -m modelOne -do all-sets -dbget.SetMeta none
+
 Get parameter input set (a.k.a. input scenario or workset) values:
 
 	dbget -m modelOne -s Default -parameter-set ageSex
@@ -336,6 +751,14 @@ Get entity microdata:
 
 	dbget -dbget.ModelName modelOne -dbget.Do micro -dbget.Run "Microdata in database" -dbget.Entity Person
 
+Use -dbget.KeyFrom and -dbget.KeyTo to get only microdata rows where entity key is in that range, inclusive.
+It allows to split microdata output into chunks by entity key, e.g. to process each chunk in parallel:
+
+	dbget -m modelOne -r "Microdata in database" -micro Person -dbget.KeyFrom 0 -dbget.KeyTo 999999 -dbget.File Person.0.csv
+	dbget -m modelOne -r "Microdata in database" -micro Person -dbget.KeyFrom 1000000 -dbget.KeyTo 1999999 -dbget.File Person.1.csv
+
+Entity key range is applied to microdata output of micro, run and all-runs actions.
+
 # Compare or aggregate values for model run output tables
 
 Compare first and last RiskPaths model runs: calculate differnce of T04_FertilityRatesByAgeGroup.Expr0 values
@@ -467,6 +890,14 @@ Get model metadata from compatibility (Modgen) views:
 
 	dbget -dbget.ModelName modelOne -dbget.Do old-model -dbget.As csv -dbget.ToConsole -dbget.Language FR
 
+Old-model json output contains SchemaVersion, it is incremented if json structure changed.
+Use -dbget.Strict to sort all arrays of old-model json by all fields,
+so json output is the same for any copy of model database and it can be compared by diff tools.
+Use -dbget.Pretty=false to write compact json instead of default indented json, it is applied to any json output:
+
+	dbget -m modelOne -do old-model -json -dbget.Strict
+	dbget -m modelOne -do old-model -json -dbget.Strict -dbget.Pretty=false
+
 Get model run parameters and output tables values from compatibility (Modgen) views:
 
 	dbget -m modelOne -do old-run
@@ -507,15 +938,51 @@ Get output table values from compatibility (Modgen) views:
 	dbget -m modelOne -do old-table -dbget.Table salarySex -dbget.NoNullCsv
 
 	dbget -dbget.ModelName modelOne -dbget.Do old-table -dbget.Table ageSexIncome -dbget.As csv -dbget.ToConsole -dbget.Language FR
+
+Get all output tables values from compatibility (Modgen) views, one file for each output table:
+
+	dbget -m modelOne -do old-table
+	dbget -m modelOne -do old-table -dbget.Table "*"
+	dbget -m modelOne -do old-table -dbget.Table "*" -tsv -dir my/dir
+
+If output directory not specified then tables are written into modelName/old-table.runName directory, e.g.: modelOne/old-table.Default
+
+By default compatibility views use first model run.
+Use -dbget.Run, -dbget.RunId or -dbget.LastRun to select model run for old-run, old-parameter and old-table output:
+
+	dbget -m modelOne -do old-run -r Default-4
+	dbget -m modelOne -do old-parameter -dbget.Parameter ageSex -dbget.LastRun
+	dbget -m modelOne -do old-table -dbget.Table salarySex -dbget.RunId 101
+
+Get input scenario (workset) parameters as Modgen .dat file:
+
+	dbget -m modelOne -do old-set -s Default
+	dbget -m modelOne -do old-set -s Default -lang fr-CA
+	dbget -m modelOne -do old-set -s Default -dbget.NoLanguage
+	dbget -m modelOne -do old-set -s Default -dbget.ToConsole
+	dbget -m modelOne -do old-set -dbget.SetId 2 -dbget.File my.dat
+
+Output file name by default is: set.Default.dat
+Parameter declaration contains Modgen type name and dimension type names, for example: double ageSex[AGE_GROUP][SEX]
+Each parameter value block starts from dimension headers: list of items for each dimension,
+rows of multi-dimensional parameter preceded by comment with items of outer dimensions.
+Classification values are enum codes, range values are integers and partition values are zero-based interval index.
+Workset description and parameter description written as Modgen labels: //EN Age by Sex
+and workset notes and parameter value notes as Modgen NOTE(ageSex, EN) comment blocks.
+If workset parameter has multiple sub-values then only default sub-value is written.
+Parameter NULL values are not supported by Modgen .dat files.
 */
 package main
 
 import (
+	"database/sql"
 	"errors"
 	"flag"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jeandeaual/go-locale"
 	_ "github.com/mattn/go-sqlite3"
@@ -530,7 +997,7 @@ import (
 const (
 	cmdArgKey           = "dbget.Do"             // action, what to do, for example: model-list
 	cmdShortKey         = "do"                   // action, what to do (short form)
-	asArgKey            = "dbget.As"             // output as csv, tsv or json, default: .csv
+	asArgKey            = "dbget.As"             // output as csv, tsv, json or sql, default: .csv
 	csvArgKey           = "csv"                  // short form of: dbget.As csv
 	tsvArgKey           = "tsv"                  // short form of: dbget.As tsv
 	jsonArgKey          = "json"                 // short form of: dbget.As json
@@ -539,10 +1006,16 @@ const (
 	outputDirArgKey     = "dbget.Dir"            // output directory to write .csv or .tsv files
 	outputDirShortKey   = "dir"                  // output directory (short form)
 	keepOutputDirArgKey = "dbget.KeepOutputDir"  // keep output directory if it is already exist
+	noClobberArgKey     = "dbget.NoClobber"      // if true then fail if output file or directory already exist
+	backupArgKey        = "dbget.Backup"         // if true then rename existing output file or directory with timestamp suffix
 	consoleArgKey       = "dbget.ToConsole"      // if true then use stdout and do not create file(s)
 	consoleShortKey     = "pipe"                 // short form of: -dbget.ToConsole -OpenM.LogToConsole=false
 	langArgKey          = "dbget.Language"       // prefered output language: fr-CA
 	langShortKey        = "lang"                 // prefered output language (short form)
+	langListArgKey      = "dbget.Languages"      // list of languages to output words, default: all languages
+	langCodeArgKey      = "dbget.LangCode"       // language code to add, copy into or delete: DE
+	langNameArgKey      = "dbget.LangName"       // language name to add: Deutsch
+	langFromArgKey      = "dbget.LangFrom"       // source language code to copy translation strings: EN
 	noLangArgKey        = "dbget.NoLanguage"     // if true then do language-neutral output: enum codes and "C" formats
 	idCsvArgKey         = "dbget.IdCsv"          // if true then do language-neutral output: enum Ids and "C" formats
 	encodingArgKey      = "dbget.CodePage"       // code page for converting source files, e.g. windows-1252
@@ -550,7 +1023,25 @@ const (
 	noZeroArgKey        = "dbget.NoZeroCsv"      // if true then do not write zero values into output tables or microdata csv
 	noNullArgKey        = "dbget.NoNullCsv"      // if true then do not write NULL values into output tables or microdata csv
 	doubleFormatArgKey  = "dbget.DoubleFormat"   // convert to string format for float and double
+	useDecimalsArgKey   = "dbget.UseDecimals"    // if true then use output table expression decimals to format values
+	roundArgKey         = "dbget.Round"          // if >= 0 then round float and double values to that number of decimals
+	floatSpecialArgKey  = "dbget.FloatSpecial"   // special float values policy: keep, null, error, sentinel or sentinel:value
+	unknownEnumArgKey   = "dbget.OnUnknownEnum"  // unknown enum id policy: error, empty or id
+	labelMapArgKey      = "dbget.LabelMap"       // custom enum labels csv file: type,enum_code,new_label
+	queryWarnArgKey     = "dbget.QueryWarnTime"  // if positive then log database queries which take longer than that number of seconds
+	queryMaxArgKey      = "dbget.QueryMaxTime"   // if positive then cancel database queries which take longer than that number of seconds
+	sqlDialectArgKey    = "dbget.SqlDialect"     // sql output dialect: sqlite, postgres or mysql
+	sqlBatchArgKey      = "dbget.SqlBatchSize"   // number of rows in each sql INSERT statement
+	sqlCreateArgKey     = "dbget.SqlCreateTable" // if true then write CREATE TABLE statement before INSERT statements
+	maxRowsArgKey       = "dbget.MaxRowsPerFile" // if positive then split parameter, table and microdata output files into chunks of max rows
+	delimiterArgKey     = "dbget.Delimiter"      // csv values delimiter: single character or tab, default: comma for csv and tab for tsv
+	quoteArgKey         = "dbget.Quote"          // csv values quoting: always, minimal or none, default: minimal
+	eolArgKey           = "dbget.Eol"            // csv line endings: crlf or lf
+	nullValueArgKey     = "dbget.NullValue"      // csv token for NULL values, default: null
+	hdrCommentsArgKey   = "dbget.HeaderComments" // if true then write run metadata comment lines before csv header
+	commentArgKey       = "dbget.CommentChar"    // comment character of csv header comments, default: #
 	noteArgKey          = "dbget.Notes"          // if true then output notes into .md files
+	noteTreeArgKey      = "dbget.NotesTree"      // if true then output notes into notes/lang/kind/name.md files and index.json
 	sqliteArgKey        = "dbget.Sqlite"         // input db SQLite path
 	sqliteShortKey      = "db"                   // input db SQLite path (short form)
 	dbConnStrArgKey     = "dbget.Database"       // db connection string
@@ -558,15 +1049,19 @@ const (
 	modelNameArgKey     = "dbget.ModelName"      // model name
 	modelNameShortKey   = "m"                    // model name (short form)
 	modelDigestArgKey   = "dbget.ModelDigest"    // model hash digest
+	modelDirArgKey      = "dbget.ModelDir"       // directory tree to search for model SQLite database file
 	runArgKey           = "dbget.Run"            // model run digest, stamp or name
 	runShortKey         = "r"                    // model run digest, stamp or name (short form)
 	runIdArgKey         = "dbget.RunId"          // model run id
 	runFirstArgKey      = "dbget.FirstRun"       // use first model run
 	runLastArgKey       = "dbget.LastRun"        // use last model run
+	copyNameArgKey      = "dbget.CopyName"       // new run name of model run copy
 	withRunsArgKey      = "dbget.WithRuns"       // with model run digests, stamps or names (variant runs)
 	withRunIdsArgKey    = "dbget.WithRunIds"     // with list model run id's (variant runs)
 	withRunFirstArgKey  = "dbget.WithFirstRun"   // with first model run (with first run as variant)
 	withRunLastArgKey   = "dbget.WithLastRun"    // with last model run (with last run as variant)
+	runWhereArgKey      = "dbget.RunWhere"       // select runs by run option values: OpenM.SubValues=16,OpenM.Threads=4
+	diffOnlyArgKey      = "dbget.DiffOnly"       // if true then output only run options which are different between runs
 	wsArgKey            = "dbget.Set"            // model workset name
 	wsShortKey          = "s"                    // model workset name (short form)
 	wsIdArgKey          = "dbget.SetId"          // model workset id
@@ -586,29 +1081,62 @@ const (
 	aggrNameArgKey      = "dbget.AggrName"       // names of aggregation expression(s)
 	calcNameArgKey      = "dbget.CalcName"       // names of calculation expression(s)
 	microdataShortKey   = "micro"                // short form of: -dbget.Do micro -dbget.Entity Name
+	keepGoingArgKey     = "dbget.KeepGoing"      // if true then continue on output error and report failed outputs at the end
+	extendedArgKey      = "dbget.Extended"       // if true then model-list output include runs and worksets count and database file size
+	prettyArgKey        = "dbget.Pretty"         // if true then write indented json, default: true
+	strictArgKey        = "dbget.Strict"         // if true then sort all arrays of old-model json by all fields
+	verifyArgKey        = "dbget.Verify"         // if true then read back each csv or tsv output file and verify it
+	snapshotArgKey      = "dbget.Snapshot"       // if true then read from temporary consistent snapshot copy of SQLite database
+	waitLockedArgKey    = "dbget.WaitLocked"     // if positive then retry read operations for that number of seconds if database is locked
+	threadsArgKey       = "dbget.Threads"        // number of parallel threads to read output table values
+	layoutArgKey        = "dbget.Layout"         // all runs output directory layout: run, flat or table
+	runDirNameArgKey    = "dbget.RunDirName"     // model run directory or file name: name, digest, stamp or id
+	setMetaArgKey       = "dbget.SetMeta"        // all sets workset metadata file format: json, csv or none, default: json
+	sinceArgKey         = "dbget.Since"          // all runs watermark: output only runs completed after run digest or date-time
+	watermarkFileArgKey = "dbget.WatermarkFile"  // all runs watermark file: read watermark and write updated watermark at the end
+	marginArgKey        = "dbget.Margin"         // output table dimension(s) to compute total item on read
+	marginAggrArgKey    = "dbget.MarginAggr"     // output table dimension total item aggregation: sum or avg
+	keyFromArgKey       = "dbget.KeyFrom"        // microdata entity key range: first entity key, inclusive
+	keyToArgKey         = "dbget.KeyTo"          // microdata entity key range: last entity key, inclusive
+	batchArgKey         = "batch.Do"             // batch: list of actions or ini-file profiles to do using the same database connection
+	quietArgKey         = "dbget.Quiet"          // if true then console output only errors and warnings
+	quietShortKey       = "quiet"                // short form of: -dbget.Quiet
+	verboseArgKey       = "dbget.Verbose"        // if true then console output of all messages, including debug
+	verboseShortKey     = "verbose"              // short form of: -dbget.Verbose
+	statusFileArgKey    = "dbget.StatusFile"     // if not empty then write completion status and warnings into that json file
+	s3EndpointArgKey    = "dbget.S3Endpoint"     // object storage endpoint url, e.g. MinIO: http://localhost:9000, default: AWS S3
+	s3RegionArgKey      = "dbget.S3Region"       // object storage region, default: us-east-1
+	s3AccessArgKey      = "dbget.S3AccessKey"    // object storage access key id, default: AWS_ACCESS_KEY_ID environment variable
+	s3SecretArgKey      = "dbget.S3SecretKey"    // object storage secret access key, default: AWS_SECRET_ACCESS_KEY environment variable
+	s3PartSizeArgKey    = "dbget.S3PartSize"     // object storage multipart upload part size in MiB, default: 16
+	s3RetryArgKey       = "dbget.S3Retry"        // number of retries of object storage request on transient failure, default: 3
 	pidFileArgKey       = "dbget.PidSaveTo"
 )
 
-// output format: csv by default, or tsv or json
+// output format: csv by default, or tsv or json or sql INSERT statements
 type outputAs int
 
 const (
 	asCsv outputAs = iota
 	asTsv
 	asJson
+	asSql
 )
 
 // run options
 var theCfg = struct {
 	action          string   // action name (what to do)
-	kind            outputAs // output as csv, tsv or json
+	kind            outputAs // output as csv, tsv, json or sql
 	fileName        string   // output file name, default depends on action
 	dir             string   // output directory
 	isKeepOutputDir bool     // if true then keep existing output directory
+	isNoClobber     bool     // if true then fail if output file or directory already exist
+	backupStamp     string   // if not empty then rename existing output file or directory with that timestamp suffix
 	isConsole       bool     // if true then write into stdout
 	modelName       string   // model name
 	modelDigest     string   // model digest
 	doubleFmt       string   // format to convert float or double value to string
+	isUseDecimals   bool     // if true then use output table expression decimals to format values
 	userLang        string   // prefered output language: fr-CA
 	lang            string   // model language matched to user language
 	isNoLang        bool     // if true then do language-neutral output: enum codes and "C" formats
@@ -616,27 +1144,88 @@ var theCfg = struct {
 	encodingName    string   // "code page" to convert source file into utf-8, for example: windows-1252
 	isWriteUtf8Bom  bool     // if true then write utf-8 BOM into csv file
 	isNote          bool     // if true then output notes into .md files
+	isNoteTree      bool     // if true then output notes into notes/lang/kind/name.md files and index.json
+	isKeepGoing     bool     // if true then continue on output error and report failed outputs at the end
+	isExtended      bool     // if true then model-list output include runs and worksets count and database file size
+	isPretty        bool     // if true then write indented json
+	isStrict        bool     // if true then sort all arrays of old-model json by all fields
+	statusFile      string   // if not empty then write completion status and warnings into that json file
+	layout          string   // all runs output directory layout: run, flat or table
+	runDirName      string   // model run directory or file name: name, digest, stamp or id
+	sqlDialect      string   // sql output dialect: sqlite, postgres or mysql
+	sqlBatchSize    int      // number of rows in each sql INSERT statement
+	isSqlCreate     bool     // if true then write CREATE TABLE statement before INSERT statements
+	maxRowsPerFile  int64    // if positive then split parameter, table and microdata output files into chunks of max rows
+	waitLocked      int      // if positive then retry read operations for that number of seconds if database is locked
+	threadCount     int      // number of parallel threads to read output table values
+	isVerify        bool     // if true then read back each csv or tsv output file and verify it
+	csvDelimiter    rune     // csv values delimiter, default: comma for csv and tab for tsv
+	csvQuote        string   // csv values quoting: always, minimal or none
+	csvEol          string   // if not empty then csv line endings: crlf or lf
+	isCsvNull       bool     // if true then replace csv null values by NULL token
+	csvNullValue    string   // csv token for NULL values
+	isHdrComments   bool     // if true then write run metadata comment lines before csv header
+	commentChar     rune     // comment character of csv header comments
 }{
-	kind:           asCsv,   // by default output as as .csv
-	encodingName:   "",      // by default detect utf-8 encoding or use OS-specific default: windows-1252 on Windowds and utf-8 outside
-	isWriteUtf8Bom: false,   // do not write BOM by default
-	doubleFmt:      "%.15g", // default format to convert float or double values to string
+	kind:           asCsv,     // by default output as as .csv
+	encodingName:   "",        // by default detect utf-8 encoding or use OS-specific default: windows-1252 on Windowds and utf-8 outside
+	isWriteUtf8Bom: false,     // do not write BOM by default
+	doubleFmt:      "%.15g",   // default format to convert float or double values to string
+	isPretty:       true,      // by default write indented json
+	layout:         "run",     // by default use run directories: run.Name/parameters/ageSex.csv
+	runDirName:     "name",    // by default use run name, prefixed by run id if run name is not unique
+	sqlDialect:     "sqlite",  // by default sql output is for SQLite
+	sqlBatchSize:   100,       // by default insert 100 rows by each sql statement
+	csvDelimiter:   ',',       // by default csv values delimiter is comma
+	csvQuote:       "minimal", // by default quote csv values only if required
+	commentChar:    '#',       // by default csv header comment lines start with #
+	threadCount:    1,         // by default output table values read by single thread
 }
 
 const logPeriod = 5 // seconds, log periodically if output takes a long time
 
+const queryCacheSize = 1000 // max number of cached output table queries of source database connection
+
 // main entry point: wrapper to handle errors
 func main() {
 	defer exitOnPanic() // fatal error handler: log and exit
 
+	handleInterrupt() // on SIGINT or SIGTERM stop after current output file
+
+	tStart := time.Now()
 	err := mainBody(os.Args)
+	unknownEnumSummary() // if unknown enum id's converted into empty or id then log summary
+	if err == nil {
+		err = verifySummary() // wait for output files verification, if -dbget.Verify specified
+	}
+	if e := failedSummary(); err == nil {
+		err = e // if any output failed then it is a partial failure, always log failed outputs and keep first error
+	}
+	err = interruptSummary(err) // if interrupted then write list of completed outputs
+	err = uploadSummary(err)    // if output is object storage then upload output files
+	warningSummary()            // if any warnings then log summary at any log level
+	writeStatusFile(tStart, err)
 	if err != nil {
-		omppLog.Log(err.Error())
-		os.Exit(1)
+		omppLog.Error(err.Error())
+		os.Exit(exitCodeOf(err))
 	}
 	omppLog.Log("Done.") // compeleted OK
 }
 
+// log summary of unknown enum id's converted into empty code or id, if -dbget.OnUnknownEnum empty or id specified
+func unknownEnumSummary() {
+
+	ueLst := db.UnknownEnumSummary()
+	if len(ueLst) <= 0 {
+		return
+	}
+	logWarning("unknown-enum", "unknown enum id's found: ", len(ueLst))
+	for _, ue := range ueLst {
+		omppLog.Log("  ", ue.Name, ": ", ue.Count)
+		collectWarning("unknown-enum", ue.Name+": "+strconv.FormatInt(ue.Count, 10))
+	}
+}
+
 // actual main body
 func mainBody(args []string) error {
 
@@ -649,7 +1238,7 @@ func mainBody(args []string) error {
 	doEntityName := ""
 	_ = flag.String(cmdArgKey, "", "action, what to do, for example: model-list")
 	_ = flag.String(cmdShortKey, "", "action, what to do (short of "+cmdArgKey+")")
-	_ = flag.String(asArgKey, "", "output as .csv, .tsv or .json, default: .csv")
+	_ = flag.String(asArgKey, "", "output as .csv, .tsv, .json or .sql, default: .csv")
 	_ = flag.Bool(csvArgKey, true, "output as .csv (short of "+asArgKey+" csv)")
 	_ = flag.Bool(tsvArgKey, false, "output as .tsv (short of "+asArgKey+" tsv)")
 	_ = flag.Bool(jsonArgKey, false, "output as .json (short of "+asArgKey+" json)")
@@ -658,16 +1247,44 @@ func mainBody(args []string) error {
 	_ = flag.String(outputDirArgKey, theCfg.dir, "output directory for model .csv or .tsv files")
 	_ = flag.String(outputDirShortKey, theCfg.dir, "output directory (short of "+outputDirArgKey+")")
 	_ = flag.Bool(keepOutputDirArgKey, theCfg.isKeepOutputDir, "keep (do not delete) existing output directory")
+	_ = flag.Bool(noClobberArgKey, false, "if true then fail if output file or directory already exist")
+	_ = flag.Bool(backupArgKey, false, "if true then rename existing output file or directory with timestamp suffix")
 	_ = flag.Bool(consoleArgKey, theCfg.isConsole, "if true then write into standard output instead of file(s)")
 	flag.BoolVar(&isPipe, consoleShortKey, theCfg.isConsole, "short form of: -"+consoleArgKey+" -"+config.LogToConsoleArgKey+"=false")
 	_ = flag.String(langArgKey, theCfg.userLang, "prefered output language")
 	_ = flag.String(langShortKey, theCfg.userLang, "prefered output language (short of "+langArgKey+")")
+	_ = flag.String(langListArgKey, "", "comma separated list of languages to output words, default: all languages")
+	_ = flag.String(langCodeArgKey, "", "language code to add, copy into or delete")
+	_ = flag.String(langNameArgKey, "", "language name to add, default: language code")
+	_ = flag.String(langFromArgKey, "", "source language code to copy translation strings")
 	_ = flag.Bool(noLangArgKey, theCfg.isNoLang, "if true then do language-neutral output: enum codes and 'C' formats")
 	_ = flag.Bool(idCsvArgKey, theCfg.isIdCsv, "if true then do language-neutral output: enum id's and 'C' formats")
 	_ = flag.String(encodingArgKey, theCfg.encodingName, "code page to convert source file into utf-8, e.g.: windows-1252")
 	_ = flag.Bool(useUtf8ArgKey, theCfg.isWriteUtf8Bom, "if true then write utf-8 BOM into output")
 	_ = flag.Bool(noteArgKey, theCfg.isNote, "if true then write notes into .md files")
+	_ = flag.Bool(noteTreeArgKey, theCfg.isNoteTree, "if true then write notes into notes/lang/kind/name.md files and index.json")
 	_ = flag.String(doubleFormatArgKey, theCfg.doubleFmt, "convert to string format for float and double")
+	_ = flag.Bool(useDecimalsArgKey, false, "if true then use output table expression decimals to format values")
+	_ = flag.Int(roundArgKey, -1, "if >= 0 then round float and double values to that number of decimals")
+	_ = flag.String(floatSpecialArgKey, "", "special float values NaN, +Inf, -Inf policy: keep, null, error, sentinel or sentinel:value")
+	_ = flag.String(unknownEnumArgKey, "", "unknown enum id policy: error (default), empty or id")
+	_ = flag.String(labelMapArgKey, "", "custom enum labels csv file: type,enum_code,new_label")
+	_ = flag.Int(queryWarnArgKey, 0, "if positive then log database queries which take longer than that number of seconds")
+	_ = flag.Int(queryMaxArgKey, 0, "if positive then cancel database queries which take longer than that number of seconds")
+	_ = flag.String(sqlDialectArgKey, theCfg.sqlDialect, "sql output dialect: sqlite, postgres or mysql")
+	_ = flag.Int(sqlBatchArgKey, theCfg.sqlBatchSize, "number of rows in each sql INSERT statement")
+	_ = flag.Bool(sqlCreateArgKey, false, "if true then write CREATE TABLE statement before sql INSERT statements")
+	_ = flag.Int64(maxRowsArgKey, 0, "if positive then split parameter, table and microdata output files into chunks of max rows")
+	_ = flag.String(delimiterArgKey, "", "csv values delimiter: single character or tab, default: comma for csv and tab for tsv")
+	_ = flag.String(quoteArgKey, theCfg.csvQuote, "csv values quoting: always, minimal or none")
+	_ = flag.String(eolArgKey, "", "csv line endings: crlf or lf, default: lf for files and OS-specific for console")
+	_ = flag.String(nullValueArgKey, "", "csv token for NULL values, default: "+helper.CsvNull)
+	_ = flag.Bool(hdrCommentsArgKey, false, "if true then write run metadata comment lines before csv header")
+	_ = flag.String(commentArgKey, string(theCfg.commentChar), "comment character of csv header comments")
+	_ = flag.Bool(snapshotArgKey, false, "if true then read from temporary consistent snapshot copy of SQLite database")
+	_ = flag.Int(waitLockedArgKey, 0, "if positive then retry read operations for that number of seconds if database is locked")
+	_ = flag.Int(threadsArgKey, theCfg.threadCount, "number of parallel threads to read output table values")
+	_ = flag.Bool(verifyArgKey, false, "if true then read back each csv or tsv output file and verify row count and values")
 	_ = flag.Bool(noZeroArgKey, false, "if true then do not write zero values into output tables .csv files")
 	_ = flag.Bool(noNullArgKey, false, "if true then do not write NULL values into output tables .csv files")
 	_ = flag.String(sqliteArgKey, "", "input database SQLite file path")
@@ -677,15 +1294,19 @@ func mainBody(args []string) error {
 	_ = flag.String(modelNameArgKey, "", "model name")
 	_ = flag.String(modelNameShortKey, "", "model name (short of "+modelNameArgKey+")")
 	_ = flag.String(modelDigestArgKey, "", "model hash digest")
+	_ = flag.String(modelDirArgKey, "", "directory tree to search for model SQLite database file by model name or digest")
 	_ = flag.String(runArgKey, "", "model run digest, run stamp or run name")
 	_ = flag.String(runShortKey, "", "model run digest, run stamp or run name (short of "+runArgKey+")")
 	_ = flag.Int(runIdArgKey, 0, "model run id")
 	_ = flag.Bool(runFirstArgKey, false, "if true then use first model run")
 	_ = flag.Bool(runLastArgKey, false, "if true then use last model run")
+	_ = flag.String(copyNameArgKey, "", "new run name of model run copy, default: source run name")
 	_ = flag.String(withRunsArgKey, "", "with model run digests, stamps or names (variant runs)")
 	_ = flag.String(withRunIdsArgKey, "", "with list model run id's (variant runs)")
 	_ = flag.Bool(withRunFirstArgKey, false, "if true then use first model run (use as variant run)")
 	_ = flag.Bool(withRunLastArgKey, false, "if true then use last model run (use as variant run)")
+	_ = flag.String(runWhereArgKey, "", "select runs by run option values, e.g.: OpenM.SubValues=16,OpenM.Threads=4")
+	_ = flag.Bool(diffOnlyArgKey, false, "if true then output only run options which are different between runs")
 	_ = flag.String(wsArgKey, "", "input scenario (workset) name")
 	_ = flag.String(wsShortKey, "", "input scenario (workset) name (short of "+wsArgKey+")")
 	_ = flag.Int(wsIdArgKey, 0, "input scenario (workset) id")
@@ -706,6 +1327,31 @@ func mainBody(args []string) error {
 	_ = flag.String(aggrNameArgKey, "", "name list of aggregation expressions")
 	_ = flag.String(calcNameArgKey, "", "name list of calculation expressions")
 	_ = flag.String(pidFileArgKey, "", "file path to save dbget process ID")
+	_ = flag.Bool(quietArgKey, false, "if true then console output only errors and warnings")
+	_ = flag.Bool(quietShortKey, false, "if true then console output only errors and warnings (short of "+quietArgKey+")")
+	_ = flag.Bool(verboseArgKey, false, "if true then console output of all messages, including debug")
+	_ = flag.Bool(verboseShortKey, false, "if true then console output of all messages, including debug (short of "+verboseArgKey+")")
+	_ = flag.String(statusFileArgKey, "", "if not empty then write completion status and warnings into that json file")
+	_ = flag.String(s3EndpointArgKey, "", "object storage endpoint url, e.g. MinIO: http://localhost:9000, default: AWS S3")
+	_ = flag.String(s3RegionArgKey, "", "object storage region, default: us-east-1")
+	_ = flag.String(s3AccessArgKey, "", "object storage access key id, default: AWS_ACCESS_KEY_ID environment variable")
+	_ = flag.String(s3SecretArgKey, "", "object storage secret access key, default: AWS_SECRET_ACCESS_KEY environment variable")
+	_ = flag.Int(s3PartSizeArgKey, s3DefaultPartSize, "object storage multipart upload part size in MiB")
+	_ = flag.Int(s3RetryArgKey, s3DefaultRetry, "number of retries of object storage request on transient failure")
+	_ = flag.Bool(keepGoingArgKey, theCfg.isKeepGoing, "if true then continue on output error and report failed outputs at the end")
+	_ = flag.Bool(extendedArgKey, theCfg.isExtended, "if true then model-list output include runs and worksets count and database file size")
+	_ = flag.Bool(prettyArgKey, theCfg.isPretty, "if true then write indented json")
+	_ = flag.Bool(strictArgKey, theCfg.isStrict, "if true then sort all arrays of old-model json by all fields")
+	_ = flag.String(layoutArgKey, theCfg.layout, "all runs output directory layout: run, flat or table")
+	_ = flag.String(runDirNameArgKey, theCfg.runDirName, "model run directory or file name: name, digest, stamp or id")
+	_ = flag.String(setMetaArgKey, "json", "all sets workset metadata file format: json, csv or none")
+	_ = flag.String(sinceArgKey, "", "all runs watermark: output only runs completed after run digest or date-time")
+	_ = flag.String(watermarkFileArgKey, "", "all runs watermark file, default: ModelName.watermark.txt")
+	_ = flag.String(marginArgKey, "", "list of output table dimensions to compute total item on read")
+	_ = flag.String(marginAggrArgKey, "", "output table dimension total item aggregation: sum (default) or avg")
+	_ = flag.String(keyFromArgKey, "", "microdata entity key range: output only rows where entity key >= KeyFrom")
+	_ = flag.String(keyToArgKey, "", "microdata entity key range: output only rows where entity key <= KeyTo")
+	_ = flag.String(batchArgKey, "", "batch: comma separated list of actions or ini-file profiles to do using the same database connection")
 
 	// pairs of full and short argument names to map short name to full name
 	var optFs = []config.FullShort{
@@ -726,18 +1372,33 @@ func mainBody(args []string) error {
 		{Full: entityArgKey, Short: microdataShortKey},
 		{Full: aggrArgKey, Short: aggrShortKey},
 		{Full: calcArgKey, Short: calcShortKey},
+		{Full: quietArgKey, Short: quietShortKey},
+		{Full: verboseArgKey, Short: verboseShortKey},
 	}
 
 	// parse command line arguments and ini-file
-	runOpts, logOpts, err := config.New(encodingArgKey, false, optFs)
+	runOpts, logOpts, err := config.NewWithProfile(encodingArgKey, cmdArgKey, false, optFs)
 	if err != nil {
-		return errors.New("invalid arguments: " + err.Error())
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+err.Error()))
 	}
 	if isPipe {
 		logOpts.IsConsole = false // suppress log console output if -pipe required
 	}
 	omppLog.New(logOpts) // adjust log options according to command line arguments or ini-values
 
+	// log level: quiet, normal or verbose
+	theCfg.statusFile = runOpts.String(statusFileArgKey)
+
+	if runOpts.Bool(quietArgKey) && runOpts.Bool(verboseArgKey) {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+quietArgKey+" cannot be used with: "+verboseArgKey))
+	}
+	if runOpts.Bool(quietArgKey) {
+		omppLog.SetLevel(omppLog.QuietLevel)
+	}
+	if runOpts.Bool(verboseArgKey) {
+		omppLog.SetLevel(omppLog.VerboseLevel)
+	}
+
 	if pidFile := runOpts.String(pidFileArgKey); pidFile != "" {
 		pid := os.Getpid()
 		if err = os.WriteFile(pidFile, []byte(strconv.Itoa(pid)), 0644); err != nil {
@@ -751,26 +1412,136 @@ func mainBody(args []string) error {
 	theCfg.action = runOpts.String(cmdArgKey)
 	theCfg.fileName = helper.CleanFileName(runOpts.String(outputFileArgKey))
 	theCfg.dir = helper.CleanFilePath(runOpts.String(outputDirArgKey))
+
+	// output into object storage: write into local staging directory and upload at the end
+	if d := runOpts.String(outputDirArgKey); isS3Url(d) {
+
+		be, err := newS3Backend(d, runOpts)
+		if err != nil {
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+outputDirArgKey+" "+d+": "+err.Error()))
+		}
+		if runOpts.Bool(noClobberArgKey) || runOpts.Bool(backupArgKey) {
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+noClobberArgKey+" or "+backupArgKey+" cannot be used with object storage output: "+d))
+		}
+		if theUpload.stageDir, err = os.MkdirTemp("", "dbget-output-"); err != nil {
+			return withExitCode(exitIo, errors.New("Error at creating output staging directory: "+err.Error()))
+		}
+		theUpload.output = be
+		theCfg.dir = theUpload.stageDir
+		omppLog.Log("Output into: ", be.dest(), " staging directory: ", theUpload.stageDir)
+	}
 	theCfg.isKeepOutputDir = runOpts.Bool(keepOutputDirArgKey)
+	theCfg.isNoClobber = runOpts.Bool(noClobberArgKey)
+	if runOpts.Bool(backupArgKey) {
+		theCfg.backupStamp = helper.MakeTimeStamp(time.Now())
+	}
 	theCfg.isConsole = runOpts.Bool(consoleArgKey)
 	theCfg.userLang = runOpts.String(langArgKey)
 	theCfg.isNoLang = runOpts.Bool(noLangArgKey)
 	theCfg.isIdCsv = runOpts.Bool(idCsvArgKey)
 	theCfg.encodingName = runOpts.String(encodingArgKey)
 	theCfg.isWriteUtf8Bom = runOpts.Bool(useUtf8ArgKey)
-	theCfg.isNote = runOpts.Bool(noteArgKey)
+	theCfg.isNoteTree = runOpts.Bool(noteTreeArgKey)
+	theCfg.isNote = runOpts.Bool(noteArgKey) || theCfg.isNoteTree
 	theCfg.doubleFmt = runOpts.String(doubleFormatArgKey)
+	theCfg.isUseDecimals = runOpts.Bool(useDecimalsArgKey)
+	if nr := runOpts.Int(roundArgKey, -1); nr >= 0 {
+		theCfg.doubleFmt = "%." + strconv.Itoa(nr) + "f" // uniform rounding of all values
+		theCfg.isUseDecimals = false
+	}
+	fs, err := db.ParseFloatSpecial(runOpts.String(floatSpecialArgKey))
+	if err != nil {
+		return withExitCode(exitConfig, err)
+	}
+	db.SetFloatSpecial(fs)
+	ue, err := db.ParseUnknownEnum(runOpts.String(unknownEnumArgKey))
+	if err != nil {
+		return withExitCode(exitConfig, err)
+	}
+	db.SetUnknownEnum(ue)
+	if lmPath := runOpts.String(labelMapArgKey); lmPath != "" {
+		lmLst, err := readLabelMap(lmPath)
+		if err != nil {
+			return err
+		}
+		db.SetLabelMap(lmLst)
+		omppLog.Log("Custom enum labels: ", len(lmLst), " from: ", lmPath)
+	}
+	db.SetQueryWatchdog(db.QueryWatchdog{
+		WarnAfter:   time.Duration(runOpts.Int(queryWarnArgKey, 0)) * time.Second,
+		CancelAfter: time.Duration(runOpts.Int(queryMaxArgKey, 0)) * time.Second,
+	})
+	theCfg.isKeepGoing = runOpts.Bool(keepGoingArgKey)
+	theCfg.isExtended = runOpts.Bool(extendedArgKey)
+	theCfg.isPretty = !runOpts.IsExist(prettyArgKey) || runOpts.Bool(prettyArgKey)
+	theCfg.isStrict = runOpts.Bool(strictArgKey)
+	theCfg.layout = strings.ToLower(runOpts.String(layoutArgKey))
+	theCfg.runDirName = strings.ToLower(runOpts.String(runDirNameArgKey))
+	theCfg.sqlDialect = strings.ToLower(runOpts.String(sqlDialectArgKey))
+	theCfg.sqlBatchSize = runOpts.Int(sqlBatchArgKey, theCfg.sqlBatchSize)
+	theCfg.isSqlCreate = runOpts.Bool(sqlCreateArgKey)
+	theCfg.maxRowsPerFile = runOpts.Int64(maxRowsArgKey, 0)
+	theCfg.waitLocked = runOpts.Int(waitLockedArgKey, 0)
+	theCfg.threadCount = runOpts.Int(threadsArgKey, theCfg.threadCount)
+	theCfg.isVerify = runOpts.Bool(verifyArgKey)
+
+	// batch mode: multiple actions, each action can have its own options from ini-file profile
+	steps, err := batchSteps(runOpts)
+	if err != nil {
+		return withExitCode(exitConfig, err)
+	}
+	if len(steps) > 0 && (doParamName != "" || doParamWsName != "" || doTableName != "" || doAccTableName != "" || doAllAccTableName != "" || doEntityName != "") {
+		return withExitCode(exitConfig, errors.New("invalid arguments: short form of action cannot be used in batch mode"))
+	}
+	actLst := []string{theCfg.action}
+	if len(steps) > 0 {
+		actLst = make([]string, len(steps))
+		for k := range steps {
+			actLst[k] = steps[k].action
+		}
+	}
 
 	// validate language options: user specified language cannot be combined with NoLanguage or IdCsv option
 	if theCfg.userLang != "" && (theCfg.isNoLang || theCfg.isIdCsv) {
-		return errors.New("invalid arguments: " + langArgKey + " cannot be combined with " + noLangArgKey + " or " + idCsvArgKey)
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+langArgKey+" cannot be combined with "+noLangArgKey+" or "+idCsvArgKey))
 	}
 
-	// get output format: cv, tsv or json
+	// validate overwrite options: fail if output exists or backup existing output
+	if theCfg.isNoClobber && theCfg.backupStamp != "" {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+noClobberArgKey+" cannot be combined with "+backupArgKey))
+	}
+
+	// validate database locked retry time, action restarted on retry and overwrite its own output files
+	if theCfg.waitLocked < 0 {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+waitLockedArgKey+" "+runOpts.String(waitLockedArgKey)+", it must be positive"))
+	}
+	if theCfg.waitLocked > 0 && (theCfg.isNoClobber || theCfg.backupStamp != "") {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+waitLockedArgKey+" cannot be combined with "+noClobberArgKey+" or "+backupArgKey))
+	}
+
+	// validate number of threads to read output table values
+	if theCfg.threadCount <= 0 {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+threadsArgKey+" "+runOpts.String(threadsArgKey)+", it must be positive"))
+	}
+
+	// validate sql output options
+	switch theCfg.sqlDialect {
+	case "sqlite", "postgres", "mysql":
+	default:
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+sqlDialectArgKey+" "+theCfg.sqlDialect+", expected: sqlite, postgres or mysql"))
+	}
+	if theCfg.sqlBatchSize <= 0 {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+sqlBatchArgKey+" "+strconv.Itoa(theCfg.sqlBatchSize)+", it must be positive"))
+	}
+	if theCfg.maxRowsPerFile < 0 {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+maxRowsArgKey+" "+strconv.FormatInt(theCfg.maxRowsPerFile, 10)+", it must be positive"))
+	}
+
+	// get output format: cv, tsv, json or sql
 	if f := runOpts.String(asArgKey); f != "" {
 
 		if runOpts.IsExist(csvArgKey) || runOpts.IsExist(tsvArgKey) || runOpts.IsExist(jsonArgKey) {
-			return errors.New("invalid arguments: " + csvArgKey + " or " + tsvArgKey + " or " + jsonArgKey)
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+csvArgKey+" or "+tsvArgKey+" or "+jsonArgKey))
 		}
 		switch strings.ToLower(f) {
 		case "csv":
@@ -779,14 +1550,16 @@ func mainBody(args []string) error {
 			theCfg.kind = asTsv
 		case "json":
 			theCfg.kind = asJson
+		case "sql":
+			theCfg.kind = asSql
 		default:
-			return errors.New("invalid arguments: " + asArgKey + " " + f)
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+asArgKey+" "+f))
 		}
 	} else {
 		if runOpts.IsExist(csvArgKey) && (runOpts.IsExist(tsvArgKey) || runOpts.IsExist(jsonArgKey)) ||
 			runOpts.IsExist(tsvArgKey) && (runOpts.IsExist(csvArgKey) || runOpts.IsExist(jsonArgKey)) ||
 			runOpts.IsExist(jsonArgKey) && (runOpts.IsExist(csvArgKey) || runOpts.IsExist(tsvArgKey)) {
-			return errors.New("invalid arguments: " + csvArgKey + " or " + tsvArgKey + " or " + jsonArgKey)
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+csvArgKey+" or "+tsvArgKey+" or "+jsonArgKey))
 		}
 		switch {
 		case runOpts.IsExist(csvArgKey) && runOpts.Bool(csvArgKey):
@@ -801,53 +1574,136 @@ func mainBody(args []string) error {
 			// if file name is empty or extension is unknown then result is csv by default
 			theCfg.kind = kindByExt(theCfg.fileName)
 		default:
-			return errors.New("invalid arguments: " + csvArgKey + " or " + tsvArgKey + " or " + jsonArgKey)
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+csvArgKey+" or "+tsvArgKey+" or "+jsonArgKey))
 		}
 	}
 
-	// output to json supported only for model metadata
-	if theCfg.kind == asJson {
-		if theCfg.action != "model-list" &&
-			theCfg.action != "model" && theCfg.action != "old-model" &&
-			theCfg.action != "run-list" && theCfg.action != "set-list" {
-			return errors.New("JSON output not allowed for: " + theCfg.action)
-		}
+	// validate action specific options, in batch mode validate options of each step
+	if len(steps) <= 0 {
+		err = checkActionOptions(theCfg.action, runOpts)
+	}
+	for k := 0; err == nil && k < len(steps); k++ {
+		err = checkActionOptions(steps[k].action, steps[k].opts)
+	}
+	if err != nil {
+		return withExitCode(exitConfig, err)
+	}
+
+	// csv dialect options: delimiter, quoting, line endings and NULL value token
+	if theCfg.kind != asCsv && theCfg.kind != asTsv &&
+		(runOpts.IsExist(delimiterArgKey) || runOpts.IsExist(quoteArgKey) || runOpts.IsExist(eolArgKey) || runOpts.IsExist(nullValueArgKey)) {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+delimiterArgKey+", "+quoteArgKey+", "+eolArgKey+" or "+nullValueArgKey+" can be used only with csv or tsv output"))
+	}
+	if theCfg.csvDelimiter, err = parseCsvDelimiter(runOpts.String(delimiterArgKey), theCfg.kind == asTsv); err != nil {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+delimiterArgKey+": "+err.Error()))
 	}
 
+	theCfg.csvQuote = strings.ToLower(runOpts.String(quoteArgKey))
+	switch theCfg.csvQuote {
+	case "always", "minimal", "none":
+	default:
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+quoteArgKey+" "+theCfg.csvQuote+", expected: always, minimal or none"))
+	}
+
+	theCfg.csvEol = strings.ToLower(runOpts.String(eolArgKey))
+	switch theCfg.csvEol {
+	case "", "crlf", "lf":
+	default:
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+eolArgKey+" "+theCfg.csvEol+", expected: crlf or lf"))
+	}
+
+	theCfg.isCsvNull = runOpts.IsExist(nullValueArgKey)
+	theCfg.csvNullValue = runOpts.String(nullValueArgKey)
+
+	theCfg.isHdrComments = runOpts.Bool(hdrCommentsArgKey)
+	if theCfg.isHdrComments && theCfg.kind != asCsv && theCfg.kind != asTsv {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+hdrCommentsArgKey+" can be used only with csv or tsv output"))
+	}
+	cc := []rune(runOpts.String(commentArgKey))
+	if len(cc) != 1 || cc[0] == '"' || cc[0] == '\r' || cc[0] == '\n' || cc[0] == theCfg.csvDelimiter {
+		return withExitCode(exitConfig, errors.New("invalid arguments: "+commentArgKey+" "+string(cc)+", expected single character which is not a delimiter"))
+	}
+	theCfg.commentChar = cc[0]
+
 	// get default user language
 	if !theCfg.isNoLang && theCfg.userLang == "" {
 		if ln, e := locale.GetLocale(); e == nil {
 			theCfg.userLang = ln
 		} else {
-			omppLog.Log("Warning: unable to get user default language")
+			logWarning("language", "unable to get user default language")
 		}
 	}
 
+	// if model directory specified then search for model SQLite database file by model name or digest
+	sqlitePath := runOpts.String(sqliteArgKey)
+
+	if md := runOpts.String(modelDirArgKey); md != "" {
+
+		if sqlitePath != "" || runOpts.String(dbConnStrArgKey) != "" {
+			return withExitCode(exitConfig, errors.New("invalid arguments: "+modelDirArgKey+" cannot be combined with "+sqliteArgKey+" or "+dbConnStrArgKey))
+		}
+		if sqlitePath, err = findModelSqlite(md, runOpts.String(modelNameArgKey), runOpts.String(modelDigestArgKey)); err != nil {
+			return err
+		}
+	}
+
+	// model list from multiple databases: SQLite path is a directory or glob pattern
+	if len(steps) > 0 && isSqliteDirOrGlob(sqlitePath) {
+		return withExitCode(exitConfig, errors.New("invalid arguments: SQLite directory or glob pattern cannot be used in batch mode: "+sqlitePath))
+	}
+	if theCfg.action == "model-list" && isSqliteDirOrGlob(sqlitePath) {
+
+		pathLst, err := sqliteFilesByDirOrGlob(sqlitePath)
+		if err != nil {
+			return err
+		}
+		if len(pathLst) <= 0 {
+			return withExitCode(exitIo, errors.New("SQLite database files not found: "+sqlitePath))
+		}
+		if err := makeOutputDir(theCfg.dir, theCfg.isKeepOutputDir); err != nil {
+			return err
+		}
+		return modelListFiles(pathLst)
+	}
+
 	// open source database connection and check is it valid
-	cs, dn := db.IfEmptyMakeDefaultReadOnly(runOpts.String(modelNameArgKey), runOpts.String(sqliteArgKey), runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
+	// database is read-only except of run-copy, table-recalc, import-words and language actions
+	cs, dn := db.IfEmptyMakeDefaultReadOnly(runOpts.String(modelNameArgKey), sqlitePath, runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
+	if slices.ContainsFunc(actLst, isUpdateAction) {
+		cs, dn = db.IfEmptyMakeDefault(runOpts.String(modelNameArgKey), sqlitePath, runOpts.String(dbConnStrArgKey), runOpts.String(dbDriverArgKey))
+	}
+
+	// if required then open consistent snapshot copy of SQLite database, snapshot file deleted at exit
+	isSnapshot := runOpts.Bool(snapshotArgKey)
 
-	srcDb, _, err := db.Open(cs, dn, false)
+	srcDb, closeSrc, err := openSrcDb(cs, dn, isSnapshot)
 	if err != nil {
-		return err
+		return withExitCode(exitIo, err)
 	}
-	defer srcDb.Close()
+	defer closeSrc()
+
+	// output table select queries are the same for each model run: prepare it once and reuse for all runs
+	db.SetQueryCache(srcDb, queryCacheSize)
+	defer db.SetQueryCache(srcDb, 0)
 
-	if err := db.CheckOpenmppSchemaVersion(srcDb); err != nil {
-		srcDb.Close()
+	if err := db.RetryIfLocked(time.Duration(theCfg.waitLocked)*time.Second, func() error { return db.CheckOpenmppSchemaVersion(srcDb) }); err != nil {
+		db.Close(srcDb)
 		return err
 	}
 
-	// if it is not a model-list then
+	// if it is not a model-list or db-usage or db-schema or language action then
 	//   find by model name or digest
 	//   match model language to user language
 	modelId := 0
-	if theCfg.action != "model-list" {
+	if slices.ContainsFunc(actLst, func(a string) bool {
+		return a != "model-list" && a != "db-usage" && a != "db-schema" && !isLangAction(a)
+	}) {
 
 		theCfg.modelName = runOpts.String(modelNameArgKey)
 		theCfg.modelDigest = runOpts.String(modelDigestArgKey)
 
 		if theCfg.modelName == "" && theCfg.modelDigest == "" {
-			return errors.New("invalid (empty) model name and model digest")
+			return withExitCode(exitConfig, errors.New("invalid (empty) model name and model digest"))
 		}
 		omppLog.Log("Model ", theCfg.modelName, " ", theCfg.modelDigest)
 
@@ -857,14 +1713,26 @@ func mainBody(args []string) error {
 			return err
 		}
 		if !ok {
-			return errors.New("model " + theCfg.modelName + " " + theCfg.modelDigest + " not found")
+			return withExitCode(exitModelNotFound, errors.New("model "+theCfg.modelName+" "+theCfg.modelDigest+" not found"))
 		}
 		mdRow, err := db.GetModelRow(srcDb, modelId)
 		if err != nil {
 			return err
 		}
 		if mdRow == nil {
-			return errors.New("model not found by Id: " + strconv.Itoa(modelId))
+			return withExitCode(exitModelNotFound, errors.New("model not found by Id: "+strconv.Itoa(modelId)))
+		}
+		setHeaderModel(mdRow.Name, mdRow.Digest)
+
+		// validate custom enum labels: all types and enums must exist in the model
+		if runOpts.String(labelMapArgKey) != "" {
+			meta, err := db.GetModelById(srcDb, modelId)
+			if err != nil {
+				return errors.New("Error at get model metadata by id: " + strconv.Itoa(modelId) + ": " + err.Error())
+			}
+			if err = db.CheckLabelMap(meta); err != nil {
+				return withExitCode(exitConfig, errors.New("Error: invalid label map: "+err.Error()))
+			}
 		}
 
 		// match user language to model language, use default model language if there are no match
@@ -875,7 +1743,7 @@ func mainBody(args []string) error {
 					return err
 				}
 				if theCfg.lang == "" {
-					omppLog.Log("Warning: unable to match user language: ", theCfg.userLang)
+					logWarning("language", "unable to match user language: ", theCfg.userLang)
 				}
 			}
 			if theCfg.lang != "" {
@@ -888,60 +1756,192 @@ func mainBody(args []string) error {
 	}
 
 	// remove output directory if required, create output directory if not already exists
-	if err := makeOutputDir(theCfg.dir, theCfg.isKeepOutputDir); err != nil {
-		return err
+	// for import-words it is input directory, language actions and table-recalc do not write any output
+	if theCfg.action != "import-words" && theCfg.action != "table-recalc" && !isLangAction(theCfg.action) {
+		if err := makeOutputDir(theCfg.dir, theCfg.isKeepOutputDir); err != nil {
+			return err
+		}
 	}
 
 	if doParamName != "" {
 		if runOpts.IsExist(cmdArgKey) && theCfg.action != "parameter" {
-			return errors.New("invalid action argument: " + theCfg.action)
+			return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 		}
 		theCfg.action = "parameter"
 	}
 	if doParamWsName != "" {
 		if runOpts.IsExist(cmdArgKey) && theCfg.action != "parameter-set" {
-			return errors.New("invalid action argument: " + theCfg.action)
+			return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 		}
 		theCfg.action = "parameter-set"
 	}
 	if doTableName != "" {
 		if runOpts.IsExist(cmdArgKey) && theCfg.action != "table" {
-			return errors.New("invalid action argument: " + theCfg.action)
+			return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 		}
 		theCfg.action = "table"
 	}
 	if doAccTableName != "" {
 		if runOpts.IsExist(cmdArgKey) && theCfg.action != "sub-table" {
-			return errors.New("invalid action argument: " + theCfg.action)
+			return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 		}
 		theCfg.action = "sub-table"
 	}
 	if doAllAccTableName != "" {
 		if runOpts.IsExist(cmdArgKey) && theCfg.action != "sub-table-all" {
-			return errors.New("invalid action argument: " + theCfg.action)
+			return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 		}
 		theCfg.action = "sub-table-all"
 	}
 	if doEntityName != "" {
 		if runOpts.IsExist(cmdArgKey) && theCfg.action != "micro" {
-			return errors.New("invalid action argument: " + theCfg.action)
+			return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 		}
 		theCfg.action = "micro"
 	}
 
+	if len(steps) > 0 {
+		err = runBatch(srcDb, modelId, sqlitePath, steps)
+	} else {
+		err = doActionRetry(srcDb, modelId, sqlitePath, runOpts)
+	}
+	if err != nil {
+		return err
+	}
+	return writeNoteIndex() // if notes tree output then write notes index.json
+}
+
+// validate action specific options: output format, all runs options and snapshot option
+func checkActionOptions(action string, runOpts *config.RunOptions) error {
+
+	// validate all runs output layout and run directory name options
+	layout := strings.ToLower(runOpts.String(layoutArgKey))
+	switch layout {
+	case "run", "flat", "table":
+	default:
+		return errors.New("invalid arguments: " + layoutArgKey + " " + layout + ", expected: run, flat or table")
+	}
+	if runOpts.IsExist(layoutArgKey) && action != "all-runs" {
+		return errors.New("invalid arguments: " + layoutArgKey + " can be used only with all-runs")
+	}
+	if (runOpts.IsExist(sinceArgKey) || runOpts.IsExist(watermarkFileArgKey)) && action != "all-runs" {
+		return errors.New("invalid arguments: " + sinceArgKey + " and " + watermarkFileArgKey + " can be used only with all-runs")
+	}
+	if runOpts.IsExist(runWhereArgKey) && action != "run-list" && action != "all-runs" && action != "table-compare" && action != "micro-compare" {
+		return errors.New("invalid arguments: " + runWhereArgKey + " can be used only with run-list, all-runs, table-compare and micro-compare")
+	}
+	dirName := strings.ToLower(runOpts.String(runDirNameArgKey))
+	switch dirName {
+	case "name", "digest", "stamp", "id":
+	default:
+		return errors.New("invalid arguments: " + runDirNameArgKey + " " + dirName + ", expected: name, digest, stamp or id")
+	}
+	setMeta := strings.ToLower(runOpts.String(setMetaArgKey))
+	switch setMeta {
+	case "json", "csv", "none":
+	default:
+		return errors.New("invalid arguments: " + setMetaArgKey + " " + setMeta + ", expected: json, csv or none")
+	}
+	if runOpts.IsExist(setMetaArgKey) && action != "all-sets" {
+		return errors.New("invalid arguments: " + setMetaArgKey + " can be used only with all-sets")
+	}
+
+	// output to json supported only for model metadata
+	if theCfg.kind == asJson {
+		if action != "model-list" && action != "db-usage" && action != "db-schema" &&
+			action != "model" && action != "old-model" &&
+			action != "run-list" && action != "run-progress" && action != "set-list" &&
+			action != "model-words" && action != "import-words" {
+			return errors.New("JSON output not allowed for: " + action)
+		}
+	}
+
+	// sql output is not supported for words import, language actions, model documentation, run copy, table recalculation and .dat output
+	if theCfg.kind == asSql && (action == "import-words" || isLangAction(action) || action == "model-doc" || action == "run-copy" || action == "table-recalc" || action == "old-set") {
+		return errors.New("SQL output not allowed for: " + action)
+	}
+
+	// chunked output files are csv or tsv files
+	if theCfg.maxRowsPerFile > 0 && (theCfg.kind == asSql || theCfg.kind == asJson) {
+		return errors.New("invalid arguments: " + maxRowsArgKey + " can be used only with csv or tsv output")
+	}
+
+	// validate microdata entity key range
+	if _, err := microKeyRange(runOpts); err != nil {
+		return err
+	}
+
+	// snapshot copy of SQLite database is read-only
+	if runOpts.Bool(snapshotArgKey) && isUpdateAction(action) {
+		return errors.New("invalid arguments: " + snapshotArgKey + " cannot be used with: " + action)
+	}
+	return nil
+}
+
+// return true if action is updating database: run-copy, table-recalc, import-words or language actions
+func isUpdateAction(action string) bool {
+	return action == "run-copy" || action == "table-recalc" || action == "import-words" || isLangAction(action)
+}
+
+// return true if it is a language action: lang-add, lang-copy or lang-delete
+func isLangAction(action string) bool {
+	return action == "lang-add" || action == "lang-copy" || action == "lang-delete"
+}
+
+// do dbget action and retry it while database is locked, if -dbget.WaitLocked specified.
+// Action restarted from the beginning on retry, update actions are not retried.
+func doActionRetry(srcDb *sql.DB, modelId int, sqlitePath string, runOpts *config.RunOptions) error {
+
+	if isUpdateAction(theCfg.action) {
+		return doAction(srcDb, modelId, sqlitePath, runOpts)
+	}
+	return db.RetryIfLocked(time.Duration(theCfg.waitLocked)*time.Second, func() error {
+		return doAction(srcDb, modelId, sqlitePath, runOpts)
+	})
+}
+
+// do dbget action using source database connection and model id
+func doAction(srcDb *sql.DB, modelId int, sqlitePath string, runOpts *config.RunOptions) error {
+
+	omppLog.Debug("Do ", theCfg.action, ", model id: ", modelId, ", output directory: ", theCfg.dir, ", file: ", theCfg.fileName)
+
 	switch theCfg.action {
 	case "model-list":
-		return modelList(srcDb)
+		return modelList(srcDb, sqlitePath)
+	case "db-usage":
+		return dbUsage(srcDb)
+	case "db-schema":
+		return dbSchema(srcDb)
 	case "run-list":
 		return runList(srcDb, modelId, runOpts)
+	case "run-options":
+		return runOptions(srcDb, modelId, runOpts)
+	case "run-progress":
+		return runProgress(srcDb, modelId, runOpts)
 	case "set-list":
 		return setList(srcDb, modelId, runOpts)
 	case "model":
 		return modelMeta(srcDb, modelId)
+	case "model-doc":
+		return modelDoc(srcDb, modelId)
+	case "model-words":
+		return modelWords(srcDb, modelId, runOpts)
+	case "import-words":
+		return importWords(srcDb, modelId)
+	case "lang-add":
+		return langAdd(srcDb, runOpts)
+	case "lang-copy":
+		return langCopy(srcDb, runOpts)
+	case "lang-delete":
+		return langDelete(srcDb, runOpts)
 	case "run":
 		return runValue(srcDb, modelId, runOpts)
 	case "all-runs":
 		return runAllValue(srcDb, modelId, runOpts)
+	case "run-copy":
+		return runCopy(srcDb, modelId, runOpts)
+	case "table-recalc":
+		return tableRecalc(srcDb, modelId, runOpts)
 	case "all-sets":
 		return setAllValue(srcDb, modelId, runOpts)
 	case "set":
@@ -970,8 +1970,10 @@ func mainBody(args []string) error {
 		return parameterOldValue(srcDb, modelId, runOpts)
 	case "old-table":
 		return tableOldValue(srcDb, modelId, runOpts)
+	case "old-set":
+		return setOldValue(srcDb, modelId, runOpts)
 	}
-	return errors.New("invalid action argument: " + theCfg.action)
+	return withExitCode(exitConfig, errors.New("invalid action argument: "+theCfg.action))
 }
 
 // exitOnPanic log error message and exit with return = 2
@@ -988,5 +1990,5 @@ func exitOnPanic() {
 	default:
 		omppLog.Log("FAILED")
 	}
-	os.Exit(2) // final exit
+	os.Exit(exitPanic) // final exit
 }
@@ -0,0 +1,97 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"strconv"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// dbget exit codes
+const (
+	exitOk            = 0 // completed successfully
+	exitError         = 1 // error: generic error
+	exitPanic         = 2 // fatal error: panic
+	exitConfig        = 3 // invalid command line arguments or ini-file options
+	exitModelNotFound = 4 // model not found in database
+	exitRunNotFound   = 5 // model run or input scenario (workset) not found
+	exitPartial       = 6 // partial failure: some output failed, see summary in the log
+	exitIo            = 7 // input or output error, e.g.: unable to open database or create output file
+	exitInterrupt     = 8 // interrupted by SIGINT or SIGTERM, see list of completed outputs in dbget.manifest.txt
+)
+
+// error with dbget exit code
+type exitCodeError struct {
+	code int   // exit code
+	err  error // source error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// return error with exit code, return nil if source error is nil
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+// return exit code by error: exit code of error, exit code by kind of db error,
+// exitIo if it is file path error or generic exitError
+func exitCodeOf(err error) int {
+	if err == nil {
+		return exitOk
+	}
+	var ec *exitCodeError
+	if errors.As(err, &ec) {
+		return ec.code
+	}
+	switch {
+	case errors.Is(err, db.ErrModelNotFound):
+		return exitModelNotFound
+	case errors.Is(err, db.ErrRunNotFound) || errors.Is(err, db.ErrWorksetNotFound):
+		return exitRunNotFound
+	case errors.Is(err, db.ErrNotOmppSchema) || errors.Is(err, db.ErrSchemaVersion) || errors.Is(err, db.ErrLocked):
+		return exitIo
+	}
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		return exitIo
+	}
+	return exitError
+}
+
+// list of failed outputs if -dbget.KeepGoing option is true
+var theFailed []string
+
+// if -dbget.KeepGoing is true then log an error, append it to the list of failed outputs and return nil.
+// Else return source error as is.
+// If dbget interrupted by SIGINT or SIGTERM then return interrupt error as is.
+func keepGoing(name string, err error) error {
+	if err == nil || !theCfg.isKeepGoing || errors.Is(err, errInterrupted) {
+		return err
+	}
+	omppLog.Log("Error at ", name, ": ", err.Error())
+	outputFailed()
+
+	theFailed = append(theFailed, name+": "+err.Error())
+	return nil
+}
+
+// log summary of failed outputs, if there are any, and return partial failure error
+func failedSummary() error {
+	if len(theFailed) <= 0 {
+		return nil
+	}
+	omppLog.Log("Failed: ", len(theFailed))
+	for _, s := range theFailed {
+		omppLog.Log("  ", s)
+	}
+	return withExitCode(exitPartial, errors.New("Error: partial failure, failed outputs: "+strconv.Itoa(len(theFailed))))
+}
@@ -51,7 +51,14 @@ func matchUserLang(srcDb *sql.DB, mdRow db.ModelDicRow) (string, error) {
 }
 
 // find model run row by digest, stamp or name, if rdsn is not "" empty, or by run id, if id > 0, or by first or last bool flag
-func findRun(srcDb *sql.DB, modelId int, rdsn string, runId int, isFirst, isLast bool) (string, *db.RunRow, error) {
+func findRun(srcDb *sql.DB, modelId int, rdsn string, runId int, isFirst, isLast bool) (msg string, run *db.RunRow, err error) {
+
+	// if model run found then use it in csv header comments
+	defer func() {
+		if err == nil && run != nil {
+			setHeaderRun(run)
+		}
+	}()
 
 	if rdsn == "" && runId <= 0 && !isFirst && !isLast {
 		return "", nil, nil
@@ -91,7 +98,7 @@ func findWs(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) (*db.Workset
 			return nil, errors.New("Error at get workset: " + wsName + " " + err.Error())
 		}
 		if ws == nil {
-			return nil, errors.New("Error: workset not found: " + wsName)
+			return nil, withExitCode(exitRunNotFound, errors.New("Error: workset not found: "+wsName))
 		}
 	} else {
 
@@ -104,7 +111,7 @@ func findWs(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) (*db.Workset
 			return nil, errors.New("Error at get workset by id: " + strconv.Itoa(nId) + " " + err.Error())
 		}
 		if ws == nil {
-			return nil, errors.New("Error: workset not found by id: " + strconv.Itoa(nId))
+			return nil, withExitCode(exitRunNotFound, errors.New("Error: workset not found by id: "+strconv.Itoa(nId)))
 		}
 		wsName = ws.Name
 	}
@@ -112,6 +119,7 @@ func findWs(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) (*db.Workset
 	if !ws.IsReadonly {
 		return nil, errors.New("Error: workset must be read-only: " + wsName)
 	}
+	setHeaderWorkset(ws)
 
 	return ws, nil
 }
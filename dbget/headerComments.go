@@ -0,0 +1,109 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// metadata of current output, it is written as leading comment lines of csv files if -dbget.HeaderComments specified
+var theHeader = struct {
+	sync.Mutex
+	modelName   string // model name
+	modelDigest string // model digest
+	runName     string // model run name, if output is model run data
+	runDigest   string // model run digest, if output is model run data
+	setName     string // workset name, if output is workset data
+}{}
+
+// set model name and digest of csv header comments
+func setHeaderModel(name, digest string) {
+	theHeader.Lock()
+	defer theHeader.Unlock()
+	theHeader.modelName = name
+	theHeader.modelDigest = digest
+}
+
+// set model run of csv header comments, workset is cleared
+func setHeaderRun(r *db.RunRow) {
+	theHeader.Lock()
+	defer theHeader.Unlock()
+	theHeader.runName = r.Name
+	theHeader.runDigest = r.RunDigest
+	theHeader.setName = ""
+}
+
+// set workset of csv header comments, model run is cleared
+func setHeaderWorkset(ws *db.WorksetRow) {
+	theHeader.Lock()
+	defer theHeader.Unlock()
+	theHeader.setName = ws.Name
+	theHeader.runName = ""
+	theHeader.runDigest = ""
+}
+
+// write leading comment lines of csv file: model, run or workset, date-time, language, dbget version and command line
+func writeHeaderComments(w io.Writer, eol string) error {
+
+	theHeader.Lock()
+	lines := [][]string{}
+
+	if theHeader.modelName != "" || theHeader.modelDigest != "" {
+		lines = append(lines, []string{"model", theHeader.modelName, theHeader.modelDigest})
+	}
+	if theHeader.runName != "" || theHeader.runDigest != "" {
+		lines = append(lines, []string{"run", theHeader.runName, theHeader.runDigest})
+	}
+	if theHeader.setName != "" {
+		lines = append(lines, []string{"workset", theHeader.setName})
+	}
+	theHeader.Unlock()
+
+	lines = append(lines, []string{"date", helper.MakeDateTime(time.Now())})
+	if theCfg.lang != "" {
+		lines = append(lines, []string{"language", theCfg.lang})
+	}
+	lines = append(lines, []string{"dbget", dbgetVersion()})
+	lines = append(lines, []string{"command", strings.Join(os.Args, " ")})
+
+	// comment line: # key: values, line breaks inside of values replaced by space
+	var b strings.Builder
+
+	for _, ln := range lines {
+		b.WriteString(string(theCfg.commentChar) + " " + ln[0] + ":")
+		for _, v := range ln[1:] {
+			if v != "" {
+				b.WriteString(" " + strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(v))
+			}
+		}
+		b.WriteString(eol)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// return dbget version from build info: module version and vcs revision, if available
+func dbgetVersion() string {
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	v := bi.Main.Version
+
+	for _, s := range bi.Settings {
+		if s.Key == "vcs.revision" && s.Value != "" {
+			v += " " + s.Value
+		}
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// manifest file name of completed outputs, written into output directory if dbget interrupted by SIGINT or SIGTERM
+const manifestFileName = "dbget.manifest.txt"
+
+// dbget interrupted by SIGINT or SIGTERM: stopped before next output file
+var errInterrupted = errors.New("dbget interrupted")
+
+// state of outputs: completed output files and current output file, interrupt flag
+var theOutput = struct {
+	lock          sync.Mutex
+	isInterrupted bool     // if true then SIGINT or SIGTERM received: stop before next output file
+	current       string   // current output file path, empty if there is no output file in progress
+	done          []string // completed output files
+}{}
+
+// start SIGINT and SIGTERM signal handler.
+// At first signal dbget completes current output file and stops before next output file.
+// At second signal dbget remove current incomplete output file, write manifest of completed outputs and exit immediately.
+func handleInterrupt() {
+
+	sigC := make(chan os.Signal, 2)
+	signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		s := <-sigC
+		omppLog.Log("Interrupted by: ", s.String(), ", stop after current output, repeat to stop immediately")
+
+		theOutput.lock.Lock()
+		theOutput.isInterrupted = true
+		theOutput.lock.Unlock()
+
+		s = <-sigC
+		omppLog.Log("Interrupted by: ", s.String(), ", stop now")
+
+		theOutput.lock.Lock()
+		if theOutput.current != "" {
+			omppLog.Log("Delete incomplete output: ", theOutput.current)
+			if e := os.Remove(theOutput.current); e != nil && !os.IsNotExist(e) {
+				omppLog.Log(e)
+			}
+			theOutput.current = ""
+		}
+		theOutput.lock.Unlock()
+
+		if e := writeManifest(); e != nil {
+			omppLog.Log(e.Error())
+		}
+		os.Exit(exitInterrupt)
+	}()
+}
+
+// begin new output file: previous output file is completed.
+// Return interrupt error if dbget interrupted by SIGINT or SIGTERM.
+func outputBegin(path string) error {
+
+	theOutput.lock.Lock()
+	defer theOutput.lock.Unlock()
+
+	if theOutput.current != "" {
+		theOutput.done = append(theOutput.done, theOutput.current)
+		theOutput.current = ""
+	}
+	if theOutput.isInterrupted {
+		return withExitCode(exitInterrupt, errInterrupted)
+	}
+	theOutput.current = path
+	return nil
+}
+
+// current output file failed: do not include it into the list of completed outputs
+func outputFailed() {
+	theOutput.lock.Lock()
+	theOutput.current = ""
+	theOutput.lock.Unlock()
+}
+
+// if dbget interrupted then log completed outputs, write manifest and return interrupt error with exit code.
+// Else return source error as is.
+func interruptSummary(err error) error {
+	if !errors.Is(err, errInterrupted) {
+		return err
+	}
+
+	theOutput.lock.Lock()
+	n := len(theOutput.done)
+	theOutput.lock.Unlock()
+
+	omppLog.Log("Completed outputs: ", n)
+
+	if e := writeManifest(); e != nil {
+		omppLog.Log(e.Error())
+	}
+	return withExitCode(exitInterrupt, errors.New("Error: interrupted, output is incomplete, completed outputs: "+strconv.Itoa(n)))
+}
+
+// write manifest of completed outputs into output directory: dbget.manifest.txt
+func writeManifest() error {
+
+	theOutput.lock.Lock()
+	defer theOutput.lock.Unlock()
+
+	if len(theOutput.done) <= 0 {
+		return nil // no output files completed
+	}
+
+	fp := filepath.Join(theCfg.dir, manifestFileName)
+
+	if err := os.WriteFile(fp, []byte(strings.Join(theOutput.done, "\n")+"\n"), 0644); err != nil {
+		return errors.New("Error at writing manifest file: " + fp + ": " + err.Error())
+	}
+	omppLog.Log("Completed outputs saved into: ", fp)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// label map csv file columns: model type name, enum code and custom label
+var labelMapColumns = []string{"type", "enum_code", "new_label"}
+
+// read custom enum labels from csv or tsv label map file, csv header must be: type,enum_code,new_label
+func readLabelMap(fp string) ([]db.EnumLabel, error) {
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, withExitCode(exitIo, errors.New("Error: unable to open: "+fp+": "+err.Error()))
+	}
+	defer f.Close()
+
+	ur, err := helper.Utf8Reader(f, theCfg.encodingName)
+	if err != nil {
+		return nil, withExitCode(exitIo, errors.New("Error: unable to read: "+fp+": "+err.Error()))
+	}
+	rd := helper.NewCsvReader(ur, helper.CsvOptions{IsTsv: kindByExt(fp) == asTsv})
+
+	hdr, err := rd.Read()
+	switch {
+	case err == io.EOF:
+		return nil, errors.New("invalid (empty) csv file: " + fp)
+	case err != nil:
+		return nil, errors.New("csv file read error: " + fp + ": " + err.Error())
+	}
+	if !helper.IsCsvHeader(hdr, labelMapColumns, true) {
+		return nil, errors.New("Invalid csv file header " + fp + ": " + strings.Join(hdr, ",") + " expected: " + strings.Join(labelMapColumns, ","))
+	}
+
+	lst := []db.EnumLabel{}
+	for {
+
+		row, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("csv file read error: " + fp + ": " + err.Error())
+		}
+		if len(row) != len(labelMapColumns) || row[0] == "" || row[1] == "" {
+			return nil, errors.New("invalid csv file line: " + fp + ": " + strings.Join(row, ","))
+		}
+		lst = append(lst, db.EnumLabel{TypeName: row[0], EnumCode: row[1], Label: row[2]})
+	}
+	return lst, nil
+}
@@ -0,0 +1,71 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// add new language into database: insert lang_lst row and copy lang_word rows from source language.
+// If source language specified then also copy all text rows from source language as placeholders for translation.
+func langAdd(srcDb *sql.DB, runOpts *config.RunOptions) error {
+
+	code := runOpts.String(langCodeArgKey)
+	name := runOpts.String(langNameArgKey)
+	from := runOpts.String(langFromArgKey)
+
+	if code == "" {
+		return withExitCode(exitConfig, errors.New("invalid (empty) language code, use -"+langCodeArgKey+" to specify language"))
+	}
+	if from != "" {
+		omppLog.Log("Do ", theCfg.action, ": ", code, " ", name, " from: ", from)
+	} else {
+		omppLog.Log("Do ", theCfg.action, ": ", code, " ", name)
+	}
+
+	if err := db.AddLanguage(srcDb, code, name, from, from != ""); err != nil {
+		return errors.New("Error at add language: " + code + ": " + err.Error())
+	}
+	omppLog.Log("Language added: ", code)
+	return nil
+}
+
+// copy language-specific rows from source language into destination language, existing translations are not changed.
+func langCopy(srcDb *sql.DB, runOpts *config.RunOptions) error {
+
+	code := runOpts.String(langCodeArgKey)
+	from := runOpts.String(langFromArgKey)
+
+	if code == "" || from == "" {
+		return withExitCode(exitConfig, errors.New("invalid (empty) language code, use -"+langCodeArgKey+" and -"+langFromArgKey+" to specify languages"))
+	}
+	omppLog.Log("Do ", theCfg.action, ": ", from, " into: ", code)
+
+	if err := db.CopyLanguageText(srcDb, from, code); err != nil {
+		return errors.New("Error at copy language: " + from + " into: " + code + ": " + err.Error())
+	}
+	omppLog.Log("Language text copied: ", from, " into: ", code)
+	return nil
+}
+
+// delete language and all language-specific rows of that language, default model language cannot be deleted.
+func langDelete(srcDb *sql.DB, runOpts *config.RunOptions) error {
+
+	code := runOpts.String(langCodeArgKey)
+	if code == "" {
+		return withExitCode(exitConfig, errors.New("invalid (empty) language code, use -"+langCodeArgKey+" to specify language"))
+	}
+	omppLog.Log("Do ", theCfg.action, ": ", code)
+
+	if err := db.DeleteLanguage(srcDb, code); err != nil {
+		return errors.New("Error at delete language: " + code + ": " + err.Error())
+	}
+	omppLog.Log("Language deleted: ", code)
+	return nil
+}
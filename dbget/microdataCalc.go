@@ -29,7 +29,7 @@ func microdataCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) er
 		}
 	} else {
 		if runOpts.String(runArgKey) != "" || runOpts.Int(runIdArgKey, 0) != 0 || runOpts.Bool(runFirstArgKey) || runOpts.Bool(runLastArgKey) {
-			return errors.New("Error: base model run not found")
+			return withExitCode(exitRunNotFound, errors.New("Error: base model run not found"))
 		}
 	}
 
@@ -40,7 +40,7 @@ func microdataCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) er
 	pushToVar := func(src string, m string, r *db.RunRow) error {
 
 		if src != "" && r == nil {
-			return errors.New("Error: model run not found: " + src)
+			return withExitCode(exitRunNotFound, errors.New("Error: model run not found: "+src))
 		}
 		if r.Status != db.DoneRunStatus {
 			return errors.New("Error: model run not completed successfully: " + m)
@@ -51,7 +51,7 @@ func microdataCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) er
 		}
 		// else: add to the list of variant runs
 		if r.RunDigest == baseRun.RunDigest {
-			omppLog.Log("Warning: skip this model run, it is the same as base run: ", src)
+			logWarning("skip-run", "skip this model run, it is the same as base run: ", src)
 			return nil
 
 		}
@@ -125,10 +125,23 @@ func microdataCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) er
 			return e
 		}
 	}
+	// get variant runs where run options are equal to -dbget.RunWhere filter values
+	whereLst, isWhere, err := runWhereList(srcDb, modelId, runOpts)
+	if err != nil {
+		return err
+	}
+	if isWhere && len(whereLst) <= 0 {
+		return withExitCode(exitRunNotFound, errors.New("Error: model run(s) not found where: "+runOpts.String(runWhereArgKey)))
+	}
+	for k := range whereLst {
+		if e := pushToVar(whereLst[k].Name, whereLst[k].Name, &whereLst[k]); e != nil {
+			return e
+		}
+	}
 
 	// check: base model run must exist
 	if baseRun == nil {
-		return errors.New("Error: base model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: base model run not found"))
 	}
 
 	// get microdata entity, group by attributes and calcultion expression(s)
@@ -349,7 +362,7 @@ func microdataCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) er
 		omppLog.Log("Do ", theCfg.action, ": "+fp)
 	}
 
-	f, csvWr, err := createCsvWriter(fp)
+	f, csvWr, err := createChunkWriter(fp, entityName)
 	if err != nil {
 		return err
 	}
@@ -389,6 +402,7 @@ func microdataCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) er
 	// read microdata values page
 	_, err = db.ReadMicrodataCalculateTo(srcDb, meta, &microLt, &calcLt, runIds, cvtWr)
 	if err != nil {
+		logExprError(err)
 		return errors.New("Error at microdata run aggregation output: " + entityName + ": " + microLt.GenDigest + ": " + err.Error())
 	}
 
@@ -24,7 +24,7 @@ func microdataValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) erro
 		return errors.New("Error at get model run: " + msg + " " + err.Error())
 	}
 	if run == nil {
-		return errors.New("Error: model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
 	}
 	if run.Status != db.DoneRunStatus {
 		return errors.New("Error: model run not completed successfully: " + run.Name)
@@ -118,6 +118,9 @@ func microdataRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, run *db.R
 		},
 		GenDigest: egLst[gIdx].GenDigest,
 	}
+	if microLt.ReadKeyRangeLayout, err = microKeyRange(runOpts); err != nil {
+		return withExitCode(exitConfig, err)
+	}
 
 	if theCfg.isNoLang || theCfg.isIdCsv {
 
@@ -158,8 +161,8 @@ func microdataRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, run *db.R
 		}
 	}
 
-	// start csv output to file or console
-	f, csvWr, err := createCsvWriter(path)
+	// start csv or sql output to file or console
+	f, csvWr, err := createChunkWriter(path, name)
 	if err != nil {
 		return err
 	}
@@ -206,3 +209,30 @@ func microdataRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, run *db.R
 
 	return nil
 }
+
+// return microdata entity key range from -dbget.KeyFrom and -dbget.KeyTo options, if specified.
+func microKeyRange(runOpts *config.RunOptions) (db.ReadKeyRangeLayout, error) {
+
+	kr := db.ReadKeyRangeLayout{}
+
+	if s := runOpts.String(keyFromArgKey); s != "" {
+		k, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return kr, errors.New("invalid arguments: " + keyFromArgKey + " " + s + ": " + err.Error())
+		}
+		kr.IsKeyFrom = true
+		kr.KeyFrom = k
+	}
+	if s := runOpts.String(keyToArgKey); s != "" {
+		k, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return kr, errors.New("invalid arguments: " + keyToArgKey + " " + s + ": " + err.Error())
+		}
+		kr.IsKeyTo = true
+		kr.KeyTo = k
+	}
+	if kr.IsKeyFrom && kr.IsKeyTo && kr.KeyFrom > kr.KeyTo {
+		return kr, errors.New("invalid arguments: " + keyFromArgKey + " " + strconv.FormatUint(kr.KeyFrom, 10) + " greater than " + keyToArgKey + " " + strconv.FormatUint(kr.KeyTo, 10))
+	}
+	return kr, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// findModelSqlite search directory tree for SQLite database files which contain the model by name and / or digest.
+//
+// Only model_dic table is read from each .sqlite file, same as oms does to discover the models.
+// It returns path to the SQLite file if model found in one file only.
+// If model not found or found in multiple files then it returns an error with list of candidate files.
+func findModelSqlite(modelDir, name, digest string) (string, error) {
+
+	if name == "" && digest == "" {
+		return "", errors.New("invalid (empty) model name and model digest, it is required to search in: " + modelDir)
+	}
+	if fi, err := os.Stat(modelDir); err != nil || !fi.IsDir() {
+		return "", errors.New("model directory not exist or not accessible: " + modelDir)
+	}
+
+	// get list of *.sqlite files
+	pathLst, err := sqliteFilesInDir(modelDir)
+	if err != nil {
+		return "", err
+	}
+
+	// find model in each database file
+	mLst := []string{}
+
+	for _, p := range pathLst {
+
+		ok, err := isModelInSqlite(p, name, digest)
+		if err != nil {
+			logWarning("skip-file", "skip ", p, ": ", err.Error())
+			continue
+		}
+		if ok {
+			mLst = append(mLst, p)
+		}
+	}
+
+	switch len(mLst) {
+	case 0:
+		return "", withExitCode(exitModelNotFound, errors.New("model "+name+" "+digest+" not found in: "+modelDir))
+	case 1:
+		omppLog.Log("Model found in: ", mLst[0])
+		return mLst[0], nil
+	}
+
+	for _, p := range mLst {
+		omppLog.Log("  ", p)
+	}
+	return "", withExitCode(exitConfig, errors.New("model "+name+" "+digest+" found in multiple files, use -"+sqliteArgKey+" or -"+modelDigestArgKey+" to select one of: "+strings.Join(mLst, ", ")))
+}
+
+// return sorted list of *.sqlite files in directory tree
+func sqliteFilesInDir(dir string) ([]string, error) {
+
+	pathLst := []string{}
+	err := filepath.WalkDir(dir, func(src string, de fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !de.IsDir() && strings.EqualFold(filepath.Ext(src), ".sqlite") {
+			pathLst = append(pathLst, src)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("fail to scan model directory: " + dir + ": " + err.Error())
+	}
+	sort.Strings(pathLst)
+
+	return pathLst, nil
+}
+
+// return true if SQLite path is a directory or a glob pattern, e.g.: models/bin or models/bin/*.sqlite
+func isSqliteDirOrGlob(sqlitePath string) bool {
+	if sqlitePath == "" {
+		return false
+	}
+	if strings.ContainsAny(sqlitePath, "*?[") {
+		return true
+	}
+	fi, err := os.Stat(sqlitePath)
+	return err == nil && fi.IsDir()
+}
+
+// return sorted list of SQLite files by directory or glob pattern.
+// If path is a directory then all *.sqlite files in directory tree are included.
+func sqliteFilesByDirOrGlob(sqlitePath string) ([]string, error) {
+
+	if fi, err := os.Stat(sqlitePath); err == nil && fi.IsDir() {
+		return sqliteFilesInDir(sqlitePath)
+	}
+
+	fLst, err := filepath.Glob(sqlitePath)
+	if err != nil {
+		return nil, withExitCode(exitConfig, errors.New("invalid SQLite file path pattern: "+sqlitePath+": "+err.Error()))
+	}
+	pathLst := []string{}
+	for _, p := range fLst {
+		if fi, e := os.Stat(p); e == nil && !fi.IsDir() {
+			pathLst = append(pathLst, p)
+		}
+	}
+	sort.Strings(pathLst)
+
+	return pathLst, nil
+}
+
+// return true if model found in SQLite database file by name and / or digest
+func isModelInSqlite(sqlitePath, name, digest string) (bool, error) {
+
+	dbConn, _, err := db.Open(db.MakeSqliteDefaultReadOnly(sqlitePath), db.SQLiteDbDriver, false)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close(dbConn)
+
+	if err := db.CheckOpenmppSchemaVersion(dbConn); err != nil {
+		return false, err
+	}
+
+	ok, _, err := db.GetModelId(dbConn, name, digest)
+	return ok, err
+}
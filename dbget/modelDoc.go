@@ -0,0 +1,437 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// model documentation writer: Markdown or HTML output
+type docWriter struct {
+	isHtml bool            // if true then output is HTML else Markdown
+	sb     strings.Builder // document content
+}
+
+// write document header
+func (dw *docWriter) begin(title string) {
+	if dw.isHtml {
+		dw.sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + html.EscapeString(title) + "</title>\n</head>\n<body>\n")
+	}
+}
+
+// write document footer
+func (dw *docWriter) end() {
+	if dw.isHtml {
+		dw.sb.WriteString("</body>\n</html>\n")
+	}
+}
+
+// write section heading, level is 1 or 2 or 3
+func (dw *docWriter) heading(level int, text string) {
+	if dw.isHtml {
+		h := "h" + strconv.Itoa(level)
+		dw.sb.WriteString("<" + h + ">" + html.EscapeString(text) + "</" + h + ">\n")
+		return
+	}
+	dw.sb.WriteString(strings.Repeat("#", level) + " " + text + "\n\n")
+}
+
+// write paragraph
+func (dw *docWriter) para(text string) {
+	if text == "" {
+		return
+	}
+	if dw.isHtml {
+		dw.sb.WriteString("<p>" + html.EscapeString(text) + "</p>\n")
+		return
+	}
+	dw.sb.WriteString(text + "\n\n")
+}
+
+// write notes, notes are Markdown text and written as is into Markdown output or preformatted into HTML
+func (dw *docWriter) note(text string) {
+	if text == "" {
+		return
+	}
+	if dw.isHtml {
+		dw.sb.WriteString("<pre>" + html.EscapeString(text) + "</pre>\n")
+		return
+	}
+	dw.sb.WriteString(strings.TrimRight(text, "\r\n") + "\n\n")
+}
+
+// write table with header row and data rows
+func (dw *docWriter) table(header []string, rows [][]string) {
+	if len(rows) <= 0 {
+		return
+	}
+	if dw.isHtml {
+		dw.sb.WriteString("<table border=\"1\">\n<tr>")
+		for _, h := range header {
+			dw.sb.WriteString("<th>" + html.EscapeString(h) + "</th>")
+		}
+		dw.sb.WriteString("</tr>\n")
+		for _, r := range rows {
+			dw.sb.WriteString("<tr>")
+			for _, c := range r {
+				dw.sb.WriteString("<td>" + html.EscapeString(c) + "</td>")
+			}
+			dw.sb.WriteString("</tr>\n")
+		}
+		dw.sb.WriteString("</table>\n")
+		return
+	}
+
+	// markdown table: escape | and replace new lines by spaces
+	cell := func(s string) string {
+		return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", "\\|")
+	}
+	dw.sb.WriteString("|")
+	for _, h := range header {
+		dw.sb.WriteString(" " + cell(h) + " |")
+	}
+	dw.sb.WriteString("\n|")
+	for range header {
+		dw.sb.WriteString("---|")
+	}
+	dw.sb.WriteString("\n")
+	for _, r := range rows {
+		dw.sb.WriteString("|")
+		for _, c := range r {
+			dw.sb.WriteString(" " + cell(c) + " |")
+		}
+		dw.sb.WriteString("\n")
+	}
+	dw.sb.WriteString("\n")
+}
+
+// write model documentation into Markdown or HTML file: description, parameters, tables, entities and groups.
+// Output is HTML if file name extension is .html or .htm, otherwise it is Markdown.
+func modelDoc(srcDb *sql.DB, modelId int) error {
+
+	// get model metadata and model text in user language or in default model language
+	meta, err := db.GetModelById(srcDb, modelId)
+	if err != nil {
+		return errors.New("Error at get model metadata by id: " + strconv.Itoa(modelId) + ": " + err.Error())
+	}
+	if meta == nil {
+		return errors.New("Invalid (empty) model metadata")
+	}
+
+	lang := theCfg.lang
+	if lang == "" {
+		lang = meta.Model.DefaultLangCode
+	}
+	txt, err := db.GetModelText(srcDb, modelId, lang, false)
+	if err != nil {
+		return errors.New("Error at get model text metadata: " + meta.Model.Name + ": " + err.Error())
+	}
+
+	// make output file path: modelName.model-doc.Lang.md
+	fp := ""
+	if !theCfg.isConsole {
+
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = helper.CleanFileName(meta.Model.Name) + ".model-doc"
+			if lang != "" {
+				fp += "." + lang
+			}
+			fp += ".md"
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+	}
+	ext := strings.ToLower(filepath.Ext(fp))
+
+	dw := docWriter{isHtml: ext == ".html" || ext == ".htm"}
+
+	if fp == "" {
+		omppLog.Log("Do ", theCfg.action, " ", meta.Model.Name)
+	} else {
+		omppLog.Log("Do ", theCfg.action, ": ", fp)
+	}
+
+	// model description and notes
+	dw.begin(meta.Model.Name)
+	dw.heading(1, meta.Model.Name)
+
+	for k := range txt.ModelTxt {
+		dw.para(txt.ModelTxt[k].Descr)
+		dw.note(txt.ModelTxt[k].Note)
+	}
+	dw.table(
+		[]string{"Version", "Created", "Digest"},
+		[][]string{{meta.Model.Version, meta.Model.CreateDateTime, meta.Model.Digest}})
+
+	// type names by type id
+	typeName := func(typeId int) string {
+		if idx, ok := meta.TypeByKey(typeId); ok {
+			return meta.Type[idx].Name
+		}
+		return strconv.Itoa(typeId)
+	}
+
+	// parameters: list of parameters and details: dimensions and notes
+	if len(meta.Param) > 0 {
+
+		dw.heading(2, "Parameters")
+
+		rows := make([][]string, 0, len(meta.Param))
+		for k := range meta.Param {
+			if meta.Param[k].IsHidden {
+				continue
+			}
+			d, _ := paramDocText(txt, meta.Param[k].ParamId)
+			rows = append(rows, []string{meta.Param[k].Name, typeName(meta.Param[k].TypeId), strconv.Itoa(meta.Param[k].Rank), d})
+		}
+		dw.table([]string{"Name", "Type", "Rank", "Description"}, rows)
+
+		for k := range meta.Param {
+			if meta.Param[k].IsHidden {
+				continue
+			}
+			d, n := paramDocText(txt, meta.Param[k].ParamId)
+			if len(meta.Param[k].Dim) <= 0 && n == "" {
+				continue
+			}
+			dw.heading(3, meta.Param[k].Name)
+			dw.para(d)
+
+			dRows := [][]string{}
+			for j := range meta.Param[k].Dim {
+				dd := ""
+				for i := range txt.ParamDimsTxt {
+					if txt.ParamDimsTxt[i].ParamId == meta.Param[k].ParamId && txt.ParamDimsTxt[i].DimId == meta.Param[k].Dim[j].DimId {
+						dd = txt.ParamDimsTxt[i].Descr
+						break
+					}
+				}
+				dRows = append(dRows, []string{meta.Param[k].Dim[j].Name, typeName(meta.Param[k].Dim[j].TypeId), dd})
+			}
+			dw.table([]string{"Dimension", "Type", "Description"}, dRows)
+			dw.note(n)
+		}
+	}
+
+	// output tables: list of tables and details: dimensions, expressions and notes
+	if len(meta.Table) > 0 {
+
+		dw.heading(2, "Output tables")
+
+		rows := make([][]string, 0, len(meta.Table))
+		for k := range meta.Table {
+			if meta.Table[k].IsHidden {
+				continue
+			}
+			d, _, _ := tableDocText(txt, meta.Table[k].TableId)
+			rows = append(rows, []string{meta.Table[k].Name, strconv.Itoa(meta.Table[k].Rank), d})
+		}
+		dw.table([]string{"Name", "Rank", "Description"}, rows)
+
+		for k := range meta.Table {
+			if meta.Table[k].IsHidden {
+				continue
+			}
+			tId := meta.Table[k].TableId
+			d, n, ed := tableDocText(txt, tId)
+
+			dw.heading(3, meta.Table[k].Name)
+			dw.para(d)
+
+			dRows := [][]string{}
+			for j := range meta.Table[k].Dim {
+				dd := ""
+				for i := range txt.TableDimsTxt {
+					if txt.TableDimsTxt[i].TableId == tId && txt.TableDimsTxt[i].DimId == meta.Table[k].Dim[j].DimId {
+						dd = txt.TableDimsTxt[i].Descr
+						break
+					}
+				}
+				dRows = append(dRows, []string{meta.Table[k].Dim[j].Name, typeName(meta.Table[k].Dim[j].TypeId), dd})
+			}
+			dw.table([]string{"Dimension", "Type", "Description"}, dRows)
+
+			dw.para(ed)
+			eRows := [][]string{}
+			for j := range meta.Table[k].Expr {
+				de := ""
+				for i := range txt.TableExprTxt {
+					if txt.TableExprTxt[i].TableId == tId && txt.TableExprTxt[i].ExprId == meta.Table[k].Expr[j].ExprId {
+						de = txt.TableExprTxt[i].Descr
+						break
+					}
+				}
+				eRows = append(eRows, []string{meta.Table[k].Expr[j].Name, de, meta.Table[k].Expr[j].SrcExpr})
+			}
+			dw.table([]string{"Expression", "Description", "Source"}, eRows)
+			dw.note(n)
+		}
+	}
+
+	// entities: attributes and attribute groups
+	if len(meta.Entity) > 0 {
+
+		dw.heading(2, "Entities")
+
+		for k := range meta.Entity {
+
+			eId := meta.Entity[k].EntityId
+			dw.heading(3, meta.Entity[k].Name)
+
+			for i := range txt.EntityTxt {
+				if txt.EntityTxt[i].EntityId == eId {
+					dw.para(txt.EntityTxt[i].Descr)
+					dw.note(txt.EntityTxt[i].Note)
+					break
+				}
+			}
+
+			aRows := [][]string{}
+			for j := range meta.Entity[k].Attr {
+				if meta.Entity[k].Attr[j].IsInternal {
+					continue
+				}
+				da := ""
+				for i := range txt.EntityAttrTxt {
+					if txt.EntityAttrTxt[i].EntityId == eId && txt.EntityAttrTxt[i].AttrId == meta.Entity[k].Attr[j].AttrId {
+						da = txt.EntityAttrTxt[i].Descr
+						break
+					}
+				}
+				aRows = append(aRows, []string{meta.Entity[k].Attr[j].Name, typeName(meta.Entity[k].Attr[j].TypeId), da})
+			}
+			dw.table([]string{"Attribute", "Type", "Description"}, aRows)
+
+			// entity attribute groups
+			gRows := [][]string{}
+			for j := range meta.EntityGroup {
+				if meta.EntityGroup[j].EntityId != eId || meta.EntityGroup[j].IsHidden {
+					continue
+				}
+				dg := ""
+				for i := range txt.EntityGroupTxt {
+					if txt.EntityGroupTxt[i].EntityId == eId && txt.EntityGroupTxt[i].GroupId == meta.EntityGroup[j].GroupId {
+						dg = txt.EntityGroupTxt[i].Descr
+						break
+					}
+				}
+				cl := []string{}
+				for _, pc := range meta.EntityGroup[j].GroupPc {
+					if pc.ChildGroupId >= 0 {
+						for i := range meta.EntityGroup {
+							if meta.EntityGroup[i].EntityId == eId && meta.EntityGroup[i].GroupId == pc.ChildGroupId {
+								cl = append(cl, meta.EntityGroup[i].Name)
+								break
+							}
+						}
+					}
+					if pc.AttrId >= 0 {
+						for i := range meta.Entity[k].Attr {
+							if meta.Entity[k].Attr[i].AttrId == pc.AttrId {
+								cl = append(cl, meta.Entity[k].Attr[i].Name)
+								break
+							}
+						}
+					}
+				}
+				gRows = append(gRows, []string{meta.EntityGroup[j].Name, dg, strings.Join(cl, ", ")})
+			}
+			dw.table([]string{"Attributes group", "Description", "Members"}, gRows)
+		}
+	}
+
+	// groups of parameters and output tables
+	if len(meta.Group) > 0 {
+
+		dw.heading(2, "Groups")
+
+		gRows := [][]string{}
+		for j := range meta.Group {
+			if meta.Group[j].IsHidden {
+				continue
+			}
+			dg := ""
+			for i := range txt.GroupTxt {
+				if txt.GroupTxt[i].GroupId == meta.Group[j].GroupId {
+					dg = txt.GroupTxt[i].Descr
+					break
+				}
+			}
+			kind := "Tables"
+			if meta.Group[j].IsParam {
+				kind = "Parameters"
+			}
+
+			cl := []string{}
+			for _, pc := range meta.Group[j].GroupPc {
+				if pc.ChildGroupId >= 0 {
+					for i := range meta.Group {
+						if meta.Group[i].GroupId == pc.ChildGroupId {
+							cl = append(cl, meta.Group[i].Name)
+							break
+						}
+					}
+				}
+				if pc.ChildLeafId >= 0 {
+					if meta.Group[j].IsParam {
+						if idx, ok := meta.ParamByKey(pc.ChildLeafId); ok {
+							cl = append(cl, meta.Param[idx].Name)
+						}
+					} else {
+						if idx, ok := meta.OutTableByKey(pc.ChildLeafId); ok {
+							cl = append(cl, meta.Table[idx].Name)
+						}
+					}
+				}
+			}
+			gRows = append(gRows, []string{meta.Group[j].Name, kind, dg, strings.Join(cl, ", ")})
+		}
+		dw.table([]string{"Group", "Kind", "Description", "Members"}, gRows)
+	}
+	dw.end()
+
+	// write documentation into file or console
+	if fp == "" {
+		fmt.Print(dw.sb.String())
+		return nil
+	}
+	if err = checkOutputFile(fp); err != nil {
+		return err
+	}
+	if err = os.WriteFile(fp, []byte(dw.sb.String()), 0644); err != nil {
+		return withExitCode(exitIo, errors.New("failed to write model documentation: "+fp+": "+err.Error()))
+	}
+	return nil
+}
+
+// return parameter description and notes
+func paramDocText(txt *db.ModelTxtMeta, paramId int) (string, string) {
+	for k := range txt.ParamTxt {
+		if txt.ParamTxt[k].ParamId == paramId {
+			return txt.ParamTxt[k].Descr, txt.ParamTxt[k].Note
+		}
+	}
+	return "", ""
+}
+
+// return output table description, notes and expressions description
+func tableDocText(txt *db.ModelTxtMeta, tableId int) (string, string, string) {
+	for k := range txt.TableTxt {
+		if txt.TableTxt[k].TableId == tableId {
+			return txt.TableTxt[k].Descr, txt.TableTxt[k].Note, txt.TableTxt[k].ExprDescr
+		}
+	}
+	return "", "", ""
+}
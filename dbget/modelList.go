@@ -6,15 +6,78 @@ package main
 import (
 	"database/sql"
 	"errors"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
-// write models list from database into text csv, tsv or json file
-func modelList(srcDb *sql.DB) error {
+// model statistics for model-list extended output, see -dbget.Extended
+type modelStats struct {
+	RunCount          int    // number of model runs
+	CompletedRunCount int    // number of successfully completed model runs
+	WorksetCount      int    // number of input scenarios (worksets)
+	LastRunDateTime   string // newest model run create date-time, empty if there are no runs
+	DbFileSize        int64  // database file size in bytes, -1 if unknown, e.g. it is not SQLite database
+}
+
+// header of extended model-list csv columns
+var modelStatsHeader = []string{"run_count", "run_completed_count", "workset_count", "last_run_dt", "db_file_size"}
+
+// return model runs count, completed runs count, worksets count and newest run date-time.
+// If dbPath is not empty then it is SQLite database file path and database file size returned.
+func getModelStats(srcDb *sql.DB, modelId int, dbPath string) (modelStats, error) {
+
+	ms := modelStats{DbFileSize: -1}
+	smId := strconv.Itoa(modelId)
+
+	err := db.SelectFirst(srcDb,
+		"SELECT COUNT(*),"+
+			" COALESCE(SUM(CASE WHEN H.status = "+db.ToQuoted(db.DoneRunStatus)+" THEN 1 ELSE 0 END), 0),"+
+			" COALESCE(MAX(H.create_dt), '')"+
+			" FROM run_lst H WHERE H.model_id = "+smId,
+		func(row *sql.Row) error {
+			return row.Scan(&ms.RunCount, &ms.CompletedRunCount, &ms.LastRunDateTime)
+		})
+	if err != nil {
+		return ms, errors.New("Error at get model runs count: " + smId + ": " + err.Error())
+	}
+
+	err = db.SelectFirst(srcDb,
+		"SELECT COUNT(*) FROM workset_lst W WHERE W.model_id = "+smId,
+		func(row *sql.Row) error {
+			return row.Scan(&ms.WorksetCount)
+		})
+	if err != nil {
+		return ms, errors.New("Error at get model worksets count: " + smId + ": " + err.Error())
+	}
+
+	if dbPath != "" {
+		if fi, e := os.Stat(dbPath); e == nil && !fi.IsDir() {
+			ms.DbFileSize = fi.Size()
+		}
+	}
+	return ms, nil
+}
+
+// append model statistics to csv row starting from index
+func appendStatsRow(ms *modelStats, row []string, idx int) {
+	row[idx] = strconv.Itoa(ms.RunCount)
+	row[idx+1] = strconv.Itoa(ms.CompletedRunCount)
+	row[idx+2] = strconv.Itoa(ms.WorksetCount)
+	row[idx+3] = ms.LastRunDateTime
+	row[idx+4] = ""
+	if ms.DbFileSize >= 0 {
+		row[idx+4] = strconv.FormatInt(ms.DbFileSize, 10)
+	}
+}
+
+// write models list from database into text csv, tsv or json file.
+// If -dbget.Extended specified then append model runs and worksets count, newest run date-time and database file size.
+func modelList(srcDb *sql.DB, dbPath string) error {
 
 	// get model list
 	mLst, err := db.GetModelList(srcDb)
@@ -26,6 +89,16 @@ func modelList(srcDb *sql.DB) error {
 		return nil
 	}
 
+	// get model runs and worksets count if extended output required
+	msLst := make([]modelStats, len(mLst))
+	if theCfg.isExtended {
+		for k := range mLst {
+			if msLst[k], err = getModelStats(srcDb, mLst[k].ModelId, dbPath); err != nil {
+				return err
+			}
+		}
+	}
+
 	// use specified file name or make default
 	fp := ""
 
@@ -85,6 +158,18 @@ func modelList(srcDb *sql.DB) error {
 			mtLst = append(mtLst, mt)
 		}
 
+		if theCfg.isExtended {
+
+			type mItemExt struct {
+				mItem
+				Stats modelStats
+			}
+			meLst := make([]mItemExt, len(mtLst))
+			for k := range mtLst {
+				meLst[k] = mItemExt{mItem: mtLst[k], Stats: msLst[k]}
+			}
+			return toJsonOutput(fp, meLst) // save results
+		}
 		return toJsonOutput(fp, mtLst) // save results
 	}
 	// else write csv or tsv output into file or console
@@ -103,12 +188,16 @@ func modelList(srcDb *sql.DB) error {
 	}
 
 	// write model master row into csv, including description
-	row := make([]string, 9)
+	hdr := []string{"model_id", "model_name", "model_digest", "model_type", "model_ver", "create_dt", "default_lang_code", "lang_code", "descr"}
+	if theCfg.isExtended {
+		hdr = append(hdr, modelStatsHeader...)
+	}
+	row := make([]string, len(hdr))
 
 	idx := 0
 	err = toCsvOutput(
 		fp,
-		[]string{"model_id", "model_name", "model_digest", "model_type", "model_ver", "create_dt", "default_lang_code", "lang_code", "descr"},
+		hdr,
 		func() (bool, []string, error) {
 			if 0 <= idx && idx < len(mLst) {
 				row[0] = strconv.Itoa(mLst[idx].ModelId)
@@ -148,11 +237,14 @@ func modelList(srcDb *sql.DB) error {
 						if isUseIdNames {
 							nm = "model." + strconv.Itoa(mLst[idx].ModelId) + "." + nm
 						}
-						if err = writeNote(theCfg.dir, nm, txt[0].LangCode, &txt[0].Note); err != nil {
+						if err = writeKindNote(theCfg.dir, "model", nm, txt[0].LangCode, &txt[0].Note); err != nil {
 							return true, row, err
 						}
 					}
 				}
+				if theCfg.isExtended {
+					appendStatsRow(&msLst[idx], row, 9)
+				}
 
 				idx++
 				return false, row, nil
@@ -165,3 +257,179 @@ func modelList(srcDb *sql.DB) error {
 
 	return nil
 }
+
+// write models list from multiple SQLite databases into text csv, tsv or json file.
+// Each model row has source database file path, files which are not openM++ databases are skipped.
+// If -dbget.Extended specified then append model runs and worksets count, newest run date-time and database file size.
+func modelListFiles(pathLst []string) error {
+
+	type mItem struct {
+		SourceFile string
+		Model      db.ModelDicRow
+		DescrNote  db.DescrNote
+	}
+	mtLst := []mItem{}
+	msLst := []modelStats{}
+
+	for _, p := range pathLst {
+
+		srcDb, _, err := db.Open(db.MakeSqliteDefaultReadOnly(p), db.SQLiteDbDriver, false)
+		if err != nil {
+			logWarning("skip-file", "skip ", p, ": ", err.Error())
+			continue
+		}
+		if err = db.CheckOpenmppSchemaVersion(srcDb); err != nil {
+			db.Close(srcDb)
+			logWarning("skip-file", "skip ", p, ": ", err.Error())
+			continue
+		}
+
+		mLst, err := db.GetModelList(srcDb)
+		if err != nil {
+			db.Close(srcDb)
+			return errors.New("Error at get model list from: " + p + ": " + err.Error())
+		}
+
+		for k := range mLst {
+
+			mt := mItem{SourceFile: p, Model: mLst[k], DescrNote: db.DescrNote{}}
+
+			// append description and notes if any exist
+			lc := ""
+			if !theCfg.isNoLang && theCfg.userLang != "" {
+
+				lc, err = matchUserLang(srcDb, mLst[k])
+				if err != nil {
+					db.Close(srcDb)
+					return err
+				}
+			}
+			if theCfg.isNoLang || lc == "" {
+				lc = mLst[k].DefaultLangCode
+			}
+			if lc != "" {
+				txt, e := db.GetModelTextRowById(srcDb, mLst[k].ModelId, lc)
+				if e != nil {
+					db.Close(srcDb)
+					return e // error at model_dic_txt select
+				}
+				if len(txt) > 0 && txt[0].LangCode != "" {
+					mt.DescrNote.LangCode = txt[0].LangCode
+					mt.DescrNote.Descr = txt[0].Descr
+					mt.DescrNote.Note = txt[0].Note
+				}
+			}
+			mtLst = append(mtLst, mt)
+
+			ms := modelStats{DbFileSize: -1}
+			if theCfg.isExtended {
+				if ms, err = getModelStats(srcDb, mLst[k].ModelId, p); err != nil {
+					db.Close(srcDb)
+					return errors.New("Error at get model statistics from: " + p + ": " + err.Error())
+				}
+			}
+			msLst = append(msLst, ms)
+		}
+		db.Close(srcDb)
+	}
+	if len(mtLst) <= 0 {
+		omppLog.Log("Models not found in: ", len(pathLst), " database files")
+		return nil
+	}
+	omppLog.Log("Models: ", len(mtLst), " in: ", len(pathLst), " database files")
+
+	// use specified file name or make default
+	fp := ""
+
+	if theCfg.isConsole {
+		omppLog.Log("Do model-list")
+	} else {
+
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = "model-list" + extByKind()
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do model-list: " + fp)
+	}
+
+	// write json output into file or console
+	if theCfg.kind == asJson {
+
+		if theCfg.isExtended {
+
+			type mItemExt struct {
+				mItem
+				Stats modelStats
+			}
+			meLst := make([]mItemExt, len(mtLst))
+			for k := range mtLst {
+				meLst[k] = mItemExt{mItem: mtLst[k], Stats: msLst[k]}
+			}
+			return toJsonOutput(fp, meLst) // save results
+		}
+		return toJsonOutput(fp, mtLst) // save results
+	}
+	// else write csv or tsv output into file or console
+
+	// notes .md file name is model name, if model name duplicates then use source file name and model id
+	isUseIdNames := false
+	for k := 0; !isUseIdNames && k < len(mtLst); k++ {
+		for i := k + 1; !isUseIdNames && i < len(mtLst); i++ {
+			isUseIdNames = mtLst[i].Model.Name == mtLst[k].Model.Name
+		}
+	}
+
+	// write model master row into csv, including source file and description
+	hdr := []string{"source_file", "model_id", "model_name", "model_digest", "model_type", "model_ver", "create_dt", "default_lang_code", "lang_code", "descr"}
+	if theCfg.isExtended {
+		hdr = append(hdr, modelStatsHeader...)
+	}
+	row := make([]string, len(hdr))
+
+	idx := 0
+	err := toCsvOutput(
+		fp,
+		hdr,
+		func() (bool, []string, error) {
+			if 0 <= idx && idx < len(mtLst) {
+
+				m := &mtLst[idx].Model
+				row[0] = filepath.ToSlash(mtLst[idx].SourceFile)
+				row[1] = strconv.Itoa(m.ModelId)
+				row[2] = m.Name
+				row[3] = m.Digest
+				row[4] = strconv.Itoa(m.Type)
+				row[5] = m.Version
+				row[6] = m.CreateDateTime
+				row[7] = m.DefaultLangCode
+				row[8] = mtLst[idx].DescrNote.LangCode
+				row[9] = mtLst[idx].DescrNote.Descr
+
+				if mtLst[idx].DescrNote.LangCode != "" {
+
+					nm := m.Name
+					if isUseIdNames {
+						sf := filepath.Base(mtLst[idx].SourceFile)
+						nm = strings.TrimSuffix(sf, filepath.Ext(sf)) + ".model." + strconv.Itoa(m.ModelId) + "." + nm
+					}
+					if e := writeKindNote(theCfg.dir, "model", nm, mtLst[idx].DescrNote.LangCode, &mtLst[idx].DescrNote.Note); e != nil {
+						return true, row, e
+					}
+				}
+				if theCfg.isExtended {
+					appendStatsRow(&msLst[idx], row, 10)
+				}
+
+				idx++
+				return false, row, nil
+			}
+			return true, row, nil // end of model rows
+		})
+	if err != nil {
+		return errors.New("failed to write model into csv " + err.Error())
+	}
+
+	return nil
+}
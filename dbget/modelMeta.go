@@ -7,7 +7,6 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -19,29 +18,6 @@ import (
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
-// write notes into Name.Lang.md file, ex: modelOne.FR.md or to console
-func writeNote(dir, name string, langCode string, note *string) error {
-	if !theCfg.isNote || note == nil || *note == "" {
-		return nil
-	}
-	if theCfg.isConsole {
-		fmt.Println(*note)
-		return nil
-	}
-
-	nm := helper.CleanFileName(name)
-	if langCode != "" {
-		nm += "." + langCode
-	}
-	nm += ".md"
-
-	err := os.WriteFile(filepath.Join(dir, nm), []byte(*note), 0644)
-	if err != nil {
-		return errors.New("failed to write notes: " + name + " " + langCode + ": " + err.Error())
-	}
-	return nil
-}
-
 // write model metada from database into text csv, tsv or json file
 func modelMeta(srcDb *sql.DB, modelId int) error {
 
@@ -110,6 +86,9 @@ func modelMeta(srcDb *sql.DB, modelId int) error {
 		if fp == "" { // output to console
 			w = os.Stdout
 		} else {
+			if err := checkOutputFile(fp); err != nil {
+				return err
+			}
 			f, err := os.OpenFile(fp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
 			if err != nil {
 				return errors.New("json file create error: " + err.Error())
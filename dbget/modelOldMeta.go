@@ -4,9 +4,12 @@
 package main
 
 import (
+	"cmp"
 	"database/sql"
 	"errors"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strconv"
 
 	"github.com/openmpp/go/ompp/db"
@@ -252,6 +255,9 @@ type oldTableGroupMemberDic struct {
 	MemberGroupID *int64
 }
 
+// old-model json schema version, it must be incremented if json structure changed
+const oldModelSchemaVersion = 1
+
 // write old compatibilty model metada from database into text csv, tsv or json file
 func modelOldMeta(srcDb *sql.DB, modelId int) error {
 
@@ -297,6 +303,7 @@ func modelOldMeta(srcDb *sql.DB, modelId int) error {
 
 	// get model metadata from compatibility views
 	mcv := struct {
+		SchemaVersion           int // version of old-model json, incremented if json structure changed
 		LanguageDic             []oldLanguageDic
 		ModelDic                []oldModelDic
 		ModelInfoDic            []oldModelInfoDic
@@ -323,6 +330,7 @@ func modelOldMeta(srcDb *sql.DB, modelId int) error {
 		TableGroupDic           []oldTableGroupDic
 		TableGroupMemberDic     []oldTableGroupMemberDic
 	}{
+		SchemaVersion:           oldModelSchemaVersion,
 		LanguageDic:             []oldLanguageDic{},
 		ModelDic:                []oldModelDic{},
 		ModelInfoDic:            []oldModelInfoDic{},
@@ -1107,7 +1115,11 @@ func modelOldMeta(srcDb *sql.DB, modelId int) error {
 	}
 
 	// write json output into file or console
+	// if strict json required then sort all arrays by all fields, so output is the same for any database copy
 	if theCfg.kind == asJson {
+		if theCfg.isStrict {
+			sortAllSlices(&mcv)
+		}
 		return toJsonOutput(fp, mcv) // save results
 	}
 	// else write csv or tsv output into file or console
@@ -1807,3 +1819,63 @@ func modelOldMeta(srcDb *sql.DB, modelId int) error {
 
 	return nil
 }
+
+// sortAllSlices sort each slice field of struct by all fields of slice element, in order of fields declaration.
+// It is used to make json output deterministic, independent of database rows order.
+func sortAllSlices(src interface{}) {
+
+	sv := reflect.ValueOf(src).Elem()
+
+	for k := 0; k < sv.NumField(); k++ {
+
+		fv := sv.Field(k)
+		if fv.Kind() != reflect.Slice || fv.Len() <= 1 {
+			continue
+		}
+		sort.SliceStable(fv.Interface(), func(i, j int) bool {
+			return compareValues(fv.Index(i), fv.Index(j)) < 0
+		})
+	}
+}
+
+// compareValues return -1, 0 or 1 by comparing struct fields, pointers, strings, integers or booleans.
+// Nil pointer is less than any not nil value.
+func compareValues(a, b reflect.Value) int {
+
+	switch a.Kind() {
+	case reflect.Struct:
+		for k := 0; k < a.NumField(); k++ {
+			if c := compareValues(a.Field(k), b.Field(k)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Pointer:
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+		return compareValues(a.Elem(), b.Elem())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		}
+		if !a.Bool() {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// csv columns of model words file: kind of word (lang or model), language code, word code and word value
+var wordColumns = []string{"word_kind", "lang_code", "word_code", "word_value"}
+
+// language code and words in that language: lang_word or model_word rows as (code, value) map
+type wordLang struct {
+	LangCode string
+	Words    map[string]string
+}
+
+// model words json: lang_word and model_word rows for each language
+type modelWordsJson struct {
+	ModelName   string
+	ModelDigest string
+	LangWord    []wordLang
+	ModelWord   []wordLang
+}
+
+// write lang_word and model_word translation strings from database into csv, tsv or json file.
+// If -dbget.Languages specified then only words in those languages are written, else words in all languages.
+func modelWords(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	mw, langDef, err := getModelWords(srcDb, modelId)
+	if err != nil {
+		return err
+	}
+
+	// select languages, by default all languages
+	lcLst := []string{}
+	for k := range langDef.Lang {
+		lcLst = append(lcLst, langDef.Lang[k].LangCode)
+	}
+	if ls := helper.ParseCsvLine(runOpts.String(langListArgKey), ','); len(ls) > 0 {
+
+		lcLst = []string{}
+		for _, lc := range ls {
+			i := langIndex(langDef, lc)
+			if i < 0 {
+				return withExitCode(exitConfig, errors.New("Error: language not found: "+lc))
+			}
+			lcLst = append(lcLst, langDef.Lang[i].LangCode)
+		}
+	}
+
+	// collect words for selected languages
+	wj := modelWordsJson{ModelName: mw.ModelName, ModelDigest: mw.ModelDigest, LangWord: []wordLang{}, ModelWord: []wordLang{}}
+
+	for _, lc := range lcLst {
+		for k := range langDef.Lang {
+			if langDef.Lang[k].LangCode == lc {
+				wj.LangWord = append(wj.LangWord, wordLang{LangCode: lc, Words: langDef.Lang[k].Words})
+				break
+			}
+		}
+		for k := range mw.ModelWord {
+			if mw.ModelWord[k].LangCode == lc {
+				wj.ModelWord = append(wj.ModelWord, wordLang{LangCode: lc, Words: mw.ModelWord[k].Words})
+				break
+			}
+		}
+	}
+
+	// use specified file name or make default as modelName.words.csv
+	fp := ""
+
+	if theCfg.isConsole {
+		omppLog.Log("Do ", theCfg.action, " ", mw.ModelName)
+	} else {
+
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = helper.CleanFileName(mw.ModelName) + ".words" + extByKind()
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do ", theCfg.action, ": ", fp)
+	}
+
+	// write json output into file or console
+	if theCfg.kind == asJson {
+		return toJsonOutput(fp, wj)
+	}
+	// else write csv or tsv output into file or console
+
+	// make csv rows sorted by word kind, language and word code
+	rows := [][]string{}
+
+	for _, kw := range []struct {
+		kind string
+		lst  []wordLang
+	}{
+		{kind: "lang", lst: wj.LangWord},
+		{kind: "model", lst: wj.ModelWord},
+	} {
+		for _, wl := range kw.lst {
+
+			cLst := make([]string, 0, len(wl.Words))
+			for c := range wl.Words {
+				cLst = append(cLst, c)
+			}
+			slices.Sort(cLst)
+
+			for _, c := range cLst {
+				rows = append(rows, []string{kw.kind, wl.LangCode, c, wl.Words[c]})
+			}
+		}
+	}
+
+	idx := 0
+	err = toCsvOutput(
+		fp,
+		wordColumns,
+		func() (bool, []string, error) {
+			if 0 <= idx && idx < len(rows) {
+				idx++
+				return false, rows[idx-1], nil
+			}
+			return true, nil, nil // end of words
+		})
+	if err != nil {
+		return errors.New("failed to write model words into csv " + err.Error())
+	}
+	return nil
+}
+
+// read lang_word and model_word translation strings from csv, tsv or json file and update database.
+// Input file name is -dbget.File argument, file words are merged with existing database words:
+// existing words updated with new values, new words inserted and words not in the file are not changed.
+// Languages must already exist in database.
+func importWords(srcDb *sql.DB, modelId int) error {
+
+	if theCfg.fileName == "" {
+		return withExitCode(exitConfig, errors.New("invalid (empty) input file name, use -"+outputFileArgKey+" to specify words file"))
+	}
+	fp := filepath.Join(theCfg.dir, theCfg.fileName)
+
+	omppLog.Log("Do ", theCfg.action, ": ", fp)
+
+	mw, langDef, err := getModelWords(srcDb, modelId)
+	if err != nil {
+		return err
+	}
+	modelDef, err := db.GetModelById(srcDb, modelId)
+	if err != nil {
+		return errors.New("Error at get model metadata: " + mw.ModelName + ": " + err.Error())
+	}
+
+	// read words from input file
+	wj, err := readWordsFile(fp)
+	if err != nil {
+		return err
+	}
+	if wj.ModelName != "" && wj.ModelName != mw.ModelName || wj.ModelDigest != "" && wj.ModelDigest != mw.ModelDigest {
+		return withExitCode(exitConfig, errors.New("Error: words file model "+wj.ModelName+" "+wj.ModelDigest+" not match to: "+mw.ModelName+" "+mw.ModelDigest))
+	}
+
+	// merge file words into database words, empty values are skipped
+	nLang := 0
+	for _, wl := range wj.LangWord {
+
+		i := langIndex(langDef, wl.LangCode)
+		if i < 0 {
+			return errors.New("Error: language not found: " + wl.LangCode)
+		}
+		for c, v := range wl.Words {
+			if c != "" && v != "" {
+				langDef.Lang[i].Words[c] = v
+				nLang++
+			}
+		}
+	}
+
+	nModel := 0
+	for _, wl := range wj.ModelWord {
+
+		i := langIndex(langDef, wl.LangCode)
+		if i < 0 {
+			return errors.New("Error: language not found: " + wl.LangCode)
+		}
+		lc := langDef.Lang[i].LangCode
+
+		j := 0
+		for ; j < len(mw.ModelWord); j++ {
+			if mw.ModelWord[j].LangCode == lc {
+				break
+			}
+		}
+		if j >= len(mw.ModelWord) {
+			mw.ModelWord = append(mw.ModelWord, struct {
+				LangCode string
+				Words    map[string]string
+			}{LangCode: lc, Words: map[string]string{}})
+		}
+		for c, v := range wl.Words {
+			if c != "" && v != "" {
+				mw.ModelWord[j].Words[c] = v
+				nModel++
+			}
+		}
+	}
+
+	// update database
+	if nLang > 0 {
+		if err = db.UpdateLanguage(srcDb, langDef); err != nil {
+			return errors.New("Error at update language words: " + err.Error())
+		}
+	}
+	if nModel > 0 {
+		if err = db.UpdateModelWord(srcDb, modelDef, langDef, mw); err != nil {
+			return errors.New("Error at update model words: " + mw.ModelName + ": " + err.Error())
+		}
+	}
+	omppLog.Log("Language words: ", nLang, ", model words: ", nModel)
+
+	return nil
+}
+
+// return index of language in language list by language code, compared case-insensitive, or -1 if language not found
+func langIndex(langDef *db.LangMeta, langCode string) int {
+	for k := range langDef.Lang {
+		if strings.EqualFold(langDef.Lang[k].LangCode, langCode) {
+			return k
+		}
+	}
+	return -1
+}
+
+// return model words and languages with language words from database
+func getModelWords(srcDb *sql.DB, modelId int) (*db.ModelWordMeta, *db.LangMeta, error) {
+
+	mw, err := db.GetModelWord(srcDb, modelId, "")
+	if err != nil {
+		return nil, nil, errors.New("Error at get model words: " + err.Error())
+	}
+	langDef, err := db.GetLanguages(srcDb)
+	if err != nil {
+		return nil, nil, errors.New("Error at get language words: " + err.Error())
+	}
+	return mw, langDef, nil
+}
+
+// read words from json or csv file, file kind is defined by extension, it is csv by default
+func readWordsFile(fp string) (*modelWordsJson, error) {
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, withExitCode(exitIo, errors.New("Error: unable to open: "+fp+": "+err.Error()))
+	}
+	defer f.Close()
+
+	ur, err := helper.Utf8Reader(f, theCfg.encodingName)
+	if err != nil {
+		return nil, withExitCode(exitIo, errors.New("Error: unable to read: "+fp+": "+err.Error()))
+	}
+
+	// read json words file
+	kind := kindByExt(fp)
+	wj := modelWordsJson{}
+
+	if kind == asJson {
+		if err = json.NewDecoder(ur).Decode(&wj); err != nil {
+			return nil, errors.New("Error: invalid json words file: " + fp + ": " + err.Error())
+		}
+		return &wj, nil
+	}
+
+	// read csv words file, append each word to the language of that kind: lang or model
+	rd := helper.NewCsvReader(ur, helper.CsvOptions{IsTsv: kind == asTsv})
+
+	hdr, err := rd.Read()
+	switch {
+	case err == io.EOF:
+		return nil, errors.New("invalid (empty) csv file: " + fp)
+	case err != nil:
+		return nil, errors.New("csv file read error: " + fp + ": " + err.Error())
+	}
+	if !helper.IsCsvHeader(hdr, wordColumns, true) {
+		return nil, errors.New("Invalid csv file header " + fp + ": " + strings.Join(hdr, ",") + " expected: " + strings.Join(wordColumns, ","))
+	}
+
+	addWord := func(lst []wordLang, lc, code, val string) []wordLang {
+		for k := range lst {
+			if lst[k].LangCode == lc {
+				lst[k].Words[code] = val
+				return lst
+			}
+		}
+		return append(lst, wordLang{LangCode: lc, Words: map[string]string{code: val}})
+	}
+
+	for {
+
+		row, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("csv file read error: " + fp + ": " + err.Error())
+		}
+		if len(row) != len(wordColumns) {
+			return nil, errors.New("invalid csv file line: " + fp + ": " + strings.Join(row, ","))
+		}
+
+		switch strings.ToLower(row[0]) {
+		case "lang":
+			wj.LangWord = addWord(wj.LangWord, row[1], row[2], row[3])
+		case "model":
+			wj.ModelWord = addWord(wj.ModelWord, row[1], row[2], row[3])
+		default:
+			return nil, errors.New("invalid word kind, expected lang or model: " + fp + ": " + strings.Join(row, ","))
+		}
+	}
+	return &wj, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// notes index entry: object kind, name, language and path to .md file relative to notes directory
+type noteIndexEntry struct {
+	Kind     string // object kind, e.g.: table_dic, parameter_dic, model, run, set
+	Name     string // object name, e.g.: ageSexIncome or ageSexIncome.dim0
+	LangCode string // language code, e.g.: en-CA, empty if notes are language-neutral
+	Path     string // notes file path relative to notes directory, e.g.: en-CA/table_dic/ageSexIncome.md
+}
+
+// index of notes files written in -dbget.NotesTree mode by notes directory
+var theNoteIndex = struct {
+	sync.Mutex
+	dirs map[string][]noteIndexEntry
+}{dirs: map[string][]noteIndexEntry{}}
+
+// write notes into Name.Lang.md file, ex: modelOne.FR.md or to console.
+// Name is prefixed by object kind, ex: table_dic.ageSexIncome,
+// if -dbget.NotesTree is true then kind and object name are used as notes/FR/table_dic/ageSexIncome.md
+func writeNote(dir, name string, langCode string, note *string) error {
+
+	kind, obj, ok := strings.Cut(name, ".")
+	if !ok {
+		kind, obj = "", name
+	}
+	return writeNoteOf(dir, name, kind, obj, langCode, note)
+}
+
+// write notes of model, run or workset into Name.Lang.md file, ex: modelOne.FR.md or to console.
+// If -dbget.NotesTree is true then notes written into notes/Lang/kind/Name.md, ex: notes/FR/run/Default.md
+func writeKindNote(dir, kind, name string, langCode string, note *string) error {
+	return writeNoteOf(dir, name, kind, name, langCode, note)
+}
+
+// write notes into flatName.Lang.md file or, if -dbget.NotesTree is true, into notes/Lang/kind/name.md file or to console
+func writeNoteOf(dir, flatName, kind, name string, langCode string, note *string) error {
+	if !theCfg.isNote || note == nil || *note == "" {
+		return nil
+	}
+	if theCfg.isConsole {
+		fmt.Println(*note)
+		return nil
+	}
+
+	// flat output: name.lang.md
+	if !theCfg.isNoteTree {
+
+		nm := helper.CleanFileName(flatName)
+		if langCode != "" {
+			nm += "." + langCode
+		}
+		return writeNoteFile(filepath.Join(dir, nm+".md"), flatName, langCode, note)
+	}
+
+	// organized output: notes/lang/kind/name.md
+	ld := langCode
+	if ld == "" {
+		ld = "default"
+	}
+	rel := helper.CleanFileName(ld)
+	if kind != "" {
+		rel = filepath.Join(rel, helper.CleanFileName(kind))
+	}
+	rel = filepath.Join(rel, helper.CleanFileName(name)+".md")
+
+	nd := filepath.Join(dir, "notes")
+	fp := filepath.Join(nd, rel)
+
+	if err := os.MkdirAll(filepath.Dir(fp), 0750); err != nil {
+		return errors.New("failed to create notes directory: " + filepath.Dir(fp) + ": " + err.Error())
+	}
+	if err := writeNoteFile(fp, name, langCode, note); err != nil {
+		return err
+	}
+
+	theNoteIndex.Lock()
+	theNoteIndex.dirs[nd] = append(theNoteIndex.dirs[nd], noteIndexEntry{Kind: kind, Name: name, LangCode: langCode, Path: filepath.ToSlash(rel)})
+	theNoteIndex.Unlock()
+
+	return nil
+}
+
+// write notes into .md file
+func writeNoteFile(fp, name string, langCode string, note *string) error {
+
+	if err := checkOutputFile(fp); err != nil {
+		return err
+	}
+	err := os.WriteFile(fp, []byte(*note), 0644)
+	if err != nil {
+		return errors.New("failed to write notes: " + name + " " + langCode + ": " + err.Error())
+	}
+	return nil
+}
+
+// write index.json into each notes directory: list of objects and notes files sorted by kind, name and language
+func writeNoteIndex() error {
+
+	theNoteIndex.Lock()
+	defer theNoteIndex.Unlock()
+
+	for nd, idx := range theNoteIndex.dirs {
+
+		slices.SortStableFunc(idx, func(a, b noteIndexEntry) int {
+			if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+				return c
+			}
+			if c := strings.Compare(a.Name, b.Name); c != 0 {
+				return c
+			}
+			return strings.Compare(a.LangCode, b.LangCode)
+		})
+
+		fp := filepath.Join(nd, "index.json")
+		omppLog.Log("Notes index: ", fp)
+
+		if err := helper.ToJsonIndentFile(fp, idx); err != nil {
+			return errors.New("failed to write notes index: " + fp + ": " + err.Error())
+		}
+	}
+	theNoteIndex.dirs = map[string][]noteIndexEntry{}
+	return nil
+}
@@ -29,7 +29,7 @@ func parameterRunValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) e
 		return errors.New("Error at get model run: " + msg + " " + err.Error())
 	}
 	if run == nil {
-		return errors.New("Error: model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
 	}
 	if run.Status != db.DoneRunStatus {
 		return errors.New("Error: model run not completed successfully: " + run.Name)
@@ -177,8 +177,8 @@ func parameterValue(srcDb *sql.DB, meta *db.ModelMeta, name string, fromId int,
 		}
 	}
 
-	// start csv output to file or console
-	f, csvWr, err := createCsvWriter(path)
+	// start csv or sql output to file or console
+	f, csvWr, err := createChunkWriter(path, name)
 	if err != nil {
 		return err
 	}
@@ -19,16 +19,10 @@ import (
 // write old compatibilty model run parameters and output tables into csv or tsv files
 func runOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 
-	// find first model run
-	msg, run, err := findRun(srcDb, modelId, "", 0, true, false)
+	// find model run, by default use first model run
+	run, err := findOldRun(srcDb, modelId, runOpts)
 	if err != nil {
-		return errors.New("Error at get model run: " + msg + " " + err.Error())
-	}
-	if run == nil {
-		return errors.New("Error: first model run not found")
-	}
-	if run.Status != db.DoneRunStatus {
-		return errors.New("Error: model run not completed successfully: " + run.Name)
+		return err
 	}
 	runMeta, err := db.GetRunFull(srcDb, run)
 	if err != nil {
@@ -93,7 +87,7 @@ func runOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 			fp = filepath.Join(paramCsvDir, meta.Param[j].Name+extByKind())
 		}
 		err = parameterOldOut(srcDb, meta, meta.Param[j].Name, run, fp)
-		if err != nil {
+		if err = keepGoing("parameter "+meta.Param[j].Name, err); err != nil {
 			return err
 		}
 	}
@@ -122,7 +116,7 @@ func runOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 			fp = filepath.Join(tableCsvDir, name+extByKind())
 		}
 		err = tableOldOut(srcDb, meta, name, run.RunId, runOpts, fp)
-		if err != nil {
+		if err = keepGoing("output table "+name, err); err != nil {
 			return err
 		}
 	}
@@ -130,19 +124,35 @@ func runOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 	return nil
 }
 
-// write old compatibilty run parameter values into csv or tsv file
-func parameterOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+// find model run for compatibility views output by -dbget.Run, -dbget.RunId, -dbget.FirstRun or -dbget.LastRun.
+// If none of model run options specified then use first model run.
+func findOldRun(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) (*db.RunRow, error) {
+
+	rdsn := runOpts.String(runArgKey)
+	runId := runOpts.Int(runIdArgKey, 0)
+	isLast := runOpts.Bool(runLastArgKey)
+	isFirst := runOpts.Bool(runFirstArgKey) || rdsn == "" && runId <= 0 && !isLast
 
-	// find first model run
-	msg, run, err := findRun(srcDb, modelId, "", 0, true, false)
+	msg, run, err := findRun(srcDb, modelId, rdsn, runId, isFirst, isLast)
 	if err != nil {
-		return errors.New("Error at get model run: " + msg + " " + err.Error())
+		return nil, errors.New("Error at get model run: " + msg + " " + err.Error())
 	}
 	if run == nil {
-		return errors.New("Error: first model run not found")
+		return nil, withExitCode(exitRunNotFound, errors.New("Error: model run not found: "+msg))
 	}
 	if run.Status != db.DoneRunStatus {
-		return errors.New("Error: model run not completed successfully: " + run.Name)
+		return nil, errors.New("Error: model run not completed successfully: " + run.Name)
+	}
+	return run, nil
+}
+
+// write old compatibilty run parameter values into csv or tsv file
+func parameterOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	// find model run, by default use first model run
+	run, err := findOldRun(srcDb, modelId, runOpts)
+	if err != nil {
+		return err
 	}
 
 	// get model metadata and find parameter
@@ -198,16 +208,10 @@ func parameterOldOut(srcDb *sql.DB, meta *db.ModelMeta, name string, run *db.Run
 // write old compatibilty output table values into csv or tsv file
 func tableOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 
-	// find model run
-	msg, run, err := findRun(srcDb, modelId, "", 0, true, false)
+	// find model run, by default use first model run
+	run, err := findOldRun(srcDb, modelId, runOpts)
 	if err != nil {
-		return errors.New("Error at get model run: " + msg + " " + err.Error())
-	}
-	if run == nil {
-		return errors.New("Error: model run not found")
-	}
-	if run.Status != db.DoneRunStatus {
-		return errors.New("Error: model run not completed successfully: " + run.Name)
+		return err
 	}
 
 	// get model metadata and find output table
@@ -216,11 +220,11 @@ func tableOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 		return errors.New("Error at get model metadata by id: " + strconv.Itoa(modelId) + ": " + err.Error())
 	}
 	name := runOpts.String(tableArgKey)
-	if name == "" {
-		return errors.New("Invalid (empty) output tabel name")
+	if name == "" || name == "*" {
+		return tableOldAll(srcDb, meta, run, runOpts)
 	}
 
-	// write parameter values to csv or tsv file
+	// write output table values to csv or tsv file
 	fp := ""
 	if theCfg.isConsole {
 		omppLog.Log("Do ", theCfg.action, " ", name)
@@ -260,3 +264,65 @@ func tableOldOut(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, runO
 	// write output table values to csv or tsv file
 	return tableRunValue(srcDb, meta, name, runId, runOpts, path, true, hdr)
 }
+
+// write all old compatibilty output tables of model run into csv or tsv files, one file for each output table.
+// If output directory not explicitly specified then use model name and run name by default: modelOne/old-table.Default
+func tableOldAll(srcDb *sql.DB, meta *db.ModelMeta, run *db.RunRow, runOpts *config.RunOptions) error {
+
+	if theCfg.fileName != "" {
+		return withExitCode(exitConfig, errors.New("Error: output file name cannot be used for all output tables, use output directory"))
+	}
+	runMeta, err := db.GetRunFull(srcDb, run)
+	if err != nil {
+		return errors.New("Error at get model run: " + run.Name + " " + err.Error())
+	}
+
+	csvDir := theCfg.dir
+
+	if theCfg.isConsole {
+		omppLog.Log("Do ", theCfg.action, " ", run.Name)
+	} else {
+
+		isKeep := true // do not delete output directory if it is explicitly specified
+		if csvDir == "" {
+			csvDir = filepath.Join(helper.CleanFileName(meta.Model.Name), "old-table."+helper.CleanFileName(run.Name))
+			isKeep = theCfg.isKeepOutputDir
+		}
+		if err = makeOutputDir(csvDir, isKeep); err != nil {
+			return err
+		}
+		omppLog.Log("Do ", theCfg.action, ": "+csvDir)
+	}
+
+	// write output tables into csv file, if the table included in run results
+	nT := len(runMeta.Table)
+	omppLog.Log("  Tables: ", nT)
+	logT := time.Now().Unix()
+
+	for j := 0; j < nT; j++ {
+
+		// check if table exist in model run results
+		name := ""
+		for k := range meta.Table {
+			if meta.Table[k].TableHid == runMeta.Table[j].TableHid {
+				name = meta.Table[k].Name
+				break
+			}
+		}
+		if name == "" {
+			continue // skip table: it is suppressed and not in run results
+		}
+		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", name)
+
+		fp := ""
+		if !theCfg.isConsole {
+			fp = filepath.Join(csvDir, name+extByKind())
+		}
+		err = tableOldOut(srcDb, meta, name, run.RunId, runOpts, fp)
+		if err = keepGoing("output table "+name, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
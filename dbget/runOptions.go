@@ -0,0 +1,163 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// write run options of selected model runs into csv or tsv file as wide table: option key and value of each run.
+// Runs are selected by -dbget.Run, -dbget.RunId, -dbget.FirstRun, -dbget.LastRun, -dbget.WithRuns, -dbget.WithRunIds,
+// if none of the runs specified then all successfully completed model runs are used.
+func runOptions(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	// get model metadata
+	meta, err := db.GetModelById(srcDb, modelId)
+	if err != nil {
+		return errors.New("Error at get model metadata by id: " + strconv.Itoa(modelId) + ": " + err.Error())
+	}
+
+	// make list of model runs
+	rl := []db.RunRow{}
+
+	pushToList := func(src string, r *db.RunRow) error {
+
+		if r == nil {
+			return withExitCode(exitRunNotFound, errors.New("Error: model run not found: "+src))
+		}
+		if !slices.ContainsFunc(rl, func(rr db.RunRow) bool { return rr.RunId == r.RunId }) {
+			rl = append(rl, *r)
+		}
+		return nil
+	}
+
+	if runOpts.String(runArgKey) != "" || runOpts.Int(runIdArgKey, 0) != 0 || runOpts.Bool(runFirstArgKey) || runOpts.Bool(runLastArgKey) {
+
+		m, r, e := findRun(srcDb, modelId, runOpts.String(runArgKey), runOpts.Int(runIdArgKey, 0), runOpts.Bool(runFirstArgKey), runOpts.Bool(runLastArgKey))
+		if e != nil {
+			return errors.New("Error at get model run: " + m + " " + e.Error())
+		}
+		if e = pushToList(m, r); e != nil {
+			return e
+		}
+	}
+	for _, rdsn := range helper.ParseCsvLine(runOpts.String(withRunsArgKey), ',') {
+
+		m, r, e := findRun(srcDb, modelId, rdsn, 0, false, false)
+		if e != nil {
+			return errors.New("Error at get model run: " + m + " " + e.Error())
+		}
+		if e = pushToList(rdsn, r); e != nil {
+			return e
+		}
+	}
+	for _, sId := range helper.ParseCsvLine(runOpts.String(withRunIdsArgKey), ',') {
+
+		if sId == "" {
+			continue
+		}
+		rId, e := strconv.Atoi(sId)
+		if e != nil || rId <= 0 {
+			return errors.New("Invalid model run id: " + sId)
+		}
+
+		m, r, e := findRun(srcDb, modelId, "", rId, false, false)
+		if e != nil {
+			return errors.New("Error at get model run: " + m + " " + e.Error())
+		}
+		if e = pushToList(sId, r); e != nil {
+			return e
+		}
+	}
+
+	// by default use all completed model runs
+	if len(rl) <= 0 {
+
+		rl, err = db.GetRunList(srcDb, modelId)
+		if err != nil {
+			return errors.New("Error at get model runs list: " + err.Error())
+		}
+		rl = slices.DeleteFunc(rl, func(r db.RunRow) bool { return r.Status != db.DoneRunStatus })
+
+		if len(rl) <= 0 {
+			omppLog.Log("Do ", theCfg.action, ": ", "there are no completed model runs")
+			return nil
+		}
+	}
+
+	// get run options aligned by option key
+	runIds := make([]int, len(rl))
+	for k := range rl {
+		runIds[k] = rl[k].RunId
+	}
+
+	rd, err := db.GetRunOptionsDiff(srcDb, runIds)
+	if err != nil {
+		return errors.New("Error at get model run options: " + err.Error())
+	}
+	optLst := rd.Option
+	if runOpts.Bool(diffOnlyArgKey) {
+		optLst = rd.DiffOnly()
+	}
+
+	// use specified file name or make default as modelName.run-options.csv or .tsv
+	fp := ""
+
+	if theCfg.isConsole {
+		omppLog.Log("Do ", theCfg.action, " ", meta.Model.Name)
+	} else {
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = helper.CleanFileName(meta.Model.Name) + ".run-options" + extByKind()
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do ", theCfg.action, ": ", fp)
+	}
+
+	// csv header: option_key and run name for each run, use run id's if run names are not unique
+	isUseIdNames := false
+	for k := range rl {
+		for i := k + 1; !isUseIdNames && i < len(rl); i++ {
+			isUseIdNames = runDirName(&rl[i], false) == runDirName(&rl[k], false)
+		}
+	}
+
+	hdr := make([]string, 1+len(rl))
+	hdr[0] = "option_key"
+	for k := range rl {
+		hdr[k+1] = runDirName(&rl[k], isUseIdNames)
+	}
+
+	// write option rows: option key and value of each run
+	row := make([]string, len(hdr))
+
+	idx := 0
+	err = toCsvOutput(
+		fp,
+		hdr,
+		func() (bool, []string, error) {
+			if 0 <= idx && idx < len(optLst) {
+				row[0] = optLst[idx].Key
+				copy(row[1:], optLst[idx].Value)
+				idx++
+				return false, row, nil
+			}
+			return true, row, nil // end of option rows
+		})
+	if err != nil {
+		return errors.New("failed to write run options into csv " + err.Error())
+	}
+
+	return nil
+}
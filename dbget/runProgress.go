@@ -0,0 +1,203 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// run status and run progress of each sub-value
+type runProgressPub struct {
+	RunId          int              // run id
+	Name           string           // run name
+	RunDigest      string           // run digest
+	RunStamp       string           // run stamp
+	SubCount       int              // sub-values count
+	SubCompleted   int              // number of completed sub-values
+	Status         string           // run status: i=init p=progress s=success x=exit e=error(failed)
+	CreateDateTime string           // start date-time
+	UpdateDateTime string           // last update date-time
+	Duration       string           // run duration in seconds, empty if date-time is invalid
+	Progress       []subProgressPub // sub-values run progress
+}
+
+// sub-value run progress and duration
+type subProgressPub struct {
+	db.RunProgress        // sub-value run progress: run_progress row
+	Duration       string // sub-value duration in seconds, empty if date-time is invalid
+}
+
+// write run status and sub-values run progress into csv, tsv or json file.
+// Run can be selected by -dbget.Run, -dbget.RunId, -dbget.FirstRun, -dbget.LastRun,
+// if run not specified then all model runs are used, including failed or not completed runs.
+func runProgress(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	// get model metadata
+	meta, err := db.GetModelById(srcDb, modelId)
+	if err != nil {
+		return errors.New("Error at get model metadata by id: " + strconv.Itoa(modelId) + ": " + err.Error())
+	}
+
+	// find model run or get list of all model runs
+	rl := []db.RunRow{}
+
+	if runOpts.String(runArgKey) != "" || runOpts.Int(runIdArgKey, 0) != 0 || runOpts.Bool(runFirstArgKey) || runOpts.Bool(runLastArgKey) {
+
+		m, r, e := findRun(srcDb, modelId, runOpts.String(runArgKey), runOpts.Int(runIdArgKey, 0), runOpts.Bool(runFirstArgKey), runOpts.Bool(runLastArgKey))
+		if e != nil {
+			return errors.New("Error at get model run: " + m + " " + e.Error())
+		}
+		if r == nil {
+			return withExitCode(exitRunNotFound, errors.New("Error: model run not found: "+m))
+		}
+		rl = append(rl, *r)
+	} else {
+
+		rl, err = db.GetRunList(srcDb, modelId)
+		if err != nil {
+			return errors.New("Error at get model runs list: " + err.Error())
+		}
+	}
+	if len(rl) <= 0 {
+		omppLog.Log("Do ", theCfg.action, ": ", "there are no model runs")
+		return nil
+	}
+
+	// for each run get sub-values run progress
+	rpl := make([]runProgressPub, len(rl))
+
+	for k := range rl {
+
+		rp, err := db.GetRunProgress(srcDb, rl[k].RunId)
+		if err != nil {
+			return errors.New("Error at get run progress: " + strconv.Itoa(rl[k].RunId) + ": " + err.Error())
+		}
+
+		rpl[k] = runProgressPub{
+			RunId:          rl[k].RunId,
+			Name:           rl[k].Name,
+			RunDigest:      rl[k].RunDigest,
+			RunStamp:       rl[k].RunStamp,
+			SubCount:       rl[k].SubCount,
+			SubCompleted:   rl[k].SubCompleted,
+			Status:         rl[k].Status,
+			CreateDateTime: rl[k].CreateDateTime,
+			UpdateDateTime: rl[k].UpdateDateTime,
+			Duration:       durationSeconds(rl[k].CreateDateTime, rl[k].UpdateDateTime),
+			Progress:       make([]subProgressPub, len(rp)),
+		}
+		for j := range rp {
+			rpl[k].Progress[j] = subProgressPub{
+				RunProgress: rp[j],
+				Duration:    durationSeconds(rp[j].CreateDateTime, rp[j].UpdateDateTime),
+			}
+		}
+	}
+
+	// use specified file name or make default as modelName.run-progress.json or .csv or .tsv
+	fp := ""
+
+	if theCfg.isConsole {
+		omppLog.Log("Do ", theCfg.action, " ", meta.Model.Name)
+	} else {
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = helper.CleanFileName(meta.Model.Name) + ".run-progress" + extByKind()
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do ", theCfg.action, ": ", fp)
+	}
+
+	// write json output into file or console
+	if theCfg.kind == asJson {
+		return toJsonOutput(fp, rpl) // save results
+	}
+	// else write csv or tsv output into file or console
+
+	// write run progress rows into csv: one row for each sub-value of each run
+	// if there are no run_progress rows then write only run status with empty sub-value columns
+	row := make([]string, 17)
+
+	idx := 0
+	nSub := 0
+	err = toCsvOutput(
+		fp,
+		[]string{
+			"run_id", "run_name", "run_digest", "run_stamp", "sub_count", "sub_completed",
+			"run_status", "run_create_dt", "run_update_dt", "run_duration",
+			"sub_id", "sub_status", "sub_create_dt", "sub_update_dt", "sub_duration",
+			"progress_count", "progress_value"},
+		func() (bool, []string, error) {
+
+			if idx < 0 || idx >= len(rpl) {
+				return true, row, nil // end of run rows
+			}
+
+			row[0] = strconv.Itoa(rpl[idx].RunId)
+			row[1] = rpl[idx].Name
+			row[2] = rpl[idx].RunDigest
+			row[3] = rpl[idx].RunStamp
+			row[4] = strconv.Itoa(rpl[idx].SubCount)
+			row[5] = strconv.Itoa(rpl[idx].SubCompleted)
+			row[6] = rpl[idx].Status
+			row[7] = rpl[idx].CreateDateTime
+			row[8] = rpl[idx].UpdateDateTime
+			row[9] = rpl[idx].Duration
+
+			if nSub < len(rpl[idx].Progress) {
+				p := &rpl[idx].Progress[nSub]
+				row[10] = strconv.Itoa(p.SubId)
+				row[11] = p.Status
+				row[12] = p.CreateDateTime
+				row[13] = p.UpdateDateTime
+				row[14] = p.Duration
+				row[15] = strconv.Itoa(p.Count)
+				row[16] = strconv.FormatFloat(p.Value, 'g', -1, 64)
+				nSub++
+			} else {
+				for j := 10; j < len(row); j++ {
+					row[j] = ""
+				}
+			}
+
+			// move to the next run after last sub-value
+			if nSub >= len(rpl[idx].Progress) {
+				idx++
+				nSub = 0
+			}
+			return false, row, nil
+		})
+	if err != nil {
+		return errors.New("failed to write run progress into csv " + err.Error())
+	}
+
+	return nil
+}
+
+// return duration in seconds between start and end date-time, e.g.: 12.345
+// date-time expected as: 2012-08-17 16:04:59.148, return empty string if date-time is invalid.
+func durationSeconds(startDt, endDt string) string {
+
+	const dtLayout = "2006-01-02 15:04:05.000"
+
+	st, err := time.ParseInLocation(dtLayout, startDt, time.Local)
+	if err != nil {
+		return ""
+	}
+	et, err := time.ParseInLocation(dtLayout, endDt, time.Local)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatFloat(et.Sub(st).Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,80 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// return watermark file path: -dbget.WatermarkFile or default: modelOne.watermark.txt or my/output/dir.watermark.txt
+func watermarkPath(modelName string, runOpts *config.RunOptions) string {
+
+	if p := runOpts.String(watermarkFileArgKey); p != "" {
+		return p
+	}
+	if theCfg.dir != "" {
+		return filepath.Clean(theCfg.dir) + ".watermark.txt"
+	}
+	return helper.CleanFileName(modelName) + ".watermark.txt"
+}
+
+// return all runs watermark date-time from -dbget.Since or from watermark file, or empty "" string if there is no watermark.
+// Watermark can be a run digest, date-time: 2024-05-01 12:00:00.123 or 2024-05-01, or timestamp: 2024_05_01_12_00_00_123.
+// If watermark is a run digest then watermark is a date-time of that run completion.
+func runSince(modelName string, rl []db.RunRow, runOpts *config.RunOptions) (string, error) {
+
+	src := runOpts.String(sinceArgKey)
+	if src == "" && runOpts.IsExist(watermarkFileArgKey) {
+
+		fp := watermarkPath(modelName, runOpts)
+
+		bt, err := os.ReadFile(fp)
+		if err != nil && !os.IsNotExist(err) {
+			return "", errors.New("Error at reading watermark file: " + fp + ": " + err.Error())
+		}
+		src = strings.TrimSpace(string(bt))
+		if src != "" {
+			omppLog.Log("Watermark: ", src, " from: ", fp)
+		}
+	}
+	if src == "" {
+		return "", nil // no watermark: all runs
+	}
+
+	for k := range rl {
+		if rl[k].RunDigest == src {
+			return rl[k].UpdateDateTime, nil
+		}
+	}
+	if helper.IsUnderscoreTimeStamp(src) {
+		return helper.FromUnderscoreTimeStamp(src), nil
+	}
+	if len(src) >= len("2006-01-02") {
+		if _, e := time.Parse("2006-01-02", src[:len("2006-01-02")]); e == nil {
+			return src, nil
+		}
+	}
+	return "", errors.New("invalid watermark, expected run digest, date-time or timestamp: " + src)
+}
+
+// write watermark date-time into watermark file
+func writeWatermark(modelName string, since string, runOpts *config.RunOptions) error {
+
+	fp := watermarkPath(modelName, runOpts)
+
+	if err := os.WriteFile(fp, []byte(since+"\n"), 0644); err != nil {
+		return errors.New("Error at writing watermark file: " + fp + ": " + err.Error())
+	}
+	omppLog.Log("Watermark: ", since, " saved into: ", fp)
+	return nil
+}
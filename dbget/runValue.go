@@ -26,7 +26,7 @@ func runValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		return errors.New("Error at get model run: " + msg + " " + err.Error())
 	}
 	if run == nil {
-		return errors.New("Error: model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
 	}
 	if run.Status != db.DoneRunStatus {
 		return errors.New("Error: model run not completed successfully: " + run.Name)
@@ -58,7 +58,7 @@ func runValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 			case runOpts.Bool(runLastArgKey):
 				runTop = helper.CleanFileName(meta.Model.Name) + ".last-run"
 			default:
-				runTop = "run." + helper.CleanFileName(runMeta.Run.Name)
+				runTop = runDirName(&runMeta.Run, false)
 			}
 			if err = makeOutputDir(runTop, theCfg.isKeepOutputDir); err != nil {
 				return err
@@ -67,13 +67,82 @@ func runValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		omppLog.Log("Do ", theCfg.action, ": "+runTop)
 	}
 
-	return runValueOut(srcDb, meta, runMeta, runTop, isDefaultTop, runOpts)
+	return runValueOut(srcDb, meta, runMeta, runTop, "", isDefaultTop, runOpts)
 }
 
-// write model run parameters, output tables and microdata into csv or tsv files
-func runValueOut(srcDb *sql.DB, meta *db.ModelMeta, runMeta *db.RunMeta, runTop string, isDefaultTop bool, runOpts *config.RunOptions) error {
+// copy model run in the same database: run metadata copied and run values shared with source run.
+func runCopy(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	// find model run
+	msg, run, err := findRun(srcDb, modelId, runOpts.String(runArgKey), runOpts.Int(runIdArgKey, 0), runOpts.Bool(runFirstArgKey), runOpts.Bool(runLastArgKey))
+	if err != nil {
+		return errors.New("Error at get model run: " + msg + " " + err.Error())
+	}
+	if run == nil {
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
+	}
+	omppLog.Log("Do ", theCfg.action, " ", run.Name)
+
+	nId, err := db.CopyRun(srcDb, run.RunId, runOpts.String(copyNameArgKey))
+	if err != nil {
+		return errors.New("Error at copy model run: " + run.Name + " " + err.Error())
+	}
+	rc, err := db.GetRun(srcDb, nId)
+	if err != nil {
+		return errors.New("Error at get model run copy: " + strconv.Itoa(nId) + " " + err.Error())
+	}
+	if rc == nil {
+		return withExitCode(exitRunNotFound, errors.New("Error: model run copy not found: "+strconv.Itoa(nId)))
+	}
+	omppLog.Log("Run copy: ", rc.RunId, " ", rc.Name, " ", rc.RunDigest, " ", rc.RunStamp)
+
+	return nil
+}
+
+// return model run directory name, for example: run.Default, run.11.Default, run.8a9b... run.2026_01_02_03_04_05_678.
+// If isUseId is true then run id added to the name to make it unique.
+// If run directory name is run digest and digest is empty then run id and run name is used.
+func runDirName(run *db.RunRow, isUseId bool) string {
+
+	n := helper.CleanFileName(run.Name)
+	switch theCfg.runDirName {
+	case "digest":
+		if run.RunDigest != "" {
+			n = run.RunDigest
+		} else {
+			isUseId = true
+		}
+	case "stamp":
+		n = helper.CleanFileName(run.RunStamp)
+	case "id":
+		isUseId = true
+	}
+	if isUseId {
+		return "run." + strconv.Itoa(run.RunId) + "." + n
+	}
+	return "run." + n
+}
+
+// write model run parameters, output tables and microdata into csv or tsv files.
+//
+// Output directories and file names depend on layout, if runDir is empty then it is always run layout:
+//
+//	run:   csvTop/runDir/parameters/ageSex.csv
+//	flat:  csvTop/parameters/runDir.ageSex.csv
+//	table: csvTop/parameters/ageSex/runDir.csv
+func runValueOut(srcDb *sql.DB, meta *db.ModelMeta, runMeta *db.RunMeta, csvTop string, runDir string, isDefaultTop bool, runOpts *config.RunOptions) error {
+
+	setHeaderRun(&runMeta.Run)
 
 	// create sub directories for parameters, output tables and microdata
+	// in flat and table layout directories are shared between model runs and must not be deleted
+	isRunLayout := theCfg.layout == "run" || runDir == ""
+	isKeepDir := theCfg.isKeepOutputDir || !isRunLayout
+
+	runTop := csvTop
+	if isRunLayout {
+		runTop = filepath.Join(csvTop, runDir)
+	}
 	paramCsvDir := ""
 	tableCsvDir := ""
 	microCsvDir := ""
@@ -94,19 +163,36 @@ func runValueOut(srcDb *sql.DB, meta *db.ModelMeta, runMeta *db.RunMeta, runTop
 		tableCsvDir = filepath.Join(runTop, "output-tables"+dirSuffix)
 		microCsvDir = filepath.Join(runTop, "microdata"+dirSuffix)
 
-		if e := makeOutputDir(paramCsvDir, theCfg.isKeepOutputDir); e != nil {
+		if e := makeOutputDir(paramCsvDir, isKeepDir); e != nil {
 			return e
 		}
-		if e := makeOutputDir(tableCsvDir, theCfg.isKeepOutputDir); e != nil {
+		if e := makeOutputDir(tableCsvDir, isKeepDir); e != nil {
 			return e
 		}
 		if nMd > 0 {
-			if e := makeOutputDir(microCsvDir, theCfg.isKeepOutputDir); e != nil {
+			if e := makeOutputDir(microCsvDir, isKeepDir); e != nil {
 				return e
 			}
 		}
 	}
 
+	// return output file path, in table layout create parameter, output table or entity subdirectory
+	outPath := func(dir, name string) (string, error) {
+		switch {
+		case theCfg.isConsole:
+			return "", nil
+		case isRunLayout:
+			return filepath.Join(dir, name+extByKind()), nil
+		case theCfg.layout == "flat":
+			return filepath.Join(dir, runDir+"."+name+extByKind()), nil
+		}
+		d := filepath.Join(dir, name)
+		if e := makeOutputDir(d, true); e != nil {
+			return "", e
+		}
+		return filepath.Join(d, runDir+extByKind()), nil
+	}
+
 	// write all parameters into csv file
 	nP := len(meta.Param)
 	omppLog.Log("  Parameters: ", nP)
@@ -116,14 +202,14 @@ func runValueOut(srcDb *sql.DB, meta *db.ModelMeta, runMeta *db.RunMeta, runTop
 
 		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nP, ": ", meta.Param[j].Name)
 
-		fp := ""
-		if !theCfg.isConsole {
-			fp = filepath.Join(paramCsvDir, meta.Param[j].Name+extByKind())
-		}
-		e := parameterValue(srcDb, meta, meta.Param[j].Name, runMeta.Run.RunId, false, fp, false, nil)
+		fp, e := outPath(paramCsvDir, meta.Param[j].Name)
 		if e != nil {
 			return e
 		}
+		e = parameterValue(srcDb, meta, meta.Param[j].Name, runMeta.Run.RunId, false, fp, false, nil)
+		if e = keepGoing("run "+runMeta.Run.Name+" parameter "+meta.Param[j].Name, e); e != nil {
+			return e
+		}
 	}
 
 	// write output tables into csv file, if the table included in run results
@@ -145,14 +231,14 @@ func runValueOut(srcDb *sql.DB, meta *db.ModelMeta, runMeta *db.RunMeta, runTop
 		}
 		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nT, ": ", name)
 
-		fp := ""
-		if !theCfg.isConsole {
-			fp = filepath.Join(tableCsvDir, name+extByKind())
-		}
-		e := tableRunValue(srcDb, meta, name, runMeta.Run.RunId, runOpts, fp, false, nil)
+		fp, e := outPath(tableCsvDir, name)
 		if e != nil {
 			return e
 		}
+		e = tableRunValue(srcDb, meta, name, runMeta.Run.RunId, runOpts, fp, false, nil)
+		if e = keepGoing("run "+runMeta.Run.Name+" output table "+name, e); e != nil {
+			return e
+		}
 	}
 
 	// write microdata into csv file, if there is any microdata for that model run
@@ -169,13 +255,13 @@ func runValueOut(srcDb *sql.DB, meta *db.ModelMeta, runMeta *db.RunMeta, runTop
 			}
 			logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nMd, ": ", meta.Entity[eIdx].Name)
 
-			fp := ""
-			if !theCfg.isConsole {
-				fp = filepath.Join(microCsvDir, meta.Entity[eIdx].Name+extByKind())
+			fp, e := outPath(microCsvDir, meta.Entity[eIdx].Name)
+			if e != nil {
+				return e
 			}
 
-			e := microdataRunValue(srcDb, meta, meta.Entity[eIdx].Name, &runMeta.Run, runOpts, fp)
-			if e != nil {
+			e = microdataRunValue(srcDb, meta, meta.Entity[eIdx].Name, &runMeta.Run, runOpts, fp)
+			if e = keepGoing("run "+runMeta.Run.Name+" microdata "+meta.Entity[eIdx].Name, e); e != nil {
 				return e
 			}
 
@@ -197,18 +283,44 @@ func runAllValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 	if err != nil {
 		return errors.New("Error at get model runs list: " + err.Error())
 	}
+	whereLst, err := parseRunWhere(runOpts)
+	if err != nil {
+		return withExitCode(exitConfig, err)
+	}
+
+	// if watermark specified then use only runs completed after watermark date-time
+	isSince := runOpts.IsExist(sinceArgKey) || runOpts.IsExist(watermarkFileArgKey)
+	since := ""
+	if isSince {
+		if since, err = runSince(meta.Model.Name, rl, runOpts); err != nil {
+			return withExitCode(exitConfig, err)
+		}
+	}
+
 	rl = slices.DeleteFunc(rl, func(r db.RunRow) bool { return r.Status != db.DoneRunStatus })
 
+	// new watermark is the newest completion date-time of all completed runs
+	mark := since
+	for k := range rl {
+		if rl[k].UpdateDateTime > mark {
+			mark = rl[k].UpdateDateTime
+		}
+	}
+	if isSince && len(rl) <= 0 {
+		omppLog.Log("Do ", theCfg.action, ": ", "there are no completed model runs")
+		return writeWatermark(meta.Model.Name, mark, runOpts)
+	}
+
 	if len(rl) <= 0 {
 		omppLog.Log("Do ", theCfg.action, ": ", "there are no completed model runs")
 		return nil
 	}
 
-	// check if any run name is not unique then use run id's in directory names
+	// check if any run directory name is not unique then use run id's in directory names
 	isUseIdNames := false
 	for k := range rl {
 		for i := range rl {
-			if isUseIdNames = i != k && rl[i].Name == rl[k].Name; isUseIdNames {
+			if isUseIdNames = i != k && runDirName(&rl[i], false) == runDirName(&rl[k], false); isUseIdNames {
 				break
 			}
 		}
@@ -217,6 +329,32 @@ func runAllValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		}
 	}
 
+	// exclude runs completed before watermark
+	// run directory names are the same as in full output because unique names checked above for all completed runs
+	if since != "" {
+		rl = slices.DeleteFunc(rl, func(r db.RunRow) bool { return r.UpdateDateTime <= since })
+
+		omppLog.Log("Do ", theCfg.action, ": ", len(rl), " model run(s) completed after: ", since)
+		if len(rl) <= 0 {
+			return writeWatermark(meta.Model.Name, mark, runOpts)
+		}
+	}
+
+	// exclude runs where run options are not equal to -dbget.RunWhere filter values
+	if len(whereLst) > 0 {
+		if rl, err = runWhere(srcDb, modelId, rl, whereLst); err != nil {
+			return err
+		}
+
+		omppLog.Log("Do ", theCfg.action, ": ", len(rl), " model run(s) where: ", runOpts.String(runWhereArgKey))
+		if len(rl) <= 0 {
+			if isSince {
+				return writeWatermark(meta.Model.Name, mark, runOpts)
+			}
+			return nil
+		}
+	}
+
 	// create output directory
 	// if output directory name not explicitly specified then use ModelName by default
 	csvTop := theCfg.dir
@@ -239,32 +377,39 @@ func runAllValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 
 		runMeta, err := db.GetRunFull(srcDb, &rm)
 		if err != nil {
-			return errors.New("Error at get model run: " + rm.Name + " " + err.Error())
+			if e := keepGoing("run "+rm.Name, err); e != nil {
+				return errors.New("Error at get model run: " + rm.Name + " " + err.Error())
+			}
+			continue
 		}
 		if runMeta.Run.Status != db.DoneRunStatus {
 			continue // unexpected change of model run status
 		}
 		omppLog.Log("Model run ", rm.RunId, " ", rm.Name)
 
-		// run output directory is: run.Name_Of_the_Run or run.ID.Name_Of_the_Run
-		runTop := ""
-		if !theCfg.isConsole {
-			if !isUseIdNames {
-				runTop = filepath.Join(csvTop, "run."+helper.CleanFileName(rm.Name))
-			} else {
-				runTop = filepath.Join(csvTop, "run."+strconv.Itoa(rm.RunId)+"."+helper.CleanFileName(rm.Name))
-			}
-			if err = makeOutputDir(runTop, theCfg.isKeepOutputDir); err != nil {
+		// run output directory or file name prefix is: run.Name_Of_the_Run or run.ID.Name_Of_the_Run
+		runDir := runDirName(&rm, isUseIdNames)
+
+		if !theCfg.isConsole && theCfg.layout == "run" {
+			if err = makeOutputDir(filepath.Join(csvTop, runDir), theCfg.isKeepOutputDir); err != nil {
 				return err
 			}
 		}
 
-		err = runValueOut(srcDb, meta, runMeta, runTop, isDefaultTop, runOpts)
-		if err != nil {
+		err = runValueOut(srcDb, meta, runMeta, csvTop, runDir, isDefaultTop, runOpts)
+		if err = keepGoing("run "+rm.Name, err); err != nil {
 			return err
 		}
 	}
 
+	// update watermark only if all runs output completed successfully
+	if isSince {
+		if len(theFailed) > 0 {
+			omppLog.Log("Watermark not updated because of failed outputs, it is still: ", since)
+			return nil
+		}
+		return writeWatermark(meta.Model.Name, mark, runOpts)
+	}
 	return nil
 }
 
@@ -290,6 +435,15 @@ func runList(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		return errors.New("Error at get model runs list: " + err.Error())
 	}
 
+	// if run option filters specified then use only runs where run options are equal to filter values
+	whereLst, err := parseRunWhere(runOpts)
+	if err != nil {
+		return withExitCode(exitConfig, err)
+	}
+	if rl, err = runWhere(srcDb, modelId, rl, whereLst); err != nil {
+		return err
+	}
+
 	// for each run_lst find run_txt row if exist and convert to "public" run format
 	rpl := make([]db.RunPub, len(rl))
 
@@ -401,8 +555,8 @@ func runList(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 					if isUseIdNames {
 						nm = "run." + strconv.Itoa(rpl[idx].RunId) + "." + nm
 					}
-					if e := writeNote(
-						theCfg.dir, nm, rpl[idx].Txt[0].LangCode, &rpl[idx].Txt[0].Note,
+					if e := writeKindNote(
+						theCfg.dir, "run", nm, rpl[idx].Txt[0].LangCode, &rpl[idx].Txt[0].Note,
 					); e != nil {
 						return true, row, err
 					}
@@ -0,0 +1,96 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// run option filter: option key and value, e.g.: OpenM.SubValues=16
+type runWhereFilter struct {
+	key   string // run option key, e.g.: OpenM.SubValues
+	value string // run option value, e.g.: 16
+}
+
+// return list of run option filters from -dbget.RunWhere comma separated list of OptionKey=Value, e.g.: OpenM.SubValues=16,OpenM.Threads=4
+// Return empty list if there are no run option filters.
+func parseRunWhere(runOpts *config.RunOptions) ([]runWhereFilter, error) {
+
+	fLst := []runWhereFilter{}
+
+	for _, s := range helper.ParseCsvLine(runOpts.String(runWhereArgKey), ',') {
+
+		if s == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(s, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, errors.New("invalid run option filter, expected OptionKey=Value: " + s)
+		}
+		fLst = append(fLst, runWhereFilter{key: k, value: strings.TrimSpace(v)})
+	}
+	return fLst, nil
+}
+
+// return model runs where all run options are equal to filter values, option keys compared case-insensitive.
+// If run does not have that run option then run is excluded.
+func runWhere(srcDb *sql.DB, modelId int, rl []db.RunRow, fLst []runWhereFilter) ([]db.RunRow, error) {
+
+	if len(fLst) <= 0 {
+		return rl, nil // no filters: all runs
+	}
+
+	rkv, err := db.GetModelRunOptions(srcDb, modelId)
+	if err != nil {
+		return nil, errors.New("Error at get run options: " + err.Error())
+	}
+
+	return slices.DeleteFunc(rl, func(r db.RunRow) bool {
+
+		kv := rkv[r.RunId]
+
+		for _, f := range fLst {
+			isFound := false
+			for k, v := range kv {
+				if isFound = strings.EqualFold(k, f.key) && v == f.value; isFound {
+					break
+				}
+			}
+			if !isFound {
+				return true // run option not found or value is different: exclude run
+			}
+		}
+		return false
+	}), nil
+}
+
+// return successfully completed model runs where run options are equal to -dbget.RunWhere filter values.
+// Return false if there are no run option filters.
+func runWhereList(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) ([]db.RunRow, bool, error) {
+
+	fLst, err := parseRunWhere(runOpts)
+	if err != nil {
+		return nil, false, withExitCode(exitConfig, err)
+	}
+	if len(fLst) <= 0 {
+		return []db.RunRow{}, false, nil
+	}
+
+	rl, err := db.GetRunList(srcDb, modelId)
+	if err != nil {
+		return nil, true, errors.New("Error at get model runs list: " + err.Error())
+	}
+	rl = slices.DeleteFunc(rl, func(r db.RunRow) bool { return r.Status != db.DoneRunStatus })
+
+	rl, err = runWhere(srcDb, modelId, rl, fLst)
+	return rl, true, err
+}
@@ -0,0 +1,464 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// url prefix of object storage output directory: s3://bucket/prefix
+const s3UrlPrefix = "s3://"
+
+// default size of multipart upload part in MiB, files up to that size uploaded by single request
+const s3DefaultPartSize = 16
+
+// minimal size of multipart upload part in MiB, it is S3 limit
+const s3MinPartSize = 5
+
+// default number of retries of object storage request on transient failure
+const s3DefaultRetry = 3
+
+// object storage retry delay in seconds, it is doubled on each retry
+const s3RetryDelay = 1
+
+// object storage http request timeout in seconds
+const s3Timeout = 600
+
+// outputBackend is a destination of output files: files written into local staging directory and uploaded at the end
+type outputBackend interface {
+	dest() string                           // destination url, to use in log messages
+	put(key string, localPath string) error // upload local file, key is a slash separated path relative to destination
+}
+
+// output destination: if output is object storage then output files written into local staging directory and uploaded at exit
+var theUpload = struct {
+	output   outputBackend // if not nil then output destination is object storage, e.g.: s3://bucket/prefix
+	stageDir string        // local staging directory of object storage output, deleted at exit
+}{}
+
+// s3Backend is S3 or S3 compatible (e.g. MinIO) object storage output destination
+type s3Backend struct {
+	endpoint    url.URL      // object storage endpoint: scheme and host
+	isPathStyle bool         // if true then use path-style url: endpoint/bucket/key else bucket.endpoint/key
+	bucket      string       // bucket name
+	prefix      string       // key prefix, if not empty then ends with /
+	region      string       // region name, e.g.: us-east-1
+	accessKey   string       // access key id
+	secretKey   string       // secret access key
+	token       string       // if not empty then session token
+	partSize    int64        // multipart upload part size in bytes
+	retry       int          // number of retries on transient failure
+	client      *http.Client // http client
+}
+
+// return true if output directory is object storage url: s3://bucket/prefix
+func isS3Url(dir string) bool {
+	return strings.HasPrefix(strings.ToLower(dir), s3UrlPrefix)
+}
+
+// create S3 output backend from s3://bucket/prefix url.
+// Credentials, region and endpoint are from command line or ini-file options, if not specified then from environment:
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION or AWS_DEFAULT_REGION, AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL.
+func newS3Backend(dir string, runOpts *config.RunOptions) (*s3Backend, error) {
+
+	p := dir[len(s3UrlPrefix):]
+	bucket, prefix, _ := strings.Cut(p, "/")
+	if bucket == "" {
+		return nil, errors.New("invalid (empty) bucket name: " + dir)
+	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	sb := s3Backend{
+		bucket:    bucket,
+		prefix:    prefix,
+		region:    optOrEnv(runOpts, s3RegionArgKey, "AWS_REGION", "AWS_DEFAULT_REGION"),
+		accessKey: optOrEnv(runOpts, s3AccessArgKey, "AWS_ACCESS_KEY_ID"),
+		secretKey: optOrEnv(runOpts, s3SecretArgKey, "AWS_SECRET_ACCESS_KEY"),
+		token:     os.Getenv("AWS_SESSION_TOKEN"),
+		partSize:  int64(runOpts.Int(s3PartSizeArgKey, s3DefaultPartSize)) * 1024 * 1024,
+		retry:     runOpts.Int(s3RetryArgKey, s3DefaultRetry),
+		client:    &http.Client{Timeout: s3Timeout * time.Second},
+	}
+	if sb.region == "" {
+		sb.region = "us-east-1"
+	}
+	if sb.accessKey == "" || sb.secretKey == "" {
+		return nil, errors.New("object storage credentials not found, use " + s3AccessArgKey + " and " + s3SecretArgKey + " or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if sb.partSize < s3MinPartSize*1024*1024 {
+		return nil, errors.New("invalid arguments: " + s3PartSizeArgKey + " must be at least " + strconv.Itoa(s3MinPartSize) + " MiB")
+	}
+	if sb.retry < 0 {
+		sb.retry = 0
+	}
+
+	// endpoint: AWS S3 by default or custom endpoint, e.g.: MinIO http://localhost:9000
+	ep := optOrEnv(runOpts, s3EndpointArgKey, "AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL")
+	if ep == "" {
+		sb.endpoint = url.URL{Scheme: "https", Host: "s3." + sb.region + ".amazonaws.com"}
+		sb.isPathStyle = strings.Contains(bucket, ".") // bucket name with dots does not match https certificate
+	} else {
+		u, err := url.Parse(ep)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, errors.New("invalid object storage endpoint url: " + ep)
+		}
+		sb.endpoint = url.URL{Scheme: u.Scheme, Host: u.Host}
+		sb.isPathStyle = true // custom endpoints, e.g. MinIO, use path-style by default
+	}
+	return &sb, nil
+}
+
+// return option value or first not empty environment variable value
+func optOrEnv(runOpts *config.RunOptions, key string, envNames ...string) string {
+
+	if v := runOpts.String(key); v != "" {
+		return v
+	}
+	for _, e := range envNames {
+		if v := os.Getenv(e); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// return destination url
+func (sb *s3Backend) dest() string {
+	return s3UrlPrefix + sb.bucket + "/" + sb.prefix
+}
+
+// upload local file into object storage: single request if file size not exceed part size else multipart upload.
+// Multipart upload read file by parts, it does not read entire file in memory.
+func (sb *s3Backend) put(key string, localPath string) error {
+
+	fi, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	objKey := sb.prefix + key
+
+	if fi.Size() <= sb.partSize {
+
+		data, err := os.ReadFile(localPath)
+		if err != nil {
+			return err
+		}
+		_, _, err = sb.doRetry(http.MethodPut, objKey, url.Values{}, data)
+		return err
+	}
+
+	// multipart upload: initiate, upload parts and complete, abort upload on error
+	_, body, err := sb.doRetry(http.MethodPost, objKey, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return err
+	}
+	var ir struct {
+		UploadId string `xml:"UploadId"`
+	}
+	if err = xml.Unmarshal(body, &ir); err != nil || ir.UploadId == "" {
+		return errors.New("invalid response of multipart upload initiate: " + objKey)
+	}
+
+	err = sb.putParts(objKey, ir.UploadId, localPath)
+	if err != nil {
+		if _, _, e := sb.doRetry(http.MethodDelete, objKey, url.Values{"uploadId": {ir.UploadId}}, nil); e != nil {
+			omppLog.Log("Error at abort multipart upload: ", objKey, ": ", e.Error())
+		}
+	}
+	return err
+}
+
+// upload file parts and complete multipart upload
+func (sb *s3Backend) putParts(objKey, uploadId string, localPath string) error {
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	type partEtag struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+	cmp := struct {
+		XMLName xml.Name   `xml:"CompleteMultipartUpload"`
+		Parts   []partEtag `xml:"Part"`
+	}{}
+
+	buf := make([]byte, sb.partSize)
+	for n := 1; ; n++ {
+
+		nr, e := io.ReadFull(f, buf)
+		if e == io.EOF {
+			break // end of file
+		}
+		if e != nil && e != io.ErrUnexpectedEOF {
+			return e
+		}
+
+		hdr, _, err := sb.doRetry(http.MethodPut, objKey, url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadId}}, buf[:nr])
+		if err != nil {
+			return err
+		}
+		cmp.Parts = append(cmp.Parts, partEtag{PartNumber: n, ETag: hdr.Get("ETag")})
+
+		if e == io.ErrUnexpectedEOF {
+			break // last part
+		}
+	}
+
+	bt, err := xml.Marshal(&cmp)
+	if err != nil {
+		return err
+	}
+	_, body, err := sb.doRetry(http.MethodPost, objKey, url.Values{"uploadId": {uploadId}}, bt)
+	if err != nil {
+		return err
+	}
+	// complete multipart upload may return error inside of 200 OK response
+	if bytes.Contains(body, []byte("<Error>")) {
+		return s3ResponseError(http.StatusOK, body, objKey)
+	}
+	return nil
+}
+
+// do object storage request and retry on transient failure: network error, 5xx http status or 429 too many requests.
+// Return response headers and body.
+func (sb *s3Backend) doRetry(method, objKey string, query url.Values, data []byte) (http.Header, []byte, error) {
+
+	delay := s3RetryDelay * time.Second
+	var err error
+
+	for k := 0; k <= sb.retry; k++ {
+
+		if k > 0 {
+			omppLog.Log("Retry: ", method, " ", objKey, " after: ", err.Error())
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		var rsp *http.Response
+		rsp, err = sb.client.Do(sb.newRequest(method, objKey, query, data, time.Now().UTC()))
+		if err != nil {
+			continue // network error: retry
+		}
+		body, e := io.ReadAll(rsp.Body)
+		rsp.Body.Close()
+		if e != nil {
+			err = e
+			continue
+		}
+
+		if rsp.StatusCode >= 200 && rsp.StatusCode < 300 {
+			return rsp.Header, body, nil // done
+		}
+		err = s3ResponseError(rsp.StatusCode, body, objKey)
+
+		if rsp.StatusCode < 500 && rsp.StatusCode != http.StatusTooManyRequests {
+			return nil, nil, err // not a transient error: retry is useless
+		}
+	}
+	return nil, nil, err
+}
+
+// return object storage error from response status and xml error body
+func s3ResponseError(status int, body []byte, objKey string) error {
+
+	var er struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	m := strconv.Itoa(status) + " " + http.StatusText(status)
+	if xml.Unmarshal(body, &er) == nil && er.Code != "" {
+		m += ": " + er.Code + ": " + er.Message
+	}
+	return errors.New("object storage error: " + objKey + ": " + m)
+}
+
+// create object storage request signed by AWS Signature Version 4
+func (sb *s3Backend) newRequest(method, objKey string, query url.Values, data []byte, t time.Time) *http.Request {
+
+	u := sb.endpoint
+	p := "/" + objKey
+	if sb.isPathStyle {
+		p = "/" + sb.bucket + p
+	} else {
+		u.Host = sb.bucket + "." + u.Host
+	}
+	u.Path = p
+	u.RawPath = s3UriEncode(p, false)
+	u.RawQuery = s3CanonicalQuery(query)
+
+	req, _ := http.NewRequest(method, u.String(), bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+
+	sh := sha256.Sum256(data)
+	payloadHash := hex.EncodeToString(sh[:])
+	amzDate := t.Format("20060102T150405Z")
+
+	hdr := map[string]string{
+		"host":                 u.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if sb.token != "" {
+		hdr["x-amz-security-token"] = sb.token
+	}
+	for k, v := range hdr {
+		if k != "host" {
+			req.Header.Set(k, v)
+		}
+	}
+	req.Header.Set("Authorization", s3Authorization(method, u.RawPath, u.RawQuery, hdr, payloadHash, sb.region, sb.accessKey, sb.secretKey, t))
+	return req
+}
+
+// return AWS Signature Version 4 authorization header value, all headers are signed, header names must be lower case
+func s3Authorization(method, uri, query string, hdr map[string]string, payloadHash, region, accessKey, secretKey string, t time.Time) string {
+
+	names := make([]string, 0, len(hdr))
+	for k := range hdr {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	ch := ""
+	for _, k := range names {
+		ch += k + ":" + strings.TrimSpace(hdr[k]) + "\n"
+	}
+	signed := strings.Join(names, ";")
+
+	creq := method + "\n" + uri + "\n" + query + "\n" + ch + "\n" + signed + "\n" + payloadHash
+	ch256 := sha256.Sum256([]byte(creq))
+
+	day := t.Format("20060102")
+	scope := day + "/" + region + "/s3/aws4_request"
+	sts := "AWS4-HMAC-SHA256\n" + t.Format("20060102T150405Z") + "\n" + scope + "\n" + hex.EncodeToString(ch256[:])
+
+	key := s3Hmac([]byte("AWS4"+secretKey), day)
+	key = s3Hmac(key, region)
+	key = s3Hmac(key, "s3")
+	key = s3Hmac(key, "aws4_request")
+
+	return "AWS4-HMAC-SHA256 Credential=" + accessKey + "/" + scope + ", SignedHeaders=" + signed + ", Signature=" + hex.EncodeToString(s3Hmac(key, sts))
+}
+
+// return HMAC-SHA256 of data
+func s3Hmac(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// return sorted and encoded query string, empty values are included as: name=
+func s3CanonicalQuery(query url.Values) string {
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	q := []string{}
+	for _, k := range keys {
+		for _, v := range query[k] {
+			q = append(q, s3UriEncode(k, true)+"="+s3UriEncode(v, true))
+		}
+	}
+	return strings.Join(q, "&")
+}
+
+// uri encode as required by AWS Signature Version 4: encode all except unreserved characters, encode slash only if isSlash is true
+func s3UriEncode(src string, isSlash bool) string {
+
+	var sb strings.Builder
+	for _, b := range []byte(src) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			sb.WriteByte(b)
+		case b == '/' && !isSlash:
+			sb.WriteByte(b)
+		default:
+			sb.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{b})))
+		}
+	}
+	return sb.String()
+}
+
+// upload all files from local staging directory into output destination and return number of uploaded files
+func uploadOutput(be outputBackend, localDir string) (int, error) {
+
+	omppLog.Log("Upload output into: ", be.dest())
+
+	n := 0
+	err := filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		omppLog.Debug("Upload: ", key)
+		if err = be.put(key, path); err != nil {
+			return errors.New("Error at upload of: " + key + ": " + err.Error())
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// if output destination is object storage then upload output files from local staging directory and delete staging directory.
+// Output uploaded if dbget completed successfully, partially failed or interrupted, else output discarded.
+func uploadSummary(err error) error {
+
+	if theUpload.output == nil || theUpload.stageDir == "" {
+		return err // output is a local directory
+	}
+	defer dirDeleteAndLog(theUpload.stageDir)
+
+	if ec := exitCodeOf(err); err != nil && ec != exitPartial && ec != exitInterrupt {
+		omppLog.Log("Output is not uploaded due to error: ", theUpload.output.dest())
+		return err
+	}
+
+	n, e := uploadOutput(theUpload.output, theUpload.stageDir)
+	omppLog.Log("Uploaded files: ", n)
+	if e != nil {
+		if err != nil {
+			omppLog.Error(e.Error())
+			return err // return original error and exit code
+		}
+		return withExitCode(exitIo, e)
+	}
+	return err
+}
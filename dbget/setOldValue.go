@@ -0,0 +1,423 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// dictionary id of partition type: 0=simple 1=logical 2=classification 3=range 4=partition 5=link
+const partitionDicId = 4
+
+// max number of values in one line of Modgen .dat value block
+const datValuesPerLine = 10
+
+// write workset parameters into Modgen compatible .dat file.
+//
+// Parameter declaration contains Modgen type name and dimension(s) type names, for example:
+//
+//	parameters {
+//		//EN Age by Sex
+//		double ageSex[AGE_GROUP][SEX] = {
+//			// AGE_GROUP: 10-20, 20-30, 30-40, 40+
+//			// SEX: M, F
+//			// 10-20
+//			0.1, 0.2,
+//			...
+//		};
+//	};
+//
+// Classification values are enum codes, range values are integers, partition values are zero-based interval index.
+// Logical values are TRUE or FALSE. Consecutive repeated values written as Modgen repeater: (3) 0.5
+// If workset parameter has multiple sub-values then only default sub-value is written.
+func setOldValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	// get model metadata
+	meta, err := db.GetModelById(srcDb, modelId)
+	if err != nil {
+		return errors.New("Error at get model metadata by id: " + strconv.Itoa(modelId) + ": " + err.Error())
+	}
+
+	// find workset, it must be readonly
+	wsRow, err := findWs(srcDb, modelId, runOpts)
+	if err != nil {
+		return err
+	}
+
+	// get workset parameters, description and notes
+	// description and notes are in user language or in default model language, it is empty if language neutral output
+	lang := ""
+	if !theCfg.isNoLang {
+		lang = theCfg.lang
+		if lang == "" {
+			lang = meta.Model.DefaultLangCode
+		}
+	}
+	wm, err := db.GetWorksetFull(srcDb, wsRow, lang)
+	if err != nil {
+		return errors.New("Error at get workset metadata: " + wsRow.Name + ": " + err.Error())
+	}
+
+	var txt *db.ModelTxtMeta
+	if lang != "" {
+		if txt, err = db.GetModelText(srcDb, modelId, lang, true); err != nil {
+			return errors.New("Error at get model text metadata: " + err.Error())
+		}
+	}
+
+	// make output file path: set.Name.dat
+	fp := ""
+	if theCfg.isConsole {
+		omppLog.Log("Do ", theCfg.action, " ", wsRow.Name)
+	} else {
+
+		fp = theCfg.fileName
+		if fp == "" {
+			fp = "set." + helper.CleanFileName(wsRow.Name) + ".dat"
+		}
+		fp = filepath.Join(theCfg.dir, fp)
+
+		omppLog.Log("Do ", theCfg.action, ": "+fp)
+	}
+
+	// scenario header: workset description and notes
+	var sb strings.Builder
+	lc := strings.ToUpper(lang)
+
+	for k := range wm.Txt {
+		if wm.Txt[k].LangCode == lang {
+			if wm.Txt[k].Descr != "" {
+				sb.WriteString("//LABEL(" + wsRow.Name + ", " + lc + ") " + datLabel(wm.Txt[k].Descr) + "\n")
+			}
+			datNote(&sb, "", wsRow.Name, lc, wm.Txt[k].Note)
+		}
+	}
+	sb.WriteString("\nparameters {\n")
+
+	// write all workset parameters
+	nP := len(wm.Param)
+	omppLog.Log("  Parameters: ", nP)
+	logT := time.Now().Unix()
+
+	for j := 0; j < nP; j++ {
+
+		idx, ok := meta.ParamByHid(wm.Param[j].ParamHid)
+		if !ok {
+			return errors.New("missing workset parameter Hid: " + strconv.Itoa(wm.Param[j].ParamHid) + " workset: " + wsRow.Name)
+		}
+		logT = omppLog.LogIfTime(logT, logPeriod, "    ", j, " of ", nP, ": ", meta.Param[idx].Name)
+
+		// parameter description and workset parameter value notes
+		descr := ""
+		if txt != nil {
+			descr, _ = paramDocText(txt, meta.Param[idx].ParamId)
+		}
+		note := ""
+		for k := range wm.Param[j].Txt {
+			if wm.Param[j].Txt[k].LangCode == lang {
+				note = wm.Param[j].Txt[k].Note
+			}
+		}
+
+		e := paramDatOut(srcDb, meta, &meta.Param[idx], wsRow.SetId, wm.Param[j].DefaultSubId, descr, lc, note, &sb)
+		if e = keepGoing("workset "+wsRow.Name+" parameter "+meta.Param[idx].Name, e); e != nil {
+			return e
+		}
+	}
+	sb.WriteString("};\n")
+
+	// write .dat into file or console
+	if fp == "" {
+		fmt.Print(sb.String())
+		return nil
+	}
+	if err = checkOutputFile(fp); err != nil {
+		return err
+	}
+	if err = os.WriteFile(fp, []byte(sb.String()), 0644); err != nil {
+		return withExitCode(exitIo, errors.New("failed to write .dat file: "+fp+": "+err.Error()))
+	}
+	return nil
+}
+
+// write workset parameter declaration and values in Modgen .dat syntax
+func paramDatOut(srcDb *sql.DB, meta *db.ModelMeta, param *db.ParamMeta, setId int, subId int, descr, lc, note string, sb *strings.Builder) error {
+
+	// parameter type and dimension types: size of each dimension and position of dimension item
+	tIdx, ok := meta.TypeByKey(param.TypeId)
+	if !ok {
+		return errors.New("parameter type not found: " + param.Name)
+	}
+	typeOf := &meta.Type[tIdx]
+
+	dimTypes := make([]*db.TypeMeta, param.Rank)
+	dimPos := make([]func(itemId int) (int, bool), param.Rank)
+	dimSize := make([]int, param.Rank)
+	n := 1
+
+	for k := range param.Dim {
+
+		i, ok := meta.TypeByKey(param.Dim[k].TypeId)
+		if !ok {
+			return errors.New("parameter dimension type not found: " + param.Name + ": " + param.Dim[k].Name)
+		}
+		dimTypes[k] = &meta.Type[i]
+		dimSize[k], dimPos[k] = datItemPos(dimTypes[k])
+		n *= dimSize[k]
+	}
+
+	// read default sub-value and place each value at the cell position
+	vals := make([]string, n)
+	isSet := make([]bool, n)
+
+	paramLt := db.ReadParamLayout{
+		IsFromSet:       true,
+		ReadLayout:      db.ReadLayout{Name: param.Name, FromId: setId},
+		ReadSubIdLayout: db.ReadSubIdLayout{IsSubId: true, SubId: subId},
+	}
+
+	cvtWr := func(src interface{}) (bool, error) {
+
+		c, ok := src.(db.CellParam)
+		if !ok {
+			return false, errors.New("invalid type, expected: parameter cell (internal error)")
+		}
+		if c.IsNull {
+			return false, errors.New("NULL value is not supported by .dat file")
+		}
+
+		nPos := 0
+		for k := range c.DimIds {
+			p, ok := dimPos[k](c.DimIds[k])
+			if !ok {
+				return false, errors.New("invalid dimension item id: " + strconv.Itoa(c.DimIds[k]) + " of dimension: " + param.Dim[k].Name)
+			}
+			nPos = nPos*dimSize[k] + p
+		}
+
+		v, err := datValue(typeOf, c.Value)
+		if err != nil {
+			return false, err
+		}
+		vals[nPos] = v
+		isSet[nPos] = true
+		return true, nil
+	}
+
+	if _, err := db.ReadParameterTo(srcDb, meta, &paramLt, cvtWr); err != nil {
+		return errors.New("Error at parameter output: " + param.Name + ": " + err.Error())
+	}
+	for k := range isSet {
+		if !isSet[k] {
+			return errors.New("parameter values are incomplete, expected: " + strconv.Itoa(n) + " values")
+		}
+	}
+
+	// parameter declaration: label comment, type name and dimension(s) type names
+	sb.WriteString("\n")
+	if descr != "" {
+		sb.WriteString("\t//" + lc + " " + datLabel(descr) + "\n")
+	}
+	sb.WriteString("\t" + datTypeName(typeOf) + " " + param.Name)
+	for k := range dimTypes {
+		sb.WriteString("[" + datTypeName(dimTypes[k]) + "]")
+	}
+
+	// scalar parameter: single value
+	if param.Rank <= 0 {
+		sb.WriteString(" = " + vals[0] + ";\n")
+		datNote(sb, "\t", param.Name, lc, note)
+		return nil
+	}
+
+	// value block: dimension headers and rows of the last dimension values
+	sb.WriteString(" = {\n")
+
+	for k := range dimTypes {
+		sb.WriteString("\t\t// " + datTypeName(dimTypes[k]) + ": " + strings.Join(datItemCodes(dimTypes[k]), ", ") + "\n")
+	}
+
+	nLast := dimSize[param.Rank-1]
+	outerCodes := make([][]string, param.Rank-1)
+	for k := 0; k < param.Rank-1; k++ {
+		outerCodes[k] = datItemCodes(dimTypes[k])
+	}
+
+	for nRow := 0; nRow*nLast < n; nRow++ {
+
+		// for multi-dimensional parameter write row header: items of outer dimensions
+		if param.Rank > 1 {
+			hdr := make([]string, param.Rank-1)
+			r := nRow
+			for k := param.Rank - 2; k >= 0; k-- {
+				hdr[k] = outerCodes[k][r%dimSize[k]]
+				r /= dimSize[k]
+			}
+			sb.WriteString("\t\t// " + strings.Join(hdr, ", ") + "\n")
+		}
+
+		isLast := (nRow+1)*nLast >= n
+		datValueLines(sb, vals[nRow*nLast:(nRow+1)*nLast], isLast)
+	}
+	sb.WriteString("\t};\n")
+
+	datNote(sb, "\t", param.Name, lc, note)
+	return nil
+}
+
+// write values in lines of max datValuesPerLine values, consecutive repeated values written as (count) value
+func datValueLines(sb *strings.Builder, vals []string, isLast bool) {
+
+	items := []string{}
+	for k := 0; k < len(vals); {
+
+		nRep := 1
+		for k+nRep < len(vals) && vals[k+nRep] == vals[k] {
+			nRep++
+		}
+		if nRep > 1 {
+			items = append(items, "("+strconv.Itoa(nRep)+") "+vals[k])
+		} else {
+			items = append(items, vals[k])
+		}
+		k += nRep
+	}
+
+	for k := 0; k < len(items); k += datValuesPerLine {
+
+		nEnd := min(k+datValuesPerLine, len(items))
+		sb.WriteString("\t\t" + strings.Join(items[k:nEnd], ", "))
+		if !isLast || nEnd < len(items) {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// return Modgen type name: logical for bool type, for other types it is the same as openM++ type name
+func datTypeName(typeOf *db.TypeMeta) string {
+	if typeOf.IsBool() {
+		return "logical"
+	}
+	return typeOf.Name
+}
+
+// return dimension size and function to find item position by item id
+func datItemPos(typeOf *db.TypeMeta) (int, func(itemId int) (int, bool)) {
+
+	if typeOf.IsBool() {
+		return 2, func(itemId int) (int, bool) { return itemId, itemId == 0 || itemId == 1 }
+	}
+	if typeOf.IsRange {
+		return typeOf.MaxEnumId - typeOf.MinEnumId + 1, func(itemId int) (int, bool) {
+			return itemId - typeOf.MinEnumId, itemId >= typeOf.MinEnumId && itemId <= typeOf.MaxEnumId
+		}
+	}
+
+	pm := make(map[int]int, len(typeOf.Enum))
+	for k := range typeOf.Enum {
+		pm[typeOf.Enum[k].EnumId] = k
+	}
+	return len(typeOf.Enum), func(itemId int) (int, bool) {
+		p, ok := pm[itemId]
+		return p, ok
+	}
+}
+
+// return dimension items codes, for range type it is integer values
+func datItemCodes(typeOf *db.TypeMeta) []string {
+
+	if typeOf.IsBool() {
+		return []string{"FALSE", "TRUE"}
+	}
+	if typeOf.IsRange {
+		cs := make([]string, 0, typeOf.MaxEnumId-typeOf.MinEnumId+1)
+		for k := typeOf.MinEnumId; k <= typeOf.MaxEnumId; k++ {
+			cs = append(cs, strconv.Itoa(k))
+		}
+		return cs
+	}
+
+	cs := make([]string, len(typeOf.Enum))
+	for k := range typeOf.Enum {
+		cs[k] = typeOf.Enum[k].Name
+	}
+	return cs
+}
+
+// convert parameter value to Modgen .dat value
+func datValue(typeOf *db.TypeMeta, src interface{}) (string, error) {
+
+	switch {
+	case typeOf.IsBool():
+		if is, ok := src.(bool); ok {
+			if is {
+				return "TRUE", nil
+			}
+			return "FALSE", nil
+		}
+		return "", errors.New("invalid parameter value type, expected: bool")
+
+	case typeOf.IsString():
+		return strconv.Quote(fmt.Sprint(src)), nil
+
+	case typeOf.IsFloat():
+		if f, ok := src.(float64); ok {
+			return fmt.Sprintf(theCfg.doubleFmt, f), nil
+		}
+		return "", errors.New("invalid parameter value type, expected: float")
+
+	case typeOf.IsBuiltIn():
+		return fmt.Sprint(src), nil
+	}
+
+	// enum-based value: classification code, range value or partition interval index
+	id, ok := helper.ToIntValue(src)
+	if !ok {
+		return "", errors.New("invalid parameter value type, expected: integer enum id")
+	}
+	if typeOf.IsRange {
+		return strconv.Itoa(id), nil
+	}
+	for k := range typeOf.Enum {
+		if typeOf.Enum[k].EnumId == id {
+			if typeOf.DicId == partitionDicId {
+				return strconv.Itoa(k), nil
+			}
+			return typeOf.Enum[k].Name, nil
+		}
+	}
+	return "", errors.New("invalid parameter value enum id: " + strconv.Itoa(id) + " of type: " + typeOf.Name)
+}
+
+// return single line label: line breaks replaced by spaces
+func datLabel(src string) string {
+	return strings.Join(strings.Fields(src), " ")
+}
+
+// write Modgen note comment: /* NOTE(name, EN) ... */
+func datNote(sb *strings.Builder, indent string, name, lc, note string) {
+
+	if strings.TrimSpace(note) == "" {
+		return
+	}
+	sb.WriteString(indent + "/* NOTE(" + name + ", " + lc + ")\n")
+
+	for _, s := range strings.Split(strings.ReplaceAll(note, "\r\n", "\n"), "\n") {
+		sb.WriteString(indent + "\t" + strings.ReplaceAll(s, "*/", "* /") + "\n")
+	}
+	sb.WriteString(indent + "*/\n")
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/openmpp/go/ompp/config"
@@ -55,6 +56,8 @@ func setValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 // write workset parameters into csv or tsv files
 func setValueOut(srcDb *sql.DB, meta *db.ModelMeta, wsRow *db.WorksetRow, paramCsvDir string) error {
 
+	setHeaderWorkset(wsRow)
+
 	// get workset parameters list
 	hIds, _, _, err := db.GetWorksetParamList(srcDb, wsRow.SetId)
 	if err != nil {
@@ -81,7 +84,7 @@ func setValueOut(srcDb *sql.DB, meta *db.ModelMeta, wsRow *db.WorksetRow, paramC
 			fp = filepath.Join(paramCsvDir, meta.Param[idx].Name+extByKind())
 		}
 		e := parameterValue(srcDb, meta, meta.Param[idx].Name, wsRow.SetId, true, fp, false, nil)
-		if e != nil {
+		if e = keepGoing("workset "+wsRow.Name+" parameter "+meta.Param[idx].Name, e); e != nil {
 			return e
 		}
 	}
@@ -104,6 +107,8 @@ func setAllValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 	}
 	wsLst = slices.DeleteFunc(wsLst, func(w db.WorksetRow) bool { return !w.IsReadonly })
 
+	setMeta := strings.ToLower(runOpts.String(setMetaArgKey))
+
 	if len(wsLst) <= 0 {
 		omppLog.Log("Do ", theCfg.action, ": ", "there are no readonly worksets")
 		return nil
@@ -144,11 +149,96 @@ func setAllValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		}
 
 		err = setValueOut(srcDb, meta, &ws, wsDir)
-		if err != nil {
+		if err = keepGoing("workset "+ws.Name, err); err != nil {
 			return err
 		}
+
+		// write workset metadata file: description, notes, base run and parameters list
+		if !theCfg.isConsole && setMeta != "none" {
+			err = setMetaOut(srcDb, meta, &ws, wsDir, setMeta == "csv")
+			if err = keepGoing("workset "+ws.Name+" metadata", err); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// workset metadata file content: workset header and parameters list with parameter digests
+type setMetaPub struct {
+	db.WorksetHdrPub
+	Param []setParamMetaPub // workset parameters: name, digest, sub-values count and value notes
+}
+
+// workset parameter metadata: name, digest, sub-values count, default sub-value id and value notes
+type setParamMetaPub struct {
+	db.ParamRunSetPub
+	Digest string // parameter digest
+}
+
+// write workset metadata into wsDir/set.Name.meta.json or, if isCsv is true, into csv or tsv file.
+// Json file contains workset description and notes, base run digest, update date-time
+// and parameters list with digests, sub-values count and value notes.
+// Csv file contains one row for each parameter and does not include descriptions and notes.
+func setMetaOut(srcDb *sql.DB, meta *db.ModelMeta, wsRow *db.WorksetRow, wsDir string, isCsv bool) error {
+
+	wm, err := db.GetWorksetFull(srcDb, wsRow, "")
+	if err != nil {
+		return errors.New("Error at get workset metadata: " + wsRow.Name + ": " + err.Error())
+	}
+	wp, err := wm.ToPublic(srcDb, meta)
+	if err != nil {
+		return errors.New("Error at workset conversion: " + wsRow.Name + ": " + err.Error())
+	}
+
+	smp := setMetaPub{WorksetHdrPub: wp.WorksetHdrPub, Param: make([]setParamMetaPub, len(wp.Param))}
+
+	for k := range wp.Param {
+		smp.Param[k].ParamRunSetPub = wp.Param[k]
+		if idx, ok := meta.ParamByName(wp.Param[k].Name); ok {
+			smp.Param[k].Digest = meta.Param[idx].Digest
+		}
+	}
+
+	fp := filepath.Join(wsDir, "set."+helper.CleanFileName(wsRow.Name)+".meta")
+
+	if !isCsv {
+		return toJsonOutput(fp+".json", smp) // save results
 	}
+	// else write csv or tsv output: one row for each parameter
 
+	hdr := []string{"set_name", "base_run_digest", "update_dt", "parameter_name", "parameter_digest", "sub_count", "default_sub_id"}
+	row := make([]string, len(hdr))
+
+	idx := 0
+	err = toCsvOutput(
+		fp+extByKind(),
+		hdr,
+		func() (bool, []string, error) {
+			if idx > 0 && idx >= len(smp.Param) {
+				return true, row, nil // end of rows
+			}
+			row[0] = smp.Name
+			row[1] = smp.BaseRunDigest
+			row[2] = smp.UpdateDateTime
+			row[3] = ""
+			row[4] = ""
+			row[5] = ""
+			row[6] = ""
+
+			if idx < len(smp.Param) { // if workset is empty then write only workset header
+				row[3] = smp.Param[idx].Name
+				row[4] = smp.Param[idx].Digest
+				row[5] = strconv.Itoa(smp.Param[idx].SubCount)
+				row[6] = strconv.Itoa(smp.Param[idx].DefaultSubId)
+			}
+			idx++
+			return false, row, nil
+		})
+	if err != nil {
+		return errors.New("failed to write workset metadata into csv " + err.Error())
+	}
 	return nil
 }
 
@@ -255,8 +345,8 @@ func setList(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 					row[4] = wpl[idx].Txt[0].LangCode
 					row[5] = wpl[idx].Txt[0].Descr
 
-					if e := writeNote(
-						theCfg.dir, wpl[idx].Name, wpl[idx].Txt[0].LangCode, &wpl[idx].Txt[0].Note,
+					if e := writeKindNote(
+						theCfg.dir, "set", wpl[idx].Name, wpl[idx].Txt[0].LangCode, &wpl[idx].Txt[0].Note,
 					); e != nil {
 						return true, row, err
 					}
@@ -0,0 +1,307 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// rowWriter write rows of output values: it is csv or tsv writer or sql INSERT statements writer
+type rowWriter interface {
+	Write(row []string) error
+	Flush()
+	Error() error
+}
+
+// kind of sql column in flat schema of sql output, it is inferred from first batch of rows
+type sqlColumnKind int
+
+const (
+	sqlInt   sqlColumnKind = iota // all values are integers or NULL
+	sqlFloat                      // all values are numbers or NULL
+	sqlText                       // any other values
+)
+
+// sqlWriter write rows as sql INSERT statements: first row is column names, next rows are values.
+// Rows are written by batches, each batch is a single multi-row INSERT statement.
+// If CREATE TABLE required then column types are inferred from the first batch of rows.
+type sqlWriter struct {
+	wr       *bufio.Writer   // output file or console writer
+	dialect  string          // sql dialect: postgres, mysql or sqlite
+	table    string          // destination table name
+	batch    int             // number of rows in each INSERT statement
+	isCreate bool            // if true then write CREATE TABLE statement before first INSERT
+	cols     []string        // column names: first row
+	kinds    []sqlColumnKind // column types, inferred from the first batch
+	rows     [][]string      // rows to insert in the next batch
+	isStart  bool            // if true then column types already defined
+	err      error           // first write error, if any
+}
+
+// create output writer: csv or tsv writer or sql writer if output kind is sql.
+// Sql destination table name is output file name without extension,
+// if output is console then it is tableName or action name if tableName is empty.
+func createRowWriter(path string, tableName string) (*os.File, rowWriter, error) {
+
+	if theCfg.kind != asSql {
+		return createCsvWriter(path)
+	}
+
+	switch {
+	case path != "":
+		tableName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	case tableName == "":
+		tableName = theCfg.action
+	}
+
+	// create output file or write into console
+	var w io.Writer = os.Stdout
+	var f *os.File
+
+	if path != "" {
+		if err := checkOutputFile(path); err != nil {
+			return nil, nil, err
+		}
+		var err error
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+		w = f
+	}
+
+	sw := &sqlWriter{
+		wr:       bufio.NewWriter(w),
+		dialect:  theCfg.sqlDialect,
+		table:    tableName,
+		batch:    theCfg.sqlBatchSize,
+		isCreate: theCfg.isSqlCreate,
+	}
+	if sw.batch <= 0 {
+		sw.batch = 1
+	}
+	return f, sw, nil
+}
+
+// Write first row as column names and next rows as values, values are written by batches
+func (sw *sqlWriter) Write(row []string) error {
+
+	if sw.err != nil {
+		return sw.err
+	}
+	if sw.cols == nil {
+		sw.cols = append([]string{}, row...)
+		return nil
+	}
+	if len(row) != len(sw.cols) {
+		sw.err = errors.New("invalid number of values: " + strconv.Itoa(len(row)) + ", expected: " + strconv.Itoa(len(sw.cols)) + " into: " + sw.table)
+		return sw.err
+	}
+
+	sw.rows = append(sw.rows, append([]string{}, row...))
+	if len(sw.rows) >= sw.batch {
+		sw.writeBatch()
+	}
+	return sw.err
+}
+
+// Flush write pending rows and flush output
+func (sw *sqlWriter) Flush() {
+	if sw.err != nil || sw.cols == nil {
+		return
+	}
+	sw.writeBatch()
+	if sw.err == nil {
+		sw.err = sw.wr.Flush()
+	}
+}
+
+// Error return first write error, if any
+func (sw *sqlWriter) Error() error {
+	return sw.err
+}
+
+// write batch of rows as INSERT statement, at first batch infer column types and write CREATE TABLE, if required
+func (sw *sqlWriter) writeBatch() {
+
+	if !sw.isStart {
+		sw.isStart = true
+
+		sw.kinds = make([]sqlColumnKind, len(sw.cols))
+		for k := range sw.cols {
+			sw.kinds[k] = sqlKindOf(sw.rows, k)
+		}
+		if sw.isCreate {
+			sw.writeCreateTable()
+		}
+	}
+	if sw.err != nil || len(sw.rows) <= 0 {
+		return
+	}
+
+	// INSERT INTO ageSex (sub_id, dim0, dim1, param_value) VALUES
+	// (0, 'M', '10-20', 1.5),
+	// (0, 'F', '10-20', 2.5);
+	var b strings.Builder
+
+	b.WriteString("INSERT INTO " + sw.quoteName(sw.table) + " (")
+	for k := range sw.cols {
+		if k > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(sw.quoteName(sw.cols[k]))
+	}
+	b.WriteString(") VALUES\n")
+
+	for j := range sw.rows {
+		if j > 0 {
+			b.WriteString(",\n")
+		}
+		b.WriteString("(")
+		for k, v := range sw.rows[j] {
+			if k > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(sw.valueLiteral(sw.kinds[k], v))
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(";\n")
+
+	_, sw.err = sw.wr.WriteString(b.String())
+	sw.rows = sw.rows[:0]
+}
+
+// write CREATE TABLE statement using inferred column types
+func (sw *sqlWriter) writeCreateTable() {
+
+	var b strings.Builder
+
+	b.WriteString("CREATE TABLE " + sw.quoteName(sw.table) + " (\n")
+	for k := range sw.cols {
+		b.WriteString("  " + sw.quoteName(sw.cols[k]) + " " + sw.typeName(sw.kinds[k]))
+		if k < len(sw.cols)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(");\n")
+
+	_, sw.err = sw.wr.WriteString(b.String())
+}
+
+// return column type by column values: integer if all values are integers, float if all values are numbers, else text.
+// Empty values are NULLs, and if all values are NULL then column type is text.
+func sqlKindOf(rows [][]string, nCol int) sqlColumnKind {
+
+	kind := sqlInt
+	isAny := false
+
+	for j := range rows {
+
+		v := rows[j][nCol]
+		if v == "" {
+			continue
+		}
+		isAny = true
+
+		if kind == sqlInt {
+			if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+				continue
+			}
+			kind = sqlFloat
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return sqlText
+		}
+	}
+	if !isAny {
+		return sqlText
+	}
+	return kind
+}
+
+// return sql literal of the value: NULL if value is empty, number as is, string or invalid number as 'quoted' string.
+// Special float values NaN, +Inf, -Inf are 'NaN', 'Infinity', '-Infinity' for postgres and NULL for other dialects.
+func (sw *sqlWriter) valueLiteral(kind sqlColumnKind, v string) string {
+
+	if v == "" {
+		return "NULL"
+	}
+	if kind == sqlText {
+		return sw.quoteValue(v)
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return sw.quoteValue(v)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		if sw.dialect != "postgres" {
+			return "NULL"
+		}
+		switch {
+		case math.IsInf(f, 1):
+			return "'Infinity'"
+		case math.IsInf(f, -1):
+			return "'-Infinity'"
+		}
+		return "'NaN'"
+	}
+	return v
+}
+
+// return 'quoted' sql string, for mysql also escape \ backslash
+func (sw *sqlWriter) quoteValue(v string) string {
+	if sw.dialect == "mysql" {
+		v = strings.ReplaceAll(v, "\\", "\\\\")
+	}
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+// return quoted table or column name: `name` for mysql and "name" for postgres and sqlite
+func (sw *sqlWriter) quoteName(name string) string {
+	if sw.dialect == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}
+
+// return sql column type name for target dialect
+func (sw *sqlWriter) typeName(kind sqlColumnKind) string {
+
+	switch sw.dialect {
+	case "postgres":
+		switch kind {
+		case sqlInt:
+			return "BIGINT"
+		case sqlFloat:
+			return "DOUBLE PRECISION"
+		}
+		return "VARCHAR"
+	case "mysql":
+		switch kind {
+		case sqlInt:
+			return "BIGINT"
+		case sqlFloat:
+			return "DOUBLE"
+		}
+		return "TEXT"
+	}
+	// sqlite
+	switch kind {
+	case sqlInt:
+		return "INTEGER"
+	case sqlFloat:
+		return "REAL"
+	}
+	return "TEXT"
+}
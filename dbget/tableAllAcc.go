@@ -23,7 +23,7 @@ func tableAllAcc(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		return errors.New("Error at get model run: " + msg + " " + err.Error())
 	}
 	if run == nil {
-		return errors.New("Error: model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
 	}
 	if run.Status != db.DoneRunStatus {
 		return errors.New("Error: model run not completed successfully: " + run.Name)
@@ -93,6 +93,7 @@ func tableRunAllAcc(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, r
 		IsAccum:    true,
 		IsAllAccum: true,
 	}
+	tblLt.ReadMarginLayout = tableMarginLayout(runOpts)
 
 	if theCfg.isNoLang || theCfg.isIdCsv {
 
@@ -139,8 +140,8 @@ func tableRunAllAcc(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, r
 		}
 	}
 
-	// start csv output to file or console
-	f, csvWr, err := createCsvWriter(path)
+	// start csv or sql output to file or console
+	f, csvWr, err := createChunkWriter(path, name)
 	if err != nil {
 		return err
 	}
@@ -178,7 +179,7 @@ func tableRunAllAcc(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, r
 	}
 
 	// read output table accumulators
-	_, err = db.ReadOutputTableTo(srcDb, meta, &tblLt, cvtWr)
+	_, err = db.ReadOutputTablePartsTo(srcDb, meta, &tblLt, theCfg.threadCount, cvtWr)
 	if err != nil {
 		return errors.New("Error at output table output: " + name + ": " + err.Error())
 	}
@@ -31,7 +31,7 @@ func tableCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 		}
 	} else {
 		if runOpts.String(runArgKey) != "" || runOpts.Int(runIdArgKey, 0) != 0 || runOpts.Bool(runFirstArgKey) || runOpts.Bool(runLastArgKey) {
-			return errors.New("Error: base model run not found")
+			return withExitCode(exitRunNotFound, errors.New("Error: base model run not found"))
 		}
 	}
 
@@ -42,7 +42,7 @@ func tableCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 	pushToVar := func(src string, m string, r *db.RunRow) error {
 
 		if src != "" && r == nil {
-			return errors.New("Error: model run not found: " + src)
+			return withExitCode(exitRunNotFound, errors.New("Error: model run not found: "+src))
 		}
 		if r.Status != db.DoneRunStatus {
 			return errors.New("Error: model run not completed successfully: " + m)
@@ -53,7 +53,7 @@ func tableCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 		}
 		// else: add to the list of variant runs
 		if r.RunDigest == baseRun.RunDigest {
-			omppLog.Log("Warning: skip this model run, it is the same as base run: ", src)
+			logWarning("skip-run", "skip this model run, it is the same as base run: ", src)
 			return nil
 
 		}
@@ -127,10 +127,23 @@ func tableCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 			return e
 		}
 	}
+	// get variant runs where run options are equal to -dbget.RunWhere filter values
+	whereLst, isWhere, err := runWhereList(srcDb, modelId, runOpts)
+	if err != nil {
+		return err
+	}
+	if isWhere && len(whereLst) <= 0 {
+		return withExitCode(exitRunNotFound, errors.New("Error: model run(s) not found where: "+runOpts.String(runWhereArgKey)))
+	}
+	for k := range whereLst {
+		if e := pushToVar(whereLst[k].Name, whereLst[k].Name, &whereLst[k]); e != nil {
+			return e
+		}
+	}
 
 	// check: base model run must exist
 	if baseRun == nil {
-		return errors.New("Error: base model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: base model run not found"))
 	}
 
 	// get model metadata and check if table exists in the model
@@ -288,7 +301,7 @@ func tableCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 		omppLog.Log("Do ", theCfg.action, ": "+fp)
 	}
 
-	f, csvWr, err := createCsvWriter(fp)
+	f, csvWr, err := createChunkWriter(fp, name)
 	if err != nil {
 		return err
 	}
@@ -328,6 +341,7 @@ func tableCompare(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error
 	// read output table page
 	_, err = db.ReadOutputTableCalculteTo(srcDb, meta, &tableLt, calcLt, runIds, cvtWr)
 	if err != nil {
+		logExprError(err)
 		return errors.New("Error at output table aggregation output: " + name + ": " + err.Error())
 	}
 
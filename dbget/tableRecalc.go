@@ -0,0 +1,39 @@
+// Copyright OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// recalculate output table expression values of model run from accumulators using table_expr expression sql.
+// All expression rows of that run output table replaced by calculated values.
+func tableRecalc(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
+
+	name := runOpts.String(tableArgKey)
+	if name == "" {
+		return withExitCode(exitConfig, errors.New("invalid (empty) output table name, use -"+tableArgKey+" to specify output table"))
+	}
+
+	// find model run
+	msg, run, err := findRun(srcDb, modelId, runOpts.String(runArgKey), runOpts.Int(runIdArgKey, 0), runOpts.Bool(runFirstArgKey), runOpts.Bool(runLastArgKey))
+	if err != nil {
+		return errors.New("Error at get model run: " + msg + " " + err.Error())
+	}
+	if run == nil {
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
+	}
+	omppLog.Log("Do ", theCfg.action, ": ", name, " of run: ", run.Name)
+
+	if err = db.RecalculateTableExpressions(srcDb, run.RunId, name); err != nil {
+		return errors.New("Error at recalculate output table expressions: " + name + " of run: " + run.Name + ": " + err.Error())
+	}
+	omppLog.Log("Output table expressions recalculated: ", name)
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/openmpp/go/ompp/config"
 	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
@@ -23,7 +24,7 @@ func tableValue(srcDb *sql.DB, modelId int, runOpts *config.RunOptions) error {
 		return errors.New("Error at get model run: " + msg + " " + err.Error())
 	}
 	if run == nil {
-		return errors.New("Error: model run not found")
+		return withExitCode(exitRunNotFound, errors.New("Error: model run not found"))
 	}
 	if run.Status != db.DoneRunStatus {
 		return errors.New("Error: model run not completed successfully: " + run.Name)
@@ -79,19 +80,23 @@ func tableRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, ru
 	hdr := []string{}
 	var cvtRow func(interface{}, []string) (bool, error)
 
-	cvtExpr := &db.CellExprConverter{CellTableConverter: db.CellTableConverter{
-		ModelDef:    meta,
-		Name:        name,
-		IsIdCsv:     theCfg.isIdCsv,
-		DoubleFmt:   theCfg.doubleFmt,
-		IsNoZeroCsv: runOpts.Bool(noZeroArgKey),
-		IsNoNullCsv: runOpts.Bool(noNullArgKey),
-	}}
+	cvtExpr := &db.CellExprConverter{
+		CellTableConverter: db.CellTableConverter{
+			ModelDef:    meta,
+			Name:        name,
+			IsIdCsv:     theCfg.isIdCsv,
+			DoubleFmt:   theCfg.doubleFmt,
+			IsNoZeroCsv: runOpts.Bool(noZeroArgKey),
+			IsNoNullCsv: runOpts.Bool(noNullArgKey),
+		},
+		IsUseDecimals: theCfg.isUseDecimals,
+	}
 	tblLt := db.ReadTableLayout{
 		ReadLayout: db.ReadLayout{
 			Name:   name,
 			FromId: runId,
 		},
+		ReadMarginLayout: tableMarginLayout(runOpts),
 	}
 
 	if theCfg.isNoLang || theCfg.isIdCsv {
@@ -139,8 +144,8 @@ func tableRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, ru
 		}
 	}
 
-	// start csv output to file or console
-	f, csvWr, err := createCsvWriter(path)
+	// start csv or sql output to file or console
+	f, csvWr, err := createChunkWriter(path, name)
 	if err != nil {
 		return err
 	}
@@ -194,7 +199,7 @@ func tableRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, ru
 	}
 
 	// read output table values
-	_, err = db.ReadOutputTableTo(srcDb, meta, &tblLt, cvtWr)
+	_, err = db.ReadOutputTablePartsTo(srcDb, meta, &tblLt, theCfg.threadCount, cvtWr)
 	if err != nil {
 		return errors.New("Error at output table output: " + name + ": " + err.Error())
 	}
@@ -203,3 +208,11 @@ func tableRunValue(srcDb *sql.DB, meta *db.ModelMeta, name string, runId int, ru
 
 	return nil
 }
+
+// return output table dimensions to compute total item on read from -dbget.Margin and aggregation from -dbget.MarginAggr
+func tableMarginLayout(runOpts *config.RunOptions) db.ReadMarginLayout {
+	return db.ReadMarginLayout{
+		Margin:     helper.ParseCsvLine(runOpts.String(marginArgKey), ','),
+		MarginAggr: runOpts.String(marginAggrArgKey),
+	}
+}
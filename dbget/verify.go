@@ -0,0 +1,198 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"crypto/md5"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// max number of csv files verified at the same time
+const maxVerifyJobs = 4
+
+// verifyWriter is a csv writer which calculate digest of all rows and keep sample of rows written into csv file.
+// After csv file flushed it is verified: file is read back and row count, digest and sampled rows are compared.
+type verifyWriter struct {
+	rowWriter                  // csv or tsv writer
+	path      string           // csv file path
+	comma     rune             // values delimiter
+	nRow      int64            // number of rows written, including header
+	hash      hash.Hash        // digest of all rows
+	samples   map[int64]string // sampled rows by row number: rows 1, 2, 4, 8, 16,...
+	isDone    bool             // if true then verification already started
+}
+
+// csv files verification state: running verifications and list of mismatches
+var theVerify = struct {
+	wg       sync.WaitGroup
+	sem      chan struct{}
+	lock     sync.Mutex
+	nFiles   int
+	mismatch []string
+}{
+	sem: make(chan struct{}, maxVerifyJobs),
+}
+
+// return csv writer which verify csv file after flush, if -dbget.Verify option specified
+func newVerifyWriter(wr rowWriter, path string) rowWriter {
+	if !theCfg.isVerify || path == "" || theCfg.kind != asCsv && theCfg.kind != asTsv {
+		return wr
+	}
+	return &verifyWriter{
+		rowWriter: wr,
+		path:      path,
+		comma:     theCfg.csvDelimiter,
+		hash:      md5.New(),
+		samples:   map[int64]string{},
+	}
+}
+
+// Write row into csv and append it to rows digest
+func (vw *verifyWriter) Write(row []string) error {
+
+	if err := vw.rowWriter.Write(row); err != nil {
+		return err
+	}
+	vw.nRow++
+
+	s := rowDigestLine(row)
+	vw.hash.Write([]byte(s))
+
+	if vw.nRow&(vw.nRow-1) == 0 { // sample rows: 1, 2, 4, 8, 16,...
+		vw.samples[vw.nRow] = s
+	}
+	return nil
+}
+
+// Flush csv rows and start csv file verification
+func (vw *verifyWriter) Flush() {
+
+	vw.rowWriter.Flush()
+
+	if vw.isDone || vw.rowWriter.Error() != nil {
+		return
+	}
+	vw.isDone = true
+
+	theVerify.wg.Add(1)
+	go func(path string, comma rune, nRow int64, digest string, samples map[int64]string) {
+		defer theVerify.wg.Done()
+
+		theVerify.sem <- struct{}{}
+		defer func() { <-theVerify.sem }()
+
+		err := verifyCsvFile(path, comma, nRow, digest, samples)
+
+		theVerify.lock.Lock()
+		defer theVerify.lock.Unlock()
+
+		theVerify.nFiles++
+		if err != nil {
+			theVerify.mismatch = append(theVerify.mismatch, path+": "+err.Error())
+		}
+	}(vw.path, vw.comma, vw.nRow, fmt.Sprintf("%x", vw.hash.Sum(nil)), vw.samples)
+}
+
+// read csv file and compare row count, rows digest and sampled rows with rows written into that file
+func verifyCsvFile(path string, comma rune, nRow int64, digest string, samples map[int64]string) error {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.New("unable to open: " + err.Error())
+	}
+	defer f.Close()
+
+	// skip header comments, if any
+	var r io.Reader = f
+	if theCfg.isHdrComments {
+		r = helper.NewCsvCommentReader(f, theCfg.commentChar)
+	}
+
+	rd := csv.NewReader(r)
+	rd.Comma = comma
+	rd.FieldsPerRecord = -1
+	rd.LazyQuotes = theCfg.csvQuote == "none" // values are not quoted and may contain " quotes
+
+	h := md5.New()
+	var n int64
+	nFirst := int64(0) // first mismatched sample row
+
+	for {
+		row, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.New("unable to read row " + strconv.FormatInt(n+1, 10) + ": " + err.Error())
+		}
+		n++
+		if n == 1 {
+			row = helper.TrimCsvBom(row) // utf-8 bom written into file if -dbget.Utf8Bom specified
+		}
+
+		s := rowDigestLine(row)
+		h.Write([]byte(s))
+
+		if nFirst == 0 {
+			if sv, ok := samples[n]; ok && sv != s {
+				nFirst = n
+			}
+		}
+	}
+
+	if n != nRow {
+		return errors.New("row count mismatch: " + strconv.FormatInt(n, 10) + ", expected: " + strconv.FormatInt(nRow, 10))
+	}
+	if fmt.Sprintf("%x", h.Sum(nil)) != digest {
+		if nFirst > 0 {
+			return errors.New("values mismatch at row: " + strconv.FormatInt(nFirst, 10))
+		}
+		return errors.New("values digest mismatch")
+	}
+	return nil
+}
+
+// return row as digest line: values separated by unit separator and ended by record separator.
+// Csv reader replace \r\n by \n inside of quoted value and it is done here for written rows as well.
+func rowDigestLine(row []string) string {
+	s := strings.Join(row, "\x1f") + "\x1e"
+	if strings.Contains(s, "\r\n") {
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+	}
+	return s
+}
+
+// wait until all csv files verified, log summary and return error if there are any mismatches
+func verifySummary() error {
+
+	if !theCfg.isVerify {
+		return nil
+	}
+	theVerify.wg.Wait()
+
+	theVerify.lock.Lock()
+	defer theVerify.lock.Unlock()
+
+	omppLog.Log("Verified: ", theVerify.nFiles, " files")
+
+	if len(theVerify.mismatch) <= 0 {
+		return nil
+	}
+	omppLog.Log("Verification failed: ", len(theVerify.mismatch))
+	for _, s := range theVerify.mismatch {
+		omppLog.Log("  ", s)
+	}
+	return withExitCode(exitIo, errors.New("Error: verification failed, files: "+strconv.Itoa(len(theVerify.mismatch))))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// warning message and kind of warning, e.g.: language, overwrite, unknown-enum, skip-run, skip-file
+type warningPub struct {
+	Kind string // kind of warning
+	Msg  string // warning message
+}
+
+// dbget completion status written into -dbget.StatusFile
+type statusPub struct {
+	Action         string       // dbget action
+	IsSuccess      bool         // if true then dbget completed successfully
+	ExitCode       int          // process exit code
+	Error          string       // error message if dbget failed
+	StartDateTime  string       // dbget start date-time
+	UpdateDateTime string       // dbget completion date-time
+	Warnings       []warningPub // list of warnings
+	Chunks         []chunkPub   // chunked output files, if -dbget.MaxRowsPerFile specified
+}
+
+// list of warnings collected during dbget run
+var theWarnings = struct {
+	sync.Mutex
+	lst []warningPub
+}{lst: []warningPub{}}
+
+// log warning message and append to the list of warnings
+func logWarning(kind string, msg ...interface{}) {
+	m := fmt.Sprint(msg...)
+	omppLog.Log("Warning: ", m)
+	collectWarning(kind, m)
+}
+
+// append warning message to the list of warnings without logging it
+func collectWarning(kind string, msg string) {
+	theWarnings.Lock()
+	defer theWarnings.Unlock()
+	theWarnings.lst = append(theWarnings.lst, warningPub{Kind: kind, Msg: msg})
+}
+
+// return copy of the list of warnings
+func warningList() []warningPub {
+	theWarnings.Lock()
+	defer theWarnings.Unlock()
+	return append([]warningPub{}, theWarnings.lst...)
+}
+
+// log summary of all warnings collected during dbget run, summary is logged at any log level
+func warningSummary() {
+
+	wl := warningList()
+	if len(wl) <= 0 {
+		return
+	}
+	omppLog.Warning("total: ", len(wl))
+	for _, w := range wl {
+		omppLog.Warning(w.Kind, ": ", w.Msg)
+	}
+}
+
+// write dbget completion status and list of warnings into json file, if -dbget.StatusFile specified
+func writeStatusFile(startTime time.Time, err error) {
+
+	if theCfg.statusFile == "" {
+		return
+	}
+	st := statusPub{
+		Action:         theCfg.action,
+		IsSuccess:      err == nil,
+		ExitCode:       exitCodeOf(err),
+		StartDateTime:  helper.MakeDateTime(startTime),
+		UpdateDateTime: helper.MakeDateTime(time.Now()),
+		Warnings:       warningList(),
+		Chunks:         chunkList(),
+	}
+	if err != nil {
+		st.Error = err.Error()
+	}
+
+	if e := helper.ToJsonIndentFile(theCfg.statusFile, &st); e != nil {
+		omppLog.Error("Error at writing status file: ", theCfg.statusFile, ": ", e.Error())
+	}
+}
@@ -4,6 +4,17 @@
 /*
 Package config to merge run options: command line arguments and ini-file content.
 Command line arguments take precedence over ini-file.
+
+Ini-file can contain named profiles: [profile.name] sections with options, selected by -profile name.
+Profile options take precedence over other ini-file options and command line arguments take precedence over profile.
+Profile keys are full option names, for example:
+
+	[profile.archive]
+	dbget.Do     = all-runs
+	dbget.Layout = table
+	OpenM.LogToFile = true
+
+Profile name cannot contain . dot.
 */
 package config
 
@@ -21,10 +32,15 @@ import (
 
 // Standard config keys to get values from ini-file or command line arguments
 const (
-	IniFile      = "OpenM.IniFile" // ini-file path
-	IniFileShort = "ini"           // ini-file path (short form)
+	IniFile         = "OpenM.IniFile" // ini-file path
+	IniFileShort    = "ini"           // ini-file path (short form)
+	ProfileArgKey   = "OpenM.Profile" // name of ini-file profile: [profile.name] section
+	ProfileShortKey = "profile"       // name of ini-file profile (short form)
 )
 
+// prefix of ini-file profile section name: [profile.name]
+const profilePrefix = "profile."
+
 /*
 Log config keys.
 Log can be enabled/disabled for two independent streams:
@@ -53,6 +69,10 @@ type RunOptions struct {
 	KeyValue        map[string]string // (key=>value) from command line arguments and ini-file
 	DefaultKeyValue map[string]string // default (key=>value), if non-empty default for command line argument
 	iniPath         string            // path to ini-file
+	profile         string            // name of ini-file profile
+
+	// ini-file profiles: profile name => (key => value)
+	profiles map[string]map[string]string
 }
 
 // LogOptions for console and log file output
@@ -86,6 +106,16 @@ type FullShort struct {
 // 2. *LogOptions: openM++ log file settings, also merge of command line and ini-file.
 // 3. error or nil on success
 func New(encodingKey string, isExtra bool, optFs []FullShort) (*RunOptions, *LogOptions, error) {
+	return NewWithProfile(encodingKey, "", isExtra, optFs)
+}
+
+// NewWithProfile combines command-line arguments, ini-file profile and ini-file options, see New().
+//
+// Profile is an ini-file section [profile.name] selected by -profile name or by OpenM.Profile ini-file option.
+// If profile not specified and profileKey is not empty then value of profileKey option is a default profile name,
+// for example, if profileKey is dbget.Do and command line is: -dbget.Do all-runs then [profile.all-runs] used, if it exists.
+// It is an error if profile specified by -profile name does not exist in ini-file.
+func NewWithProfile(encodingKey string, profileKey string, isExtra bool, optFs []FullShort) (*RunOptions, *LogOptions, error) {
 
 	runOpts := &RunOptions{
 		KeyValue:        make(map[string]string),
@@ -122,34 +152,85 @@ func New(encodingKey string, isExtra bool, optFs []FullShort) (*RunOptions, *Log
 		runOpts.KeyValue = kvIni
 	}
 
+	// move profiles out of ini-file options: [profile.name] key = value
+	profiles, err := splitProfiles(runOpts.KeyValue, optFs)
+	if err != nil {
+		return nil, nil, err
+	}
+	runOpts.profiles = profiles
+
 	// validate ini-file flags: all keys should be defined flag names
 	if !isExtra {
-		for key := range kvIni {
+		for key := range runOpts.KeyValue {
 			if f := flag.Lookup(key); f == nil {
 				return nil, nil, errors.New("Invalid ini file section.key: " + key)
 			}
 		}
+		for name, kv := range profiles {
+			for key := range kv {
+				if f := flag.Lookup(key); f == nil {
+					return nil, nil, errors.New("Invalid ini file profile " + name + " key: " + key)
+				}
+			}
+		}
 	}
 
-	// override ini-file values with command-line arguments
+	// command-line arguments
+	argKv := map[string]string{}
+
 	flag.Visit(func(f *flag.Flag) {
 		if f.Name == IniFile || f.Name == IniFileShort {
-			runOpts.KeyValue[IniFile] = runOpts.iniPath
+			argKv[IniFile] = runOpts.iniPath
+			return
+		}
+		if f.Name == ProfileArgKey || f.Name == ProfileShortKey {
+			argKv[ProfileArgKey] = runOpts.profile
 			return
 		}
 		if f.Name == LogToConsoleArgKey || f.Name == LogToConsoleShortKey {
-			runOpts.KeyValue[LogToConsoleArgKey] = strconv.FormatBool(logOpts.IsConsole)
+			argKv[LogToConsoleArgKey] = strconv.FormatBool(logOpts.IsConsole)
 			return
 		}
 		for _, fs := range optFs {
 			if f.Name == fs.Full || f.Name == fs.Short {
-				runOpts.KeyValue[fs.Full] = f.Value.String()
+				argKv[fs.Full] = f.Value.String()
 				return
 			}
 		}
-		runOpts.KeyValue[f.Name] = f.Value.String()
+		argKv[f.Name] = f.Value.String()
 	})
 
+	// find profile: by command line -profile name or ini-file OpenM.Profile or by default profile key value
+	pName, isProfile := argKv[ProfileArgKey]
+	if !isProfile {
+		pName, isProfile = runOpts.KeyValue[ProfileArgKey]
+	}
+	if !isProfile && profileKey != "" {
+		if v, ok := argKv[profileKey]; ok {
+			pName = v
+		} else {
+			pName = runOpts.KeyValue[profileKey]
+		}
+	}
+	if pName != "" {
+		kv, ok := profiles[pName]
+		if !ok && isProfile {
+			return nil, nil, errors.New("Invalid profile, ini file section not found: [" + profilePrefix + pName + "]")
+		}
+		// override ini-file values with profile values
+		if ok {
+			for key, val := range kv {
+				runOpts.KeyValue[key] = val
+			}
+			runOpts.KeyValue[ProfileArgKey] = pName
+		}
+	}
+
+	// override ini-file and profile values with command-line arguments
+	for key, val := range argKv {
+		runOpts.KeyValue[key] = val
+	}
+
 	// set default (key,value) from flag defaults if not empty
 	flag.VisitAll(func(f *flag.Flag) {
 		if f.DefValue == "" {
@@ -159,6 +240,9 @@ func New(encodingKey string, isExtra bool, optFs []FullShort) (*RunOptions, *Log
 		if n == IniFileShort {
 			n = IniFile
 		}
+		if n == ProfileShortKey {
+			n = ProfileArgKey
+		}
 		if n == LogToConsoleShortKey {
 			n = LogToConsoleArgKey
 		}
@@ -204,6 +288,23 @@ func FromIni(iniPath string, encodingName string) (*RunOptions, error) {
 	return runOpts, nil
 }
 
+// ProfileKeyValue return copy of ini-file profile [profile.name] options: (key => value), return false if profile not found.
+// Short key names in profile are mapped to full names.
+func (opts *RunOptions) ProfileKeyValue(name string) (map[string]string, bool) {
+	if opts == nil || opts.profiles == nil {
+		return nil, false
+	}
+	kv, ok := opts.profiles[name]
+	if !ok {
+		return nil, false
+	}
+	pkv := make(map[string]string, len(kv))
+	for key, val := range kv {
+		pkv[key] = val
+	}
+	return pkv, true
+}
+
 // IsExist return true if key is defined as command line argument or ini-file option.
 func (opts *RunOptions) IsExist(key string) bool {
 	if opts == nil || opts.KeyValue == nil {
@@ -305,11 +406,47 @@ func (opts *RunOptions) Float(key string, defaultValue float64) float64 {
 	return defaultValue
 }
 
+// move profiles out of ini-file options and return map of profiles: profile name => (key => value).
+// Profile options in ini-file are: profile.name.key = value, short key names mapped to full names.
+func splitProfiles(kvIni map[string]string, optFs []FullShort) (map[string]map[string]string, error) {
+
+	profiles := map[string]map[string]string{}
+
+	for key, val := range kvIni {
+
+		if !strings.HasPrefix(key, profilePrefix) {
+			continue
+		}
+		delete(kvIni, key)
+
+		name, k, ok := strings.Cut(key[len(profilePrefix):], ".")
+		if !ok || name == "" || k == "" {
+			return nil, errors.New("Invalid ini file profile key: " + key)
+		}
+		if k == LogToConsoleShortKey {
+			k = LogToConsoleArgKey
+		}
+		for _, fs := range optFs {
+			if k == fs.Short {
+				k = fs.Full
+				break
+			}
+		}
+		if profiles[name] == nil {
+			profiles[name] = map[string]string{}
+		}
+		profiles[name][k] = val
+	}
+	return profiles, nil
+}
+
 // add "standard" config options to command line arguments
 func addStandardFlags(runOpts *RunOptions, logOpts *LogOptions) {
 
 	flag.StringVar(&runOpts.iniPath, IniFile, "", "path to `ini-file`")
 	flag.StringVar(&runOpts.iniPath, IniFileShort, "", "path to `ini-file` (short of "+IniFile+")")
+	flag.StringVar(&runOpts.profile, ProfileArgKey, "", "name of ini-file profile: [profile.name] section")
+	flag.StringVar(&runOpts.profile, ProfileShortKey, "", "name of ini-file profile (short of "+ProfileArgKey+")")
 
 	// add log options to command line arguments
 	flag.BoolVar(&logOpts.IsConsole, LogToConsoleArgKey, true, "if true then log to standard output")
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package config
+
+import (
+	"testing"
+)
+
+func TestSplitProfiles(t *testing.T) {
+
+	kvIni, err := loadIni(`
+[dbget]
+Do = model-list
+
+[profile.archive]
+dbget.Do     = all-runs
+dbget.Layout = table
+v            = false
+
+[profile.quick-check]
+m = modelOne
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, err := splitProfiles(kvIni, []FullShort{{Full: "dbget.ModelName", Short: "m"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// profile keys must be removed from ini-file options
+	if len(kvIni) != 1 || kvIni["dbget.Do"] != "model-list" {
+		t.Errorf("invalid ini-file options: %v", kvIni)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("invalid profiles: %v", profiles)
+	}
+
+	check := func(name, key, expected string) {
+		val, ok := profiles[name][key]
+		if !ok || val != expected {
+			t.Errorf("profile %s key %s: %s expected: %s", name, key, val, expected)
+		}
+	}
+	check("archive", "dbget.Do", "all-runs")
+	check("archive", "dbget.Layout", "table")
+	check("archive", LogToConsoleArgKey, "false")
+	check("quick-check", "dbget.ModelName", "modelOne")
+
+	// profile section without key name is an error
+	kvIni = map[string]string{"profile.archive": "value"}
+	if _, err = splitProfiles(kvIni, nil); err == nil {
+		t.Error("expected error for invalid profile key")
+	}
+}
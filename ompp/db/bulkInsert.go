@@ -0,0 +1,149 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// max number of rows in one multi-row INSERT statement
+const bulkInsertMaxRows = 1000
+
+// max number of sql statement parameters in one multi-row INSERT statement,
+// PostgreSQL and MySQL limit is 65535 parameters per statement
+const bulkInsertMaxParams = 32000
+
+// trxInsertBulk execute sql insert in transaction scope until put() return true.
+//
+// For PostgreSQL and MySQL it is using multi-row INSERT INTO ... VALUES (...), (...), ... statements to reduce number of round trips.
+// Native bulk protocols, COPY FROM STDIN for PostgreSQL and LOAD DATA LOCAL for MySQL,
+// are not available through ODBC driver which is used for those databases.
+// For any other database it is the same as TrxUpdateStatement(): execute insert statement for each row.
+//
+// Insert query must be: INSERT INTO table (columns) VALUES (values), where values can contain ? sql statement parameters.
+func trxInsertBulk(dbTrx *sql.Tx, dbFacet Facet, query string, put func() (bool, []interface{}, error)) error {
+
+	if dbFacet != PgSqlFacet && dbFacet != MySqlFacet {
+		return TrxUpdateStatement(dbTrx, query, put)
+	}
+	if dbTrx == nil {
+		return errors.New("invalid database transaction")
+	}
+
+	// split insert query into: INSERT INTO table (columns) VALUES and values row: (2, ?, ?, ?)
+	prefix, rowSql, nParam := splitInsertValues(query)
+	if nParam <= 0 {
+		return TrxUpdateStatement(dbTrx, query, put)
+	}
+
+	nMax := bulkInsertMaxParams / nParam
+	if nMax > bulkInsertMaxRows {
+		nMax = bulkInsertMaxRows
+	}
+	if nMax <= 1 {
+		return TrxUpdateStatement(dbTrx, query, put)
+	}
+
+	// insert rows from the buffer, prepare statement to insert full buffer only once
+	var stmt *sql.Stmt
+	defer func() {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}()
+
+	args := make([]interface{}, 0, nMax*nParam)
+	nRow := 0
+
+	flush := func() error {
+		if nRow <= 0 {
+			return nil
+		}
+		var err error
+
+		if nRow < nMax {
+			q := makeBulkInsertSql(prefix, rowSql, nRow)
+			omppLog.LogSql(q)
+			_, err = dbTrx.Exec(q, args...)
+		} else {
+			if stmt == nil {
+				q := makeBulkInsertSql(prefix, rowSql, nMax)
+				omppLog.LogSql(q)
+				if stmt, err = dbTrx.Prepare(q); err != nil {
+					return toLockedError(err)
+				}
+			}
+			_, err = stmt.Exec(args...)
+		}
+		if err != nil {
+			return toLockedError(err)
+		}
+		args = args[:0]
+		nRow = 0
+		return nil
+	}
+
+	// until put() return next row values append row into the buffer
+	// values must be copied: put() can re-use the same row slice
+	for {
+		isNext, r, err := put()
+		if err != nil {
+			return err
+		}
+		if !isNext {
+			break
+		}
+		if len(r) != nParam {
+			return errors.New("invalid number of insert statement parameters, expected: " + strconv.Itoa(nParam))
+		}
+		args = append(args, r...)
+		nRow++
+
+		if nRow >= nMax {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// splitInsertValues split insert query at last VALUES keyword and return:
+// query prefix: INSERT INTO table (columns) VALUES, values row: (2, ?, ?, ?) and number of ? parameters in values row.
+// If query is not an INSERT ... VALUES (...) then number of parameters is zero.
+func splitInsertValues(query string) (string, string, int) {
+
+	n := strings.LastIndex(query, " VALUES ")
+	if n <= 0 {
+		return "", "", 0
+	}
+	prefix := query[:n+len(" VALUES ")]
+	rowSql := strings.TrimSpace(query[n+len(" VALUES "):])
+
+	if !strings.HasPrefix(rowSql, "(") || !strings.HasSuffix(rowSql, ")") {
+		return "", "", 0
+	}
+	return prefix, rowSql, strings.Count(rowSql, "?")
+}
+
+// makeBulkInsertSql return multi-row insert: INSERT INTO table (columns) VALUES (2, ?, ?), (2, ?, ?), ...
+func makeBulkInsertSql(prefix, rowSql string, nRow int) string {
+
+	var sb strings.Builder
+	sb.Grow(len(prefix) + nRow*(len(rowSql)+2))
+
+	sb.WriteString(prefix)
+	for k := 0; k < nRow; k++ {
+		if k > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(rowSql)
+	}
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+)
+
+func TestBulkInsert(t *testing.T) {
+
+	prefix, rowSql, n := splitInsertValues("INSERT INTO ageSex_p2012817 (run_id, sub_id, dim0, param_value) VALUES (2, ?, ?, ?)")
+	if prefix != "INSERT INTO ageSex_p2012817 (run_id, sub_id, dim0, param_value) VALUES " || rowSql != "(2, ?, ?, ?)" || n != 3 {
+		t.Error("Fail to split insert query:", prefix, rowSql, n)
+	}
+	if s := makeBulkInsertSql(prefix, rowSql, 2); s != prefix+"(2, ?, ?, ?), (2, ?, ?, ?)" {
+		t.Error("Fail to make multi-row insert:", s)
+	}
+	if _, _, n = splitInsertValues("UPDATE run_lst SET sub_restart = 0"); n != 0 {
+		t.Error("Fail: expected zero parameters for non-insert query:", n)
+	}
+
+	dbConn := openTestDb(t, "CREATE TABLE ageSex_p2012817 (run_id INT NOT NULL, sub_id INT NOT NULL, dim0 INT NOT NULL, param_value FLOAT NULL)")
+
+	// insert rows as multi-row batches: two full batches and remainder
+	// put() is re-using the same row slice
+	const nRows = 2*bulkInsertMaxRows + 7
+	for _, facet := range []Facet{SqliteFacet, PgSqlFacet} {
+
+		k := 0
+		row := make([]interface{}, 3)
+		put := func() (bool, []interface{}, error) {
+			if k >= nRows {
+				return false, nil, nil
+			}
+			row[0] = int(facet)
+			row[1] = k
+			row[2] = float64(k)
+			k++
+			return true, row, nil
+		}
+
+		trx, err := dbConn.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := "INSERT INTO ageSex_p2012817 (run_id, sub_id, dim0, param_value) VALUES (2, ?, ?, ?)"
+		if err = trxInsertBulk(trx, facet, q, put); err != nil {
+			trx.Rollback()
+			t.Fatal(facet, err)
+		}
+		trx.Commit()
+
+		nCount, nSum := 0, 0
+		err = SelectFirst(dbConn,
+			"SELECT COUNT(*), SUM(dim0) FROM ageSex_p2012817 WHERE sub_id = "+strconv.Itoa(int(facet)),
+			func(row *sql.Row) error {
+				return row.Scan(&nCount, &nSum)
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nCount != nRows || nSum != nRows*(nRows-1)/2 {
+			t.Error("Fail: invalid rows inserted", facet, nCount, nSum)
+		}
+	}
+}
@@ -20,7 +20,7 @@ func CalculateOutputTable(dbConn *sql.DB, modelDef *ModelMeta, tableLt *ReadCalc
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if tableLt == nil {
 		return nil, nil, errors.New("invalid (empty) output table layout")
@@ -40,6 +40,11 @@ func CalculateOutputTable(dbConn *sql.DB, modelDef *ModelMeta, tableLt *ReadCalc
 		return nil, nil, errors.New("output table not found: " + tableLt.Name)
 	}
 
+	// base run and variant runs must be visible
+	if err := checkRunFilter(dbConn, append([]int{tableLt.FromId}, runIds...)...); err != nil {
+		return nil, nil, err
+	}
+
 	// translate calculation to sql
 	q, err := translateTableCalcToSql(modelDef, table, &tableLt.ReadLayout, tableLt.Calculation, runIds)
 	if err != nil {
@@ -80,7 +85,7 @@ func CalculateOutputTable(dbConn *sql.DB, modelDef *ModelMeta, tableLt *ReadCalc
 			c.IsNull = !vf.Valid
 			c.Value = 0.0
 			if !c.IsNull {
-				c.Value = vf.Float64
+				c.Value = floatFromDb(vf.Float64)
 			}
 			return c, nil
 		})
@@ -206,7 +211,7 @@ func CalculateMicrodata(dbConn *sql.DB, modelDef *ModelMeta, microLt *ReadCalcul
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if microLt == nil {
 		return nil, nil, errors.New("invalid (empty) microdata calculate layout")
@@ -227,6 +232,11 @@ func CalculateMicrodata(dbConn *sql.DB, modelDef *ModelMeta, microLt *ReadCalcul
 		return nil, nil, errors.New("entity not found: " + microLt.Name)
 	}
 
+	// base run and variant runs must be visible
+	if err := checkRunFilter(dbConn, append([]int{microLt.FromId}, runIds...)...); err != nil {
+		return nil, nil, err
+	}
+
 	// find entity generation by entity id, as it is today model run has only one entity generation for each entity
 	egLst, err := GetEntityGenList(dbConn, microLt.FromId)
 	if err != nil {
@@ -448,14 +458,6 @@ func makeParamCteSql(paramCols map[string]paramColumn, fromId int, runIds []int)
 	}
 	sort.Ints(extraIds)
 
-	extraLst := ""
-	for _, rId := range extraIds {
-		if extraLst != "" {
-			extraLst += ", "
-		}
-		extraLst += strconv.Itoa(rId)
-	}
-
 	// for each parameter make CTE for simple, [base] and [variant] use of parameter scalar
 	lastId := -1
 	cte := ""
@@ -506,12 +508,12 @@ func makeParamCteSql(paramCols map[string]paramColumn, fromId int, runIds []int)
 				"SELECT RP.run_id, AVG(C.param_value)" +
 				" FROM " + pCol.paramRow.DbRunTable + " C" +
 				" INNER JOIN run_parameter RP ON (RP.base_run_id = C.run_id AND RP.parameter_hid = " + sHid + ")" +
-				" WHERE RP.run_id"
+				" WHERE"
 
-			if extraLst == "" {
-				cte += " = " + strconv.Itoa(fromId)
+			if len(extraIds) <= 0 {
+				cte += " RP.run_id = " + strconv.Itoa(fromId)
 			} else {
-				cte += " IN (" + strconv.Itoa(fromId) + ", " + extraLst + ")"
+				cte += " " + makeIdInList("RP.run_id", append([]int{fromId}, extraIds...))
 			}
 			cte += " GROUP BY RP.run_id" +
 				")"
@@ -555,7 +557,7 @@ func makeParamCteSql(paramCols map[string]paramColumn, fromId int, runIds []int)
 			if cte != "" {
 				cte += ", "
 			}
-			if extraLst == "" {
+			if len(extraIds) <= 0 {
 				return "", errors.New("Invalid (empty) list of variant runs to get a parameter: " + minKey)
 			}
 			cte += "pvar_" + sHid + " (run_id, param_var) AS" +
@@ -563,7 +565,7 @@ func makeParamCteSql(paramCols map[string]paramColumn, fromId int, runIds []int)
 				"SELECT RP.run_id, AVG(C.param_value)" +
 				" FROM " + pCol.paramRow.DbRunTable + " C" +
 				" INNER JOIN run_parameter RP ON (RP.base_run_id = C.run_id AND RP.parameter_hid = " + sHid + ")" +
-				" WHERE RP.run_id IN (" + extraLst + ")" +
+				" WHERE " + makeIdInList("RP.run_id", extraIds) +
 				" GROUP BY RP.run_id" +
 				")"
 		}
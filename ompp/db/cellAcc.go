@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/openmpp/go/ompp/helper"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -189,7 +190,7 @@ func (cellCvt *CellAccConverter) ToCsvIdRow() (func(interface{}, []string) (bool
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+2] = "null"
+			row[n+2] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -199,7 +200,7 @@ func (cellCvt *CellAccConverter) ToCsvIdRow() (func(interface{}, []string) (bool
 			}
 
 			if cellCvt.DoubleFmt != "" {
-				row[n+2] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+2] = formatFloat(cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+2] = fmt.Sprint(cell.Value)
 			}
@@ -263,7 +264,7 @@ func (cellCvt *CellAccConverter) ToCsvRow() (func(interface{}, []string) (bool,
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+2] = "null"
+			row[n+2] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -273,7 +274,7 @@ func (cellCvt *CellAccConverter) ToCsvRow() (func(interface{}, []string) (bool,
 			}
 
 			if cellCvt.DoubleFmt != "" {
-				row[n+2] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+2] = formatFloat(cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+2] = fmt.Sprint(cell.Value)
 			}
@@ -349,7 +350,7 @@ func (cellCvt *CellAccLocaleConverter) ToCsvRow() (func(interface{}, []string) (
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+2] = "null"
+			row[n+2] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -359,7 +360,7 @@ func (cellCvt *CellAccLocaleConverter) ToCsvRow() (func(interface{}, []string) (
 			}
 
 			if cellCvt.DoubleFmt != "" {
-				row[n+2] = prt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+2] = formatFloatLocale(prt, cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+2] = prt.Sprint(cell.Value)
 			}
@@ -438,7 +439,7 @@ func (cellCvt *CellAccConverter) ToCell() (func(row []string) (interface{}, erro
 		}
 
 		// value conversion
-		cell.IsNull = row[n+2] == "" || row[n+2] == "null"
+		cell.IsNull = helper.IsCsvNull(row[n+2])
 
 		if cell.IsNull {
 			cell.Value = 0.0
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/openmpp/go/ompp/helper"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -238,14 +239,14 @@ func (cellCvt *CellAllAccConverter) ToCsvIdRow() (func(interface{}, []string) (b
 		for k := 0; k < nAcc; k++ {
 
 			if cell.IsNull[k] {
-				row[1+nRank+k] = "null"
+				row[1+nRank+k] = helper.CsvNull
 			} else {
 
 				row[1+nRank+k] = "0"
 
 				if !cellCvt.IsNoZeroCsv || cell.Value[k] != 0 {
 					if cellCvt.DoubleFmt != "" {
-						row[1+nRank+k] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value[k])
+						row[1+nRank+k] = formatFloat(cellCvt.DoubleFmt, cell.Value[k])
 					} else {
 						row[1+nRank+k] = fmt.Sprint(cell.Value[k])
 					}
@@ -329,14 +330,14 @@ func (cellCvt *CellAllAccConverter) ToCsvRow() (func(interface{}, []string) (boo
 		for k := 0; k < nAcc; k++ {
 
 			if cell.IsNull[k] {
-				row[1+nRank+k] = "null"
+				row[1+nRank+k] = helper.CsvNull
 			} else {
 
 				row[1+nRank+k] = "0"
 
 				if !cellCvt.IsNoZeroCsv || cell.Value[k] != 0 {
 					if cellCvt.DoubleFmt != "" {
-						row[1+nRank+k] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value[k])
+						row[1+nRank+k] = formatFloat(cellCvt.DoubleFmt, cell.Value[k])
 					} else {
 						row[1+nRank+k] = fmt.Sprint(cell.Value[k])
 					}
@@ -424,14 +425,14 @@ func (cellCvt *CellAllAccLocaleConverter) ToCsvRow() (func(interface{}, []string
 		for k := 0; k < nAcc; k++ {
 
 			if cell.IsNull[k] {
-				row[1+nRank+k] = "null"
+				row[1+nRank+k] = helper.CsvNull
 			} else {
 
 				row[1+nRank+k] = "0"
 
 				if !cellCvt.IsNoZeroCsv || cell.Value[k] != 0 {
 					if cellCvt.DoubleFmt != "" {
-						row[1+nRank+k] = prt.Sprintf(cellCvt.DoubleFmt, cell.Value[k])
+						row[1+nRank+k] = formatFloatLocale(prt, cellCvt.DoubleFmt, cell.Value[k])
 					} else {
 						row[1+nRank+k] = prt.Sprint(cell.Value[k])
 					}
@@ -511,7 +512,7 @@ func (cellCvt *CellAllAccConverter) ToCell() (func(row []string) (interface{}, e
 		// value conversion
 		for k := 0; k < nAcc; k++ {
 
-			cell.IsNull[k] = row[1+nRank+k] == "" || row[1+nRank+k] == "null"
+			cell.IsNull[k] = helper.IsCsvNull(row[1+nRank+k])
 
 			if cell.IsNull[k] {
 				cell.Value[k] = 0.0
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/openmpp/go/ompp/helper"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -38,7 +39,8 @@ type CellTableConverter struct {
 
 // CellExprConverter is a converter for output table expression to implement CsvConverter interface.
 type CellExprConverter struct {
-	CellTableConverter // model metadata and output table name
+	CellTableConverter      // model metadata and output table name
+	IsUseDecimals      bool // if true then use expression decimals to format value, if expression decimals < 0 then use DoubleFmt
 }
 
 // Converter for output table expression to implement CsvLocaleConverter interface.
@@ -166,10 +168,11 @@ func (cellCvt *CellExprConverter) KeyIds(name string) (func(interface{}, []int)
 func (cellCvt *CellExprConverter) ToCsvIdRow() (func(interface{}, []string) (bool, error), error) {
 
 	// find output table by name
-	_, err := cellCvt.tableByName()
+	table, err := cellCvt.tableByName()
 	if err != nil {
 		return nil, err
 	}
+	exprFmt := cellCvt.exprValueFmt(table) // format of expression value
 
 	// return converter from id based cell to csv string array
 	cvt := func(src interface{}, row []string) (bool, error) {
@@ -194,7 +197,7 @@ func (cellCvt *CellExprConverter) ToCsvIdRow() (func(interface{}, []string) (boo
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+1] = "null"
+			row[n+1] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -203,8 +206,8 @@ func (cellCvt *CellExprConverter) ToCsvIdRow() (func(interface{}, []string) (boo
 				isNotEmpty = ok && fv != 0
 			}
 
-			if cellCvt.DoubleFmt != "" {
-				row[n+1] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+			if vf := exprFmt(cell.ExprId); vf != "" {
+				row[n+1] = formatFloat(vf, cell.Value)
 			} else {
 				row[n+1] = fmt.Sprint(cell.Value)
 			}
@@ -239,6 +242,7 @@ func (cellCvt *CellExprConverter) ToCsvRow() (func(interface{}, []string) (bool,
 		}
 		fd[k] = f
 	}
+	exprFmt := cellCvt.exprValueFmt(table) // format of expression value
 
 	cvt := func(src interface{}, row []string) (bool, error) {
 
@@ -267,7 +271,7 @@ func (cellCvt *CellExprConverter) ToCsvRow() (func(interface{}, []string) (bool,
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+1] = "null"
+			row[n+1] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -276,8 +280,8 @@ func (cellCvt *CellExprConverter) ToCsvRow() (func(interface{}, []string) (bool,
 				isNotEmpty = ok && fv != 0
 			}
 
-			if cellCvt.DoubleFmt != "" {
-				row[n+1] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+			if vf := exprFmt(cell.ExprId); vf != "" {
+				row[n+1] = formatFloat(vf, cell.Value)
 			} else {
 				row[n+1] = fmt.Sprint(cell.Value)
 			}
@@ -321,6 +325,7 @@ func (cellCvt *CellExprLocaleConverter) ToCsvRow() (func(interface{}, []string)
 
 	// format value locale-specific strings, e.g.: 1234.56 => 1 234,56
 	prt := message.NewPrinter(language.Make(cellCvt.Lang))
+	exprFmt := cellCvt.exprValueFmt(table)
 
 	cvt := func(src interface{}, row []string) (bool, error) {
 
@@ -352,7 +357,7 @@ func (cellCvt *CellExprLocaleConverter) ToCsvRow() (func(interface{}, []string)
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+1] = "null"
+			row[n+1] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -361,8 +366,8 @@ func (cellCvt *CellExprLocaleConverter) ToCsvRow() (func(interface{}, []string)
 				isNotEmpty = ok && fv != 0
 			}
 
-			if cellCvt.DoubleFmt != "" {
-				row[n+1] = prt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+			if vf := exprFmt(cell.ExprId); vf != "" {
+				row[n+1] = formatFloatLocale(prt, vf, cell.Value)
 			} else {
 				row[n+1] = prt.Sprint(cell.Value)
 			}
@@ -429,7 +434,7 @@ func (cellCvt *CellExprConverter) ToCell() (func(row []string) (interface{}, err
 		}
 
 		// value conversion
-		cell.IsNull = row[n+1] == "" || row[n+1] == "null"
+		cell.IsNull = helper.IsCsvNull(row[n+1])
 
 		if cell.IsNull {
 			cell.Value = 0.0
@@ -514,7 +519,7 @@ func (cellCvt *CellTableConverter) tableByName() (*TableMeta, error) {
 
 	// validate parameters
 	if cellCvt.ModelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if cellCvt.Name == "" {
 		return nil, errors.New("invalid (empty) output table name")
@@ -530,6 +535,28 @@ func (cellCvt *CellTableConverter) tableByName() (*TableMeta, error) {
 	return cellCvt.theTable, nil
 }
 
+// Return function to get format of expression value by expression id.
+// If IsUseDecimals is true and expression decimals >= 0 then format is "%.Nf", where N is expression decimals, else it is DoubleFmt.
+func (cellCvt *CellExprConverter) exprValueFmt(table *TableMeta) func(exprId int) string {
+
+	fmtMap := make(map[int]string, len(table.Expr))
+
+	if cellCvt.IsUseDecimals {
+		for j := range table.Expr {
+			if table.Expr[j].Decimals >= 0 {
+				fmtMap[table.Expr[j].ExprId] = "%." + strconv.Itoa(table.Expr[j].Decimals) + "f"
+			}
+		}
+	}
+
+	return func(exprId int) string {
+		if f, ok := fmtMap[exprId]; ok {
+			return f
+		}
+		return cellCvt.DoubleFmt
+	}
+}
+
 // Return converter from expression id to language-specific label.
 // Converter return expression description by expression id and language.
 // If language code or description is empty then return expression name
@@ -44,6 +44,7 @@ type CellEntityConverter struct {
 	DoubleFmt   string          // if not empty then format string is used to sprintf if value type is float, double, long double
 	IsNoZeroCsv bool            // if true then do not write zero values into csv output
 	IsNoNullCsv bool            // if true then do not write NULL values into csv output
+	Attrs       []string        // if not empty then use only those attributes, in the order of entity generation
 	theEntity   *EntityMeta     // if not nil then entity found
 	theAttrs    []EntityAttrRow // if not empty then entity generation attributes
 }
@@ -155,7 +156,7 @@ func (cellCvt *CellMicroConverter) ToCsvIdRow() (func(interface{}, []string) (bo
 
 		// for float attributes use format if specified
 		if cellCvt.DoubleFmt != "" && ea.typeOf.IsFloat() {
-			fd[k] = func(v interface{}) string { return fmt.Sprintf(cellCvt.DoubleFmt, v) }
+			fd[k] = func(v interface{}) string { return formatFloat(cellCvt.DoubleFmt, v) }
 		} else {
 			fd[k] = func(v interface{}) string { return fmt.Sprint(v) }
 		}
@@ -187,7 +188,7 @@ func (cellCvt *CellMicroConverter) ToCsvIdRow() (func(interface{}, []string) (bo
 
 			// use "null" string for db NULL values
 			if a.IsNull || a.Value == nil {
-				row[k+1] = "null"
+				row[k+1] = helper.CsvNull
 			} else {
 				row[k+1] = fd[k](a.Value)
 			}
@@ -225,7 +226,7 @@ func (cellCvt *CellMicroConverter) ToCsvRow() (func(interface{}, []string) (bool
 
 			// for float attributes use format if specified
 			if cellCvt.DoubleFmt != "" && ea.typeOf.IsFloat() {
-				fd[k] = func(v interface{}) (string, error) { return fmt.Sprintf(cellCvt.DoubleFmt, v), nil }
+				fd[k] = func(v interface{}) (string, error) { return formatFloat(cellCvt.DoubleFmt, v), nil }
 			} else {
 				fd[k] = func(v interface{}) (string, error) { return fmt.Sprint(v), nil }
 			}
@@ -275,7 +276,7 @@ func (cellCvt *CellMicroConverter) ToCsvRow() (func(interface{}, []string) (bool
 
 			// use "null" string for db NULL values
 			if a.IsNull || a.Value == nil {
-				row[k+1] = "null"
+				row[k+1] = helper.CsvNull
 			} else {
 				if s, e := fd[k](a.Value); e != nil { // use attribute value converter
 					return false, e
@@ -318,7 +319,7 @@ func (cellCvt *CellMicroLocaleConverter) ToCsvRow() (func(interface{}, []string)
 
 			// for float attributes use format if specified
 			if cellCvt.DoubleFmt != "" && ea.typeOf.IsFloat() {
-				fd[k] = func(v interface{}) (string, error) { return prt.Sprintf(cellCvt.DoubleFmt, v), nil }
+				fd[k] = func(v interface{}) (string, error) { return formatFloatLocale(prt, cellCvt.DoubleFmt, v), nil }
 			} else {
 				fd[k] = func(v interface{}) (string, error) { return prt.Sprint(v), nil }
 			}
@@ -369,7 +370,7 @@ func (cellCvt *CellMicroLocaleConverter) ToCsvRow() (func(interface{}, []string)
 
 			// use "null" string for db NULL values
 			if a.IsNull || a.Value == nil {
-				row[k+1] = "null"
+				row[k+1] = helper.CsvNull
 			} else {
 				if s, e := fd[k](a.Value); e != nil { // use attribute value converter
 					return false, e
@@ -500,7 +501,7 @@ func (cellCvt *CellMicroConverter) ToCell() (func(row []string) (interface{}, er
 		}
 
 		// convert microdata key, it is uint 64 bit
-		if row[0] == "" || row[0] == "null" {
+		if helper.IsCsvNull(row[0]) {
 			return nil, errors.New("invalid microdata key, it cannot be NULL: " + cellCvt.Name)
 		}
 
@@ -513,7 +514,7 @@ func (cellCvt *CellMicroConverter) ToCell() (func(row []string) (interface{}, er
 		// convert attributes
 		for k := 0; k < nAttr; k++ {
 
-			cell.Attr[k].IsNull = row[k+1] == "" || row[k+1] == "null"
+			cell.Attr[k].IsNull = helper.IsCsvNull(row[k+1])
 
 			if !cell.Attr[k].IsNull {
 				v, e := fd[k](row[k+1])
@@ -710,7 +711,7 @@ func (cellCvt *CellEntityConverter) entityByName() (*EntityMeta, error) {
 
 	// validate parameters
 	if cellCvt.ModelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if cellCvt.Name == "" {
 		return nil, errors.New("invalid (empty) entity name")
@@ -744,15 +745,9 @@ func (cellCvt *CellEntityConverter) entityAttrs() (*EntityMeta, []EntityAttrRow,
 	}
 
 	// collect generation attribues
-	attrs := make([]EntityAttrRow, len(cellCvt.EntityGen.GenAttr))
-
-	for k, ga := range cellCvt.EntityGen.GenAttr {
-
-		aIdx, ok := ent.AttrByKey(ga.AttrId)
-		if !ok {
-			return nil, []EntityAttrRow{}, errors.New("entity attribute not found by id: " + strconv.Itoa(ga.AttrId) + " " + cellCvt.Name)
-		}
-		attrs[k] = ent.Attr[aIdx]
+	attrs, err := genEntityAttrs(ent, cellCvt.EntityGen, cellCvt.Attrs)
+	if err != nil {
+		return nil, []EntityAttrRow{}, err
 	}
 	cellCvt.theAttrs = attrs
 
@@ -168,7 +168,7 @@ func (cellCvt *CellMicroCalcConverter) ToCsvIdRow() (func(interface{}, []string)
 
 	// for calculated value use format if specified
 	if cellCvt.DoubleFmt != "" {
-		fa[nGrp] = func(v interface{}) string { return fmt.Sprintf(cellCvt.DoubleFmt, v) }
+		fa[nGrp] = func(v interface{}) string { return formatFloat(cellCvt.DoubleFmt, v) }
 	} else {
 		fa[nGrp] = func(v interface{}) string { return fmt.Sprint(v) }
 	}
@@ -195,7 +195,7 @@ func (cellCvt *CellMicroCalcConverter) ToCsvIdRow() (func(interface{}, []string)
 
 			// use "null" string for db NULL values
 			if a.IsNull || a.Value == nil {
-				row[k+2] = "null"
+				row[k+2] = helper.CsvNull
 			} else {
 				row[k+2] = fa[k](a.Value)
 			}
@@ -259,7 +259,7 @@ func (cellCvt *CellMicroCalcConverter) ToCsvRow() (func(interface{}, []string) (
 
 	// for calculated value use format if specified
 	if cellCvt.DoubleFmt != "" {
-		fa[nGrp] = func(v interface{}) (string, error) { return fmt.Sprintf(cellCvt.DoubleFmt, v), nil }
+		fa[nGrp] = func(v interface{}) (string, error) { return formatFloat(cellCvt.DoubleFmt, v), nil }
 	} else {
 		fa[nGrp] = func(v interface{}) (string, error) { return fmt.Sprint(v), nil }
 	}
@@ -292,7 +292,7 @@ func (cellCvt *CellMicroCalcConverter) ToCsvRow() (func(interface{}, []string) (
 
 			// use "null" string for db NULL values
 			if a.IsNull || a.Value == nil {
-				row[k+2] = "null"
+				row[k+2] = helper.CsvNull
 			} else {
 				if s, e := fa[k](a.Value); e != nil { // use attribute value converter
 					return false, e
@@ -362,7 +362,7 @@ func (cellCvt *CellMicroCalcLocaleConverter) ToCsvRow() (func(interface{}, []str
 
 	// for calculated value use locale-specific Sprint or Sprintf if format if specified
 	if cellCvt.DoubleFmt != "" {
-		fa[nGrp] = func(v interface{}) (string, error) { return prt.Sprintf(cellCvt.DoubleFmt, v), nil }
+		fa[nGrp] = func(v interface{}) (string, error) { return formatFloatLocale(prt, cellCvt.DoubleFmt, v), nil }
 	} else {
 		fa[nGrp] = func(v interface{}) (string, error) { return prt.Sprint(v), nil }
 	}
@@ -395,7 +395,7 @@ func (cellCvt *CellMicroCalcLocaleConverter) ToCsvRow() (func(interface{}, []str
 
 			// use "null" string for db NULL values
 			if a.IsNull || a.Value == nil {
-				row[k+2] = "null"
+				row[k+2] = helper.CsvNull
 			} else {
 				if s, e := fa[k](a.Value); e != nil { // use attribute value converter
 					return false, e
@@ -28,11 +28,12 @@ type CellCodeParam struct {
 
 // CellParamConverter is a converter for input parameter to implement CsvConverter interface.
 type CellParamConverter struct {
-	ModelDef  *ModelMeta // model metadata
-	Name      string     // parameter name
-	IsIdCsv   bool       // if true then use enum id's else use enum codes
-	DoubleFmt string     // if not empty then format string is used to sprintf if value type is float, double, long double
-	theParam  *ParamMeta // if not nil then parameter found
+	ModelDef   *ModelMeta // model metadata
+	Name       string     // parameter name
+	IsIdCsv    bool       // if true then use enum id's else use enum codes
+	IsCodeOrId bool       // if true then csv enum items can be enum codes or enum id's, used by ToCell() only
+	DoubleFmt  string     // if not empty then format string is used to sprintf if value type is float, double, long double
+	theParam   *ParamMeta // if not nil then parameter found
 }
 
 // Converter for input parameter to implement CsvLocaleConverter interface.
@@ -180,10 +181,10 @@ func (cellCvt *CellParamConverter) ToCsvIdRow() (func(interface{}, []string) (bo
 
 		// use "null" string for db NULL values and format for model float types
 		if cell.IsNull {
-			row[n+1] = "null"
+			row[n+1] = helper.CsvNull
 		} else {
 			if isUseFmt {
-				row[n+1] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+1] = formatFloat(cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+1] = fmt.Sprint(cell.Value)
 			}
@@ -261,10 +262,10 @@ func (cellCvt *CellParamConverter) ToCsvRow() (func(interface{}, []string) (bool
 		// use "null" string for db NULL values and format for model float types
 		switch {
 		case cell.IsNull:
-			row[n+1] = "null"
+			row[n+1] = helper.CsvNull
 
 		case isUseFmt:
-			row[n+1] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+			row[n+1] = formatFloat(cellCvt.DoubleFmt, cell.Value)
 
 		case isUseEnum:
 			// depending on sql + driver it can be different type
@@ -362,10 +363,10 @@ func (cellCvt *CellParamLocaleConverter) ToCsvRow() (func(interface{}, []string)
 		// use "null" string for db NULL values and format for model float types
 		switch {
 		case cell.IsNull:
-			row[n+1] = "null"
+			row[n+1] = helper.CsvNull
 
 		case isUseFmt:
-			row[n+1] = prt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+			row[n+1] = formatFloatLocale(prt, cellCvt.DoubleFmt, cell.Value)
 
 		case isUseEnum:
 			// depending on sql + driver it can be different type
@@ -411,6 +412,7 @@ func (cellCvt *CellParamLocaleConverter) ToCsvRow() (func(interface{}, []string)
 // It does return error if len(row) not equal to number of fields in cell db-record.
 // If dimension type is enum based then csv row is enum code and it is converted into cell.DimIds (into dimension type type enum ids).
 // If parameter type is enum based then csv row value is enum code and it is converted into value enum id.
+// If IsCodeOrId is true then csv row dimension items and enum-based value can be enum codes or enum id's.
 func (cellCvt *CellParamConverter) ToCell() (func(row []string) (interface{}, error), error) {
 
 	// find parameter by name
@@ -419,11 +421,19 @@ func (cellCvt *CellParamConverter) ToCell() (func(row []string) (interface{}, er
 		return nil, err
 	}
 
+	// converter from enum code to id or, if IsCodeOrId is true, from enum code or id to id
+	toId := func(typeOf *TypeMeta, msgName string) (func(src string) (int, error), error) {
+		if cellCvt.IsCodeOrId {
+			return typeOf.itemCodeOrIdToId(msgName, false)
+		}
+		return typeOf.itemCodeToId(msgName, false)
+	}
+
 	// for each dimension create converter from item code to id
 	fd := make([]func(src string) (int, error), param.Rank)
 
 	for k := 0; k < param.Rank; k++ {
-		f, err := param.Dim[k].typeOf.itemCodeToId(cellCvt.Name+"."+param.Dim[k].Name, false)
+		f, err := toId(param.Dim[k].typeOf, cellCvt.Name+"."+param.Dim[k].Name)
 		if err != nil {
 			return nil, err
 		}
@@ -440,7 +450,7 @@ func (cellCvt *CellParamConverter) ToCell() (func(row []string) (interface{}, er
 
 	switch {
 	case isEnum:
-		f, err := param.typeOf.itemCodeToId(cellCvt.Name, false)
+		f, err := toId(param.typeOf, cellCvt.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -448,7 +458,7 @@ func (cellCvt *CellParamConverter) ToCell() (func(row []string) (interface{}, er
 	case isFloat:
 		ff = func(src string) (bool, float64, error) {
 
-			if src == "" || src == "null" {
+			if helper.IsCsvNull(src) {
 				if isNullable {
 					return true, 0.0, nil
 				}
@@ -714,7 +724,7 @@ func (cellCvt *CellParamConverter) paramByName() (*ParamMeta, error) {
 
 	// validate parameters
 	if cellCvt.ModelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if cellCvt.Name == "" {
 		return nil, errors.New("invalid (empty) parameter name")
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/openmpp/go/ompp/helper"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
@@ -202,7 +203,7 @@ func (cellCvt *CellTableCalcConverter) ToCsvIdRow() (func(interface{}, []string)
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+2] = "null"
+			row[n+2] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -212,7 +213,7 @@ func (cellCvt *CellTableCalcConverter) ToCsvIdRow() (func(interface{}, []string)
 			}
 
 			if cellCvt.DoubleFmt != "" {
-				row[n+2] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+2] = formatFloat(cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+2] = fmt.Sprint(cell.Value)
 			}
@@ -284,7 +285,7 @@ func (cellCvt *CellTableCalcConverter) ToCsvRow() (func(interface{}, []string) (
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+2] = "null"
+			row[n+2] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -294,7 +295,7 @@ func (cellCvt *CellTableCalcConverter) ToCsvRow() (func(interface{}, []string) (
 			}
 
 			if cellCvt.DoubleFmt != "" {
-				row[n+2] = fmt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+2] = formatFloat(cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+2] = fmt.Sprint(cell.Value)
 			}
@@ -368,7 +369,7 @@ func (cellCvt *CellTableCalcLocaleConverter) ToCsvRow() (func(interface{}, []str
 		isNotEmpty := true
 
 		if cell.IsNull {
-			row[n+2] = "null"
+			row[n+2] = helper.CsvNull
 			isNotEmpty = !cellCvt.IsNoNullCsv
 		} else {
 
@@ -378,7 +379,7 @@ func (cellCvt *CellTableCalcLocaleConverter) ToCsvRow() (func(interface{}, []str
 			}
 
 			if cellCvt.DoubleFmt != "" {
-				row[n+2] = prt.Sprintf(cellCvt.DoubleFmt, cell.Value)
+				row[n+2] = formatFloatLocale(prt, cellCvt.DoubleFmt, cell.Value)
 			} else {
 				row[n+2] = prt.Sprint(cell.Value)
 			}
@@ -135,6 +135,7 @@ type CellToIdConverter interface {
 // If dimension is enum-based then from enum id to enum code or to the "all" total enum code;
 // If dimension is simple integer type then use Itoa(integer id) as code;
 // If dimension is boolean then 0=>false, (1 or -1)=>true else error
+// If enum id not found then it is an error or empty code or id, see SetUnknownEnum()
 func (typeOf *TypeMeta) itemIdToCode(msgName string, isTotalEnabled bool) (func(itemId int) (string, error), error) {
 
 	var cvt func(itemId int) (string, error)
@@ -160,7 +161,7 @@ func (typeOf *TypeMeta) itemIdToCode(msgName string, isTotalEnabled bool) (func(
 					return strconv.Itoa(itemId), nil
 				}
 			}
-			return "", errors.New("invalid value: " + strconv.Itoa(itemId) + " of: " + msgName)
+			return unknownEnumToCode(itemId, msgName) // enum id not found: error or empty or id
 		}
 
 	case typeOf.IsBool(): // boolean dimension: 0=>false, (1 or -1)=>true else error
@@ -197,6 +198,8 @@ func (typeOf *TypeMeta) itemIdToCode(msgName string, isTotalEnabled bool) (func(
 // If dimension is enum-based then from enum id to enum description or to the "all" total enum label;
 // If dimension is simple integer type then use Itoa(integer id) as code;
 // If dimension is boolean then 0=>false, (1 or -1)=>true else error
+// If enum id not found then it is an error or empty label or id, see SetUnknownEnum()
+// If custom labels defined for the type then custom labels used instead of enum descriptions, see SetLabelMap()
 func (typeOf *TypeMeta) itemIdToLabel(lang string, enumTxt []TypeEnumTxtRow, langDef *LangMeta, msgName string, isTotalEnabled bool) (func(itemId int) (string, error), error) {
 
 	if lang == "" {
@@ -219,6 +222,14 @@ func (typeOf *TypeMeta) itemIdToLabel(lang string, enumTxt []TypeEnumTxtRow, lan
 				labelMap[enumTxt[j].EnumId] = enumTxt[j].Descr
 			}
 		}
+		// replace labels by custom labels, if custom labels defined for that type, see SetLabelMap()
+		if cl := customLabelsOf(typeOf.Name); cl != nil {
+			for j := range typeOf.Enum {
+				if lbl, ok := cl[typeOf.Enum[j].Name]; ok {
+					labelMap[typeOf.Enum[j].EnumId] = lbl
+				}
+			}
+		}
 	}
 
 	// if total item enabled in dimension then find language-specific total label
@@ -247,20 +258,28 @@ func (typeOf *TypeMeta) itemIdToLabel(lang string, enumTxt []TypeEnumTxtRow, lan
 			if isTotalEnabled && itemId == typeOf.TotalEnumId { // check is it total item
 				return allLabel, nil
 			}
+			if !typeOf.IsBool() {
+				return unknownEnumToCode(itemId, msgName) // enum id not found: error or empty or id
+			}
 			return "", errors.New("invalid value: " + strconv.Itoa(itemId) + " of: " + msgName)
 		}
 
-	case typeOf.IsRange: // range dimension: item id the same as label
+	case typeOf.IsRange: // range dimension: item id the same as label, unless there is a custom label
+
+		rangeLabels := customLabelsOf(typeOf.Name)
 
 		cvt = func(itemId int) (string, error) {
 
 			if typeOf.MinEnumId <= itemId && itemId <= typeOf.MaxEnumId {
+				if lbl, ok := rangeLabels[strconv.Itoa(itemId)]; ok {
+					return lbl, nil
+				}
 				return prt.Sprintf("%d", itemId), nil
 			}
 			if isTotalEnabled && itemId == typeOf.TotalEnumId { // check is it total item
 				return allLabel, nil
 			}
-			return "", errors.New("invalid value: " + strconv.Itoa(itemId) + " of: " + msgName)
+			return unknownEnumToCode(itemId, msgName) // range item not found: error or empty or id
 		}
 
 	case typeOf.IsInt(): // integer dimension
@@ -0,0 +1,334 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// ColumnTypeMismatch is a column of parameter or output table values db table
+// where actual column type does not match type expected for database facet, e.g.: TEXT instead of FLOAT.
+//
+// It is typically a result of database migration between different database engines.
+type ColumnTypeMismatch struct {
+	Kind     string // p=parameter run values, w=workset values, v=expressions, a=accumulators, same as OrphanTable kind
+	Name     string // parameter or output table name
+	DbTable  string // db table name
+	Column   string // column name
+	Expected string // expected column type, e.g.: FLOAT
+	Actual   string // actual column type in database, e.g.: TEXT
+}
+
+// name of temporary db table to rebuild values table with mismatched columns types
+const migrateTmpTable = "ompp_migrate_tmp"
+
+// parameter or output table values db table: expected columns and sql to create table with expected columns types
+type valueTableDef struct {
+	kind      string              // p=parameter run values, w=workset values, v=expressions, a=accumulators
+	name      string              // parameter or output table name
+	dbTable   string              // db table name
+	col       []valueColumnDef    // expected columns
+	createSql func(string) string // return sql to create table with specified name
+	table     *TableMeta          // if not nil then it is output table accumulators table
+}
+
+// column name and expected column type
+type valueColumnDef struct {
+	name    string // column name
+	sqlType string // expected column type, e.g.: FLOAT
+}
+
+// AuditColumnTypes compare actual column types of model parameters and output tables values db tables
+// against column types expected for database facet.
+//
+// It returns list of mismatched columns, for example, TEXT column instead of FLOAT.
+// Types compared by type class: integer, float or text, e.g.: INT and BIGINT are the same class.
+// Db tables which not exist in database are skipped.
+func AuditColumnTypes(dbConn *sql.DB, modelDef *ModelMeta) ([]ColumnTypeMismatch, error) {
+
+	if modelDef == nil {
+		return nil, errors.New("invalid (empty) model metadata")
+	}
+	facet := facetOf(dbConn)
+
+	vtLst, err := valueTableDefs(facet, modelDef)
+	if err != nil {
+		return nil, err
+	}
+
+	ctLst := []ColumnTypeMismatch{}
+
+	for k := range vtLst {
+
+		// select actual columns types
+		actual := map[string]string{}
+
+		err = SelectRows(dbConn, facet.selectColumnTypesSql(vtLst[k].dbTable),
+			func(rows *sql.Rows) error {
+				var cn, ct string
+				if err := rows.Scan(&cn, &ct); err != nil {
+					return err
+				}
+				actual[strings.ToLower(cn)] = ct
+				return nil
+			})
+		if err != nil {
+			return nil, errors.New("failed to select columns of: " + vtLst[k].dbTable + ": " + err.Error())
+		}
+		if len(actual) <= 0 {
+			continue // db table not exist
+		}
+
+		for _, c := range vtLst[k].col {
+
+			ct, ok := actual[c.name]
+			if ok && sqlTypeClass(ct) == sqlTypeClass(c.sqlType) {
+				continue
+			}
+			ctLst = append(ctLst, ColumnTypeMismatch{
+				Kind:     vtLst[k].kind,
+				Name:     vtLst[k].name,
+				DbTable:  vtLst[k].dbTable,
+				Column:   c.name,
+				Expected: c.sqlType,
+				Actual:   ct,
+			})
+		}
+	}
+	return ctLst, nil
+}
+
+// MigrateColumnTypes rebuild model values db tables with mismatched columns types, as returned by AuditColumnTypes.
+//
+// Each db table rebuilt in place: create temporary table with expected columns types,
+// copy rows into temporary table, drop source table and rename temporary table.
+// Output table all accumulators view is re-created together with accumulators table.
+// Each db table rebuilt in separate transaction, MySQL and Oracle commit DDL statements implicitly
+// and for those databases rebuild of db table is not transactional.
+func MigrateColumnTypes(dbConn *sql.DB, modelDef *ModelMeta, ctLst []ColumnTypeMismatch) error {
+
+	if modelDef == nil {
+		return errors.New("invalid (empty) model metadata")
+	}
+	facet := facetOf(dbConn)
+
+	vtLst, err := valueTableDefs(facet, modelDef)
+	if err != nil {
+		return err
+	}
+
+	// rebuild each db table once, columns with mismatched types are converted by CAST
+	done := map[string]bool{}
+
+	for k := range ctLst {
+
+		if done[ctLst[k].DbTable] {
+			continue
+		}
+		done[ctLst[k].DbTable] = true
+
+		vt := (*valueTableDef)(nil)
+		for j := range vtLst {
+			if vtLst[j].dbTable == ctLst[k].DbTable {
+				vt = &vtLst[j]
+				break
+			}
+		}
+		if vt == nil {
+			return errors.New("db table not found in model values tables: " + ctLst[k].DbTable + ": " + modelDef.Model.Name)
+		}
+
+		isCast := map[string]bool{}
+		for j := range ctLst {
+			if ctLst[j].DbTable == vt.dbTable {
+				isCast[ctLst[j].Column] = true
+			}
+		}
+
+		trx, err := dbConn.Begin()
+		if err != nil {
+			return err
+		}
+		if err = trxMigrateTable(trx, facet, vt, isCast); err != nil {
+			trx.Rollback()
+			return errors.New("failed to rebuild db table: " + vt.dbTable + ": " + err.Error())
+		}
+		trx.Commit()
+	}
+	return nil
+}
+
+// rebuild values db table: create temporary table, copy rows, drop source table and rename temporary table.
+// If isCast[column] true then column value is converted to expected column type.
+// It does update as part of transaction.
+func trxMigrateTable(trx *sql.Tx, dbFacet Facet, vt *valueTableDef, isCast map[string]bool) error {
+
+	// drop temporary table if left from previous non-transactional rebuild
+	isTmp := false
+	err := TrxSelectRows(trx, dbFacet.selectTableNamesSql(false),
+		func(rows *sql.Rows) error {
+			var tn string
+			if err := rows.Scan(&tn); err != nil {
+				return err
+			}
+			isTmp = isTmp || strings.EqualFold(tn, migrateTmpTable)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	if isTmp {
+		if err = TrxUpdate(trx, "DROP TABLE "+migrateTmpTable); err != nil {
+			return err
+		}
+	}
+
+	// all accumulators view depends on accumulators table
+	if vt.table != nil {
+		if err = TrxUpdate(trx, "DROP VIEW "+vt.table.DbAccAllView); err != nil {
+			return err
+		}
+	}
+
+	// create temporary table and copy rows, MySQL does implicit conversion and does not support CAST to INT
+	if err = TrxUpdate(trx, vt.createSql(migrateTmpTable)); err != nil {
+		return err
+	}
+
+	cols := ""
+	vals := ""
+	for k, c := range vt.col {
+		if k > 0 {
+			cols += ", "
+			vals += ", "
+		}
+		cols += c.name
+		if isCast[c.name] && dbFacet != MySqlFacet {
+			vals += "CAST(" + c.name + " AS " + c.sqlType + ")"
+		} else {
+			vals += c.name
+		}
+	}
+	if err = TrxUpdate(trx, "INSERT INTO "+migrateTmpTable+" ("+cols+") SELECT "+vals+" FROM "+vt.dbTable); err != nil {
+		return err
+	}
+
+	// swap tables and re-create all accumulators view
+	if err = TrxUpdate(trx, "DROP TABLE "+vt.dbTable); err != nil {
+		return err
+	}
+	if err = TrxUpdate(trx, dbFacet.renameTableSql(migrateTmpTable, vt.dbTable)); err != nil {
+		return err
+	}
+	if vt.table != nil {
+		return TrxUpdate(trx, sqlCreateAccAllView(dbFacet, vt.table))
+	}
+	return nil
+}
+
+// return list of model parameters and output tables values db tables with expected columns types
+func valueTableDefs(dbFacet Facet, modelDef *ModelMeta) ([]valueTableDef, error) {
+
+	vtLst := []valueTableDef{}
+
+	for k := range modelDef.Param {
+
+		param := &modelDef.Param[k]
+
+		vType, err := param.typeOf.sqlColumnType(dbFacet)
+		if err != nil {
+			return nil, errors.New("invalid type of parameter: " + param.Name + ": " + err.Error())
+		}
+		colPart := []valueColumnDef{{"sub_id", "SMALLINT"}}
+		for j := range param.Dim {
+			colPart = append(colPart, valueColumnDef{param.Dim[j].colName, "INT"})
+		}
+		colPart = append(colPart, valueColumnDef{"param_value", vType})
+
+		vtLst = append(vtLst,
+			valueTableDef{
+				kind:    "p",
+				name:    param.Name,
+				dbTable: param.DbRunTable,
+				col:     append([]valueColumnDef{{"run_id", "INT"}}, colPart...),
+				createSql: func(tableName string) string {
+					p := *param
+					p.DbRunTable = tableName
+					rSql, _, _ := sqlCreateParamTable(dbFacet, &p)
+					return rSql
+				},
+			},
+			valueTableDef{
+				kind:    "w",
+				name:    param.Name,
+				dbTable: param.DbSetTable,
+				col:     append([]valueColumnDef{{"set_id", "INT"}}, colPart...),
+				createSql: func(tableName string) string {
+					p := *param
+					p.DbSetTable = tableName
+					_, wSql, _ := sqlCreateParamTable(dbFacet, &p)
+					return wSql
+				},
+			})
+	}
+
+	for k := range modelDef.Table {
+
+		table := &modelDef.Table[k]
+
+		dimPart := []valueColumnDef{}
+		for j := range table.Dim {
+			dimPart = append(dimPart, valueColumnDef{table.Dim[j].colName, "INT"})
+		}
+
+		eCol := append([]valueColumnDef{{"run_id", "INT"}, {"expr_id", "SMALLINT"}}, dimPart...)
+		aCol := append([]valueColumnDef{{"run_id", "INT"}, {"acc_id", "SMALLINT"}, {"sub_id", "SMALLINT"}}, dimPart...)
+
+		vtLst = append(vtLst,
+			valueTableDef{
+				kind:    "v",
+				name:    table.Name,
+				dbTable: table.DbExprTable,
+				col:     append(eCol, valueColumnDef{"expr_value", dbFacet.floatType()}),
+				createSql: func(tableName string) string {
+					t := *table
+					t.DbExprTable = tableName
+					eSql, _ := sqlCreateOutTable(dbFacet, &t)
+					return eSql
+				},
+			},
+			valueTableDef{
+				kind:    "a",
+				name:    table.Name,
+				dbTable: table.DbAccTable,
+				col:     append(aCol, valueColumnDef{"acc_value", dbFacet.floatType()}),
+				createSql: func(tableName string) string {
+					t := *table
+					t.DbAccTable = tableName
+					_, aSql := sqlCreateOutTable(dbFacet, &t)
+					return aSql
+				},
+				table: table,
+			})
+	}
+	return vtLst, nil
+}
+
+// return sql type class: int, float or text, or empty "" string if type is unknown.
+// It is similar to SQLite column affinity rules.
+func sqlTypeClass(sqlType string) string {
+
+	t := strings.ToUpper(sqlType)
+	switch {
+	case strings.Contains(t, "CHAR") || strings.Contains(t, "TEXT") || strings.Contains(t, "CLOB"):
+		return "text"
+	case strings.Contains(t, "FLOA") || strings.Contains(t, "DOUB") || strings.Contains(t, "REAL"):
+		return "float"
+	case strings.Contains(t, "INT") || strings.Contains(t, "NUMBER") || strings.Contains(t, "NUMERIC") || strings.Contains(t, "DECIMAL"):
+		return "int"
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestColumnTypeAudit(t *testing.T) {
+
+	dbConn := openTestDb(t)
+	setFacetOf(dbConn, SqliteFacet)
+
+	intType := &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 4, Name: "int"}}
+	dblType := &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 14, Name: "double"}}
+
+	meta := &ModelMeta{
+		Model: ModelDicRow{Name: "modelOne"},
+		Param: []ParamMeta{{
+			ParamDicRow: ParamDicRow{Name: "ageSex", Rank: 1, DbRunTable: "ageSex_p12345678", DbSetTable: "ageSex_w12345678"},
+			Dim:         []ParamDimsRow{{Name: "dim0", colName: "dim0", typeOf: intType}},
+			typeOf:      dblType,
+		}},
+		Table: []TableMeta{{
+			TableDicRow: TableDicRow{Name: "salary", Rank: 1, DbExprTable: "salary_v12345678", DbAccTable: "salary_a12345678", DbAccAllView: "salary_d12345678"},
+			Dim:         []TableDimsRow{{Name: "dim0", colName: "dim0", typeOf: intType}},
+			Acc:         []TableAccRow{{Name: "acc0", colName: "acc0"}},
+		}},
+	}
+
+	// parameter run values and accumulators migrated from another database as text columns
+	_, wSql, err := sqlCreateParamTable(SqliteFacet, &meta.Param[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	eSql, _ := sqlCreateOutTable(SqliteFacet, &meta.Table[0])
+
+	testUpdate(t, dbConn,
+		"CREATE TABLE ageSex_p12345678 (run_id INT NOT NULL, sub_id SMALLINT NOT NULL, dim0 INT NOT NULL, param_value TEXT NOT NULL, PRIMARY KEY (run_id, sub_id, dim0))",
+		wSql,
+		eSql,
+		"CREATE TABLE salary_a12345678 (run_id INT NOT NULL, acc_id SMALLINT NOT NULL, sub_id SMALLINT NOT NULL, dim0 VARCHAR(32) NOT NULL, acc_value VARCHAR(32) NULL, PRIMARY KEY (run_id, acc_id, sub_id, dim0))",
+		sqlCreateAccAllView(SqliteFacet, &meta.Table[0]),
+		"INSERT INTO ageSex_p12345678 (run_id, sub_id, dim0, param_value) VALUES (1, 0, 0, '1.5'), (1, 0, 1, '2.5')",
+		"INSERT INTO salary_a12345678 (run_id, acc_id, sub_id, dim0, acc_value) VALUES (1, 0, 0, '1', '0.25')",
+	)
+
+	ctLst, err := AuditColumnTypes(dbConn, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		dbTable, column, expected string
+	}{
+		{"ageSex_p12345678", "param_value", "FLOAT"},
+		{"salary_a12345678", "dim0", "INT"},
+		{"salary_a12345678", "acc_value", "FLOAT"},
+	}
+	if len(ctLst) != len(want) {
+		t.Fatal("Fail: invalid number of mismatched columns:", len(ctLst), ctLst)
+	}
+	for k, w := range want {
+		if ctLst[k].DbTable != w.dbTable || ctLst[k].Column != w.column || ctLst[k].Expected != w.expected {
+			t.Error("Fail: invalid mismatched column:", k, ctLst[k])
+		}
+	}
+
+	// rebuild tables and check values and all accumulators view
+	if err = MigrateColumnTypes(dbConn, meta, ctLst); err != nil {
+		t.Fatal(err)
+	}
+	if ctLst, err = AuditColumnTypes(dbConn, meta); err != nil || len(ctLst) != 0 {
+		t.Error("Fail: mismatched columns after migration:", ctLst, err)
+	}
+
+	var sum float64
+	var vt string
+	err = SelectFirst(dbConn,
+		"SELECT SUM(param_value), MIN(typeof(param_value)) FROM ageSex_p12345678",
+		func(row *sql.Row) error { return row.Scan(&sum, &vt) })
+	if err != nil || sum != 4.0 || vt != "real" {
+		t.Error("Fail: invalid parameter values after migration:", sum, vt, err)
+	}
+	err = SelectFirst(dbConn,
+		"SELECT acc0 FROM salary_d12345678 WHERE dim0 = 1",
+		func(row *sql.Row) error { return row.Scan(&sum) })
+	if err != nil || sum != 0.25 {
+		t.Error("Fail: invalid all accumulators view after migration:", sum, err)
+	}
+}
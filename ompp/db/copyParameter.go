@@ -45,11 +45,16 @@ func CopyParameterFromRun(dbConn *sql.DB, modelDef *ModelMeta, ws *WorksetRow, p
 	pm := modelDef.Param[i]
 
 	// copy parameter metadata and values from model run into workset inside of transaction scope
+	isDgst := IsWorksetParamDigest(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err = dbCopyParameterFromRun(trx, ws, &pm, isReplace, rs); err != nil {
+	err = dbCopyParameterFromRun(trx, ws, &pm, isReplace, rs)
+	if err == nil && isDgst {
+		_, err = doUpdateWorksetParamDigest(trx, modelDef, &pm, ws.SetId)
+	}
+	if err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -86,11 +91,16 @@ func CopyParameterFromWorkset(dbConn *sql.DB, modelDef *ModelMeta, dstWs *Workse
 	pm := modelDef.Param[i]
 
 	// copy parameter metadata and values  from one workset to another inside of transaction scope
+	isDgst := IsWorksetParamDigest(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err = dbCopyParameterFromWorkset(trx, dstWs, &pm, isReplace, srcWs); err != nil {
+	err = dbCopyParameterFromWorkset(trx, dstWs, &pm, isReplace, srcWs)
+	if err == nil && isDgst {
+		_, err = doUpdateWorksetParamDigest(trx, modelDef, &pm, dstWs.SetId)
+	}
+	if err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -127,7 +137,7 @@ func dbCopyParameterFromRun(trx *sql.Tx, ws *WorksetRow, pm *ParamMeta, isReplac
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("failed to copy: destination workset not found: " + ws.Name)
+		return newDbError(ErrWorksetNotFound, "failed to copy: destination workset not found: "+ws.Name)
 	case err != nil:
 		return err
 	case nRd != 1:
@@ -242,7 +252,7 @@ func dbCopyParameterFromWorkset(trx *sql.Tx, dstWs *WorksetRow, pm *ParamMeta, i
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("failed to copy: destination workset not found: " + dstWs.Name)
+		return newDbError(ErrWorksetNotFound, "failed to copy: destination workset not found: "+dstWs.Name)
 	case err != nil:
 		return err
 	case nRd != 1:
@@ -282,7 +292,7 @@ func dbCopyParameterFromWorkset(trx *sql.Tx, dstWs *WorksetRow, pm *ParamMeta, i
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("failed to copy: source workset not found: " + srcWs.Name)
+		return newDbError(ErrWorksetNotFound, "failed to copy: source workset not found: "+srcWs.Name)
 	case err != nil:
 		return err
 	case nRd <= 1:
@@ -399,8 +409,8 @@ func dbCopyParameterFromWorkset(trx *sql.Tx, dstWs *WorksetRow, pm *ParamMeta, i
 }
 
 // Check if parameter exist in destination workset and:
-//  - if isReplace is true then error returned.
-//  - if isReplace is false then delete existing metadata and new insert new from model run.
+//   - if isReplace is true then error returned.
+//   - if isReplace is false then delete existing metadata and new insert new from model run.
 func prepareWorksetForParameterInsert(trx *sql.Tx, dstWs *WorksetRow, pm *ParamMeta, isReplace bool) error {
 
 	// check if parameter already exist in destination workset
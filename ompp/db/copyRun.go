@@ -0,0 +1,134 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// CopyRun create a copy of completed model run in the same database and return new run id.
+//
+// New run has new run name, run stamp, create date-time and run digest.
+// Run options, description, notes and run progress are copied from source run.
+// Parameters, output tables and microdata values are not copied:
+// new run_parameter, run_table and run_entity rows are linked to the base run which is holding the values.
+// If source run is deleted later then values are moved to the next run, for example, to that copy of the run.
+// If new run name is empty then source run name is used.
+func CopyRun(dbConn *sql.DB, runId int, newName string) (int, error) {
+
+	// validate parameters
+	if runId <= 0 {
+		return 0, errors.New("invalid run id: " + strconv.Itoa(runId))
+	}
+
+	// source run must exist and completed
+	src, err := GetRun(dbConn, runId)
+	if err != nil {
+		return 0, err
+	}
+	if src == nil {
+		return 0, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(runId))
+	}
+	if !IsRunCompleted(src.Status) {
+		return 0, errors.New("model run not completed: " + strconv.Itoa(runId) + " " + src.Name)
+	}
+	if newName == "" {
+		newName = src.Name
+	}
+
+	// copy run inside of transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	dstId, err := doCopyRun(trx, runId, newName)
+	if err != nil {
+		trx.Rollback()
+		return 0, err
+	}
+	trx.Commit()
+	return dstId, nil
+}
+
+// doCopyRun insert copy of model run metadata and link copy to the source run parameters, output tables and microdata values.
+// It does update as part of transaction.
+func doCopyRun(trx *sql.Tx, runId int, newName string) (int, error) {
+
+	// get new run id
+	dstId := 0
+
+	err := TrxUpdate(trx, "UPDATE id_lst SET id_value = id_value + 1 WHERE id_key = 'run_id_set_id'")
+	if err != nil {
+		return 0, err
+	}
+	err = TrxSelectFirst(trx,
+		"SELECT id_value FROM id_lst WHERE id_key = 'run_id_set_id'",
+		func(row *sql.Row) error {
+			return row.Scan(&dstId)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, newDbError(ErrNotOmppSchema, "invalid database, likely not an openM++ database")
+	case err != nil:
+		return 0, err
+	}
+	sId := strconv.Itoa(runId)
+	sDst := strconv.Itoa(dstId)
+
+	// new run create date-time and run stamp
+	tNow := time.Now()
+	dtNow := helper.MakeDateTime(tNow)
+	stamp := helper.MakeTimeStamp(tNow)
+
+	// INSERT INTO run_lst: run digest is temporary and updated after run metadata copied
+	err = TrxUpdate(trx,
+		"INSERT INTO run_lst"+
+			" (run_id, model_id, run_name, sub_count, sub_started, sub_completed, sub_restart, create_dt, status, update_dt, run_digest, value_digest, run_stamp)"+
+			" SELECT "+
+			sDst+", model_id, "+toQuotedMax(newName, nameDbMax)+", sub_count, sub_started, sub_completed, sub_restart, "+
+			ToQuoted(dtNow)+", status, "+ToQuoted(dtNow)+", "+
+			ToQuoted("c-"+sDst+"-"+stamp)+", value_digest, "+toQuotedMax(stamp, codeDbMax)+
+			" FROM run_lst WHERE run_id = "+sId)
+	if err != nil {
+		return 0, err
+	}
+
+	// copy run metadata rows and link to parameters, output tables and microdata values of the base run
+	for _, q := range []string{
+		"INSERT INTO run_txt (run_id, lang_id, descr, note)" +
+			" SELECT " + sDst + ", lang_id, descr, note FROM run_txt WHERE run_id = " + sId,
+		"INSERT INTO run_option (run_id, option_key, option_value)" +
+			" SELECT " + sDst + ", option_key, option_value FROM run_option WHERE run_id = " + sId +
+			" AND option_key NOT IN ('OpenM.RunId', 'OpenM.RunName', 'OpenM.RunStamp')",
+		"INSERT INTO run_parameter (run_id, parameter_hid, base_run_id, sub_count, value_digest)" +
+			" SELECT " + sDst + ", parameter_hid, base_run_id, sub_count, value_digest FROM run_parameter WHERE run_id = " + sId,
+		"INSERT INTO run_parameter_txt (run_id, parameter_hid, lang_id, note)" +
+			" SELECT " + sDst + ", parameter_hid, lang_id, note FROM run_parameter_txt WHERE run_id = " + sId,
+		"INSERT INTO run_table (run_id, table_hid, base_run_id, value_digest)" +
+			" SELECT " + sDst + ", table_hid, base_run_id, value_digest FROM run_table WHERE run_id = " + sId,
+		"INSERT INTO run_entity (run_id, entity_gen_hid, base_run_id, row_count, value_digest)" +
+			" SELECT " + sDst + ", entity_gen_hid, base_run_id, row_count, value_digest FROM run_entity WHERE run_id = " + sId,
+		"INSERT INTO run_progress (run_id, sub_id, create_dt, status, update_dt, progress_count, progress_value)" +
+			" SELECT " + sDst + ", sub_id, create_dt, status, update_dt, progress_count, progress_value FROM run_progress WHERE run_id = " + sId,
+		"INSERT INTO run_option (run_id, option_key, option_value)" +
+			" VALUES (" + sDst + ", 'OpenM.RunName', " + toQuotedMax(newName, optionDbMax) + ")",
+		"INSERT INTO run_option (run_id, option_key, option_value)" +
+			" VALUES (" + sDst + ", 'OpenM.RunStamp', " + ToQuoted(stamp) + ")",
+	} {
+		if err = TrxUpdate(trx, q); err != nil {
+			return 0, err
+		}
+	}
+
+	// update run digest: it is a digest of run metadata, run value digest is the same as source run value digest
+	if _, err = doUpdateRunMetaDigest(trx, dstId); err != nil {
+		return 0, err
+	}
+	return dstId, nil
+}
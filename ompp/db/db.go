@@ -26,6 +26,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
@@ -97,6 +98,9 @@ func Open(dbConnStr, dbDriver string, isFacetRequired bool) (*sql.DB, Facet, err
 	if isFacetRequired {
 		omppLog.LogSql(facet.String())
 	}
+	if facet != DefaultFacet {
+		setFacetOf(dbConn, facet)
+	}
 
 	/*
 		// to avoid database lock issues for SQLite with SQLITE_THREADSAFE=1
@@ -108,6 +112,13 @@ func Open(dbConnStr, dbDriver string, isFacetRequired bool) (*sql.DB, Facet, err
 	return dbConn, facet, nil
 }
 
+// Close database connection opened by Open() and release db facet and other cached properties of that connection.
+func Close(dbConn *sql.DB) error {
+	deleteFacetOf(dbConn)
+	deleteParamDigestOf(dbConn)
+	return dbConn.Close()
+}
+
 // return SQLite connection string and driver name based on model name:
 //
 //	Database=modelName.sqlite; Timeout=86400; OpenMode=ReadWrite;
@@ -233,7 +244,9 @@ func SelectFirst(dbConn *sql.DB, query string, cvt func(row *sql.Row) error) err
 		return errors.New("invalid database connection")
 	}
 	omppLog.LogSql(query)
-	return cvt(dbConn.QueryRow(query))
+
+	ctx, done := WatchQuery(query, 0)
+	return done(cvt(dbConn.QueryRowContext(ctx, query)))
 }
 
 // SelectRows select db rows and pass each to cvt() for rows.Scan()
@@ -244,19 +257,21 @@ func SelectRows(dbConn *sql.DB, query string, cvt func(rows *sql.Rows) error) er
 	}
 	omppLog.LogSql(query)
 
-	rows, err := dbConn.Query(query) // query db rows
+	ctx, done := WatchQuery(query, 0)
+
+	rows, err := dbConn.QueryContext(ctx, query) // query db rows
 	if err != nil {
-		return err
+		return done(toLockedError(err))
 	}
 	defer rows.Close()
 
 	// process each row
 	for rows.Next() {
 		if err = cvt(rows); err != nil {
-			return err
+			return done(err)
 		}
 	}
-	return rows.Err()
+	return done(rows.Err())
 }
 
 // SelectRowsTo select db rows and pass each row to cvt().
@@ -268,9 +283,11 @@ func SelectRowsTo(dbConn *sql.DB, query string, cvt func(rows *sql.Rows) (bool,
 	}
 	omppLog.LogSql(query)
 
-	rows, err := dbConn.Query(query) // query db rows
+	ctx, done := WatchQuery(query, 0)
+
+	rows, err := dbConn.QueryContext(ctx, query) // query db rows
 	if err != nil {
-		return err
+		return done(toLockedError(err))
 	}
 	defer rows.Close()
 
@@ -278,13 +295,13 @@ func SelectRowsTo(dbConn *sql.DB, query string, cvt func(rows *sql.Rows) (bool,
 	for rows.Next() {
 		isNext, err := cvt(rows)
 		if err != nil {
-			return err
+			return done(err)
 		}
 		if !isNext {
 			break
 		}
 	}
-	return rows.Err()
+	return done(rows.Err())
 }
 
 // SelectToList select db rows into list using cvt to convert (scan) each db row into struct.
@@ -302,12 +319,22 @@ func SelectToList(
 	// query db rows
 	omppLog.LogSql(query)
 
-	rows, err := dbConn.Query(query)
+	ctx, done := WatchQuery(query, 0)
+
+	rows, err := dbConn.QueryContext(ctx, query)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, done(err)
 	}
 	defer rows.Close()
 
+	return rowsToList(rows, layout, done, cvt)
+}
+
+// rowsToList convert db rows into list using cvt to convert (scan) each db row into struct, see SelectToList() for details.
+// Function done() is a query watchdog completion, it is called after all rows processed.
+func rowsToList(
+	rows *sql.Rows, layout ReadPageLayout, done func(err error) error, cvt func(rows *sql.Rows) (interface{}, error)) (*list.List, *ReadPageLayout, error) {
+
 	// adjust page layout: starting offset and page size
 	nStart := layout.Offset
 	if nStart < 0 {
@@ -327,10 +354,19 @@ func SelectToList(
 	}
 
 	// convert each row and append to the result list
+	// if total count requested then count all rows after the page
 	rs := list.New()
+	nAll := int64(0)
 	for rows.Next() {
+		nAll++
+		if nSize > 0 && nRow > nStart+nSize {
+			continue // count rows after the page
+		}
 		nRow++
 		if nSize > 0 && nRow > nStart+nSize {
+			if layout.isCountAll {
+				continue
+			}
 			break
 		}
 		if !lt.IsFullPage && nRow <= nStart {
@@ -340,7 +376,7 @@ func SelectToList(
 		// convert and add row to the page
 		r, err := cvt(rows)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, done(err)
 		}
 		rs.PushBack(r)
 
@@ -350,7 +386,7 @@ func SelectToList(
 		}
 		lt.Size = int64(rs.Len())
 	}
-	err = rows.Err()
+	err := done(rows.Err())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -360,6 +396,7 @@ func SelectToList(
 		lt.Offset = nRow
 	}
 	lt.IsLastPage = nSize <= 0 || nSize > 0 && nRow <= nStart+nSize
+	lt.rowCount = nAll
 
 	if lt.IsFullPage { // if this is a full page reading mode then adjust page start
 
@@ -383,7 +420,7 @@ func Update(dbConn *sql.DB, query string) error {
 	omppLog.LogSql(query)
 
 	_, err := dbConn.Exec(query)
-	return err
+	return toLockedError(err)
 }
 
 // TrxSelectRows select db rows in transaction scope and pass each to cvt() for rows.Scan()
@@ -396,7 +433,7 @@ func TrxSelectRows(dbTrx *sql.Tx, query string, cvt func(rows *sql.Rows) error)
 
 	rows, err := dbTrx.Query(query) // query db rows
 	if err != nil {
-		return err
+		return toLockedError(err)
 	}
 	defer rows.Close()
 
@@ -426,7 +463,7 @@ func TrxUpdate(dbTrx *sql.Tx, query string) error {
 	omppLog.LogSql(query)
 
 	_, err := dbTrx.Exec(query)
-	return err
+	return toLockedError(err)
 }
 
 // TrxUpdateStatement execute sql statement in transaction scope until put() return true
@@ -440,7 +477,7 @@ func TrxUpdateStatement(dbTrx *sql.Tx, query string, put func() (bool, []interfa
 	// prepare statement in transaction scope
 	stmt, err := dbTrx.Prepare(query)
 	if err != nil {
-		return err
+		return toLockedError(err)
 	}
 	defer stmt.Close()
 
@@ -455,7 +492,7 @@ func TrxUpdateStatement(dbTrx *sql.Tx, query string, put func() (bool, []interfa
 		}
 		_, err = stmt.Exec(r...)
 		if err != nil {
-			return err
+			return toLockedError(err)
 		}
 	}
 	return nil
@@ -486,16 +523,52 @@ func CheckOpenmppSchemaVersion(dbConn *sql.DB) error {
 
 	nv, err := OpenmppSchemaVersion(dbConn)
 	switch {
+	case err != nil && errors.Is(toLockedError(err), ErrLocked):
+		return newDbError(ErrLocked, "error: database is locked: "+err.Error())
 	case err != nil || err == nil && nv <= 0:
-		return errors.New("error: invalid database, likely not an openM++ database")
+		return newDbError(ErrNotOmppSchema, "error: invalid database, likely not an openM++ database")
 	case nv < MinSchemaVersion:
-		return errors.New("error: incompatible, old version of database: " + strconv.Itoa(nv) + ", please use earlier version of openM++ tools")
+		return newDbError(ErrSchemaVersion, "error: incompatible, old version of database: "+strconv.Itoa(nv)+", please use earlier version of openM++ tools")
 	case nv > MaxSchemaVersion:
-		return errors.New("error: incompatible, newer version of database: " + strconv.Itoa(nv) + ", please use more recent version of openM++ tools")
+		return newDbError(ErrSchemaVersion, "error: incompatible, newer version of database: "+strconv.Itoa(nv)+", please use more recent version of openM++ tools")
 	}
 	return nil
 }
 
+// max number of items in sql IN list, Oracle limit is 1000 items
+const maxInListSize = 1000
+
+// makeIdInList return sql filter by list of id's, for example: H.run_id IN (11, 12, 13).
+// If list is longer than max IN list size then it is split into chunks: (H.run_id IN (11, ..., 1010) OR H.run_id IN (1011, ...)).
+// If list is empty then return false condition: 1 = 0
+func makeIdInList(col string, ids []int) string {
+
+	if len(ids) <= 0 {
+		return "1 = 0" // empty list: no rows
+	}
+
+	q := ""
+	for k := 0; k < len(ids); k += maxInListSize {
+
+		if k > 0 {
+			q += " OR "
+		}
+		q += col + " IN ("
+
+		for j := k; j < len(ids) && j < k+maxInListSize; j++ {
+			if j > k {
+				q += ", "
+			}
+			q += strconv.Itoa(ids[j])
+		}
+		q += ")"
+	}
+	if len(ids) > maxInListSize {
+		q = "(" + q + ")"
+	}
+	return q
+}
+
 // convert boolean to sql value: true=1, false=0
 func toBoolSqlConst(isValue bool) string {
 	if isValue {
@@ -535,6 +608,38 @@ func ToQuoted(src string) string {
 	return sb.String()
 }
 
+// split source string into sql quoted strings, each quoted string is up to maxBytes size.
+// Source string is split at utf-8 rune boundary, maxBytes must be at least 4 bytes.
+func toQuotedChunks(src string, maxBytes int) []string {
+
+	cLst := []string{}
+	var sb strings.Builder
+	sb.WriteRune('\'')
+
+	for _, c := range src {
+
+		n := utf8.RuneLen(c)
+		if c == '\'' || IsUnsafeQuote(c) {
+			n = 2
+		}
+		if sb.Len()+n+1 > maxBytes {
+			sb.WriteRune('\'')
+			cLst = append(cLst, sb.String())
+			sb.Reset()
+			sb.WriteRune('\'')
+		}
+
+		if c == '\'' || IsUnsafeQuote(c) {
+			sb.WriteString("''")
+		} else {
+			sb.WriteRune(c)
+		}
+	}
+
+	sb.WriteRune('\'')
+	return append(cLst, sb.String())
+}
+
 // return "NULL" if string ” empty or return sql quoted string, ie: 'O”Brien'
 func toQuotedOrNull(src string) string {
 	if src == "" {
@@ -556,13 +661,17 @@ func toQuotedOrNullMax(src string, maxLen int) string {
 }
 
 // Return up to maxLen bytes from src string.
-// It is return bytes (not runes) and last utf-8 rune may be incorrect in result.
+// Result is truncated at utf-8 rune boundary: last incomplete rune is not included in result.
 func leftMax(src string, maxLen int) string {
 	if maxLen <= 0 {
 		return ""
 	}
-	if len(src) > maxLen {
-		return src[:maxLen-1]
+	if len(src) <= maxLen {
+		return src
+	}
+	n := maxLen
+	for n > 0 && !utf8.RuneStart(src[n]) {
+		n--
 	}
-	return src
+	return src[:n]
 }
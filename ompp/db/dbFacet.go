@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Facet is type to define database provider and driver facets, ie: name of bigint type
@@ -78,6 +79,45 @@ func (facet Facet) textType(len int) string {
 	return "VARCHAR(" + strconv.Itoa(len) + ")"
 }
 
+// max size in bytes of quoted sql string literal for long notes, Oracle string literal max size is 4000 bytes
+const noteLiteralMax = 4000
+
+// noteToSql return sql value of notes: "NULL" if notes are empty or sql quoted string up to noteDbMax bytes.
+// Oracle and DB2 have a limit on sql string literal size,
+// long notes are split into CLOB chunks: TO_CLOB('part one') || TO_CLOB('part two').
+func (facet Facet) noteToSql(note string) string {
+
+	src := leftMax(note, noteDbMax)
+	if src == "" {
+		return "NULL"
+	}
+
+	clobFnc := ""
+	switch facet {
+	case OracleFacet:
+		clobFnc = "TO_CLOB"
+	case Db2Facet:
+		clobFnc = "CLOB"
+	}
+	if clobFnc == "" {
+		return ToQuoted(src)
+	}
+
+	cLst := toQuotedChunks(src, noteLiteralMax)
+	if len(cLst) <= 1 {
+		return ToQuoted(src)
+	}
+
+	var sb strings.Builder
+	for k, c := range cLst {
+		if k > 0 {
+			sb.WriteString(" || ")
+		}
+		sb.WriteString(clobFnc + "(" + c + ")")
+	}
+	return sb.String()
+}
+
 // createTableIfNotExist return sql statement to create table if not exists
 func (facet Facet) createTableIfNotExist(tableName string, bodySql string) string {
 
@@ -108,6 +148,157 @@ func (facet Facet) createViewIfNotExist(viewName string, bodySql string) string
 	return "CREATE VIEW " + viewName + " AS " + bodySql
 }
 
+// selectTableNamesSql return sql statement to select names of all tables or all views in current database schema
+func (facet Facet) selectTableNamesSql(isView bool) string {
+
+	switch facet {
+	case SqliteFacet:
+		if isView {
+			return "SELECT name FROM sqlite_master WHERE type = 'view'"
+		}
+		return "SELECT name FROM sqlite_master WHERE type = 'table'"
+	case OracleFacet:
+		if isView {
+			return "SELECT view_name FROM user_views"
+		}
+		return "SELECT table_name FROM user_tables"
+	case Db2Facet:
+		if isView {
+			return "SELECT TABNAME FROM SYSCAT.TABLES WHERE TABSCHEMA = CURRENT SCHEMA AND TYPE = 'V'"
+		}
+		return "SELECT TABNAME FROM SYSCAT.TABLES WHERE TABSCHEMA = CURRENT SCHEMA AND TYPE = 'T'"
+	}
+
+	q := "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES"
+	if isView {
+		q += " WHERE TABLE_TYPE = 'VIEW'"
+	} else {
+		q += " WHERE TABLE_TYPE = 'BASE TABLE'"
+	}
+	switch facet {
+	case PgSqlFacet:
+		q += " AND TABLE_SCHEMA = CURRENT_SCHEMA()"
+	case MySqlFacet:
+		q += " AND TABLE_SCHEMA = DATABASE()"
+	}
+	return q
+}
+
+// selectTableSizeSql return sql statement to select size in bytes of db table and table indexes
+// or empty "" string if table size is not available for that db facet.
+// For SQLite it is using dbstat virtual table which may not be available.
+func (facet Facet) selectTableSizeSql(tableName string) string {
+
+	switch facet {
+	case SqliteFacet:
+		return "SELECT COALESCE(SUM(S.pgsize), 0) FROM dbstat S" +
+			" INNER JOIN sqlite_master M ON (M.name = S.name)" +
+			" WHERE M.tbl_name = " + ToQuoted(tableName)
+	case PgSqlFacet:
+		return "SELECT pg_total_relation_size(" + ToQuoted(strings.ToLower(tableName)) + ")"
+	case MySqlFacet:
+		return "SELECT DATA_LENGTH + INDEX_LENGTH FROM INFORMATION_SCHEMA.TABLES" +
+			" WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = " + ToQuoted(tableName)
+	}
+	return ""
+}
+
+// selectColumnTypesSql return sql statement to select column names and column types of db table
+func (facet Facet) selectColumnTypesSql(tableName string) string {
+
+	switch facet {
+	case SqliteFacet:
+		return "SELECT name, type FROM pragma_table_info(" + ToQuoted(tableName) + ")"
+	case PgSqlFacet:
+		return "SELECT column_name, data_type FROM INFORMATION_SCHEMA.COLUMNS" +
+			" WHERE table_schema = CURRENT_SCHEMA() AND table_name = " + ToQuoted(strings.ToLower(tableName))
+	case MySqlFacet:
+		return "SELECT COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS" +
+			" WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = " + ToQuoted(tableName)
+	case OracleFacet:
+		return "SELECT column_name, data_type FROM user_tab_columns WHERE table_name = " + ToQuoted(strings.ToUpper(tableName))
+	case Db2Facet:
+		return "SELECT COLNAME, TYPENAME FROM SYSCAT.COLUMNS" +
+			" WHERE TABSCHEMA = CURRENT SCHEMA AND TABNAME = " + ToQuoted(strings.ToUpper(tableName))
+	}
+	return "SELECT COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = " + ToQuoted(tableName)
+}
+
+// renameTableSql return sql statement to rename db table
+func (facet Facet) renameTableSql(tableName string, newName string) string {
+
+	switch facet {
+	case MsSqlFacet:
+		return "EXEC sp_rename " + ToQuoted(tableName) + ", " + ToQuoted(newName)
+	case MySqlFacet, Db2Facet:
+		return "RENAME TABLE " + tableName + " TO " + newName
+	}
+	return "ALTER TABLE " + tableName + " RENAME TO " + newName
+}
+
+// selectIndexCountSql return sql statement to count indexes with specified name on db table
+// or empty "" string if it is not available for that db facet.
+func (facet Facet) selectIndexCountSql(tableName string, indexName string) string {
+
+	switch facet {
+	case SqliteFacet:
+		return "SELECT COUNT(*) FROM sqlite_master" +
+			" WHERE type = 'index' AND tbl_name = " + ToQuoted(tableName) + " AND name = " + ToQuoted(indexName)
+	case PgSqlFacet:
+		return "SELECT COUNT(*) FROM pg_indexes" +
+			" WHERE schemaname = CURRENT_SCHEMA()" +
+			" AND tablename = " + ToQuoted(strings.ToLower(tableName)) + " AND indexname = " + ToQuoted(strings.ToLower(indexName))
+	case MySqlFacet:
+		return "SELECT COUNT(DISTINCT INDEX_NAME) FROM INFORMATION_SCHEMA.STATISTICS" +
+			" WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = " + ToQuoted(tableName) + " AND INDEX_NAME = " + ToQuoted(indexName)
+	case MsSqlFacet:
+		return "SELECT COUNT(*) FROM sys.indexes" +
+			" WHERE object_id = OBJECT_ID(" + ToQuoted(tableName) + ") AND name = " + ToQuoted(indexName)
+	case OracleFacet:
+		return "SELECT COUNT(*) FROM user_indexes" +
+			" WHERE table_name = " + ToQuoted(strings.ToUpper(tableName)) + " AND index_name = " + ToQuoted(strings.ToUpper(indexName))
+	case Db2Facet:
+		return "SELECT COUNT(*) FROM SYSCAT.INDEXES" +
+			" WHERE TABSCHEMA = CURRENT SCHEMA" +
+			" AND TABNAME = " + ToQuoted(strings.ToUpper(tableName)) + " AND INDNAME = " + ToQuoted(strings.ToUpper(indexName))
+	}
+	return ""
+}
+
+// db facets of open database connections
+var theFacets = struct {
+	sync.Mutex
+	facets map[*sql.DB]Facet
+}{facets: map[*sql.DB]Facet{}}
+
+// setFacetOf store db facet of database connection
+func setFacetOf(dbConn *sql.DB, facet Facet) {
+	theFacets.Lock()
+	defer theFacets.Unlock()
+	theFacets.facets[dbConn] = facet
+}
+
+// deleteFacetOf remove db facet of database connection, it must be called when connection is closed
+func deleteFacetOf(dbConn *sql.DB) {
+	theFacets.Lock()
+	defer theFacets.Unlock()
+	delete(theFacets.facets, dbConn)
+}
+
+// facetOf return db facet of database connection.
+// If db facet is not known from Open() then it is detected by quiering sql server.
+func facetOf(dbConn *sql.DB) Facet {
+	theFacets.Lock()
+	facet, ok := theFacets.facets[dbConn]
+	theFacets.Unlock()
+
+	if !ok {
+		facet = detectFacet(dbConn)
+		setFacetOf(dbConn, facet)
+	}
+	return facet
+}
+
 // detectFacet obtains db facet by quiering sql server.
 // It may not be always reliable and even not true facet.
 // It is better to use driver information to determine db facet.
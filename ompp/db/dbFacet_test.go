@@ -0,0 +1,94 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLeftMax(t *testing.T) {
+
+	if s := leftMax("abc", 3); s != "abc" {
+		t.Error("Fail leftMax(abc, 3):", s)
+	}
+	if s := leftMax("abcd", 3); s != "abc" {
+		t.Error("Fail leftMax(abcd, 3):", s)
+	}
+
+	// 2 bytes per rune: must be truncated at rune boundary
+	src := strings.Repeat("é", 4)
+	s := leftMax(src, 5)
+	if s != "éé" || !utf8.ValidString(s) {
+		t.Error("Fail leftMax at rune boundary:", s)
+	}
+}
+
+func TestNoteToSql(t *testing.T) {
+
+	if s := OracleFacet.noteToSql(""); s != "NULL" {
+		t.Error("Fail empty note:", s)
+	}
+	if s := OracleFacet.noteToSql("O'Brien"); s != "'O''Brien'" {
+		t.Error("Fail short note:", s)
+	}
+
+	// long note must be chunked for Oracle and DB2 and quoted as is for other facets
+	src := strings.Repeat("O'Brien ", 1000)
+	if s := SqliteFacet.noteToSql(src); s != ToQuoted(src) {
+		t.Error("Fail long note for SQLite, length:", len(s))
+	}
+
+	for _, fc := range []struct {
+		facet Facet
+		fnc   string
+	}{{OracleFacet, "TO_CLOB("}, {Db2Facet, "CLOB("}} {
+
+		s := fc.facet.noteToSql(src)
+		parts := strings.Split(s, " || ")
+		if len(parts) <= 1 {
+			t.Error("Fail long note is not chunked:", fc.facet.String())
+			continue
+		}
+
+		res := ""
+		for _, p := range parts {
+			if !strings.HasPrefix(p, fc.fnc) || !strings.HasSuffix(p, ")") {
+				t.Error("Fail long note chunk:", fc.facet.String(), p[:20])
+				break
+			}
+			q := p[len(fc.fnc) : len(p)-1]
+			if len(q) > noteLiteralMax {
+				t.Error("Fail long note chunk size:", fc.facet.String(), len(q))
+			}
+			res += strings.ReplaceAll(q[1:len(q)-1], "''", "'")
+		}
+		if res != src {
+			t.Error("Fail long note chunks content:", fc.facet.String())
+		}
+	}
+}
+
+func TestCloseFacet(t *testing.T) {
+
+	dbConn, facet, err := Open(":memory:", Sqlite3DbDriver, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if facet != SqliteFacet {
+		t.Error("Fail: invalid db facet:", facet.String())
+	}
+
+	// db facet of connection must be removed when connection closed
+	if err = Close(dbConn); err != nil {
+		t.Fatal(err)
+	}
+	theFacets.Lock()
+	_, ok := theFacets.facets[dbConn]
+	theFacets.Unlock()
+	if ok {
+		t.Error("Fail: db facet not removed after connection closed")
+	}
+}
@@ -36,7 +36,6 @@ const rangeDicId = 3      // range type
 //
 // Unless otherwise specified each array is ordered by model-specific id's and binary search can be used.
 // For example type array is ordered by (model_id, type_id) and type enum array by (model_id, type_id, enum_id).
-//
 type ModelMeta struct {
 	Model       ModelDicRow       // model_dic table row
 	Type        []TypeMeta        // types metadata: type name and enums
@@ -471,6 +471,44 @@ func (typeOf *TypeMeta) itemCodeToId(msgName string, isTotalEnabled bool) (func(
 	return cvt, nil
 }
 
+// itemCodeOrIdToId return converter from dimension item code or item id to id.
+// It is also used for parameter values if parameter type is enum-based.
+// If dimension is enum-based then source can be enum code or enum id, enum code match take precedence over enum id;
+// If dimension is range or simple integer or boolean type then it is the same as itemCodeToId.
+func (typeOf *TypeMeta) itemCodeOrIdToId(msgName string, isTotalEnabled bool) (func(src string) (int, error), error) {
+
+	cvtCode, err := typeOf.itemCodeToId(msgName, isTotalEnabled)
+	if err != nil {
+		return nil, err
+	}
+	if typeOf.IsBuiltIn() || typeOf.IsRange {
+		return cvtCode, nil // range or built-in type: item id the same as code
+	}
+
+	cvt := func(src string) (int, error) {
+
+		if nId, e := cvtCode(src); e == nil {
+			return nId, nil // found by enum code
+		}
+
+		// enum dimension: check if source is enum id
+		nId, e := strconv.Atoi(src)
+		if e == nil {
+			if isTotalEnabled && nId == typeOf.TotalEnumId {
+				return nId, nil
+			}
+			for j := range typeOf.Enum {
+				if nId == typeOf.Enum[j].EnumId {
+					return nId, nil
+				}
+			}
+		}
+		return 0, errors.New("invalid value: " + src + " of: " + msgName)
+	}
+
+	return cvt, nil
+}
+
 // IsRunCompleted return true if run status one of: s=success, x=exit, e=error
 func IsRunCompleted(status string) bool {
 	return status == DoneRunStatus || status == ExitRunStatus || status == ErrorRunStatus
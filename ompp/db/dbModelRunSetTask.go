@@ -8,9 +8,10 @@ package db
 // That portion of model database updated during model run and should not be cached.
 
 // Model run status (run_lst table) and modeling task run status (task_run_lst table):
-//   if task status = w (wait) then
-//      model wait and NOT completed until other process set status to one of finals: s,x,e
-//      model check if any new sets inserted into task_set and run it as they arrive
+//
+//	if task status = w (wait) then
+//	   model wait and NOT completed until other process set status to one of finals: s,x,e
+//	   model check if any new sets inserted into task_set and run it as they arrive
 const (
 	InitRunStatus     = "i" // i = initial status
 	ProgressRunStatus = "p" // p = run in progress
@@ -337,9 +338,10 @@ type TaskTxtRow struct {
 // This table contains task run history and status.
 //
 // Task status: i=init p=progress w=wait s=success x=exit e=error(failed)
-//   if task status = w (wait) then
-//      model wait and NOT completed until other process set status to one of finals: s,x,e
-//      model check if any new sets inserted into task_set and run it as they arrive
+//
+//	if task status = w (wait) then
+//	   model wait and NOT completed until other process set status to one of finals: s,x,e
+//	   model check if any new sets inserted into task_set and run it as they arrive
 type TaskRunRow struct {
 	TaskRunId      int    // task_run_id INT          NOT NULL, -- unique task run id
 	TaskId         int    // task_id     INT          NOT NULL
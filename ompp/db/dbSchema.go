@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// DbSchemaItem is a db table or view of openM++ database: name, kind, row count and, for SQLite, page count and DDL.
+type DbSchemaItem struct {
+	Name      string // db table or view name
+	IsView    bool   // if true then it is a view
+	Kind      string // m=metadata or other, p=parameter run values, w=workset values, v=expressions, a=accumulators, d=all accumulators view, g=microdata
+	RowCount  int64  // number of rows in db table, -1 for views: rows of views are not counted
+	PageCount int64  // SQLite only: number of db pages of table and indexes, -1 if page count is unknown
+	Ddl       string // SQLite only: CREATE TABLE or CREATE VIEW statement
+}
+
+// GetDbSchema return list of all db tables and views: openM++ metadata tables, model values db tables and views.
+//
+// It does not select any values, only count rows of db tables.
+// For SQLite it also return DDL of each table or view and page count if dbstat virtual table available.
+// Result sorted by kind: metadata tables and views first, then model values tables and views, and by name.
+func GetDbSchema(dbConn *sql.DB) ([]DbSchemaItem, error) {
+
+	facet := facetOf(dbConn)
+	siLst := []DbSchemaItem{}
+
+	// select tables and views, for SQLite also select DDL
+	addRows := func(isView, isDdl bool) func(rows *sql.Rows) error {
+		return func(rows *sql.Rows) error {
+
+			si := DbSchemaItem{IsView: isView, Kind: "m", RowCount: -1, PageCount: -1}
+			var ddl sql.NullString
+			var err error
+			if isDdl {
+				err = rows.Scan(&si.Name, &si.IsView, &ddl)
+			} else {
+				err = rows.Scan(&si.Name)
+			}
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(si.Name, "sqlite_") {
+				return nil // skip SQLite internal tables
+			}
+			if ddl.Valid {
+				si.Ddl = ddl.String
+			}
+			if m := valueTableRx.FindStringSubmatch(si.Name); len(m) > 1 {
+				si.Kind = m[1]
+			}
+			siLst = append(siLst, si)
+			return nil
+		}
+	}
+
+	if facet == SqliteFacet {
+		err := SelectRows(dbConn,
+			"SELECT name, CASE WHEN type = 'view' THEN 1 ELSE 0 END, sql FROM sqlite_master WHERE type IN ('table', 'view')",
+			addRows(false, true))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, isView := range []bool{false, true} {
+			if err := SelectRows(dbConn, facet.selectTableNamesSql(isView), addRows(isView, false)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	slices.SortFunc(siLst, func(a, b DbSchemaItem) int {
+		if a.Kind == "m" && b.Kind != "m" {
+			return -1
+		}
+		if a.Kind != "m" && b.Kind == "m" {
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// count table rows and for SQLite count table pages, if page count is not available then do not try it again
+	isPages := facet == SqliteFacet
+
+	for k := range siLst {
+
+		if siLst[k].IsView {
+			continue
+		}
+		err := SelectFirst(dbConn,
+			"SELECT COUNT(*) FROM "+siLst[k].Name,
+			func(row *sql.Row) error {
+				return row.Scan(&siLst[k].RowCount)
+			})
+		if err != nil {
+			return nil, errors.New("failed to count rows of: " + siLst[k].Name + ": " + err.Error())
+		}
+
+		if isPages {
+			e := SelectFirst(dbConn,
+				"SELECT COUNT(*) FROM dbstat S"+
+					" INNER JOIN sqlite_master M ON (M.name = S.name)"+
+					" WHERE M.tbl_name = "+ToQuoted(siLst[k].Name),
+				func(row *sql.Row) error {
+					return row.Scan(&siLst[k].PageCount)
+				})
+			if e != nil {
+				isPages = false // page count is not available, SQLite without dbstat
+				siLst[k].PageCount = -1
+			}
+		}
+	}
+	return siLst, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDbSchema(t *testing.T) {
+
+	dbConn := openTestDb(t,
+		"CREATE TABLE salary_a12345678 (run_id INT, acc_id INT, sub_id INT, acc_value FLOAT)",
+		"CREATE VIEW salary_d12345678 AS SELECT run_id, sub_id, acc_value AS acc0 FROM salary_a12345678 WHERE acc_id = 0",
+		"CREATE TABLE ageSex_p12345678 (run_id INT, dim0 INT, param_value FLOAT)",
+		"INSERT INTO model_dic (model_id, model_name, model_digest, model_type, model_ver, create_dt, default_lang_id)"+
+			" VALUES (1, 'modelOne', 'm1', 0, '1.0', '2026-01-01 00:00:00.000', 0), (2, 'modelTwo', 'm2', 0, '1.0', '2026-01-01 00:00:00.000', 0)",
+		"INSERT INTO ageSex_p12345678 (run_id, dim0, param_value) VALUES (1, 0, 1), (1, 1, 2), (2, 0, 3)",
+	)
+	setFacetOf(dbConn, SqliteFacet)
+
+	// number of openM++ metadata tables created by schema script
+	nMeta := 0
+	bt, err := os.ReadFile(testSchemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range splitSqlScript(string(bt)) {
+		if strings.HasPrefix(q, "CREATE TABLE ") {
+			nMeta++
+		}
+	}
+
+	siLst, err := GetDbSchema(dbConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name     string
+		isView   bool
+		kind     string
+		rowCount int64
+	}{
+		{"model_dic", false, "m", 2},
+		{"ageSex_p12345678", false, "p", 3},
+		{"salary_a12345678", false, "a", 0},
+		{"salary_d12345678", true, "d", -1},
+	}
+	if len(siLst) != nMeta+3 {
+		t.Fatal("Fail: invalid db schema length:", len(siLst), nMeta+3)
+	}
+	siIdx := map[string]int{}
+	for k := range siLst {
+		siIdx[siLst[k].Name] = k
+	}
+	for _, w := range want {
+		k, ok := siIdx[w.name]
+		if !ok {
+			t.Error("Fail: db schema item not found:", w.name)
+			continue
+		}
+		si := siLst[k]
+		if si.IsView != w.isView || si.Kind != w.kind || si.RowCount != w.rowCount {
+			t.Error("Fail: invalid db schema item:", k, si)
+		}
+		if !strings.HasPrefix(si.Ddl, "CREATE ") {
+			t.Error("Fail: invalid db schema DDL:", k, si.Ddl)
+		}
+		if si.IsView && si.PageCount != -1 {
+			t.Error("Fail: unexpected page count of the view:", k, si.PageCount)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// OpenSnapshot make consistent copy of SQLite database in temporary file and open read-only connection to that copy.
+//
+// Database copy is created by SQLite online backup API in one step,
+// it is consistent even if source database is updated by model run at the same time.
+// Snapshot file is created in tempDir or in OS default temporary directory if tempDir is empty.
+// Return database connection and close function which must be called to close connection and delete snapshot file.
+// Only SQLite databases are supported: connection string is the same as for Open(), e.g.:
+//
+//	Database=modelName.sqlite; Timeout=86400; OpenMode=ReadOnly;
+//	file:m1.sqlite?mode=ro&_busy_timeout=86400000
+func OpenSnapshot(dbConnStr, dbDriver string, tempDir string) (*sql.DB, func(), error) {
+
+	if dbDriver != "" && dbDriver != SQLiteDbDriver && dbDriver != Sqlite3DbDriver {
+		return nil, nil, errors.New("database snapshot supported only for SQLite, invalid driver: " + dbDriver)
+	}
+
+	// open source database
+	srcDb, _, err := Open(dbConnStr, dbDriver, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer Close(srcDb)
+
+	// create snapshot file and copy source database into it
+	f, err := os.CreateTemp(tempDir, "ompp-snapshot-*.sqlite")
+	if err != nil {
+		return nil, nil, errors.New("failed to create database snapshot file: " + err.Error())
+	}
+	snapPath := f.Name()
+	f.Close()
+
+	if err = backupSqlite(srcDb, snapPath); err != nil {
+		os.Remove(snapPath)
+		return nil, nil, errors.New("failed to create database snapshot: " + err.Error())
+	}
+	omppLog.Log("Database snapshot: ", snapPath)
+
+	// open snapshot database read-only
+	dbConn, _, err := Open(MakeSqliteDefaultReadOnly(snapPath), SQLiteDbDriver, false)
+	if err != nil {
+		os.Remove(snapPath)
+		return nil, nil, err
+	}
+
+	closeSnapshot := func() {
+		Close(dbConn)
+		if e := os.Remove(snapPath); e != nil && !os.IsNotExist(e) {
+			omppLog.Log("Failed to delete database snapshot: ", snapPath, ": ", e.Error())
+		}
+	}
+	return dbConn, closeSnapshot, nil
+}
+
+// backupSqlite copy SQLite database into destination file using SQLite online backup API.
+// All database pages are copied in one step under read lock, which make destination a consistent snapshot of source.
+func backupSqlite(srcDb *sql.DB, dstPath string) error {
+
+	ctx := context.Background()
+
+	srcConn, err := srcDb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	dstDb, err := sql.Open(Sqlite3DbDriver, "file:"+dstPath+"?mode=rwc")
+	if err != nil {
+		return err
+	}
+	defer dstDb.Close()
+
+	dstConn, err := dstDb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+
+	return dstConn.Raw(func(dc any) error {
+		return srcConn.Raw(func(sc any) error {
+
+			dst, ok := dc.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.New("invalid destination database connection, expected SQLite")
+			}
+			src, ok := sc.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.New("invalid source database connection, expected SQLite")
+			}
+
+			bk, err := dst.Backup("main", src, "main")
+			if err != nil {
+				return err
+			}
+			if _, err = bk.Step(-1); err != nil {
+				bk.Finish()
+				return err
+			}
+			return bk.Finish()
+		})
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// DbTableUsage is a parameter or output table values db table and list of models which are sharing it.
+//
+// Parameters and output tables with the same digest are shared between models in the same database:
+// values of such parameter or output table stored in the same db table for all models.
+type DbTableUsage struct {
+	Kind        string   // p=parameter run values, w=workset values, v=expressions, a=accumulators, same as OrphanTable kind
+	Name        string   // parameter or output table name
+	Digest      string   // parameter or output table digest
+	DbTable     string   // db table name
+	RowCount    int64    // number of rows in db table
+	Size        int64    // db table and table indexes size in bytes, -1 if size is unknown
+	ModelDigest []string // digests of models which are using that db table
+}
+
+// GetDbUsage return list of parameter and output table values db tables, models using each table, row count and size.
+//
+// Parameter produce two rows: run values and workset values db tables,
+// output table also produce two rows: expressions and accumulators db tables.
+// If GetDbUsage cannot obtain db table size, e.g. it is SQLite without dbstat virtual table, then Size is -1.
+// Result sorted by kind: parameters first, then output tables, and by parameter or output table Hid.
+func GetDbUsage(dbConn *sql.DB) ([]DbTableUsage, error) {
+
+	// get digests of all models
+	mdMap := map[int]string{}
+
+	err := SelectRows(dbConn,
+		"SELECT model_id, model_digest FROM model_dic ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var mId int
+			var d string
+			if err := rows.Scan(&mId, &d); err != nil {
+				return err
+			}
+			mdMap[mId] = d
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// select parameters and output tables db tables with models using it
+	tuLst := []DbTableUsage{}
+
+	addRows := func(kind1, kind2 string) func(rows *sql.Rows) error {
+
+		lastHid := 0
+		return func(rows *sql.Rows) error {
+
+			var hid, mId int
+			var name, digest, t1, t2 string
+			if err := rows.Scan(&hid, &name, &digest, &t1, &t2, &mId); err != nil {
+				return err
+			}
+			if hid != lastHid {
+				lastHid = hid
+				tuLst = append(tuLst,
+					DbTableUsage{Kind: kind1, Name: name, Digest: digest, DbTable: t1, Size: -1, ModelDigest: []string{}},
+					DbTableUsage{Kind: kind2, Name: name, Digest: digest, DbTable: t2, Size: -1, ModelDigest: []string{}},
+				)
+			}
+			if d, ok := mdMap[mId]; ok {
+				n := len(tuLst)
+				tuLst[n-2].ModelDigest = append(tuLst[n-2].ModelDigest, d)
+				tuLst[n-1].ModelDigest = append(tuLst[n-1].ModelDigest, d)
+			}
+			return nil
+		}
+	}
+
+	err = SelectRows(dbConn,
+		"SELECT D.parameter_hid, D.parameter_name, D.parameter_digest, D.db_run_table, D.db_set_table, M.model_id"+
+			" FROM parameter_dic D"+
+			" INNER JOIN model_parameter_dic M ON (M.parameter_hid = D.parameter_hid)"+
+			" ORDER BY 1, 6",
+		addRows("p", "w"))
+	if err != nil {
+		return nil, err
+	}
+
+	err = SelectRows(dbConn,
+		"SELECT D.table_hid, D.table_name, D.table_digest, D.db_expr_table, D.db_acc_table, M.model_id"+
+			" FROM table_dic D"+
+			" INNER JOIN model_table_dic M ON (M.table_hid = D.table_hid)"+
+			" ORDER BY 1, 6",
+		addRows("v", "a"))
+	if err != nil {
+		return nil, err
+	}
+
+	// count rows and get db table size, if table size is not available then do not try it again
+	facet := facetOf(dbConn)
+	isSize := facet.selectTableSizeSql("") != ""
+
+	for k := range tuLst {
+
+		err = SelectFirst(dbConn,
+			"SELECT COUNT(*) FROM "+tuLst[k].DbTable,
+			func(row *sql.Row) error {
+				return row.Scan(&tuLst[k].RowCount)
+			})
+		if err != nil {
+			return nil, errors.New("failed to count rows of: " + tuLst[k].DbTable + ": " + err.Error())
+		}
+
+		if isSize {
+			var n sql.NullInt64
+			e := SelectFirst(dbConn,
+				facet.selectTableSizeSql(tuLst[k].DbTable),
+				func(row *sql.Row) error {
+					return row.Scan(&n)
+				})
+			switch {
+			case e != nil && e != sql.ErrNoRows:
+				isSize = false // table size is not available, e.g. SQLite without dbstat
+			case e == nil && n.Valid:
+				tuLst[k].Size = n.Int64
+			}
+		}
+	}
+	return tuLst, nil
+}
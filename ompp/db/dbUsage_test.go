@@ -0,0 +1,59 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestDbUsage(t *testing.T) {
+
+	// two models sharing parameter ageSex, second model has its own output table
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageSex_p12345678 (run_id INT, dim0 INT, param_value FLOAT)",
+		"CREATE TABLE ageSex_w12345678 (set_id INT, dim0 INT, param_value FLOAT)",
+		"CREATE TABLE salary_v12345678 (run_id INT, expr_id INT, expr_value FLOAT)",
+		"CREATE TABLE salary_a12345678 (run_id INT, acc_id INT, sub_id INT, acc_value FLOAT)",
+		"INSERT INTO model_dic (model_id, model_name, model_digest, model_type, model_ver, create_dt, default_lang_id)"+
+			" VALUES (1, 'modelOne', 'm1', 0, '1.0', '2026-01-01 00:00:00.000', 0), (2, 'modelTwo', 'm2', 0, '1.0', '2026-01-01 00:00:00.000', 0)",
+		"INSERT INTO parameter_dic"+
+			" (parameter_hid, parameter_name, parameter_digest, db_run_table, db_set_table, parameter_rank, type_hid, is_extendable, num_cumulated, import_digest)"+
+			" VALUES (10, 'ageSex', 'p10', 'ageSex_p12345678', 'ageSex_w12345678', 1, 7, 0, 0, 'i10')",
+		"INSERT INTO model_parameter_dic (model_id, model_parameter_id, parameter_hid, is_hidden) VALUES (1, 0, 10, 0), (2, 0, 10, 0)",
+		"INSERT INTO table_dic"+
+			" (table_hid, table_name, table_digest, table_rank, is_sparse, db_expr_table, db_acc_table, db_acc_all_view, import_digest)"+
+			" VALUES (20, 'salary', 't20', 0, 0, 'salary_v12345678', 'salary_a12345678', 'salary_d12345678', 'i20')",
+		"INSERT INTO model_table_dic (model_id, model_table_id, table_hid, is_user, expr_dim_pos, is_hidden) VALUES (2, 0, 20, 0, 0, 0)",
+		"INSERT INTO ageSex_p12345678 (run_id, dim0, param_value) VALUES (1, 0, 1), (1, 1, 2), (2, 0, 3)",
+		"INSERT INTO salary_a12345678 (run_id, acc_id, sub_id, acc_value) VALUES (2, 0, 0, 1)",
+	)
+
+	tuLst, err := GetDbUsage(dbConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		kind, name, dbTable string
+		rowCount            int64
+		models              int
+	}{
+		{"p", "ageSex", "ageSex_p12345678", 3, 2},
+		{"w", "ageSex", "ageSex_w12345678", 0, 2},
+		{"v", "salary", "salary_v12345678", 0, 1},
+		{"a", "salary", "salary_a12345678", 1, 1},
+	}
+	if len(tuLst) != len(want) {
+		t.Fatalf("invalid number of db tables: %d, expected: %d", len(tuLst), len(want))
+	}
+	for k, w := range want {
+		tu := tuLst[k]
+		if tu.Kind != w.kind || tu.Name != w.name || tu.DbTable != w.dbTable || tu.RowCount != w.rowCount || len(tu.ModelDigest) != w.models {
+			t.Errorf("invalid db table usage [%d]: %+v", k, tu)
+		}
+	}
+	if tuLst[0].ModelDigest[0] != "m1" || tuLst[0].ModelDigest[1] != "m2" || tuLst[3].ModelDigest[0] != "m2" {
+		t.Errorf("invalid model digests: %v %v", tuLst[0].ModelDigest, tuLst[3].ModelDigest)
+	}
+}
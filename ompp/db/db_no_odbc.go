@@ -1,6 +1,7 @@
 // Copyright (c) 2016 OpenM++
 // This code is licensed under the MIT license (see LICENSE.txt for details)
 
+//go:build !odbc
 // +build !odbc
 
 package db
@@ -18,11 +18,43 @@ func DeleteModel(dbConn *sql.DB, modelId int) error {
 	}
 
 	// delete inside of transaction scope
+	dbFacet := facetOf(dbConn)
+	isLock := isWorksetLockTableExist(dbConn)
+	isHist := isTableSelectable(dbConn, worksetParamVersionTable)
+	isStats := isTableSelectable(dbConn, tableStatsTable)
+
+	histTables, err := modelParamHistTables(dbConn, modelId)
+	if err != nil {
+		return err
+	}
+
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err := doDeleteModel(trx, modelId); err != nil {
+	if isLock {
+		if err := trxDeleteModelWorksetLocks(trx, modelId); err != nil {
+			trx.Rollback()
+			return err
+		}
+	}
+	if isHist {
+		if err := trxDeleteModelParamHistory(trx, modelId, histTables); err != nil {
+			trx.Rollback()
+			return err
+		}
+	}
+	if isStats {
+		if err := trxDeleteModelTableExprStats(trx, modelId); err != nil {
+			trx.Rollback()
+			return err
+		}
+	}
+	if err := doDeleteModel(trx, dbFacet, modelId); err != nil {
+		trx.Rollback()
+		return err
+	}
+	if err := trxCreateMapViews(trx, dbFacet, false); err != nil { // refresh model mapping views, if views exist
 		trx.Rollback()
 		return err
 	}
@@ -32,7 +64,7 @@ func DeleteModel(dbConn *sql.DB, modelId int) error {
 
 // delete existing model metadata and drop model data tables from database.
 // It does update as part of transaction
-func doDeleteModel(trx *sql.Tx, modelId int) error {
+func doDeleteModel(trx *sql.Tx, dbFacet Facet, modelId int) error {
 
 	// update model master record to prevent model use
 	smId := strconv.Itoa(modelId)
@@ -632,8 +664,12 @@ func doDeleteModel(trx *sql.Tx, modelId int) error {
 		if n <= 0 {
 			return errors.New("Unable to delete model run microdata:" + " " + strconv.Itoa(r.hId) + " " + "error: new base run id not found, old run id:" + " " + strconv.Itoa(r.runId))
 		}
+		vTbl, err := trxMicroValueTable(trx, dbFacet, mHidTbl[r.hId])
+		if err != nil {
+			return err
+		}
 		err = TrxUpdate(trx,
-			"UPDATE "+mHidTbl[r.hId]+" SET run_id = "+strconv.Itoa(n)+" WHERE run_id = "+strconv.Itoa(r.runId))
+			"UPDATE "+vTbl+" SET run_id = "+strconv.Itoa(n)+" WHERE run_id = "+strconv.Itoa(r.runId))
 		if err != nil {
 			return err
 		}
@@ -1266,7 +1302,7 @@ func doDeleteModel(trx *sql.Tx, modelId int) error {
 	// where entity not shared between models
 	for k := range mdArr {
 
-		err = TrxUpdate(trx, "DROP TABLE "+mdArr[k].tbl)
+		err = trxDropMicroTable(trx, dbFacet, mdArr[k].tbl)
 		if err != nil {
 			return err
 		}
@@ -21,11 +21,21 @@ func DeleteRun(dbConn *sql.DB, runId int) error {
 	}
 
 	// delete inside of transaction scope
+	// delete output tables statistics of the run, if statistics table exists
+	dbFacet := facetOf(dbConn)
+	isStats := isTableSelectable(dbConn, tableStatsTable)
+
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err := doDeleteRun(trx, runId); err != nil {
+	if isStats {
+		err = trxDeleteRunTableExprStats(trx, runId)
+	}
+	if err == nil {
+		err = doDeleteRun(trx, dbFacet, runId)
+	}
+	if err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -36,7 +46,7 @@ func DeleteRun(dbConn *sql.DB, runId int) error {
 // delete model run metadata and run values (parameter, output tables, microdata) run values from database.
 // if run values used by any other run as a base run then base run id updated to the next minimal run id.
 // It does update as part of transaction
-func doDeleteRun(trx *sql.Tx, runId int) error {
+func doDeleteRun(trx *sql.Tx, dbFacet Facet, runId int) error {
 
 	// update model run master record to prevent run use
 	sId := strconv.Itoa(runId)
@@ -546,8 +556,12 @@ func doDeleteRun(trx *sql.Tx, runId int) error {
 		if n <= 0 {
 			return errors.New("Unable to delete model run microdata:" + " " + strconv.Itoa(hId) + " " + "error: new base run id not found")
 		}
+		vTbl, err := trxMicroValueTable(trx, dbFacet, mHidTbl[hId])
+		if err != nil {
+			return err
+		}
 		err = TrxUpdate(trx,
-			"UPDATE "+mHidTbl[hId]+" SET run_id = "+strconv.Itoa(n)+" WHERE run_id = "+sId)
+			"UPDATE "+vTbl+" SET run_id = "+strconv.Itoa(n)+" WHERE run_id = "+sId)
 		if err != nil {
 			return err
 		}
@@ -641,7 +655,11 @@ func doDeleteRun(trx *sql.Tx, runId int) error {
 		// or drop microdata table if there are no run_entity rows exist for that table
 		if n <= 0 {
 
-			err = TrxUpdate(trx, "DELETE FROM "+mTbls[k]+" WHERE run_id = "+sId)
+			vTbl, err := trxMicroValueTable(trx, dbFacet, mTbls[k])
+			if err != nil {
+				return err
+			}
+			err = TrxUpdate(trx, "DELETE FROM "+vTbl+" WHERE run_id = "+sId)
 			if err != nil {
 				return err
 			}
@@ -670,7 +688,7 @@ func doDeleteRun(trx *sql.Tx, runId int) error {
 				return err
 			}
 
-			err = TrxUpdate(trx, "DROP TABLE "+mTbls[k])
+			err = trxDropMicroTable(trx, dbFacet, mTbls[k])
 			if err != nil {
 				return err
 			}
@@ -13,6 +13,8 @@ import (
 )
 
 // DeleteWorkset delete workset metadata and workset parameter values from database.
+// Workset cannot be deleted if there are active read locks of the workset.
+// History of workset parameter values, if exists, is also deleted.
 func DeleteWorkset(dbConn *sql.DB, setId int) error {
 
 	// validate parameters
@@ -21,16 +23,30 @@ func DeleteWorkset(dbConn *sql.DB, setId int) error {
 	}
 
 	// delete inside of transaction scope
+	// workset cannot be deleted while it is locked by model run or other reader
+	isLock := isWorksetLockTableExist(dbConn)
+
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err := dbDeleteWorkset(trx, setId); err != nil {
+	if isLock {
+		err = trxCheckWorksetNotLocked(trx, setId)
+		if err == nil {
+			err = TrxUpdate(trx, "DELETE FROM "+worksetLockTable+" WHERE set_id = "+strconv.Itoa(setId))
+		}
+	}
+	if err == nil {
+		err = dbDeleteWorkset(trx, setId)
+	}
+	if err != nil {
 		trx.Rollback()
 		return err
 	}
 	trx.Commit()
-	return nil
+
+	// delete history of workset parameter values, if any
+	return deleteWorksetParamHistory(dbConn, setId)
 }
 
 // DeleteWorksetAllParameters delete all parameters metadata and values from workset.
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// entityKeyIndexName return name of index on (run_id, entity_key) columns of microdata db table, e.g.: Person_g87abcdef_ek
+func entityKeyIndexName(dbTable string) string {
+	if len(dbTable) > maxTableNameSize-3 {
+		dbTable = dbTable[:maxTableNameSize-3]
+	}
+	return dbTable + "_ek"
+}
+
+// IsEntityKeyIndex return true if entity generation microdata db table has index on (run_id, entity_key) columns,
+// created by EnsureEntityKeyIndex().
+func IsEntityKeyIndex(dbConn *sql.DB, entityGen *EntityGenMeta) (bool, error) {
+
+	if entityGen == nil || entityGen.DbEntityTable == "" {
+		return false, errors.New("invalid (empty) entity generation or microdata db table name")
+	}
+
+	q := facetOf(dbConn).selectIndexCountSql(entityGen.DbEntityTable, entityKeyIndexName(entityGen.DbEntityTable))
+	if q == "" {
+		return false, errors.New("unable to check index of microdata table: " + entityGen.DbEntityTable + ", it is not supported by database")
+	}
+
+	n := 0
+	err := SelectFirst(dbConn, q,
+		func(row *sql.Row) error {
+			return row.Scan(&n)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, errors.New("failed to check index of microdata table: " + entityGen.DbEntityTable + ": " + err.Error())
+	}
+	return n > 0, nil
+}
+
+// EnsureEntityKeyIndex create index on (run_id, entity_key) columns of entity generation microdata db table, if not exists.
+// Return true if index created or false if index already exists.
+//
+// Microdata db tables created by openM++ have primary key (run_id, entity_key) which is usually enough to read entity key range.
+// Index is useful if microdata db table created or bulk loaded without primary key by other tools.
+func EnsureEntityKeyIndex(dbConn *sql.DB, entityGen *EntityGenMeta) (bool, error) {
+
+	isExist, err := IsEntityKeyIndex(dbConn, entityGen)
+	if err != nil {
+		return false, err
+	}
+	if isExist {
+		return false, nil
+	}
+
+	err = Update(dbConn,
+		"CREATE INDEX "+entityKeyIndexName(entityGen.DbEntityTable)+" ON "+entityGen.DbEntityTable+" (run_id, entity_key)")
+	if err != nil {
+		return false, errors.New("failed to create index of microdata table: " + entityGen.DbEntityTable + ": " + err.Error())
+	}
+	return true, nil
+}
+
+// EnsureRunEntityKeyIndex create index on (run_id, entity_key) columns of microdata db tables of all entity generations in model run.
+// Return number of indexes created.
+func EnsureRunEntityKeyIndex(dbConn *sql.DB, runId int) (int, error) {
+
+	egLst, err := GetEntityGenList(dbConn, runId)
+	if err != nil {
+		return 0, errors.New("failed to get entity generations of model run, id: " + strconv.Itoa(runId) + ": " + err.Error())
+	}
+
+	n := 0
+	for k := range egLst {
+		isNew, err := EnsureEntityKeyIndex(dbConn, &egLst[k])
+		if err != nil {
+			return n, err
+		}
+		if isNew {
+			n++
+		}
+	}
+	return n, nil
+}
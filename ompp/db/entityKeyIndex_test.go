@@ -0,0 +1,46 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntityKeyIndex(t *testing.T) {
+
+	dbConn := openTestDb(t, "CREATE TABLE Person_g87abcdef (run_id INT NOT NULL, entity_key BIGINT NOT NULL, attr4 INT NOT NULL)")
+	setFacetOf(dbConn, SqliteFacet)
+
+	eg := &EntityGenMeta{}
+	eg.DbEntityTable = "Person_g87abcdef"
+
+	isExist, err := IsEntityKeyIndex(dbConn, eg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isExist {
+		t.Error("Fail: index must not exist before EnsureEntityKeyIndex")
+	}
+
+	// first call must create index and second call must do nothing
+	isNew, err := EnsureEntityKeyIndex(dbConn, eg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isNew {
+		t.Error("Fail: index must be created")
+	}
+	if isExist, err = IsEntityKeyIndex(dbConn, eg); err != nil || !isExist {
+		t.Error("Fail: index must exist after EnsureEntityKeyIndex", err)
+	}
+	if isNew, err = EnsureEntityKeyIndex(dbConn, eg); err != nil || isNew {
+		t.Error("Fail: index must not be created second time", err)
+	}
+
+	// index name must not exceed max db name size
+	if s := entityKeyIndexName(strings.Repeat("a", 100)); len(s) > maxTableNameSize || !strings.HasSuffix(s, "_ek") {
+		t.Error("Fail: invalid index name:", s)
+	}
+}
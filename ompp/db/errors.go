@@ -0,0 +1,54 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors returned by db functions, use errors.Is() to check the kind of error:
+//
+//	if errors.Is(err, db.ErrRunNotFound) { .... }
+//
+// Error message is the same as before, for example: "model run not found, id: 11",
+// and it is wrapping one of these errors.
+var (
+	ErrModelNotFound   = errors.New("model not found")                                  // model not found in database
+	ErrRunNotFound     = errors.New("model run not found")                              // model run not found
+	ErrWorksetNotFound = errors.New("workset not found")                                // input scenario (workset) not found
+	ErrTaskNotFound    = errors.New("modeling task not found")                          // modeling task not found
+	ErrNotOmppSchema   = errors.New("invalid database, likely not an openM++ database") // it is not an openM++ database
+	ErrSchemaVersion   = errors.New("incompatible version of openM++ database")         // openM++ database schema version not supported
+	ErrLocked          = errors.New("database is locked")                               // database is locked or busy
+)
+
+// dbError is an error with its own message wrapping one of db errors: ErrModelNotFound, ErrRunNotFound, etc.
+type dbError struct {
+	kind error  // kind of error: ErrModelNotFound, ErrRunNotFound, etc.
+	msg  string // error message
+}
+
+func (e *dbError) Error() string { return e.msg }
+func (e *dbError) Unwrap() error { return e.kind }
+
+// return new error with message which is wrapping kind of db error
+func newDbError(kind error, msg string) error {
+	return &dbError{kind: kind, msg: msg}
+}
+
+// if source error is database locked or busy error then return error wrapping ErrLocked, else return source error as is.
+// It does check error message because db driver errors are not exposed by ompp/db: SQLite return "database is locked".
+func toLockedError(err error) error {
+
+	if err == nil || errors.Is(err, ErrLocked) {
+		return err
+	}
+	s := strings.ToLower(err.Error())
+
+	if strings.Contains(s, "database is locked") || strings.Contains(s, "database table is locked") || strings.Contains(s, "sqlite_busy") {
+		return newDbError(ErrLocked, err.Error())
+	}
+	return err
+}
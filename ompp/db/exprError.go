@@ -0,0 +1,242 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExprError is an error in output table calculation or microdata aggregation expression.
+//
+// It contains error position in source expression, offending fragment and list of expected symbols, if known.
+// Position and length are in characters (unicode code points) of source expression, position is zero based.
+// If error position is unknown then Pos is -1.
+// Use AsExprError() to get expression error from error returned by calculation functions.
+type ExprError struct {
+	Context  string   // error context, e.g.: Error at outputTableName
+	Msg      string   // error message, e.g.: unbalanced brackets after: OM_AVG
+	Expr     string   // source expression
+	Pos      int      // zero based error position in source expression, -1 if position unknown
+	Len      int      // length of offending fragment
+	Fragment string   // offending fragment of source expression
+	Expected []string // expected symbols, e.g.: ( or )
+}
+
+// Error return error message with context, error position, expected symbols and source expression.
+func (e *ExprError) Error() string {
+
+	s := ""
+	if e.Context != "" {
+		s = e.Context + ": "
+	}
+	s += "Error in expression, " + e.Msg
+	if e.Pos >= 0 {
+		s += " at position " + strconv.Itoa(e.Pos+1)
+	}
+	if len(e.Expected) > 0 {
+		s += ", expected: " + strings.Join(e.Expected, " ")
+	}
+	return s + ": " + e.Expr
+}
+
+// Marker return source expression line and marker line which underline error position, for example:
+//
+//	OM_AVG(acc0 + 'a)
+//	              ^
+//
+// It return empty "" marker line if error position is unknown.
+func (e *ExprError) Marker() (string, string) {
+
+	src := cleanSourceExpr(e.Expr)
+	if e.Pos < 0 {
+		return src, ""
+	}
+	n := e.Len
+	if n <= 0 {
+		n = 1
+	}
+	return src, strings.Repeat(" ", e.Pos) + strings.Repeat("^", n)
+}
+
+// AsExprError return expression error if err is an error in calculation or aggregation expression.
+func AsExprError(err error) (*ExprError, bool) {
+	var e *ExprError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// CheckTableCalculate validate output table calculation expression without reading any data from database.
+//
+// It return nil if expression can be translated into sql or error, which can be an ExprError with error position.
+// If calcLt.IsAggr true then it must be accumulator(s) aggregation else output table expression(s) calculation.
+func CheckTableCalculate(modelDef *ModelMeta, tableName string, calcLt *CalculateTableLayout) error {
+	_, err := TranslateTableCalculate(modelDef, tableName, calcLt)
+	return err
+}
+
+// TranslateTableCalculate validate output table calculation expression and return sql query without reading any data from database.
+//
+// Sql query is the same as used to calculate output table values, except of run id(s) which are not known and zero run id is used.
+// It return error if expression is invalid, which can be an ExprError with error position.
+// If calcLt.IsAggr true then it must be accumulator(s) aggregation else output table expression(s) calculation.
+func TranslateTableCalculate(modelDef *ModelMeta, tableName string, calcLt *CalculateTableLayout) (string, error) {
+
+	if modelDef == nil {
+		return "", newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	if calcLt == nil || calcLt.Calculate == "" {
+		return "", errors.New("invalid (empty) calculation expression")
+	}
+
+	var table *TableMeta
+	if k, ok := modelDef.OutTableByName(tableName); ok {
+		table = &modelDef.Table[k]
+	} else {
+		return "", errors.New("output table not found: " + tableName)
+	}
+
+	cl := *calcLt
+	if cl.CalcId < CALCULATED_ID_OFFSET {
+		cl.CalcId = CALCULATED_ID_OFFSET
+	}
+	return translateTableCalcToSql(modelDef, table, &ReadLayout{Name: tableName}, []CalculateTableLayout{cl}, []int{})
+}
+
+// TranslateMicroCalculate validate microdata aggregation expression and return sql query without reading any data from database.
+//
+// Model run is not known and entity generation is made from all entity attributes,
+// sql query is using db table name of such entity generation and zero run id.
+// It return error if expression or group by attributes are invalid, error can be an ExprError with error position.
+func TranslateMicroCalculate(modelDef *ModelMeta, entityName string, calcLt *CalculateLayout, groupBy []string) (string, error) {
+
+	if modelDef == nil {
+		return "", newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	if calcLt == nil || calcLt.Calculate == "" {
+		return "", errors.New("invalid (empty) microdata aggregation expression")
+	}
+	if len(groupBy) <= 0 {
+		return "", errors.New("invalid (empty) microdata group by attributes: " + entityName)
+	}
+
+	eIdx, ok := modelDef.EntityByName(entityName)
+	if !ok {
+		return "", errors.New("entity not found: " + entityName)
+	}
+	entity := &modelDef.Entity[eIdx]
+
+	// group by attributes must boolean or not built-in
+	for _, name := range groupBy {
+
+		k, ok := entity.AttrByName(name)
+		if !ok {
+			return "", errors.New("entity group by attribute not found by: " + entity.Name + "." + name)
+		}
+		if entity.Attr[k].typeOf.IsBuiltIn() && !entity.Attr[k].typeOf.IsBool() {
+			return "", errors.New("invalid type of entity group by attribute: " + entity.Name + "." + name + " : " + entity.Attr[k].typeOf.Name)
+		}
+	}
+
+	// make entity generation from all entity attributes
+	eg := EntityGenMeta{GenAttr: make([]entityGenAttrRow, len(entity.Attr))}
+	eg.EntityId = entity.EntityId
+	eg.EntityHid = entity.EntityHid
+
+	for k := range entity.Attr {
+		eg.GenAttr[k].AttrId = entity.Attr[k].AttrId
+	}
+	rm := RunMeta{EntityGen: []EntityGenMeta{eg}}
+
+	if err := rm.updateEntityGenInternals(modelDef); err != nil {
+		return "", err
+	}
+
+	cl := *calcLt
+	if cl.CalcId < CALCULATED_ID_OFFSET {
+		cl.CalcId = CALCULATED_ID_OFFSET
+	}
+	return translateMicroToSql(
+		modelDef, entity, &rm.EntityGen[0], &ReadLayout{Name: entityName}, &CalculateMicroLayout{Calculation: []CalculateLayout{cl}, GroupBy: groupBy}, []int{})
+}
+
+// newExprError return expression error at byte position in expression.
+// If position is negative and fragment is not empty then position of the first occurence of fragment is used.
+func newExprError(msg, expr string, bytePos int, fragment string, expected ...string) *ExprError {
+
+	if bytePos < 0 && fragment != "" {
+		bytePos = strings.Index(expr, fragment)
+	}
+	pos := -1
+	if bytePos >= 0 && bytePos <= len(expr) {
+		pos = utf8.RuneCountInString(expr[:bytePos])
+	}
+	return &ExprError{
+		Msg:      msg,
+		Expr:     expr,
+		Pos:      pos,
+		Len:      utf8.RuneCountInString(fragment),
+		Fragment: fragment,
+		Expected: expected,
+	}
+}
+
+// exprErrorAt return error with context prefix.
+// If it is an expression error then error position is located in source expression.
+// Expression errors are found in cleaned or partially translated source expression,
+// if it is partially translated then error position is a position of offending fragment in source expression.
+func exprErrorAt(context, srcExpr string, err error) error {
+
+	e, ok := AsExprError(err)
+	if !ok {
+		return errors.New(context + ": " + err.Error())
+	}
+	ee := *e
+	ee.Context = context
+
+	// cleaned source expression have the same characters count as original source
+	if ee.Expr != srcExpr && ee.Expr != cleanSourceExpr(srcExpr) {
+		ee.Pos = -1
+		if ee.Fragment != "" {
+			if n := strings.Index(cleanSourceExpr(srcExpr), ee.Fragment); n >= 0 {
+				ee.Pos = utf8.RuneCountInString(cleanSourceExpr(srcExpr)[:n])
+			}
+		}
+	}
+	ee.Expr = srcExpr
+	return &ee
+}
+
+// return start byte position and name at position in expression, e.g.: Expr1[base] or param.Name.
+// Name is delimited by space or left delimiters on the left and space, comma or right delimiters on the right.
+func exprNameAt(expr string, bytePos int) (int, string) {
+
+	if bytePos < 0 || bytePos >= len(expr) {
+		return bytePos, ""
+	}
+
+	nStart := bytePos
+	for nStart > 0 {
+		r, n := utf8.DecodeLastRuneInString(expr[:nStart])
+		if unicode.IsSpace(r) || strings.ContainsRune(leftDelims, r) {
+			break
+		}
+		nStart -= n
+	}
+
+	nEnd := bytePos
+	for nEnd < len(expr) {
+		r, n := utf8.DecodeRuneInString(expr[nEnd:])
+		if r == ',' || unicode.IsSpace(r) || r != '[' && strings.ContainsRune(rightDelims, r) {
+			break
+		}
+		nEnd += n
+	}
+	return nStart, expr[nStart:nEnd]
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExprErrorPosition(t *testing.T) {
+
+	// error position, fragment and marker of unsafe sql
+	testSql := []struct {
+		src      string
+		pos      int
+		fragment string
+		marker   string
+	}{
+		{src: "acc0 + 1; DROP", pos: 8, fragment: ";", marker: "        ^"},
+		{src: "'a;b' + acc0 -- x", pos: 13, fragment: "--", marker: "             ^^"},
+		{src: "acc0 + 'x' + DELETE", pos: 13, fragment: "DELETE", marker: "             ^^^^^^"},
+		{src: "acc0 + 'x", pos: 7, fragment: "'", marker: "       ^"},
+	}
+	for _, ts := range testSql {
+
+		err := errorIfUnsafeSqlOrComment(ts.src)
+		e, ok := AsExprError(err)
+		if !ok {
+			t.Errorf("expected expression error: %s: %v", ts.src, err)
+			continue
+		}
+		if e.Pos != ts.pos || e.Fragment != ts.fragment {
+			t.Errorf("invalid error position: %s: %d %s expected: %d %s", ts.src, e.Pos, e.Fragment, ts.pos, ts.fragment)
+		}
+		if _, m := e.Marker(); m != ts.marker {
+			t.Errorf("invalid error marker: %s: [%s] expected: [%s]", ts.src, m, ts.marker)
+		}
+	}
+
+	// error position in source expression after partial translation, position is in characters
+	src := "OM_AVG(acc0) + ÄÖ ; 1"
+	err := exprErrorAt("Error at T", src, newExprError("semicolon found", "AVG(acc0) + ÄÖ ; 1", -1, ";"))
+	if e, ok := AsExprError(err); !ok || e.Pos != 18 || e.Expr != src || e.Context != "Error at T" {
+		t.Errorf("invalid error position in source expression: %v", err)
+	}
+
+	// not an expression error
+	err = exprErrorAt("Error at T", src, errors.New("test"))
+	if _, ok := AsExprError(err); ok || err.Error() != "Error at T: test" {
+		t.Errorf("invalid error: %v", err)
+	}
+
+	// name at position
+	if n, nm := exprNameAt("OM_AVG(Expr1[base]) - 1", 9); n != 7 || nm != "Expr1[base]" {
+		t.Errorf("invalid name at position: %d %s", n, nm)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// ParseFilterText return list of filter conditions from text, conditions are ; semicolon separated:
+//
+//	Age>=20;Sex=F;Province=BC,AB,ON
+//
+// Each condition is attribute or dimension name, operator and value or comma separated list of values.
+// Operator is one of: = != > >= < <=, if operator is = equal and there is a list of values then it is IN filter.
+// For string or enum-based attributes value is a string or enum code, it can be escaped with "double" or 'single' quotes.
+func ParseFilterText(src string) ([]FilterColumn, error) {
+
+	fLst := []FilterColumn{}
+
+	for _, cond := range helper.ParseCsvLine(src, ';') {
+
+		if cond == "" {
+			continue
+		}
+
+		// find start of operator: name must not be empty
+		nOp := strings.IndexAny(cond, "=!<>")
+		if nOp <= 0 {
+			return nil, errors.New("invalid filter condition, expected: name=value, actual: " + cond)
+		}
+		name := strings.TrimSpace(cond[:nOp])
+		if name == "" {
+			return nil, errors.New("invalid (empty) name of filter condition: " + cond)
+		}
+
+		// operators sorted in order to match longest first
+		var op FilterOp
+		rest := cond[nOp:]
+
+		for _, fo := range []FilterOp{NeOpFilter, GeOpFilter, LeOpFilter, EqOpFilter, GtOpFilter, LtOpFilter} {
+			if strings.HasPrefix(rest, string(fo)) {
+				op = fo
+				rest = rest[len(fo):]
+				break
+			}
+		}
+		if op == "" || strings.ContainsAny(rest[:min(1, len(rest))], "=!<>") {
+			return nil, errors.New("invalid operator of filter condition, expected one of: = != > >= < <=, actual: " + cond)
+		}
+
+		vals := helper.ParseCsvLine(rest, ',')
+		if len(vals) <= 0 {
+			return nil, errors.New("invalid (empty) value of filter condition: " + cond)
+		}
+		if len(vals) > 1 {
+			if op != EqOpFilter {
+				return nil, errors.New("invalid filter condition, list of values allowed only with = operator: " + cond)
+			}
+			op = InOpFilter
+		}
+
+		fLst = append(fLst, FilterColumn{Name: name, Op: op, Values: vals})
+	}
+	return fLst, nil
+}
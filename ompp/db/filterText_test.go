@@ -0,0 +1,43 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestParseFilterText(t *testing.T) {
+
+	fLst, err := ParseFilterText("Age>=20; Sex=F ;Province=BC,AB,ON;Income!=0;Name='a;b'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fLst) != 5 {
+		t.Fatalf("expected 5 conditions, got: %d", len(fLst))
+	}
+	if fLst[0].Name != "Age" || fLst[0].Op != GeOpFilter || len(fLst[0].Values) != 1 || fLst[0].Values[0] != "20" {
+		t.Errorf("invalid condition: %+v", fLst[0])
+	}
+	if fLst[1].Name != "Sex" || fLst[1].Op != EqOpFilter || fLst[1].Values[0] != "F" {
+		t.Errorf("invalid condition: %+v", fLst[1])
+	}
+	if fLst[2].Op != InOpFilter || len(fLst[2].Values) != 3 || fLst[2].Values[2] != "ON" {
+		t.Errorf("invalid condition: %+v", fLst[2])
+	}
+	if fLst[3].Op != NeOpFilter {
+		t.Errorf("invalid condition: %+v", fLst[3])
+	}
+	if fLst[4].Name != "Name" || fLst[4].Values[0] != "a;b" {
+		t.Errorf("invalid condition: %+v", fLst[4])
+	}
+
+	if fLst, err = ParseFilterText(""); err != nil || len(fLst) != 0 {
+		t.Errorf("expected empty filter: %v %v", fLst, err)
+	}
+	for _, src := range []string{"Age", "=20", "Age=", "Age>1,2", "Age=>1"} {
+		if _, err = ParseFilterText(src); err == nil {
+			t.Errorf("expected error: %s", src)
+		}
+	}
+}
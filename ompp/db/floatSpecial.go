@@ -0,0 +1,186 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/text/message"
+)
+
+// FloatSpecialWrite is a policy to write special float values: NaN, +Inf, -Inf into database
+type FloatSpecialWrite int
+
+const (
+	FloatSpecialKeep     FloatSpecialWrite = iota // default: write special float value as is, it may fail on some databases, e.g.: MSSQL or MySQL
+	FloatSpecialNull                              // write special float value as NULL, it is an error if value cannot be NULL
+	FloatSpecialSentinel                          // write NaN as sentinel value, +Inf as max float and -Inf as min float, convert it back on read
+	FloatSpecialError                             // return an error if value is NaN, +Inf or -Inf
+)
+
+// canonical rendering of special float values in csv and text output, it can be parsed back by strconv.ParseFloat
+const (
+	NaNText    = "NaN"  // canonical text of NaN value
+	PosInfText = "+Inf" // canonical text of positive infinity
+	NegInfText = "-Inf" // canonical text of negative infinity
+)
+
+// FloatSpecialPolicy is a policy to write and read special float values: NaN, +Inf, -Inf
+type FloatSpecialPolicy struct {
+	OnWrite  FloatSpecialWrite // policy to write special float values into database
+	Sentinel float64           // if OnWrite is FloatSpecialSentinel then NaN value is written as Sentinel
+}
+
+// current policy of special float values, by default special values written as is
+var theFloatSpecial atomic.Pointer[FloatSpecialPolicy]
+
+// SetFloatSpecial set policy to write and read special float values: NaN, +Inf, -Inf.
+// It must be called before any database write or read, policy is the same for all database connections.
+func SetFloatSpecial(policy FloatSpecialPolicy) {
+	theFloatSpecial.Store(&policy)
+}
+
+// return current special float values policy
+func floatSpecial() FloatSpecialPolicy {
+	if p := theFloatSpecial.Load(); p != nil {
+		return *p
+	}
+	return FloatSpecialPolicy{}
+}
+
+// ParseFloatSpecial return special float values policy from string:
+//
+//	keep           write NaN, +Inf, -Inf as is (default)
+//	null           write NaN, +Inf, -Inf as NULL
+//	error          it is an error to write NaN, +Inf, -Inf
+//	sentinel       write NaN as -1.0e308, +Inf as max float and -Inf as min float
+//	sentinel:-9999 write NaN as -9999, +Inf as max float and -Inf as min float
+//
+// If policy is sentinel then sentinel values are converted back to NaN, +Inf, -Inf on read.
+func ParseFloatSpecial(src string) (FloatSpecialPolicy, error) {
+
+	sp, sv, isSv := strings.Cut(strings.TrimSpace(src), ":")
+
+	switch strings.ToLower(strings.TrimSpace(sp)) {
+	case "", "keep":
+		if !isSv {
+			return FloatSpecialPolicy{OnWrite: FloatSpecialKeep}, nil
+		}
+	case "null":
+		if !isSv {
+			return FloatSpecialPolicy{OnWrite: FloatSpecialNull}, nil
+		}
+	case "error":
+		if !isSv {
+			return FloatSpecialPolicy{OnWrite: FloatSpecialError}, nil
+		}
+	case "sentinel":
+		if !isSv {
+			return FloatSpecialPolicy{OnWrite: FloatSpecialSentinel, Sentinel: -1.0e308}, nil
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(sv), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v == math.MaxFloat64 || v == -math.MaxFloat64 {
+			return FloatSpecialPolicy{}, errors.New("invalid sentinel value of special float policy: " + src)
+		}
+		return FloatSpecialPolicy{OnWrite: FloatSpecialSentinel, Sentinel: v}, nil
+	}
+	return FloatSpecialPolicy{}, errors.New("invalid special float policy, expected one of: keep, null, error, sentinel or sentinel:value, actual: " + src)
+}
+
+// return true if value is NaN, +Inf or -Inf
+func isFloatSpecial(v float64) bool {
+	return math.IsNaN(v) || math.IsInf(v, 0)
+}
+
+// floatToDb convert float value to write into database using special float values policy.
+// Return value, is NULL flag and error if value cannot be written.
+func floatToDb(v float64, isNullable bool, msgName string) (float64, bool, error) {
+
+	if !isFloatSpecial(v) {
+		return v, false, nil
+	}
+	p := floatSpecial()
+
+	switch p.OnWrite {
+	case FloatSpecialNull:
+		if !isNullable {
+			return 0.0, false, errors.New("invalid value " + formatFloat("%g", v) + ", it cannot be NULL " + msgName)
+		}
+		return 0.0, true, nil
+	case FloatSpecialSentinel:
+		switch {
+		case math.IsInf(v, 1):
+			return math.MaxFloat64, false, nil
+		case math.IsInf(v, -1):
+			return -math.MaxFloat64, false, nil
+		}
+		return p.Sentinel, false, nil
+	case FloatSpecialError:
+		return 0.0, false, errors.New("invalid value " + formatFloat("%g", v) + " " + msgName)
+	}
+	return v, false, nil // keep value as is
+}
+
+// floatFromDb convert float value read from database using special float values policy:
+// if policy is sentinel then sentinel value converted to NaN, max float to +Inf and min float to -Inf.
+func floatFromDb(v float64) float64 {
+
+	p := floatSpecial()
+	if p.OnWrite != FloatSpecialSentinel {
+		return v
+	}
+	switch v {
+	case p.Sentinel:
+		return math.NaN()
+	case math.MaxFloat64:
+		return math.Inf(1)
+	case -math.MaxFloat64:
+		return math.Inf(-1)
+	}
+	return v
+}
+
+// return canonical text of special float value: NaN, +Inf, -Inf and true or empty string and false if value is not special
+func floatSpecialText(src interface{}) (string, bool) {
+
+	var v float64
+	switch fv := src.(type) {
+	case float64:
+		v = fv
+	case float32:
+		v = float64(fv)
+	default:
+		return "", false
+	}
+	switch {
+	case math.IsNaN(v):
+		return NaNText, true
+	case math.IsInf(v, 1):
+		return PosInfText, true
+	case math.IsInf(v, -1):
+		return NegInfText, true
+	}
+	return "", false
+}
+
+// formatFloat return float value formatted by format string, special values NaN, +Inf, -Inf are rendered as canonical text
+func formatFloat(format string, v interface{}) string {
+	if s, ok := floatSpecialText(v); ok {
+		return s
+	}
+	return fmt.Sprintf(format, v)
+}
+
+// formatFloatLocale return float value formatted by locale printer, special values NaN, +Inf, -Inf are rendered as canonical text
+func formatFloatLocale(prt *message.Printer, format string, v interface{}) string {
+	if s, ok := floatSpecialText(v); ok {
+		return s
+	}
+	return prt.Sprintf(format, v)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloatSpecial(t *testing.T) {
+
+	defer SetFloatSpecial(FloatSpecialPolicy{})
+
+	// parse policy
+	for _, src := range []string{"", "keep", "NULL", "error", "sentinel", "sentinel:-9999"} {
+		if _, err := ParseFloatSpecial(src); err != nil {
+			t.Errorf("unexpected error: %s: %v", src, err)
+		}
+	}
+	for _, src := range []string{"zero", "null:1", "sentinel:NaN", "sentinel:abc"} {
+		if _, err := ParseFloatSpecial(src); err == nil {
+			t.Errorf("expected error: %s", src)
+		}
+	}
+
+	// canonical rendering
+	if s := formatFloat("%.2f", math.NaN()); s != NaNText {
+		t.Errorf("invalid NaN text: %s", s)
+	}
+	if s := formatFloat("%.2f", math.Inf(1)); s != PosInfText {
+		t.Errorf("invalid +Inf text: %s", s)
+	}
+	if s := formatFloat("%.2f", math.Inf(-1)); s != NegInfText {
+		t.Errorf("invalid -Inf text: %s", s)
+	}
+	if s := formatFloat("%.2f", 1.234); s != "1.23" {
+		t.Errorf("invalid float text: %s", s)
+	}
+
+	// default: keep value as is
+	if v, isNull, err := floatToDb(math.Inf(1), false, "p"); err != nil || isNull || !math.IsInf(v, 1) {
+		t.Errorf("invalid keep result: %v %v %v", v, isNull, err)
+	}
+
+	// write as NULL
+	p, _ := ParseFloatSpecial("null")
+	SetFloatSpecial(p)
+
+	if _, isNull, err := floatToDb(math.NaN(), true, "p"); err != nil || !isNull {
+		t.Errorf("invalid null result: %v %v", isNull, err)
+	}
+	if _, _, err := floatToDb(math.NaN(), false, "p"); err == nil {
+		t.Error("expected error if value cannot be NULL")
+	}
+	if v, isNull, err := floatToDb(2.5, false, "p"); err != nil || isNull || v != 2.5 {
+		t.Errorf("invalid regular value result: %v %v %v", v, isNull, err)
+	}
+
+	// error on special value
+	p, _ = ParseFloatSpecial("error")
+	SetFloatSpecial(p)
+
+	if _, _, err := floatToDb(math.Inf(-1), true, "p"); err == nil {
+		t.Error("expected error on -Inf")
+	}
+
+	// sentinel round trip
+	p, _ = ParseFloatSpecial("sentinel:-9999")
+	SetFloatSpecial(p)
+
+	for _, src := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+
+		v, isNull, err := floatToDb(src, false, "p")
+		if err != nil || isNull || isFloatSpecial(v) {
+			t.Errorf("invalid sentinel result: %v %v %v", v, isNull, err)
+		}
+		r := floatFromDb(v)
+		if math.IsNaN(src) && !math.IsNaN(r) || !math.IsNaN(src) && r != src {
+			t.Errorf("invalid sentinel round trip: %v %v", src, r)
+		}
+	}
+	if v, _, _ := floatToDb(math.NaN(), false, "p"); v != -9999 {
+		t.Errorf("invalid NaN sentinel: %v", v)
+	}
+}
@@ -55,7 +55,7 @@ func GetProfile(dbConn *sql.DB, name string) (*ProfileMeta, error) {
 func GetRunOptions(dbConn *sql.DB, runId int) (map[string]string, error) {
 
 	return getOpts(dbConn,
-		"SELECT option_key, option_value FROM run_option WHERE run_id = "+strconv.Itoa(runId))
+		"SELECT M.option_key, M.option_value FROM run_option M WHERE M.run_id = "+strconv.Itoa(runId)+runFilterExists("M.run_id"))
 }
 
 // getOpts return option table (profile_option or run_option) rows as (key, value) map.
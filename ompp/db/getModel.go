@@ -124,7 +124,7 @@ func GetModel(dbConn *sql.DB, name, digest string) (*ModelMeta, error) {
 		return nil, err
 	}
 	if !isExist {
-		return nil, errors.New("model " + name + " " + digest + " not found")
+		return nil, newDbError(ErrModelNotFound, "model "+name+" "+digest+" not found")
 	}
 
 	return GetModelById(dbConn, mId)
@@ -146,7 +146,7 @@ func GetModelById(dbConn *sql.DB, modelId int) (*ModelMeta, error) {
 		return nil, err
 	}
 	if modelRow == nil {
-		return nil, errors.New("model not found, id: " + strconv.Itoa(modelId))
+		return nil, newDbError(ErrModelNotFound, "model not found, id: "+strconv.Itoa(modelId))
 	}
 
 	return getModel(dbConn, modelRow)
@@ -6,7 +6,9 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 // GetRun return model run row by id: run_lst table row.
@@ -17,7 +19,8 @@ func GetRun(dbConn *sql.DB, runId int) (*RunRow, error) {
 			" H.sub_started, H.sub_completed, H.create_dt, H.status,"+
 			" H.update_dt, H.run_digest, H.value_digest, H.run_stamp"+
 			" FROM run_lst H"+
-			" WHERE H.run_id = "+strconv.Itoa(runId))
+			" WHERE H.run_id = "+strconv.Itoa(runId)+
+			runFilterAnd("H"))
 }
 
 // GetFirstRun return first run of the model: run_lst table row.
@@ -29,7 +32,7 @@ func GetFirstRun(dbConn *sql.DB, modelId int) (*RunRow, error) {
 			" H.update_dt, H.run_digest, H.value_digest, H.run_stamp"+
 			" FROM run_lst H"+
 			" WHERE H.run_id ="+
-			" (SELECT MIN(M.run_id) FROM run_lst M WHERE M.model_id = "+strconv.Itoa(modelId)+")")
+			" (SELECT MIN(M.run_id) FROM run_lst M WHERE M.model_id = "+strconv.Itoa(modelId)+runFilterAnd("M")+")")
 }
 
 // GetLastRun return last run of the model: run_lst table row.
@@ -41,7 +44,7 @@ func GetLastRun(dbConn *sql.DB, modelId int) (*RunRow, error) {
 			" H.update_dt, H.run_digest, H.value_digest, H.run_stamp"+
 			" FROM run_lst H"+
 			" WHERE H.run_id ="+
-			" (SELECT MAX(M.run_id) FROM run_lst M WHERE M.model_id = "+strconv.Itoa(modelId)+")")
+			" (SELECT MAX(M.run_id) FROM run_lst M WHERE M.model_id = "+strconv.Itoa(modelId)+runFilterAnd("M")+")")
 }
 
 // GetLastCompletedRun return last completed run of the model: run_lst table row.
@@ -59,6 +62,7 @@ func GetLastCompletedRun(dbConn *sql.DB, modelId int) (*RunRow, error) {
 			" SELECT MAX(M.run_id) FROM run_lst M"+
 			" WHERE M.model_id = "+strconv.Itoa(modelId)+
 			" AND M.status IN ("+ToQuoted(DoneRunStatus)+", "+ToQuoted(ErrorRunStatus)+", "+ToQuoted(ExitRunStatus)+")"+
+			runFilterAnd("M")+
 			" )")
 }
 
@@ -70,7 +74,8 @@ func GetRunByDigest(dbConn *sql.DB, digest string) (*RunRow, error) {
 			" H.sub_started, H.sub_completed, H.create_dt, H.status,"+
 			" H.update_dt, H.run_digest, H.value_digest, H.run_stamp"+
 			" FROM run_lst H"+
-			" WHERE H.run_digest = "+ToQuoted(digest))
+			" WHERE H.run_digest = "+ToQuoted(digest)+
+			runFilterAnd("H"))
 }
 
 // GetRunByStamp return model run row by run stamp: run_lst table row.
@@ -88,6 +93,7 @@ func GetRunByStamp(dbConn *sql.DB, modelId int, stamp string) (*RunRow, error) {
 			" SELECT MIN(M.run_id) FROM run_lst M"+
 			" WHERE M.model_id = "+strconv.Itoa(modelId)+
 			" AND M.run_stamp = "+ToQuoted(stamp)+
+			runFilterAnd("M")+
 			")")
 }
 
@@ -106,6 +112,7 @@ func GetRunByName(dbConn *sql.DB, modelId int, name string) (*RunRow, error) {
 			" SELECT MIN(M.run_id) FROM run_lst M"+
 			" WHERE M.model_id = "+strconv.Itoa(modelId)+
 			" AND M.run_name = "+ToQuoted(name)+
+			runFilterAnd("M")+
 			")")
 }
 
@@ -124,6 +131,7 @@ func GetLastRunByName(dbConn *sql.DB, modelId int, name string) (*RunRow, error)
 			" SELECT MAX(M.run_id) FROM run_lst M"+
 			" WHERE M.model_id = "+strconv.Itoa(modelId)+
 			" AND M.run_name = "+ToQuoted(name)+
+			runFilterAnd("M")+
 			")")
 }
 
@@ -154,22 +162,23 @@ func GetRunListByDigestStampName(dbConn *sql.DB, modelId int, rdsn string) ([]Ru
 		" H.sub_started, H.sub_completed, H.create_dt, H.status," +
 		" H.update_dt, H.run_digest, H.value_digest, H.run_stamp" +
 		" FROM run_lst H"
+	rf := runFilterAnd("H")
 
 	rLst, err := getRunLst(dbConn,
 		sql+" WHERE H.model_id = "+strconv.Itoa(modelId)+
-			" AND H.run_digest = "+ToQuoted(rdsn)+
+			" AND H.run_digest = "+ToQuoted(rdsn)+rf+
 			" ORDER BY 1")
 
 	if err == nil && len(rLst) <= 0 {
 		rLst, err = getRunLst(dbConn,
 			sql+" WHERE H.model_id = "+strconv.Itoa(modelId)+
-				" AND H.run_stamp = "+ToQuoted(rdsn)+
+				" AND H.run_stamp = "+ToQuoted(rdsn)+rf+
 				" ORDER BY 1")
 	}
 	if err == nil && len(rLst) <= 0 {
 		rLst, err = getRunLst(dbConn,
 			sql+" WHERE H.model_id = "+strconv.Itoa(modelId)+
-				" AND H.run_name = "+ToQuoted(rdsn)+
+				" AND H.run_name = "+ToQuoted(rdsn)+rf+
 				" ORDER BY 1")
 	}
 	return rLst, err
@@ -211,17 +220,10 @@ func getRunLst(dbConn *sql.DB, query string) ([]RunRow, error) {
 
 	err := SelectRows(dbConn, query,
 		func(rows *sql.Rows) error {
-			var r RunRow
-			var svd sql.NullString
-			if err := rows.Scan(
-				&r.RunId, &r.ModelId, &r.Name, &r.SubCount,
-				&r.SubStarted, &r.SubCompleted, &r.CreateDateTime, &r.Status,
-				&r.UpdateDateTime, &r.RunDigest, &svd, &r.RunStamp); err != nil {
+			r, err := scanRunRow(rows)
+			if err != nil {
 				return err
 			}
-			if svd.Valid {
-				r.ValueDigest = svd.String
-			}
 			runRs = append(runRs, r)
 			return nil
 		})
@@ -231,6 +233,23 @@ func getRunLst(dbConn *sql.DB, query string) ([]RunRow, error) {
 	return runRs, nil
 }
 
+// scanRunRow return run_lst row from select results.
+func scanRunRow(rows *sql.Rows) (RunRow, error) {
+
+	var r RunRow
+	var svd sql.NullString
+	if err := rows.Scan(
+		&r.RunId, &r.ModelId, &r.Name, &r.SubCount,
+		&r.SubStarted, &r.SubCompleted, &r.CreateDateTime, &r.Status,
+		&r.UpdateDateTime, &r.RunDigest, &svd, &r.RunStamp); err != nil {
+		return r, err
+	}
+	if svd.Valid {
+		r.ValueDigest = svd.String
+	}
+	return r, nil
+}
+
 // GetRunList return list of model runs by model_id: run_lst rows.
 func GetRunList(dbConn *sql.DB, modelId int) ([]RunRow, error) {
 
@@ -246,6 +265,7 @@ func GetRunList(dbConn *sql.DB, modelId int) ([]RunRow, error) {
 		" H.update_dt, H.run_digest, H.value_digest, H.run_stamp" +
 		" FROM run_lst H" +
 		" WHERE H.model_id = " + strconv.Itoa(modelId) +
+		runFilterAnd("H") +
 		" ORDER BY 1"
 
 	runRs, err := getRunLst(dbConn, q)
@@ -276,6 +296,7 @@ func GetRunListText(dbConn *sql.DB, modelId int, langCode string) ([]RunRow, []R
 		" H.update_dt, H.run_digest, H.value_digest, H.run_stamp" +
 		" FROM run_lst H" +
 		" WHERE H.model_id = " + strconv.Itoa(modelId) +
+		runFilterAnd("H") +
 		" ORDER BY 1"
 
 	runRs, err := getRunLst(dbConn, q)
@@ -291,7 +312,8 @@ func GetRunListText(dbConn *sql.DB, modelId int, langCode string) ([]RunRow, []R
 		" FROM run_txt M" +
 		" INNER JOIN run_lst H ON (H.run_id = M.run_id)" +
 		" INNER JOIN lang_lst L ON (L.lang_id = M.lang_id)" +
-		" WHERE H.model_id = " + strconv.Itoa(modelId)
+		" WHERE H.model_id = " + strconv.Itoa(modelId) +
+		runFilterAnd("H")
 	if langCode != "" {
 		q += " AND L.lang_code = " + ToQuoted(langCode)
 	}
@@ -304,6 +326,146 @@ func GetRunListText(dbConn *sql.DB, modelId int, langCode string) ([]RunRow, []R
 	return runRs, txtRs, nil
 }
 
+// GetRunListPage return page of model runs by model_id: run_lst rows,
+// actual page layout and total count of model runs.
+//
+// Runs are sorted by layout OrderBy: "id", "create", "update", "name" or "status", default is by run id.
+// If layout AfterId is positive then only runs after that run id selected (keyset paging), it requires run id sort order.
+// If layout page size <= 0 then all model runs selected.
+func GetRunListPage(dbConn *sql.DB, modelId int, layout RunListLayout) ([]RunRow, *ReadPageLayout, int, error) {
+
+	// model not found: model id must be positive
+	if modelId <= 0 {
+		return nil, &ReadPageLayout{IsLastPage: true}, 0, nil
+	}
+
+	where, orderBy, err := runListWhereOrderBy(modelId, layout)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// total count of model runs
+	nTotal := 0
+	err = SelectFirst(dbConn,
+		"SELECT COUNT(*) FROM run_lst H WHERE H.model_id = "+strconv.Itoa(modelId)+runFilterAnd("H"),
+		func(row *sql.Row) error {
+			return row.Scan(&nTotal)
+		})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// get page of runs for that model id
+	q := "SELECT" +
+		" H.run_id, H.model_id, H.run_name, H.sub_count," +
+		" H.sub_started, H.sub_completed, H.create_dt, H.status," +
+		" H.update_dt, H.run_digest, H.value_digest, H.run_stamp" +
+		" FROM run_lst H" +
+		" WHERE " + where +
+		" ORDER BY " + orderBy
+
+	lst, lt, err := SelectToList(dbConn, q, layout.ReadPageLayout,
+		func(rows *sql.Rows) (interface{}, error) {
+			return scanRunRow(rows)
+		})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	runRs := make([]RunRow, 0, lst.Len())
+	for el := lst.Front(); el != nil; el = el.Next() {
+		runRs = append(runRs, el.Value.(RunRow))
+	}
+	return runRs, lt, nTotal, nil
+}
+
+// GetRunListTextPage return page of model runs with description and notes: run_lst and run_txt rows,
+// actual page layout and total count of model runs.
+//
+// Runs are sorted and selected same way as GetRunListPage() does, run_txt rows sorted by run id and language id.
+// If langCode not empty then only specified language selected else all languages
+func GetRunListTextPage(dbConn *sql.DB, modelId int, langCode string, layout RunListLayout) ([]RunRow, []RunTxtRow, *ReadPageLayout, int, error) {
+
+	runRs, lt, nTotal, err := GetRunListPage(dbConn, modelId, layout)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	if len(runRs) <= 0 { // no model runs
+		return nil, nil, lt, nTotal, nil
+	}
+
+	// get run description and notes by model id and language, only for the runs of current page
+	idLst := make([]int, len(runRs))
+	for k := range runRs {
+		idLst[k] = runRs[k].RunId
+	}
+	where := makeIdInList("H.run_id", idLst)
+
+	q := "SELECT M.run_id, M.lang_id, L.lang_code, M.descr, M.note" +
+		" FROM run_txt M" +
+		" INNER JOIN run_lst H ON (H.run_id = M.run_id)" +
+		" INNER JOIN lang_lst L ON (L.lang_id = M.lang_id)" +
+		" WHERE " + where
+	if langCode != "" {
+		q += " AND L.lang_code = " + ToQuoted(langCode)
+	}
+	q += " ORDER BY 1, 2"
+
+	txtRs, err := getRunText(dbConn, q)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+
+	ids := make(map[int]bool, len(runRs))
+	for k := range runRs {
+		ids[runRs[k].RunId] = true
+	}
+	txtRs = slices.DeleteFunc(txtRs, func(t RunTxtRow) bool { return !ids[t.RunId] })
+
+	return runRs, txtRs, lt, nTotal, nil
+}
+
+// return WHERE and ORDER BY of run list select: filter by model id and optional keyset after run id.
+func runListWhereOrderBy(modelId int, layout RunListLayout) (string, string, error) {
+
+	col := ""
+	switch strings.ToLower(layout.OrderBy) {
+	case "", "id":
+	case "create":
+		col = "H.create_dt"
+	case "update":
+		col = "H.update_dt"
+	case "name":
+		col = "H.run_name"
+	case "status":
+		col = "H.status"
+	default:
+		return "", "", errors.New("invalid run list sort order: " + layout.OrderBy)
+	}
+	if layout.AfterId > 0 && col != "" {
+		return "", "", errors.New("run list paging after run id can be used only with run id sort order: " + layout.OrderBy)
+	}
+
+	desc := ""
+	if layout.IsDesc {
+		desc = " DESC"
+	}
+	orderBy := "H.run_id" + desc
+	if col != "" {
+		orderBy = col + desc + ", " + orderBy
+	}
+
+	where := "H.model_id = " + strconv.Itoa(modelId) + runFilterAnd("H")
+	if layout.AfterId > 0 {
+		if layout.IsDesc {
+			where += " AND H.run_id < " + strconv.Itoa(layout.AfterId)
+		} else {
+			where += " AND H.run_id > " + strconv.Itoa(layout.AfterId)
+		}
+	}
+	return where, orderBy, nil
+}
+
 // GetRunText return model run description and notes: run_txt table rows.
 //
 // If langCode not empty then only specified language selected else all languages
@@ -312,7 +474,8 @@ func GetRunText(dbConn *sql.DB, runId int, langCode string) ([]RunTxtRow, error)
 	q := "SELECT M.run_id, M.lang_id, L.lang_code, M.descr, M.note" +
 		" FROM run_txt M" +
 		" INNER JOIN lang_lst L ON (L.lang_id = M.lang_id)" +
-		" WHERE M.run_id = " + strconv.Itoa(runId)
+		" WHERE M.run_id = " + strconv.Itoa(runId) +
+		runFilterExists("M.run_id")
 	if langCode != "" {
 		q += " AND L.lang_code = " + ToQuoted(langCode)
 	}
@@ -357,7 +520,8 @@ func GetRunParamText(dbConn *sql.DB, runId int, paramHid int, langCode string) (
 		" FROM run_parameter_txt M" +
 		" INNER JOIN lang_lst L ON (L.lang_id = M.lang_id)" +
 		" WHERE M.run_id = " + strconv.Itoa(runId) +
-		" AND M.parameter_hid = " + strconv.Itoa(paramHid)
+		" AND M.parameter_hid = " + strconv.Itoa(paramHid) +
+		runFilterExists("M.run_id")
 	if langCode != "" {
 		q += " AND L.lang_code = " + ToQuoted(langCode)
 	}
@@ -376,7 +540,8 @@ func GetRunAllParamText(dbConn *sql.DB, runId int, langCode string) ([]RunParamT
 	q := "SELECT M.run_id, M.parameter_hid, M.lang_id, L.lang_code, M.note" +
 		" FROM run_parameter_txt M" +
 		" INNER JOIN lang_lst L ON (L.lang_id = M.lang_id)" +
-		" WHERE M.run_id = " + strconv.Itoa(runId)
+		" WHERE M.run_id = " + strconv.Itoa(runId) +
+		runFilterExists("M.run_id")
 	if langCode != "" {
 		q += " AND L.lang_code = " + ToQuoted(langCode)
 	}
@@ -422,6 +587,7 @@ func GetRunProgress(dbConn *sql.DB, runId int) ([]RunProgress, error) {
 			" RP.run_id, RP.sub_id, RP.create_dt, RP.status, RP.update_dt, RP.progress_count, RP.progress_value"+
 			" FROM run_progress RP"+
 			" WHERE RP.run_id = "+strconv.Itoa(runId)+
+			runFilterExists("RP.run_id")+
 			" ORDER BY 1, 2")
 	if err != nil {
 		return nil, err
@@ -470,6 +636,7 @@ func GetEntityGenList(dbConn *sql.DB, runId int) ([]EntityGenMeta, error) {
 		" INNER JOIN entity_gen EG ON (EG.entity_gen_hid = RE.entity_gen_hid)" +
 		" INNER JOIN model_entity_dic ME ON (ME.model_id = H.model_id AND ME.entity_hid = EG.entity_hid)" +
 		" WHERE H.run_id = " + strconv.Itoa(runId) +
+		runFilterAnd("H") +
 		" ORDER BY 1, 2"
 
 	err := SelectRows(dbConn, q,
@@ -496,6 +663,7 @@ func GetEntityGenList(dbConn *sql.DB, runId int) ([]EntityGenMeta, error) {
 		" INNER JOIN run_entity RE ON (RE.run_id = H.run_id)" +
 		" INNER JOIN entity_gen_attr EA ON (EA.entity_gen_hid = RE.entity_gen_hid)" +
 		" WHERE H.run_id = " + strconv.Itoa(runId) +
+		runFilterAnd("H") +
 		" ORDER BY 1, 2, 3"
 
 	err = SelectRows(dbConn, q,
@@ -590,7 +758,10 @@ func GetRunFull(dbConn *sql.DB, runRow *RunRow) (*RunMeta, error) {
 
 	// validate parameters
 	if runRow == nil {
-		return nil, errors.New("invalid (empty) model run row, it may be model run not found")
+		return nil, newDbError(ErrRunNotFound, "invalid (empty) model run row, it may be model run not found")
+	}
+	if err := checkRunFilter(dbConn, runRow.RunId); err != nil {
+		return nil, err
 	}
 	sRunId := strconv.Itoa(runRow.RunId)
 
@@ -695,7 +866,7 @@ func GetRunFullText(dbConn *sql.DB, runRow *RunRow, isSuccess bool, langCode str
 
 	// validate parameters
 	if runRow == nil {
-		return nil, errors.New("invalid (empty) model run row, it may be model run not found")
+		return nil, newDbError(ErrRunNotFound, "invalid (empty) model run row, it may be model run not found")
 	}
 	sRunId := strconv.Itoa(runRow.RunId)
 
@@ -707,6 +878,7 @@ func GetRunFullText(dbConn *sql.DB, runRow *RunRow, isSuccess bool, langCode str
 		runWhere += " AND H.status IN (" +
 			ToQuoted(DoneRunStatus) + ", " + ToQuoted(ErrorRunStatus) + ", " + ToQuoted(ExitRunStatus) + ", " + ToQuoted(ProgressRunStatus) + ")"
 	}
+	runWhere += runFilterAnd("H")
 
 	var langFilter string
 	if langCode != "" {
@@ -861,6 +1033,7 @@ func GetRunFullTextList(dbConn *sql.DB, modelId int, isSuccess bool, langCode st
 		statusFilter = " AND H.status IN (" +
 			ToQuoted(DoneRunStatus) + ", " + ToQuoted(ErrorRunStatus) + ", " + ToQuoted(ExitRunStatus) + ", " + ToQuoted(ProgressRunStatus) + ")"
 	}
+	statusFilter += runFilterAnd("H")
 
 	var langFilter string
 	if langCode != "" {
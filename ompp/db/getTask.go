@@ -5,7 +5,6 @@ package db
 
 import (
 	"database/sql"
-	"errors"
 	"strconv"
 )
 
@@ -499,7 +498,7 @@ func GetTaskRunList(dbConn *sql.DB, taskRow *TaskRow) (*TaskMeta, error) {
 
 	// validate parameters
 	if taskRow == nil {
-		return nil, errors.New("invalid (empty) task row, it may be task not found")
+		return nil, newDbError(ErrTaskNotFound, "invalid (empty) task row, it may be task not found")
 	}
 
 	// task meta header: task_lst master row and empty details
@@ -622,7 +621,7 @@ func GetTaskFull(dbConn *sql.DB, taskRow *TaskRow, isSuccess bool, langCode stri
 
 	// validate parameters
 	if taskRow == nil {
-		return nil, errors.New("invalid (empty) task row, it may be task not found")
+		return nil, newDbError(ErrTaskNotFound, "invalid (empty) task row, it may be task not found")
 	}
 
 	// where filters
@@ -5,7 +5,6 @@ package db
 
 import (
 	"database/sql"
-	"errors"
 	"strconv"
 )
 
@@ -362,7 +361,7 @@ func GetWorksetFull(dbConn *sql.DB, setRow *WorksetRow, langCode string) (*Works
 
 	// validate parameters
 	if setRow == nil {
-		return nil, errors.New("invalid (empty) workset row, it may be workset not found")
+		return nil, newDbError(ErrWorksetNotFound, "invalid (empty) workset row, it may be workset not found")
 	}
 
 	// where filters
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// UpstreamRun is upstream model run to import downstream model parameters from.
+type UpstreamRun struct {
+	DbConn   *sql.DB    // database connection to upstream model
+	ModelDef *ModelMeta // upstream model metadata
+	Run      *RunRow    // upstream model run, it must be completed
+}
+
+// ParamImportMap is resolved import of downstream model parameter from upstream model run parameter or output table.
+type ParamImportMap struct {
+	ParamName   string       // downstream model parameter name
+	FromModel   string       // upstream model name: model_parameter_import.from_model_name
+	FromName    string       // upstream parameter or output table name: model_parameter_import.from_name
+	IsFromTable bool         // if true then import from upstream output table first expression else from upstream parameter
+	ExprName    string       // if import from output table then expression name
+	Upstream    *UpstreamRun // upstream model run to import from
+}
+
+// ResolveParamImport return list of downstream model parameters which can be imported from upstream model runs.
+//
+// Parameter import is defined by model_parameter_import rows: upstream model name and upstream parameter or output table name.
+// Upstream runs are checked in order of the list and first run of upstream model is used.
+// Upstream parameter name is searched first and if not found then upstream output table name.
+// Import digest of upstream parameter or output table must be the same as downstream parameter import digest.
+//
+// It is an error if import digest not matched, if upstream parameter or output table not found
+// or if sample dimension import required (is_sample_dim), which is not supported.
+// Parameters which do not have imports from any of upstream models are not included in result.
+func ResolveParamImport(modelDef *ModelMeta, upLst []UpstreamRun) ([]ParamImportMap, error) {
+
+	// validate parameters
+	if modelDef == nil {
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	for k := range upLst {
+		if upLst[k].ModelDef == nil {
+			return nil, newDbError(ErrModelNotFound, "invalid (empty) upstream model metadata, look like model not found")
+		}
+		if upLst[k].Run == nil {
+			return nil, newDbError(ErrRunNotFound, "invalid (empty) upstream model run, model: "+upLst[k].ModelDef.Model.Name)
+		}
+		if !IsRunCompleted(upLst[k].Run.Status) {
+			return nil, errors.New("upstream model run not completed: " + strconv.Itoa(upLst[k].Run.RunId) + " " + upLst[k].Run.Name)
+		}
+	}
+
+	imLst := []ParamImportMap{}
+
+	for j := range modelDef.Param {
+
+		pm := &modelDef.Param[j]
+		isFound := false
+
+		for i := 0; !isFound && i < len(pm.Import); i++ {
+
+			pi := &pm.Import[i]
+
+			// find first upstream run of that model
+			var up *UpstreamRun
+			for k := range upLst {
+				if upLst[k].ModelDef.Model.Name == pi.FromModel {
+					up = &upLst[k]
+					break
+				}
+			}
+			if up == nil {
+				continue // there is no upstream run of that model
+			}
+
+			if pi.IsSampleDim {
+				return nil, errors.New("parameter import with sample dimension is not supported: " + pm.Name + " from: " + pi.FromModel + "." + pi.FromName)
+			}
+			im := ParamImportMap{ParamName: pm.Name, FromModel: pi.FromModel, FromName: pi.FromName, Upstream: up}
+
+			// find upstream parameter or output table and check import digest
+			dgst := ""
+			if k, ok := up.ModelDef.ParamByName(pi.FromName); ok {
+				dgst = up.ModelDef.Param[k].ImportDigest
+			} else {
+				k, ok := up.ModelDef.OutTableByName(pi.FromName)
+				if !ok {
+					return nil, errors.New("upstream parameter or output table not found: " + pi.FromModel + "." + pi.FromName + " to import: " + pm.Name)
+				}
+				tm := &up.ModelDef.Table[k]
+				if len(tm.Expr) <= 0 {
+					return nil, errors.New("upstream output table does not have any expressions: " + pi.FromModel + "." + pi.FromName)
+				}
+
+				// use first expression: expression with minimal id
+				n := 0
+				for e := range tm.Expr {
+					if tm.Expr[e].ExprId < tm.Expr[n].ExprId {
+						n = e
+					}
+				}
+				dgst = tm.ImportDigest
+				im.IsFromTable = true
+				im.ExprName = tm.Expr[n].Name
+			}
+			if dgst != pm.ImportDigest {
+				return nil, errors.New("import digest not matched: " + pm.Name + " from: " + pi.FromModel + "." + pi.FromName)
+			}
+
+			imLst = append(imLst, im)
+			isFound = true
+		}
+	}
+
+	return imLst, nil
+}
+
+// ImportParameterFromUpstream insert or replace parameter values in workset by copy values from upstream model run.
+//
+// Values of upstream parameter are copied including all sub-values, default sub-value id is zero.
+// Values of upstream output table first expression are copied as single sub-value parameter.
+// Workset must exist and must be read-write.
+func (meta *WorksetMeta) ImportParameterFromUpstream(dbConn *sql.DB, modelDef *ModelMeta, langDef *LangMeta, im *ParamImportMap) error {
+
+	// validate parameters
+	if modelDef == nil {
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	if im == nil || im.Upstream == nil || im.Upstream.ModelDef == nil || im.Upstream.Run == nil {
+		return errors.New("invalid (empty) parameter import")
+	}
+	up := im.Upstream
+
+	// read values from upstream model run and convert it to parameter cells
+	cLst := []interface{}{}
+	nSub := 1
+
+	if im.IsFromTable {
+
+		tblLt := ReadTableLayout{
+			ReadLayout: ReadLayout{Name: im.FromName, FromId: up.Run.RunId},
+			ValueName:  im.ExprName,
+		}
+		_, err := ReadOutputTableTo(up.DbConn, up.ModelDef, &tblLt, func(src interface{}) (bool, error) {
+
+			cell, ok := src.(CellExpr)
+			if !ok {
+				return false, errors.New("invalid type, expected: output table expression cell (internal error): " + im.FromName)
+			}
+			cLst = append(cLst, CellParam{cellIdValue: cell.cellIdValue, SubId: 0})
+			return true, nil
+		})
+		if err != nil {
+			return errors.New("failed to read upstream output table: " + im.FromModel + "." + im.FromName + ": " + err.Error())
+		}
+
+	} else {
+
+		paramLt := ReadParamLayout{ReadLayout: ReadLayout{Name: im.FromName, FromId: up.Run.RunId}}
+
+		_, err := ReadParameterTo(up.DbConn, up.ModelDef, &paramLt, func(src interface{}) (bool, error) {
+
+			cell, ok := src.(CellParam)
+			if !ok {
+				return false, errors.New("invalid type, expected: parameter cell (internal error): " + im.FromName)
+			}
+			if cell.SubId >= nSub {
+				nSub = cell.SubId + 1
+			}
+			cLst = append(cLst, cell)
+			return true, nil
+		})
+		if err != nil {
+			return errors.New("failed to read upstream parameter: " + im.FromModel + "." + im.FromName + ": " + err.Error())
+		}
+	}
+	if len(cLst) <= 0 {
+		return errors.New("missing upstream values: " + im.FromModel + "." + im.FromName + " run id: " + strconv.Itoa(up.Run.RunId))
+	}
+
+	// insert or replace parameter values in workset
+	idx := 0
+	from := func() (interface{}, error) {
+		if idx >= len(cLst) {
+			return nil, nil // end of data
+		}
+		idx++
+		return cLst[idx-1], nil
+	}
+
+	pub := ParamRunSetPub{
+		ParamRunSetTxtPub: ParamRunSetTxtPub{Name: im.ParamName, Txt: []LangNote{}},
+		SubCount:          nSub,
+		DefaultSubId:      0,
+	}
+	_, err := meta.UpdateWorksetParameterFrom(dbConn, modelDef, true, &pub, langDef, from)
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestResolveParamImport(t *testing.T) {
+
+	// upstream model: parameter and output table with two expressions
+	upModel := &ModelMeta{
+		Model: ModelDicRow{Name: "upModel"},
+		Param: []ParamMeta{
+			{ParamDicRow: ParamDicRow{Name: "upParam", ImportDigest: "d-param"}},
+		},
+		Table: []TableMeta{
+			{
+				TableDicRow: TableDicRow{Name: "upTable", ImportDigest: "d-table"},
+				Expr: []TableExprRow{
+					{ExprId: 1, Name: "expr1"},
+					{ExprId: 0, Name: "expr0"},
+				},
+			},
+		},
+	}
+	upLst := []UpstreamRun{
+		{ModelDef: upModel, Run: &RunRow{RunId: 11, Name: "upRun", Status: DoneRunStatus}},
+	}
+
+	// downstream model: imports from parameter and output table, import from other model and parameter without import
+	dnModel := &ModelMeta{
+		Model: ModelDicRow{Name: "dnModel"},
+		Param: []ParamMeta{
+			{
+				ParamDicRow: ParamDicRow{Name: "fromParam", ImportDigest: "d-param"},
+				Import:      []ParamImportRow{{FromName: "upParam", FromModel: "upModel"}},
+			},
+			{
+				ParamDicRow: ParamDicRow{Name: "fromTable", ImportDigest: "d-table"},
+				Import:      []ParamImportRow{{FromName: "otherTable", FromModel: "otherModel"}, {FromName: "upTable", FromModel: "upModel"}},
+			},
+			{
+				ParamDicRow: ParamDicRow{Name: "fromOther", ImportDigest: "d-other"},
+				Import:      []ParamImportRow{{FromName: "otherParam", FromModel: "otherModel"}},
+			},
+			{
+				ParamDicRow: ParamDicRow{Name: "noImport", ImportDigest: "d-none"},
+			},
+		},
+	}
+
+	imLst, err := ResolveParamImport(dnModel, upLst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imLst) != 2 {
+		t.Fatalf("expected 2 imports, got: %d", len(imLst))
+	}
+	if imLst[0].ParamName != "fromParam" || imLst[0].IsFromTable || imLst[0].FromName != "upParam" || imLst[0].Upstream.Run.RunId != 11 {
+		t.Errorf("invalid parameter import: %+v", imLst[0])
+	}
+	if imLst[1].ParamName != "fromTable" || !imLst[1].IsFromTable || imLst[1].FromName != "upTable" || imLst[1].ExprName != "expr0" {
+		t.Errorf("invalid output table import: %+v", imLst[1])
+	}
+
+	// import digest must match
+	dnModel.Param[0].ImportDigest = "d-wrong"
+	if _, err = ResolveParamImport(dnModel, upLst); err == nil {
+		t.Error("expected error if import digest not matched")
+	}
+	dnModel.Param[0].ImportDigest = "d-param"
+
+	// sample dimension import is not supported
+	dnModel.Param[0].Import[0].IsSampleDim = true
+	if _, err = ResolveParamImport(dnModel, upLst); err == nil {
+		t.Error("expected error if import with sample dimension")
+	}
+	dnModel.Param[0].Import[0].IsSampleDim = false
+
+	// upstream run must be completed
+	upLst[0].Run.Status = ProgressRunStatus
+	if _, err = ResolveParamImport(dnModel, upLst); err == nil {
+		t.Error("expected error if upstream run not completed")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+)
+
+// EnumLabel is a custom label of enum item, e.g. publication label which is different from model enum label
+type EnumLabel struct {
+	TypeName string // model type name, e.g.: SEX
+	EnumCode string // enum code, e.g.: M, for range types it is enum id, e.g.: 25
+	Label    string // custom label, e.g.: Males
+}
+
+// custom enum labels: map of type name to map of enum code to label, nil if there are no custom labels
+var theLabelMap atomic.Pointer[map[string]map[string]string]
+
+// SetLabelMap set custom labels of enum items, use empty list to clear custom labels.
+// Custom labels are used instead of model enum labels (descriptions) for language-specific output,
+// e.g. csv output of parameters, output tables and microdata with dimension item labels.
+// It is not used for enum codes and enum id's output.
+// It must be called before any database read, labels are the same for all database connections.
+func SetLabelMap(labels []EnumLabel) {
+
+	if len(labels) <= 0 {
+		theLabelMap.Store(nil)
+		return
+	}
+	lm := map[string]map[string]string{}
+
+	for _, el := range labels {
+		if _, ok := lm[el.TypeName]; !ok {
+			lm[el.TypeName] = map[string]string{}
+		}
+		lm[el.TypeName][el.EnumCode] = el.Label
+	}
+	theLabelMap.Store(&lm)
+}
+
+// CheckLabelMap validate custom enum labels: all types and enums must exist in the model.
+// Types must be enum-based, boolean or range types, for range types enum code must be an integer in type range.
+func CheckLabelMap(modelDef *ModelMeta) error {
+
+	p := theLabelMap.Load()
+	if p == nil {
+		return nil // there are no custom labels
+	}
+	if modelDef == nil {
+		return errors.New("invalid (empty) model metadata, unable to validate custom enum labels")
+	}
+
+	for tName, codeLabel := range *p {
+
+		var typeOf *TypeMeta
+		for k := range modelDef.Type {
+			if modelDef.Type[k].Name == tName {
+				typeOf = &modelDef.Type[k]
+				break
+			}
+		}
+		if typeOf == nil {
+			return errors.New("invalid custom enum label, type not found: " + tName + " in model: " + modelDef.Model.Name)
+		}
+		if typeOf.IsBuiltIn() && !typeOf.IsBool() {
+			return errors.New("invalid custom enum label, type is not enum-based: " + tName)
+		}
+
+		for code := range codeLabel {
+
+			isFound := false
+			if typeOf.IsRange {
+				if n, e := strconv.Atoi(code); e == nil {
+					isFound = typeOf.MinEnumId <= n && n <= typeOf.MaxEnumId
+				}
+			} else {
+				for j := range typeOf.Enum {
+					if typeOf.Enum[j].Name == code {
+						isFound = true
+						break
+					}
+				}
+			}
+			if !isFound {
+				return errors.New("invalid custom enum label, enum not found: " + code + " of type: " + tName)
+			}
+		}
+	}
+	return nil
+}
+
+// return custom labels of type enums as map of enum code to label or nil if there are no custom labels for that type
+func customLabelsOf(typeName string) map[string]string {
+	if p := theLabelMap.Load(); p != nil {
+		return (*p)[typeName]
+	}
+	return nil
+}
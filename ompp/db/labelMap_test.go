@@ -0,0 +1,73 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestLabelMap(t *testing.T) {
+
+	defer SetLabelMap(nil)
+
+	sexType := TypeMeta{
+		TypeDicRow: TypeDicRow{TypeId: maxBuiltInTypeId + 1, Name: "SEX"},
+		Enum:       []TypeEnumRow{{EnumId: 0, Name: "F"}, {EnumId: 1, Name: "M"}},
+	}
+	ageType := TypeMeta{
+		TypeDicRow: TypeDicRow{TypeId: maxBuiltInTypeId + 2, Name: "AGE", IsRange: true, MinEnumId: 20, MaxEnumId: 30},
+	}
+	modelDef := &ModelMeta{Model: ModelDicRow{Name: "modelOne"}, Type: []TypeMeta{sexType, ageType}}
+
+	SetLabelMap([]EnumLabel{
+		{TypeName: "SEX", EnumCode: "M", Label: "Males"},
+		{TypeName: "AGE", EnumCode: "25", Label: "Age 25"},
+	})
+	if err := CheckLabelMap(modelDef); err != nil {
+		t.Fatal(err)
+	}
+
+	// custom label replace enum description, other enums are not changed
+	txt := []TypeEnumTxtRow{{TypeId: sexType.TypeId, EnumId: 0, LangCode: "en", Descr: "Female"}, {TypeId: sexType.TypeId, EnumId: 1, LangCode: "en", Descr: "Male"}}
+
+	cvt, err := sexType.itemIdToLabel("en", txt, nil, "ageSex.dim1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := cvt(1); err != nil || s != "Males" {
+		t.Error("Fail to convert enum id 1 into custom label:", s, err)
+	}
+	if s, err := cvt(0); err != nil || s != "Female" {
+		t.Error("Fail to convert enum id 0 into label:", s, err)
+	}
+
+	cvt, err = ageType.itemIdToLabel("en", nil, nil, "ageSex.dim0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := cvt(25); err != nil || s != "Age 25" {
+		t.Error("Fail to convert range id 25 into custom label:", s, err)
+	}
+
+	// enum codes are not changed
+	cvt, err = sexType.itemIdToCode("ageSex.dim1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := cvt(1); err != nil || s != "M" {
+		t.Error("Fail to convert enum id 1 into code:", s, err)
+	}
+
+	// validation: type and enums must exist in the model
+	for _, el := range []EnumLabel{
+		{TypeName: "NONE", EnumCode: "M", Label: "x"},
+		{TypeName: "SEX", EnumCode: "X", Label: "x"},
+		{TypeName: "AGE", EnumCode: "31", Label: "x"},
+	} {
+		SetLabelMap([]EnumLabel{el})
+		if err = CheckLabelMap(modelDef); err == nil {
+			t.Error("Fail: expected error for custom label:", el)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// Lineage is a provenance graph of model runs and worksets (input scenarios).
+//
+// Graph nodes are model runs and worksets, graph edges are:
+// "base-run" if workset based on model run and "input-set" if model run used workset as input.
+type Lineage struct {
+	ModelDigest string        // model digest
+	Node        []LineageNode // model runs and worksets
+	Edge        []LineageEdge // relationship between model runs and worksets
+}
+
+// LineageNode is a model run or workset in lineage graph.
+type LineageNode struct {
+	Kind           string // node kind: "run" or "set"
+	Id             int    // model run id or workset id
+	Name           string // model run name or workset name
+	Digest         string // model run digest, empty for workset
+	Status         string // model run status, empty for workset
+	CreateDateTime string // model run start date-time, empty for workset
+	UpdateDateTime string // last update date-time
+	IsReadonly     bool   // workset read-only status, false for model run
+	IsStart        bool   // if true then it is a starting node of the graph: run or workset requested
+}
+
+// LineageEdge is a relationship between model run and workset in lineage graph.
+type LineageEdge struct {
+	Kind  string // edge kind: "base-run" if workset based on model run or "input-set" if model run used workset as input
+	RunId int    // model run id
+	SetId int    // workset id
+}
+
+// GetLineage return lineage graph of model run or workset: all runs and worksets connected to the starting node.
+//
+// Graph includes ancestors: workset base run, input workset of the run and so on,
+// and descendants: runs which used the workset as input, worksets based on the run and so on.
+// Input workset of model run found by run options: OpenM.SetId or, if set id not found, OpenM.SetName.
+// This method is "local" to database and if data transfered between databases it very likely return wrong results,
+// similar to GetWorksetRunIds().
+func GetLineage(dbConn *sql.DB, modelId int, isRun bool, id int) (*Lineage, error) {
+
+	if modelId <= 0 {
+		return nil, errors.New("invalid model id: " + strconv.Itoa(modelId))
+	}
+
+	// get model runs, worksets and input workset of each run
+	rl, err := GetRunList(dbConn, modelId)
+	if err != nil {
+		return nil, err
+	}
+	wl, err := GetWorksetList(dbConn, modelId)
+	if err != nil {
+		return nil, err
+	}
+
+	setOf := map[int]int{} // map run id to input workset id
+	setName := map[string]int{}
+	for k := range wl {
+		setName[wl[k].Name] = wl[k].SetId
+	}
+
+	err = SelectRows(dbConn,
+		"SELECT RO.run_id, RO.option_key, RO.option_value"+
+			" FROM run_option RO"+
+			" INNER JOIN run_lst RL ON (RL.run_id = RO.run_id)"+
+			" WHERE RL.model_id = "+strconv.Itoa(modelId)+
+			" AND RO.option_key IN ('OpenM.SetId', 'OpenM.SetName')"+
+			" ORDER BY 1, 2",
+		func(rows *sql.Rows) error {
+			var rId int
+			var key, val string
+			if err := rows.Scan(&rId, &key, &val); err != nil {
+				return err
+			}
+			if key == "OpenM.SetId" {
+				if n, e := strconv.Atoi(val); e == nil && n > 0 {
+					setOf[rId] = n
+				}
+				return nil
+			}
+			// OpenM.SetName: use it only if set id not found
+			if _, ok := setOf[rId]; !ok {
+				if n, ok := setName[val]; ok {
+					setOf[rId] = n
+				}
+			}
+			return nil
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// index runs and worksets by id
+	runIdx := map[int]int{}
+	for k := range rl {
+		runIdx[rl[k].RunId] = k
+	}
+	setIdx := map[int]int{}
+	for k := range wl {
+		setIdx[wl[k].SetId] = k
+	}
+
+	// starting node must exist
+	if isRun {
+		if _, ok := runIdx[id]; !ok {
+			return nil, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(id))
+		}
+	} else {
+		if _, ok := setIdx[id]; !ok {
+			return nil, newDbError(ErrWorksetNotFound, "workset not found, id: "+strconv.Itoa(id))
+		}
+	}
+
+	// make all graph edges between existing runs and worksets
+	var allEdge []LineageEdge
+	for k := range wl {
+		if _, ok := runIdx[wl[k].BaseRunId]; ok && wl[k].BaseRunId > 0 {
+			allEdge = append(allEdge, LineageEdge{Kind: "base-run", RunId: wl[k].BaseRunId, SetId: wl[k].SetId})
+		}
+	}
+	for k := range rl {
+		if sId, ok := setOf[rl[k].RunId]; ok {
+			if _, ok = setIdx[sId]; ok {
+				allEdge = append(allEdge, LineageEdge{Kind: "input-set", RunId: rl[k].RunId, SetId: sId})
+			}
+		}
+	}
+
+	// collect all nodes connected to the starting node
+	isRunUse := map[int]bool{}
+	isSetUse := map[int]bool{}
+	if isRun {
+		isRunUse[id] = true
+	} else {
+		isSetUse[id] = true
+	}
+	isEdgeUse := make([]bool, len(allEdge))
+
+	for isNext := true; isNext; {
+		isNext = false
+		for k := range allEdge {
+			if isEdgeUse[k] {
+				continue
+			}
+			e := allEdge[k]
+			if isRunUse[e.RunId] || isSetUse[e.SetId] {
+				isEdgeUse[k] = true
+				isRunUse[e.RunId] = true
+				isSetUse[e.SetId] = true
+				isNext = true
+			}
+		}
+	}
+
+	// make lineage graph: nodes in the order of runs and worksets id's, edges in the order of discovery
+	lg := Lineage{Node: []LineageNode{}, Edge: []LineageEdge{}}
+
+	for k := range rl {
+		if isRunUse[rl[k].RunId] {
+			lg.Node = append(lg.Node, LineageNode{
+				Kind:           "run",
+				Id:             rl[k].RunId,
+				Name:           rl[k].Name,
+				Digest:         rl[k].RunDigest,
+				Status:         rl[k].Status,
+				CreateDateTime: rl[k].CreateDateTime,
+				UpdateDateTime: rl[k].UpdateDateTime,
+				IsStart:        isRun && rl[k].RunId == id,
+			})
+		}
+	}
+	for k := range wl {
+		if isSetUse[wl[k].SetId] {
+			lg.Node = append(lg.Node, LineageNode{
+				Kind:           "set",
+				Id:             wl[k].SetId,
+				Name:           wl[k].Name,
+				UpdateDateTime: wl[k].UpdateDateTime,
+				IsReadonly:     wl[k].IsReadonly,
+				IsStart:        !isRun && wl[k].SetId == id,
+			})
+		}
+	}
+	for k := range allEdge {
+		if isEdgeUse[k] {
+			lg.Edge = append(lg.Edge, allEdge[k])
+		}
+	}
+
+	return &lg, nil
+}
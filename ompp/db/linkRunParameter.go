@@ -0,0 +1,189 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// LinkRunParameter insert run parameter as a link to existing parameter values with the same value digest instead of copy the values.
+//
+// Parameter values digest is a source value digest, e.g. from source database or from model run .json file.
+// If there is other model run in database where parameter has the same value digest and the same number of sub-values
+// then parameter inserted into model run with base run id of that run and values not copied.
+// Return base run id and number of parameter value rows not copied.
+// If there are no such parameter values in database then return zero base run id and nothing inserted,
+// parameter values must be written by WriteParameterFrom().
+//
+// Model run must exist and be in completed state (i.e. success or error state).
+// Model run should not already contain parameter values: parameter can be inserted only once in model run and cannot be updated after.
+func LinkRunParameter(dbConn *sql.DB, modelDef *ModelMeta, layout *WriteParamLayout, valueDigest string) (int, int64, error) {
+
+	// validate parameters
+	if modelDef == nil {
+		return 0, 0, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	if layout == nil {
+		return 0, 0, errors.New("invalid (empty) write layout")
+	}
+	if layout.Name == "" {
+		return 0, 0, errors.New("invalid (empty) parameter name")
+	}
+	if !layout.IsToRun || layout.ToId <= 0 {
+		return 0, 0, errors.New("invalid destination run id: " + strconv.Itoa(layout.ToId))
+	}
+	if layout.SubCount <= 0 {
+		return 0, 0, errors.New("invalid number of parameter sub-vaules: " + strconv.Itoa(layout.SubCount))
+	}
+	if valueDigest == "" {
+		return 0, 0, nil // source value digest unknown: parameter values must be copied
+	}
+
+	var param *ParamMeta
+	if k, ok := modelDef.ParamByName(layout.Name); ok {
+		param = &modelDef.Param[k]
+	} else {
+		return 0, 0, errors.New("parameter not found: " + layout.Name)
+	}
+
+	// do link parameter in transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	nBase, nRow, err := doLinkRunParameter(trx, param, layout.ToId, layout.SubCount, valueDigest)
+	if err != nil || nBase <= 0 {
+		trx.Rollback()
+		return 0, 0, err
+	}
+	trx.Commit()
+
+	return nBase, nRow, nil
+}
+
+// doLinkRunParameter insert run parameter as a link to existing base run parameter values with the same value digest.
+// It does update as part of transaction.
+// Return base run id and number of base run parameter rows or zero base run id if there are no such parameter values.
+func doLinkRunParameter(trx *sql.Tx, param *ParamMeta, runId int, subCount int, valueDigest string) (int, int64, error) {
+
+	// update model run master record to prevent run use and check if parameter can be inserted
+	srId := strconv.Itoa(runId)
+	sHid := strconv.Itoa(param.ParamHid)
+	sSub := strconv.Itoa(subCount)
+
+	if err := trxCheckRunParamInsert(trx, param, srId); err != nil {
+		return 0, 0, err
+	}
+
+	// find base run by digest: base run must contain parameter values rows
+	var nb sql.NullInt64
+	err := TrxSelectFirst(trx,
+		"SELECT MIN(run_id) FROM run_parameter"+
+			" WHERE parameter_hid = "+sHid+
+			" AND value_digest = "+ToQuoted(valueDigest)+
+			" AND sub_count = "+sSub+
+			" AND run_id = base_run_id"+
+			" AND run_id <> "+srId,
+		func(row *sql.Row) error {
+			if err := row.Scan(&nb); err != nil {
+				return err
+			}
+			return nil
+		})
+	switch {
+	case err != nil && err != sql.ErrNoRows:
+		return 0, 0, err
+	}
+	if !nb.Valid || nb.Int64 <= 0 {
+		return 0, 0, nil // parameter values not found
+	}
+	nBase := int(nb.Int64)
+	sBase := strconv.Itoa(nBase)
+
+	// insert into run_parameter with base run id and source value digest
+	err = TrxUpdate(trx,
+		"INSERT INTO run_parameter (run_id, parameter_hid, base_run_id, sub_count, value_digest)"+
+			" VALUES ("+
+			srId+", "+sHid+", "+sBase+", "+sSub+", "+ToQuoted(valueDigest)+")")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// number of parameter rows not copied
+	var nRow int64
+	err = TrxSelectFirst(trx,
+		"SELECT COUNT(*) FROM "+param.DbRunTable+" WHERE run_id = "+sBase,
+		func(row *sql.Row) error {
+			if err := row.Scan(&nRow); err != nil {
+				return err
+			}
+			return nil
+		})
+	switch {
+	case err != nil && err != sql.ErrNoRows:
+		return 0, 0, err
+	}
+
+	// completed OK, restore run_lst values
+	err = TrxUpdate(trx,
+		"UPDATE run_lst SET sub_restart = sub_restart + 1 WHERE run_id = "+srId)
+	if err != nil {
+		return 0, 0, err
+	}
+	return nBase, nRow, nil
+}
+
+// trxCheckRunParamInsert update model run master record to prevent run use and check if parameter values can be inserted:
+// model run must exist and be completed and parameter values must not already exist in that model run.
+// It does update as part of transaction, caller must restore run_lst.sub_restart value after parameter insert.
+func trxCheckRunParamInsert(trx *sql.Tx, param *ParamMeta, srId string) error {
+
+	// update model run master record to prevent run use
+	err := TrxUpdate(trx,
+		"UPDATE run_lst SET sub_restart = sub_restart - 1 WHERE run_id = "+srId)
+	if err != nil {
+		return err
+	}
+
+	// check if model run exist and status is completed
+	st := ""
+	err = TrxSelectFirst(trx,
+		"SELECT status FROM run_lst WHERE run_id = "+srId,
+		func(row *sql.Row) error {
+			if err := row.Scan(&st); err != nil {
+				return err
+			}
+			return nil
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return newDbError(ErrRunNotFound, "model run not found, id: "+srId)
+	case err != nil:
+		return err
+	}
+	if !IsRunCompleted(st) {
+		return errors.New("model run not completed, id: " + srId)
+	}
+
+	// check if parameter values not already exist for that run
+	n := 0
+	err = TrxSelectFirst(trx,
+		"SELECT COUNT(*) FROM run_parameter"+" WHERE run_id = "+srId+" AND parameter_hid = "+strconv.Itoa(param.ParamHid),
+		func(row *sql.Row) error {
+			if err := row.Scan(&n); err != nil {
+				return err
+			}
+			return nil
+		})
+	switch {
+	case err != nil && err != sql.ErrNoRows:
+		return err
+	}
+	if n > 0 {
+		return errors.New("model run with id: " + srId + " already contain parameter values " + param.Name)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestLinkRunParameter(t *testing.T) {
+
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageSex_p_2012817 (run_id INT NOT NULL, sub_id INT NOT NULL, dim0 INT NOT NULL, param_value FLOAT NOT NULL)",
+		testRunSql(11, 1, 1, "s"),
+		testRunSql(12, 1, 1, "s"),
+		testRunSql(13, 1, 1, "s"),
+		testRunSql(14, 1, 1, "p"),
+		"INSERT INTO run_parameter (run_id, parameter_hid, base_run_id, sub_count, value_digest) VALUES (11, 101, 11, 1, 'abc'), (12, 101, 11, 1, 'abc')",
+		"INSERT INTO ageSex_p_2012817 (run_id, sub_id, dim0, param_value) VALUES (11, 0, 0, 1.5), (11, 0, 1, 2.5), (11, 0, 2, 3.5)",
+	)
+	setFacetOf(dbConn, SqliteFacet)
+
+	meta := &ModelMeta{
+		Param: []ParamMeta{{ParamDicRow: ParamDicRow{ParamHid: 101, Name: "ageSex", DbRunTable: "ageSex_p_2012817"}}},
+	}
+	layout := WriteParamLayout{WriteLayout: WriteLayout{Name: "ageSex", ToId: 13}, SubCount: 1, IsToRun: true}
+
+	// digest not found or different sub-values count: nothing inserted
+	if nBase, _, err := LinkRunParameter(dbConn, meta, &layout, "xyz"); err != nil || nBase != 0 {
+		t.Error("Fail: unexpected link by unknown digest:", nBase, err)
+	}
+	layout.SubCount = 2
+	if nBase, _, err := LinkRunParameter(dbConn, meta, &layout, "abc"); err != nil || nBase != 0 {
+		t.Error("Fail: unexpected link with different sub-values count:", nBase, err)
+	}
+	layout.SubCount = 1
+
+	// same digest: link to base run which contains values rows
+	nBase, nRow, err := LinkRunParameter(dbConn, meta, &layout, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nBase != 11 || nRow != 3 {
+		t.Error("Fail: invalid base run or rows count:", nBase, nRow)
+	}
+
+	nb := 0
+	if err = SelectFirst(dbConn, "SELECT base_run_id FROM run_parameter WHERE run_id = 13 AND parameter_hid = 101", func(row *sql.Row) error { return row.Scan(&nb) }); err != nil {
+		t.Fatal(err)
+	}
+	if nb != 11 {
+		t.Error("Fail: invalid base run id:", nb)
+	}
+	n := 0
+	if err = SelectFirst(dbConn, "SELECT sub_restart FROM run_lst WHERE run_id = 13", func(row *sql.Row) error { return row.Scan(&n) }); err != nil || n != 0 {
+		t.Error("Fail: run_lst sub_restart not restored:", n, err)
+	}
+
+	// parameter already exist in model run and model run not completed
+	if _, _, err = LinkRunParameter(dbConn, meta, &layout, "abc"); err == nil {
+		t.Error("Fail: expected error if parameter already exist in model run")
+	}
+	layout.ToId = 14
+	if _, _, err = LinkRunParameter(dbConn, meta, &layout, "abc"); err == nil {
+		t.Error("Fail: expected error if model run not completed")
+	}
+}
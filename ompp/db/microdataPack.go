@@ -0,0 +1,276 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// MicrodataPackOptPrefix is run option prefix to pack microdata attributes into dictionary table, e.g.: Microdata.Pack.Person = sex,region
+const MicrodataPackOptPrefix = "Microdata.Pack."
+
+// MicrodataPackAttrs return names of entity attributes to pack into dictionary table.
+// Attribute names are comma-separated value of run option, e.g.: Microdata.Pack.Person = sex,region
+// Option key is case insensitive. If there is no such run option then return empty list.
+func MicrodataPackAttrs(runOpts map[string]string, entityName string) []string {
+
+	for key, val := range runOpts {
+		if strings.EqualFold(key, MicrodataPackOptPrefix+entityName) {
+
+			names := []string{}
+			for _, s := range strings.Split(val, ",") {
+				if s = strings.TrimSpace(s); s != "" && !slices.Contains(names, s) {
+					names = append(names, s)
+				}
+			}
+			return names
+		}
+	}
+	return []string{}
+}
+
+// return name of packed microdata db object: dictionary, values table or trigger, e.g.: Person_g87abcdef_d
+func microPackName(dbTable string, suffix string) string {
+	if len(dbTable) > maxTableNameSize-len(suffix) {
+		dbTable = dbTable[:maxTableNameSize-len(suffix)]
+	}
+	return dbTable + suffix
+}
+
+// trxCreatePackedMicroTable create microdata db objects where packed attributes values stored in dictionary table.
+// It is supported only for SQLite.
+//
+// Rarely-varying attributes, e.g. sex and region, are stored once for each unique combination of values in dictionary table
+// and values table contains only dictionary key and the rest of attributes.
+// Microdata db table is a view with the same columns as regular microdata table and INSTEAD OF INSERT and DELETE triggers,
+// therefore reading, inserting and deleting microdata is transparent for all db table users.
+// Only non-float attributes can be packed, float attributes may contain NULL.
+//
+//	CREATE TABLE Person_g87abcdef_d (pack_key INTEGER PRIMARY KEY, attr4 INT NOT NULL, UNIQUE (attr4))
+//	CREATE TABLE Person_g87abcdef_v (run_id INT NOT NULL, entity_key BIGINT NOT NULL, pack_key INT NOT NULL, attr7 FLOAT NULL, PRIMARY KEY (run_id, entity_key))
+//	CREATE VIEW Person_g87abcdef AS
+//	  SELECT V.run_id, V.entity_key, D.attr4, V.attr7 FROM Person_g87abcdef_v V INNER JOIN Person_g87abcdef_d D ON (D.pack_key = V.pack_key)
+func trxCreatePackedMicroTable(trx *sql.Tx, dbFacet Facet, dbTable string, attrs []EntityAttrRow, packNames []string) error {
+
+	if dbFacet != SqliteFacet {
+		return errors.New("microdata attributes packing is supported only for SQLite, db table: " + dbTable)
+	}
+	if len(packNames) <= 0 {
+		return errors.New("invalid (empty) list of microdata attributes to pack, db table: " + dbTable)
+	}
+
+	// validate attributes to pack: it must be generation attributes and must not be float
+	isPack := make([]bool, len(attrs))
+
+	for _, name := range packNames {
+
+		k := slices.IndexFunc(attrs, func(a EntityAttrRow) bool { return a.Name == name })
+		if k < 0 {
+			return errors.New("microdata attribute to pack not found: " + name + ", db table: " + dbTable)
+		}
+		if attrs[k].typeOf.IsFloat() {
+			return errors.New("microdata float attribute cannot be packed: " + name + ", db table: " + dbTable)
+		}
+		isPack[k] = true
+	}
+
+	dTbl := microPackName(dbTable, "_d")
+	vTbl := microPackName(dbTable, "_v")
+
+	// columns of dictionary, values table, view and triggers
+	dCols := ""
+	dColSql := ""
+	vColSql := ""
+	viewCols := ""
+	vInsCols := ""
+	vInsVals := ""
+	dInsVals := ""
+	dWhere := ""
+
+	for k, a := range attrs {
+
+		sqlType, err := a.typeOf.sqlColumnType(dbFacet)
+		if err != nil {
+			return err
+		}
+
+		if isPack[k] {
+
+			if dCols != "" {
+				dCols += ", "
+				dInsVals += ", "
+				dWhere += " AND "
+			}
+			dCols += a.colName
+			dColSql += a.colName + " " + sqlType + " NOT NULL, "
+			dInsVals += "NEW." + a.colName
+			dWhere += "D." + a.colName + " = NEW." + a.colName
+			viewCols += ", D." + a.colName
+			continue
+		}
+
+		if a.typeOf.IsFloat() {
+			vColSql += a.colName + " " + sqlType + " NULL, "
+		} else {
+			vColSql += a.colName + " " + sqlType + " NOT NULL, "
+		}
+		viewCols += ", V." + a.colName
+		vInsCols += ", " + a.colName
+		vInsVals += ", NEW." + a.colName
+	}
+
+	err := TrxUpdate(trx,
+		"CREATE TABLE "+dTbl+
+			" (pack_key INTEGER PRIMARY KEY, "+dColSql+"UNIQUE ("+dCols+"))")
+	if err != nil {
+		return err
+	}
+
+	err = TrxUpdate(trx,
+		"CREATE TABLE "+vTbl+
+			" (run_id INT NOT NULL, entity_key BIGINT NOT NULL, pack_key INT NOT NULL, "+vColSql+"PRIMARY KEY (run_id, entity_key))")
+	if err != nil {
+		return err
+	}
+
+	err = TrxUpdate(trx,
+		"CREATE VIEW "+dbTable+" AS"+
+			" SELECT V.run_id, V.entity_key"+viewCols+
+			" FROM "+vTbl+" V"+
+			" INNER JOIN "+dTbl+" D ON (D.pack_key = V.pack_key)")
+	if err != nil {
+		return err
+	}
+
+	// insert trigger: add values combination into dictionary if not exist and insert dictionary key and the rest of values
+	err = TrxUpdate(trx,
+		"CREATE TRIGGER "+microPackName(dbTable, "_pi")+" INSTEAD OF INSERT ON "+dbTable+
+			" BEGIN"+
+			" INSERT OR IGNORE INTO "+dTbl+" ("+dCols+") VALUES ("+dInsVals+");"+
+			" INSERT INTO "+vTbl+" (run_id, entity_key, pack_key"+vInsCols+")"+
+			" VALUES (NEW.run_id, NEW.entity_key, (SELECT D.pack_key FROM "+dTbl+" D WHERE "+dWhere+")"+vInsVals+");"+
+			" END")
+	if err != nil {
+		return err
+	}
+
+	// delete trigger: delete from values table, dictionary rows are not deleted because may be shared between runs
+	err = TrxUpdate(trx,
+		"CREATE TRIGGER "+microPackName(dbTable, "_pd")+" INSTEAD OF DELETE ON "+dbTable+
+			" BEGIN"+
+			" DELETE FROM "+vTbl+" WHERE run_id = OLD.run_id AND entity_key = OLD.entity_key;"+
+			" END")
+	return err
+}
+
+// trxIsPackedMicroTable return true if microdata db table is a view over packed dictionary and values tables.
+func trxIsPackedMicroTable(trx *sql.Tx, dbFacet Facet, dbTable string) (bool, error) {
+
+	if dbFacet != SqliteFacet {
+		return false, nil // packing supported only for SQLite
+	}
+
+	n := 0
+	err := TrxSelectFirst(trx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'view' AND name = "+ToQuoted(dbTable),
+		func(row *sql.Row) error {
+			return row.Scan(&n)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// trxMicroValueTable return db table name to update or delete microdata rows:
+// values table name if microdata packed or microdata db table name as is.
+// Packed microdata view does not allow UPDATE and DELETE trigger removes rows one by one.
+func trxMicroValueTable(trx *sql.Tx, dbFacet Facet, dbTable string) (string, error) {
+
+	isPack, err := trxIsPackedMicroTable(trx, dbFacet, dbTable)
+	if err != nil {
+		return "", err
+	}
+	if isPack {
+		return microPackName(dbTable, "_v"), nil
+	}
+	return dbTable, nil
+}
+
+// trxDropMicroTable drop microdata db table or, if microdata packed, drop view, values and dictionary tables.
+// Triggers are dropped together with the view.
+func trxDropMicroTable(trx *sql.Tx, dbFacet Facet, dbTable string) error {
+
+	isPack, err := trxIsPackedMicroTable(trx, dbFacet, dbTable)
+	if err != nil {
+		return err
+	}
+	if !isPack {
+		return TrxUpdate(trx, "DROP TABLE "+dbTable)
+	}
+
+	if err = TrxUpdate(trx, "DROP VIEW "+dbTable); err != nil {
+		return err
+	}
+	if err = TrxUpdate(trx, "DROP TABLE "+microPackName(dbTable, "_v")); err != nil {
+		return err
+	}
+	return TrxUpdate(trx, "DROP TABLE "+microPackName(dbTable, "_d"))
+}
+
+// trxIsMicroTableExist return true if SQLite microdata db table or view exists.
+func trxIsMicroTableExist(trx *sql.Tx, dbTable string) (bool, error) {
+
+	n := 0
+	err := TrxSelectFirst(trx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type IN ('table', 'view') AND name = "+ToQuoted(dbTable),
+		func(row *sql.Row) error {
+			return row.Scan(&n)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// microColumnTable return db table name to update microdata attribute column:
+// dictionary or values table name if microdata packed or microdata db table name as is.
+func microColumnTable(dbConn *sql.DB, dbTable string, colName string) (string, error) {
+
+	if facetOf(dbConn) != SqliteFacet {
+		return dbTable, nil // packing supported only for SQLite
+	}
+
+	nView := 0
+	err := SelectFirst(dbConn,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'view' AND name = "+ToQuoted(dbTable),
+		func(row *sql.Row) error {
+			return row.Scan(&nView)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+	if nView <= 0 {
+		return dbTable, nil // regular microdata table
+	}
+
+	// packed microdata: check if attribute column is in dictionary table
+	dTbl := microPackName(dbTable, "_d")
+	n := 0
+	err = SelectFirst(dbConn,
+		"SELECT COUNT(*) FROM pragma_table_info("+ToQuoted(dTbl)+") WHERE name = "+ToQuoted(colName),
+		func(row *sql.Row) error {
+			return row.Scan(&n)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+	if n > 0 {
+		return dTbl, nil
+	}
+	return microPackName(dbTable, "_v"), nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMicrodataPack(t *testing.T) {
+
+	if names := MicrodataPackAttrs(map[string]string{"microdata.pack.Person": "Sex, Region,Sex"}, "Person"); len(names) != 2 || names[0] != "Sex" || names[1] != "Region" {
+		t.Error("Fail to get microdata attributes to pack:", names)
+	}
+	if names := MicrodataPackAttrs(map[string]string{"Microdata.Person": "Sex"}, "Person"); len(names) != 0 {
+		t.Error("Fail: expected empty list of attributes to pack:", names)
+	}
+
+	dbConn := openTestDb(t)
+	setFacetOf(dbConn, SqliteFacet)
+
+	enumType := &TypeMeta{TypeDicRow: TypeDicRow{TypeId: maxBuiltInTypeId + 1, Name: "SEX"}}
+	floatType := &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 14, Name: "double"}}
+	attrs := []EntityAttrRow{
+		{Name: "Sex", typeOf: enumType, colName: "attr0"},
+		{Name: "Income", typeOf: floatType, colName: "attr1"},
+		{Name: "Region", typeOf: enumType, colName: "attr2"},
+	}
+	const tbl = "Person_g87abcdef"
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = trxCreatePackedMicroTable(trx, SqliteFacet, tbl, attrs, []string{"Income"}); err == nil {
+		t.Error("Fail: float attribute must not be packed")
+	}
+	if err = trxCreatePackedMicroTable(trx, SqliteFacet, tbl, attrs, []string{"Sex", "Region"}); err != nil {
+		trx.Rollback()
+		t.Fatal(err)
+	}
+	trx.Commit()
+
+	// insert into view: values combination must be stored in dictionary only once
+	err = Update(dbConn,
+		"INSERT INTO "+tbl+" (run_id, entity_key, attr0, attr1, attr2) VALUES"+
+			" (11, 1, 0, 1.5, 3), (11, 2, 0, NULL, 3), (11, 3, 1, 2.5, 3), (12, 1, 0, 3.5, 3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nDict := 0
+	if err = SelectFirst(dbConn, "SELECT COUNT(*) FROM "+tbl+"_d", func(row *sql.Row) error { return row.Scan(&nDict) }); err != nil {
+		t.Fatal(err)
+	}
+	if nDict != 2 {
+		t.Error("Fail: invalid number of dictionary rows:", nDict)
+	}
+
+	// read from view: all attributes must be expanded
+	var nKey, nSex, nRegion int
+	var vIncome sql.NullFloat64
+	err = SelectFirst(dbConn,
+		"SELECT entity_key, attr0, attr1, attr2 FROM "+tbl+" WHERE run_id = 11 AND entity_key = 3",
+		func(row *sql.Row) error { return row.Scan(&nKey, &nSex, &vIncome, &nRegion) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nKey != 3 || nSex != 1 || !vIncome.Valid || vIncome.Float64 != 2.5 || nRegion != 3 {
+		t.Error("Fail: invalid microdata row:", nKey, nSex, vIncome, nRegion)
+	}
+
+	// update column tables: packed attribute in dictionary and the rest in values table
+	if s, err := microColumnTable(dbConn, tbl, "attr2"); err != nil || s != tbl+"_d" {
+		t.Error("Fail: invalid packed column table:", s, err)
+	}
+	if s, err := microColumnTable(dbConn, tbl, "attr1"); err != nil || s != tbl+"_v" {
+		t.Error("Fail: invalid values column table:", s, err)
+	}
+
+	// delete from view and drop packed microdata
+	if err = Update(dbConn, "DELETE FROM "+tbl+" WHERE run_id = 11"); err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	if err = SelectFirst(dbConn, "SELECT COUNT(*) FROM "+tbl, func(row *sql.Row) error { return row.Scan(&n) }); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Error("Fail: invalid number of rows after delete:", n)
+	}
+
+	trx, err = dbConn.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := trxMicroValueTable(trx, SqliteFacet, tbl); err != nil || s != tbl+"_v" {
+		t.Error("Fail: invalid values table:", s, err)
+	}
+	if err = trxDropMicroTable(trx, SqliteFacet, tbl); err != nil {
+		trx.Rollback()
+		t.Fatal(err)
+	}
+	trx.Commit()
+
+	if err = SelectFirst(dbConn, "SELECT COUNT(*) FROM sqlite_master WHERE name LIKE 'Person%'", func(row *sql.Row) error { return row.Scan(&n) }); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Error("Fail: packed microdata db objects not dropped:", n)
+	}
+}
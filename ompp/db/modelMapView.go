@@ -0,0 +1,221 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// names of optional views to map model id and parameter or output table name to Hid and db table names
+const (
+	ParamMapView = "model_parameter_map" // model parameters: model id, model name, parameter id, Hid, name and db table names
+	TableMapView = "model_table_map"     // model output tables: model id, model name, table id, Hid, name and db table names
+)
+
+// ParamMapRow is a row of model parameter mapping: model id and parameter id to parameter Hid and db table names
+type ParamMapRow struct {
+	ModelId     int    // model_id           INT          NOT NULL
+	ModelName   string // model_name         VARCHAR(255) NOT NULL
+	ModelDigest string // model_digest       VARCHAR(32)  NOT NULL
+	ParamId     int    // model_parameter_id INT          NOT NULL
+	ParamHid    int    // parameter_hid      INT          NOT NULL
+	Name        string // parameter_name     VARCHAR(255) NOT NULL
+	Digest      string // parameter_digest   VARCHAR(32)  NOT NULL
+	DbRunTable  string // db_run_table       VARCHAR(64)  NOT NULL
+	DbSetTable  string // db_set_table       VARCHAR(64)  NOT NULL
+}
+
+// TableMapRow is a row of model output table mapping: model id and table id to output table Hid and db table names
+type TableMapRow struct {
+	ModelId      int    // model_id        INT          NOT NULL
+	ModelName    string // model_name      VARCHAR(255) NOT NULL
+	ModelDigest  string // model_digest    VARCHAR(32)  NOT NULL
+	TableId      int    // model_table_id  INT          NOT NULL
+	TableHid     int    // table_hid       INT          NOT NULL
+	Name         string // table_name      VARCHAR(255) NOT NULL
+	Digest       string // table_digest    VARCHAR(32)  NOT NULL
+	DbExprTable  string // db_expr_table   VARCHAR(64)  NOT NULL
+	DbAccTable   string // db_acc_table    VARCHAR(64)  NOT NULL
+	DbAccAllView string // db_acc_all_view VARCHAR(64)  NOT NULL
+}
+
+// select body of model_parameter_map view
+const paramMapSql = "SELECT" +
+	" M.model_id, M.model_name, M.model_digest, P.model_parameter_id, P.parameter_hid," +
+	" D.parameter_name, D.parameter_digest, D.db_run_table, D.db_set_table" +
+	" FROM model_dic M" +
+	" INNER JOIN model_parameter_dic P ON (P.model_id = M.model_id)" +
+	" INNER JOIN parameter_dic D ON (D.parameter_hid = P.parameter_hid)"
+
+// select body of model_table_map view
+const tableMapSql = "SELECT" +
+	" M.model_id, M.model_name, M.model_digest, T.model_table_id, T.table_hid," +
+	" D.table_name, D.table_digest, D.db_expr_table, D.db_acc_table, D.db_acc_all_view" +
+	" FROM model_dic M" +
+	" INNER JOIN model_table_dic T ON (T.model_id = M.model_id)" +
+	" INNER JOIN table_dic D ON (D.table_hid = T.table_hid)"
+
+// GetParamMap return parameters mapping of all models or of one model if modelId > 0:
+// model id, model name and parameter id mapped to parameter Hid and db table names.
+func GetParamMap(dbConn *sql.DB, modelId int) ([]ParamMapRow, error) {
+
+	q := paramMapSql
+	if modelId > 0 {
+		q += " WHERE M.model_id = " + strconv.Itoa(modelId)
+	}
+	q += " ORDER BY 1, 4"
+
+	pmLst := []ParamMapRow{}
+
+	err := SelectRows(dbConn, q,
+		func(rows *sql.Rows) error {
+			var r ParamMapRow
+			if err := rows.Scan(
+				&r.ModelId, &r.ModelName, &r.ModelDigest, &r.ParamId, &r.ParamHid,
+				&r.Name, &r.Digest, &r.DbRunTable, &r.DbSetTable); err != nil {
+				return err
+			}
+			pmLst = append(pmLst, r)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return pmLst, nil
+}
+
+// GetTableMap return output tables mapping of all models or of one model if modelId > 0:
+// model id, model name and output table id mapped to table Hid and db table names.
+func GetTableMap(dbConn *sql.DB, modelId int) ([]TableMapRow, error) {
+
+	q := tableMapSql
+	if modelId > 0 {
+		q += " WHERE M.model_id = " + strconv.Itoa(modelId)
+	}
+	q += " ORDER BY 1, 4"
+
+	tmLst := []TableMapRow{}
+
+	err := SelectRows(dbConn, q,
+		func(rows *sql.Rows) error {
+			var r TableMapRow
+			if err := rows.Scan(
+				&r.ModelId, &r.ModelName, &r.ModelDigest, &r.TableId, &r.TableHid,
+				&r.Name, &r.Digest, &r.DbExprTable, &r.DbAccTable, &r.DbAccAllView); err != nil {
+				return err
+			}
+			tmLst = append(tmLst, r)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tmLst, nil
+}
+
+// CreateModelMapViews create or re-create views to map model id and parameter or output table name to Hid and db table names:
+// model_parameter_map and model_table_map.
+//
+// Views are optional and created only by request.
+// If views exist in database then views are refreshed on each model insert or delete.
+func CreateModelMapViews(dbConn *sql.DB) error {
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = trxCreateMapViews(trx, facetOf(dbConn), true); err != nil {
+		trx.Rollback()
+		return err
+	}
+	trx.Commit()
+	return nil
+}
+
+// DropModelMapViews drop model_parameter_map and model_table_map views if views exist in database.
+func DropModelMapViews(dbConn *sql.DB) error {
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = trxDropMapViews(trx, facetOf(dbConn)); err != nil {
+		trx.Rollback()
+		return err
+	}
+	trx.Commit()
+	return nil
+}
+
+// drop and create model mapping views.
+// If isCreate is false then views are re-created only if views already exist.
+// It does update as part of transaction.
+func trxCreateMapViews(trx *sql.Tx, dbFacet Facet, isCreate bool) error {
+
+	isExist, err := trxIsMapViewExist(trx, dbFacet)
+	if err != nil {
+		return err
+	}
+	if !isExist && !isCreate {
+		return nil // views not exist and not requested
+	}
+
+	if err = trxDropMapViews(trx, dbFacet); err != nil {
+		return err
+	}
+	if err = TrxUpdate(trx, dbFacet.createViewIfNotExist(ParamMapView, paramMapSql)); err != nil {
+		return err
+	}
+	return TrxUpdate(trx, dbFacet.createViewIfNotExist(TableMapView, tableMapSql))
+}
+
+// drop model mapping views if exist.
+// It does update as part of transaction.
+func trxDropMapViews(trx *sql.Tx, dbFacet Facet) error {
+
+	vm, err := trxMapViewNames(trx, dbFacet)
+	if err != nil {
+		return err
+	}
+	for _, v := range []string{ParamMapView, TableMapView} {
+		if vm[v] {
+			if err = TrxUpdate(trx, "DROP VIEW "+v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// return true if any of model mapping views exist
+func trxIsMapViewExist(trx *sql.Tx, dbFacet Facet) (bool, error) {
+
+	vm, err := trxMapViewNames(trx, dbFacet)
+	if err != nil {
+		return false, err
+	}
+	return vm[ParamMapView] || vm[TableMapView], nil
+}
+
+// return map of model mapping views which exist in database
+func trxMapViewNames(trx *sql.Tx, dbFacet Facet) (map[string]bool, error) {
+
+	vm := map[string]bool{}
+
+	err := TrxSelectRows(trx, dbFacet.selectTableNamesSql(true),
+		func(rows *sql.Rows) error {
+			var vn string
+			if err := rows.Scan(&vn); err != nil {
+				return err
+			}
+			switch v := strings.ToLower(vn); v {
+			case ParamMapView, TableMapView:
+				vm[v] = true
+			}
+			return nil
+		})
+	return vm, err
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestModelMapViews(t *testing.T) {
+
+	dbConn := openTestDb(t,
+		testModelSql(1, "modelOne", "d1"),
+		testModelSql(2, "modelTwo", "d2"),
+		"INSERT INTO parameter_dic"+
+			" (parameter_hid, parameter_name, parameter_digest, db_run_table, db_set_table, parameter_rank, type_hid, is_extendable, num_cumulated, import_digest)"+
+			" VALUES (101, 'ageSex', 'p1', 'ageSex_p2012_817', 'ageSex_w2012_817', 2, 7, 0, 0, 'i1'),"+
+			" (102, 'salaryAge', 'p2', 'salaryAge_p2012_818', 'salaryAge_w2012_818', 2, 7, 0, 0, 'i2')",
+		"INSERT INTO model_parameter_dic (model_id, model_parameter_id, parameter_hid, is_hidden) VALUES (1, 0, 101, 0), (1, 1, 102, 0), (2, 0, 101, 0)",
+		"INSERT INTO table_dic"+
+			" (table_hid, table_name, table_digest, table_rank, is_sparse, db_expr_table, db_acc_table, db_acc_all_view, import_digest)"+
+			" VALUES (201, 'salarySex', 't1', 2, 0, 'salarySex_v2012_820', 'salarySex_a2012_820', 'salarySex_d2012_820', 'i3')",
+		"INSERT INTO model_table_dic (model_id, model_table_id, table_hid, is_user, expr_dim_pos, is_hidden) VALUES (1, 0, 201, 0, 0, 0), (2, 3, 201, 0, 0, 0)",
+	)
+	setFacetOf(dbConn, SqliteFacet)
+
+	// mapping of all models and mapping of one model
+	pm, err := GetParamMap(dbConn, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pm) != 3 || pm[1].ModelId != 1 || pm[1].ParamId != 1 || pm[1].ParamHid != 102 || pm[1].DbRunTable != "salaryAge_p2012_818" {
+		t.Error("Fail: invalid parameters mapping:", pm)
+	}
+	tm, err := GetTableMap(dbConn, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tm) != 1 || tm[0].ModelName != "modelTwo" || tm[0].TableId != 3 || tm[0].TableHid != 201 || tm[0].DbAccAllView != "salarySex_d2012_820" {
+		t.Error("Fail: invalid output tables mapping:", tm)
+	}
+
+	// views are not created until requested
+	trx, err := dbConn.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = trxCreateMapViews(trx, SqliteFacet, false); err != nil {
+		t.Fatal(err)
+	}
+	isExist, err := trxIsMapViewExist(trx, SqliteFacet)
+	trx.Commit()
+	if err != nil || isExist {
+		t.Error("Fail: unexpected mapping views created:", isExist, err)
+	}
+
+	// create views, create again is refresh
+	for k := 0; k < 2; k++ {
+		if err = CreateModelMapViews(dbConn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := 0
+	if err = SelectFirst(dbConn, "SELECT COUNT(*) FROM "+ParamMapView+" WHERE model_id = 1", func(row *sql.Row) error { return row.Scan(&n) }); err != nil || n != 2 {
+		t.Error("Fail: invalid model parameters view row count:", n, err)
+	}
+	if err = SelectFirst(dbConn, "SELECT table_hid FROM "+TableMapView+" WHERE model_name = 'modelTwo'", func(row *sql.Row) error { return row.Scan(&n) }); err != nil || n != 201 {
+		t.Error("Fail: invalid model output tables view table Hid:", n, err)
+	}
+
+	if err = DropModelMapViews(dbConn); err != nil {
+		t.Fatal(err)
+	}
+	if err = SelectFirst(dbConn, "SELECT COUNT(*) FROM "+ParamMapView, func(row *sql.Row) error { return row.Scan(&n) }); err == nil {
+		t.Error("Fail: model parameters view not deleted")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// OrphanTable is parameter, output table or microdata values db table or view which is not referenced by any model.
+//
+// Such tables can be left in database after failed model import or failed model delete.
+type OrphanTable struct {
+	Name     string // db table or view name
+	Kind     string // p=parameter run values, w=workset values, v=expressions, a=accumulators, d=all accumulators view, g=microdata
+	IsView   bool   // if true then it is a view
+	RowCount int64  // number of rows in table or view
+}
+
+// regexp to match model values db table name: prefix, underscore, one letter kind and 8 hex digits of crc32 digest
+var valueTableRx = regexp.MustCompile(`^\w+_([pwvadg])[0-9a-f]{8}$`)
+
+// FindOrphanTables return list of parameter, output table and microdata values db tables and views not referenced by any model.
+//
+// Db table or view is a model values table if name is: name_ followed by p, w, v, a, d or g and 8 hex digits, e.g.: ageSex_p12345678, salarySex_a1234abcd.
+// It is an orphan if it is not referenced by parameter_dic, table_dic or entity_gen tables.
+// It should not be used during model import because new model tables can be created before model metadata saved.
+func FindOrphanTables(dbConn *sql.DB) ([]OrphanTable, error) {
+
+	facet := facetOf(dbConn)
+
+	// select all db tables and views names referenced by model metadata
+	refs := map[string]bool{}
+
+	addRef := func(rows *sql.Rows) error {
+		var s1, s2, s3 sql.NullString
+		if err := rows.Scan(&s1, &s2, &s3); err != nil {
+			return err
+		}
+		for _, s := range []sql.NullString{s1, s2, s3} {
+			if s.Valid && s.String != "" {
+				refs[strings.ToLower(s.String)] = true
+			}
+		}
+		return nil
+	}
+	for _, q := range []string{
+		"SELECT db_run_table, db_set_table, NULL FROM parameter_dic",
+		"SELECT db_expr_table, db_acc_table, db_acc_all_view FROM table_dic",
+		"SELECT db_entity_table, NULL, NULL FROM entity_gen",
+	} {
+		if err := SelectRows(dbConn, q, addRef); err != nil {
+			return nil, err
+		}
+	}
+
+	// find db tables and views which are look like a model values table and not referenced by any model
+	otLst := []OrphanTable{}
+
+	for _, isView := range []bool{false, true} {
+
+		err := SelectRows(dbConn,
+			facet.selectTableNamesSql(isView),
+			func(rows *sql.Rows) error {
+				var tn string
+				if err := rows.Scan(&tn); err != nil {
+					return err
+				}
+				m := valueTableRx.FindStringSubmatch(strings.ToLower(tn))
+				if len(m) < 2 || refs[strings.ToLower(tn)] {
+					return nil // not a model values db table or it is referenced by model
+				}
+				otLst = append(otLst, OrphanTable{Name: tn, Kind: m[1], IsView: isView})
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// count table rows, it is an estimate of table size
+	for k := range otLst {
+
+		err := SelectFirst(dbConn,
+			"SELECT COUNT(*) FROM "+otLst[k].Name,
+			func(row *sql.Row) error {
+				return row.Scan(&otLst[k].RowCount)
+			})
+		if err != nil {
+			return nil, errors.New("failed to count rows of: " + otLst[k].Name + ": " + err.Error())
+		}
+	}
+	return otLst, nil
+}
+
+// DropOrphanTables drop parameter, output table and microdata values db tables and views not referenced by any model.
+//
+// If names list is not empty then only orphan tables from that list are deleted, else all orphan tables deleted.
+// If isDryRun is true then nothing is deleted.
+// It return list of orphan tables which are deleted or to be deleted in case of dry run.
+// Views are deleted before tables and all deletes done in one transaction.
+func DropOrphanTables(dbConn *sql.DB, names []string, isDryRun bool) ([]OrphanTable, error) {
+
+	// find orphan tables and check if it is in the list of names to delete
+	otLst, err := FindOrphanTables(dbConn)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 {
+		otLst = slices.DeleteFunc(otLst, func(ot OrphanTable) bool {
+			return !slices.ContainsFunc(names, func(s string) bool { return strings.EqualFold(s, ot.Name) })
+		})
+	}
+	if isDryRun || len(otLst) <= 0 {
+		return otLst, nil // dry run or nothing to delete
+	}
+
+	// drop all views first: view can depend on accumulators table
+	slices.SortStableFunc(otLst, func(a, b OrphanTable) int {
+		switch {
+		case a.IsView && !b.IsView:
+			return -1
+		case !a.IsView && b.IsView:
+			return 1
+		}
+		return 0
+	})
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	for k := range otLst {
+
+		q := "DROP TABLE " + otLst[k].Name
+		if otLst[k].IsView {
+			q = "DROP VIEW " + otLst[k].Name
+		}
+		if err = TrxUpdate(trx, q); err != nil {
+			trx.Rollback()
+			return nil, err
+		}
+	}
+	trx.Commit()
+
+	return otLst, nil
+}
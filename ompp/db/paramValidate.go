@@ -0,0 +1,215 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// max number of invalid parameter rows to collect in validation error report
+const maxParamRowErrors = 100
+
+// ParamRowError is invalid parameter row: row number and error message
+type ParamRowError struct {
+	Row int64  // input row number, starting from 1
+	Msg string // error message, e.g.: invalid enum id: 7 of dimension: dim0
+}
+
+// ParamValueError is a report of invalid parameter rows, it is returned at the end of parameter values validation
+type ParamValueError struct {
+	Name  string          // parameter name
+	Count int64           // total number of invalid rows
+	Rows  []ParamRowError // invalid rows, up to 100 first rows
+}
+
+// Error return parameter name, number of invalid rows and first invalid rows
+func (e *ParamValueError) Error() string {
+
+	m := "invalid values of parameter: " + e.Name + ", rows: " + strconv.FormatInt(e.Count, 10)
+	for k := 0; k < len(e.Rows) && k < 10; k++ {
+		m += "; row " + strconv.FormatInt(e.Rows[k].Row, 10) + ": " + e.Rows[k].Msg
+	}
+	if e.Count > 10 {
+		m += "; ..."
+	}
+	return m
+}
+
+// ValidateParamFrom return from() wrapper which validate each parameter cell against type constraints before write.
+//
+// Dimension items must be valid enum id's of dimension type: enum id must exist, range bounds respected, logical is 0 or 1.
+// Parameter value of enum-based type must be valid enum id, value of range type must be in range bounds
+// and value of logical type must be true or false, 0 or 1.
+// Validation does not stop on first invalid cell: invalid cells are skipped and collected into error report with row numbers.
+// At the end of input, if there are any invalid cells, then wrapper return *ParamValueError instead of end of data,
+// and parameter write fails without any changes in database.
+func ValidateParamFrom(modelDef *ModelMeta, name string, from func() (interface{}, error)) (func() (interface{}, error), error) {
+
+	if modelDef == nil {
+		return nil, errors.New("invalid (empty) model metadata")
+	}
+	if from == nil {
+		return nil, errors.New("invalid (empty) parameter values")
+	}
+	var param *ParamMeta
+	if k, ok := modelDef.ParamByName(name); ok {
+		param = &modelDef.Param[k]
+	} else {
+		return nil, errors.New("parameter not found: " + name)
+	}
+
+	pe := &ParamValueError{Name: name, Rows: []ParamRowError{}}
+	var nRow int64
+
+	vf := func() (interface{}, error) {
+
+		for {
+			c, err := from()
+			if err != nil {
+				return nil, err
+			}
+			if c == nil { // end of data: return validation errors report if any invalid rows found
+				if pe.Count > 0 {
+					return nil, pe
+				}
+				return nil, nil
+			}
+			nRow++
+
+			cell, ok := c.(CellParam)
+			if !ok {
+				return nil, errors.New("invalid type, expected: parameter cell (internal error)")
+			}
+
+			msg := validateParamCell(param, &cell)
+			if msg == "" {
+				return c, nil // valid cell
+			}
+			pe.Count++
+			if len(pe.Rows) < maxParamRowErrors {
+				pe.Rows = append(pe.Rows, ParamRowError{Row: nRow, Msg: msg})
+			}
+		}
+	}
+	return vf, nil
+}
+
+// validate parameter cell dimension items and value, return error message or empty string if cell is valid
+func validateParamCell(param *ParamMeta, cell *CellParam) string {
+
+	if len(cell.DimIds) != param.Rank {
+		return "invalid number of dimensions: " + strconv.Itoa(len(cell.DimIds)) + ", expected: " + strconv.Itoa(param.Rank)
+	}
+	msg := []string{}
+
+	for k, id := range cell.DimIds {
+		if !isValidEnumId(param.Dim[k].typeOf, id) {
+			msg = append(msg, "invalid item id: "+strconv.Itoa(id)+" of dimension: "+param.Dim[k].Name)
+		}
+	}
+
+	if !cell.IsNull && cell.Value != nil {
+
+		t := param.typeOf
+		switch {
+		case t.IsBool():
+			ok := false
+			switch v := cell.Value.(type) {
+			case bool:
+				ok = true
+			default:
+				if n, isInt := valueToInt(v); isInt {
+					ok = n == 0 || n == 1
+				}
+			}
+			if !ok {
+				msg = append(msg, "invalid logical value: "+valueToString(cell.Value))
+			}
+		case !t.IsBuiltIn():
+			if n, isInt := valueToInt(cell.Value); !isInt || !isValidEnumId(t, n) {
+				msg = append(msg, "invalid value: "+valueToString(cell.Value)+" of type: "+t.Name)
+			}
+		}
+	}
+	return strings.Join(msg, ", ")
+}
+
+// return true if id is valid enum id: enum exists, range bounds respected, logical is 0 or 1, any id of integer type
+func isValidEnumId(t *TypeMeta, id int) bool {
+
+	switch {
+	case t == nil:
+		return false
+	case t.IsBool():
+		return id == 0 || id == 1
+	case t.IsBuiltIn():
+		return true // integer type: any value
+	case t.IsRange:
+		return t.MinEnumId <= id && id <= t.MaxEnumId
+	}
+	for j := range t.Enum {
+		if t.Enum[j].EnumId == id {
+			return true
+		}
+	}
+	return false
+}
+
+// convert integer or integral float value to int, return false if value is not an integer
+func valueToInt(src interface{}) (int, bool) {
+
+	switch v := src.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int8:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+			return int(v), true
+		}
+	case float32:
+		if f := float64(v); f == math.Trunc(f) && math.Abs(f) <= math.MaxInt32 {
+			return int(f), true
+		}
+	}
+	return 0, false
+}
+
+// return value as string for error message
+func valueToString(src interface{}) string {
+
+	switch v := src.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+	if n, ok := valueToInt(src); ok {
+		return strconv.Itoa(n)
+	}
+	return "?"
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateParamFrom(t *testing.T) {
+
+	sexType := &TypeMeta{
+		TypeDicRow: TypeDicRow{TypeId: 101, Name: "SEX"},
+		Enum:       []TypeEnumRow{{TypeId: 101, EnumId: 0, Name: "M"}, {TypeId: 101, EnumId: 1, Name: "F"}},
+	}
+	ageType := &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 102, Name: "AGE", IsRange: true, MinEnumId: 10, MaxEnumId: 20}}
+	boolType := &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 7, Name: "bool"}}
+
+	meta := &ModelMeta{
+		Param: []ParamMeta{
+			{
+				ParamDicRow: ParamDicRow{Name: "ageSex", Rank: 2},
+				Dim:         []ParamDimsRow{{Name: "dim0", typeOf: ageType}, {Name: "dim1", typeOf: sexType}},
+				typeOf:      sexType,
+			},
+			{
+				ParamDicRow: ParamDicRow{Name: "isOld", Rank: 0},
+				typeOf:      boolType,
+			},
+		},
+	}
+
+	// valid and invalid cells: range bounds, enum id not exist, invalid rank, invalid enum value
+	cells := []CellParam{
+		{cellIdValue: cellIdValue{DimIds: []int{10, 0}, Value: int64(1)}},
+		{cellIdValue: cellIdValue{DimIds: []int{21, 1}, Value: int64(0)}},
+		{cellIdValue: cellIdValue{DimIds: []int{20, 5}, Value: int64(1)}},
+		{cellIdValue: cellIdValue{DimIds: []int{15}, Value: int64(1)}},
+		{cellIdValue: cellIdValue{DimIds: []int{15, 1}, Value: float64(3)}},
+		{cellIdValue: cellIdValue{DimIds: []int{20, 1}, Value: float64(1)}},
+	}
+	vf, err := ValidateParamFrom(meta, "ageSex", makeTestCellFrom(cells))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nValid := 0
+	for {
+		c, e := vf()
+		if e != nil {
+			err = e
+			break
+		}
+		if c == nil {
+			break
+		}
+		nValid++
+	}
+
+	var pe *ParamValueError
+	if !errors.As(err, &pe) {
+		t.Fatal("Fail: expected parameter value error, got:", err)
+	}
+	if nValid != 2 || pe.Count != 4 || len(pe.Rows) != 4 {
+		t.Error("Fail: invalid number of valid or invalid rows:", nValid, pe.Count, len(pe.Rows))
+	}
+	for k, row := range []int64{2, 3, 4, 5} {
+		if k < len(pe.Rows) && pe.Rows[k].Row != row {
+			t.Error("Fail: invalid row number:", pe.Rows[k].Row, "expected:", row)
+		}
+	}
+	t.Log(pe.Error())
+
+	// logical parameter: true, false, 0 or 1
+	cells = []CellParam{
+		{cellIdValue: cellIdValue{DimIds: []int{}, Value: true}},
+		{cellIdValue: cellIdValue{DimIds: []int{}, Value: int64(1)}},
+		{cellIdValue: cellIdValue{DimIds: []int{}, Value: int64(2)}},
+		{cellIdValue: cellIdValue{DimIds: []int{}, IsNull: true}},
+	}
+	vf, err = ValidateParamFrom(meta, "isOld", makeTestCellFrom(cells))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		c, e := vf()
+		if e != nil || c == nil {
+			err = e
+			break
+		}
+	}
+	if !errors.As(err, &pe) || pe.Count != 1 || pe.Rows[0].Row != 3 {
+		t.Error("Fail: expected invalid logical value at row 3, got:", err)
+	}
+
+	if _, err = ValidateParamFrom(meta, "notAParam", makeTestCellFrom(cells)); err == nil {
+		t.Error("Fail: expected error for unknown parameter")
+	}
+}
+
+// return reader of parameter cells
+func makeTestCellFrom(cells []CellParam) func() (interface{}, error) {
+	k := -1
+	return func() (interface{}, error) {
+		k++
+		if k >= len(cells) {
+			return nil, nil
+		}
+		return cells[k], nil
+	}
+}
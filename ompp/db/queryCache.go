@@ -0,0 +1,213 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"container/list"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// QueryCacheStat is a number of queries found in cache and number of queries prepared and stored in cache
+type QueryCacheStat struct {
+	Size      int   // current number of cached queries
+	HitCount  int64 // number of queries found in cache
+	MissCount int64 // number of queries prepared and stored in cache
+}
+
+// cached select query and prepared statement
+type cachedQuery struct {
+	query string    // sql select query with ? run id parameter
+	stmt  *sql.Stmt // prepared statement
+}
+
+// cache of select queries and prepared statements of database connection
+type queryCache struct {
+	maxSize int                     // max number of cached queries
+	items   map[string]*cachedQuery // queries by key: model digest, table Hid and read layout
+	stat    QueryCacheStat          // cache usage statistics
+}
+
+// select queries and prepared statements cache by database connection
+var theQueryCache = struct {
+	sync.Mutex
+	conn map[*sql.DB]*queryCache
+}{conn: map[*sql.DB]*queryCache{}}
+
+// SetQueryCache enable or disable cache of output table select queries and prepared statements for database connection.
+//
+// If maxSize is positive then select queries are made once for each output table and read layout,
+// run id is a statement parameter and the same prepared statement is reused to read multiple model runs.
+// If cache is full then new queries are not cached.
+// If maxSize is zero or negative then cache is disabled and all cached statements are closed,
+// it must be called before database connection closed.
+func SetQueryCache(dbConn *sql.DB, maxSize int) QueryCacheStat {
+
+	theQueryCache.Lock()
+	defer theQueryCache.Unlock()
+
+	qc, ok := theQueryCache.conn[dbConn]
+	st := QueryCacheStat{}
+	if ok {
+		st = qc.stat
+		st.Size = len(qc.items)
+	}
+
+	if maxSize <= 0 {
+		if ok {
+			qc.clear()
+			delete(theQueryCache.conn, dbConn)
+		}
+		return st
+	}
+
+	if !ok {
+		qc = &queryCache{items: map[string]*cachedQuery{}}
+		theQueryCache.conn[dbConn] = qc
+	}
+	qc.maxSize = maxSize
+	return st
+}
+
+// close all cached statements and clear cache
+func (qc *queryCache) clear() {
+	for _, c := range qc.items {
+		if c.stmt != nil {
+			c.stmt.Close()
+		}
+	}
+	qc.items = map[string]*cachedQuery{}
+}
+
+// cachedQueryOf return select query and prepared statement from database connection cache by key.
+//
+// If cache is not enabled for database connection then return query made by makeSql(runIdSql) and nil statement,
+// where run id sql is a literal run id, e.g.: 102.
+// If query not found in cache then it is made by makeSql("?"), statement prepared and stored in cache,
+// run id must be passed to the statement as a parameter.
+// Statement is prepared outside of cache lock, if other goroutine stored the same key first then its statement is used.
+func cachedQueryOf(dbConn *sql.DB, key string, runIdSql string, makeSql func(runIdSql string) (string, error)) (string, *sql.Stmt, error) {
+
+	// find query in cache or return literal run id query if cache disabled or full
+	isCache := false
+
+	theQueryCache.Lock()
+	if qc, ok := theQueryCache.conn[dbConn]; ok {
+
+		if c, ok := qc.items[key]; ok {
+			qc.stat.HitCount++
+			theQueryCache.Unlock()
+			return c.query, c.stmt, nil
+		}
+		isCache = len(qc.items) < qc.maxSize
+	}
+	theQueryCache.Unlock()
+
+	if !isCache {
+		q, err := makeSql(runIdSql)
+		return q, nil, err // cache is not enabled or cache is full
+	}
+
+	// make and prepare query with run id parameter
+	q, err := makeSql("?")
+	if err != nil {
+		return "", nil, err
+	}
+	omppLog.LogSql(q)
+
+	stmt, err := dbConn.Prepare(q)
+	if err != nil {
+		return "", nil, errors.New("failed to prepare select query: " + err.Error())
+	}
+
+	// store statement in cache, if cache is still enabled and not full
+	theQueryCache.Lock()
+	defer theQueryCache.Unlock()
+
+	qc, ok := theQueryCache.conn[dbConn]
+	if ok {
+		if c, ok := qc.items[key]; ok {
+			stmt.Close() // other goroutine prepared the same query
+			qc.stat.HitCount++
+			return c.query, c.stmt, nil
+		}
+	}
+	if !ok || len(qc.items) >= qc.maxSize {
+		stmt.Close()
+		q, err = makeSql(runIdSql)
+		return q, nil, err // cache disabled or full while statement prepared
+	}
+
+	qc.items[key] = &cachedQuery{query: q, stmt: stmt}
+	qc.stat.MissCount++
+
+	return q, stmt, nil
+}
+
+// selectRowsStmtTo select db rows and pass each row to cvt(), same as SelectRowsTo().
+// If statement is not nil then execute prepared statement with arguments else execute query.
+func selectRowsStmtTo(dbConn *sql.DB, stmt *sql.Stmt, query string, args []interface{}, cvt func(rows *sql.Rows) (bool, error)) error {
+
+	if stmt == nil {
+		return SelectRowsTo(dbConn, query, cvt)
+	}
+	omppLog.LogSql(query)
+
+	ctx, done := WatchQuery(query, 0)
+
+	rows, err := stmt.QueryContext(ctx, args...) // query db rows
+	if err != nil {
+		return done(toLockedError(err))
+	}
+	defer rows.Close()
+
+	// process each row until the end or until cvt() return false to continue
+	for rows.Next() {
+		isNext, err := cvt(rows)
+		if err != nil {
+			return done(err)
+		}
+		if !isNext {
+			break
+		}
+	}
+	return done(rows.Err())
+}
+
+// selectStmtToList select db rows into list using cvt to convert (scan) each db row into struct, same as SelectToList().
+// If statement is not nil then execute prepared statement with arguments else execute query.
+func selectStmtToList(
+	dbConn *sql.DB, stmt *sql.Stmt, query string, args []interface{}, layout ReadPageLayout, cvt func(rows *sql.Rows) (interface{}, error),
+) (*list.List, *ReadPageLayout, error) {
+
+	if stmt == nil {
+		return SelectToList(dbConn, query, layout, cvt)
+	}
+	omppLog.LogSql(query)
+
+	ctx, done := WatchQuery(query, 0)
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, nil, done(err)
+	}
+	defer rows.Close()
+
+	return rowsToList(rows, layout, done, cvt)
+}
+
+// queryCacheKey return cache key of output table select query: model digest, table Hid and read layout without run id and page size.
+func queryCacheKey(modelDigest string, tableHid int, layout *ReadTableLayout) string {
+
+	lt := *layout
+	lt.FromId = 0
+	lt.ReadPageLayout = ReadPageLayout{}
+
+	return modelDigest + ":" + strconv.Itoa(tableHid) + ":" + fmt.Sprintf("%#v", lt)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestQueryCache(t *testing.T) {
+
+	dbConn := openTestDb(t,
+		"CREATE TABLE salarySex_v2012820 (run_id INT NOT NULL, expr_value FLOAT NULL)",
+		"INSERT INTO salarySex_v2012820 (run_id, expr_value) VALUES (11, 1.5), (12, 2.5), (12, 3.5)",
+	)
+
+	nMake := 0
+	makeSql := func(runIdSql string) (string, error) {
+		nMake++
+		return "SELECT expr_value FROM salarySex_v2012820 WHERE run_id = " + runIdSql + " ORDER BY 1", nil
+	}
+
+	// select values of the run and return number of rows
+	selectRun := func(key string, runId int) int {
+
+		q, stmt, err := cachedQueryOf(dbConn, key, strconv.Itoa(runId), makeSql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var args []interface{}
+		if stmt != nil {
+			args = []interface{}{runId}
+		}
+		n := 0
+		err = selectRowsStmtTo(dbConn, stmt, q, args, func(rows *sql.Rows) (bool, error) {
+			n++
+			return true, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// cache disabled: sql made for each run with literal run id
+	if n := selectRun("k1", 11); n != 1 || nMake != 1 {
+		t.Error("Fail to select without cache:", n, nMake)
+	}
+
+	// cache enabled: sql made once and statement reused for each run
+	SetQueryCache(dbConn, 1)
+	nMake = 0
+
+	if n := selectRun("k1", 11); n != 1 {
+		t.Error("Fail to select run 11 using cache:", n)
+	}
+	if n := selectRun("k1", 12); n != 2 {
+		t.Error("Fail to select run 12 using cache:", n)
+	}
+	if nMake != 1 {
+		t.Error("Fail: sql must be made only once:", nMake)
+	}
+
+	// cache is full: query not cached
+	if n := selectRun("k2", 12); n != 2 || nMake != 2 {
+		t.Error("Fail to select if cache is full:", n, nMake)
+	}
+
+	st := SetQueryCache(dbConn, 0)
+	if st.Size != 1 || st.HitCount != 1 || st.MissCount != 1 {
+		t.Error("Fail: invalid query cache statistics:", st)
+	}
+	if _, ok := theQueryCache.conn[dbConn]; ok {
+		t.Error("Fail: query cache must be removed")
+	}
+}
+
+func TestReadOutputTableQueryCache(t *testing.T) {
+
+	// run 12 output table values stored in its own rows, run 13 is using values of run 11
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageSex_v12345678 (run_id INT NOT NULL, expr_id INT NOT NULL, dim0 INT NOT NULL, expr_value FLOAT NULL)",
+		"CREATE TABLE ageSex_a12345678 (run_id INT NOT NULL, acc_id INT NOT NULL, sub_id INT NOT NULL, dim0 INT NOT NULL, acc_value FLOAT NULL)",
+		testRunSql(11, 1, 1, "s"),
+		testRunSql(12, 1, 1, "s"),
+		testRunSql(13, 1, 1, "s"),
+		"INSERT INTO run_table (run_id, table_hid, base_run_id) VALUES (11, 12, 11), (12, 12, 12), (13, 12, 11)",
+		"INSERT INTO ageSex_v12345678 (run_id, expr_id, dim0, expr_value) VALUES (11, 0, 0, 1.5), (11, 0, 1, 2.5), (12, 0, 0, 3.5), (12, 0, 1, NULL)",
+		"INSERT INTO ageSex_a12345678 (run_id, acc_id, sub_id, dim0, acc_value) VALUES (11, 0, 0, 0, 1.5), (11, 0, 0, 1, 2.5), (12, 0, 0, 0, 3.5)",
+	)
+
+	ageType := &TypeMeta{TypeDicRow: TypeDicRow{Name: "age", IsRange: true, MinEnumId: 0, MaxEnumId: 1, sizeOf: 2}}
+
+	modelDef := &ModelMeta{
+		Model: ModelDicRow{ModelId: 1, Digest: "m1"},
+		Type:  []TypeMeta{{TypeDicRow: TypeDicRow{Name: "double", Digest: "_double_"}}},
+		Table: []TableMeta{{
+			TableDicRow: TableDicRow{TableHid: 12, Name: "ageSex", Rank: 1, DbExprTable: "ageSex_v12345678", DbAccTable: "ageSex_a12345678"},
+			Dim:         []TableDimsRow{{DimId: 0, Name: "dim0", typeOf: ageType, colName: "dim0"}},
+			Acc:         []TableAccRow{{AccId: 0, Name: "acc0", colName: "acc0"}},
+			Expr:        []TableExprRow{{ExprId: 0, Name: "expr0"}},
+		}},
+	}
+
+	// read output table rows of the run and return it as list of strings
+	readRows := func(layout ReadTableLayout, runId int) []string {
+
+		rows := []string{}
+		layout.FromId = runId
+		_, err := ReadOutputTableTo(dbConn, modelDef, &layout, func(src interface{}) (bool, error) {
+			rows = append(rows, fmt.Sprint(src))
+			return true, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	lts := []ReadTableLayout{
+		{ReadLayout: ReadLayout{Name: "ageSex"}},
+		{ReadLayout: ReadLayout{Name: "ageSex", ReadPageLayout: ReadPageLayout{Offset: 1, Size: 1}}},
+		{ReadLayout: ReadLayout{Name: "ageSex", ReadPageLayout: ReadPageLayout{IsFullPage: true}}},
+		{ReadLayout: ReadLayout{Name: "ageSex"}, IsAccum: true},
+	}
+	runIds := []int{11, 12, 13}
+
+	// read without cache and read again using cached statements: rows must be the same
+	src := [][]string{}
+	for _, lt := range lts {
+		for _, id := range runIds {
+			src = append(src, readRows(lt, id))
+		}
+	}
+
+	SetQueryCache(dbConn, 100)
+	defer SetQueryCache(dbConn, 0)
+
+	for n := 0; n < 2; n++ {
+		k := 0
+		for _, lt := range lts {
+			for _, id := range runIds {
+				if rows := readRows(lt, id); fmt.Sprint(rows) != fmt.Sprint(src[k]) {
+					t.Errorf("Fail: read using query cache: %v expected: %v run: %d layout: %v", rows, src[k], id, lt)
+				}
+				k++
+			}
+		}
+	}
+	if len(src[0]) != 2 || len(src[2]) != 2 || len(src[3]) != 1 {
+		t.Error("Fail: invalid number of rows:", src)
+	}
+
+	st := SetQueryCache(dbConn, 100)
+	if st.MissCount <= 0 || st.HitCount <= 0 || st.Size != int(st.MissCount) {
+		t.Error("Fail: query cache not used:", st)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// QueryWatchdog is a thresholds of long query watchdog.
+// If query takes longer than WarnAfter then it is logged as slow query.
+// If query takes longer than CancelAfter then query is cancelled, if database driver supports cancellation.
+// Zero or negative threshold means no limit.
+type QueryWatchdog struct {
+	WarnAfter   time.Duration // if positive then log queries which take longer than that
+	CancelAfter time.Duration // if positive then cancel queries which take longer than that
+}
+
+// current long query watchdog thresholds, by default there is no limits
+var theWatchdog atomic.Pointer[QueryWatchdog]
+
+// SetQueryWatchdog set long query watchdog thresholds.
+// Watchdog is applied to select queries started by SelectFirst, SelectRows, SelectRowsTo and SelectToList,
+// thresholds are the same for all database connections.
+func SetQueryWatchdog(wd QueryWatchdog) {
+	theWatchdog.Store(&wd)
+}
+
+// return current long query watchdog thresholds
+func queryWatchdog() QueryWatchdog {
+	if p := theWatchdog.Load(); p != nil {
+		return *p
+	}
+	return QueryWatchdog{}
+}
+
+// WatchQuery register query with expected maximum duration and return query context and done function.
+// If maxDuration is zero then watchdog CancelAfter threshold is used.
+// Query must be executed using returned context and done function must be called after query rows processed.
+// If query exceed maximum duration then context is cancelled, done() log SQL digest and duration and return cancellation error.
+// If query take longer than watchdog WarnAfter threshold then done() log it as slow query.
+func WatchQuery(query string, maxDuration time.Duration) (context.Context, func(err error) error) {
+
+	wd := queryWatchdog()
+	if maxDuration <= 0 {
+		maxDuration = wd.CancelAfter
+	}
+	if maxDuration <= 0 && wd.WarnAfter <= 0 {
+		return context.Background(), func(err error) error { return err } // no watchdog
+	}
+
+	ctx := context.Background()
+	cancel := func() {}
+	if maxDuration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, maxDuration)
+	}
+	start := time.Now()
+
+	return ctx, func(err error) error {
+
+		d := time.Since(start).Round(time.Millisecond)
+		isCancel := ctx.Err() == context.DeadlineExceeded
+		cancel()
+
+		if isCancel {
+			dg := queryDigest(query)
+			omppLog.Log("Query cancelled by watchdog after: ", d, " SQL digest: ", dg)
+			omppLog.LogSql("Query cancelled: " + dg + ": " + query)
+
+			if err == nil {
+				return errors.New("query cancelled after: " + d.String() + " exceeded max duration: " + maxDuration.String())
+			}
+			return errors.New("query cancelled after: " + d.String() + " exceeded max duration: " + maxDuration.String() + ": " + err.Error())
+		}
+		if wd.WarnAfter > 0 && d >= wd.WarnAfter {
+			dg := queryDigest(query)
+			omppLog.Log("Slow query: ", d, " SQL digest: ", dg)
+			omppLog.LogSql("Slow query: " + dg + ": " + query)
+		}
+		return err
+	}
+}
+
+// return SQL digest: md5 of query text, it can be used to find query in sql log
+func queryDigest(query string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(query)))
+}
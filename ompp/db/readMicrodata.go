@@ -14,7 +14,7 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return nil, errors.New("invalid (empty) microdata read layout")
@@ -22,6 +22,9 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 	if layout.Name == "" {
 		return nil, errors.New("invalid (empty) parameter name")
 	}
+	if layout.IsKeyFrom && layout.IsKeyTo && layout.KeyFrom > layout.KeyTo {
+		return nil, errors.New("invalid entity key range: " + strconv.FormatUint(layout.KeyFrom, 10) + " greater than " + strconv.FormatUint(layout.KeyTo, 10))
+	}
 
 	// find entity by name
 	eIdx, ok := modelDef.EntityByName(layout.Name)
@@ -36,7 +39,7 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		return nil, err
 	}
 	if runRow == nil {
-		return nil, errors.New("model run not found, id: " + strconv.Itoa(layout.FromId))
+		return nil, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(layout.FromId))
 	}
 	if runRow.Status != DoneRunStatus {
 		return nil, errors.New("model run not completed successfully, id: " + strconv.Itoa(layout.FromId))
@@ -59,21 +62,21 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		return nil, errors.New("model run does not contain entity generation: " + layout.GenDigest + " " + entity.Name + " in run, id: " + strconv.Itoa(layout.FromId))
 	}
 
-	entityAttrs := make([]EntityAttrRow, len(entGen.GenAttr))
-
-	for k, ga := range entGen.GenAttr {
-
-		aIdx, isOk := entity.AttrByKey(ga.AttrId)
-		if !isOk {
-			return nil, errors.New("entity attribute id not found: " + strconv.Itoa(ga.AttrId) + " " + entity.Name)
-		}
-		entityAttrs[k] = entity.Attr[aIdx]
+	// all generation attributes can be used in filters and only selected attributes are in the output
+	genAttrs, err := genEntityAttrs(entity, entGen, nil)
+	if err != nil {
+		return nil, err
+	}
+	entityAttrs, err := genEntityAttrs(entity, entGen, layout.Attrs)
+	if err != nil {
+		return nil, err
 	}
 
 	// make sql to select microdata from model run:
 	// 	 SELECT entity_key, attr4, attr7
 	//   FROM Person_g87abcdef
 	//   WHERE run_id = (SELECT base_run_id FROM run_entity WHERE run_id = 1234 AND entity_gen_hid = 1)
+	//   AND entity_key >= 1000 AND entity_key <= 1999
 	//   ORDER BY 1, 2
 	//
 	q := "SELECT entity_key "
@@ -88,13 +91,21 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		" WHERE run_id = " + strconv.Itoa(layout.FromId) +
 		" AND entity_gen_hid = " + strconv.Itoa(entGen.GenHid) + ")"
 
+	// append entity key range, if specified
+	if layout.IsKeyFrom {
+		q += " AND entity_key >= " + strconv.FormatUint(layout.KeyFrom, 10)
+	}
+	if layout.IsKeyTo {
+		q += " AND entity_key <= " + strconv.FormatUint(layout.KeyTo, 10)
+	}
+
 	// append attribute enum code filters, if specified
 	for k := range layout.Filter {
 
 		// find attribute index by name
 		aIdx := -1
-		for j := range entityAttrs {
-			if entityAttrs[j].Name == layout.Filter[k].Name {
+		for j := range genAttrs {
+			if genAttrs[j].Name == layout.Filter[k].Name {
 				aIdx = j
 				break
 			}
@@ -104,7 +115,7 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		}
 
 		f, err := makeWhereFilter(
-			&layout.Filter[k], "", entityAttrs[aIdx].colName, entityAttrs[aIdx].typeOf, false, entityAttrs[aIdx].Name, "entity "+entity.Name)
+			&layout.Filter[k], "", genAttrs[aIdx].colName, genAttrs[aIdx].typeOf, false, genAttrs[aIdx].Name, "entity "+entity.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -117,8 +128,8 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 
 		// find attribute index by name
 		aIdx := -1
-		for j := range entityAttrs {
-			if entityAttrs[j].Name == layout.FilterById[k].Name {
+		for j := range genAttrs {
+			if genAttrs[j].Name == layout.FilterById[k].Name {
 				aIdx = j
 				break
 			}
@@ -128,7 +139,7 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		}
 
 		f, err := makeWhereIdFilter(
-			&layout.FilterById[k], "", entityAttrs[aIdx].colName, entityAttrs[aIdx].typeOf, entityAttrs[aIdx].Name, "entity "+entity.Name)
+			&layout.FilterById[k], "", genAttrs[aIdx].colName, genAttrs[aIdx].typeOf, genAttrs[aIdx].Name, "entity "+entity.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -202,9 +213,10 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		func(rows *sql.Rows) (bool, error) {
 
 			// if page size is limited then select only a page of rows
+			// if total count requested then count all rows after the page
 			nRow++
 			if nSize > 0 && nRow > nStart+nSize {
-				return false, nil
+				return layout.isCountAll, nil
 			}
 			if nRow <= nStart {
 				return true, nil
@@ -234,10 +246,55 @@ func ReadMicrodataTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadMicroLayou
 		lt.Offset = nRow
 	}
 	lt.IsLastPage = nSize <= 0 || nSize > 0 && nRow <= nStart+nSize
+	lt.rowCount = nRow
 
 	return &lt, nil
 }
 
+// genEntityAttrs return entity generation attributes in the order of generation.
+// If list of attribute names is not empty then return only attributes from that list, it is an error if attribute not found.
+func genEntityAttrs(entity *EntityMeta, entGen *EntityGenMeta, attrNames []string) ([]EntityAttrRow, error) {
+
+	attrs := make([]EntityAttrRow, 0, len(entGen.GenAttr))
+	nFound := 0
+
+	for _, ga := range entGen.GenAttr {
+
+		aIdx, ok := entity.AttrByKey(ga.AttrId)
+		if !ok {
+			return nil, errors.New("entity attribute id not found: " + strconv.Itoa(ga.AttrId) + " " + entity.Name)
+		}
+		if len(attrNames) > 0 {
+
+			isFound := false
+			for k := 0; !isFound && k < len(attrNames); k++ {
+				isFound = attrNames[k] == entity.Attr[aIdx].Name
+			}
+			if !isFound {
+				continue
+			}
+			nFound++
+		}
+		attrs = append(attrs, entity.Attr[aIdx])
+	}
+
+	// each attribute name must be found in entity generation
+	if nFound < len(attrNames) {
+		for _, an := range attrNames {
+
+			isFound := false
+			for k := 0; !isFound && k < len(attrs); k++ {
+				isFound = attrs[k].Name == an
+			}
+			if !isFound {
+				return nil, errors.New("entity " + entity.Name + " generation does not have attribute " + an)
+			}
+		}
+		return nil, errors.New("entity " + entity.Name + " attributes list contains duplicates")
+	}
+	return attrs, nil
+}
+
 // trxReadMicrodataTo read entity microdata rows (microdata key, attributes) from model run results and process each row by cvtTo().
 func trxReadMicrodataTo(trx *sql.Tx, entity *EntityMeta, entityAttrs []EntityAttrRow, query string, cvtTo func(src interface{}) error) error {
 
@@ -353,7 +410,7 @@ func scanSqlRowToCellMicro(entity *EntityMeta, entityAttrs []EntityAttrRow) ([]i
 					return attrValue{IsNull: true}, nil
 				}
 				if vf.Valid {
-					return attrValue{IsNull: false, Value: vf.Float64}, nil
+					return attrValue{IsNull: false, Value: floatFromDb(vf.Float64)}, nil
 				}
 				return attrValue{IsNull: true, Value: 0.0}, nil
 			}
@@ -396,7 +453,7 @@ func ReadMicrodataCalculateTo(
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return nil, errors.New("invalid (empty) microdata read layout")
@@ -427,7 +484,7 @@ func ReadMicrodataCalculateTo(
 		return nil, err
 	}
 	if runRow == nil {
-		return nil, errors.New("model run not found, id: " + strconv.Itoa(layout.FromId))
+		return nil, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(layout.FromId))
 	}
 	if runRow.Status != DoneRunStatus {
 		return nil, errors.New("model run not completed successfully, id: " + strconv.Itoa(layout.FromId))
@@ -556,9 +613,10 @@ func ReadMicrodataCalculateTo(
 		func(rows *sql.Rows) (bool, error) {
 
 			// if page size is limited then select only a page of rows
+			// if total count requested then count all rows after the page
 			nRow++
 			if nSize > 0 && nRow > nStart+nSize {
-				return false, nil
+				return layout.isCountAll, nil
 			}
 			if nRow <= nStart {
 				return true, nil
@@ -587,6 +645,7 @@ func ReadMicrodataCalculateTo(
 		lt.Offset = nRow
 	}
 	lt.IsLastPage = nSize <= 0 || nSize > 0 && nRow <= nStart+nSize
+	lt.rowCount = nRow
 
 	return &lt, nil
 }
@@ -683,7 +742,7 @@ func scanSqlRowToCellMicroCalc(entity *EntityMeta, aGroupBy []EntityAttrRow) ([]
 			return attrValue{IsNull: true}, nil
 		}
 		if vf.Valid {
-			return attrValue{IsNull: false, Value: vf.Float64}, nil
+			return attrValue{IsNull: false, Value: floatFromDb(vf.Float64)}, nil
 		}
 		return attrValue{IsNull: true, Value: 0.0}, nil
 	}
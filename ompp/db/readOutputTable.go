@@ -7,17 +7,19 @@ import (
 	"database/sql"
 	"errors"
 	"strconv"
+	"strings"
 )
 
 // ReadOutputTableTo read output table page (dimensions and values) from model run results and process each row by cvtTo().
 //
 // If layout.IsAccum true then select accumulator(s) else output expression value(s)
 // If layout.ValueName not empty then select only that expression (accumulator) else all expressions (accumulators)
+// If layout.SampleSize > 0 then select only a sample of cells: first cells or random sample, see ReadSampleLayout
 func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLayout, cvtTo func(src interface{}) (bool, error)) (*ReadPageLayout, error) {
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return nil, errors.New("invalid (empty) output table read layout")
@@ -72,13 +74,19 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 		accCount = len(table.Acc)
 	}
 
+	// if sample requested then find sample by dimensions
+	sIdx, err := sampleByIndex(table, &layout.ReadSampleLayout)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if model run exist and model run completed
 	runRow, err := GetRun(dbConn, layout.FromId)
 	if err != nil {
 		return nil, err
 	}
 	if runRow == nil {
-		return nil, errors.New("model run not found, id: " + strconv.Itoa(layout.FromId))
+		return nil, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(layout.FromId))
 	}
 	if runRow.Status != DoneRunStatus {
 		return nil, errors.New("model run not completed successfully, id: " + strconv.Itoa(layout.FromId))
@@ -145,169 +153,220 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 	//   AND dim1 IN (10, 20, 30, 40)
 	//   ORDER BY 1, 2, 3, 4
 	//
-	q := ""
-	if layout.IsAllAccum {
-		q = sqlAccAllViewAsWith(table) + " "
-	}
+	// make sql or get it from the cache of connection: sql is the same for any run id
+	// if sql is cached then run id is a parameter of prepared statement
+	makeSql := func(runIdSql string) (string, error) {
 
-	q += "SELECT"
+		withSql := ""
+		if layout.IsAllAccum {
+			withSql = sqlAccAllViewAsWith(table)
+		}
 
-	if layout.IsAccum {
-		if !layout.IsAllAccum {
-			q += " acc_id,"
+		// id columns: expr_id or acc_id, sub_id or sub_id and value columns: expr_value or acc_value or acc0, acc1,...
+		idCols := []string{"expr_id"}
+		valCols := []string{"expr_value"}
+		if layout.IsAccum {
+			if !layout.IsAllAccum {
+				idCols = []string{"acc_id", "sub_id"}
+				valCols = []string{"acc_value"}
+			} else {
+				idCols = []string{"sub_id"}
+				if valAccCol != "" {
+					valCols = []string{valAccCol}
+				} else {
+					valCols = make([]string, len(table.Acc))
+					for k := range table.Acc {
+						valCols[k] = table.Acc[k].colName
+					}
+				}
+			}
 		}
-		q += " sub_id"
-	} else {
-		q += " expr_id"
-	}
 
-	for k := range table.Dim {
-		q += ", " + table.Dim[k].colName
-	}
+		q := "SELECT " + strings.Join(idCols, ", ")
 
-	if !layout.IsAccum {
-		q += ", expr_value FROM " + table.DbExprTable
-	} else {
-		if !layout.IsAllAccum {
-			q += ", acc_value FROM " + table.DbAccTable
+		for k := range table.Dim {
+			q += ", " + table.Dim[k].colName
+		}
+		q += ", " + strings.Join(valCols, ", ")
+
+		if !layout.IsAccum {
+			q += " FROM " + table.DbExprTable
 		} else {
-			if valAccCol != "" {
-				q += ", " + valAccCol
+			if !layout.IsAllAccum {
+				q += " FROM " + table.DbAccTable
 			} else {
-				for k := range table.Acc {
-					q += ", " + table.Acc[k].colName
-				}
+				q += " FROM v_all_acc"
 			}
-			q += " FROM v_all_acc"
 		}
-	}
 
-	q += " WHERE run_id =" +
-		" (SELECT base_run_id FROM run_table" +
-		" WHERE run_id = " + strconv.Itoa(layout.FromId) +
-		" AND table_hid = " + strconv.Itoa(table.TableHid) + ")"
+		q += " WHERE run_id =" +
+			" (SELECT base_run_id FROM run_table" +
+			" WHERE run_id = " + runIdSql +
+			" AND table_hid = " + strconv.Itoa(table.TableHid) + ")"
 
-	if !layout.IsAllAccum && valId >= 0 {
-		if layout.IsAccum {
-			q += " AND acc_id = " + strconv.Itoa(valId)
-		} else {
-			q += " AND expr_id = " + strconv.Itoa(valId)
+		if !layout.IsAllAccum && valId >= 0 {
+			if layout.IsAccum {
+				q += " AND acc_id = " + strconv.Itoa(valId)
+			} else {
+				q += " AND expr_id = " + strconv.Itoa(valId)
+			}
 		}
-	}
 
-	// append sub-value id filter
-	if layout.IsAccum && layout.IsSubId {
-		q += " AND sub_id = " + strconv.Itoa(layout.SubId)
-	}
+		// append sub-value id filter
+		if layout.IsAccum && layout.IsSubId {
+			q += " AND sub_id = " + strconv.Itoa(layout.SubId)
+		}
 
-	// append dimension enum code filters, if specified
-	iDbl, ok := modelDef.TypeOfDouble()
-	if !ok {
-		return nil, errors.New("double type not found, output table " + table.Name)
-	}
+		// if dimension total items computed on read then select from stored values and computed margins
+		// dimension filters and value filters are applied to the result of margins computation
+		// WITH part of sql is prepended to the final select after filters and sample applied
+		whereAnd := " AND "
+		withPart := withSql
+		if len(layout.Margin) > 0 {
+			if q, err = sqlTableMargin(table, &layout.ReadMarginLayout, withSql, q, idCols, valCols); err != nil {
+				return "", err
+			}
+			whereAnd = " WHERE "
 
-	for k := range layout.Filter {
+			withPart = "" // split margins sql: WITH mt_base AS (...), mt_all AS (...) SELECT ... FROM mt_all
+			if i := strings.LastIndex(q, ") SELECT "); i >= 0 {
+				withPart = q[:i+1]
+				q = q[i+2:]
+			}
+		}
 
-		// filter by expression value or accumulator value or find dimension index by name
-		var err error
-		f := ""
+		// append dimension enum code filters, if specified
+		iDbl, ok := modelDef.TypeOfDouble()
+		if !ok {
+			return "", errors.New("double type not found, output table " + table.Name)
+		}
 
-		if !layout.IsAccum {
+		for k := range layout.Filter {
 
-			eix := -1
-			for j := range table.Expr {
-				if table.Expr[j].Name == layout.Filter[k].Name {
-					eix = j
-					break
+			// filter by expression value or accumulator value or find dimension index by name
+			var err error
+			f := ""
+
+			if !layout.IsAccum {
+
+				eix := -1
+				for j := range table.Expr {
+					if table.Expr[j].Name == layout.Filter[k].Name {
+						eix = j
+						break
+					}
 				}
-			}
-			if eix >= 0 {
-				f, err = makeWhereValueFilter(
-					&layout.Filter[k], "", "expr_value", "expr_id", table.Expr[eix].ExprId, &modelDef.Type[iDbl], layout.Filter[k].Name, "output table "+table.Name)
-				if err != nil {
-					return nil, err
+				if eix >= 0 {
+					f, err = makeWhereValueFilter(
+						&layout.Filter[k], "", "expr_value", "expr_id", table.Expr[eix].ExprId, &modelDef.Type[iDbl], layout.Filter[k].Name, "output table "+table.Name)
+					if err != nil {
+						return "", err
+					}
 				}
-			}
-		} else {
+			} else {
 
-			aix := -1
-			for j := range table.Acc {
-				if (!table.Acc[j].IsDerived || layout.IsAllAccum) && table.Acc[j].Name == layout.Filter[k].Name {
-					aix = j
-					break
+				aix := -1
+				for j := range table.Acc {
+					if (!table.Acc[j].IsDerived || layout.IsAllAccum) && table.Acc[j].Name == layout.Filter[k].Name {
+						aix = j
+						break
+					}
+				}
+				if aix >= 0 {
+					if !layout.IsAllAccum {
+
+						f, err = makeWhereValueFilter(
+							&layout.Filter[k], "", "acc_value", "acc_id", table.Acc[aix].AccId, &modelDef.Type[iDbl], layout.Filter[k].Name, "output table "+table.Name)
+						if err != nil {
+							return "", err
+						}
+					} else {
+
+						f, err = makeWhereFilter(
+							&layout.Filter[k], "", table.Acc[aix].Name, &modelDef.Type[iDbl], false, layout.Filter[k].Name, "output table "+table.Name)
+						if err != nil {
+							return "", err
+						}
+					}
 				}
 			}
-			if aix >= 0 {
-				if !layout.IsAllAccum {
+			if f == "" { // if not a filter by value then it must be filter by dimension
 
-					f, err = makeWhereValueFilter(
-						&layout.Filter[k], "", "acc_value", "acc_id", table.Acc[aix].AccId, &modelDef.Type[iDbl], layout.Filter[k].Name, "output table "+table.Name)
-					if err != nil {
-						return nil, err
+				dix := -1
+				for j := range table.Dim {
+					if table.Dim[j].Name == layout.Filter[k].Name {
+						dix = j
+						break
 					}
-				} else {
+				}
+				if dix < 0 {
+					return "", errors.New("output table " + table.Name + " does not have dimension " + layout.Filter[k].Name)
+				}
 
-					f, err = makeWhereFilter(
-						&layout.Filter[k], "", table.Acc[aix].Name, &modelDef.Type[iDbl], false, layout.Filter[k].Name, "output table "+table.Name)
-					if err != nil {
-						return nil, err
-					}
+				f, err = makeWhereFilter(
+					&layout.Filter[k], "", table.Dim[dix].colName, table.Dim[dix].typeOf, table.Dim[dix].IsTotal, table.Dim[dix].Name, "output table "+table.Name)
+				if err != nil {
+					return "", err
 				}
 			}
+
+			q += whereAnd + f
+			whereAnd = " AND "
 		}
-		if f == "" { // if not a filter by value then it must be filter by dimension
 
+		// append dimension enum id filters, if specified
+		for k := range layout.FilterById {
+
+			// find dimension index by name
 			dix := -1
 			for j := range table.Dim {
-				if table.Dim[j].Name == layout.Filter[k].Name {
+				if table.Dim[j].Name == layout.FilterById[k].Name {
 					dix = j
 					break
 				}
 			}
 			if dix < 0 {
-				return nil, errors.New("output table " + table.Name + " does not have dimension " + layout.Filter[k].Name)
+				return "", errors.New("output table " + table.Name + " does not have dimension " + layout.FilterById[k].Name)
 			}
 
-			f, err = makeWhereFilter(
-				&layout.Filter[k], "", table.Dim[dix].colName, table.Dim[dix].typeOf, table.Dim[dix].IsTotal, table.Dim[dix].Name, "output table "+table.Name)
+			f, err := makeWhereIdFilter(
+				&layout.FilterById[k], "", table.Dim[dix].colName, table.Dim[dix].typeOf, table.Dim[dix].Name, "output table "+table.Name)
 			if err != nil {
-				return nil, err
+				return "", err
 			}
-		}
-
-		q += " AND " + f
-	}
 
-	// append dimension enum id filters, if specified
-	for k := range layout.FilterById {
+			q += whereAnd + f
+			whereAnd = " AND "
+		}
 
-		// find dimension index by name
-		dix := -1
-		for j := range table.Dim {
-			if table.Dim[j].Name == layout.FilterById[k].Name {
-				dix = j
-				break
-			}
+		// if first cells sample requested then select first rows for each combination of id columns and sample dimensions
+		if layout.SampleSize > 0 && !layout.IsRandom {
+			q = sqlTableSample(table, &layout.ReadSampleLayout, sIdx, q, idCols, valCols)
 		}
-		if dix < 0 {
-			return nil, errors.New("output table " + table.Name + " does not have dimension " + layout.FilterById[k].Name)
+		if withPart != "" {
+			q = withPart + " " + q
 		}
 
-		f, err := makeWhereIdFilter(
-			&layout.FilterById[k], "", table.Dim[dix].colName, table.Dim[dix].typeOf, table.Dim[dix].Name, "output table "+table.Name)
-		if err != nil {
-			return nil, err
+		// append order by expr_id or acc_id, sub_id or sub_id
+		nExtraCol := 1
+		if layout.IsAccum && !layout.IsAllAccum {
+			nExtraCol = 2 // extra columns: acc_id, sub_id
 		}
+		q += makeOrderBy(table.Rank, layout.OrderBy, nExtraCol)
 
-		q += " AND " + f
+		return q, nil
 	}
 
-	// append order by expr_id or acc_id, sub_id or sub_id
-	nExtraCol := 1
-	if layout.IsAccum && !layout.IsAllAccum {
-		nExtraCol = 2 // extra columns: acc_id, sub_id
+	cacheKey := queryCacheKey(modelDef.Model.Digest, table.TableHid, layout)
+
+	q, stmt, err := cachedQueryOf(dbConn, cacheKey, strconv.Itoa(layout.FromId), makeSql)
+	if err != nil {
+		return nil, err
+	}
+	var args []interface{}
+	if stmt != nil {
+		args = []interface{}{layout.FromId}
 	}
-	q += makeOrderBy(table.Rank, layout.OrderBy, nExtraCol)
 
 	// prepare db-row conversion buffer:
 	// acc_id, sub_id, expr_id, dimensions, value or []values
@@ -345,7 +404,7 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 				ca.IsNull = !vf.Valid
 				ca.Value = 0.0
 				if !ca.IsNull {
-					ca.Value = vf.Float64
+					ca.Value = floatFromDb(vf.Float64)
 				}
 				return ca // return accumulator cell
 			}
@@ -363,7 +422,7 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 				cl.IsNull[k] = !fa[k].Valid
 				cl.Value[k] = 0.0
 				if !cl.IsNull[k] {
-					cl.Value[k] = fa[k].Float64
+					cl.Value[k] = floatFromDb(fa[k].Float64)
 				}
 			}
 			return cl // return all-accumulators cell
@@ -376,17 +435,50 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 		ce.IsNull = !vf.Valid
 		ce.Value = 0.0
 		if !ce.IsNull {
-			ce.Value = vf.Float64
+			ce.Value = floatFromDb(vf.Float64)
 		}
 		return ce // return table expression cell
 	}
 
+	// if random sample requested:
+	// select all rows into reservoir sample and write a page of sample cells into output stream
+	if layout.SampleSize > 0 && layout.IsRandom {
+
+		cr := newCellReservoir(layout.SampleSize)
+		var nRow int64
+
+		err = selectRowsStmtTo(dbConn, stmt, q, args,
+			func(rows *sql.Rows) (bool, error) {
+
+				if e := rows.Scan(scanBuf...); e != nil {
+					return false, e
+				}
+				nRow++
+
+				// sample key: expr_id or acc_id, sub_id or sub_id and sample by dimension items
+				key := strconv.Itoa(n1)
+				if layout.IsAccum && !layout.IsAllAccum {
+					key += "," + strconv.Itoa(n2)
+				}
+				for _, dix := range sIdx {
+					key += "," + strconv.Itoa(d[dix])
+				}
+				cr.add(key, nRow, makeCell)
+				return true, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		return writeSamplePageTo(cr.cells(), layout.ReadPageLayout, cvtTo)
+	}
+
 	// if full page requested:
 	// select rows into the list buffer and write rows from the list into output stream
 	if layout.IsFullPage {
 
 		// make a list of output cells
-		cLst, lt, e := SelectToList(dbConn, q, layout.ReadPageLayout,
+		cLst, lt, e := selectStmtToList(dbConn, stmt, q, args, layout.ReadPageLayout,
 			func(rows *sql.Rows) (interface{}, error) {
 
 				if e := rows.Scan(scanBuf...); e != nil {
@@ -430,13 +522,14 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 	// select cells:
 	// expr_id or or sub_id or acc_id and sub_id, dimension(s) enum ids
 	// value or all accumulator values and null status
-	err = SelectRowsTo(dbConn, q,
+	err = selectRowsStmtTo(dbConn, stmt, q, args,
 		func(rows *sql.Rows) (bool, error) {
 
 			// if page size is limited then select only a page of rows
+			// if total count requested then count all rows after the page
 			nRow++
 			if nSize > 0 && nRow > nStart+nSize {
-				return false, nil
+				return layout.isCountAll, nil
 			}
 			if nRow <= nStart {
 				return true, nil
@@ -460,6 +553,7 @@ func ReadOutputTableTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLay
 		lt.Offset = nRow
 	}
 	lt.IsLastPage = nSize <= 0 || nSize > 0 && nRow <= nStart+nSize
+	lt.rowCount = nRow
 
 	return &lt, nil
 }
@@ -474,7 +568,7 @@ func ReadOutputTableCalculteTo(
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return nil, errors.New("invalid (empty) output table read layout")
@@ -485,6 +579,9 @@ func ReadOutputTableCalculteTo(
 	if len(calcLt) <= 0 {
 		return nil, errors.New("invalid (empty) output table calculation expression(s): " + layout.Name)
 	}
+	if len(layout.Margin) > 0 {
+		return nil, errors.New("dimension total items computation is not supported for output table calculation: " + layout.Name)
+	}
 
 	// find output table id by name
 	var table *TableMeta
@@ -526,7 +623,7 @@ func ReadOutputTableCalculteTo(
 		c.IsNull = !vf.Valid
 		c.Value = 0.0
 		if !c.IsNull {
-			c.Value = vf.Float64
+			c.Value = floatFromDb(vf.Float64)
 		}
 		return c
 	}
@@ -584,9 +681,10 @@ func ReadOutputTableCalculteTo(
 		func(rows *sql.Rows) (bool, error) {
 
 			// if page size is limited then select only a page of rows
+			// if total count requested then count all rows after the page
 			nRow++
 			if nSize > 0 && nRow > nStart+nSize {
-				return false, nil
+				return layout.isCountAll, nil
 			}
 			if nRow <= nStart {
 				return true, nil
@@ -610,6 +708,7 @@ func ReadOutputTableCalculteTo(
 		lt.Offset = nRow
 	}
 	lt.IsLastPage = nSize <= 0 || nSize > 0 && nRow <= nStart+nSize
+	lt.rowCount = nRow
 
 	return &lt, nil
 }
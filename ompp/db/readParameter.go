@@ -14,7 +14,7 @@ func ReadParameterTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadParamLayou
 
 	// validate parameters
 	if modelDef == nil {
-		return nil, errors.New("invalid (empty) model metadata, look like model not found")
+		return nil, newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return nil, errors.New("invalid (empty) parameter read layout")
@@ -48,7 +48,7 @@ func ReadParameterTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadParamLayou
 			return nil, err
 		}
 		if setRow == nil {
-			return nil, errors.New("workset not found, id: " + strconv.Itoa(layout.FromId))
+			return nil, newDbError(ErrWorksetNotFound, "workset not found, id: "+strconv.Itoa(layout.FromId))
 		}
 
 		// workset readonly status must be compatible with (oposite to) "edit workset" status
@@ -93,7 +93,7 @@ func ReadParameterTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadParamLayou
 			return nil, err
 		}
 		if runRow == nil {
-			return nil, errors.New("model run not found, id: " + strconv.Itoa(srcRunId))
+			return nil, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(srcRunId))
 		}
 		if !IsRunCompleted(runRow.Status) && runRow.Status != ProgressRunStatus {
 			return nil, errors.New("model run not completed, id: " + strconv.Itoa(srcRunId))
@@ -261,9 +261,10 @@ func ReadParameterTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadParamLayou
 		func(rows *sql.Rows) (bool, error) {
 
 			// if page size is limited then select only a page of rows
+			// if total count requested then count all rows after the page
 			nRow++
 			if nSize > 0 && nRow > nStart+nSize {
-				return false, nil
+				return layout.isCountAll, nil
 			}
 			if nRow <= nStart {
 				return true, nil
@@ -293,6 +294,7 @@ func ReadParameterTo(dbConn *sql.DB, modelDef *ModelMeta, layout *ReadParamLayou
 		lt.Offset = nRow
 	}
 	lt.IsLastPage = nSize <= 0 || nSize > 0 && nRow <= nStart+nSize
+	lt.rowCount = nRow
 
 	return &lt, nil
 }
@@ -402,7 +404,7 @@ func scanSqlRowToCellParam(param *ParamMeta) ([]interface{}, func(*CellParam) er
 			c.IsNull = !vf.Valid
 			c.Value = 0.0
 			if !c.IsNull {
-				c.Value = vf.Float64
+				c.Value = floatFromDb(vf.Float64)
 			}
 			return nil
 		}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// make sql to select output table values and dimension total items (margins) computed from other dimension items.
+//
+// Source sql is a select of id columns, dimensions and value columns without dimension filters:
+//
+//	SELECT expr_id, dim0, dim1, expr_value FROM salarySex_v2012_820 WHERE run_id = (...)
+//
+// If margin dimensions are dim0 and dim1, total item of dim0 is 800 and dim1 is 400 then result is:
+//
+//	WITH mt_base AS
+//	(
+//	  SELECT expr_id, dim0, dim1, expr_value FROM salarySex_v2012_820 WHERE run_id = (...)
+//	  AND dim0 <> 800 AND dim1 <> 400
+//	),
+//	mt_all AS
+//	(
+//	  SELECT expr_id, dim0, dim1, expr_value FROM mt_base
+//	  UNION ALL
+//	  SELECT expr_id, 800, dim1, SUM(expr_value) FROM mt_base GROUP BY expr_id, dim1
+//	  UNION ALL
+//	  SELECT expr_id, dim0, 400, SUM(expr_value) FROM mt_base GROUP BY expr_id, dim0
+//	  UNION ALL
+//	  SELECT expr_id, 800, 400, SUM(expr_value) FROM mt_base GROUP BY expr_id
+//	)
+//	SELECT expr_id, dim0, dim1, expr_value FROM mt_all
+//
+// If withSql is not empty then it is a WITH part of source sql, for example: all accumulators view.
+func sqlTableMargin(table *TableMeta, layout *ReadMarginLayout, withSql, srcSql string, idCols, valCols []string) (string, error) {
+
+	if table == nil {
+		return "", errors.New("invalid (empty) output table metadata")
+	}
+	if layout == nil || len(layout.Margin) <= 0 {
+		return "", errors.New("invalid (empty) list of margin dimensions, output table: " + table.Name)
+	}
+
+	aggr := "SUM"
+	switch strings.ToLower(layout.MarginAggr) {
+	case "", "sum":
+	case "avg":
+		aggr = "AVG"
+	default:
+		return "", errors.New("invalid margin aggregation: " + layout.MarginAggr + ", expected: sum or avg, output table: " + table.Name)
+	}
+
+	// find margin dimensions, each dimension must have total item
+	mIdx := []int{}
+	for _, name := range layout.Margin {
+
+		dix := -1
+		for j := range table.Dim {
+			if table.Dim[j].Name == name {
+				dix = j
+				break
+			}
+		}
+		if dix < 0 {
+			return "", errors.New("output table " + table.Name + " does not have dimension " + name)
+		}
+		if !table.Dim[dix].IsTotal || table.Dim[dix].typeOf == nil {
+			return "", errors.New("output table " + table.Name + " dimension does not have total item: " + name)
+		}
+		for _, k := range mIdx {
+			if k == dix {
+				return "", errors.New("output table " + table.Name + " margin dimension is not unique: " + name)
+			}
+		}
+		mIdx = append(mIdx, dix)
+	}
+	if len(mIdx) > 8 {
+		return "", errors.New("too many margin dimensions: " + strconv.Itoa(len(mIdx)) + ", output table: " + table.Name)
+	}
+
+	// all columns: id columns, dimensions, value columns
+	cols := append([]string{}, idCols...)
+	for k := range table.Dim {
+		cols = append(cols, table.Dim[k].colName)
+	}
+	cols = append(cols, valCols...)
+	colLst := strings.Join(cols, ", ")
+
+	// base values: exclude stored total items of margin dimensions
+	q := withSql
+	if q != "" {
+		q += ", mt_base AS (" + srcSql
+	} else {
+		q = "WITH mt_base AS (" + srcSql
+	}
+	for _, dix := range mIdx {
+		q += " AND " + table.Dim[dix].colName + " <> " + strconv.Itoa(table.Dim[dix].typeOf.TotalEnumId)
+	}
+	q += "), mt_all AS (SELECT " + colLst + " FROM mt_base"
+
+	// for each combination of margin dimensions select total items:
+	// use total enum id as margin dimension value and group by other dimensions
+	for nMask := 1; nMask < 1<<len(mIdx); nMask++ {
+
+		isTotal := make([]bool, table.Rank)
+		for j, dix := range mIdx {
+			isTotal[dix] = nMask&(1<<j) != 0
+		}
+
+		sel := append([]string{}, idCols...)
+		grp := append([]string{}, idCols...)
+
+		for k := range table.Dim {
+			if isTotal[k] {
+				sel = append(sel, strconv.Itoa(table.Dim[k].typeOf.TotalEnumId)+" AS "+table.Dim[k].colName)
+			} else {
+				sel = append(sel, table.Dim[k].colName)
+				grp = append(grp, table.Dim[k].colName)
+			}
+		}
+		for _, vc := range valCols {
+			sel = append(sel, aggr+"("+vc+") AS "+vc)
+		}
+
+		q += " UNION ALL SELECT " + strings.Join(sel, ", ") + " FROM mt_base GROUP BY " + strings.Join(grp, ", ")
+	}
+
+	q += ") SELECT " + colLst + " FROM mt_all"
+
+	return q, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestTableMargin(t *testing.T) {
+
+	// expression table with two dimensions: dim0 total item is 2, dim1 total item is 3
+	// stored total item row (2, 0) must be ignored
+	dbConn := openTestDb(t,
+		"CREATE TABLE t_v (run_id INT, expr_id INT, dim0 INT, dim1 INT, expr_value FLOAT)",
+		"INSERT INTO t_v (run_id, expr_id, dim0, dim1, expr_value) VALUES (1, 0, 0, 0, 1), (1, 0, 0, 1, 2), (1, 0, 1, 0, 3), (1, 0, 1, 1, 4), (1, 0, 2, 0, 100)",
+	)
+
+	table := TableMeta{
+		TableDicRow: TableDicRow{Name: "T", Rank: 2},
+		Dim: []TableDimsRow{
+			{Name: "D0", IsTotal: true, typeOf: &TypeMeta{TypeDicRow: TypeDicRow{TotalEnumId: 2}}, colName: "dim0"},
+			{Name: "D1", IsTotal: true, typeOf: &TypeMeta{TypeDicRow: TypeDicRow{TotalEnumId: 3}}, colName: "dim1"},
+		},
+	}
+	src := "SELECT expr_id, dim0, dim1, expr_value FROM t_v WHERE run_id = 1"
+	idCols := []string{"expr_id"}
+	valCols := []string{"expr_value"}
+
+	for _, tc := range []struct {
+		margin []string
+		aggr   string
+		want   map[[2]int]float64
+	}{
+		{
+			margin: []string{"D0"},
+			want:   map[[2]int]float64{{0, 0}: 1, {0, 1}: 2, {1, 0}: 3, {1, 1}: 4, {2, 0}: 4, {2, 1}: 6},
+		},
+		{
+			margin: []string{"D0", "D1"},
+			want: map[[2]int]float64{
+				{0, 0}: 1, {0, 1}: 2, {1, 0}: 3, {1, 1}: 4,
+				{2, 0}: 4, {2, 1}: 6, {0, 3}: 3, {1, 3}: 7, {2, 3}: 10,
+			},
+		},
+		{
+			margin: []string{"D1"},
+			aggr:   "avg",
+			want:   map[[2]int]float64{{0, 0}: 1, {0, 1}: 2, {1, 0}: 3, {1, 1}: 4, {0, 3}: 1.5, {1, 3}: 3.5, {2, 0}: 100, {2, 3}: 100},
+		},
+	} {
+		q, err := sqlTableMargin(&table, &ReadMarginLayout{Margin: tc.margin, MarginAggr: tc.aggr}, "", src, idCols, valCols)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := map[[2]int]float64{}
+		err = SelectRows(dbConn, q+" ORDER BY 1, 2, 3", func(rows *sql.Rows) error {
+			var eId, d0, d1 int
+			var v float64
+			if err := rows.Scan(&eId, &d0, &d1, &v); err != nil {
+				return err
+			}
+			got[[2]int{d0, d1}] = v
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != len(tc.want) {
+			t.Errorf("invalid number of rows: %d, expected: %d, margin: %v", len(got), len(tc.want), tc.margin)
+		}
+		for k, v := range tc.want {
+			if g, ok := got[k]; !ok || g != v {
+				t.Errorf("invalid value at %v: %v, expected: %v, margin: %v", k, g, v, tc.margin)
+			}
+		}
+	}
+
+	// invalid margin layouts
+	for _, ml := range []ReadMarginLayout{
+		{Margin: []string{"D2"}},
+		{Margin: []string{"D0", "D0"}},
+		{Margin: []string{"D0"}, MarginAggr: "max"},
+	} {
+		if _, err := sqlTableMargin(&table, &ml, "", src, idCols, valCols); err == nil {
+			t.Errorf("expected error for margin: %v aggregation: %s", ml.Margin, ml.MarginAggr)
+		}
+	}
+
+	table.Dim[1].IsTotal = false
+	if _, err := sqlTableMargin(&table, &ReadMarginLayout{Margin: []string{"D1"}}, "", src, idCols, valCols); err == nil {
+		t.Errorf("expected error for dimension without total item")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+)
+
+// ReadOutputTablePartsTo read output table values from model run results by parallel partitioned scan and process each row by cvtTo().
+//
+// Table scan is split into partitions by expression or accumulator id, by sub-value id and by ranges of first dimension items.
+// Partitions are read concurrently by up to threadCount workers, each worker is using its own connection from database pool
+// and keeps rows of the partition in memory, so memory usage is bounded by size of threadCount partitions.
+// Rows are passed to cvtTo() in the same order as ReadOutputTableTo() does: by expression or accumulator, sub-value id and dimensions.
+// If threadCount <= 1 or if layout is a page of rows, a sample, margins or custom order by then ReadOutputTableTo() is used.
+func ReadOutputTablePartsTo(
+	dbConn *sql.DB, modelDef *ModelMeta, layout *ReadTableLayout, threadCount int, cvtTo func(src interface{}) (bool, error),
+) (*ReadPageLayout, error) {
+
+	if threadCount <= 1 || modelDef == nil || layout == nil ||
+		layout.Offset > 0 || layout.Size > 0 || layout.IsFullPage ||
+		len(layout.OrderBy) > 0 || len(layout.Margin) > 0 || layout.SampleSize > 0 {
+		return ReadOutputTableTo(dbConn, modelDef, layout, cvtTo)
+	}
+
+	// find output table by name, number of sub-values is required to split accumulators by sub-value id
+	k, ok := modelDef.OutTableByName(layout.Name)
+	if !ok {
+		return nil, errors.New("output table not found: " + layout.Name)
+	}
+	table := &modelDef.Table[k]
+
+	runRow, err := GetRun(dbConn, layout.FromId)
+	if err != nil {
+		return nil, err
+	}
+	if runRow == nil {
+		return nil, newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(layout.FromId))
+	}
+
+	parts := tablePartitions(table, layout, runRow.SubCount, threadCount)
+	if len(parts) <= 1 {
+		return ReadOutputTableTo(dbConn, modelDef, layout, cvtTo)
+	}
+
+	// start partition workers: run up to threadCount workers, next worker started when rows of previous partition received
+	// each worker select partition rows into the list, stop reading if done channel closed
+	type partRows struct {
+		cells []interface{}
+		err   error
+	}
+	res := make([]chan partRows, len(parts))
+	for k := range res {
+		res[k] = make(chan partRows, 1)
+	}
+	sem := make(chan struct{}, threadCount)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for k := range parts {
+			select {
+			case sem <- struct{}{}:
+			case <-done:
+				return
+			}
+			go func(k int) {
+				cells := []interface{}{}
+
+				_, e := ReadOutputTableTo(dbConn, modelDef, &parts[k], func(src interface{}) (bool, error) {
+					select {
+					case <-done:
+						return false, nil // stop: rows are not needed anymore
+					default:
+					}
+					cells = append(cells, src)
+					return true, nil
+				})
+				res[k] <- partRows{cells: cells, err: e}
+			}(k)
+		}
+	}()
+
+	// pass rows to cvtTo() in the order of partitions
+	lt := ReadPageLayout{IsLastPage: true}
+
+	for k := range parts {
+
+		pr := <-res[k]
+		<-sem
+
+		if pr.err != nil {
+			return nil, pr.err
+		}
+		for _, c := range pr.cells {
+
+			isNext, e := cvtTo(c)
+			if e != nil {
+				return nil, e
+			}
+			if !isNext {
+				return &lt, nil
+			}
+			lt.Size++
+		}
+	}
+	lt.rowCount = lt.Size
+
+	return &lt, nil
+}
+
+// tablePartitions return read layouts of output table partitions in the order of output table rows.
+//
+// Table is split by expression or accumulator and by sub-value id of accumulators, same as ORDER BY of ReadOutputTableTo().
+// If there are less of such partitions than 2 * threadCount then each of it is split by ranges of first dimension items:
+// first range is dim0 <= last id of the range, last range is dim0 >= first id of the range and BETWEEN for other ranges,
+// it is all rows included, even if dimension items in database are different from model metadata.
+func tablePartitions(table *TableMeta, layout *ReadTableLayout, subCount int, threadCount int) []ReadTableLayout {
+
+	// expressions or accumulators in order of id, if value name is not specified
+	type idName struct {
+		id   int
+		name string
+	}
+	vals := []idName{{name: layout.ValueName}}
+
+	if layout.ValueName == "" && !layout.IsAllAccum {
+		vals = []idName{}
+
+		if !layout.IsAccum {
+			for k := range table.Expr {
+				vals = append(vals, idName{id: table.Expr[k].ExprId, name: table.Expr[k].Name})
+			}
+		} else {
+			for k := range table.Acc {
+				if !table.Acc[k].IsDerived {
+					vals = append(vals, idName{id: table.Acc[k].AccId, name: table.Acc[k].Name})
+				}
+			}
+		}
+		slices.SortFunc(vals, func(a, b idName) int { return a.id - b.id })
+	}
+
+	// sub-value id's of accumulators, if sub-value id is not specified
+	subs := []int{-1}
+	if layout.IsAccum && !layout.IsSubId && subCount > 1 {
+		subs = make([]int, subCount)
+		for k := range subs {
+			subs[k] = k
+		}
+	}
+
+	// ranges of first dimension items
+	nVs := len(vals) * len(subs)
+	rng := [][2]int{}
+
+	if table.Rank > 0 && nVs > 0 && nVs < 2*threadCount {
+
+		ids := dimEnumIds(&table.Dim[0])
+		nr := min((4*threadCount+nVs-1)/nVs, len(ids))
+
+		for k := 0; k < nr; k++ {
+			rng = append(rng, [2]int{ids[k*len(ids)/nr], ids[(k+1)*len(ids)/nr-1]})
+		}
+	}
+	if len(rng) <= 1 {
+		rng = [][2]int{{}}
+	}
+
+	// make partitions layout: copy source layout and add expression or accumulator name, sub-value id and dimension range filter
+	parts := []ReadTableLayout{}
+
+	for _, v := range vals {
+		for _, sId := range subs {
+			for k, r := range rng {
+
+				p := *layout
+				p.ValueName = v.name
+				if sId >= 0 {
+					p.IsSubId = true
+					p.SubId = sId
+				}
+
+				// copy filters: enum id's are sorted in place by where filter
+				p.FilterById = make([]FilterIdColumn, len(layout.FilterById), len(layout.FilterById)+1)
+				for j := range layout.FilterById {
+					p.FilterById[j] = layout.FilterById[j]
+					p.FilterById[j].EnumIds = slices.Clone(layout.FilterById[j].EnumIds)
+				}
+
+				if len(rng) > 1 {
+					f := FilterIdColumn{Name: table.Dim[0].Name, Op: BetweenOpFilter, EnumIds: []int{r[0], r[1]}}
+					switch k {
+					case 0:
+						f.Op = LeOpFilter
+						f.EnumIds = []int{r[1]}
+					case len(rng) - 1:
+						f.Op = GeOpFilter
+						f.EnumIds = []int{r[0]}
+					}
+					p.FilterById = append(p.FilterById, f)
+				}
+				parts = append(parts, p)
+			}
+		}
+	}
+	return parts
+}
+
+// return sorted enum id's of table dimension, including total item id if total enabled
+func dimEnumIds(dim *TableDimsRow) []int {
+
+	ids := []int{}
+	if dim.typeOf != nil {
+		if dim.typeOf.IsRange {
+			for e := dim.typeOf.MinEnumId; e <= dim.typeOf.MaxEnumId; e++ {
+				ids = append(ids, e)
+			}
+		} else {
+			for k := range dim.typeOf.Enum {
+				ids = append(ids, dim.typeOf.Enum[k].EnumId)
+			}
+		}
+		if dim.IsTotal {
+			ids = append(ids, dim.typeOf.TotalEnumId)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
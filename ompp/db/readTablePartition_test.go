@@ -0,0 +1,112 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestReadOutputTableParts(t *testing.T) {
+
+	// completed run id 2 with 2 sub-values use output table values of base run id 1
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageSex_v12345678 (run_id INT, expr_id INT, dim0 INT, dim1 INT, expr_value FLOAT)",
+		"CREATE TABLE ageSex_a12345678 (run_id INT, acc_id INT, sub_id INT, dim0 INT, dim1 INT, acc_value FLOAT)",
+		testRunSql(1, 1, 2, "s"),
+		testRunSql(2, 1, 2, "s"),
+		"INSERT INTO run_table (run_id, table_hid, base_run_id) VALUES (1, 12, 1), (2, 12, 1)",
+	)
+
+	// insert rows in descending order: age 0 to 9 and total 10, sex 0 and 1
+	for age := 10; age >= 0; age-- {
+		for sex := 1; sex >= 0; sex-- {
+			for id := 1; id >= 0; id-- {
+				v := strconv.Itoa(100*id + 10*age + sex)
+				testUpdate(t, dbConn, "INSERT INTO ageSex_v12345678 VALUES (1, "+strconv.Itoa(id)+", "+strconv.Itoa(age)+", "+strconv.Itoa(sex)+", "+v+")")
+				for sub := 1; sub >= 0; sub-- {
+					testUpdate(t, dbConn,
+						"INSERT INTO ageSex_a12345678 VALUES (1, "+strconv.Itoa(id)+", "+strconv.Itoa(sub)+", "+strconv.Itoa(age)+", "+strconv.Itoa(sex)+", "+v+")")
+				}
+			}
+		}
+	}
+
+	ageType := &TypeMeta{TypeDicRow: TypeDicRow{Name: "age", IsRange: true, MinEnumId: 0, MaxEnumId: 9, TotalEnumId: 10, sizeOf: 10}}
+	sexType := &TypeMeta{TypeDicRow: TypeDicRow{Name: "sex", TotalEnumId: 2, sizeOf: 2}, Enum: []TypeEnumRow{{EnumId: 0}, {EnumId: 1}}}
+
+	modelDef := &ModelMeta{
+		Model: ModelDicRow{ModelId: 1, Digest: "m1"},
+		Type:  []TypeMeta{{TypeDicRow: TypeDicRow{Name: "double", Digest: "_double_"}}},
+		Table: []TableMeta{{
+			TableDicRow: TableDicRow{TableHid: 12, Name: "ageSex", Rank: 2, DbExprTable: "ageSex_v12345678", DbAccTable: "ageSex_a12345678"},
+			Dim: []TableDimsRow{
+				{DimId: 0, Name: "dim0", IsTotal: true, typeOf: ageType, colName: "dim0"},
+				{DimId: 1, Name: "dim1", typeOf: sexType, colName: "dim1"},
+			},
+			Acc: []TableAccRow{
+				{AccId: 1, Name: "acc1", colName: "acc1"},
+				{AccId: 0, Name: "acc0", colName: "acc0"},
+				{AccId: 2, Name: "acc2", IsDerived: true, colName: "acc2"},
+			},
+			Expr: []TableExprRow{{ExprId: 1, Name: "expr1"}, {ExprId: 0, Name: "expr0"}},
+		}},
+	}
+
+	// read output table rows and return it as list of strings
+	readRows := func(layout *ReadTableLayout, threadCount int, maxRows int) []string {
+
+		rows := []string{}
+		_, err := ReadOutputTablePartsTo(dbConn, modelDef, layout, threadCount, func(src interface{}) (bool, error) {
+			rows = append(rows, fmt.Sprint(src))
+			return maxRows <= 0 || len(rows) < maxRows, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	for _, lt := range []ReadTableLayout{
+		{ReadLayout: ReadLayout{Name: "ageSex", FromId: 2}},
+		{ReadLayout: ReadLayout{Name: "ageSex", FromId: 2}, ValueName: "expr1"},
+		{ReadLayout: ReadLayout{Name: "ageSex", FromId: 2}, IsAccum: true},
+		{ReadLayout: ReadLayout{Name: "ageSex", FromId: 2}, IsAccum: true, ReadSubIdLayout: ReadSubIdLayout{IsSubId: true, SubId: 1}},
+		{ReadLayout: ReadLayout{Name: "ageSex", FromId: 2, FilterById: []FilterIdColumn{{Name: "dim1", Op: InOpFilter, EnumIds: []int{1}}}}},
+	} {
+		src := readRows(&lt, 1, 0)
+		if len(src) == 0 {
+			t.Fatal("Fail: empty output table rows:", lt)
+		}
+
+		for _, n := range []int{2, 3, 8} {
+
+			rows := readRows(&lt, n, 0)
+			if fmt.Sprint(rows) != fmt.Sprint(src) {
+				t.Errorf("Fail: partitioned read threads: %d rows: %d expected: %d layout: %v", n, len(rows), len(src), lt)
+			}
+		}
+
+		// stop reading after 5 rows
+		if rows := readRows(&lt, 4, 5); fmt.Sprint(rows) != fmt.Sprint(src[:5]) {
+			t.Errorf("Fail: partitioned read first rows: %v expected: %v", rows, src[:5])
+		}
+	}
+
+	// partitions of expressions by first dimension ranges: all rows included by first and last range
+	parts := tablePartitions(&modelDef.Table[0], &ReadTableLayout{ReadLayout: ReadLayout{Name: "ageSex", FromId: 2}}, 2, 3)
+	if len(parts) != 12 {
+		t.Fatal("Fail: invalid number of partitions:", len(parts))
+	}
+	if p := parts[0]; p.ValueName != "expr0" || len(p.FilterById) != 1 || p.FilterById[0].Op != LeOpFilter || p.FilterById[0].EnumIds[0] != 0 {
+		t.Error("Fail: invalid first partition:", p)
+	}
+	if p := parts[5]; p.ValueName != "expr0" || len(p.FilterById) != 1 || p.FilterById[0].Op != GeOpFilter || p.FilterById[0].EnumIds[0] != 9 {
+		t.Error("Fail: invalid last partition of expression:", p)
+	}
+	if p := parts[6]; p.ValueName != "expr1" {
+		t.Error("Fail: invalid partition of second expression:", p)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// return indices of sample by dimensions of output table or error if dimension not found or not unique
+func sampleByIndex(table *TableMeta, layout *ReadSampleLayout) ([]int, error) {
+
+	if table == nil {
+		return nil, errors.New("invalid (empty) output table metadata")
+	}
+	if layout.SampleSize <= 0 {
+		return []int{}, nil // sample is not requested
+	}
+
+	sIdx := make([]int, 0, len(layout.SampleBy))
+
+	for _, name := range layout.SampleBy {
+
+		dix := -1
+		for k := range table.Dim {
+			if table.Dim[k].Name == name {
+				dix = k
+				break
+			}
+		}
+		if dix < 0 {
+			return nil, errors.New("output table " + table.Name + " does not have dimension " + name)
+		}
+		if slices.Contains(sIdx, dix) {
+			return nil, errors.New("output table " + table.Name + " sample dimension is not unique: " + name)
+		}
+		sIdx = append(sIdx, dix)
+	}
+	return sIdx, nil
+}
+
+// make sql to select first cells of output table for each combination of id columns and sample by dimensions.
+//
+// Source sql is a select of id columns, dimensions and value columns, it can include dimension filters but no order by.
+// If sample size is 100 and sample by dimension is dim0 then result is:
+//
+//	SELECT expr_id, dim0, dim1, expr_value
+//	FROM
+//	(
+//	  SELECT
+//	    S.expr_id, S.dim0, S.dim1, S.expr_value,
+//	    ROW_NUMBER() OVER (PARTITION BY S.expr_id, S.dim0 ORDER BY S.dim0, S.dim1) AS smp_rn
+//	  FROM (SELECT expr_id, dim0, dim1, expr_value FROM salarySex_v2012_820 WHERE run_id = (...)) S
+//	) SR
+//	WHERE SR.smp_rn <= 100
+//
+// Source sql must not have WITH part, it must be prepended to the result sql.
+func sqlTableSample(table *TableMeta, layout *ReadSampleLayout, sIdx []int, srcSql string, idCols, valCols []string) string {
+
+	cols := append([]string{}, idCols...)
+	dimCols := make([]string, table.Rank)
+	for k := range table.Dim {
+		cols = append(cols, table.Dim[k].colName)
+		dimCols[k] = "S." + table.Dim[k].colName
+	}
+	cols = append(cols, valCols...)
+
+	part := make([]string, 0, len(idCols)+len(sIdx))
+	for _, c := range idCols {
+		part = append(part, "S."+c)
+	}
+	for _, dix := range sIdx {
+		part = append(part, "S."+table.Dim[dix].colName)
+	}
+
+	srcCols := make([]string, len(cols))
+	for k := range cols {
+		srcCols[k] = "S." + cols[k]
+	}
+
+	q := "SELECT " + strings.Join(cols, ", ") +
+		" FROM (SELECT " + strings.Join(srcCols, ", ") + ", ROW_NUMBER() OVER ("
+
+	if len(part) > 0 {
+		q += "PARTITION BY " + strings.Join(part, ", ")
+		if len(dimCols) > 0 {
+			q += " "
+		}
+	}
+	if len(dimCols) > 0 {
+		q += "ORDER BY " + strings.Join(dimCols, ", ")
+	}
+	q += ") AS smp_rn FROM (" + srcSql + ") S) SR" +
+		" WHERE SR.smp_rn <= " + strconv.Itoa(layout.SampleSize)
+
+	return q
+}
+
+// cellReservoir is a random (reservoir) sample of cells for each combination of sample key values
+type cellReservoir struct {
+	size  int                        // max number of cells in each sample
+	group map[string]*reservoirGroup // sample cells for each key
+}
+
+// reservoirGroup is a random sample of cells with the same sample key
+type reservoirGroup struct {
+	count int64          // number of cells offered to the sample
+	cells []sampleCellAt // sample of cells
+}
+
+// sample cell and row number of the cell in source rows
+type sampleCellAt struct {
+	nRow int64       // row number in source rows
+	cell interface{} // sample cell
+}
+
+// return new random sample to select max size cells for each sample key
+func newCellReservoir(size int) *cellReservoir {
+	return &cellReservoir{size: size, group: map[string]*reservoirGroup{}}
+}
+
+// add cell into sample of the key: if sample is not full then append cell else replace random sample cell.
+// Cell make function called only if cell is included in the sample.
+func (cr *cellReservoir) add(key string, nRow int64, makeCell func() interface{}) {
+
+	g, ok := cr.group[key]
+	if !ok {
+		g = &reservoirGroup{cells: make([]sampleCellAt, 0, cr.size)}
+		cr.group[key] = g
+	}
+	g.count++
+
+	if len(g.cells) < cr.size {
+		g.cells = append(g.cells, sampleCellAt{nRow: nRow, cell: makeCell()})
+		return
+	}
+	if j := rand.Int64N(g.count); j < int64(cr.size) {
+		g.cells[j] = sampleCellAt{nRow: nRow, cell: makeCell()}
+	}
+}
+
+// return sample cells of all keys in the order of source rows
+func (cr *cellReservoir) cells() []interface{} {
+
+	all := []sampleCellAt{}
+	for _, g := range cr.group {
+		all = append(all, g.cells...)
+	}
+	slices.SortFunc(all, func(a, b sampleCellAt) int {
+		switch {
+		case a.nRow < b.nRow:
+			return -1
+		case a.nRow > b.nRow:
+			return 1
+		}
+		return 0
+	})
+
+	cLst := make([]interface{}, len(all))
+	for k := range all {
+		cLst[k] = all[k].cell
+	}
+	return cLst
+}
+
+// write a page of sample cells into output stream by cvtTo(), page offset and size applied to the list of sample cells.
+// If full page requested and offset is beyond the last full page then offset adjusted to return full last page.
+func writeSamplePageTo(cLst []interface{}, layout ReadPageLayout, cvtTo func(src interface{}) (bool, error)) (*ReadPageLayout, error) {
+
+	n := int64(len(cLst))
+
+	// adjust page layout: starting offset and page size
+	nStart := max(layout.Offset, 0)
+	nSize := max(layout.Size, 0)
+
+	if layout.IsFullPage && nSize > 0 && nStart+nSize > n {
+		nStart = max(n-nSize, 0)
+	}
+	nStart = min(nStart, n)
+
+	nEnd := n
+	if nSize > 0 && nStart+nSize < n {
+		nEnd = nStart + nSize
+	}
+
+	lt := ReadPageLayout{
+		Offset:     nStart,
+		IsLastPage: nEnd >= n,
+		rowCount:   n,
+	}
+	for k := nStart; k < nEnd; k++ {
+
+		lt.Size++
+		isNext, err := cvtTo(cLst[k])
+		if err != nil {
+			return nil, err
+		}
+		if !isNext {
+			break
+		}
+	}
+	return &lt, nil
+}
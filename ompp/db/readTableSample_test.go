@@ -0,0 +1,118 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+)
+
+func TestTableSample(t *testing.T) {
+
+	// expression table with two expressions and two dimensions: dim0 has 2 items, dim1 has 5 items
+	dbConn := openTestDb(t, "CREATE TABLE t_v (run_id INT, expr_id INT, dim0 INT, dim1 INT, expr_value FLOAT)")
+
+	for e := 0; e < 2; e++ {
+		for d0 := 0; d0 < 2; d0++ {
+			for d1 := 0; d1 < 5; d1++ {
+				v := "(1, " + strconv.Itoa(e) + ", " + strconv.Itoa(d0) + ", " + strconv.Itoa(d1) + ", " + strconv.Itoa(100*e+10*d0+d1) + ")"
+				testUpdate(t, dbConn, "INSERT INTO t_v (run_id, expr_id, dim0, dim1, expr_value) VALUES "+v)
+			}
+		}
+	}
+
+	table := TableMeta{
+		TableDicRow: TableDicRow{Name: "T", Rank: 2},
+		Dim: []TableDimsRow{
+			{Name: "D0", colName: "dim0"},
+			{Name: "D1", colName: "dim1"},
+		},
+	}
+	src := "SELECT expr_id, dim0, dim1, expr_value FROM t_v WHERE run_id = 1 AND dim1 <> 0"
+	idCols := []string{"expr_id"}
+	valCols := []string{"expr_value"}
+
+	for _, tc := range []struct {
+		sample ReadSampleLayout
+		want   [][3]int // expr_id, dim0, dim1
+	}{
+		{
+			sample: ReadSampleLayout{SampleSize: 2},
+			want:   [][3]int{{0, 0, 1}, {0, 0, 2}, {1, 0, 1}, {1, 0, 2}},
+		},
+		{
+			sample: ReadSampleLayout{SampleSize: 1, SampleBy: []string{"D0"}},
+			want:   [][3]int{{0, 0, 1}, {0, 1, 1}, {1, 0, 1}, {1, 1, 1}},
+		},
+	} {
+		sIdx, err := sampleByIndex(&table, &tc.sample)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := sqlTableSample(&table, &tc.sample, sIdx, src, idCols, valCols)
+
+		got := [][3]int{}
+		err = SelectRows(dbConn, q+" ORDER BY 1, 2, 3", func(rows *sql.Rows) error {
+			var c [3]int
+			var v float64
+			if err := rows.Scan(&c[0], &c[1], &c[2], &v); err != nil {
+				return err
+			}
+			got = append(got, c)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != len(tc.want) {
+			t.Fatalf("invalid number of rows: %d, expected: %d, sample: %v", len(got), len(tc.want), tc.sample)
+		}
+		for k := range tc.want {
+			if got[k] != tc.want[k] {
+				t.Errorf("invalid row %d: %v, expected: %v, sample: %v", k, got[k], tc.want[k], tc.sample)
+			}
+		}
+	}
+
+	// invalid sample layouts
+	for _, sl := range []ReadSampleLayout{
+		{SampleSize: 10, SampleBy: []string{"D2"}},
+		{SampleSize: 10, SampleBy: []string{"D0", "D0"}},
+	} {
+		if _, err := sampleByIndex(&table, &sl); err == nil {
+			t.Errorf("expected error for sample by: %v", sl.SampleBy)
+		}
+	}
+
+	// random sample: max 3 cells for each key, cells in the order of source rows
+	cr := newCellReservoir(3)
+	for k := 0; k < 100; k++ {
+		n := k
+		cr.add(strconv.Itoa(k%2), int64(k), func() interface{} { return n })
+	}
+	cLst := cr.cells()
+	if len(cLst) != 6 {
+		t.Fatalf("invalid random sample size: %d, expected: 6", len(cLst))
+	}
+	for k := 1; k < len(cLst); k++ {
+		if cLst[k-1].(int) >= cLst[k].(int) {
+			t.Errorf("invalid random sample order: %v", cLst)
+		}
+	}
+
+	// page of sample cells
+	nLst := []int{}
+	lt, err := writeSamplePageTo(cLst, ReadPageLayout{Offset: 4, Size: 4}, func(src interface{}) (bool, error) {
+		nLst = append(nLst, src.(int))
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nLst) != 2 || lt.Size != 2 || lt.Offset != 4 || !lt.IsLastPage {
+		t.Errorf("invalid sample page: %v layout: %+v", nLst, *lt)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ValuePageLayout describes page of values and total number of rows selected by filters.
+type ValuePageLayout struct {
+	ReadPageLayout       // page first row offset, page size and last page flag
+	TotalCount     int64 // total number of rows selected by filters, if IsTotalCount is true
+	IsTotalCount   bool  // if true then total number of rows counted, it is false if rows reading stopped before the end
+	PageIndex      int64 // zero-based page index: page offset divided by requested page size, zero if page size <= 0
+	PageCount      int64 // number of pages of requested size, zero if total count unknown or page size <= 0
+}
+
+// ReadValuePage read page of parameter, output table or microdata values and process each row by cvtTo().
+// It also return total number of rows selected by filters.
+//
+// Layout must be one of: *ReadParamLayout, *ReadTableLayout or *ReadMicroLayout,
+// see ReadParameterTo(), ReadOutputTableTo() and ReadMicrodataTo() for details.
+// Total count of rows obtained from the same query as page of rows, without additional SELECT COUNT(*) query.
+// If IsFullPage is true then page offset adjusted to return full last page.
+func ReadValuePage(dbConn *sql.DB, modelDef *ModelMeta, layout interface{}, cvtTo func(src interface{}) (bool, error)) (*ValuePageLayout, error) {
+
+	// if cvtTo() stop reading then total count is unknown
+	isStop := false
+	cvt := func(src interface{}) (bool, error) {
+		isNext, err := cvtTo(src)
+		if !isNext {
+			isStop = true
+		}
+		return isNext, err
+	}
+
+	var lt *ReadPageLayout
+	var nSize int64
+	var err error
+
+	switch rl := layout.(type) {
+	case *ReadParamLayout:
+		if rl == nil {
+			return nil, errors.New("invalid (empty) parameter read layout")
+		}
+		nSize = rl.Size
+		rl.isCountAll = true
+		lt, err = ReadParameterTo(dbConn, modelDef, rl, cvt)
+	case *ReadTableLayout:
+		if rl == nil {
+			return nil, errors.New("invalid (empty) output table read layout")
+		}
+		nSize = rl.Size
+		rl.isCountAll = true
+		lt, err = ReadOutputTableTo(dbConn, modelDef, rl, cvt)
+	case *ReadMicroLayout:
+		if rl == nil {
+			return nil, errors.New("invalid (empty) microdata read layout")
+		}
+		nSize = rl.Size
+		rl.isCountAll = true
+		lt, err = ReadMicrodataTo(dbConn, modelDef, rl, cvt)
+	default:
+		return nil, errors.New("invalid read layout, expected parameter, output table or microdata layout")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lt == nil {
+		return nil, errors.New("invalid (empty) result page layout")
+	}
+
+	return makeValuePageLayout(lt, nSize, !isStop), nil
+}
+
+// return page layout with total count and page window from result page layout and requested page size
+func makeValuePageLayout(lt *ReadPageLayout, nSize int64, isTotal bool) *ValuePageLayout {
+
+	vl := ValuePageLayout{ReadPageLayout: *lt, IsTotalCount: isTotal}
+	if isTotal {
+		vl.TotalCount = lt.rowCount
+	}
+
+	if nSize > 0 {
+		vl.PageIndex = vl.Offset / nSize
+		if isTotal {
+			vl.PageCount = (vl.TotalCount + nSize - 1) / nSize
+		}
+	}
+	return &vl
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestValuePageCount(t *testing.T) {
+
+	dbConn := openTestDb(t,
+		"CREATE TABLE v (n INT NOT NULL)",
+		"INSERT INTO v (n) VALUES (1), (2), (3), (4), (5), (6), (7), (8), (9), (10)",
+	)
+
+	cvt := func(rows *sql.Rows) (interface{}, error) {
+		var n int
+		err := rows.Scan(&n)
+		return n, err
+	}
+
+	for _, tc := range []struct {
+		offset, size                int64
+		isFull                      bool
+		rowOffset, rowSize          int64
+		isLast                      bool
+		pageIndex, pageCount, total int64
+	}{
+		{offset: 0, size: 4, rowOffset: 0, rowSize: 4, isLast: false, pageIndex: 0, pageCount: 3, total: 10},
+		{offset: 4, size: 4, rowOffset: 4, rowSize: 4, isLast: false, pageIndex: 1, pageCount: 3, total: 10},
+		{offset: 8, size: 4, rowOffset: 8, rowSize: 2, isLast: true, pageIndex: 2, pageCount: 3, total: 10},
+		{offset: 20, size: 4, isFull: true, rowOffset: 6, rowSize: 4, isLast: true, pageIndex: 1, pageCount: 3, total: 10},
+		{offset: 0, size: 0, rowOffset: 0, rowSize: 10, isLast: true, pageIndex: 0, pageCount: 0, total: 10},
+	} {
+		pl := ReadPageLayout{Offset: tc.offset, Size: tc.size, IsFullPage: tc.isFull, isCountAll: true}
+
+		_, lt, err := SelectToList(dbConn, "SELECT n FROM v ORDER BY 1", pl, cvt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vl := makeValuePageLayout(lt, tc.size, true)
+
+		if vl.Offset != tc.rowOffset || vl.Size != tc.rowSize || vl.IsLastPage != tc.isLast ||
+			vl.TotalCount != tc.total || !vl.IsTotalCount || vl.PageIndex != tc.pageIndex || vl.PageCount != tc.pageCount {
+			t.Errorf("invalid page layout: %+v for offset: %d size: %d full page: %v", *vl, tc.offset, tc.size, tc.isFull)
+		}
+	}
+
+	// if total count unknown then page count is zero
+	vl := makeValuePageLayout(&ReadPageLayout{Offset: 4, Size: 4}, 4, false)
+	if vl.IsTotalCount || vl.TotalCount != 0 || vl.PageCount != 0 || vl.PageIndex != 1 {
+		t.Errorf("invalid page layout with unknown total: %+v", *vl)
+	}
+}
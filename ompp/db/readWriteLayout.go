@@ -100,19 +100,59 @@ type ReadParamLayout struct {
 // If ValueName is not empty then only accumulator or output expression
 // with that name selected (i.e: "acc1" or "expr4") else all output table accumulators (expressions) selected.
 type ReadTableLayout struct {
-	ReadLayout             // output table name, run id, page size, where filters and order by
-	ValueName       string // if not empty then expression or accumulator name to select
-	IsAccum         bool   // if true then select output table accumulator else expression
-	IsAllAccum      bool   // if true then select from all accumulators view else from accumulators table
-	ReadSubIdLayout        // sub-value id filter: select rows with only one sub-value id
+	ReadLayout              // output table name, run id, page size, where filters and order by
+	ValueName        string // if not empty then expression or accumulator name to select
+	IsAccum          bool   // if true then select output table accumulator else expression
+	IsAllAccum       bool   // if true then select from all accumulators view else from accumulators table
+	ReadSubIdLayout         // sub-value id filter: select rows with only one sub-value id
+	ReadMarginLayout        // dimension(s) total items computed on read
+	ReadSampleLayout        // if sample size > 0 then read a sample of output table cells, e.g. to preview big table
+}
+
+// ReadMarginLayout describes dimension total items (margins) of output table computed on read.
+//
+// If Margin is not empty then total items of those dimensions computed from other dimension items
+// and stored total items of those dimensions ignored.
+// If there are multiple margin dimensions then cross totals also computed, e.g. total of total.
+// Dimension must have total item enabled in model metadata.
+type ReadMarginLayout struct {
+	Margin     []string // names of dimensions to compute total item
+	MarginAggr string   // aggregation function to compute total item: sum (default) or avg
+}
+
+// ReadSampleLayout describes sampling read of output table, it is intended to preview big tables without reading all cells.
+//
+// If SampleSize > 0 then only a sample of cells selected for each combination of expression or accumulator id
+// and SampleBy dimension items, e.g. first 100 cells of each expression and each item of Sex dimension.
+// If IsRandom is false then first cells selected in the order of dimension items, it is done by sql.
+// If IsRandom is true then reservoir sample of cells selected and cells returned in the same order as table rows.
+// Dimension filters and margins applied before sampling, order by, page offset and page size applied to the sample.
+type ReadSampleLayout struct {
+	SampleSize int      // if > 0 then max number of cells to select for each combination of SampleBy dimensions items
+	SampleBy   []string // names of dimensions to sample by, if empty then sample is done for each expression or accumulator
+	IsRandom   bool     // if true then random (reservoir) sample else first cells
 }
 
 // ReadMicroLayout describes source and size of data page to read entity microdata.
 //
 // Only one entity generation digest expected for each run id + entity name, but there is no such constarint in db schema.
 type ReadMicroLayout struct {
-	ReadLayout        // entity name, run id, page size, where filters and order by
-	GenDigest  string // entity generation digest
+	ReadLayout                  // entity name, run id, page size, where filters and order by
+	GenDigest          string   // entity generation digest
+	Attrs              []string // if not empty then select only those attributes, in the order of entity generation
+	ReadKeyRangeLayout          // entity key range: select only rows where entity key between KeyFrom and KeyTo
+}
+
+// ReadKeyRangeLayout describes range of entity keys to select microdata rows: KeyFrom <= entity_key <= KeyTo.
+//
+// It is intended to split microdata into chunks by entity key for parallel processing,
+// for example: KeyFrom = 0, KeyTo = 999999 and next chunk is KeyFrom = 1000000, KeyTo = 1999999.
+// Use EnsureEntityKeyIndex() to make sure there is an index on microdata table entity key.
+type ReadKeyRangeLayout struct {
+	IsKeyFrom bool   // if true then select only rows where entity_key >= KeyFrom
+	KeyFrom   uint64 // first entity key of the range, inclusive
+	IsKeyTo   bool   // if true then select only rows where entity_key <= KeyTo
+	KeyTo     uint64 // last entity key of the range, inclusive
 }
 
 // ReadSubIdLayout supply sub-value id filter to select rows with only single sub_id from output table or input parameter values.
@@ -127,6 +167,19 @@ type ReadPageLayout struct {
 	Size       int64 // max row count to select, if <= 0 then all rows
 	IsLastPage bool  // output last page flag: return true if it was a last page of rows
 	IsFullPage bool  // input last page flag: if true then adjust offset to return full last page
+	isCountAll bool  // if true then count all rows selected by query, including rows after the page
+	rowCount   int64 // output number of rows selected by query, all rows if isCountAll is true
+}
+
+// RunListLayout describes page and sort order to read list of model runs.
+//
+// Default sort order is by run id.
+// Keyset paging by AfterId can be used only with run id sort order, it is faster than offset paging for large run list.
+type RunListLayout struct {
+	ReadPageLayout        // page offset and size, if page size <= 0 then all rows
+	OrderBy        string // sort order: "id", "create", "update", "name" or "status", default: run id
+	IsDesc         bool   // if true then descending sort order
+	AfterId        int    // if positive then select only runs after that run id, in sort order direction
 }
 
 // ReadCompareTableLayout to compare output table runs with base run using multiple comparison expressions and/or calculation measures.
@@ -310,6 +363,11 @@ func makeCodeWhereFiler(alias, colName string, isStrType bool, flt *FilterColumn
 	var vals []string
 
 	if !isStrType {
+		for k := range flt.Values {
+			if _, err := strconv.ParseFloat(flt.Values[k], 64); err != nil {
+				return "", errors.New("invalid numeric value of filter " + msgParent + " " + msgName + ": " + flt.Values[k])
+			}
+		}
 		vals = flt.Values
 	} else {
 		vals = make([]string, len(flt.Values))
@@ -0,0 +1,261 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RecalculateTableExpressions recalculate output table expression values of model run from accumulators.
+//
+// Expression values are calculated by expression sql of table_expr rows, for example:
+//
+//	SELECT M1.run_id, M1.dim0, M1.dim1, AVG(M1.acc_value) AS expr0 FROM salarySex_a_2012882 M1 WHERE M1.acc_id = 0 GROUP BY M1.run_id, M1.dim0, M1.dim1
+//
+// It is intended to use after table_expr metadata edited and stored expression values are stale.
+// All expression rows of the model run output table are deleted and replaced by calculated values in a single transaction.
+// If output table values are shared with other runs, i.e. stored under the base run (run_table.base_run_id),
+// then base run expressions are recalculated and the value digest updated for all runs which share those values.
+// Model run must be completed.
+func RecalculateTableExpressions(dbConn *sql.DB, runId int, tableName string) error {
+
+	// validate parameters
+	if runId <= 0 {
+		return errors.New("invalid model run id: " + strconv.Itoa(runId))
+	}
+	if tableName == "" {
+		return errors.New("invalid (empty) output table name")
+	}
+
+	// find model run and model metadata, run must be completed
+	runRow, err := GetRun(dbConn, runId)
+	if err != nil {
+		return err
+	}
+	if runRow == nil {
+		return newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(runId))
+	}
+	if !IsRunCompleted(runRow.Status) {
+		return errors.New("model run not completed, id: " + strconv.Itoa(runId))
+	}
+
+	modelDef, err := GetModelById(dbConn, runRow.ModelId)
+	if err != nil {
+		return err
+	}
+	k, ok := modelDef.OutTableByName(tableName)
+	if !ok {
+		return errors.New("output table not found: " + tableName)
+	}
+
+	// do delete and insert expressions in transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = doRecalculateTableExpressions(trx, modelDef, &modelDef.Table[k], runId); err != nil {
+		trx.Rollback()
+		return err
+	}
+	trx.Commit()
+	return nil
+}
+
+// doRecalculateTableExpressions delete output table expression values and insert new values calculated by expression sql.
+// It does update as part of transaction
+func doRecalculateTableExpressions(trx *sql.Tx, modelDef *ModelMeta, meta *TableMeta, runId int) error {
+
+	// update model run master record to prevent run use
+	srId := strconv.Itoa(runId)
+	err := TrxUpdate(trx,
+		"UPDATE run_lst SET sub_restart = sub_restart - 1 WHERE run_id = "+srId)
+	if err != nil {
+		return err
+	}
+
+	// find base run id where output table values stored
+	sHid := strconv.Itoa(meta.TableHid)
+	baseId := 0
+	err = TrxSelectFirst(trx,
+		"SELECT base_run_id FROM run_table WHERE run_id = "+srId+" AND table_hid = "+sHid,
+		func(row *sql.Row) error {
+			return row.Scan(&baseId)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return errors.New("model run with id: " + srId + " does not contain output table values " + meta.Name)
+	case err != nil:
+		return err
+	}
+	sBase := strconv.Itoa(baseId)
+
+	// delete existing expression values and insert values calculated from accumulators:
+	//
+	// INSERT INTO salarySex_v_2012882 (run_id, expr_id, dim0, dim1, expr_value)
+	// SELECT E.run_id, 0, E.dim0, E.dim1, E.expr0
+	// FROM (SELECT M1.run_id, M1.dim0, M1.dim1, AVG(M1.acc_value) AS expr0 FROM ....) E
+	// WHERE E.run_id = 102
+	err = TrxUpdate(trx, "DELETE FROM "+meta.DbExprTable+" WHERE run_id = "+sBase)
+	if err != nil {
+		return err
+	}
+
+	dimCols := ""
+	selCols := ""
+	for k := range meta.Dim {
+		dimCols += meta.Dim[k].colName + ", "
+		selCols += "E." + meta.Dim[k].colName + ", "
+	}
+
+	for k := range meta.Expr {
+
+		if strings.TrimSpace(meta.Expr[k].ExprSql) == "" {
+			return errors.New("invalid (empty) expression sql: " + meta.Name + "." + meta.Expr[k].Name)
+		}
+
+		err = TrxUpdate(trx,
+			"INSERT INTO "+meta.DbExprTable+" (run_id, expr_id, "+dimCols+"expr_value)"+
+				" SELECT E.run_id, "+strconv.Itoa(meta.Expr[k].ExprId)+", "+selCols+"E."+meta.Expr[k].colName+
+				" FROM ("+meta.Expr[k].ExprSql+") E"+
+				" WHERE E.run_id = "+sBase)
+		if err != nil {
+			return errors.New("failed to calculate expression: " + meta.Name + "." + meta.Expr[k].Name + ": " + err.Error())
+		}
+	}
+
+	// recalculate output table value digest and update it for all runs which share the same output table values
+	dgst, err := digestOutputTableValues(trx, modelDef, meta, baseId)
+	if err != nil {
+		return err
+	}
+	err = TrxUpdate(trx,
+		"UPDATE run_table SET value_digest = "+ToQuoted(dgst)+
+			" WHERE base_run_id = "+sBase+
+			" AND table_hid = "+sHid)
+	if err != nil {
+		return err
+	}
+
+	rIds := []int{}
+	err = TrxSelectRows(trx,
+		"SELECT run_id FROM run_table WHERE base_run_id = "+sBase+" AND table_hid = "+sHid+" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			rIds = append(rIds, id)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	for _, id := range rIds {
+		if _, err = doUpdateRunValueDigest(trx, id); err != nil {
+			return err
+		}
+	}
+
+	// completed OK, restore run_lst values
+	err = TrxUpdate(trx,
+		"UPDATE run_lst SET sub_restart = sub_restart + 1 WHERE run_id = "+srId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// digestOutputTableValues calculate output table value digest from accumulators and expressions of the run.
+// Digest is calculated same way as at output table insert: accumulators ordered by acc_id, sub_id, dim0, dim1,...
+// and expressions ordered by expr_id, dim0, dim1,...
+func digestOutputTableValues(trx *sql.Tx, modelDef *ModelMeta, meta *TableMeta, runId int) (string, error) {
+
+	hMd5, digestAcc, isOrderBy, err := digestAccumulatorsFrom(modelDef, meta, "")
+	if err != nil {
+		return "", err
+	}
+
+	// SELECT acc_id, sub_id, dim0, dim1, acc_value FROM salarySex_a_2012882 WHERE run_id = 102 ORDER BY 1, 2, 3, 4
+	dimCols := ""
+	orderBy := ""
+	for k := range meta.Dim {
+		dimCols += meta.Dim[k].colName + ", "
+		orderBy += ", " + strconv.Itoa(k+3)
+	}
+	sRunId := strconv.Itoa(runId)
+
+	d := make([]int, meta.Rank)
+	var n1, n2 int
+	var vf sql.NullFloat64
+
+	err = TrxSelectRows(trx,
+		"SELECT acc_id, sub_id, "+dimCols+"acc_value FROM "+meta.DbAccTable+" WHERE run_id = "+sRunId+" ORDER BY 1, 2"+orderBy,
+		func(rows *sql.Rows) error {
+
+			scanBuf := []interface{}{&n1, &n2}
+			for k := range d {
+				scanBuf = append(scanBuf, &d[k])
+			}
+			if err := rows.Scan(append(scanBuf, &vf)...); err != nil {
+				return err
+			}
+			ca := CellAcc{cellIdValue: cellIdValue{DimIds: make([]int, meta.Rank)}, AccId: n1, SubId: n2}
+			copy(ca.DimIds, d)
+			ca.IsNull = !vf.Valid
+			ca.Value = 0.0
+			if !ca.IsNull {
+				ca.Value = floatFromDb(vf.Float64)
+			}
+			return digestAcc(ca)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return "", errors.New("digest accumulators failed: " + meta.Name + " " + err.Error())
+	}
+	if isOrderBy != nil && !*isOrderBy {
+		return "", errors.New("invalid digest due to incorrect accumulator(s) rows order: " + meta.Name)
+	}
+
+	// SELECT expr_id, dim0, dim1, expr_value FROM salarySex_v_2012882 WHERE run_id = 102 ORDER BY 1, 2, 3
+	digestExpr, isOrderBy, err := digestExpressionsFrom(modelDef, meta, "", hMd5)
+	if err != nil {
+		return "", err
+	}
+	orderBy = ""
+	for k := range meta.Dim {
+		orderBy += ", " + strconv.Itoa(k+2)
+	}
+
+	err = TrxSelectRows(trx,
+		"SELECT expr_id, "+dimCols+"expr_value FROM "+meta.DbExprTable+" WHERE run_id = "+sRunId+" ORDER BY 1"+orderBy,
+		func(rows *sql.Rows) error {
+
+			scanBuf := []interface{}{&n1}
+			for k := range d {
+				scanBuf = append(scanBuf, &d[k])
+			}
+			if err := rows.Scan(append(scanBuf, &vf)...); err != nil {
+				return err
+			}
+			ce := CellExpr{cellIdValue: cellIdValue{DimIds: make([]int, meta.Rank)}, ExprId: n1}
+			copy(ce.DimIds, d)
+			ce.IsNull = !vf.Valid
+			ce.Value = 0.0
+			if !ce.IsNull {
+				ce.Value = floatFromDb(vf.Float64)
+			}
+			return digestExpr(ce)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return "", errors.New("digest expressions failed: " + meta.Name + " " + err.Error())
+	}
+	if isOrderBy != nil && !*isOrderBy {
+		return "", errors.New("invalid digest due to incorrect expression(s) rows order: " + meta.Name)
+	}
+
+	return fmt.Sprintf("%x", hMd5.Sum(nil)), nil
+}
@@ -0,0 +1,502 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"crypto/md5"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// EnumRenumber is a report of type enum ids renumbering: old and new enum ids and db columns where enum ids are updated.
+type EnumRenumber struct {
+	TypeName  string               // model type name
+	TypeHid   int                  // type Hid, unique type id
+	IsChanged bool                 // if true then enum ids are not 0,...,N-1 and must be renumbered
+	Enum      []EnumIdPair         // enum ids: old enum id and new enum id
+	TotalOld  int                  // total enum id before renumbering
+	TotalNew  int                  // total enum id after renumbering, it is number of enums N
+	Digest    string               // new type digest after renumbering
+	Column    []EnumRenumberColumn // db columns where enum ids stored
+	params    []int                // indexes of model parameters where type is used
+	tables    []int                // indexes of model output tables where type is used
+	entities  []int                // indexes of model entities where type is used
+}
+
+// EnumIdPair is enum code, old enum id and new enum id
+type EnumIdPair struct {
+	Name  string // enum code: enum_name
+	OldId int    // enum id before renumbering
+	NewId int    // enum id after renumbering
+}
+
+// EnumRenumberColumn is db table column where enum ids of the type stored.
+type EnumRenumberColumn struct {
+	Kind     string // t=type metadata, p=parameter run values, w=workset values, h=workset values history, v=expressions, a=accumulators, g=microdata
+	Name     string // parameter, output table or entity name, empty for type metadata
+	DbTable  string // db table name
+	Column   string // db column name
+	RowCount int64  // number of rows where enum id must be updated
+	where    string // additional filter, e.g.: type_hid = 101
+}
+
+// RenumberEnumIds renumber enum ids of model type to 0,...,N-1 and update all dependent model metadata and values.
+//
+// Enum ids are renumbered in order of existing enum ids, enum codes (names) are not changed.
+// Total enum id of the type is set to N, it is number of enums.
+// Following is updated in one transaction: type_enum_lst, type_enum_txt, type_dic total enum id,
+// dimensions and enum-based values of parameters run and workset values,
+// dimensions of output tables expressions and accumulators, enum-based attributes of microdata.
+// Range types and built-in types cannot be renumbered, as well as types shared with other models.
+//
+// Renumbered type is a different type: type gets a new digest, parameters, output tables and entities of that type get new digests.
+// New digest is made from old digest, it is the same if the same type renumbered in other database.
+// Value digests of parameters run values, workset values and output tables are recalculated, as well as model runs value digests.
+// Microdata value digests are not recalculated.
+//
+// If isDryRun is true then nothing is updated and return report of enum ids and db columns to be updated.
+// After renumbering model metadata must be reloaded from database.
+func RenumberEnumIds(dbConn *sql.DB, modelDef *ModelMeta, typeName string, isDryRun bool) (*EnumRenumber, error) {
+
+	// validate parameters
+	if modelDef == nil {
+		return nil, errors.New("invalid (empty) model metadata")
+	}
+	if typeName == "" {
+		return nil, errors.New("invalid (empty) model type name")
+	}
+
+	nType := -1
+	for k := range modelDef.Type {
+		if modelDef.Type[k].Name == typeName {
+			nType = k
+			break
+		}
+	}
+	if nType < 0 {
+		return nil, errors.New("model type not found: " + typeName)
+	}
+	typeOf := &modelDef.Type[nType]
+
+	if typeOf.IsBuiltIn() {
+		return nil, errors.New("invalid type, built-in type cannot be renumbered: " + typeName)
+	}
+	if typeOf.IsRange {
+		return nil, errors.New("invalid type, range type cannot be renumbered: " + typeName)
+	}
+	if len(typeOf.Enum) <= 0 {
+		return nil, errors.New("invalid type, it does not have any enums: " + typeName)
+	}
+
+	// type must not be shared with other models: values of other models are not updated
+	nShared := 0
+	err := SelectFirst(dbConn,
+		"SELECT COUNT(*) FROM model_type_dic"+
+			" WHERE type_hid = "+strconv.Itoa(typeOf.TypeHid)+
+			" AND model_id <> "+strconv.Itoa(modelDef.Model.ModelId),
+		func(row *sql.Row) error {
+			return row.Scan(&nShared)
+		})
+	if err != nil {
+		return nil, err
+	}
+	if nShared > 0 {
+		return nil, errors.New("invalid type, it is shared with other models: " + typeName)
+	}
+
+	// make old and new enum ids
+	er := &EnumRenumber{
+		TypeName: typeOf.Name,
+		TypeHid:  typeOf.TypeHid,
+		TotalOld: typeOf.TotalEnumId,
+		Column:   []EnumRenumberColumn{},
+	}
+	er.Enum, er.TotalNew, er.IsChanged = makeEnumRenumber(typeOf.Enum, typeOf.TotalEnumId)
+
+	if !er.IsChanged {
+		return er, nil // enum ids are already 0,...,N-1
+	}
+	er.Digest = renumberedDigest(typeOf.Digest, "enum_id,0,"+strconv.Itoa(er.TotalNew))
+
+	// collect db columns where enum ids stored: type metadata, parameters, output tables and microdata
+	sHid := strconv.Itoa(typeOf.TypeHid)
+	er.Column = append(er.Column,
+		EnumRenumberColumn{Kind: "t", DbTable: "type_enum_lst", Column: "enum_id", where: "type_hid = " + sHid},
+		EnumRenumberColumn{Kind: "t", DbTable: "type_enum_txt", Column: "enum_id", where: "type_hid = " + sHid},
+	)
+
+	for k := range modelDef.Param {
+
+		p := &modelDef.Param[k]
+		cLst := []string{}
+		for j := range p.Dim {
+			if p.Dim[j].TypeId == typeOf.TypeId {
+				cLst = append(cLst, p.Dim[j].colName)
+			}
+		}
+		if p.TypeId == typeOf.TypeId {
+			cLst = append(cLst, "param_value")
+		}
+		if len(cLst) <= 0 {
+			continue
+		}
+		er.params = append(er.params, k)
+
+		// workset parameter values history table exist only if history enabled and values saved
+		hTable := worksetParamHistTable(p)
+		isHist := isTableSelectable(dbConn, hTable)
+
+		for _, c := range cLst {
+			er.Column = append(er.Column,
+				EnumRenumberColumn{Kind: "p", Name: p.Name, DbTable: p.DbRunTable, Column: c},
+				EnumRenumberColumn{Kind: "w", Name: p.Name, DbTable: p.DbSetTable, Column: c},
+			)
+			if isHist {
+				er.Column = append(er.Column, EnumRenumberColumn{Kind: "h", Name: p.Name, DbTable: hTable, Column: c})
+			}
+		}
+	}
+
+	for k := range modelDef.Table {
+
+		t := &modelDef.Table[k]
+		isUsed := false
+		for j := range t.Dim {
+			if t.Dim[j].TypeId == typeOf.TypeId {
+				isUsed = true
+				er.Column = append(er.Column,
+					EnumRenumberColumn{Kind: "v", Name: t.Name, DbTable: t.DbExprTable, Column: t.Dim[j].colName},
+					EnumRenumberColumn{Kind: "a", Name: t.Name, DbTable: t.DbAccTable, Column: t.Dim[j].colName},
+				)
+			}
+		}
+		if isUsed {
+			er.tables = append(er.tables, k)
+		}
+	}
+
+	// microdata: attributes of entity generations of the model entities
+	for k := range modelDef.Entity {
+
+		ent := &modelDef.Entity[k]
+		for j := range ent.Attr {
+			if ent.Attr[j].TypeId != typeOf.TypeId {
+				continue
+			}
+			if !slices.Contains(er.entities, k) {
+				er.entities = append(er.entities, k)
+			}
+
+			tnLst := []string{}
+
+			err = SelectRows(dbConn,
+				"SELECT EG.db_entity_table"+
+					" FROM entity_gen EG"+
+					" INNER JOIN entity_gen_attr EA ON (EA.entity_gen_hid = EG.entity_gen_hid)"+
+					" WHERE EG.entity_hid = "+strconv.Itoa(ent.EntityHid)+
+					" AND EA.attr_id = "+strconv.Itoa(ent.Attr[j].AttrId)+
+					" ORDER BY 1",
+				func(rows *sql.Rows) error {
+					var tn string
+					if err := rows.Scan(&tn); err != nil {
+						return err
+					}
+					tnLst = append(tnLst, tn)
+					return nil
+				})
+			if err != nil {
+				return nil, err
+			}
+
+			// if microdata packed then update dictionary or values table
+			for _, tn := range tnLst {
+
+				ct, err := microColumnTable(dbConn, tn, ent.Attr[j].colName)
+				if err != nil {
+					return nil, err
+				}
+				er.Column = append(er.Column,
+					EnumRenumberColumn{Kind: "g", Name: ent.Name, DbTable: ct, Column: ent.Attr[j].colName})
+			}
+		}
+	}
+
+	// count rows to update
+	oldIds := []int{}
+	for _, e := range er.Enum {
+		if e.OldId != e.NewId {
+			oldIds = append(oldIds, e.OldId)
+		}
+	}
+	if er.TotalOld != er.TotalNew {
+		oldIds = append(oldIds, er.TotalOld)
+	}
+
+	for k := range er.Column {
+
+		q := "SELECT COUNT(*) FROM " + er.Column[k].DbTable + " WHERE "
+		if er.Column[k].where != "" {
+			q += er.Column[k].where + " AND "
+		}
+		q += "(" + makeIdInList(er.Column[k].Column, oldIds) + ")"
+
+		err = SelectFirst(dbConn, q,
+			func(row *sql.Row) error {
+				return row.Scan(&er.Column[k].RowCount)
+			})
+		if err != nil {
+			return nil, errors.New("failed to count rows of: " + er.Column[k].DbTable + ": " + err.Error())
+		}
+	}
+	if isDryRun {
+		return er, nil
+	}
+	isWsDigest := IsWorksetParamDigest(dbConn)
+
+	// do update in transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	if err = doRenumberEnumIds(trx, er); err != nil {
+		trx.Rollback()
+		return nil, err
+	}
+	if err = doUpdateRenumberDigests(trx, modelDef, er, isWsDigest); err != nil {
+		trx.Rollback()
+		return nil, err
+	}
+	if err = trx.Commit(); err != nil {
+		return nil, err
+	}
+	return er, nil
+}
+
+// renumberedDigest return new digest of type, parameter, output table or entity after type enum ids renumbered.
+func renumberedDigest(digest, renumber string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(digest+","+renumber)))
+}
+
+// doUpdateRenumberDigests update digests after type enum ids renumbered.
+// It does update as part of transaction.
+//
+// Type digest is replaced by new digest, digest and import digest of parameters, output tables and entities
+// where type is used are replaced by digests made from old digest and new type digest.
+// Value digests of parameter run values, workset values and output tables are recalculated
+// and value digests of all model runs are recalculated.
+func doUpdateRenumberDigests(trx *sql.Tx, modelDef *ModelMeta, er *EnumRenumber, isWsDigest bool) error {
+
+	err := TrxUpdate(trx,
+		"UPDATE type_dic SET type_digest = "+ToQuoted(er.Digest)+" WHERE type_hid = "+strconv.Itoa(er.TypeHid))
+	if err != nil {
+		return errors.New("failed to update type digest: " + er.TypeName + ": " + err.Error())
+	}
+
+	// select list of ids: base run ids or workset ids
+	selectIds := func(q string) ([]int, error) {
+		idLst := []int{}
+		err := TrxSelectRows(trx, q, func(rows *sql.Rows) error {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			idLst = append(idLst, id)
+			return nil
+		})
+		return idLst, err
+	}
+
+	// parameters: update metadata digests and recalculate value digests
+	for _, k := range er.params {
+
+		p := modelDef.Param[k] // copy of parameter metadata with new digest
+		sHid := strconv.Itoa(p.ParamHid)
+		p.Digest = renumberedDigest(p.Digest, er.Digest)
+		p.ImportDigest = renumberedDigest(p.ImportDigest, er.Digest)
+
+		err = TrxUpdate(trx,
+			"UPDATE parameter_dic SET parameter_digest = "+ToQuoted(p.Digest)+", import_digest = "+ToQuoted(p.ImportDigest)+
+				" WHERE parameter_hid = "+sHid)
+		if err != nil {
+			return errors.New("failed to update parameter digest: " + p.Name + ": " + err.Error())
+		}
+
+		// run values are stored in base run, other runs are linked to base run values
+		bLst, err := selectIds(
+			"SELECT DISTINCT base_run_id FROM run_parameter WHERE parameter_hid = " + sHid + " AND value_digest IS NOT NULL ORDER BY 1")
+		if err != nil {
+			return err
+		}
+		for _, bId := range bLst {
+
+			dgst, err := digestRunParam(trx, modelDef, &p, bId)
+			if err != nil {
+				return err
+			}
+			err = TrxUpdate(trx,
+				"UPDATE run_parameter SET value_digest = "+ToQuoted(dgst)+
+					" WHERE parameter_hid = "+sHid+" AND base_run_id = "+strconv.Itoa(bId))
+			if err != nil {
+				return err
+			}
+		}
+
+		// workset values digest, if it is calculated
+		if !isWsDigest {
+			continue
+		}
+		sLst, err := selectIds(
+			"SELECT set_id FROM workset_parameter WHERE parameter_hid = " + sHid + " AND value_digest IS NOT NULL ORDER BY 1")
+		if err != nil {
+			return err
+		}
+		for _, setId := range sLst {
+			if _, err = doUpdateWorksetParamDigest(trx, modelDef, &p, setId); err != nil {
+				return err
+			}
+		}
+	}
+
+	// output tables: update metadata digests and recalculate value digests
+	for _, k := range er.tables {
+
+		t := modelDef.Table[k] // copy of output table metadata with new digest
+		sHid := strconv.Itoa(t.TableHid)
+		t.Digest = renumberedDigest(t.Digest, er.Digest)
+		t.ImportDigest = renumberedDigest(t.ImportDigest, er.Digest)
+
+		err = TrxUpdate(trx,
+			"UPDATE table_dic SET table_digest = "+ToQuoted(t.Digest)+", import_digest = "+ToQuoted(t.ImportDigest)+
+				" WHERE table_hid = "+sHid)
+		if err != nil {
+			return errors.New("failed to update output table digest: " + t.Name + ": " + err.Error())
+		}
+
+		bLst, err := selectIds(
+			"SELECT DISTINCT base_run_id FROM run_table WHERE table_hid = " + sHid + " AND value_digest IS NOT NULL ORDER BY 1")
+		if err != nil {
+			return err
+		}
+		for _, bId := range bLst {
+
+			dgst, err := digestOutputTableValues(trx, modelDef, &t, bId)
+			if err != nil {
+				return err
+			}
+			err = TrxUpdate(trx,
+				"UPDATE run_table SET value_digest = "+ToQuoted(dgst)+
+					" WHERE table_hid = "+sHid+" AND base_run_id = "+strconv.Itoa(bId))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// entities: update metadata digest
+	for _, k := range er.entities {
+
+		ent := &modelDef.Entity[k]
+		err = TrxUpdate(trx,
+			"UPDATE entity_dic SET entity_digest = "+ToQuoted(renumberedDigest(ent.Digest, er.Digest))+
+				" WHERE entity_hid = "+strconv.Itoa(ent.EntityHid))
+		if err != nil {
+			return errors.New("failed to update entity digest: " + ent.Name + ": " + err.Error())
+		}
+	}
+
+	// recalculate model runs value digests
+	if len(er.params) <= 0 && len(er.tables) <= 0 {
+		return nil
+	}
+	rLst, err := selectIds("SELECT run_id FROM run_lst WHERE model_id = " + strconv.Itoa(modelDef.Model.ModelId) + " ORDER BY 1")
+	if err != nil {
+		return err
+	}
+	for _, runId := range rLst {
+		if _, err = doUpdateRunValueDigest(trx, runId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// makeEnumRenumber return old and new enum ids in order of old enum ids, new total enum id and true if any enum id changed.
+// New enum ids are 0,...,N-1 and new total enum id is N.
+func makeEnumRenumber(enums []TypeEnumRow, totalId int) ([]EnumIdPair, int, bool) {
+
+	eLst := make([]EnumIdPair, len(enums))
+	for k := range enums {
+		eLst[k] = EnumIdPair{Name: enums[k].Name, OldId: enums[k].EnumId}
+	}
+	slices.SortStableFunc(eLst, func(a, b EnumIdPair) int { return a.OldId - b.OldId })
+
+	isChanged := totalId != len(eLst)
+	for k := range eLst {
+		eLst[k].NewId = k
+		isChanged = isChanged || eLst[k].OldId != k
+	}
+	return eLst, len(eLst), isChanged
+}
+
+// doRenumberEnumIds replace old enum ids by new enum ids in all db columns and update total enum id of the type.
+// It does update as part of transaction.
+//
+// Update is done in two steps to avoid primary key conflicts:
+// at first old id replaced by new id + shift, where shift is greater than any old id, and after that shift is subtracted.
+func doRenumberEnumIds(trx *sql.Tx, er *EnumRenumber) error {
+
+	// id pairs to update: enum ids and total enum id
+	idLst := []EnumIdPair{}
+	shift := max(er.TotalOld, er.TotalNew)
+
+	for _, e := range er.Enum {
+		shift = max(shift, e.OldId)
+		if e.OldId != e.NewId {
+			idLst = append(idLst, e)
+		}
+	}
+	if er.TotalOld != er.TotalNew && !slices.ContainsFunc(er.Enum, func(e EnumIdPair) bool { return e.OldId == er.TotalOld }) {
+		idLst = append(idLst, EnumIdPair{OldId: er.TotalOld, NewId: er.TotalNew})
+	}
+	shift++
+	sShift := strconv.Itoa(shift)
+
+	for k := range er.Column {
+
+		w := ""
+		if er.Column[k].where != "" {
+			w = er.Column[k].where + " AND "
+		}
+		col := er.Column[k].Column
+
+		// UPDATE ageSex_p12345678 SET dim0 = 1003 WHERE dim0 = 10
+		for _, e := range idLst {
+			err := TrxUpdate(trx,
+				"UPDATE "+er.Column[k].DbTable+" SET "+col+" = "+strconv.Itoa(e.NewId+shift)+
+					" WHERE "+w+col+" = "+strconv.Itoa(e.OldId))
+			if err != nil {
+				return errors.New("failed to update: " + er.Column[k].DbTable + "." + col + ": " + err.Error())
+			}
+		}
+
+		// UPDATE ageSex_p12345678 SET dim0 = dim0 - 1002 WHERE dim0 >= 1002
+		err := TrxUpdate(trx,
+			"UPDATE "+er.Column[k].DbTable+" SET "+col+" = "+col+" - "+sShift+
+				" WHERE "+w+col+" >= "+sShift)
+		if err != nil {
+			return errors.New("failed to update: " + er.Column[k].DbTable + "." + col + ": " + err.Error())
+		}
+	}
+
+	// update type total enum id
+	err := TrxUpdate(trx,
+		"UPDATE type_dic SET total_enum_id = "+strconv.Itoa(er.TotalNew)+" WHERE type_hid = "+strconv.Itoa(er.TypeHid))
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestMakeEnumRenumber(t *testing.T) {
+
+	// sparse enum ids in not sorted order
+	enums := []TypeEnumRow{
+		{EnumId: 20, Name: "High"},
+		{EnumId: 5, Name: "Low"},
+		{EnumId: 10, Name: "Medium"},
+	}
+
+	eLst, total, isChanged := makeEnumRenumber(enums, 21)
+
+	if !isChanged || total != 3 || len(eLst) != 3 {
+		t.Fatalf("invalid renumbering: changed: %t total: %d enums: %+v", isChanged, total, eLst)
+	}
+	for k, name := range []string{"Low", "Medium", "High"} {
+		if eLst[k].Name != name || eLst[k].NewId != k {
+			t.Errorf("invalid enum at %d: %+v", k, eLst[k])
+		}
+	}
+	if eLst[0].OldId != 5 || eLst[2].OldId != 20 {
+		t.Errorf("invalid old enum ids: %+v", eLst)
+	}
+
+	// enum ids are already 0,...,N-1
+	enums = []TypeEnumRow{{EnumId: 0, Name: "F"}, {EnumId: 1, Name: "M"}}
+
+	if _, total, isChanged = makeEnumRenumber(enums, 2); isChanged || total != 2 {
+		t.Errorf("expected no renumbering: changed: %t total: %d", isChanged, total)
+	}
+	if _, _, isChanged = makeEnumRenumber(enums, 5); !isChanged {
+		t.Errorf("expected renumbering of total enum id")
+	}
+}
+
+func TestRenumberEnumIds(t *testing.T) {
+
+	// type sex with enum ids 10, 20 used by dimension of parameter ageSex,
+	// parameter values of run 1, run 2 linked to run 1 values and workset 1
+	dbConn := openTestDb(t,
+		testModelSql(1, "m1", "m1"),
+		"INSERT INTO type_dic (type_hid, type_name, type_digest, dic_id, total_enum_id) VALUES (101, 'sex', 't101', 2, 30)",
+		"INSERT INTO model_type_dic (model_id, model_type_id, type_hid) VALUES (1, 101, 101)",
+		"INSERT INTO type_enum_lst (type_hid, enum_id, enum_name) VALUES (101, 10, 'F'), (101, 20, 'M')",
+		"INSERT INTO parameter_dic"+
+			" (parameter_hid, parameter_name, parameter_digest, db_run_table, db_set_table, parameter_rank, type_hid, is_extendable, num_cumulated, import_digest)"+
+			" VALUES (17, 'ageSex', 'p17', 'ageSex_p12345678', 'ageSex_w12345678', 1, 7, 0, 0, 'i17')",
+		"INSERT INTO model_parameter_dic (model_id, model_parameter_id, parameter_hid, is_hidden) VALUES (1, 0, 17, 0)",
+		"CREATE TABLE ageSex_p12345678 (run_id INT, sub_id INT, dim0 INT, param_value FLOAT, PRIMARY KEY (run_id, sub_id, dim0))",
+		"CREATE TABLE ageSex_w12345678 (set_id INT, sub_id INT, dim0 INT, param_value FLOAT, PRIMARY KEY (set_id, sub_id, dim0))",
+		"INSERT INTO ageSex_p12345678 (run_id, sub_id, dim0, param_value) VALUES (1, 0, 10, 1.5), (1, 0, 20, 2.5)",
+		"INSERT INTO ageSex_w12345678 (set_id, sub_id, dim0, param_value) VALUES (1, 0, 10, 1.5), (1, 0, 20, 2.5)",
+		testRunSql(1, 1, 1, "s"),
+		testRunSql(2, 1, 1, "s"),
+		"INSERT INTO run_parameter (run_id, parameter_hid, base_run_id, sub_count, value_digest) VALUES (1, 17, 1, 1, 'old'), (2, 17, 1, 1, 'old')",
+		testWorksetSql(1, 1, "Edit", false),
+		"INSERT INTO workset_parameter (set_id, parameter_hid, sub_count, default_sub_id, value_digest) VALUES (1, 17, 1, 0, 'old')",
+	)
+
+	sexType := TypeMeta{
+		TypeDicRow: TypeDicRow{ModelId: 1, TypeId: 101, TypeHid: 101, Name: "sex", Digest: "t101", DicId: 2, TotalEnumId: 30},
+		Enum:       []TypeEnumRow{{ModelId: 1, TypeId: 101, EnumId: 10, Name: "F"}, {ModelId: 1, TypeId: 101, EnumId: 20, Name: "M"}},
+	}
+	modelDef := &ModelMeta{
+		Model: ModelDicRow{ModelId: 1, Name: "m1", Digest: "m1"},
+		Type:  []TypeMeta{sexType},
+		Param: []ParamMeta{{
+			ParamDicRow: ParamDicRow{
+				ModelId: 1, ParamHid: 17, Name: "ageSex", Digest: "p17", ImportDigest: "i17", Rank: 1, TypeId: 7,
+				DbRunTable: "ageSex_p12345678", DbSetTable: "ageSex_w12345678",
+			},
+			Dim:    []ParamDimsRow{{ModelId: 1, Name: "dim0", TypeId: 101, colName: "dim0"}},
+			typeOf: &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 7, Name: "double"}},
+		}},
+	}
+
+	// select string value from database
+	selectStr := func(q string) string {
+		t.Helper()
+		var s sql.NullString
+		if err := SelectFirst(dbConn, q, func(row *sql.Row) error { return row.Scan(&s) }); err != nil {
+			t.Fatal(err, ": ", q)
+		}
+		return s.String
+	}
+
+	// dry run: nothing updated
+	er, err := RenumberEnumIds(dbConn, modelDef, "sex", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !er.IsChanged || er.TotalNew != 2 || er.Digest != renumberedDigest("t101", "enum_id,0,2") {
+		t.Fatalf("Fail: invalid renumbering report: %+v", er)
+	}
+	if s := selectStr("SELECT type_digest FROM type_dic WHERE type_hid = 101"); s != "t101" {
+		t.Error("Fail: type digest updated by dry run:", s)
+	}
+
+	if _, err = RenumberEnumIds(dbConn, modelDef, "sex", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// renumbered type is a new type: type and parameter have new digests
+	if s := selectStr("SELECT type_digest FROM type_dic WHERE type_hid = 101"); s != er.Digest {
+		t.Errorf("Fail: invalid type digest: %s expected: %s", s, er.Digest)
+	}
+	if s := selectStr("SELECT parameter_digest FROM parameter_dic WHERE parameter_hid = 17"); s != renumberedDigest("p17", er.Digest) {
+		t.Error("Fail: invalid parameter digest:", s)
+	}
+	if s := selectStr("SELECT import_digest FROM parameter_dic WHERE parameter_hid = 17"); s != renumberedDigest("i17", er.Digest) {
+		t.Error("Fail: invalid parameter import digest:", s)
+	}
+	if s := selectStr("SELECT MAX(enum_id) FROM type_enum_lst WHERE type_hid = 101"); s != "1" {
+		t.Error("Fail: invalid max enum id:", s)
+	}
+	if s := selectStr("SELECT MAX(dim0) FROM ageSex_p12345678 WHERE run_id = 1"); s != "1" {
+		t.Error("Fail: invalid max enum id of parameter run values:", s)
+	}
+
+	// value digests recalculated: run values and workset values are the same
+	d1 := selectStr("SELECT value_digest FROM run_parameter WHERE run_id = 1 AND parameter_hid = 17")
+	if len(d1) != 32 {
+		t.Fatal("Fail: invalid parameter run value digest:", d1)
+	}
+	if s := selectStr("SELECT value_digest FROM run_parameter WHERE run_id = 2 AND parameter_hid = 17"); s != d1 {
+		t.Errorf("Fail: invalid linked run parameter value digest: %s expected: %s", s, d1)
+	}
+	if s := selectStr("SELECT value_digest FROM workset_parameter WHERE set_id = 1 AND parameter_hid = 17"); s != d1 {
+		t.Errorf("Fail: invalid workset parameter value digest: %s expected: %s", s, d1)
+	}
+	if s := selectStr("SELECT value_digest FROM run_lst WHERE run_id = 1"); len(s) != 32 {
+		t.Error("Fail: run value digest not updated:", s)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// initial and max delay between retries if database is locked, delay is doubled on each retry
+const (
+	lockedRetryDelay    = 500 * time.Millisecond
+	lockedRetryMaxDelay = 30 * time.Second
+)
+
+// IsLockedError return true if error is database locked or busy error, e.g.: SQLite database is locked by running model.
+func IsLockedError(err error) bool {
+	return err != nil && errors.Is(toLockedError(err), ErrLocked)
+}
+
+// RetryIfLocked call fn() and retry it with exponential backoff while fn() return database locked error.
+// Retry stops after total wait time exceeded and last error returned.
+// If wait time is not positive then fn() is called only once.
+func RetryIfLocked(wait time.Duration, fn func() error) error {
+
+	err := fn()
+	if wait <= 0 || !IsLockedError(err) {
+		return err
+	}
+
+	tEnd := time.Now().Add(wait)
+	delay := lockedRetryDelay
+
+	for n := 1; IsLockedError(err); n++ {
+
+		d := time.Until(tEnd)
+		if d <= 0 {
+			return newDbError(ErrLocked, "error: database is locked, retry time exceeded: "+err.Error())
+		}
+		if delay < d {
+			d = delay
+		}
+		omppLog.Log("Database is locked, retry ", n, " after: ", d.String())
+		time.Sleep(d)
+
+		if delay *= 2; delay > lockedRetryMaxDelay {
+			delay = lockedRetryMaxDelay
+		}
+		err = fn()
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryIfLocked(t *testing.T) {
+
+	errLock := errors.New("failed to read parameter: database is locked")
+	errOther := errors.New("no such table: model_dic")
+
+	if !IsLockedError(errLock) || IsLockedError(errOther) || IsLockedError(nil) {
+		t.Fatal("invalid result of IsLockedError")
+	}
+
+	// no retry if wait time is zero or error is not a locked error
+	n := 0
+	if err := RetryIfLocked(0, func() error { n++; return errLock }); err != errLock || n != 1 {
+		t.Errorf("expected single call without retry: %d %v", n, err)
+	}
+	n = 0
+	if err := RetryIfLocked(time.Minute, func() error { n++; return errOther }); err != errOther || n != 1 {
+		t.Errorf("expected single call of not locked error: %d %v", n, err)
+	}
+
+	// retry until database unlocked
+	n = 0
+	err := RetryIfLocked(time.Minute, func() error {
+		if n++; n < 3 {
+			return errLock
+		}
+		return nil
+	})
+	if err != nil || n != 3 {
+		t.Errorf("expected success after retry: %d %v", n, err)
+	}
+
+	// retry until wait time exceeded
+	n = 0
+	err = RetryIfLocked(700*time.Millisecond, func() error { n++; return errLock })
+	if !errors.Is(err, ErrLocked) || n != 3 {
+		t.Errorf("expected locked error after retry time exceeded: %d %v", n, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"slices"
+	"strconv"
+	"sync/atomic"
+)
+
+// RunFilter is a hook to restrict visibility of model runs, e.g. to honor run access rules of hosted environment.
+//
+// If run filter is set then model run reads from run_lst table are restricted by SQL condition returned from RunWhere():
+// run row by id, digest, stamp or name, first and last runs, run lists and pages and run full metadata lists.
+// Run text, parameter notes, run options, progress, entity generations and output table statistics
+// are selected by run id only for visible runs.
+// Parameters, output tables and microdata values are read and calculated only from visible runs:
+// run row is selected by GetRun() before reading values or each run id is checked before calculation.
+type RunFilter interface {
+	// RunWhere return SQL condition on run_lst table columns using table alias, e.g.: H.run_name NOT LIKE 'private%'
+	// or empty "" string if there is no restrictions.
+	RunWhere(alias string) string
+}
+
+// RunFilterFunc is a function adapter to use ordinary function as run filter.
+type RunFilterFunc func(alias string) string
+
+// RunWhere return SQL condition on run_lst table columns, see RunFilter.
+func (f RunFilterFunc) RunWhere(alias string) string {
+	return f(alias)
+}
+
+// wrapper to store run filter interface
+type runFilterHolder struct {
+	f RunFilter
+}
+
+// current run filter, by default there is no run filter and all runs are visible
+var theRunFilter atomic.Pointer[runFilterHolder]
+
+// SetRunFilter set run filter hook to restrict visibility of model runs, if filter is nil then all runs are visible.
+// Run filter is the same for all database connections.
+func SetRunFilter(f RunFilter) {
+	if f == nil {
+		theRunFilter.Store(nil)
+		return
+	}
+	theRunFilter.Store(&runFilterHolder{f: f})
+}
+
+// return run filter condition on run_lst columns prefixed by AND: " AND (H.run_name <> 'private')"
+// or empty "" string if there is no run filter
+func runFilterAnd(alias string) string {
+
+	p := theRunFilter.Load()
+	if p == nil || p.f == nil {
+		return ""
+	}
+	if w := p.f.RunWhere(alias); w != "" {
+		return " AND (" + w + ")"
+	}
+	return ""
+}
+
+// return run filter condition on run id column prefixed by AND:
+// " AND EXISTS (SELECT RF.run_id FROM run_lst RF WHERE RF.run_id = M.run_id AND (RF.run_name <> 'private'))"
+// or empty "" string if there is no run filter
+func runFilterExists(runIdColumn string) string {
+
+	if rf := runFilterAnd("RF"); rf != "" {
+		return " AND EXISTS (SELECT RF.run_id FROM run_lst RF WHERE RF.run_id = " + runIdColumn + rf + ")"
+	}
+	return ""
+}
+
+// checkRunFilter return error if any of model run ids is not visible due to run filter.
+// If there is no run filter then all runs are visible and run ids are not checked.
+func checkRunFilter(dbConn *sql.DB, runIds ...int) error {
+
+	rf := runFilterAnd("H")
+	if rf == "" || len(runIds) <= 0 {
+		return nil
+	}
+
+	idLst := slices.Clone(runIds)
+	slices.Sort(idLst)
+	idLst = slices.Compact(idLst)
+
+	vLst := []int{}
+	err := SelectRows(dbConn,
+		"SELECT H.run_id FROM run_lst H WHERE ("+makeIdInList("H.run_id", idLst)+")"+rf,
+		func(rows *sql.Rows) error {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			vLst = append(vLst, id)
+			return nil
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	for _, id := range idLst {
+		if !slices.Contains(vLst, id) {
+			return newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(id))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunFilter(t *testing.T) {
+
+	defer SetRunFilter(nil)
+
+	if s := runFilterAnd("H"); s != "" {
+		t.Errorf("expected empty run filter: %s", s)
+	}
+
+	SetRunFilter(RunFilterFunc(func(alias string) string {
+		return alias + ".run_name <> " + ToQuoted("private")
+	}))
+	if s := runFilterAnd("H"); s != " AND (H.run_name <> 'private')" {
+		t.Errorf("invalid run filter: %s", s)
+	}
+	if s := runFilterAnd("M"); s != " AND (M.run_name <> 'private')" {
+		t.Errorf("invalid run filter: %s", s)
+	}
+
+	// empty condition: no restrictions
+	SetRunFilter(RunFilterFunc(func(alias string) string { return "" }))
+	if s := runFilterAnd("H"); s != "" {
+		t.Errorf("expected empty run filter: %s", s)
+	}
+
+	SetRunFilter(nil)
+	if s := runFilterAnd("H"); s != "" {
+		t.Errorf("expected empty run filter after reset: %s", s)
+	}
+}
+
+func TestRunFilterCalculate(t *testing.T) {
+
+	// completed runs 1 and 2 with output table values, run 2 is private
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageSex_v12345678 (run_id INT, expr_id INT, dim0 INT, dim1 INT, expr_value FLOAT)",
+		"CREATE TABLE ageSex_a12345678 (run_id INT, acc_id INT, sub_id INT, dim0 INT, dim1 INT, acc_value FLOAT)",
+		testModelSql(1, "m1", "m1"),
+		testRunSql(1, 1, 1, "s"),
+		testRunSql(2, 1, 1, "s"),
+		"INSERT INTO run_table (run_id, table_hid, base_run_id) VALUES (1, 12, 1), (2, 12, 2)",
+		"INSERT INTO ageSex_v12345678 VALUES (1, 0, 0, 0, 10), (2, 0, 0, 0, 15)",
+		"INSERT INTO ageSex_a12345678 VALUES (1, 0, 0, 0, 0, 10), (2, 0, 0, 0, 0, 15)",
+		"INSERT INTO run_txt (run_id, lang_id, descr, note) VALUES (2, 0, 'private run', NULL)",
+		"INSERT INTO run_option (run_id, option_key, option_value) VALUES (1, 'Parameter.A', '1'), (2, 'Parameter.A', '2')",
+	)
+	testUpdate(t, dbConn,
+		"INSERT INTO lang_lst (lang_id, lang_code, lang_name) VALUES (0, 'EN', 'English')",
+		"UPDATE run_lst SET run_name = 'private' WHERE run_id = 2",
+	)
+
+	ageType := &TypeMeta{TypeDicRow: TypeDicRow{Name: "age", IsRange: true, MinEnumId: 0, MaxEnumId: 1, sizeOf: 2}}
+	sexType := &TypeMeta{TypeDicRow: TypeDicRow{Name: "sex", sizeOf: 2}, Enum: []TypeEnumRow{{EnumId: 0}, {EnumId: 1}}}
+
+	modelDef := &ModelMeta{
+		Model: ModelDicRow{ModelId: 1, Digest: "m1"},
+		Type:  []TypeMeta{{TypeDicRow: TypeDicRow{Name: "double", Digest: "_double_"}}},
+		Table: []TableMeta{{
+			TableDicRow: TableDicRow{TableHid: 12, Name: "ageSex", Rank: 2, DbExprTable: "ageSex_v12345678", DbAccTable: "ageSex_a12345678"},
+			Dim: []TableDimsRow{
+				{DimId: 0, Name: "dim0", typeOf: ageType, colName: "dim0"},
+				{DimId: 1, Name: "dim1", typeOf: sexType, colName: "dim1"},
+			},
+			Acc:  []TableAccRow{{AccId: 0, Name: "acc0", colName: "acc0"}},
+			Expr: []TableExprRow{{ExprId: 0, Name: "expr0"}},
+		}},
+	}
+	calcLt := &ReadCalculteTableLayout{
+		ReadLayout:  ReadLayout{Name: "ageSex", FromId: 1},
+		Calculation: []CalculateTableLayout{{CalculateLayout: CalculateLayout{Calculate: "expr0[variant] - expr0[base]", CalcId: 12000, Name: "diff"}}},
+	}
+
+	// no run filter: all runs visible
+	if _, _, err := CalculateOutputTable(dbConn, modelDef, calcLt, []int{2}); err != nil {
+		t.Fatal(err)
+	}
+
+	defer SetRunFilter(nil)
+	SetRunFilter(RunFilterFunc(func(alias string) string {
+		return alias + ".run_name <> " + ToQuoted("private")
+	}))
+
+	// private run is not visible as variant run or as base run
+	if _, _, err := CalculateOutputTable(dbConn, modelDef, calcLt, []int{2}); !errors.Is(err, ErrRunNotFound) {
+		t.Error("Fail: expected model run not found error for private variant run, got:", err)
+	}
+	calcLt.FromId = 2
+	if _, _, err := CalculateOutputTable(dbConn, modelDef, calcLt, []int{1}); !errors.Is(err, ErrRunNotFound) {
+		t.Error("Fail: expected model run not found error for private base run, got:", err)
+	}
+	microLt := &ReadCalculteMicroLayout{
+		ReadLayout:           ReadLayout{Name: "Person", FromId: 1},
+		CalculateMicroLayout: CalculateMicroLayout{Calculation: []CalculateLayout{{Calculate: "OM_AVG(Income)", CalcId: 12000}}},
+	}
+	modelDef.Entity = []EntityMeta{{EntityDicRow: EntityDicRow{Name: "Person"}}}
+	if _, _, err := CalculateMicrodata(dbConn, modelDef, microLt, []int{2}); !errors.Is(err, ErrRunNotFound) {
+		t.Error("Fail: expected model run not found error for private microdata run, got:", err)
+	}
+
+	// run text, options and options difference of private run not visible
+	if txt, err := GetRunText(dbConn, 2, ""); err != nil || len(txt) != 0 {
+		t.Error("Fail: private run text must be empty:", txt, err)
+	}
+	if opts, err := GetRunOptions(dbConn, 2); err != nil || len(opts) != 0 {
+		t.Error("Fail: private run options must be empty:", opts, err)
+	}
+	if opts, err := GetRunOptions(dbConn, 1); err != nil || opts["Parameter.A"] != "1" {
+		t.Error("Fail: invalid run options:", opts, err)
+	}
+	if _, err := GetRunOptionsDiff(dbConn, []int{1, 2}); !errors.Is(err, ErrRunNotFound) {
+		t.Error("Fail: expected model run not found error for private run options, got:", err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+// RunOptionsDiff is run options of multiple model runs aligned by option key.
+// It is a matrix where rows are option keys and columns are model runs.
+type RunOptionsDiff struct {
+	RunIds []int               // model run id's, in the same order as values of each option row
+	Option []RunOptionsDiffRow // option rows sorted by option key
+}
+
+// RunOptionsDiffRow is a row of run options matrix: option key and option value in each model run.
+type RunOptionsDiffRow struct {
+	Key     string   // option key
+	Value   []string // option value for each run, empty if option not found in that run
+	IsFound []bool   // if true then option found in that run
+	IsDiff  bool     // if true then option value is different between runs or option not found in some of the runs
+}
+
+// GetRunOptionsDiff return run_option rows of model runs aligned by option key.
+//
+// Result contains all option keys of all runs, use IsDiff flag to select only options which are different between runs.
+func GetRunOptionsDiff(dbConn *sql.DB, runIds []int) (*RunOptionsDiff, error) {
+
+	if len(runIds) <= 0 {
+		return nil, errors.New("invalid (empty) list of model runs to compare options")
+	}
+
+	if err := checkRunFilter(dbConn, runIds...); err != nil {
+		return nil, err
+	}
+
+	rkv, err := getRunOpts(dbConn,
+		"SELECT run_id, option_key, option_value FROM run_option"+
+			" WHERE "+makeIdInList("run_id", runIds)+
+			" ORDER BY 1, 2")
+	if err != nil {
+		return nil, err
+	}
+	return MakeRunOptionsDiff(runIds, rkv), nil
+}
+
+// MakeRunOptionsDiff align run options by option key: map(run_id, map(key, value)) into matrix of option rows.
+func MakeRunOptionsDiff(runIds []int, runOpts map[int]map[string]string) *RunOptionsDiff {
+
+	// collect all option keys and sort it
+	keys := []string{}
+	km := map[string]bool{}
+
+	for _, rId := range runIds {
+		for key := range runOpts[rId] {
+			if !km[key] {
+				km[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	// make option rows: value of each option for each run
+	rd := RunOptionsDiff{
+		RunIds: append([]int{}, runIds...),
+		Option: make([]RunOptionsDiffRow, len(keys)),
+	}
+
+	for k, key := range keys {
+
+		r := RunOptionsDiffRow{
+			Key:     key,
+			Value:   make([]string, len(runIds)),
+			IsFound: make([]bool, len(runIds)),
+		}
+		for j, rId := range runIds {
+
+			r.Value[j], r.IsFound[j] = runOpts[rId][key]
+
+			if j > 0 && (r.IsFound[j] != r.IsFound[0] || r.Value[j] != r.Value[0]) {
+				r.IsDiff = true
+			}
+		}
+		rd.Option[k] = r
+	}
+
+	return &rd
+}
+
+// DiffOnly return options which are different between runs
+func (rd *RunOptionsDiff) DiffOnly() []RunOptionsDiffRow {
+
+	dLst := []RunOptionsDiffRow{}
+	for k := range rd.Option {
+		if rd.Option[k].IsDiff {
+			dLst = append(dLst, rd.Option[k])
+		}
+	}
+	return dLst
+}
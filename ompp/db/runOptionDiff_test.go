@@ -0,0 +1,40 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestMakeRunOptionsDiff(t *testing.T) {
+
+	runOpts := map[int]map[string]string{
+		11: {"OpenM.SubValues": "16", "OpenM.Threads": "4", "Parameter.StartingSeed": "1"},
+		22: {"OpenM.SubValues": "16", "OpenM.Threads": "8"},
+		33: {"OpenM.SubValues": "16", "OpenM.Threads": "4"},
+	}
+
+	rd := MakeRunOptionsDiff([]int{11, 22, 33}, runOpts)
+
+	if len(rd.RunIds) != 3 || len(rd.Option) != 3 {
+		t.Fatalf("invalid options matrix size: %d runs %d options", len(rd.RunIds), len(rd.Option))
+	}
+	if rd.Option[0].Key != "OpenM.SubValues" || rd.Option[1].Key != "OpenM.Threads" || rd.Option[2].Key != "Parameter.StartingSeed" {
+		t.Errorf("invalid options order: %s %s %s", rd.Option[0].Key, rd.Option[1].Key, rd.Option[2].Key)
+	}
+	if rd.Option[0].IsDiff {
+		t.Errorf("expected same option values: %+v", rd.Option[0])
+	}
+	if !rd.Option[1].IsDiff || rd.Option[1].Value[1] != "8" {
+		t.Errorf("expected different option values: %+v", rd.Option[1])
+	}
+	if !rd.Option[2].IsDiff || !rd.Option[2].IsFound[0] || rd.Option[2].IsFound[1] || rd.Option[2].Value[1] != "" {
+		t.Errorf("expected option not found in some runs: %+v", rd.Option[2])
+	}
+
+	dLst := rd.DiffOnly()
+	if len(dLst) != 2 || dLst[0].Key != "OpenM.Threads" {
+		t.Errorf("invalid list of different options: %+v", dLst)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// TableExprStats is precomputed statistics of output table expression values in model run:
+// count of not NULL values, min, max and mean, e.g. to make color scale of heatmap without reading table values.
+//
+// Statistics stored in run_table_stats table, it is created by sql/create_db.sql script
+// or by sql/upgrade_side_tables.sql for existing database:
+//
+//	CREATE TABLE run_table_stats
+//	(
+//	  run_id      INT    NOT NULL, -- model run id
+//	  table_hid   INT    NOT NULL, -- output table unique id
+//	  expr_id     INT    NOT NULL, -- output table expression id
+//	  value_count BIGINT NOT NULL, -- count of not NULL values
+//	  min_value   FLOAT  NULL,     -- min of values, NULL if there are no values
+//	  max_value   FLOAT  NULL,     -- max of values, NULL if there are no values
+//	  mean_value  FLOAT  NULL,     -- mean of values, NULL if there are no values
+//	  PRIMARY KEY (run_id, table_hid, expr_id)
+//	)
+type TableExprStats struct {
+	RunId    int     // model run id
+	TableHid int     // output table unique id
+	ExprId   int     // output table expression id
+	Count    int64   // count of not NULL values
+	IsNull   bool    // if true then there are no values and Min, Max, Mean are zero
+	Min      float64 // min of values
+	Max      float64 // max of values
+	Mean     float64 // mean of values
+}
+
+// output table expressions statistics db table name
+const tableStatsTable = "run_table_stats"
+
+// UpdateRunTableExprStats calculate and store statistics of all output table expressions of completed model run.
+// Existing statistics of model run is replaced.
+func UpdateRunTableExprStats(dbConn *sql.DB, modelDef *ModelMeta, runId int) error {
+
+	// validate parameters
+	if modelDef == nil {
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	rRow, err := GetRun(dbConn, runId)
+	if err != nil {
+		return err
+	}
+	if rRow == nil {
+		return newDbError(ErrRunNotFound, "model run not found, id: "+strconv.Itoa(runId))
+	}
+	if rRow.ModelId != modelDef.Model.ModelId {
+		return errors.New("model run " + strconv.Itoa(runId) + " invalid model id " + strconv.Itoa(rRow.ModelId) + " expected: " + strconv.Itoa(modelDef.Model.ModelId))
+	}
+	if !IsRunCompleted(rRow.Status) {
+		return errors.New("model run not completed: " + strconv.Itoa(runId) + " " + rRow.Name)
+	}
+
+	// statistics table must be created by database schema script
+	if !isTableSelectable(dbConn, tableStatsTable) {
+		return errors.New("output tables statistics table not found, database must be upgraded by sql/upgrade_side_tables.sql: " + tableStatsTable)
+	}
+
+	// calculate statistics of each expression of each output table
+	sId := strconv.Itoa(runId)
+	stLst := []TableExprStats{}
+
+	for k := range modelDef.Table {
+
+		table := &modelDef.Table[k]
+		sHid := strconv.Itoa(table.TableHid)
+
+		// SELECT expr_id, COUNT(expr_value), MIN(expr_value), MAX(expr_value), AVG(expr_value)
+		// FROM salarySex_v2012_820
+		// WHERE run_id = (SELECT base_run_id FROM run_table WHERE run_id = 2 AND table_hid = 12345)
+		// GROUP BY expr_id
+		tS := map[int]bool{}
+
+		err = SelectRows(dbConn,
+			"SELECT expr_id, COUNT(expr_value), MIN(expr_value), MAX(expr_value), AVG(expr_value)"+
+				" FROM "+table.DbExprTable+
+				" WHERE run_id = (SELECT base_run_id FROM run_table WHERE run_id = "+sId+" AND table_hid = "+sHid+")"+
+				" GROUP BY expr_id"+
+				" ORDER BY 1",
+			func(rows *sql.Rows) error {
+				var st = TableExprStats{RunId: runId, TableHid: table.TableHid}
+				var vMin, vMax, vMean sql.NullFloat64
+				if err := rows.Scan(&st.ExprId, &st.Count, &vMin, &vMax, &vMean); err != nil {
+					return err
+				}
+				st.IsNull = !vMin.Valid || !vMax.Valid || !vMean.Valid
+				if !st.IsNull {
+					st.Min, st.Max, st.Mean = vMin.Float64, vMax.Float64, vMean.Float64
+				}
+				tS[st.ExprId] = true
+				stLst = append(stLst, st)
+				return nil
+			})
+		if err != nil {
+			return errors.New("failed to calculate output table statistics: " + table.Name + ": " + err.Error())
+		}
+
+		// expressions without any rows: count is zero
+		for j := range table.Expr {
+			if !tS[table.Expr[j].ExprId] {
+				stLst = append(stLst, TableExprStats{RunId: runId, TableHid: table.TableHid, ExprId: table.Expr[j].ExprId, IsNull: true})
+			}
+		}
+	}
+
+	// replace run statistics in transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = TrxUpdate(trx, "DELETE FROM "+tableStatsTable+" WHERE run_id = "+sId); err != nil {
+		trx.Rollback()
+		return err
+	}
+
+	// INSERT INTO run_table_stats (run_id, table_hid, expr_id, value_count, min_value, max_value, mean_value) VALUES (2, ?, ?, ?, ?, ?, ?)
+	nIdx := 0
+	put := func() (bool, []interface{}, error) {
+
+		if nIdx >= len(stLst) {
+			return false, nil, nil // end of data
+		}
+		st := &stLst[nIdx]
+		nIdx++
+
+		r := []interface{}{st.TableHid, st.ExprId, st.Count, nil, nil, nil}
+		if !st.IsNull {
+			r[3], r[4], r[5] = st.Min, st.Max, st.Mean
+		}
+		return true, r, nil
+	}
+	err = TrxUpdateStatement(trx,
+		"INSERT INTO "+tableStatsTable+
+			" (run_id, table_hid, expr_id, value_count, min_value, max_value, mean_value)"+
+			" VALUES ("+sId+", ?, ?, ?, ?, ?, ?)",
+		put)
+	if err != nil {
+		trx.Rollback()
+		return errors.New("failed to insert output tables statistics: " + err.Error())
+	}
+	trx.Commit()
+
+	return nil
+}
+
+// GetRunTableExprStats return statistics of output table expressions in model run, ordered by expression id.
+// Return empty list if statistics not calculated for that model run.
+func GetRunTableExprStats(dbConn *sql.DB, runId int, tableHid int) ([]TableExprStats, error) {
+
+	if !isTableSelectable(dbConn, tableStatsTable) {
+		return []TableExprStats{}, nil // statistics table not exists
+	}
+
+	stLst := []TableExprStats{}
+
+	err := SelectRows(dbConn,
+		"SELECT M.run_id, M.table_hid, M.expr_id, M.value_count, M.min_value, M.max_value, M.mean_value FROM "+tableStatsTable+" M"+
+			" WHERE M.run_id = "+strconv.Itoa(runId)+
+			" AND M.table_hid = "+strconv.Itoa(tableHid)+
+			runFilterExists("M.run_id")+
+			" ORDER BY 3",
+		func(rows *sql.Rows) error {
+			var st TableExprStats
+			var vMin, vMax, vMean sql.NullFloat64
+			if err := rows.Scan(&st.RunId, &st.TableHid, &st.ExprId, &st.Count, &vMin, &vMax, &vMean); err != nil {
+				return err
+			}
+			st.IsNull = !vMin.Valid || !vMax.Valid || !vMean.Valid
+			if !st.IsNull {
+				st.Min, st.Max, st.Mean = vMin.Float64, vMax.Float64, vMean.Float64
+			}
+			stLst = append(stLst, st)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return stLst, nil
+}
+
+// delete output tables statistics of model run.
+// It does update as part of transaction.
+func trxDeleteRunTableExprStats(trx *sql.Tx, runId int) error {
+	return TrxUpdate(trx, "DELETE FROM "+tableStatsTable+" WHERE run_id = "+strconv.Itoa(runId))
+}
+
+// delete output tables statistics of all model runs.
+// It does update as part of transaction.
+func trxDeleteModelTableExprStats(trx *sql.Tx, modelId int) error {
+	return TrxUpdate(trx,
+		"DELETE FROM "+tableStatsTable+" WHERE EXISTS"+
+			" (SELECT run_id FROM run_lst M WHERE M.run_id = "+tableStatsTable+".run_id AND M.model_id = "+strconv.Itoa(modelId)+")")
+}
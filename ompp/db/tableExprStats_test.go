@@ -0,0 +1,83 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestRunTableExprStats(t *testing.T) {
+
+	// completed run id 2 use output table values of base run id 1, expression 1 values are all NULL
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageSex_v12345678 (run_id INT, expr_id INT, dim0 INT, expr_value FLOAT)",
+		testRunSql(1, 1, 1, "s"),
+		testRunSql(2, 1, 1, "s"),
+		testRunSql(3, 1, 1, "p"),
+		"INSERT INTO run_table (run_id, table_hid, base_run_id) VALUES (1, 12, 1), (2, 12, 1)",
+		"INSERT INTO ageSex_v12345678 VALUES (1, 0, 0, 1.5), (1, 0, 1, -2.5), (1, 0, 2, 4), (1, 0, 3, NULL), (1, 1, 0, NULL)",
+	)
+
+	modelDef := &ModelMeta{
+		Model: ModelDicRow{ModelId: 1},
+		Table: []TableMeta{{
+			TableDicRow: TableDicRow{TableHid: 12, Name: "ageSex", DbExprTable: "ageSex_v12345678"},
+			Expr:        []TableExprRow{{ExprId: 0}, {ExprId: 1}, {ExprId: 2}},
+		}},
+	}
+
+	// there are no statistics before update
+	stLst, err := GetRunTableExprStats(dbConn, 2, 12)
+	if err != nil || len(stLst) != 0 {
+		t.Fatalf("expected empty statistics: %v %v", stLst, err)
+	}
+
+	// statistics can be calculated only for completed run
+	if err = UpdateRunTableExprStats(dbConn, modelDef, 3); err == nil {
+		t.Error("expected error at statistics of not completed run")
+	}
+
+	// calculate statistics twice: it must be replaced
+	for range 2 {
+		if err = UpdateRunTableExprStats(dbConn, modelDef, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stLst, err = GetRunTableExprStats(dbConn, 2, 12); err != nil {
+		t.Fatal(err)
+	}
+	if len(stLst) != 3 {
+		t.Fatalf("invalid statistics size: %v", stLst)
+	}
+	if st := stLst[0]; st.ExprId != 0 || st.Count != 3 || st.IsNull || st.Min != -2.5 || st.Max != 4 || st.Mean != 1 {
+		t.Errorf("invalid expression 0 statistics: %v", st)
+	}
+	if st := stLst[1]; st.ExprId != 1 || st.Count != 0 || !st.IsNull {
+		t.Errorf("invalid expression 1 statistics: %v", st)
+	}
+	if st := stLst[2]; st.ExprId != 2 || st.Count != 0 || !st.IsNull {
+		t.Errorf("invalid expression 2 statistics: %v", st)
+	}
+
+	// statistics deleted with the run
+	if err = DeleteRun(dbConn, 2); err != nil {
+		t.Fatal(err)
+	}
+	if stLst, err = GetRunTableExprStats(dbConn, 2, 12); err != nil || len(stLst) != 0 {
+		t.Errorf("expected empty statistics after run delete: %v %v", stLst, err)
+	}
+
+	// statistics deleted with the model
+	if err = UpdateRunTableExprStats(dbConn, modelDef, 1); err != nil {
+		t.Fatal(err)
+	}
+	testUpdate(t, dbConn, testModelSql(1, "modelOne", "m1"))
+
+	if err = DeleteModel(dbConn, 1); err != nil {
+		t.Fatal(err)
+	}
+	if stLst, err = GetRunTableExprStats(dbConn, 1, 12); err != nil || len(stLst) != 0 {
+		t.Errorf("expected empty statistics after model delete: %v %v", stLst, err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// path to openM++ database schema script, relative to ompp/db package directory
+const testSchemaPath = "../../sql/create_db.sql"
+
+// openTestDb return connection to new in-memory SQLite database with openM++ schema created by sql/create_db.sql script.
+// Optional sql statements are executed after schema created, for example: INSERT INTO run_lst or CREATE TABLE of model values.
+func openTestDb(t *testing.T, sqls ...string) *sql.DB {
+	t.Helper()
+
+	bt, err := os.ReadFile(testSchemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbConn.Close() })
+	dbConn.SetMaxOpenConns(1) // each connection to in-memory database is a new database
+
+	testUpdate(t, dbConn, splitSqlScript(string(bt))...)
+	testUpdate(t, dbConn, sqls...)
+
+	return dbConn
+}
+
+// testUpdate execute each sql statement, test fails on first error
+func testUpdate(t *testing.T, dbConn *sql.DB, sqls ...string) {
+	t.Helper()
+
+	for _, q := range sqls {
+		if err := Update(dbConn, q); err != nil {
+			t.Fatal(err, ": ", q)
+		}
+	}
+}
+
+// testModelSql return sql to insert model_dic row, model default language id is zero
+func testModelSql(modelId int, name, digest string) string {
+	return "INSERT INTO model_dic (model_id, model_name, model_digest, model_type, model_ver, create_dt, default_lang_id)" +
+		" VALUES (" + strconv.Itoa(modelId) + ", " + ToQuoted(name) + ", " + ToQuoted(digest) + ", 0, '1.0', '2026-01-01 00:00:00.000', 0)"
+}
+
+// testRunSql return sql to insert run_lst row: all sub-values completed, run name, digest and stamp made from run id
+func testRunSql(runId, modelId, subCount int, status string) string {
+	sId := strconv.Itoa(runId)
+	sc := strconv.Itoa(subCount)
+	return "INSERT INTO run_lst" +
+		" (run_id, model_id, run_name, sub_count, sub_started, sub_completed, sub_restart, create_dt, status, update_dt, run_digest, value_digest, run_stamp)" +
+		" VALUES (" + sId + ", " + strconv.Itoa(modelId) + ", 'run_" + sId + "', " + sc + ", " + sc + ", " + sc + ", 0," +
+		" '2026-01-01 00:00:00.000', " + ToQuoted(status) + ", '2026-01-01 00:00:00.000', 'd" + sId + "', NULL, 's" + sId + "')"
+}
+
+// testWorksetSql return sql to insert workset_lst row without base run
+func testWorksetSql(setId, modelId int, name string, isReadonly bool) string {
+	return "INSERT INTO workset_lst (set_id, base_run_id, model_id, set_name, is_readonly, update_dt)" +
+		" VALUES (" + strconv.Itoa(setId) + ", NULL, " + strconv.Itoa(modelId) + ", " + ToQuoted(name) + ", " + toBoolSqlConst(isReadonly) + ", '2026-01-01 00:00:00.000')"
+}
+
+// splitSqlScript remove -- comments and return list of sql statements delimited by ;
+func splitSqlScript(script string) []string {
+
+	var sb strings.Builder
+	for _, ln := range strings.Split(strings.ReplaceAll(script, "\r", ""), "\n") {
+		if n := strings.Index(ln, "--"); n >= 0 {
+			ln = ln[:n]
+		}
+		sb.WriteString(ln)
+		sb.WriteString("\n")
+	}
+
+	sqls := []string{}
+	for _, q := range strings.Split(sb.String(), ";") {
+		if q = strings.TrimSpace(q); q != "" {
+			sqls = append(sqls, q)
+		}
+	}
+	return sqls
+}
+
+func TestSchemaScript(t *testing.T) {
+
+	dbConn := openTestDb(t)
+
+	if err := CheckOpenmppSchemaVersion(dbConn); err != nil {
+		t.Fatal(err)
+	}
+	mLst, err := GetModelList(dbConn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mLst) != 0 {
+		t.Error("Fail: expected empty list of models:", len(mLst))
+	}
+}
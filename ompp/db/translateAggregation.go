@@ -110,8 +110,8 @@ func parseAggrCalculation(
 			//  for each accumulator name check if name exist in that unquoted part of sql
 			for k := 0; k < len(aggrCols); k++ {
 
-				if findNamePos(src[nStart:nEnd], aggrCols[k].name) >= 0 {
-					return errors.New("error: top level columns must be inside of aggregation function: " + aggrCols[k].name + " : " + calculateExpr)
+				if n := findNamePos(src[nStart:nEnd], aggrCols[k].name); n >= 0 {
+					return newExprError("top level columns must be inside of aggregation function: "+aggrCols[k].name, src, nStart+n, aggrCols[k].name)
 				}
 			}
 			nStart = nEnd // to the next 'unquoted part' of calculation string
@@ -437,7 +437,7 @@ func (lps *levelParseState) processParamColumns(
 func (lps *levelParseState) translateAggregationFnc(name, arg string, src string) (string, error) {
 
 	if len(arg) <= 0 {
-		return "", errors.New("invalid (empty) function argument: " + name + " : " + src)
+		return "", newExprError("invalid (empty) function argument: "+name, src, -1, name)
 	}
 
 	// translate function argument
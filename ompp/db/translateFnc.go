@@ -69,7 +69,7 @@ func findFirstFnc(src string, fncNameLst []string) (string, int, string, int, er
 	// find open and closing bracket at the same level
 	nAfter := namePos + len(fncNameLst[nFnc])
 	if nAfter >= len(src) {
-		return "", 0, "", 0, errors.New("Error in expression, missing brackets after: " + fncNameLst[nFnc] + ": " + src)
+		return "", 0, "", 0, newExprError("missing brackets after: "+fncNameLst[nFnc], src, namePos, fncNameLst[nFnc], "(")
 	}
 	level := 0
 	nOpen := -1
@@ -87,13 +87,13 @@ func findFirstFnc(src string, fncNameLst []string) (string, int, string, int, er
 		}
 
 		if !isInside && level <= 0 && !unicode.IsSpace(c) {
-			return "", 0, "", 0, errors.New("Error in expression, missing brackets after: " + fncNameLst[nFnc] + ": " + src)
+			return "", 0, "", 0, newExprError("missing brackets after: "+fncNameLst[nFnc], src, nAfter+n, string(c), "(")
 		}
 
 		if !isInside && c == ')' { // close bracket: up to previous level
 			level--
 			if level < 0 {
-				return "", 0, "", 0, errors.New("Error in expression, unbalanced brackets after: " + fncNameLst[nFnc] + ": " + src)
+				return "", 0, "", 0, newExprError("unbalanced brackets after: "+fncNameLst[nFnc], src, nAfter+n, ")")
 			}
 			if level == 0 {
 				nClose = nAfter + n
@@ -107,10 +107,13 @@ func findFirstFnc(src string, fncNameLst []string) (string, int, string, int, er
 		}
 	}
 	if level != 0 {
-		return "", 0, "", 0, errors.New("Error in expression, unbalanced brackets after: " + fncNameLst[nFnc] + ": " + src)
+		if isInside {
+			return "", 0, "", 0, newExprError("unbalanced SQL 'quotes' after: "+fncNameLst[nFnc], src, namePos, fncNameLst[nFnc], "'")
+		}
+		return "", 0, "", 0, newExprError("unbalanced brackets after: "+fncNameLst[nFnc], src, namePos, fncNameLst[nFnc], ")")
 	}
 	if nOpen < nAfter || nClose < nAfter || nOpen >= nClose || nClose >= len(src) {
-		return "", 0, "", 0, errors.New("Error in expression, missing brackets after: " + fncNameLst[nFnc] + ": " + src)
+		return "", 0, "", 0, newExprError("missing brackets after: "+fncNameLst[nFnc], src, namePos, fncNameLst[nFnc], "(", ")")
 	}
 
 	return fncNameLst[nFnc], namePos, src[nOpen+1 : nClose], nClose + 1, nil
@@ -205,7 +208,7 @@ func findFirstNameFnc(src string, fncNameLst []string) (int, int, error) {
 func translateSimpleFnc(name, arg string, src string) (string, error) {
 
 	if len(arg) <= 0 {
-		return "", errors.New("invalid (empty) function argument: " + name + " : " + src)
+		return "", newExprError("invalid (empty) function argument: "+name, src, -1, name)
 	}
 
 	switch name {
@@ -71,19 +71,19 @@ func errorIfUnsafeSqlOrComment(sql string) error {
 		}
 
 		// check if there contains semicolon or comment
-		if strings.Contains(sql[nStart:nEnd], ";") {
-			return errors.New("Error in expression, semicolon found: " + sql)
+		if n := strings.Index(sql[nStart:nEnd], ";"); n >= 0 {
+			return newExprError("semicolon found", sql, nStart+n, ";")
 		}
-		if strings.Contains(sql[nStart:nEnd], "--") {
-			return errors.New("Error in expression, SQL -- comment found: " + sql)
+		if n := strings.Index(sql[nStart:nEnd], "--"); n >= 0 {
+			return newExprError("SQL -- comment found", sql, nStart+n, "--")
 		}
 
-		if strings.Contains(sql[nStart:nEnd], "\\") {
-			return errors.New("Error in expression, SQL \\ escape sequence found: " + sql)
+		if n := strings.Index(sql[nStart:nEnd], "\\"); n >= 0 {
+			return newExprError("SQL \\ escape sequence found", sql, nStart+n, "\\")
 		}
 
 		// check if there are any of sql keywords, which are not allowed
-		if err = errorIfUnsafeSqlKeyword(sql[nStart:nEnd]); err != nil {
+		if err = errorIfUnsafeSqlKeyword(sql, nStart, nEnd); err != nil {
 			return err
 		}
 
@@ -94,7 +94,10 @@ func errorIfUnsafeSqlOrComment(sql string) error {
 
 // return error if sql contains unsafe sql keyword outside of 'quotes', for example:
 // DELETE INSERT UPDATE CREATE DROP ALTER MERGE EXEC EXECUTE CALL GO
-func errorIfUnsafeSqlKeyword(sql string) error {
+// Only part of sql between nStart and nEnd positions is checked, error position is a position in sql.
+func errorIfUnsafeSqlKeyword(src string, nStart, nEnd int) error {
+
+	sql := src[nStart:nEnd]
 
 	unsafeSqlKeywords := [...]string{ // list is incomplete by nature
 		"ABORT",
@@ -153,13 +156,13 @@ func errorIfUnsafeSqlKeyword(sql string) error {
 			n = nc + j + len(w) // next char position after keyword
 
 			if n >= len(s) {
-				return errors.New("Error in expression, unsafe SQL keyword: " + w + " : " + sql)
+				return newExprError("unsafe SQL keyword: "+w, src, nStart+nc+j, w)
 			}
 			// else: it is not the end of string, check if next char is delimeter: space, math symbol, etc.
 
 			ce, _ := utf8.DecodeRuneInString(s[n:])
 			if unicode.IsSpace(ce) || unicode.IsPunct(ce) || unicode.IsControl(ce) || unicode.IsSymbol(ce) || unicode.IsMark(ce) {
-				return errors.New("Error in expression, unsafe SQL keyword: " + w + " : " + sql)
+				return newExprError("unsafe SQL keyword: "+w, src, nStart+nc+j, w)
 			}
 
 			nc = n // skip: it is not a keyword but a prefix
@@ -179,6 +182,7 @@ func skipIfQuoted(src string, startPos int) (int, error) {
 	}
 
 	isInside := false
+	nOpen := startPos
 
 	for k, c := range src[startPos:] {
 
@@ -191,11 +195,14 @@ func skipIfQuoted(src string, startPos int) (int, error) {
 
 		// else: this is begin or end of 'quoted' sql
 		isInside = !isInside
+		if isInside {
+			nOpen = startPos + k
+		}
 	}
 
 	// sql 'quotes' must be closed (paired)
 	if isInside {
-		return -1, errors.New("Error in expression, unbalanced SQL 'quotes' in: " + src)
+		return -1, newExprError("unbalanced SQL 'quotes'", src, nOpen, "'", "'")
 	}
 
 	// empty return: nothing after last closing 'quotes'
@@ -213,6 +220,7 @@ func nextUnquoted(src string, startPos int) (int, int, error) {
 	}
 
 	nPos := startPos
+	nOpen := startPos
 	isInside := false
 
 	for k, c := range src[startPos:] {
@@ -228,6 +236,7 @@ func nextUnquoted(src string, startPos int) (int, int, error) {
 			continue
 		}
 		// else start of 'quotes'
+		nOpen = startPos + k
 
 		if startPos+k > nPos { // found part of source string outside of sql 'quotes'
 			return nPos, startPos + k, nil
@@ -236,7 +245,7 @@ func nextUnquoted(src string, startPos int) (int, int, error) {
 
 	// sql 'quotes' must be closed (paired)
 	if isInside {
-		return -1, -1, errors.New("Error in expression, unbalanced SQL 'quotes' in: " + src)
+		return -1, -1, newExprError("unbalanced SQL 'quotes'", src, nOpen, "'", "'")
 	}
 
 	// if there is any part of the string after last closing 'quote' then return it as result
@@ -246,3 +255,17 @@ func nextUnquoted(src string, startPos int) (int, int, error) {
 	// else empty return: nothing after last closing 'quotes'
 	return startPos, -1, nil
 }
+
+// return list of run id's with fromId as the first element, if fromId is positive and not already in the list
+func appendFromId(fromId int, runIds []int) []int {
+
+	if fromId <= 0 {
+		return runIds
+	}
+	for _, rId := range runIds {
+		if rId == fromId {
+			return runIds
+		}
+	}
+	return append([]int{fromId}, runIds...)
+}
@@ -88,7 +88,7 @@ func partialTranslateToAccSql(
 	//
 	cteSql, mainSql, err := transalteAccAggrToSql(table, paramCols, calcLt.CalcId, calcLt.Calculate)
 	if err != nil {
-		return "", "", errors.New("Error at " + table.Name + " " + calcLt.Calculate + ": " + err.Error())
+		return "", "", exprErrorAt("Error at "+table.Name, calcLt.Calculate, err)
 	}
 
 	// make where clause and dimension filters:
@@ -96,27 +96,7 @@ func partialTranslateToAccSql(
 	// AND A.dim0 = .....
 
 	// append run id's
-	where := " WHERE A.run_id IN ("
-
-	if readLt.FromId > 0 {
-		isFound := false
-		for k := 0; !isFound && k < len(runIds); k++ {
-			isFound = runIds[k] == readLt.FromId
-		}
-		if !isFound {
-			where += strconv.Itoa(readLt.FromId)
-			if len(runIds) > 0 {
-				where += ", "
-			}
-		}
-	}
-	for k := 0; k < len(runIds); k++ {
-		if k > 0 {
-			where += ", "
-		}
-		where += strconv.Itoa(runIds[k])
-	}
-	where += ")"
+	where := " WHERE " + makeIdInList("A.run_id", appendFromId(readLt.FromId, runIds))
 
 	// append dimension enum code filters and value filter, if specified: A.dim1 = 'M' AND (calc_value < 1234 AND calc_id = 12001)
 	iDbl, ok := modelDef.TypeOfDouble()
@@ -261,13 +241,13 @@ func transalteAccAggrToSql(table *TableMeta, paramCols map[string]paramColumn, c
 
 		pCol, ok := paramCols[colKey]
 		if !ok {
-			return "", "", errors.New("Error: parameter not found: " + colKey)
+			return "", "", newExprError("parameter not found: "+colKey, colKey, 0, colKey)
 		}
 		if !pCol.isNumber || pCol.paramRow == nil {
-			return "", "", errors.New("Error: parameter must a be numeric scalar: " + colKey)
+			return "", "", newExprError("parameter must a be numeric scalar: "+colKey, colKey, 0, colKey)
 		}
 		if !isSimple || isVar {
-			return "", "", errors.New("Error: parameter cannot be a run comparison parameter[base] or parameter[variant]: " + colKey)
+			return "", "", newExprError("parameter cannot be a run comparison parameter[base] or parameter[variant]: "+colKey, colKey, 0, colKey)
 		}
 		sHid := strconv.Itoa(pCol.paramRow.ParamHid)
 
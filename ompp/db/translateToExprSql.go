@@ -95,7 +95,7 @@ func partialTranslateToExprSql(
 	//
 	cteSql, mainSql, isRunCompare, err := translateExprCalcToSql(table, paramCols, calcLt.CalcId, calcLt.Calculate)
 	if err != nil {
-		return []string{}, "", false, errors.New("Error at " + table.Name + " " + calcLt.Calculate + ": " + err.Error())
+		return []string{}, "", false, exprErrorAt("Error at "+table.Name, calcLt.Calculate, err)
 	}
 
 	// make where clause and dimension filters:
@@ -106,32 +106,11 @@ func partialTranslateToExprSql(
 	// append run id's
 	where := " WHERE"
 	if !isRunCompare {
-		where += " B.run_id IN ("
-
-		if readLt.FromId > 0 {
-
-			isFound := false
-			for k := 0; !isFound && k < len(runIds); k++ {
-				isFound = runIds[k] == readLt.FromId
-			}
-			if !isFound {
-				where += strconv.Itoa(readLt.FromId)
-				if len(runIds) > 0 {
-					where += ", "
-				}
-			}
-		}
+		where += " " + makeIdInList("B.run_id", appendFromId(readLt.FromId, runIds))
 	} else {
 		where += " B.run_id = " + strconv.Itoa(readLt.FromId)
-		where += " AND V.run_id IN ("
-	}
-	for k := 0; k < len(runIds); k++ {
-		if k > 0 {
-			where += ", "
-		}
-		where += strconv.Itoa(runIds[k])
+		where += " AND " + makeIdInList("V.run_id", runIds)
 	}
-	where += ")"
 
 	// append dimension enum code filters and value filter, if specified: B.dim1 = 'M' AND (calc_value < 1234 AND calc_id = 12001)
 	iDbl, ok := modelDef.TypeOfDouble()
@@ -251,10 +230,10 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 
 		pCol, ok := paramCols[colKey]
 		if !ok {
-			return "", "", errors.New("Error: parameter not found: " + colKey)
+			return "", "", newExprError("parameter not found: "+colKey, colKey, 0, colKey)
 		}
 		if !pCol.isNumber || pCol.paramRow == nil {
-			return "", "", errors.New("Error: parameter must a be numeric scalar: " + colKey)
+			return "", "", newExprError("parameter must a be numeric scalar: "+colKey, colKey, 0, colKey)
 		}
 
 		sqlName := ""
@@ -444,7 +423,7 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 
 					col, pJoin, e := makeParamColName(pColKey, false, false, "B")
 					if e != nil {
-						return []string{}, expr, false, errors.New("Error at: " + calculateExpr + " : " + e.Error())
+						return []string{}, expr, false, e
 					}
 
 					isNew := true
@@ -473,7 +452,7 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 
 					col, pJoin, e := makeParamColName(pColKey, false, true, "V")
 					if e != nil {
-						return []string{}, expr, false, errors.New("Error at: " + calculateExpr + " : " + e.Error())
+						return []string{}, expr, false, e
 					}
 
 					isNew := true
@@ -502,7 +481,7 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 
 					col, pJoin, e := makeParamColName(pKey, true, false, "B")
 					if e != nil {
-						return []string{}, expr, false, errors.New("Error at: " + calculateExpr + " : " + e.Error())
+						return []string{}, expr, false, e
 					}
 
 					isNew := true
@@ -531,19 +510,19 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 		!isSrcOnly && (isAnyBase && !isAnyVar || !isAnyBase && isAnyVar) ||
 		(baseMinIdx < 0 || baseMinIdx >= exprCount) ||
 		!isSrcOnly && (varMinIdx < 0 || varMinIdx >= exprCount) {
-		return []string{}, expr, false, errors.New("invalid (or mixed forms) of expression names used in: " + calculateExpr)
+		return []string{}, expr, false, newExprError("invalid (or mixed forms) of expression names", calculateExpr, -1, "")
 	}
 	if !isSrcOnly && !isAnyBase && !isAnyVar {
-		return []string{}, expr, false, errors.New("error: there are no expression names found in: " + calculateExpr)
+		return []string{}, expr, false, newExprError("there are no expression names found", calculateExpr, -1, "")
 	}
 
 	// validate parameter names:
 	// if it is run comparison then parameter name cannot be simple else parameter name cannot be [base] or [variant]
 	if !isSrcOnly && isParamSimple {
-		return []string{}, expr, false, errors.New("invalid use of parameter name in run comparison: " + calculateExpr)
+		return []string{}, expr, false, newExprError("invalid use of parameter name in run comparison", calculateExpr, -1, "param.", "param.Name[base]", "param.Name[variant]")
 	}
 	if isSrcOnly && (isParamBase || isParamVar) {
-		return []string{}, expr, false, errors.New("invalid use of parameter run comparison name in expression: " + calculateExpr)
+		return []string{}, expr, false, newExprError("invalid use of parameter run comparison name in expression", calculateExpr, -1, "")
 	}
 
 	// validate: expression should not have any param. or [base] or [variant]
@@ -565,7 +544,8 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 			isErr = unicode.IsSpace(r) || strings.ContainsRune(leftDelims, r)
 		}
 		if isErr {
-			return []string{}, expr, false, errors.New("invalid parameter name in expression: " + calculateExpr)
+			p, nm := exprNameAt(expr, nStart+n)
+			return []string{}, expr, false, newExprError("invalid parameter name: "+nm, expr, p, nm)
 		}
 
 		n = strings.Index(expr[nStart:nEnd], "[base]")
@@ -576,7 +556,8 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 			isErr = r == ',' || unicode.IsSpace(r) || strings.ContainsRune(rightDelims, r)
 		}
 		if isErr {
-			return []string{}, expr, false, errors.New("invalid use of [base] or invalid parameter name in expression: " + calculateExpr)
+			p, nm := exprNameAt(expr, nStart+n)
+			return []string{}, expr, false, newExprError("invalid use of [base] or invalid parameter name: "+nm, expr, p, nm)
 		}
 
 		n = strings.Index(expr[nStart:nEnd], "[variant]")
@@ -587,7 +568,8 @@ func translateExprCalcToSql(table *TableMeta, paramCols map[string]paramColumn,
 			isErr = r == ',' || unicode.IsSpace(r) || strings.ContainsRune(rightDelims, r)
 		}
 		if isErr {
-			return []string{}, expr, false, errors.New("invalid use of [variant] or invalid parameter name in expression: " + calculateExpr)
+			p, nm := exprNameAt(expr, nStart+n)
+			return []string{}, expr, false, newExprError("invalid use of [variant] or invalid parameter name: "+nm, expr, p, nm)
 		}
 
 		nStart = nEnd // to the next 'unquoted part' of calculation string
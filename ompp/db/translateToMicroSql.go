@@ -160,7 +160,7 @@ func partialTranslateToMicroSql(
 	//
 	mainSql, isRunCompare, err := translateMicroCalcToSql(entity, entityGen, aggrCols, paramCols, calcLt.CalcId, calcLt.Calculate)
 	if err != nil {
-		return "", false, errors.New("Error at " + entity.Name + " " + calcLt.Calculate + ": " + err.Error())
+		return "", false, exprErrorAt("Error at "+entity.Name, calcLt.Calculate, err)
 	}
 
 	iDbl, ok := modelDef.TypeOfDouble()
@@ -346,10 +346,10 @@ func translateMicroCalcToSql(
 
 		pCol, ok := paramCols[colKey]
 		if !ok {
-			return "", "", errors.New("Error: parameter not found: " + colKey)
+			return "", "", newExprError("parameter not found: "+colKey, colKey, 0, colKey)
 		}
 		if !pCol.isNumber || pCol.paramRow == nil {
-			return "", "", errors.New("Error: parameter must a be numeric scalar: " + colKey)
+			return "", "", newExprError("parameter must a be numeric scalar: "+colKey, colKey, 0, colKey)
 		}
 
 		sqlName := ""
@@ -393,20 +393,20 @@ func translateMicroCalcToSql(
 
 	if isAttrSimple && (isAttrBase || isAttrVar) ||
 		!isAttrSimple && (isAttrBase && !isAttrVar || !isAttrBase && isAttrVar) {
-		return "", false, errors.New("invalid (or mixed forms) of attribute names used for aggregation of: " + entity.Name + ": " + calculateExpr)
+		return "", false, newExprError("invalid (or mixed forms) of attribute names used for aggregation of: "+entity.Name, calculateExpr, -1, "")
 	}
 	if !isAttrSimple && !isAttrBase && !isAttrVar {
-		return "", false, errors.New("error: there are no attribute names found for aggregation of: " + entity.Name + ": " + calculateExpr)
+		return "", false, newExprError("there are no attribute names found for aggregation of: "+entity.Name, calculateExpr, -1, "")
 	}
 	isCompare := isAttrBase && isAttrVar
 
 	// validate parameter names:
 	// if it is run comparison then parameter name cannot be simple else parameter name cannot be [base] or [variant]
 	if isCompare && isParamSimple {
-		return "", false, errors.New("invalid use of parameter name in microdata run comparison: " + entity.Name + ": " + calculateExpr)
+		return "", false, newExprError("invalid use of parameter name in microdata run comparison: "+entity.Name, calculateExpr, -1, "")
 	}
 	if !isCompare && (isParamBase || isParamVar) {
-		return "", false, errors.New("invalid use of parameter run comparison name in microdata aggregation: " + entity.Name + ": " + calculateExpr)
+		return "", false, newExprError("invalid use of parameter run comparison name in microdata aggregation: "+entity.Name, calculateExpr, -1, "")
 	}
 
 	// build main part of aggregation sql from parser state
@@ -630,14 +630,13 @@ func makeMicroCteAggrSql(
 		if len(runIds) <= 0 {
 			cteSql += " RE.run_id = " + strconv.Itoa(fromId)
 		} else {
-			cteSql += " RE.run_id IN (" + strconv.Itoa(fromId)
-
+			ids := []int{fromId}
 			for _, rId := range runIds {
 				if rId != fromId {
-					cteSql += ", " + strconv.Itoa(rId)
+					ids = append(ids, rId)
 				}
 			}
-			cteSql += ")"
+			cteSql += " " + makeIdInList("RE.run_id", ids)
 		}
 		cteSql += ")"
 	}
@@ -715,20 +714,15 @@ func makeMicroCteAggrSql(
 
 		cteSql += " FROM " + entityGen.DbEntityTable + " C" +
 			" INNER JOIN run_entity RE ON (RE.base_run_id = C.run_id AND RE.entity_gen_hid = " + strconv.Itoa(entityGen.GenHid) + ")" +
-			" WHERE RE.run_id IN ("
+			" WHERE "
 
-		isF := true
+		ids := []int{}
 		for _, rId := range runIds {
 			if rId != fromId {
-				if isF {
-					isF = false
-					cteSql += strconv.Itoa(rId)
-				} else {
-					cteSql += ", " + strconv.Itoa(rId)
-				}
+				ids = append(ids, rId)
 			}
 		}
-		cteSql += "))"
+		cteSql += makeIdInList("RE.run_id", ids) + ")"
 
 		// inner join of base and variant runs by entity_key
 		cteSql += ", abv (run_id"
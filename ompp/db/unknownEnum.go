@@ -0,0 +1,92 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// UnknownEnumPolicy is a policy to convert unknown enum id into enum code or label,
+// e.g. model run contains enum id which is deleted from model metadata.
+type UnknownEnumPolicy int32
+
+const (
+	UnknownEnumError UnknownEnumPolicy = iota // default: it is an error if enum id not found
+	UnknownEnumEmpty                          // convert unknown enum id into empty "" code or label
+	UnknownEnumId                             // convert unknown enum id into code or label as is, e.g.: 123
+)
+
+// UnknownEnumCount is a number of unknown enum id's converted by UnknownEnumEmpty or UnknownEnumId policy
+type UnknownEnumCount struct {
+	Name  string // parameter, output table or entity name and dimension or attribute name, e.g.: ageSex.dim0
+	Count int64  // number of values with unknown enum id
+}
+
+// current policy of unknown enum id's, by default it is an error
+var theUnknownEnum atomic.Int32
+
+// number of converted unknown enum id's by parameter or output table dimension or entity attribute name
+var theUnknownCount = struct {
+	sync.Mutex
+	count map[string]int64
+}{count: map[string]int64{}}
+
+// SetUnknownEnum set policy to convert unknown enum id into enum code or label.
+// It must be called before any database read, policy is the same for all database connections.
+func SetUnknownEnum(policy UnknownEnumPolicy) {
+	theUnknownEnum.Store(int32(policy))
+}
+
+// ParseUnknownEnum return unknown enum id policy from string: error (default), empty or id.
+func ParseUnknownEnum(src string) (UnknownEnumPolicy, error) {
+
+	switch strings.ToLower(strings.TrimSpace(src)) {
+	case "", "error":
+		return UnknownEnumError, nil
+	case "empty":
+		return UnknownEnumEmpty, nil
+	case "id":
+		return UnknownEnumId, nil
+	}
+	return UnknownEnumError, errors.New("invalid unknown enum policy, expected one of: error, empty or id, actual: " + src)
+}
+
+// UnknownEnumSummary return number of unknown enum id's converted into empty code or id,
+// sorted by parameter, output table or entity name and dimension or attribute name.
+func UnknownEnumSummary() []UnknownEnumCount {
+
+	theUnknownCount.Lock()
+	defer theUnknownCount.Unlock()
+
+	cLst := make([]UnknownEnumCount, 0, len(theUnknownCount.count))
+	for name, n := range theUnknownCount.count {
+		cLst = append(cLst, UnknownEnumCount{Name: name, Count: n})
+	}
+	sort.Slice(cLst, func(i, j int) bool { return cLst[i].Name < cLst[j].Name })
+	return cLst
+}
+
+// unknownEnumToCode convert unknown enum id into code or label using current unknown enum policy.
+// Return error if policy is UnknownEnumError.
+func unknownEnumToCode(itemId int, msgName string) (string, error) {
+
+	p := UnknownEnumPolicy(theUnknownEnum.Load())
+	if p != UnknownEnumEmpty && p != UnknownEnumId {
+		return "", errors.New("invalid value: " + strconv.Itoa(itemId) + " of: " + msgName)
+	}
+
+	theUnknownCount.Lock()
+	theUnknownCount.count[msgName]++
+	theUnknownCount.Unlock()
+
+	if p == UnknownEnumId {
+		return strconv.Itoa(itemId), nil
+	}
+	return "", nil
+}
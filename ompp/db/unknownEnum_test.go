@@ -0,0 +1,70 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"testing"
+)
+
+func TestUnknownEnum(t *testing.T) {
+
+	defer SetUnknownEnum(UnknownEnumError)
+
+	typeOf := &TypeMeta{
+		TypeDicRow: TypeDicRow{TypeId: maxBuiltInTypeId + 1, Name: "SEX"},
+		Enum:       []TypeEnumRow{{EnumId: 0, Name: "F"}, {EnumId: 1, Name: "M"}},
+	}
+	cvt, err := typeOf.itemIdToCode("ageSex.dim1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// default policy: unknown enum id is an error
+	if s, err := cvt(1); err != nil || s != "M" {
+		t.Error("Fail to convert enum id 1:", s, err)
+	}
+	if _, err = cvt(7); err == nil {
+		t.Error("Fail: expected error for unknown enum id 7")
+	}
+
+	SetUnknownEnum(UnknownEnumEmpty)
+	if s, err := cvt(7); err != nil || s != "" {
+		t.Error("Fail to convert unknown enum id into empty code:", s, err)
+	}
+
+	SetUnknownEnum(UnknownEnumId)
+	if s, err := cvt(7); err != nil || s != "7" {
+		t.Error("Fail to convert unknown enum id into id:", s, err)
+	}
+
+	// label converter is using the same policy
+	cvtLbl, err := typeOf.itemIdToLabel("en", []TypeEnumTxtRow{}, nil, "ageSex.dim1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := cvtLbl(8); err != nil || s != "8" {
+		t.Error("Fail to convert unknown enum id into label:", s, err)
+	}
+
+	// summary must contain all unknown values
+	isFound := false
+	for _, c := range UnknownEnumSummary() {
+		if c.Name == "ageSex.dim1" {
+			isFound = true
+			if c.Count < 3 {
+				t.Error("Fail: invalid count of unknown enum:", c.Count)
+			}
+		}
+	}
+	if !isFound {
+		t.Error("Fail: unknown enum summary not found: ageSex.dim1")
+	}
+
+	if _, err = ParseUnknownEnum("bad"); err == nil {
+		t.Error("Fail: expected error for invalid unknown enum policy")
+	}
+	if p, err := ParseUnknownEnum("ID"); err != nil || p != UnknownEnumId {
+		t.Error("Fail to parse unknown enum policy: id", p, err)
+	}
+}
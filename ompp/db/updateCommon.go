@@ -70,9 +70,17 @@ func cvtToSqlDbValue(isNullable bool, typeOf *TypeMeta, msgName string) func(boo
 			}
 			switch v := src.(type) {
 			case float64:
-				return sql.NullFloat64{Float64: v, Valid: !isNull}, nil
+				fv, isFn, err := floatToDb(v, isNullable, msgName)
+				if err != nil {
+					return nil, err
+				}
+				return sql.NullFloat64{Float64: fv, Valid: !isFn}, nil
 			case float32:
-				return sql.NullFloat64{Float64: float64(v), Valid: !isNull}, nil
+				fv, isFn, err := floatToDb(float64(v), isNullable, msgName)
+				if err != nil {
+					return nil, err
+				}
+				return sql.NullFloat64{Float64: fv, Valid: !isFn}, nil
 			case int:
 				return sql.NullFloat64{Float64: float64(v), Valid: !isNull}, nil
 			case uint:
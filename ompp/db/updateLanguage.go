@@ -117,7 +117,7 @@ func doUpdateLanguage(trx *sql.Tx, langDef *LangMeta) error {
 				})
 			switch {
 			case err == sql.ErrNoRows:
-				return errors.New("invalid destination database, likely not an openM++ database")
+				return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 			case err != nil:
 				return err
 			}
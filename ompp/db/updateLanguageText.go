@@ -0,0 +1,289 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// language-specific db table: table name, primary key columns except of lang_id and text columns
+type langTextTable struct {
+	name string   // db table name
+	keys []string // primary key columns, except of lang_id
+	cols []string // text columns
+}
+
+// all language-specific db tables, child tables first
+var langTextTables = []langTextTable{
+	{"task_txt", []string{"task_id"}, []string{"descr", "note"}},
+	{"workset_parameter_txt", []string{"set_id", "parameter_hid"}, []string{"note"}},
+	{"workset_txt", []string{"set_id"}, []string{"descr", "note"}},
+	{"run_parameter_txt", []string{"run_id", "parameter_hid"}, []string{"note"}},
+	{"run_txt", []string{"run_id"}, []string{"descr", "note"}},
+	{"entity_group_txt", []string{"model_id", "model_entity_id", "group_id"}, []string{"descr", "note"}},
+	{"entity_attr_txt", []string{"entity_hid", "attr_id"}, []string{"descr", "note"}},
+	{"entity_dic_txt", []string{"entity_hid"}, []string{"descr", "note"}},
+	{"group_txt", []string{"model_id", "group_id"}, []string{"descr", "note"}},
+	{"table_expr_txt", []string{"table_hid", "expr_id"}, []string{"descr", "note"}},
+	{"table_acc_txt", []string{"table_hid", "acc_id"}, []string{"descr", "note"}},
+	{"table_dims_txt", []string{"table_hid", "dim_id"}, []string{"descr", "note"}},
+	{"table_dic_txt", []string{"table_hid"}, []string{"descr", "note", "expr_descr", "expr_note"}},
+	{"parameter_dims_txt", []string{"parameter_hid", "dim_id"}, []string{"descr", "note"}},
+	{"parameter_dic_txt", []string{"parameter_hid"}, []string{"descr", "note"}},
+	{"type_enum_txt", []string{"type_hid", "enum_id"}, []string{"descr", "note"}},
+	{"type_dic_txt", []string{"type_hid"}, []string{"descr", "note"}},
+	{"model_dic_txt", []string{"model_id"}, []string{"descr", "note"}},
+	{"model_word", []string{"model_id", "word_code"}, []string{"word_value"}},
+}
+
+// AddLanguage insert new language into lang_lst and copy lang_word rows from source language.
+//
+// If fromLangCode is empty then lang_word rows copied from first language in lang_lst, e.g. from EN.
+// If isCopyText is true then all language-specific rows also copied from source language as placeholders for translation:
+// model words, descriptions and notes of model, types, parameters, output tables, entities, groups,
+// model runs, input sets and modeling tasks.
+// It is an error if language code already exist in database.
+func AddLanguage(dbConn *sql.DB, langCode, langName, fromLangCode string, isCopyText bool) error {
+
+	if langCode == "" {
+		return errors.New("invalid (empty) language code")
+	}
+	if langName == "" {
+		langName = langCode
+	}
+
+	// do update in transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = doAddLanguage(trx, langCode, langName, fromLangCode, isCopyText); err != nil {
+		trx.Rollback()
+		return err
+	}
+	trx.Commit()
+	return nil
+}
+
+// CopyLanguageText copy language-specific rows from source language into destination language.
+//
+// It copy lang_word, model_word rows and descriptions and notes of model, types, parameters, output tables,
+// entities, groups, model runs, input sets and modeling tasks.
+// Only rows which not exist in destination language are copied, existing translations are not changed.
+// Both languages must exist in database.
+func CopyLanguageText(dbConn *sql.DB, fromLangCode, langCode string) error {
+
+	if fromLangCode == "" || langCode == "" {
+		return errors.New("invalid (empty) language code")
+	}
+	if strings.EqualFold(fromLangCode, langCode) {
+		return errors.New("invalid language code, source and destination are the same: " + langCode)
+	}
+
+	// do update in transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = doCopyLanguageText(trx, fromLangCode, langCode, true); err != nil {
+		trx.Rollback()
+		return err
+	}
+	trx.Commit()
+	return nil
+}
+
+// DeleteLanguage delete language from lang_lst and all language-specific rows of that language.
+//
+// It delete lang_word, model_word rows and descriptions and notes of model, types, parameters, output tables,
+// entities, groups, model runs, input sets and modeling tasks.
+// Language cannot be deleted if it is default language of any model or it is the only language in database.
+// If language does not exist then nothing deleted and no errors returned, it is empty operation.
+func DeleteLanguage(dbConn *sql.DB, langCode string) error {
+
+	if langCode == "" {
+		return errors.New("invalid (empty) language code")
+	}
+
+	// delete inside of transaction scope
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err = doDeleteLanguage(trx, langCode); err != nil {
+		trx.Rollback()
+		return err
+	}
+	trx.Commit()
+	return nil
+}
+
+// doAddLanguage insert new language into lang_lst and copy lang_word and, optionally, all text rows from source language.
+// It does update as part of transaction
+func doAddLanguage(trx *sql.Tx, langCode, langName, fromLangCode string, isCopyText bool) error {
+
+	// check if language already exist
+	if _, ok, err := trxLangIdByCode(trx, langCode); err != nil {
+		return err
+	} else if ok {
+		return errors.New("language already exist: " + langCode)
+	}
+
+	// by default copy lang_word from the first language
+	if fromLangCode == "" {
+		err := TrxSelectFirst(trx,
+			"SELECT lang_code FROM lang_lst WHERE lang_id = (SELECT MIN(lang_id) FROM lang_lst)",
+			func(row *sql.Row) error {
+				return row.Scan(&fromLangCode)
+			})
+		switch {
+		case err == sql.ErrNoRows:
+			return newDbError(ErrNotOmppSchema, "invalid database: no language(s) found")
+		case err != nil:
+			return err
+		}
+	}
+
+	// insert into lang_lst
+	langDef := &LangMeta{Lang: []langWord{{LangLstRow: LangLstRow{LangCode: langCode, Name: langName}}}}
+
+	if err := doUpdateLanguage(trx, langDef); err != nil {
+		return err
+	}
+	return doCopyLanguageText(trx, fromLangCode, langCode, isCopyText)
+}
+
+// doCopyLanguageText copy lang_word rows and, if isCopyText is true, all language-specific rows into destination language.
+// Only rows which not exist in destination language are copied.
+// It does update as part of transaction
+func doCopyLanguageText(trx *sql.Tx, fromLangCode, langCode string, isCopyText bool) error {
+
+	srcId, ok, err := trxLangIdByCode(trx, fromLangCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("language not found: " + fromLangCode)
+	}
+	dstId, ok, err := trxLangIdByCode(trx, langCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("language not found: " + langCode)
+	}
+
+	tLst := []langTextTable{{"lang_word", []string{"word_code"}, []string{"word_value"}}}
+	if isCopyText {
+		tLst = append(tLst, langTextTables...)
+	}
+
+	// INSERT INTO run_txt (run_id, lang_id, descr, note)
+	// SELECT S.run_id, 1, S.descr, S.note FROM run_txt S
+	// WHERE S.lang_id = 0
+	// AND NOT EXISTS (SELECT * FROM run_txt D WHERE D.run_id = S.run_id AND D.lang_id = 1)
+	sSrc := strconv.Itoa(srcId)
+	sDst := strconv.Itoa(dstId)
+
+	for _, t := range tLst {
+
+		cols := strings.Join(t.keys, ", ") + ", lang_id, " + strings.Join(t.cols, ", ")
+		sel := "S." + strings.Join(t.keys, ", S.") + ", " + sDst + ", S." + strings.Join(t.cols, ", S.")
+
+		where := ""
+		for _, k := range t.keys {
+			where += "D." + k + " = S." + k + " AND "
+		}
+
+		err = TrxUpdate(trx,
+			"INSERT INTO "+t.name+" ("+cols+")"+
+				" SELECT "+sel+" FROM "+t.name+" S"+
+				" WHERE S.lang_id = "+sSrc+
+				" AND NOT EXISTS"+
+				" (SELECT * FROM "+t.name+" D WHERE "+where+"D.lang_id = "+sDst+")")
+		if err != nil {
+			return errors.New("failed to copy language rows of: " + t.name + ": " + err.Error())
+		}
+	}
+	return nil
+}
+
+// doDeleteLanguage delete language from lang_lst and all language-specific rows of that language.
+// It does update as part of transaction
+func doDeleteLanguage(trx *sql.Tx, langCode string) error {
+
+	langId, ok, err := trxLangIdByCode(trx, langCode)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil // language not found: nothing to delete
+	}
+	sId := strconv.Itoa(langId)
+
+	// language cannot be deleted if it is a default model language or the only language in database
+	mLst := []string{}
+	err = TrxSelectRows(trx,
+		"SELECT model_name FROM model_dic WHERE default_lang_id = "+sId+" ORDER BY model_id",
+		func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			mLst = append(mLst, name)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	if len(mLst) > 0 {
+		return errors.New("language " + langCode + " is default language of model(s): " + strings.Join(mLst, ", "))
+	}
+
+	nLang := 0
+	err = TrxSelectFirst(trx,
+		"SELECT COUNT(*) FROM lang_lst",
+		func(row *sql.Row) error {
+			return row.Scan(&nLang)
+		})
+	if err != nil {
+		return err
+	}
+	if nLang <= 1 {
+		return errors.New("language " + langCode + " is the only language in database")
+	}
+
+	// delete language-specific rows, lang_word and lang_lst row
+	for _, t := range langTextTables {
+		if err = TrxUpdate(trx, "DELETE FROM "+t.name+" WHERE lang_id = "+sId); err != nil {
+			return errors.New("failed to delete language rows of: " + t.name + ": " + err.Error())
+		}
+	}
+	if err = TrxUpdate(trx, "DELETE FROM lang_word WHERE lang_id = "+sId); err != nil {
+		return err
+	}
+	return TrxUpdate(trx, "DELETE FROM lang_lst WHERE lang_id = "+sId)
+}
+
+// return language id by language code and true or false if language not found.
+// It does select as part of transaction
+func trxLangIdByCode(trx *sql.Tx, langCode string) (int, bool, error) {
+
+	langId := 0
+	err := TrxSelectFirst(trx,
+		"SELECT lang_id FROM lang_lst WHERE lang_code = "+ToQuoted(langCode),
+		func(row *sql.Row) error {
+			return row.Scan(&langId)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, err
+	}
+	return langId, true, nil
+}
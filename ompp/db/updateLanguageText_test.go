@@ -0,0 +1,89 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestLanguageText(t *testing.T) {
+
+	dbConn := openTestDb(t,
+		"UPDATE id_lst SET id_value = 1 WHERE id_key = 'lang_id'",
+		"INSERT INTO lang_lst (lang_id, lang_code, lang_name) VALUES (0, 'EN', 'English'), (1, 'FR', 'Français')",
+		"INSERT INTO lang_word (lang_id, word_code, word_value) VALUES (0, 'all', 'All'), (0, 'max', 'Max'), (1, 'all', 'Tous')",
+		testModelSql(1, "modelOne", "m1"),
+	)
+	setFacetOf(dbConn, SqliteFacet)
+
+	// insert one EN row into each language-specific table
+	for _, lt := range langTextTables {
+		testUpdate(t, dbConn,
+			"INSERT INTO "+lt.name+" ("+strings.Join(lt.keys, ", ")+", lang_id, "+strings.Join(lt.cols, ", ")+")"+
+				" VALUES ("+strings.TrimSuffix(strings.Repeat("'1', ", len(lt.keys)), ", ")+", 0, "+strings.TrimSuffix(strings.Repeat("'text', ", len(lt.cols)), ", ")+")")
+	}
+
+	count := func(q string) int {
+		n := 0
+		if e := SelectFirst(dbConn, q, func(row *sql.Row) error { return row.Scan(&n) }); e != nil {
+			t.Fatal(e)
+		}
+		return n
+	}
+
+	// add new language: copy lang_word and all text rows from EN
+	err := AddLanguage(dbConn, "DE", "Deutsch", "EN", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = AddLanguage(dbConn, "DE", "Deutsch", "", false); err == nil {
+		t.Error("Fail: language added twice: DE")
+	}
+	if n := count("SELECT COUNT(*) FROM lang_lst WHERE lang_id = 2 AND lang_code = 'DE'"); n != 1 {
+		t.Error("Fail: language not found: DE", n)
+	}
+	if n := count("SELECT COUNT(*) FROM lang_word WHERE lang_id = 2"); n != 2 {
+		t.Error("Fail: invalid number of lang_word rows of DE:", n)
+	}
+	for _, lt := range langTextTables {
+		if n := count("SELECT COUNT(*) FROM " + lt.name + " WHERE lang_id = 2"); n != 1 {
+			t.Error("Fail: text row not copied:", lt.name, n)
+		}
+	}
+
+	// copy missing translations into FR: only lang_word max added
+	if err = CopyLanguageText(dbConn, "EN", "FR"); err != nil {
+		t.Fatal(err)
+	}
+	if n := count("SELECT COUNT(*) FROM lang_word WHERE lang_id = 1"); n != 2 {
+		t.Error("Fail: invalid number of lang_word rows of FR:", n)
+	}
+	if n := count("SELECT COUNT(*) FROM lang_word WHERE lang_id = 1 AND word_code = 'all' AND word_value = 'Tous'"); n != 1 {
+		t.Error("Fail: existing translation updated: FR all")
+	}
+	if n := count("SELECT COUNT(*) FROM run_txt WHERE lang_id = 1"); n != 1 {
+		t.Error("Fail: text row not copied into FR: run_txt", n)
+	}
+
+	// delete language and all text rows, default model language cannot be deleted
+	if err = DeleteLanguage(dbConn, "EN"); err == nil {
+		t.Error("Fail: deleted default model language: EN")
+	}
+	if err = DeleteLanguage(dbConn, "DE"); err != nil {
+		t.Fatal(err)
+	}
+	if n := count("SELECT COUNT(*) FROM lang_lst WHERE lang_code = 'DE'"); n != 0 {
+		t.Error("Fail: language not deleted: DE")
+	}
+	for _, lt := range append(langTextTables, langTextTable{name: "lang_word"}) {
+		if n := count("SELECT COUNT(*) FROM " + lt.name + " WHERE lang_id = 2"); n != 0 {
+			t.Error("Fail: text row not deleted:", lt.name, n)
+		}
+	}
+	if err = DeleteLanguage(dbConn, "DE"); err != nil {
+		t.Error("Fail: delete of not existing language:", err)
+	}
+}
@@ -49,6 +49,10 @@ func UpdateModel(dbConn *sql.DB, dbFacet Facet, modelDef *ModelMeta) (bool, erro
 		trx.Rollback()
 		return isExist, err
 	}
+	if err = trxCreateMapViews(trx, dbFacet, false); err != nil { // refresh model mapping views, if views exist
+		trx.Rollback()
+		return isExist, err
+	}
 	trx.Commit()
 	return isExist, nil
 }
@@ -88,7 +92,7 @@ func doInsertModel(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta) error {
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("invalid destination database, likely not an openM++ database")
+		return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 	case err != nil:
 		return err
 	}
@@ -164,7 +168,7 @@ func doInsertModel(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta) error {
 				})
 			switch {
 			case err == sql.ErrNoRows:
-				return errors.New("invalid destination database, likely not an openM++ database")
+				return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 			case err != nil:
 				return err
 			}
@@ -300,7 +304,7 @@ func doInsertModel(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta) error {
 				})
 			switch {
 			case err == sql.ErrNoRows:
-				return errors.New("invalid destination database, likely not an openM++ database")
+				return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 			case err != nil:
 				return err
 			}
@@ -477,7 +481,7 @@ func doInsertModel(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta) error {
 				})
 			switch {
 			case err == sql.ErrNoRows:
-				return errors.New("invalid destination database, likely not an openM++ database")
+				return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 			case err != nil:
 				return err
 			}
@@ -684,7 +688,7 @@ func doInsertModel(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta) error {
 				})
 			switch {
 			case err == sql.ErrNoRows:
-				return errors.New("invalid destination database, likely not an openM++ database")
+				return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 			case err != nil:
 				return err
 			}
@@ -29,11 +29,12 @@ func UpdateModelText(dbConn *sql.DB, modelDef *ModelMeta, langDef *LangMeta, mod
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err = doUpdateModelText(trx, modelDef, langDef, modelTxt); err != nil {
+	if err = doUpdateModelText(trx, dbFacet, modelDef, langDef, modelTxt); err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -44,7 +45,7 @@ func UpdateModelText(dbConn *sql.DB, modelDef *ModelMeta, langDef *LangMeta, mod
 // delete existing and insert new model text (description and notes) in database.
 // It does update as part of transaction
 // Model id, type Hid, parameter Hid, entity Hid, table Hid, language id updated with actual database id's
-func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, modelTxt *ModelTxtMeta) error {
+func doUpdateModelText(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, langDef *LangMeta, modelTxt *ModelTxtMeta) error {
 
 	// update model_dic_txt and ids
 	smId := strconv.Itoa(modelDef.Model.ModelId)
@@ -65,7 +66,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					smId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.ModelTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.ModelTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.ModelTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -106,7 +107,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(hId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.TypeTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TypeTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.TypeTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -139,7 +140,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(modelTxt.TypeEnumTxt[idx].EnumId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.TypeEnumTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TypeEnumTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.TypeEnumTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -172,7 +173,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(hId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.ParamTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.ParamTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.ParamTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -207,7 +208,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(modelTxt.ParamDimsTxt[idx].DimId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.ParamDimsTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.ParamDimsTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.ParamDimsTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -240,9 +241,9 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(hId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.TableTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TableTxt[idx].Note, noteDbMax)+", "+
+					dbFacet.noteToSql(modelTxt.TableTxt[idx].Note)+", "+
 					toQuotedMax(modelTxt.TableTxt[idx].ExprDescr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TableTxt[idx].ExprNote, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.TableTxt[idx].ExprNote)+")")
 			if err != nil {
 				return err
 			}
@@ -277,7 +278,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(modelTxt.TableDimsTxt[idx].DimId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.TableDimsTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TableDimsTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.TableDimsTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -312,7 +313,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(modelTxt.TableAccTxt[idx].AccId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.TableAccTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TableAccTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.TableAccTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -347,7 +348,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(modelTxt.TableExprTxt[idx].ExprId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.TableExprTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.TableExprTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.TableExprTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -380,7 +381,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(hId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.EntityTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.EntityTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.EntityTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -415,7 +416,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					strconv.Itoa(modelTxt.EntityAttrTxt[idx].AttrId)+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.EntityAttrTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.EntityAttrTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.EntityAttrTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -443,7 +444,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					sGrpId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.GroupTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.GroupTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.GroupTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -473,7 +474,7 @@ func doUpdateModelText(trx *sql.Tx, modelDef *ModelMeta, langDef *LangMeta, mode
 					sGrpId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(modelTxt.EntityGroupTxt[idx].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(modelTxt.EntityGroupTxt[idx].Note, noteDbMax)+")")
+					dbFacet.noteToSql(modelTxt.EntityGroupTxt[idx].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -151,11 +151,12 @@ func (meta *RunMeta) UpdateRun(dbConn *sql.DB, modelDef *ModelMeta, langDef *Lan
 	// else: run not exist
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return false, err
 	}
-	err = doInsertRun(trx, modelDef, meta, langDef, doubleFmt)
+	err = doInsertRun(trx, dbFacet, modelDef, meta, langDef, doubleFmt)
 	if err != nil {
 		trx.Rollback()
 		return false, err
@@ -380,7 +381,7 @@ func doUpdateRunValueDigest(trx *sql.Tx, runId int) (string, error) {
 // It does update as part of transaction.
 // Run status must be completed (success, exit or error) otherwise error returned.
 // Double format is used for progress value float conversion, if non-empty format supplied.
-func doInsertRun(trx *sql.Tx, modelDef *ModelMeta, meta *RunMeta, langDef *LangMeta, doubleFmt string) error {
+func doInsertRun(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *RunMeta, langDef *LangMeta, doubleFmt string) error {
 
 	// validate: run must be completed
 	if !IsRunCompleted(meta.Run.Status) {
@@ -422,7 +423,7 @@ func doInsertRun(trx *sql.Tx, modelDef *ModelMeta, meta *RunMeta, langDef *LangM
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("invalid destination database, likely not an openM++ database")
+		return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 	case err != nil:
 		return err
 	}
@@ -468,7 +469,7 @@ func doInsertRun(trx *sql.Tx, modelDef *ModelMeta, meta *RunMeta, langDef *LangM
 					srId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(meta.Txt[j].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[j].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[j].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -503,7 +504,7 @@ func doInsertRun(trx *sql.Tx, modelDef *ModelMeta, meta *RunMeta, langDef *LangM
 						srId+", "+
 						strconv.Itoa(meta.Param[k].ParamHid)+", "+
 						strconv.Itoa(lId)+", "+
-						toQuotedOrNullMax(meta.Param[k].Txt[j].Note, noteDbMax)+")")
+						dbFacet.noteToSql(meta.Param[k].Txt[j].Note)+")")
 				if err != nil {
 					return err
 				}
@@ -584,16 +585,17 @@ func (meta *RunMeta) UpdateRunText(dbConn *sql.DB, modelDef *ModelMeta, runId in
 	meta.Run.RunId = runId // run id exist: update run id in run metadata
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	err = doMergeRunHdrText(trx, runId, meta, langDef)
+	err = doMergeRunHdrText(trx, dbFacet, runId, meta, langDef)
 	if err != nil {
 		trx.Rollback()
 		return err
 	}
-	err = doMergeRunParameterText(trx, runId, meta.Param, langDef)
+	err = doMergeRunParameterText(trx, dbFacet, runId, meta.Param, langDef)
 	if err != nil {
 		trx.Rollback()
 		return err
@@ -662,11 +664,12 @@ func UpdateRunParameterText(dbConn *sql.DB, modelDef *ModelMeta, runId int, para
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	err = doMergeRunParameterText(trx, runId, paramLst, langDef)
+	err = doMergeRunParameterText(trx, dbFacet, runId, paramLst, langDef)
 	if err != nil {
 		trx.Rollback()
 		return err
@@ -679,7 +682,7 @@ func UpdateRunParameterText(dbConn *sql.DB, modelDef *ModelMeta, runId int, para
 // doMergeRunHdrText merge run text (description and notes) into run_txt by run_id.
 // It does update as part of transaction.
 // Run id of the input runTxt db rows updated with runId value.
-func doMergeRunHdrText(trx *sql.Tx, runId int, meta *RunMeta, langDef *LangMeta) error {
+func doMergeRunHdrText(trx *sql.Tx, dbFacet Facet, runId int, meta *RunMeta, langDef *LangMeta) error {
 
 	// update existing or insert new run_txt db rows
 	srId := strconv.Itoa(runId)
@@ -702,7 +705,7 @@ func doMergeRunHdrText(trx *sql.Tx, runId int, meta *RunMeta, langDef *LangMeta)
 					srId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(meta.Txt[k].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[k].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[k].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -715,7 +718,7 @@ func doMergeRunHdrText(trx *sql.Tx, runId int, meta *RunMeta, langDef *LangMeta)
 // It does update as part of transaction.
 // Run id of the input paramLst db rows updated with runId value.
 // If run does not exist or parameter not exist in the run then function does nothing.
-func doMergeRunParameterText(trx *sql.Tx, runId int, paramLst []runParam, langDef *LangMeta) error {
+func doMergeRunParameterText(trx *sql.Tx, dbFacet Facet, runId int, paramLst []runParam, langDef *LangMeta) error {
 
 	// update existing or insert new run_parameter_txt db rows
 	srId := strconv.Itoa(runId)
@@ -741,7 +744,7 @@ func doMergeRunParameterText(trx *sql.Tx, runId int, paramLst []runParam, langDe
 						srId+", "+
 						strconv.Itoa(paramLst[k].Txt[j].ParamHid)+", "+
 						strconv.Itoa(lId)+", "+
-						toQuotedOrNullMax(paramLst[k].Txt[j].Note, noteDbMax)+")")
+						dbFacet.noteToSql(paramLst[k].Txt[j].Note)+")")
 				if err != nil {
 					return err
 				}
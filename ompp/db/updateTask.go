@@ -75,11 +75,12 @@ func (meta *TaskMeta) UpdateTaskFull(dbConn *sql.DB, modelDef *ModelMeta, langDe
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err = doReplaceTaskFull(trx, modelDef, meta, langDef); err != nil {
+	if err = doReplaceTaskFull(trx, dbFacet, modelDef, meta, langDef); err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -91,7 +92,7 @@ func (meta *TaskMeta) UpdateTaskFull(dbConn *sql.DB, modelDef *ModelMeta, langDe
 // doReplaceTaskFull delete existing and insert new tasks and task run history in database.
 // It does update as part of transaction
 // Model id, task id, run id, set id updated with actual database id's.
-func doReplaceTaskFull(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
+func doReplaceTaskFull(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
 
 	// insert new or update existing task_lst master row by task name
 	isNew, err := doCreateTaskRow(trx, modelDef, meta)
@@ -102,7 +103,7 @@ func doReplaceTaskFull(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 
 	if isNew {
 		// insert new rows into task body tables: task_txt, task_set, task_run_lst, task_run_set
-		if err = doInsertTaskBody(trx, modelDef, meta, langDef); err != nil {
+		if err = doInsertTaskBody(trx, dbFacet, modelDef, meta, langDef); err != nil {
 			return err
 		}
 	} else {
@@ -126,7 +127,7 @@ func doReplaceTaskFull(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 		}
 
 		// insert new rows into task body tables: task_txt, task_set, task_run_lst, task_run_set
-		if err = doInsertTaskBody(trx, modelDef, meta, langDef); err != nil {
+		if err = doInsertTaskBody(trx, dbFacet, modelDef, meta, langDef); err != nil {
 			return err
 		}
 
@@ -145,7 +146,7 @@ func doReplaceTaskFull(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 // doInsertTaskBody insert new rows into task body tables: task_txt, task_set, task_run_lst, task_run_set
 // It does update as part of transaction
 // Task id and task run id updated with actual database id's.
-func doInsertTaskBody(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
+func doInsertTaskBody(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
 
 	stId := strconv.Itoa(meta.Task.TaskId)
 
@@ -163,7 +164,7 @@ func doInsertTaskBody(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 					stId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(meta.Txt[j].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[j].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[j].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -197,7 +198,7 @@ func doInsertTaskBody(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 			})
 		switch {
 		case err == sql.ErrNoRows:
-			return errors.New("invalid destination database, likely not an openM++ database")
+			return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 		case err != nil:
 			return err
 		}
@@ -266,11 +267,12 @@ func (meta *TaskMeta) ReplaceTaskDef(dbConn *sql.DB, modelDef *ModelMeta, langDe
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err = doReplaceTask(trx, modelDef, meta, langDef); err != nil {
+	if err = doReplaceTask(trx, dbFacet, modelDef, meta, langDef); err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -302,11 +304,12 @@ func (meta *TaskMeta) MergeTaskDef(dbConn *sql.DB, modelDef *ModelMeta, langDef
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	if err = doMergeTask(trx, modelDef, meta, langDef); err != nil {
+	if err = doMergeTask(trx, dbFacet, modelDef, meta, langDef); err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -318,7 +321,7 @@ func (meta *TaskMeta) MergeTaskDef(dbConn *sql.DB, modelDef *ModelMeta, langDef
 // doReplaceTask delete existing and insert new modeling task definition: task metadata and task input worksets.
 // It does update as part of transaction.
 // Model id and task id updated with actual database id's.
-func doReplaceTask(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
+func doReplaceTask(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
 
 	// insert new or update existing task_lst master row by task name
 	isNew, err := doCreateTaskRow(trx, modelDef, meta)
@@ -327,7 +330,7 @@ func doReplaceTask(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *La
 	}
 
 	// delete existing and insert new task text description and notes and task sets: task_txt and task_set rows
-	err = doReplaceTaskDef(trx, modelDef, meta, langDef)
+	err = doReplaceTaskDef(trx, dbFacet, modelDef, meta, langDef)
 	if err != nil {
 		return err
 	}
@@ -347,7 +350,7 @@ func doReplaceTask(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *La
 // doMergeTask update existing and insert new modeling task definition: task metadata and task input worksets.
 // It does update as part of transaction.
 // Model id and task id updated with actual database id's.
-func doMergeTask(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
+func doMergeTask(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
 
 	// insert new or update existing task_lst master row by task name
 	isNew, err := doCreateTaskRow(trx, modelDef, meta)
@@ -356,7 +359,7 @@ func doMergeTask(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *Lang
 	}
 
 	// insert new or update existing task text description and notes and task sets: task_txt and task_set rows
-	err = doMergeTaskDef(trx, modelDef, meta, langDef)
+	err = doMergeTaskDef(trx, dbFacet, modelDef, meta, langDef)
 	if err != nil {
 		return err
 	}
@@ -432,7 +435,7 @@ func doCreateTaskRow(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta) (bool, er
 			})
 		switch {
 		case err == sql.ErrNoRows:
-			return false, errors.New("invalid destination database, likely not an openM++ database")
+			return false, newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 		case err != nil:
 			return false, err
 		}
@@ -466,7 +469,7 @@ func doCreateTaskRow(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta) (bool, er
 // doReplaceTaskDef delete existing and insert new task text description and notes and task sets: task_txt and task_set rows.
 // It does update as part of transaction.
 // Model id and task id updated with actual database id's.
-func doReplaceTaskDef(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
+func doReplaceTaskDef(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
 
 	// delete existing task text and task input sets
 	stId := strconv.Itoa(meta.Task.TaskId)
@@ -494,7 +497,7 @@ func doReplaceTaskDef(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 					stId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(meta.Txt[j].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[j].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[j].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -517,7 +520,7 @@ func doReplaceTaskDef(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef
 // doMergeTaskDef update existing and insert new task text description and notes and task sets: task_txt and task_set rows.
 // It does update as part of transaction.
 // Model id and task id updated with actual database id's.
-func doMergeTaskDef(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
+func doMergeTaskDef(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TaskMeta, langDef *LangMeta) error {
 
 	// update task text (description and notes)
 	stId := strconv.Itoa(meta.Task.TaskId)
@@ -539,7 +542,7 @@ func doMergeTaskDef(trx *sql.Tx, modelDef *ModelMeta, meta *TaskMeta, langDef *L
 					stId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(meta.Txt[j].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[j].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[j].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -13,25 +13,42 @@ import (
 )
 
 // UpdateWorksetReadonly update workset readonly status.
+// Workset cannot be switched to read-write if there are active read locks of the workset.
 func UpdateWorksetReadonly(dbConn *sql.DB, setId int, isReadonly bool) error {
 
-	return Update(dbConn,
+	// update readonly status and check workset read locks in transaction scope
+	isLock := !isReadonly && isWorksetLockTableExist(dbConn)
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	err = TrxUpdate(trx,
 		"UPDATE workset_lst"+
 			" SET is_readonly = "+toBoolSqlConst(isReadonly)+", "+" update_dt = "+ToQuoted(helper.MakeDateTime(time.Now()))+
 			" WHERE set_id ="+strconv.Itoa(setId))
+	if err == nil && isLock {
+		err = trxCheckWorksetNotLocked(trx, setId)
+	}
+	if err != nil {
+		trx.Rollback()
+		return err
+	}
+	return trx.Commit()
 }
 
 // UpdateWorksetReadonlyByName update workset readonly status by workset name.
+// Workset cannot be switched to read-write if there are active read locks of the workset.
 func UpdateWorksetReadonlyByName(dbConn *sql.DB, modelId int, name string, isReadonly bool) error {
 
-	return Update(dbConn,
-		"UPDATE workset_lst"+
-			" SET is_readonly = "+toBoolSqlConst(isReadonly)+", "+" update_dt = "+ToQuoted(helper.MakeDateTime(time.Now()))+
-			" WHERE set_id = ("+
-			" SELECT MIN(W.set_id) FROM workset_lst W"+
-			" WHERE W.model_id = "+strconv.Itoa(modelId)+
-			" AND set_name = "+ToQuoted(name)+
-			")")
+	ws, err := GetWorksetByName(dbConn, modelId, name)
+	if err != nil {
+		return err
+	}
+	if ws == nil {
+		return nil // workset not found: nothing to do
+	}
+	return UpdateWorksetReadonly(dbConn, ws.SetId, isReadonly)
 }
 
 // RenameWorkset do rename workset if new name is not empty "" string.
@@ -167,11 +184,12 @@ func (meta *WorksetMeta) UpdateWorkset(dbConn *sql.DB, modelDef *ModelMeta, isRe
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	err = doUpdateWorkset(trx, modelDef, meta, isReplace, langDef)
+	err = doUpdateWorkset(trx, dbFacet, modelDef, meta, isReplace, langDef)
 	if err != nil {
 		trx.Rollback()
 		return err
@@ -185,7 +203,7 @@ func (meta *WorksetMeta) UpdateWorkset(dbConn *sql.DB, modelDef *ModelMeta, isRe
 // It does update as part of transaction
 // Set name is used to find workset and set id updated with actual database value
 // Workset must be read-write for replace or merge.
-func doUpdateWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, isReplace bool, langDef *LangMeta) error {
+func doUpdateWorkset(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *WorksetMeta, isReplace bool, langDef *LangMeta) error {
 
 	smId := strconv.Itoa(modelDef.Model.ModelId)
 
@@ -251,7 +269,7 @@ func doUpdateWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, isRepl
 			})
 		switch {
 		case err == sql.ErrNoRows:
-			return errors.New("invalid destination database, likely not an openM++ database")
+			return newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 		case err != nil:
 			return err
 		}
@@ -259,7 +277,7 @@ func doUpdateWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, isRepl
 		meta.Set.SetId = setId // update set id with actual value
 
 		// insert new workset with empty parameters list
-		return doInsertWorkset(trx, modelDef, meta, langDef)
+		return doInsertWorkset(trx, dbFacet, modelDef, meta, langDef)
 	}
 	// else: update existing workset
 	meta.Set.SetId = setId // workset exist, id may be different
@@ -295,16 +313,16 @@ pLoop:
 
 	// do replace of metadata or merge
 	if isReplace {
-		return doReplaceWorkset(trx, modelDef, meta, langDef)
+		return doReplaceWorkset(trx, dbFacet, modelDef, meta, langDef)
 	}
 	// else
-	return doMergeWorkset(trx, modelDef, meta, langDef)
+	return doMergeWorkset(trx, dbFacet, modelDef, meta, langDef)
 }
 
 // doInsertWorkset insert new workset metadata in database.
 // It does update as part of transaction.
 // Workset parameters list must be empty.
-func doInsertWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
+func doInsertWorkset(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
 
 	// workset parameters list must be empty in order to create new workset
 	if len(meta.Param) > 0 {
@@ -341,7 +359,7 @@ func doInsertWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDe
 
 	// insert new rows into workset_txt
 	// parameters list must be empty: workset_parameter_txt not inserted
-	if err = doInsertWorksetBody(trx, modelDef, meta, langDef); err != nil {
+	if err = doInsertWorksetBody(trx, dbFacet, modelDef, meta, langDef); err != nil {
 		return err
 	}
 	return err
@@ -350,7 +368,7 @@ func doInsertWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDe
 // doReplaceWorkset replace workset metadata in database.
 // It does update as part of transaction.
 // It does delete existing parameter values which are not in the list of workset parameters.
-func doReplaceWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
+func doReplaceWorkset(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
 
 	// if workset based on existing run then base run id must be positive
 	sbId := ""
@@ -390,7 +408,7 @@ func doReplaceWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langD
 	}
 
 	// insert new rows into workset body tables: workset_txt, workset_parameter_txt
-	if err = doInsertWorksetBody(trx, modelDef, meta, langDef); err != nil {
+	if err = doInsertWorksetBody(trx, dbFacet, modelDef, meta, langDef); err != nil {
 		return err
 	}
 	return nil
@@ -398,7 +416,7 @@ func doReplaceWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langD
 
 // doInsertWorksetBody insert into workset metadata tables: workset_txt, workset_parameter_txt
 // It does update as part of transaction.
-func doInsertWorksetBody(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
+func doInsertWorksetBody(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
 
 	sId := strconv.Itoa(meta.Set.SetId)
 
@@ -415,7 +433,7 @@ func doInsertWorksetBody(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, la
 					sId+", "+
 					strconv.Itoa(lId)+", "+
 					toQuotedMax(meta.Txt[j].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[j].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[j].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -438,7 +456,7 @@ func doInsertWorksetBody(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, la
 						sId+", "+
 						strconv.Itoa(meta.Param[k].ParamHid)+", "+
 						strconv.Itoa(lId)+", "+
-						toQuotedOrNullMax(meta.Param[k].Txt[j].Note, noteDbMax)+")")
+						dbFacet.noteToSql(meta.Param[k].Txt[j].Note)+")")
 				if err != nil {
 					return err
 				}
@@ -454,7 +472,7 @@ func doInsertWorksetBody(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, la
 // Workset master row updated with non-empty values:
 // read-only status if new read-only value is true.
 // Only parameter text is merged, not sub-value count.
-func doMergeWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
+func doMergeWorkset(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *WorksetMeta, langDef *LangMeta) error {
 
 	// UPDATE workset_lst
 	// SET is_readonly = 1, base_run_id = 1234, update_dt = '2012-08-17 16:05:59.123'
@@ -498,7 +516,7 @@ func doMergeWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDef
 					sId+", "+
 					slId+", "+
 					toQuotedMax(meta.Txt[j].Descr, descrDbMax)+", "+
-					toQuotedOrNullMax(meta.Txt[j].Note, noteDbMax)+")")
+					dbFacet.noteToSql(meta.Txt[j].Note)+")")
 			if err != nil {
 				return err
 			}
@@ -527,7 +545,7 @@ func doMergeWorkset(trx *sql.Tx, modelDef *ModelMeta, meta *WorksetMeta, langDef
 				err = TrxUpdate(trx,
 					"INSERT INTO workset_parameter_txt (set_id, parameter_hid, lang_id, note)"+
 						" SELECT "+
-						sId+", "+" parameter_hid, "+slId+", "+toQuotedOrNullMax(meta.Param[k].Txt[j].Note, noteDbMax)+
+						sId+", "+" parameter_hid, "+slId+", "+dbFacet.noteToSql(meta.Param[k].Txt[j].Note)+
 						" FROM workset_parameter"+
 						" WHERE set_id = "+sId+
 						" AND parameter_hid = "+spHid)
@@ -42,7 +42,21 @@ func (meta *WorksetMeta) UpdateWorksetParameterFrom(
 		return 0, errors.New("workset: " + meta.Set.Name + " invalid model id " + strconv.Itoa(meta.Set.ModelId) + " expected: " + strconv.Itoa(modelDef.Model.ModelId))
 	}
 
+	// if history of workset parameter values enabled then save existing values before write
+	var hist *WorksetHistoryOptions
+	if from != nil {
+		if k, ok := modelDef.ParamByName(param.Name); ok {
+			h, err := prepareWorksetParamHistory(dbConn, &modelDef.Param[k])
+			if err != nil {
+				return 0, err
+			}
+			hist = h
+		}
+	}
+
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
+	isDgst := from != nil && IsWorksetParamDigest(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return 0, err
@@ -55,7 +69,7 @@ func (meta *WorksetMeta) UpdateWorksetParameterFrom(
 	}
 
 	// create, replace or merge workset metadata
-	paramHid, err := doUpdateWorksetParameterMeta(trx, modelDef, meta, isReplaceMeta, param, isData, langDef)
+	paramHid, err := doUpdateWorksetParameterMeta(trx, dbFacet, modelDef, meta, isReplaceMeta, param, isData, langDef)
 	if err != nil {
 		trx.Rollback()
 		return 0, err
@@ -72,7 +86,13 @@ func (meta *WorksetMeta) UpdateWorksetParameterFrom(
 			return 0, errors.New("parameter not found: " + param.Name)
 		}
 
-		err = doWriteSetParameterFrom(trx, pm, meta.Set.SetId, param.SubCount, param.DefaultSubId, false, from, "")
+		err = doSaveWorksetParamVersion(trx, hist, pm, meta.Set.SetId)
+		if err == nil {
+			err = doWriteSetParameterFrom(trx, dbFacet, pm, meta.Set.SetId, param.SubCount, param.DefaultSubId, false, from, "")
+		}
+		if err == nil && isDgst {
+			_, err = doUpdateWorksetParamDigest(trx, modelDef, pm, meta.Set.SetId)
+		}
 		if err != nil {
 			trx.Rollback()
 			return 0, err
@@ -84,6 +104,132 @@ func (meta *WorksetMeta) UpdateWorksetParameterFrom(
 	return paramHid, nil
 }
 
+// MergeWorksetParameterFrom insert parameter into workset or replace parameter values only if values are different.
+//
+// Set name is used to find workset and set id updated with actual database value.
+// Workset must exist and must be read-write.
+//
+// If parameter not exist in workset then it is inserted, same as UpdateWorksetParameterFrom() with replace of metadata.
+// If parameter exist in workset then new values written and compared by value digest to existing parameter values:
+// if digests are equal then update discarded and existing parameter values and value notes preserved.
+// It return parameter Hid and true if parameter inserted or updated,
+// it return false if parameter values are unchanged.
+func (meta *WorksetMeta) MergeWorksetParameterFrom(
+	dbConn *sql.DB, modelDef *ModelMeta, param *ParamRunSetPub, langDef *LangMeta, from func() (interface{}, error),
+) (int, bool, error) {
+
+	// validate parameters
+	if modelDef == nil {
+		return 0, false, errors.New("invalid (empty) model metadata")
+	}
+	if param == nil {
+		return 0, false, errors.New("invalid (empty) parameter metadata")
+	}
+	if from == nil {
+		return 0, false, errors.New("invalid (empty) parameter values: " + param.Name)
+	}
+	if langDef == nil {
+		return 0, false, errors.New("invalid (empty) language list")
+	}
+	if meta.Set.Name == "" {
+		return 0, false, errors.New("invalid (empty) workset name")
+	}
+	if meta.Set.ModelId != modelDef.Model.ModelId {
+		return 0, false, errors.New("workset: " + meta.Set.Name + " invalid model id " + strconv.Itoa(meta.Set.ModelId) + " expected: " + strconv.Itoa(modelDef.Model.ModelId))
+	}
+	if param.SubCount <= 0 {
+		return 0, false, errors.New("parameter sub-value count must be positive: " + strconv.Itoa(param.SubCount) + ": " + param.Name)
+	}
+
+	k, ok := modelDef.ParamByName(param.Name)
+	if !ok {
+		return 0, false, errors.New("parameter not found: " + param.Name)
+	}
+	pm := &modelDef.Param[k]
+
+	// if parameter not exist in workset then insert it
+	wsRow, err := GetWorksetByName(dbConn, modelDef.Model.ModelId, meta.Set.Name)
+	if err != nil {
+		return 0, false, err
+	}
+	if wsRow == nil {
+		return 0, false, newDbError(ErrWorksetNotFound, "failed to update: workset not found: "+meta.Set.Name)
+	}
+	hLst, err := worksetParamHids(dbConn, wsRow.SetId)
+	if err != nil {
+		return 0, false, err
+	}
+	isExist := false
+	for _, h := range hLst {
+		if isExist = h == pm.ParamHid; isExist {
+			break
+		}
+	}
+	if !isExist {
+		hId, err := meta.UpdateWorksetParameterFrom(dbConn, modelDef, true, param, langDef, from)
+		return hId, err == nil, err
+	}
+
+	// if history of workset parameter values enabled then save existing values before write
+	hist, err := prepareWorksetParamHistory(dbConn, pm)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// parameter exist in workset: replace values and compare digests of existing and new values
+	dbFacet := facetOf(dbConn)
+	isDgst := IsWorksetParamDigest(dbConn)
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return 0, false, err
+	}
+
+	oldDgst, err := digestWorksetParam(trx, modelDef, pm, wsRow.SetId)
+	if err != nil {
+		trx.Rollback()
+		return 0, false, err
+	}
+
+	if err = doSaveWorksetParamVersion(trx, hist, pm, wsRow.SetId); err != nil {
+		trx.Rollback()
+		return 0, false, err
+	}
+
+	paramHid, err := doUpdateWorksetParameterMeta(trx, dbFacet, modelDef, meta, true, param, true, langDef)
+	if err != nil {
+		trx.Rollback()
+		return 0, false, err
+	}
+	if err = doWriteSetParameterFrom(trx, dbFacet, pm, meta.Set.SetId, param.SubCount, param.DefaultSubId, false, from, ""); err != nil {
+		trx.Rollback()
+		return 0, false, err
+	}
+
+	newDgst, err := digestWorksetParam(trx, modelDef, pm, meta.Set.SetId)
+	if err != nil {
+		trx.Rollback()
+		return 0, false, err
+	}
+	if newDgst == oldDgst {
+		trx.Rollback() // parameter values unchanged: keep existing values and value notes
+		return paramHid, false, nil
+	}
+
+	if isDgst {
+		err = TrxUpdate(trx,
+			"UPDATE workset_parameter SET value_digest = "+ToQuoted(newDgst)+
+				" WHERE set_id = "+strconv.Itoa(meta.Set.SetId)+
+				" AND parameter_hid = "+strconv.Itoa(paramHid))
+		if err != nil {
+			trx.Rollback()
+			return 0, false, err
+		}
+	}
+	trx.Commit()
+
+	return paramHid, true, nil
+}
+
 // UpdateWorksetParameterText merge parameter value notes into workset_parameter_txt table.
 //
 // Set name is used to find workset and set id updated with actual database value.
@@ -127,11 +273,12 @@ func UpdateWorksetParameterText(dbConn *sql.DB, modelDef *ModelMeta, setName str
 	}
 
 	// do update in transaction scope
+	dbFacet := facetOf(dbConn)
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
-	err = doUpdateWorksetParameterText(trx, modelDef, setName, paramLst, langDef)
+	err = doUpdateWorksetParameterText(trx, dbFacet, modelDef, setName, paramLst, langDef)
 	if err != nil {
 		trx.Rollback()
 		return err
@@ -152,7 +299,7 @@ func UpdateWorksetParameterText(dbConn *sql.DB, modelDef *ModelMeta, setName str
 // Set name is used to find workset and set id updated with actual database value.
 // Workset must be read-write for replace or merge.
 func doUpdateWorksetParameterMeta(
-	trx *sql.Tx, modelDef *ModelMeta, wm *WorksetMeta, isReplaceMeta bool, param *ParamRunSetPub, isData bool, langDef *LangMeta,
+	trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, wm *WorksetMeta, isReplaceMeta bool, param *ParamRunSetPub, isData bool, langDef *LangMeta,
 ) (int, error) {
 
 	// find model parameter hId by name
@@ -187,7 +334,7 @@ func doUpdateWorksetParameterMeta(
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return 0, errors.New("failed to update: workset not found: " + wm.Set.Name)
+		return 0, newDbError(ErrWorksetNotFound, "failed to update: workset not found: "+wm.Set.Name)
 	case err != nil:
 		return 0, err
 	case nRd != 1:
@@ -283,7 +430,7 @@ func doUpdateWorksetParameterMeta(
 			err = TrxUpdate(trx,
 				"INSERT INTO workset_parameter_txt (set_id, parameter_hid, lang_id, note)"+
 					" SELECT "+
-					sId+", "+" parameter_hid, "+slId+", "+toQuotedOrNullMax(param.Txt[j].Note, noteDbMax)+
+					sId+", "+" parameter_hid, "+slId+", "+dbFacet.noteToSql(param.Txt[j].Note)+
 					" FROM workset_parameter"+
 					" WHERE set_id = "+sId+
 					" AND parameter_hid = "+spHid)
@@ -314,7 +461,7 @@ func doUpdateWorksetParameterMeta(
 // Workset must exist and must be read-write for replace or merge.
 //
 // If parameter not exist in workset then function does nothing (it is empty operation).
-func doUpdateWorksetParameterText(trx *sql.Tx, modelDef *ModelMeta, setName string, paramLst []worksetParam, langDef *LangMeta) error {
+func doUpdateWorksetParameterText(trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, setName string, paramLst []worksetParam, langDef *LangMeta) error {
 
 	// "lock" workset to prevent update or use by the model
 	err := TrxUpdate(trx,
@@ -338,7 +485,7 @@ func doUpdateWorksetParameterText(trx *sql.Tx, modelDef *ModelMeta, setName stri
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("failed to update: workset not found: " + setName)
+		return newDbError(ErrWorksetNotFound, "failed to update: workset not found: "+setName)
 	case err != nil:
 		return err
 	case nRd != 1:
@@ -368,7 +515,7 @@ func doUpdateWorksetParameterText(trx *sql.Tx, modelDef *ModelMeta, setName stri
 				err = TrxUpdate(trx,
 					"INSERT INTO workset_parameter_txt (set_id, parameter_hid, lang_id, note)"+
 						" SELECT "+
-						sId+", "+" parameter_hid, "+slId+", "+toQuotedOrNullMax(paramLst[k].Txt[j].Note, noteDbMax)+
+						sId+", "+" parameter_hid, "+slId+", "+dbFacet.noteToSql(paramLst[k].Txt[j].Note)+
 						" FROM workset_parameter"+
 						" WHERE set_id = "+sId+
 						" AND parameter_hid = "+spHid)
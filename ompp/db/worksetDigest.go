@@ -0,0 +1,292 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ParamDigestCompare is workset parameter value digest compared to base run parameter value digest
+type ParamDigestCompare struct {
+	Name       string // parameter name
+	ParamHid   int    // parameter_hid
+	SetDigest  string // workset parameter value digest
+	RunDigest  string // base run parameter value digest, empty if parameter not found in base run
+	IsModified bool   // if true then workset parameter value is different from base run value
+}
+
+// workset_parameter value_digest column presence of open database connections
+var theParamDigest = struct {
+	sync.Mutex
+	isDigest map[*sql.DB]bool
+}{isDigest: map[*sql.DB]bool{}}
+
+// IsWorksetParamDigest return true if workset_parameter table has value_digest column.
+//
+// Column value_digest is created by sql/create_db.sql script or by sql/upgrade_side_tables.sql for existing database.
+// After upgrade all workset parameters digests are NULL,
+// digests are updated on workset parameter write or by UpdateWorksetParamDigest().
+// Result is checked once for each database connection.
+func IsWorksetParamDigest(dbConn *sql.DB) bool {
+
+	theParamDigest.Lock()
+	isDgst, ok := theParamDigest.isDigest[dbConn]
+	theParamDigest.Unlock()
+
+	if ok {
+		return isDgst
+	}
+
+	err := SelectFirst(dbConn,
+		"SELECT value_digest FROM workset_parameter WHERE set_id < 0",
+		func(row *sql.Row) error {
+			var s sql.NullString
+			return row.Scan(&s)
+		})
+	isDgst = err == nil || err == sql.ErrNoRows
+
+	theParamDigest.Lock()
+	theParamDigest.isDigest[dbConn] = isDgst
+	theParamDigest.Unlock()
+
+	return isDgst
+}
+
+// remove value_digest column presence of database connection, it must be called when connection is closed
+func deleteParamDigestOf(dbConn *sql.DB) {
+	theParamDigest.Lock()
+	defer theParamDigest.Unlock()
+	delete(theParamDigest.isDigest, dbConn)
+}
+
+// UpdateWorksetParamDigest recalculate and store value digests of all workset parameters.
+//
+// It does nothing if workset_parameter table does not have value_digest column.
+func UpdateWorksetParamDigest(dbConn *sql.DB, modelDef *ModelMeta, setId int) error {
+
+	if modelDef == nil {
+		return errors.New("invalid (empty) model metadata")
+	}
+	if !IsWorksetParamDigest(dbConn) {
+		return nil
+	}
+
+	hLst, err := worksetParamHids(dbConn, setId)
+	if err != nil {
+		return err
+	}
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	for _, hId := range hLst {
+
+		idx, ok := modelDef.ParamByHid(hId)
+		if !ok {
+			trx.Rollback()
+			return errors.New("parameter not found by Hid: " + strconv.Itoa(hId))
+		}
+		if _, err = doUpdateWorksetParamDigest(trx, modelDef, &modelDef.Param[idx], setId); err != nil {
+			trx.Rollback()
+			return err
+		}
+	}
+	trx.Commit()
+	return nil
+}
+
+// CompareWorksetToRun compare workset parameters value digests to model run parameters value digests.
+//
+// If runId <= 0 then workset base run is used.
+// If workset parameter value digest not stored in database then it is calculated from workset parameter values.
+func CompareWorksetToRun(dbConn *sql.DB, modelDef *ModelMeta, setId int, runId int) ([]ParamDigestCompare, error) {
+
+	if modelDef == nil {
+		return nil, errors.New("invalid (empty) model metadata")
+	}
+	sId := strconv.Itoa(setId)
+
+	// find base run id, if not specified
+	if runId <= 0 {
+		err := SelectFirst(dbConn,
+			"SELECT base_run_id FROM workset_lst WHERE set_id = "+sId,
+			func(row *sql.Row) error {
+				var n sql.NullInt64
+				if err := row.Scan(&n); err != nil {
+					return err
+				}
+				if n.Valid {
+					runId = int(n.Int64)
+				}
+				return nil
+			})
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, newDbError(ErrWorksetNotFound, "workset not found, id: "+sId)
+		case err != nil:
+			return nil, err
+		}
+		if runId <= 0 {
+			return nil, errors.New("workset base run not found, set id: " + sId)
+		}
+	}
+	if err := checkRunFilter(dbConn, runId); err != nil {
+		return nil, err
+	}
+
+	// select workset parameters stored digests, not available if there is no value_digest column
+	hLst, err := worksetParamHids(dbConn, setId)
+	if err != nil {
+		return nil, err
+	}
+	setDigest := map[int]string{}
+
+	if IsWorksetParamDigest(dbConn) {
+		err = SelectRows(dbConn,
+			"SELECT parameter_hid, value_digest FROM workset_parameter WHERE set_id = "+sId,
+			func(rows *sql.Rows) error {
+				var hId int
+				var s sql.NullString
+				if err := rows.Scan(&hId, &s); err != nil {
+					return err
+				}
+				if s.Valid && s.String != "" {
+					setDigest[hId] = s.String
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// select model run parameters digests
+	runDigest := map[int]string{}
+	err = SelectRows(dbConn,
+		"SELECT parameter_hid, value_digest FROM run_parameter WHERE run_id = "+strconv.Itoa(runId),
+		func(rows *sql.Rows) error {
+			var hId int
+			var s sql.NullString
+			if err := rows.Scan(&hId, &s); err != nil {
+				return err
+			}
+			if s.Valid {
+				runDigest[hId] = s.String
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// compare digests, calculate workset parameter digest if it is not stored in database
+	cmpLst := make([]ParamDigestCompare, 0, len(hLst))
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer trx.Rollback() // read-only: nothing to commit
+
+	for _, hId := range hLst {
+
+		idx, ok := modelDef.ParamByHid(hId)
+		if !ok {
+			return nil, errors.New("parameter not found by Hid: " + strconv.Itoa(hId))
+		}
+		sd, ok := setDigest[hId]
+		if !ok {
+			if sd, err = digestWorksetParam(trx, modelDef, &modelDef.Param[idx], setId); err != nil {
+				return nil, err
+			}
+		}
+		rd := runDigest[hId]
+
+		cmpLst = append(cmpLst, ParamDigestCompare{
+			Name:       modelDef.Param[idx].Name,
+			ParamHid:   hId,
+			SetDigest:  sd,
+			RunDigest:  rd,
+			IsModified: sd != rd,
+		})
+	}
+	return cmpLst, nil
+}
+
+// return list of workset parameters Hid's
+func worksetParamHids(dbConn *sql.DB, setId int) ([]int, error) {
+
+	hLst := []int{}
+	err := SelectRows(dbConn,
+		"SELECT parameter_hid FROM workset_parameter WHERE set_id = "+strconv.Itoa(setId)+" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var hId int
+			if err := rows.Scan(&hId); err != nil {
+				return err
+			}
+			hLst = append(hLst, hId)
+			return nil
+		})
+	return hLst, err
+}
+
+// doUpdateWorksetParamDigest calculate workset parameter value digest and store it in workset_parameter table.
+// It does update as part of transaction, workset_parameter table must have value_digest column.
+func doUpdateWorksetParamDigest(trx *sql.Tx, modelDef *ModelMeta, param *ParamMeta, setId int) (string, error) {
+
+	dgst, err := digestWorksetParam(trx, modelDef, param, setId)
+	if err != nil {
+		return "", err
+	}
+
+	err = TrxUpdate(trx,
+		"UPDATE workset_parameter SET value_digest = "+ToQuoted(dgst)+
+			" WHERE set_id = "+strconv.Itoa(setId)+
+			" AND parameter_hid = "+strconv.Itoa(param.ParamHid))
+	if err != nil {
+		return "", err
+	}
+	return dgst, nil
+}
+
+// digestWorksetParam calculate workset parameter value digest.
+// Digest is calculated same way as model run parameter value digest: ordered by sub_id, dim0, dim1,....
+func digestWorksetParam(trx *sql.Tx, modelDef *ModelMeta, param *ParamMeta, setId int) (string, error) {
+	return digestParamValues(trx, modelDef, param, param.DbSetTable, "set_id", setId)
+}
+
+// digestRunParam calculate model run parameter value digest, run id must be the base run id which is holding the values.
+func digestRunParam(trx *sql.Tx, modelDef *ModelMeta, param *ParamMeta, runId int) (string, error) {
+	return digestParamValues(trx, modelDef, param, param.DbRunTable, "run_id", runId)
+}
+
+// digestParamValues calculate parameter value digest of run or workset values table, ordered by sub_id, dim0, dim1,....
+func digestParamValues(trx *sql.Tx, modelDef *ModelMeta, param *ParamMeta, dbTable string, idColumn string, id int) (string, error) {
+
+	// SELECT sub_id, dim0, dim1, param_value FROM ageSex_w12345678 WHERE set_id = 2 ORDER BY 1, 2, 3
+	q := "SELECT sub_id, "
+	for k := range param.Dim {
+		q += param.Dim[k].colName + ", "
+	}
+	q += "param_value FROM " + dbTable + " WHERE " + idColumn + " = " + strconv.Itoa(id)
+
+	q += " ORDER BY 1"
+	for k := range param.Dim {
+		q += ", " + strconv.Itoa(k+2)
+	}
+
+	hMd5, digestFrom, _, err := digestParameterFrom(modelDef, param, "")
+	if err != nil {
+		return "", err
+	}
+	if err = trxReadParameterTo(trx, param, q, digestFrom); err != nil {
+		return "", errors.New("digest parameter failed: " + param.Name + " " + err.Error())
+	}
+	return fmt.Sprintf("%x", hMd5.Sum(nil)), nil
+}
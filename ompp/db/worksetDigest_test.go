@@ -0,0 +1,108 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestWorksetParamDigest(t *testing.T) {
+
+	// read-write workset id 1 based on completed run id 1, parameter ageRatio[dim0] of double type
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageRatio_p12345678 (run_id INT, sub_id INT, dim0 INT, param_value FLOAT, PRIMARY KEY (run_id, sub_id, dim0))",
+		"CREATE TABLE ageRatio_w12345678 (set_id INT, sub_id INT, dim0 INT, param_value FLOAT, PRIMARY KEY (set_id, sub_id, dim0))",
+		testRunSql(1, 1, 1, "s"),
+		testWorksetSql(1, 1, "Edit", false),
+		"UPDATE workset_lst SET base_run_id = 1 WHERE set_id = 1",
+		"INSERT INTO workset_parameter (set_id, parameter_hid, sub_count, default_sub_id, value_digest) VALUES (1, 17, 1, 0, NULL)",
+	)
+
+	modelDef := &ModelMeta{
+		Model: ModelDicRow{ModelId: 1},
+		Param: []ParamMeta{{
+			ParamDicRow: ParamDicRow{ParamHid: 17, Name: "ageRatio", Rank: 1, DbRunTable: "ageRatio_p12345678", DbSetTable: "ageRatio_w12345678"},
+			Dim:         []ParamDimsRow{{Name: "dim0", colName: "dim0"}},
+			typeOf:      &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 7, Name: "double"}},
+		}},
+	}
+
+	if !IsWorksetParamDigest(dbConn) {
+		t.Fatal("Fail: workset parameter value digest column not found")
+	}
+
+	// write parameter values: dim0 = 0, 1 and value = base + dim0
+	writeValues := func(layout WriteParamLayout, base float64) {
+		t.Helper()
+		n := 0
+		err := WriteParameterFrom(dbConn, modelDef, &layout, func() (interface{}, error) {
+			if n >= 2 {
+				return nil, nil
+			}
+			n++
+			return CellParam{cellIdValue: cellIdValue{DimIds: []int{n - 1}, Value: base + float64(n-1)}}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	setDigest := func() string {
+		t.Helper()
+		var s sql.NullString
+		if err := SelectFirst(dbConn, "SELECT value_digest FROM workset_parameter WHERE set_id = 1 AND parameter_hid = 17", func(row *sql.Row) error {
+			return row.Scan(&s)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return s.String
+	}
+	compare := func() ParamDigestCompare {
+		t.Helper()
+		cmpLst, err := CompareWorksetToRun(dbConn, modelDef, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cmpLst) != 1 || cmpLst[0].Name != "ageRatio" {
+			t.Fatalf("Fail: invalid digest compare result: %v", cmpLst)
+		}
+		return cmpLst[0]
+	}
+
+	// run values and workset values are the same: digest stored at write must match run digest
+	writeValues(WriteParamLayout{WriteLayout: WriteLayout{Name: "ageRatio", ToId: 1}, SubCount: 1, IsToRun: true}, 10)
+	writeValues(WriteParamLayout{WriteLayout: WriteLayout{Name: "ageRatio", ToId: 1}, SubCount: 1}, 10)
+
+	dgst := setDigest()
+	if len(dgst) != 32 {
+		t.Fatalf("Fail: invalid workset parameter digest: %q", dgst)
+	}
+	if c := compare(); c.IsModified || c.SetDigest != dgst || c.RunDigest != dgst {
+		t.Errorf("Fail: workset parameter must match the run: %v", c)
+	}
+
+	// overwrite workset values: digest updated and parameter modified since base run
+	writeValues(WriteParamLayout{WriteLayout: WriteLayout{Name: "ageRatio", ToId: 1}, SubCount: 1}, 20)
+
+	if s := setDigest(); s == dgst || len(s) != 32 {
+		t.Errorf("Fail: workset parameter digest not updated: %q", s)
+	}
+	if c := compare(); !c.IsModified || c.RunDigest != dgst {
+		t.Errorf("Fail: workset parameter must be modified: %v", c)
+	}
+
+	// restore run values: if digest is not stored then it is calculated
+	writeValues(WriteParamLayout{WriteLayout: WriteLayout{Name: "ageRatio", ToId: 1}, SubCount: 1}, 10)
+	testUpdate(t, dbConn, "UPDATE workset_parameter SET value_digest = NULL WHERE set_id = 1")
+
+	if c := compare(); c.IsModified || c.SetDigest != dgst {
+		t.Errorf("Fail: calculated workset parameter digest must match the run: %v", c)
+	}
+	if err := UpdateWorksetParamDigest(dbConn, modelDef, 1); err != nil {
+		t.Fatal(err)
+	}
+	if s := setDigest(); s != dgst {
+		t.Errorf("Fail: invalid workset parameter digest after update: %q expected: %q", s, dgst)
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// WorksetLock is an advisory read lock of workset (input set of parameters).
+//
+// Workset read lock is held by model run or any other reader of workset parameters, each reader is a lock owner.
+// Multiple owners can lock the same workset at the same time.
+// Workset must be read-only to be locked and while there is an active (not expired) lock workset cannot be
+// switched to read-write or deleted. Lock owner must refresh the lock before expiry or release it when done.
+//
+// Read locks stored in workset_lock table, it is created by sql/create_db.sql script
+// or by sql/upgrade_side_tables.sql for existing database, model can use the same table:
+//
+//	CREATE TABLE workset_lock
+//	(
+//	  set_id     INT          NOT NULL, -- workset id
+//	  owner_name VARCHAR(255) NOT NULL, -- lock owner, e.g.: model run stamp
+//	  lock_dt    VARCHAR(32)  NOT NULL, -- lock acquired or refreshed date-time
+//	  expire_ts  BIGINT       NOT NULL, -- lock expiry time: unix milliseconds
+//	  PRIMARY KEY (set_id, owner_name)
+//	)
+type WorksetLock struct {
+	SetId          int    // workset id
+	Owner          string // lock owner, e.g.: model run stamp
+	LockDateTime   string // lock acquired or refreshed date-time
+	ExpireDateTime string // lock expiry date-time
+	expireTs       int64  // lock expiry time: unix milliseconds
+}
+
+// workset read locks db table name
+const worksetLockTable = "workset_lock"
+
+// AcquireWorksetLock acquire workset read lock by owner for ttl duration.
+// If owner already has lock of that workset then lock is refreshed.
+// Workset must exist and must be read-only, it is checked in the same transaction where lock is inserted.
+// Expired locks of the workset are deleted.
+func AcquireWorksetLock(dbConn *sql.DB, setId int, owner string, ttl time.Duration) (*WorksetLock, error) {
+
+	// validate parameters
+	if setId <= 0 {
+		return nil, errors.New("invalid workset id: " + strconv.Itoa(setId))
+	}
+	owner = strings.TrimSpace(owner)
+	if owner == "" || len(owner) > 255 {
+		return nil, errors.New("invalid workset lock owner: " + owner)
+	}
+	if ttl <= 0 {
+		return nil, errors.New("invalid workset lock duration: " + ttl.String())
+	}
+
+	// workset locks table must be created by database schema script
+	if !isWorksetLockTableExist(dbConn) {
+		return nil, errors.New("workset locks table not found, database must be upgraded by sql/upgrade_side_tables.sql: " + worksetLockTable)
+	}
+
+	// delete expired locks, check workset status and replace owner lock in transaction scope
+	tNow := time.Now()
+	wl := &WorksetLock{
+		SetId:          setId,
+		Owner:          owner,
+		LockDateTime:   helper.MakeDateTime(tNow),
+		ExpireDateTime: helper.MakeDateTime(tNow.Add(ttl)),
+		expireTs:       tNow.Add(ttl).UnixMilli(),
+	}
+	sId := strconv.Itoa(setId)
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// lock workset row first to serialize with workset update or delete
+	err = trxLockWorksetRow(trx, setId)
+	if err == nil {
+		err = TrxUpdate(trx,
+			"DELETE FROM "+worksetLockTable+
+				" WHERE set_id = "+sId+
+				" AND (owner_name = "+ToQuoted(owner)+" OR expire_ts < "+strconv.FormatInt(tNow.UnixMilli(), 10)+")")
+	}
+
+	// workset must exist and must be read-only
+	if err == nil {
+		nRd := 0
+		err = TrxSelectFirst(trx,
+			"SELECT is_readonly FROM workset_lst WHERE set_id = "+sId,
+			func(row *sql.Row) error {
+				return row.Scan(&nRd)
+			})
+		switch {
+		case err == sql.ErrNoRows:
+			err = errors.New("workset not found, id: " + sId)
+		case err == nil && nRd == 0:
+			err = errors.New("workset must be read-only to be locked, id: " + sId)
+		}
+	}
+	if err == nil {
+		err = TrxUpdate(trx,
+			"INSERT INTO "+worksetLockTable+" (set_id, owner_name, lock_dt, expire_ts)"+
+				" VALUES ("+sId+", "+ToQuoted(owner)+", "+ToQuoted(wl.LockDateTime)+", "+strconv.FormatInt(wl.expireTs, 10)+")")
+	}
+	if err != nil {
+		trx.Rollback()
+		return nil, errors.New("failed to acquire workset lock: " + sId + ": " + err.Error())
+	}
+	if err = trx.Commit(); err != nil {
+		return nil, errors.New("failed to acquire workset lock: " + sId + ": " + err.Error())
+	}
+	return wl, nil
+}
+
+// RefreshWorksetLock extend workset read lock of the owner for ttl duration from now.
+// Return false if owner does not have active (not expired) lock of the workset.
+func RefreshWorksetLock(dbConn *sql.DB, setId int, owner string, ttl time.Duration) (bool, error) {
+
+	if ttl <= 0 {
+		return false, errors.New("invalid workset lock duration: " + ttl.String())
+	}
+	wl, err := findWorksetLock(dbConn, setId, owner)
+	if err != nil || wl == nil {
+		return false, err
+	}
+
+	tNow := time.Now()
+	err = Update(dbConn,
+		"UPDATE "+worksetLockTable+
+			" SET lock_dt = "+ToQuoted(helper.MakeDateTime(tNow))+", expire_ts = "+strconv.FormatInt(tNow.Add(ttl).UnixMilli(), 10)+
+			" WHERE set_id = "+strconv.Itoa(setId)+
+			" AND owner_name = "+ToQuoted(owner))
+	if err != nil {
+		return false, errors.New("failed to refresh workset lock: " + strconv.Itoa(setId) + ": " + owner + ": " + err.Error())
+	}
+	return true, nil
+}
+
+// ReleaseWorksetLock delete workset read lock of the owner.
+// Return false if owner does not have active (not expired) lock of the workset.
+func ReleaseWorksetLock(dbConn *sql.DB, setId int, owner string) (bool, error) {
+
+	wl, err := findWorksetLock(dbConn, setId, owner)
+	if err != nil || wl == nil {
+		return false, err
+	}
+
+	err = Update(dbConn,
+		"DELETE FROM "+worksetLockTable+
+			" WHERE set_id = "+strconv.Itoa(setId)+
+			" AND owner_name = "+ToQuoted(owner))
+	if err != nil {
+		return false, errors.New("failed to release workset lock: " + strconv.Itoa(setId) + ": " + owner + ": " + err.Error())
+	}
+	return true, nil
+}
+
+// GetWorksetLocks return active (not expired) read locks of the workset.
+// Return empty list if there are no locks or workset locks table does not exist.
+func GetWorksetLocks(dbConn *sql.DB, setId int) ([]WorksetLock, error) {
+
+	if !isWorksetLockTableExist(dbConn) {
+		return []WorksetLock{}, nil
+	}
+
+	wlLst := []WorksetLock{}
+
+	err := SelectRows(dbConn,
+		"SELECT set_id, owner_name, lock_dt, expire_ts FROM "+worksetLockTable+
+			" WHERE set_id = "+strconv.Itoa(setId)+
+			" AND expire_ts >= "+strconv.FormatInt(time.Now().UnixMilli(), 10)+
+			" ORDER BY 2",
+		func(rows *sql.Rows) error {
+			var wl WorksetLock
+			if err := rows.Scan(&wl.SetId, &wl.Owner, &wl.LockDateTime, &wl.expireTs); err != nil {
+				return err
+			}
+			wl.ExpireDateTime = helper.MakeDateTime(time.UnixMilli(wl.expireTs))
+			wlLst = append(wlLst, wl)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return wlLst, nil
+}
+
+// CheckWorksetNotLocked return error if workset has active (not expired) read locks.
+// Workset update or delete must use trxCheckWorksetNotLocked inside of the same transaction.
+func CheckWorksetNotLocked(dbConn *sql.DB, setId int) error {
+
+	wlLst, err := GetWorksetLocks(dbConn, setId)
+	if err != nil {
+		return err
+	}
+	oLst := make([]string, len(wlLst))
+	for k := range wlLst {
+		oLst[k] = wlLst[k].Owner
+	}
+	return worksetLockedError(setId, oLst)
+}
+
+// trxCheckWorksetNotLocked return error if workset has active (not expired) read locks.
+// It must be used in the same transaction where workset become read-write or deleted.
+// It does lock workset row and select locks as part of transaction.
+func trxCheckWorksetNotLocked(trx *sql.Tx, setId int) error {
+
+	// lock workset row first to serialize with AcquireWorksetLock
+	if err := trxLockWorksetRow(trx, setId); err != nil {
+		return err
+	}
+	oLst := []string{}
+
+	err := TrxSelectRows(trx,
+		"SELECT owner_name FROM "+worksetLockTable+
+			" WHERE set_id = "+strconv.Itoa(setId)+
+			" AND expire_ts >= "+strconv.FormatInt(time.Now().UnixMilli(), 10)+
+			" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var s string
+			if err := rows.Scan(&s); err != nil {
+				return err
+			}
+			oLst = append(oLst, s)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	return worksetLockedError(setId, oLst)
+}
+
+// lock workset row by dummy update, it must be done before workset locks are selected or changed.
+// Workset read lock and workset update or delete are serialized by that row lock,
+// otherwise under read committed isolation lock insert and workset update do not see each other.
+func trxLockWorksetRow(trx *sql.Tx, setId int) error {
+	return TrxUpdate(trx, "UPDATE workset_lst SET update_dt = update_dt WHERE set_id = "+strconv.Itoa(setId))
+}
+
+// return error if list of workset lock owners is not empty
+func worksetLockedError(setId int, owners []string) error {
+	if len(owners) > 0 {
+		return errors.New("workset is locked, id: " + strconv.Itoa(setId) + " by: " + strings.Join(owners, ", "))
+	}
+	return nil
+}
+
+// delete all read locks of all model worksets.
+// It does update as part of transaction.
+func trxDeleteModelWorksetLocks(trx *sql.Tx, modelId int) error {
+	return TrxUpdate(trx,
+		"DELETE FROM "+worksetLockTable+" WHERE EXISTS"+
+			" (SELECT set_id FROM workset_lst M WHERE M.set_id = "+worksetLockTable+".set_id AND M.model_id = "+strconv.Itoa(modelId)+")")
+}
+
+// return active (not expired) read lock of the workset by owner or nil if not found
+func findWorksetLock(dbConn *sql.DB, setId int, owner string) (*WorksetLock, error) {
+
+	wlLst, err := GetWorksetLocks(dbConn, setId)
+	if err != nil {
+		return nil, err
+	}
+	for k := range wlLst {
+		if wlLst[k].Owner == owner {
+			return &wlLst[k], nil
+		}
+	}
+	return nil, nil
+}
+
+// return true if workset locks table exists
+func isWorksetLockTableExist(dbConn *sql.DB) bool {
+	return SelectFirst(dbConn, "SELECT COUNT(*) FROM "+worksetLockTable+" WHERE 1 = 0", func(row *sql.Row) error {
+		var n int
+		return row.Scan(&n)
+	}) == nil
+}
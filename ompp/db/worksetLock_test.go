@@ -0,0 +1,111 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWorksetLock(t *testing.T) {
+
+	// read-only workset id 1 and read-write workset id 2
+	dbConn := openTestDb(t,
+		testWorksetSql(1, 1, "Default", true),
+		testWorksetSql(2, 1, "Edit", false),
+	)
+
+	// there are no locks
+	if err := CheckWorksetNotLocked(dbConn, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// read-write workset cannot be locked
+	if _, err := AcquireWorksetLock(dbConn, 2, "run-1", time.Minute); err == nil {
+		t.Error("expected error at lock of read-write workset")
+	}
+
+	// multiple owners can lock the same workset
+	for _, owner := range []string{"run-1", "run-2", "run-1"} {
+		if _, err := AcquireWorksetLock(dbConn, 1, owner, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wlLst, err := GetWorksetLocks(dbConn, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wlLst) != 2 || wlLst[0].Owner != "run-1" || wlLst[1].Owner != "run-2" {
+		t.Errorf("invalid workset locks: %v", wlLst)
+	}
+
+	// locked workset cannot be switched to read-write or deleted
+	if err = UpdateWorksetReadonly(dbConn, 1, false); err == nil {
+		t.Error("expected error at read-write update of locked workset")
+	}
+	if err = UpdateWorksetReadonlyByName(dbConn, 1, "Default", false); err == nil {
+		t.Error("expected error at read-write update of locked workset by name")
+	}
+	if err = DeleteWorkset(dbConn, 1); err == nil {
+		t.Error("expected error at delete of locked workset")
+	}
+
+	// refresh and release locks
+	if ok, err := RefreshWorksetLock(dbConn, 1, "run-2", time.Minute); err != nil || !ok {
+		t.Errorf("failed to refresh workset lock: %v %v", ok, err)
+	}
+	if ok, err := RefreshWorksetLock(dbConn, 1, "run-3", time.Minute); err != nil || ok {
+		t.Errorf("expected refresh failure of not existing lock: %v %v", ok, err)
+	}
+	for _, owner := range []string{"run-1", "run-2"} {
+		if ok, err := ReleaseWorksetLock(dbConn, 1, owner); err != nil || !ok {
+			t.Errorf("failed to release workset lock: %s %v %v", owner, ok, err)
+		}
+	}
+
+	// expired lock is ignored
+	if _, err = AcquireWorksetLock(dbConn, 1, "run-4", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err = Update(dbConn, "UPDATE workset_lock SET expire_ts = 1 WHERE set_id = 1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = CheckWorksetNotLocked(dbConn, 1); err != nil {
+		t.Errorf("expired lock must be ignored: %v", err)
+	}
+	if err = UpdateWorksetReadonly(dbConn, 1, false); err != nil {
+		t.Error(err)
+	}
+
+	// workset delete and model delete must delete workset locks
+	countLocks := func(setId int) int {
+		t.Helper()
+		n := 0
+		if err := SelectFirst(dbConn, "SELECT COUNT(*) FROM workset_lock WHERE set_id = "+strconv.Itoa(setId), func(row *sql.Row) error {
+			return row.Scan(&n)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	if err = DeleteWorkset(dbConn, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n := countLocks(1); n != 0 {
+		t.Errorf("workset locks not deleted by workset delete: %d", n)
+	}
+
+	testUpdate(t, dbConn,
+		testModelSql(1, "modelOne", "m1"),
+		"INSERT INTO workset_lock (set_id, owner_name, lock_dt, expire_ts) VALUES (2, 'run-5', '2026-01-01 00:00:00.000', 1)",
+	)
+	if err = DeleteModel(dbConn, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n := countLocks(2); n != 0 {
+		t.Errorf("workset locks not deleted by model delete: %d", n)
+	}
+}
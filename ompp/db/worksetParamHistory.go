@@ -0,0 +1,687 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// WorksetHistoryOptions is retention options of workset parameter values history.
+//
+// If history of workset parameter values enabled then before each overwrite of workset parameter
+// existing values are saved as a new version of that parameter.
+// Old versions deleted if number of versions exceeds MaxVersions or if version is older than MaxDays.
+//
+// History options stored in workset_param_history table, versions list in workset_param_version table,
+// both tables created by sql/create_db.sql script or by sql/upgrade_side_tables.sql for existing database.
+// Parameter values of each version stored in workset_param_h<parameter_hid> table, it is created on first save, for example:
+//
+//	CREATE TABLE workset_param_history
+//	(
+//	  max_versions INT NOT NULL, -- if positive then max number of versions of workset parameter
+//	  max_days     INT NOT NULL  -- if positive then max age in days of workset parameter version
+//	)
+//	CREATE TABLE workset_param_version
+//	(
+//	  set_id         INT         NOT NULL, -- workset id
+//	  parameter_hid  INT         NOT NULL, -- parameter unique id
+//	  version_id     INT         NOT NULL, -- version number: 1, 2, 3,...
+//	  sub_count      INT         NOT NULL, -- number of parameter sub-values
+//	  default_sub_id INT         NOT NULL, -- default sub-value id
+//	  version_dt     VARCHAR(32) NOT NULL, -- date-time when values were overwritten
+//	  PRIMARY KEY (set_id, parameter_hid, version_id)
+//	)
+//	CREATE TABLE workset_param_h17
+//	(
+//	  set_id      INT      NOT NULL,
+//	  version_id  INT      NOT NULL,
+//	  sub_id      SMALLINT NOT NULL,
+//	  dim0        INT      NOT NULL,
+//	  dim1        INT      NOT NULL,
+//	  param_value FLOAT    NULL,
+//	  PRIMARY KEY (set_id, version_id, sub_id, dim0, dim1)
+//	)
+type WorksetHistoryOptions struct {
+	MaxVersions int // if positive then max number of versions of workset parameter, older versions deleted
+	MaxDays     int // if positive then max age in days of workset parameter version, older versions deleted
+}
+
+// WorksetParamVersion is a version of workset parameter values: workset_param_version db row
+type WorksetParamVersion struct {
+	SetId           int    // workset id
+	ParamHid        int    // parameter unique id
+	VersionId       int    // version number: 1, 2, 3,...
+	SubCount        int    // number of parameter sub-values
+	DefaultSubId    int    // default sub-value id
+	VersionDateTime string // date-time when values were overwritten
+}
+
+// workset parameter history db tables
+const (
+	worksetHistoryTable      = "workset_param_history" // history options table
+	worksetParamVersionTable = "workset_param_version" // versions of workset parameters
+	worksetParamHistPrefix   = "workset_param_h"       // prefix of parameter values history table name
+)
+
+// EnableWorksetParamHistory enable history of workset parameter values and set retention options.
+// If history already enabled then retention options updated and applied to existing versions.
+func EnableWorksetParamHistory(dbConn *sql.DB, opts WorksetHistoryOptions) error {
+
+	if opts.MaxVersions < 0 || opts.MaxDays < 0 {
+		return errors.New("invalid workset history options: " + strconv.Itoa(opts.MaxVersions) + ", " + strconv.Itoa(opts.MaxDays))
+	}
+
+	// history tables must be created by database schema script
+	for _, tn := range []string{worksetHistoryTable, worksetParamVersionTable} {
+		if !isTableSelectable(dbConn, tn) {
+			return errors.New("workset history table not found, database must be upgraded by sql/upgrade_side_tables.sql: " + tn)
+		}
+	}
+
+	// replace history options
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	err = TrxUpdate(trx, "DELETE FROM "+worksetHistoryTable)
+	if err == nil {
+		err = TrxUpdate(trx,
+			"INSERT INTO "+worksetHistoryTable+" (max_versions, max_days)"+
+				" VALUES ("+strconv.Itoa(opts.MaxVersions)+", "+strconv.Itoa(opts.MaxDays)+")")
+	}
+	if err != nil {
+		trx.Rollback()
+		return errors.New("failed to update workset history options: " + err.Error())
+	}
+	trx.Commit()
+
+	return PruneWorksetParamHistory(dbConn)
+}
+
+// DisableWorksetParamHistory disable history of workset parameter values.
+// Existing versions are not deleted and can be restored, use PruneWorksetParamHistory to delete it.
+func DisableWorksetParamHistory(dbConn *sql.DB) error {
+
+	if !isTableSelectable(dbConn, worksetHistoryTable) {
+		return nil // history is not enabled
+	}
+	return Update(dbConn, "DELETE FROM "+worksetHistoryTable)
+}
+
+// GetWorksetParamHistoryOptions return history retention options and true if history of workset parameter values enabled.
+func GetWorksetParamHistoryOptions(dbConn *sql.DB) (*WorksetHistoryOptions, bool, error) {
+
+	if !isTableSelectable(dbConn, worksetHistoryTable) {
+		return nil, false, nil // history is not enabled
+	}
+
+	var opts WorksetHistoryOptions
+
+	err := SelectFirst(dbConn,
+		"SELECT max_versions, max_days FROM "+worksetHistoryTable,
+		func(row *sql.Row) error {
+			return row.Scan(&opts.MaxVersions, &opts.MaxDays)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, false, nil // history is disabled
+	case err != nil:
+		return nil, false, err
+	}
+	return &opts, true, nil
+}
+
+// GetWorksetParamVersions return list of versions of workset parameter values, ordered by version number.
+// Return empty list if history is not enabled or there are no versions.
+func GetWorksetParamVersions(dbConn *sql.DB, setId int, paramHid int) ([]WorksetParamVersion, error) {
+
+	if !isTableSelectable(dbConn, worksetParamVersionTable) {
+		return []WorksetParamVersion{}, nil
+	}
+
+	vLst := []WorksetParamVersion{}
+
+	err := SelectRows(dbConn,
+		"SELECT set_id, parameter_hid, version_id, sub_count, default_sub_id, version_dt FROM "+worksetParamVersionTable+
+			" WHERE set_id = "+strconv.Itoa(setId)+
+			" AND parameter_hid = "+strconv.Itoa(paramHid)+
+			" ORDER BY 3",
+		func(rows *sql.Rows) error {
+			var v WorksetParamVersion
+			if err := rows.Scan(&v.SetId, &v.ParamHid, &v.VersionId, &v.SubCount, &v.DefaultSubId, &v.VersionDateTime); err != nil {
+				return err
+			}
+			vLst = append(vLst, v)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return vLst, nil
+}
+
+// ReadWorksetParamVersionTo read parameter values of workset parameter version and pass each row into cvtTo().
+// Each row passed to cvtTo() is a CellParam, rows ordered by sub-value id and dimensions.
+func ReadWorksetParamVersionTo(dbConn *sql.DB, modelDef *ModelMeta, setId int, name string, versionId int, cvtTo func(src interface{}) (bool, error)) error {
+
+	if modelDef == nil {
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	k, ok := modelDef.ParamByName(name)
+	if !ok {
+		return errors.New("parameter not found: " + name)
+	}
+	param := &modelDef.Param[k]
+
+	v, err := findWorksetParamVersion(dbConn, setId, param.ParamHid, versionId)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return errors.New("workset parameter version not found: " + name + ": " + strconv.Itoa(versionId))
+	}
+
+	// SELECT sub_id, dim0, dim1, param_value FROM workset_param_h17 WHERE set_id = 2 AND version_id = 3 ORDER BY 1, 2, 3
+	q := "SELECT sub_id, "
+	for j := range param.Dim {
+		q += param.Dim[j].colName + ", "
+	}
+	q += "param_value FROM " + worksetParamHistTable(param) +
+		" WHERE set_id = " + strconv.Itoa(setId) +
+		" AND version_id = " + strconv.Itoa(versionId) +
+		" ORDER BY 1"
+	for j := range param.Dim {
+		q += ", " + strconv.Itoa(j+2)
+	}
+
+	scanBuf, fc := scanSqlRowToCellParam(param)
+	isNext := true
+
+	err = SelectRows(dbConn, q,
+		func(rows *sql.Rows) error {
+
+			if !isNext {
+				return nil // skip the rest of rows
+			}
+			if e := rows.Scan(scanBuf...); e != nil {
+				return e
+			}
+			var c = CellParam{cellIdValue: cellIdValue{DimIds: make([]int, param.Rank)}}
+			if e := fc(&c); e != nil {
+				return e
+			}
+
+			var e error
+			isNext, e = cvtTo(c)
+			return e
+		})
+	return err
+}
+
+// RestoreWorksetParamVersion replace workset parameter values by values of parameter version.
+// Workset must be read-write and parameter must exist in workset.
+// Current parameter values saved as a new version before restore, if history is enabled.
+func RestoreWorksetParamVersion(dbConn *sql.DB, modelDef *ModelMeta, setId int, name string, versionId int) error {
+
+	if modelDef == nil {
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
+	}
+	k, ok := modelDef.ParamByName(name)
+	if !ok {
+		return errors.New("parameter not found: " + name)
+	}
+	param := &modelDef.Param[k]
+
+	v, err := findWorksetParamVersion(dbConn, setId, param.ParamHid, versionId)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return errors.New("workset parameter version not found: " + name + ": " + strconv.Itoa(versionId))
+	}
+	if n, _, e := GetWorksetParam(dbConn, setId, param.ParamHid); e != nil || n <= 0 {
+		if e != nil {
+			return e
+		}
+		return errors.New("parameter not found: " + name + " in workset: " + strconv.Itoa(setId))
+	}
+
+	// read version values
+	cLst := []CellParam{}
+	err = ReadWorksetParamVersionTo(dbConn, modelDef, setId, name, versionId, func(src interface{}) (bool, error) {
+		cLst = append(cLst, src.(CellParam))
+		return true, nil
+	})
+	if err != nil {
+		return errors.New("failed to read workset parameter version: " + name + ": " + strconv.Itoa(versionId) + ": " + err.Error())
+	}
+
+	nIdx := 0
+	from := func() (interface{}, error) {
+		if nIdx >= len(cLst) {
+			return nil, nil // end of data
+		}
+		nIdx++
+		return cLst[nIdx-1], nil
+	}
+
+	// replace parameter values and sub-values count in transaction scope
+	hist, err := prepareWorksetParamHistory(dbConn, param)
+	if err != nil {
+		return err
+	}
+	isDgst := IsWorksetParamDigest(dbConn)
+	dbFacet := facetOf(dbConn)
+	sId := strconv.Itoa(setId)
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	err = doSaveWorksetParamVersion(trx, hist, param, setId)
+	if err == nil {
+		err = TrxUpdate(trx,
+			"UPDATE workset_parameter"+
+				" SET sub_count = "+strconv.Itoa(v.SubCount)+", default_sub_id = "+strconv.Itoa(v.DefaultSubId)+
+				" WHERE set_id = "+sId+" AND parameter_hid = "+strconv.Itoa(param.ParamHid))
+	}
+	if err == nil {
+		err = doWriteSetParameterFrom(trx, dbFacet, param, setId, v.SubCount, v.DefaultSubId, false, from, "")
+	}
+	if err == nil && isDgst {
+		_, err = doUpdateWorksetParamDigest(trx, modelDef, param, setId)
+	}
+	if err != nil {
+		trx.Rollback()
+		return errors.New("failed to restore workset parameter version: " + name + ": " + strconv.Itoa(versionId) + ": " + err.Error())
+	}
+	trx.Commit()
+	return nil
+}
+
+// PruneWorksetParamHistory delete versions of workset parameters according to retention options.
+// If history is disabled then all versions deleted.
+func PruneWorksetParamHistory(dbConn *sql.DB) error {
+
+	if !isTableSelectable(dbConn, worksetParamVersionTable) {
+		return nil // there is no history
+	}
+	hist, isOn, err := GetWorksetParamHistoryOptions(dbConn)
+	if err != nil {
+		return err
+	}
+	if !isOn {
+		hist = nil
+	}
+
+	// for each workset parameter delete versions out of retention limits
+	type setParam struct{ setId, paramHid int }
+	spLst := []setParam{}
+
+	err = SelectRows(dbConn,
+		"SELECT DISTINCT set_id, parameter_hid FROM "+worksetParamVersionTable+" ORDER BY 1, 2",
+		func(rows *sql.Rows) error {
+			var sp setParam
+			if err := rows.Scan(&sp.setId, &sp.paramHid); err != nil {
+				return err
+			}
+			spLst = append(spLst, sp)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	for _, sp := range spLst {
+
+		trx, err := dbConn.Begin()
+		if err != nil {
+			return err
+		}
+		if hist != nil {
+			err = doPruneWorksetParamVersions(trx, hist, sp.setId, sp.paramHid)
+		} else {
+			err = doDeleteWorksetParamVersions(trx, sp.setId, sp.paramHid, "")
+		}
+		if err != nil {
+			trx.Rollback()
+			return errors.New("failed to delete workset parameter versions: " + strconv.Itoa(sp.setId) + ": " + strconv.Itoa(sp.paramHid) + ": " + err.Error())
+		}
+		trx.Commit()
+	}
+	return nil
+}
+
+// delete all versions of all parameters of the workset, it is empty operation if there is no history
+func deleteWorksetParamHistory(dbConn *sql.DB, setId int) error {
+
+	if !isTableSelectable(dbConn, worksetParamVersionTable) {
+		return nil // there is no history
+	}
+	hLst := []int{}
+
+	err := SelectRows(dbConn,
+		"SELECT DISTINCT parameter_hid FROM "+worksetParamVersionTable+" WHERE set_id = "+strconv.Itoa(setId)+" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var h int
+			if err := rows.Scan(&h); err != nil {
+				return err
+			}
+			hLst = append(hLst, h)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	trx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	for _, h := range hLst {
+		if err = doDeleteWorksetParamVersions(trx, setId, h, ""); err != nil {
+			trx.Rollback()
+			return err
+		}
+	}
+	trx.Commit()
+	return nil
+}
+
+// return list of parameter values history tables of model parameters, which are not shared with other models.
+// Only existing tables included, it must be called before model delete transaction.
+func modelParamHistTables(dbConn *sql.DB, modelId int) ([]string, error) {
+
+	if !isTableSelectable(dbConn, worksetParamVersionTable) {
+		return []string{}, nil // there is no history
+	}
+	smId := strconv.Itoa(modelId)
+
+	hLst := []int{}
+	err := SelectRows(dbConn,
+		"SELECT M.parameter_hid FROM model_parameter_dic M"+
+			" WHERE M.model_id = "+smId+
+			" AND NOT EXISTS"+
+			" (SELECT NE.parameter_hid FROM model_parameter_dic NE WHERE NE.parameter_hid = M.parameter_hid AND NE.model_id <> "+smId+")"+
+			" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var h int
+			if err := rows.Scan(&h); err != nil {
+				return err
+			}
+			hLst = append(hLst, h)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	tLst := []string{}
+	for _, h := range hLst {
+		if tn := worksetParamHistPrefix + strconv.Itoa(h); isTableSelectable(dbConn, tn) {
+			tLst = append(tLst, tn)
+		}
+	}
+	return tLst, nil
+}
+
+// delete versions of parameters of all model worksets and drop parameter values history tables.
+// It does update as part of transaction.
+func trxDeleteModelParamHistory(trx *sql.Tx, modelId int, histTables []string) error {
+
+	smId := strconv.Itoa(modelId)
+
+	// parameters of model worksets where versions exist
+	hLst := []int{}
+	err := TrxSelectRows(trx,
+		"SELECT DISTINCT V.parameter_hid FROM "+worksetParamVersionTable+" V"+
+			" INNER JOIN workset_lst W ON (W.set_id = V.set_id)"+
+			" WHERE W.model_id = "+smId+
+			" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var h int
+			if err := rows.Scan(&h); err != nil {
+				return err
+			}
+			hLst = append(hLst, h)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	// DELETE FROM workset_param_h17 WHERE EXISTS (SELECT set_id FROM workset_lst W WHERE W.set_id = workset_param_h17.set_id AND W.model_id = 1)
+	for _, h := range hLst {
+		tn := worksetParamHistPrefix + strconv.Itoa(h)
+		err = TrxUpdate(trx,
+			"DELETE FROM "+tn+" WHERE EXISTS"+
+				" (SELECT set_id FROM workset_lst W WHERE W.set_id = "+tn+".set_id AND W.model_id = "+smId+")")
+		if err != nil {
+			return err
+		}
+	}
+	err = TrxUpdate(trx,
+		"DELETE FROM "+worksetParamVersionTable+" WHERE EXISTS"+
+			" (SELECT set_id FROM workset_lst W WHERE W.set_id = "+worksetParamVersionTable+".set_id AND W.model_id = "+smId+")")
+	if err != nil {
+		return err
+	}
+
+	// drop history tables of parameters which are not shared with other models
+	for _, tn := range histTables {
+		if err = TrxUpdate(trx, "DROP TABLE "+tn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// return history retention options if history is enabled and create parameter values history table if not exists.
+// Return nil if history of workset parameter values is not enabled.
+// It must be called before transaction because database schema may be updated.
+func prepareWorksetParamHistory(dbConn *sql.DB, param *ParamMeta) (*WorksetHistoryOptions, error) {
+
+	hist, isOn, err := GetWorksetParamHistoryOptions(dbConn)
+	if err != nil || !isOn {
+		return nil, err
+	}
+
+	hTable := worksetParamHistTable(param)
+	if isTableSelectable(dbConn, hTable) {
+		return hist, nil
+	}
+
+	// CREATE TABLE workset_param_h17 (set_id INT NOT NULL, version_id INT NOT NULL, sub_id SMALLINT NOT NULL, dim0 INT NOT NULL, param_value FLOAT NULL, PRIMARY KEY (set_id, version_id, sub_id, dim0))
+	dbFacet := facetOf(dbConn)
+
+	tname, err := param.typeOf.sqlColumnType(dbFacet)
+	if err != nil {
+		return nil, err
+	}
+	colPart := ""
+	keyPart := ""
+	for k := range param.Dim {
+		colPart += param.Dim[k].colName + " INT NOT NULL, "
+		keyPart += ", " + param.Dim[k].colName
+	}
+
+	err = Update(dbConn, dbFacet.createTableIfNotExist(hTable, "("+
+		"set_id INT NOT NULL, "+
+		"version_id INT NOT NULL, "+
+		"sub_id SMALLINT NOT NULL, "+
+		colPart+
+		"param_value "+tname+" NULL, "+
+		"PRIMARY KEY (set_id, version_id, sub_id"+keyPart+"))"))
+	if err != nil {
+		return nil, errors.New("failed to create parameter history table: " + param.Name + ": " + err.Error())
+	}
+	return hist, nil
+}
+
+// save current workset parameter values as a new version and delete old versions out of retention limits.
+// It is empty operation if history is not enabled (hist is nil) or workset does not contain parameter values.
+// It does update as part of transaction.
+func doSaveWorksetParamVersion(trx *sql.Tx, hist *WorksetHistoryOptions, param *ParamMeta, setId int) error {
+
+	if hist == nil {
+		return nil // history is not enabled
+	}
+	sId := strconv.Itoa(setId)
+	sHid := strconv.Itoa(param.ParamHid)
+
+	// count sub-values of existing values, skip if workset parameter is empty
+	nSub := 0
+	err := TrxSelectFirst(trx,
+		"SELECT COUNT(DISTINCT sub_id) FROM "+param.DbSetTable+" WHERE set_id = "+sId,
+		func(row *sql.Row) error {
+			return row.Scan(&nSub)
+		})
+	if err != nil {
+		return err
+	}
+	if nSub <= 0 {
+		return nil // workset parameter values are empty: nothing to save
+	}
+
+	defId := 0
+	err = TrxSelectFirst(trx,
+		"SELECT default_sub_id FROM workset_parameter WHERE set_id = "+sId+" AND parameter_hid = "+sHid,
+		func(row *sql.Row) error {
+			return row.Scan(&defId)
+		})
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if defId < 0 || defId >= nSub {
+		defId = 0
+	}
+
+	// next version number
+	nVer := 0
+	err = TrxSelectFirst(trx,
+		"SELECT COALESCE(MAX(version_id), 0) FROM "+worksetParamVersionTable+" WHERE set_id = "+sId+" AND parameter_hid = "+sHid,
+		func(row *sql.Row) error {
+			return row.Scan(&nVer)
+		})
+	if err != nil {
+		return err
+	}
+	nVer++
+	sVer := strconv.Itoa(nVer)
+
+	// INSERT INTO workset_param_h17 (set_id, version_id, sub_id, dim0, dim1, param_value)
+	// SELECT set_id, 3, sub_id, dim0, dim1, param_value FROM ageSex_w2012_817 WHERE set_id = 2
+	cols := "sub_id, "
+	for k := range param.Dim {
+		cols += param.Dim[k].colName + ", "
+	}
+	cols += "param_value"
+
+	err = TrxUpdate(trx,
+		"INSERT INTO "+worksetParamHistTable(param)+" (set_id, version_id, "+cols+")"+
+			" SELECT set_id, "+sVer+", "+cols+" FROM "+param.DbSetTable+" WHERE set_id = "+sId)
+	if err != nil {
+		return err
+	}
+	err = TrxUpdate(trx,
+		"INSERT INTO "+worksetParamVersionTable+" (set_id, parameter_hid, version_id, sub_count, default_sub_id, version_dt)"+
+			" VALUES ("+sId+", "+sHid+", "+sVer+", "+strconv.Itoa(nSub)+", "+strconv.Itoa(defId)+", "+ToQuoted(helper.MakeDateTime(time.Now()))+")")
+	if err != nil {
+		return err
+	}
+
+	return doPruneWorksetParamVersions(trx, hist, setId, param.ParamHid)
+}
+
+// delete workset parameter versions out of retention limits: exceeding max number of versions or older than max days.
+// It does update as part of transaction.
+func doPruneWorksetParamVersions(trx *sql.Tx, hist *WorksetHistoryOptions, setId int, paramHid int) error {
+
+	sId := strconv.Itoa(setId)
+	sHid := strconv.Itoa(paramHid)
+
+	if hist.MaxVersions > 0 {
+
+		// find the oldest version to keep
+		nMax := 0
+		err := TrxSelectFirst(trx,
+			"SELECT COALESCE(MAX(version_id), 0) FROM "+worksetParamVersionTable+" WHERE set_id = "+sId+" AND parameter_hid = "+sHid,
+			func(row *sql.Row) error {
+				return row.Scan(&nMax)
+			})
+		if err != nil {
+			return err
+		}
+		if nMax > hist.MaxVersions {
+			err = doDeleteWorksetParamVersions(trx, setId, paramHid, "version_id <= "+strconv.Itoa(nMax-hist.MaxVersions))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if hist.MaxDays > 0 {
+		dt := helper.MakeDateTime(time.Now().AddDate(0, 0, -hist.MaxDays))
+		if err := doDeleteWorksetParamVersions(trx, setId, paramHid, "version_dt < "+ToQuoted(dt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete workset parameter versions and version values where versions filter is true, if filter is empty then delete all versions.
+// It does update as part of transaction.
+func doDeleteWorksetParamVersions(trx *sql.Tx, setId int, paramHid int, where string) error {
+
+	sId := strconv.Itoa(setId)
+	sHid := strconv.Itoa(paramHid)
+
+	vWhere := " WHERE set_id = " + sId + " AND parameter_hid = " + sHid
+	if where != "" {
+		vWhere += " AND " + where
+	}
+
+	// DELETE FROM workset_param_h17 WHERE set_id = 2 AND version_id IN (SELECT version_id FROM workset_param_version WHERE set_id = 2 AND parameter_hid = 17 AND version_id <= 3)
+	err := TrxUpdate(trx,
+		"DELETE FROM "+worksetParamHistPrefix+sHid+
+			" WHERE set_id = "+sId+
+			" AND version_id IN (SELECT version_id FROM "+worksetParamVersionTable+vWhere+")")
+	if err != nil {
+		return err
+	}
+	return TrxUpdate(trx, "DELETE FROM "+worksetParamVersionTable+vWhere)
+}
+
+// return workset parameter version or nil if not found
+func findWorksetParamVersion(dbConn *sql.DB, setId int, paramHid int, versionId int) (*WorksetParamVersion, error) {
+
+	vLst, err := GetWorksetParamVersions(dbConn, setId, paramHid)
+	if err != nil {
+		return nil, err
+	}
+	for k := range vLst {
+		if vLst[k].VersionId == versionId {
+			return &vLst[k], nil
+		}
+	}
+	return nil, nil
+}
+
+// return parameter values history table name: workset_param_h17
+func worksetParamHistTable(param *ParamMeta) string {
+	return worksetParamHistPrefix + strconv.Itoa(param.ParamHid)
+}
+
+// return true if table exists and can be selected
+func isTableSelectable(dbConn *sql.DB, tableName string) bool {
+	return SelectFirst(dbConn, "SELECT COUNT(*) FROM "+tableName+" WHERE 1 = 0", func(row *sql.Row) error {
+		var n int
+		return row.Scan(&n)
+	}) == nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestWorksetParamHistory(t *testing.T) {
+
+	// read-write workset id 1 with parameter ageRatio[dim0] of double type
+	dbConn := openTestDb(t,
+		"CREATE TABLE ageRatio_w (set_id INT, sub_id INT, dim0 INT, param_value FLOAT, PRIMARY KEY (set_id, sub_id, dim0))",
+		testWorksetSql(1, 1, "Edit", false),
+		"INSERT INTO workset_parameter (set_id, parameter_hid, sub_count, default_sub_id) VALUES (1, 17, 1, 0)",
+	)
+
+	modelDef := &ModelMeta{Param: []ParamMeta{{
+		ParamDicRow: ParamDicRow{ParamHid: 17, Name: "ageRatio", Rank: 1, DbSetTable: "ageRatio_w"},
+		Dim:         []ParamDimsRow{{Name: "dim0", colName: "dim0"}},
+		typeOf:      &TypeMeta{TypeDicRow: TypeDicRow{TypeId: 7, Name: "double"}},
+	}}}
+
+	// write parameter values: dim0 = 0, 1 and value = base + dim0
+	var err error
+	writeValues := func(base float64) {
+		t.Helper()
+		n := 0
+		err = WriteParameterFrom(dbConn, modelDef,
+			&WriteParamLayout{WriteLayout: WriteLayout{Name: "ageRatio", ToId: 1}, SubCount: 1},
+			func() (interface{}, error) {
+				if n >= 2 {
+					return nil, nil
+				}
+				n++
+				return CellParam{cellIdValue: cellIdValue{DimIds: []int{n - 1}, Value: base + float64(n-1)}}, nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	readVersion := func(versionId int) []float64 {
+		t.Helper()
+		vals := []float64{}
+		err = ReadWorksetParamVersionTo(dbConn, modelDef, 1, "ageRatio", versionId, func(src interface{}) (bool, error) {
+			vals = append(vals, src.(CellParam).Value.(float64))
+			return true, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vals
+	}
+
+	// history is not enabled: there are no versions
+	writeValues(10)
+	writeValues(20)
+	if _, isOn, err := GetWorksetParamHistoryOptions(dbConn); err != nil || isOn {
+		t.Fatalf("history must be disabled: %v %v", isOn, err)
+	}
+	if vLst, err := GetWorksetParamVersions(dbConn, 1, 17); err != nil || len(vLst) != 0 {
+		t.Fatalf("invalid versions: %v %v", vLst, err)
+	}
+
+	// enable history and overwrite values: previous values kept as versions, only 2 last versions retained
+	if err = EnableWorksetParamHistory(dbConn, WorksetHistoryOptions{MaxVersions: 2}); err != nil {
+		t.Fatal(err)
+	}
+	writeValues(30)
+	writeValues(40)
+	writeValues(50)
+
+	vLst, err := GetWorksetParamVersions(dbConn, 1, 17)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vLst) != 2 || vLst[0].VersionId != 2 || vLst[1].VersionId != 3 || vLst[0].SubCount != 1 {
+		t.Fatalf("invalid versions: %v", vLst)
+	}
+	if v := readVersion(2); len(v) != 2 || v[0] != 30 || v[1] != 31 {
+		t.Errorf("invalid version 2 values: %v", v)
+	}
+
+	// restore version 2: current values saved as version 4 and version 2 deleted by retention
+	if err = RestoreWorksetParamVersion(dbConn, modelDef, 1, "ageRatio", 2); err != nil {
+		t.Fatal(err)
+	}
+	var v0 float64
+	if err = SelectFirst(dbConn, "SELECT param_value FROM ageRatio_w WHERE set_id = 1 AND dim0 = 0", func(row *sql.Row) error {
+		return row.Scan(&v0)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if v0 != 30 {
+		t.Errorf("invalid restored value: %v", v0)
+	}
+	if vLst, err = GetWorksetParamVersions(dbConn, 1, 17); err != nil || len(vLst) != 2 || vLst[1].VersionId != 4 {
+		t.Fatalf("invalid versions after restore: %v %v", vLst, err)
+	}
+	if v := readVersion(4); len(v) != 2 || v[0] != 50 {
+		t.Errorf("invalid version 4 values: %v", v)
+	}
+	if err = RestoreWorksetParamVersion(dbConn, modelDef, 1, "ageRatio", 2); err == nil {
+		t.Error("expected error at restore of deleted version")
+	}
+
+	// disable history and delete all versions
+	if err = DisableWorksetParamHistory(dbConn); err != nil {
+		t.Fatal(err)
+	}
+	if err = PruneWorksetParamHistory(dbConn); err != nil {
+		t.Fatal(err)
+	}
+	if vLst, err = GetWorksetParamVersions(dbConn, 1, 17); err != nil || len(vLst) != 0 {
+		t.Fatalf("invalid versions after prune: %v %v", vLst, err)
+	}
+
+	// model delete must delete versions and drop parameter history table
+	if err = EnableWorksetParamHistory(dbConn, WorksetHistoryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	writeValues(60)
+	if vLst, err = GetWorksetParamVersions(dbConn, 1, 17); err != nil || len(vLst) != 1 {
+		t.Fatalf("invalid versions before model delete: %v %v", vLst, err)
+	}
+	testUpdate(t, dbConn,
+		testModelSql(1, "modelOne", "m1"),
+		"INSERT INTO model_parameter_dic (model_id, model_parameter_id, parameter_hid, is_hidden) VALUES (1, 0, 17, 0)",
+	)
+	if err = DeleteModel(dbConn, 1); err != nil {
+		t.Fatal(err)
+	}
+	if vLst, err = GetWorksetParamVersions(dbConn, 1, 17); err != nil || len(vLst) != 0 {
+		t.Errorf("invalid versions after model delete: %v %v", vLst, err)
+	}
+	if isTableSelectable(dbConn, "workset_param_h17") {
+		t.Error("parameter history table not dropped by model delete")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"hash"
 	"strconv"
+
+	"github.com/openmpp/go/ompp/omppLog"
 )
 
 // WriteMicrodataFrom insert microdata values into model run until from() return not nil CellMicro value.
@@ -27,10 +29,10 @@ func WriteMicrodataFrom(
 
 	// validate parameters
 	if modelDef == nil {
-		return errors.New("invalid (empty) model metadata, look like model not found")
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if runMeta == nil {
-		return errors.New("invalid (empty) model run metadata, look like model run not found")
+		return newDbError(ErrRunNotFound, "invalid (empty) model run metadata, look like model run not found")
 	}
 	if layout == nil {
 		return errors.New("invalid (empty) write layout")
@@ -130,7 +132,7 @@ func doWriteMicrodataFrom(
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return []RunEntityRow{}, errors.New("model run not found, id: " + sRunId)
+		return []RunEntityRow{}, newDbError(ErrRunNotFound, "model run not found, id: "+sRunId)
 	case err != nil:
 		return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
 	}
@@ -209,7 +211,7 @@ func doWriteMicrodataFrom(
 			})
 		switch {
 		case err == sql.ErrNoRows:
-			return []RunEntityRow{}, errors.New("invalid destination database, likely not an openM++ database")
+			return []RunEntityRow{}, newDbError(ErrNotOmppSchema, "invalid destination database, likely not an openM++ database")
 		case err != nil:
 			return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
 		}
@@ -267,18 +269,43 @@ func doWriteMicrodataFrom(
 			}
 		}
 
-		tSql := dbFacet.createTableIfNotExist(
-			entityGen.DbEntityTable,
-			"("+
-				"run_id INT NOT NULL, "+
-				"entity_key BIGINT NOT NULL, "+
-				attrSql+
-				"PRIMARY KEY (run_id, entity_key)"+
-				")",
-		)
-		err = TrxUpdate(trx, tSql)
-		if err != nil {
-			return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
+		// if run option Microdata.Pack.Person = sex,region specified then
+		// for SQLite store packed attributes in dictionary table and create microdata view instead of table
+		isPack := false
+
+		if packNames := MicrodataPackAttrs(runMeta.Opts, entityName); len(packNames) > 0 {
+
+			if dbFacet != SqliteFacet {
+				omppLog.Log("Warning: microdata attributes packing is supported only for SQLite, ignored: ", MicrodataPackOptPrefix+entityName)
+			} else {
+
+				isExist, err := trxIsMicroTableExist(trx, entityGen.DbEntityTable)
+				if err != nil {
+					return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
+				}
+				if !isExist {
+					if err = trxCreatePackedMicroTable(trx, dbFacet, entityGen.DbEntityTable, entAttr, packNames); err != nil {
+						return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
+					}
+				}
+				isPack = true
+			}
+		}
+
+		if !isPack {
+			tSql := dbFacet.createTableIfNotExist(
+				entityGen.DbEntityTable,
+				"("+
+					"run_id INT NOT NULL, "+
+					"entity_key BIGINT NOT NULL, "+
+					attrSql+
+					"PRIMARY KEY (run_id, entity_key)"+
+					")",
+			)
+			err = TrxUpdate(trx, tSql)
+			if err != nil {
+				return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
+			}
 		}
 	}
 
@@ -312,7 +339,7 @@ func doWriteMicrodataFrom(
 	put := putInsertMicroFrom(entityName, entAttr, from, digestFrom)
 
 	// execute sql insert using put() above for each row
-	if err = TrxUpdateStatement(trx, q, put); err != nil {
+	if err = trxInsertBulk(trx, dbFacet, q, put); err != nil {
 		return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
 	}
 
@@ -358,7 +385,11 @@ func doWriteMicrodataFrom(
 		if err != nil {
 			return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
 		}
-		err = TrxUpdate(trx, "DELETE FROM "+entityGen.DbEntityTable+" WHERE run_id = "+sRunId)
+		vTbl, err := trxMicroValueTable(trx, dbFacet, entityGen.DbEntityTable)
+		if err != nil {
+			return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
+		}
+		err = TrxUpdate(trx, "DELETE FROM "+vTbl+" WHERE run_id = "+sRunId)
 		if err != nil {
 			return []RunEntityRow{}, errors.New("insert microdata failed: " + entityName + ": " + err.Error())
 		}
@@ -24,7 +24,7 @@ func WriteOutputTableFrom(
 
 	// validate parameters
 	if modelDef == nil {
-		return errors.New("invalid (empty) model metadata, look like model not found")
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return errors.New("invalid (empty) write layout")
@@ -52,7 +52,7 @@ func WriteOutputTableFrom(
 	if err != nil {
 		return err
 	}
-	if err = doWriteOutputTableFrom(trx, modelDef, meta, layout.ToId, layout.DoubleFmt, accFrom, exprFrom); err != nil {
+	if err = doWriteOutputTableFrom(trx, facetOf(dbConn), modelDef, meta, layout.ToId, layout.DoubleFmt, accFrom, exprFrom); err != nil {
 		trx.Rollback()
 		return err
 	}
@@ -67,7 +67,7 @@ func WriteOutputTableFrom(
 // Model run should not already contain output table values: it can be inserted only once in model run and cannot be updated after.
 // Double format is used for float model types digest calculation, if non-empty format supplied
 func doWriteOutputTableFrom(
-	trx *sql.Tx, modelDef *ModelMeta, meta *TableMeta, runId int, doubleFmt string, accFrom func() (interface{}, error), exprFrom func() (interface{}, error),
+	trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, meta *TableMeta, runId int, doubleFmt string, accFrom func() (interface{}, error), exprFrom func() (interface{}, error),
 ) error {
 
 	// update model run master record to prevent run use
@@ -90,7 +90,7 @@ func doWriteOutputTableFrom(
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("model run not found, id: " + srId)
+		return newDbError(ErrRunNotFound, "model run not found, id: "+srId)
 	case err != nil:
 		return err
 	}
@@ -137,7 +137,7 @@ func doWriteOutputTableFrom(
 	accSql := makeSqlAccValueInsert(meta, runId)
 	put := putAccInsertFrom(meta, accFrom, digestAcc)
 
-	if err = TrxUpdateStatement(trx, accSql, put); err != nil {
+	if err = trxInsertBulk(trx, dbFacet, accSql, put); err != nil {
 		return err
 	}
 	// check if all rows ordered by primary key, digest is incorrect otherwise
@@ -156,7 +156,7 @@ func doWriteOutputTableFrom(
 	exprSql := makeSqlExprValueInsert(meta, runId)
 	put = putExprInsertFrom(meta, exprFrom, digestExpr)
 
-	if err = TrxUpdateStatement(trx, exprSql, put); err != nil {
+	if err = trxInsertBulk(trx, dbFacet, exprSql, put); err != nil {
 		return err
 	}
 	// check if all rows ordered by primary key, digest is incorrect otherwise
@@ -341,7 +341,11 @@ func putExprInsertFrom(
 		}
 
 		// cell value is nullable
-		row[n+1] = sql.NullFloat64{Float64: cell.Value.(float64), Valid: !cell.IsNull}
+		fv, isFn, err := floatToDb(cell.Value.(float64), true, meta.Name)
+		if err != nil {
+			return false, nil, err
+		}
+		row[n+1] = sql.NullFloat64{Float64: fv, Valid: !cell.IsNull && !isFn}
 
 		// append row digest to output table digest
 		err = digestFrom(cell)
@@ -416,7 +420,11 @@ func putAccInsertFrom(
 		}
 
 		// cell value is nullable
-		row[n+2] = sql.NullFloat64{Float64: cell.Value.(float64), Valid: !cell.IsNull}
+		fv, isFn, err := floatToDb(cell.Value.(float64), true, meta.Name)
+		if err != nil {
+			return false, nil, err
+		}
+		row[n+2] = sql.NullFloat64{Float64: fv, Valid: !cell.IsNull && !isFn}
 
 		// append row digest to output table digest
 		err = digestFrom(cell)
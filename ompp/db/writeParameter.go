@@ -29,7 +29,7 @@ func WriteParameterFrom(dbConn *sql.DB, modelDef *ModelMeta, layout *WriteParamL
 
 	// validate parameters
 	if modelDef == nil {
-		return errors.New("invalid (empty) model metadata, look like model not found")
+		return newDbError(ErrModelNotFound, "invalid (empty) model metadata, look like model not found")
 	}
 	if layout == nil {
 		return errors.New("invalid (empty) write layout")
@@ -70,15 +70,35 @@ func WriteParameterFrom(dbConn *sql.DB, modelDef *ModelMeta, layout *WriteParamL
 		defSubId = defId
 	}
 
+	// if workset parameter value digest column exist then update digest after write
+	isDgst := !layout.IsToRun && IsWorksetParamDigest(dbConn)
+	dbFacet := facetOf(dbConn)
+
+	// if history of workset parameter values enabled then save existing values before write
+	var hist *WorksetHistoryOptions
+	if !layout.IsToRun {
+		h, e := prepareWorksetParamHistory(dbConn, param)
+		if e != nil {
+			return e
+		}
+		hist = h
+	}
+
 	// do insert or update parameter in transaction scope
 	trx, err := dbConn.Begin()
 	if err != nil {
 		return err
 	}
 	if layout.IsToRun {
-		err = doWriteRunParameterFrom(trx, modelDef, param, layout.ToId, layout.SubCount, from, layout.DoubleFmt)
+		err = doWriteRunParameterFrom(trx, dbFacet, modelDef, param, layout.ToId, layout.SubCount, from, layout.DoubleFmt)
 	} else {
-		err = doWriteSetParameterFrom(trx, param, layout.ToId, layout.SubCount, defSubId, layout.IsPage, from, layout.DoubleFmt)
+		err = doSaveWorksetParamVersion(trx, hist, param, layout.ToId)
+		if err == nil {
+			err = doWriteSetParameterFrom(trx, dbFacet, param, layout.ToId, layout.SubCount, defSubId, layout.IsPage, from, layout.DoubleFmt)
+		}
+		if err == nil && isDgst {
+			_, err = doUpdateWorksetParamDigest(trx, modelDef, param, layout.ToId)
+		}
 	}
 	if err != nil {
 		trx.Rollback()
@@ -95,55 +115,17 @@ func WriteParameterFrom(dbConn *sql.DB, modelDef *ModelMeta, layout *WriteParamL
 // Model run should not already contain parameter values: parameter can be inserted only once in model run and cannot be updated after.
 // Double format is used for float model types digest calculation, if non-empty format supplied
 func doWriteRunParameterFrom(
-	trx *sql.Tx, modelDef *ModelMeta, param *ParamMeta, runId int, subCount int, from func() (interface{}, error), doubleFmt string,
+	trx *sql.Tx, dbFacet Facet, modelDef *ModelMeta, param *ParamMeta, runId int, subCount int, from func() (interface{}, error), doubleFmt string,
 ) error {
 
-	// update model run master record to prevent run use
+	// update model run master record to prevent run use and check if parameter can be inserted
 	srId := strconv.Itoa(runId)
-	err := TrxUpdate(trx,
-		"UPDATE run_lst SET sub_restart = sub_restart - 1 WHERE run_id = "+srId)
-	if err != nil {
-		return err
-	}
-
-	// check if model run exist and status is completed
-	st := ""
-	err = TrxSelectFirst(trx,
-		"SELECT status FROM run_lst WHERE run_id = "+srId,
-		func(row *sql.Row) error {
-			if err := row.Scan(&st); err != nil {
-				return err
-			}
-			return nil
-		})
-	switch {
-	case err == sql.ErrNoRows:
-		return errors.New("model run not found, id: " + srId)
-	case err != nil:
-		return err
-	}
-	if !IsRunCompleted(st) {
-		return errors.New("model run not completed, id: " + srId)
-	}
-
-	// check if parameter values not already exist for that run
 	sHid := strconv.Itoa(param.ParamHid)
-	n := 0
-	err = TrxSelectFirst(trx,
-		"SELECT COUNT(*) FROM run_parameter"+" WHERE run_id = "+srId+" AND parameter_hid = "+sHid,
-		func(row *sql.Row) error {
-			if err := row.Scan(&n); err != nil {
-				return err
-			}
-			return nil
-		})
-	switch {
-	case err != nil && err != sql.ErrNoRows:
+
+	err := trxCheckRunParamInsert(trx, param, srId)
+	if err != nil {
 		return err
 	}
-	if n > 0 {
-		return errors.New("model run with id: " + srId + " already contain parameter values " + param.Name)
-	}
 
 	// insert into run_parameter with current run id as base run id and NULL digest
 	err = TrxUpdate(trx,
@@ -166,7 +148,7 @@ func doWriteRunParameterFrom(
 	put := putInsertParamFrom(param, subCount, 0, from, digestFrom)
 
 	// execute sql insert using put() above for each row
-	if err = TrxUpdateStatement(trx, q, put); err != nil {
+	if err = trxInsertBulk(trx, dbFacet, q, put); err != nil {
 		return errors.New("insert parameter failed: " + param.Name + " " + err.Error())
 	}
 
@@ -258,7 +240,7 @@ func doWriteRunParameterFrom(
 // It does insert as part of transaction
 // If workset already contain parameter values then values updated else inserted.
 func doWriteSetParameterFrom(
-	trx *sql.Tx, param *ParamMeta, setId int, subCount int, defaultSubId int, isPage bool, from func() (interface{}, error), doubleFmt string,
+	trx *sql.Tx, dbFacet Facet, param *ParamMeta, setId int, subCount int, defaultSubId int, isPage bool, from func() (interface{}, error), doubleFmt string,
 ) error {
 
 	// start workset update
@@ -283,7 +265,7 @@ func doWriteSetParameterFrom(
 		})
 	switch {
 	case err == sql.ErrNoRows:
-		return errors.New("workset not found, id: " + sId)
+		return newDbError(ErrWorksetNotFound, "workset not found, id: "+sId)
 	case err != nil:
 		return err
 	}
@@ -303,7 +285,7 @@ func doWriteSetParameterFrom(
 		put := putInsertParamFrom(param, subCount, defaultSubId, from, nil)
 
 		// execute sql insert using put() above for each row
-		if err = TrxUpdateStatement(trx, sql, put); err != nil {
+		if err = trxInsertBulk(trx, dbFacet, sql, put); err != nil {
 			return errors.New("insert parameter failed: " + param.Name + " " + err.Error())
 		}
 
@@ -533,10 +515,11 @@ func cvtParamValueToSqlString(param *ParamMeta, doubleFmt string) func(cell Cell
 	// cell value is nullable for extended parameters only
 	isNullable := param.IsExtendable
 
-	isUseFmt := param.typeOf.IsFloat() && doubleFmt != "" // for float model types use format if specified
-	isUseEnum := !param.typeOf.IsBuiltIn()                // parameter is enum-based: validate enum id, it must be in enum type
-	isBool := param.typeOf.IsBool()                       // boolean sql values are 0 or 1
-	isStr := param.typeOf.IsString()                      // string value must 'sql quoted'
+	isFloat := param.typeOf.IsFloat()      // float values can be NaN, +Inf, -Inf
+	isUseFmt := isFloat && doubleFmt != "" // for float model types use format if specified
+	isUseEnum := !param.typeOf.IsBuiltIn() // parameter is enum-based: validate enum id, it must be in enum type
+	isBool := param.typeOf.IsBool()        // boolean sql values are 0 or 1
+	isStr := param.typeOf.IsString()       // string value must 'sql quoted'
 
 	cvt := func(cell CellParam) (string, error) {
 
@@ -583,6 +566,21 @@ func cvtParamValueToSqlString(param *ParamMeta, doubleFmt string) func(cell Cell
 			return "", errors.New("invalid parameter value type, enum id not found: " + strconv.Itoa(iv))
 		}
 
+		// special float values: NaN, +Inf, -Inf written according to special float values policy
+		if isFloat {
+			if v, ok := cell.Value.(float64); ok && isFloatSpecial(v) {
+
+				fv, isFn, err := floatToDb(v, isNullable, param.Name)
+				if err != nil {
+					return "", err
+				}
+				if isFn {
+					return "NULL", nil
+				}
+				return strconv.FormatFloat(fv, 'g', -1, 64), nil
+			}
+		}
+
 		// format integer and for model float types
 		if isUseFmt {
 			return fmt.Sprintf(doubleFmt, cell.Value), nil
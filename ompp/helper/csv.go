@@ -0,0 +1,166 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// CsvNull is csv value of database NULL, empty "" csv value is also treated as NULL on read
+const CsvNull = "null"
+
+// CsvOptions define csv (or tsv) format used to read or write parameters, output tables and microdata.
+type CsvOptions struct {
+	IsTsv   bool // if true then use tab separator instead of comma
+	IsBom   bool // if true then write utf-8 BOM at the beginning of output
+	IsCrlf  bool // if true then use \r\n as line terminator instead of \n
+	Comment rune // if not zero then on read skip leading lines starting with that character, e.g. # run metadata comments
+}
+
+// IsCsvNull return true if csv value is NULL: empty "" or "null".
+func IsCsvNull(src string) bool {
+	return src == "" || src == CsvNull
+}
+
+// TrimCsvBom remove utf-8 BOM from the first column of csv header line.
+func TrimCsvBom(row []string) []string {
+	if len(row) > 0 {
+		row[0] = strings.TrimPrefix(row[0], string(Utf8bom))
+	}
+	return row
+}
+
+// IsCsvHeader return true if csv header line is equal to expected column names.
+// BOM is removed from the first column.
+// If isFold is true then column names compared case-insensitive after removing leading and trailing spaces.
+func IsCsvHeader(row []string, columnNames []string, isFold bool) bool {
+
+	TrimCsvBom(row)
+
+	if len(row) != len(columnNames) {
+		return false
+	}
+	for k := range columnNames {
+		if !isFold && row[k] != columnNames[k] ||
+			isFold && !strings.EqualFold(strings.TrimSpace(row[k]), columnNames[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewCsvWriter create csv writer and if required write utf-8 BOM at the beginning of output.
+func NewCsvWriter(w io.Writer, opts CsvOptions) (*csv.Writer, error) {
+
+	if opts.IsBom {
+		if _, err := w.Write(Utf8bom); err != nil {
+			return nil, err
+		}
+	}
+
+	wr := csv.NewWriter(w)
+	if opts.IsTsv {
+		wr.Comma = '\t'
+	}
+	wr.UseCRLF = opts.IsCrlf
+	return wr, nil
+}
+
+// NewCsvReader create csv reader: leading spaces are trimmed and row slice is reused on each read.
+// Source must be already converted into utf-8, for example by Utf8Reader().
+// If comment character specified then leading comment lines before csv header are skipped.
+func NewCsvReader(r io.Reader, opts CsvOptions) *csv.Reader {
+
+	if opts.Comment != 0 {
+		r = NewCsvCommentReader(r, opts.Comment)
+	}
+	rd := csv.NewReader(r)
+	if opts.IsTsv {
+		rd.Comma = '\t'
+	}
+	rd.TrimLeadingSpace = true
+	rd.ReuseRecord = true
+	return rd
+}
+
+// NewCsvCommentReader return reader which skip leading comment lines of csv source: lines starting with comment character.
+// Utf-8 BOM before first comment line is also skipped.
+func NewCsvCommentReader(r io.Reader, comment rune) io.Reader {
+	return &csvCommentReader{rd: bufio.NewReader(r), comment: []byte(string(comment))}
+}
+
+// csvCommentReader skip leading comment lines on first read
+type csvCommentReader struct {
+	rd      *bufio.Reader // source reader
+	comment []byte        // comment character
+	isDone  bool          // if true then leading comment lines already skipped
+}
+
+// Read from source after leading comment lines
+func (cr *csvCommentReader) Read(p []byte) (int, error) {
+
+	if !cr.isDone {
+		cr.isDone = true
+
+		if b, err := cr.rd.Peek(len(Utf8bom) + len(cr.comment)); err == nil && bytes.HasPrefix(b, Utf8bom) && bytes.Equal(b[len(Utf8bom):], cr.comment) {
+			cr.rd.Discard(len(Utf8bom))
+		}
+		for {
+			b, err := cr.rd.Peek(len(cr.comment))
+			if err != nil || !bytes.Equal(b, cr.comment) {
+				break
+			}
+			if _, err = cr.rd.ReadString('\n'); err != nil {
+				break
+			}
+		}
+	}
+	return cr.rd.Read(p)
+}
+
+// CsvFrom read csv header line, validate it and return closure to iterate over csv rows.
+//
+// Header must be equal to expected column names, BOM is removed from the first column.
+// Each csv row is converted by toCell() hook, for example from enum codes into enum id's db cell.
+// Closure return (nil, nil) at the end of csv.
+func CsvFrom(
+	rd *csv.Reader, name string, columnNames []string, toCell func(row []string) (interface{}, error),
+) (func() (interface{}, error), error) {
+
+	// read and validate header line
+	fhs, err := rd.Read()
+	switch {
+	case err == io.EOF:
+		return nil, errors.New("invalid (empty) csv file: " + name)
+	case err != nil:
+		return nil, errors.New("csv file read error: " + name + ": " + err.Error())
+	}
+	if !IsCsvHeader(fhs, columnNames, false) {
+		return nil, errors.New("Invalid csv file header " + name + ": " + strings.Join(fhs, ",") + " expected: " + strings.Join(columnNames, ","))
+	}
+
+	// convert each csv line into cell
+	from := func() (interface{}, error) {
+
+		row, err := rd.Read()
+		switch {
+		case err == io.EOF:
+			return nil, nil // eof
+		case err != nil:
+			return nil, errors.New("csv file read error: " + name + ": " + err.Error())
+		}
+
+		c, err := toCell(row)
+		if err != nil {
+			return nil, errors.New("csv file row convert error: " + name + ": " + err.Error())
+		}
+		return c, nil
+	}
+	return from, nil
+}
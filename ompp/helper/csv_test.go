@@ -0,0 +1,101 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package helper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCsvWriteRead(t *testing.T) {
+
+	// write csv with BOM
+	var buf bytes.Buffer
+	wr, err := NewCsvWriter(&buf, CsvOptions{IsBom: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hdr := []string{"sub_id", "dim0", "param_value"}
+	wr.Write(hdr)
+	wr.Write([]string{"0", "F", "1.5"})
+	wr.Write([]string{"0", "M", CsvNull})
+	wr.Flush()
+
+	if !bytes.HasPrefix(buf.Bytes(), Utf8bom) {
+		t.Error("Fail: csv BOM not found")
+	}
+
+	// read csv and check header and null values
+	from, err := CsvFrom(NewCsvReader(&buf, CsvOptions{}), "test.csv", hdr, func(row []string) (interface{}, error) {
+		return strings.Join(row, "|"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"0|F|1.5", "0|M|null"} {
+		c, err := from()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != expected {
+			t.Error("Fail csv row:", c, "expected:", expected)
+		}
+	}
+	if c, err := from(); c != nil || err != nil {
+		t.Error("Fail csv end of file:", c, err)
+	}
+
+	if !IsCsvNull("") || !IsCsvNull(CsvNull) || IsCsvNull("0") {
+		t.Error("Fail IsCsvNull")
+	}
+
+	// invalid header
+	_, err = CsvFrom(NewCsvReader(strings.NewReader("sub_id,dim0\n0,F\n"), CsvOptions{}), "test.csv", hdr, nil)
+	if err == nil {
+		t.Error("Fail: invalid csv header not detected")
+	}
+
+	// header compared case-insensitive
+	if !IsCsvHeader([]string{string(Utf8bom) + "Sub_Id", " DIM0", "param_value "}, hdr, true) {
+		t.Error("Fail IsCsvHeader case-insensitive")
+	}
+	if IsCsvHeader([]string{"Sub_Id", "dim0", "param_value"}, hdr, false) {
+		t.Error("Fail IsCsvHeader case-sensitive")
+	}
+}
+
+func TestCsvCommentRead(t *testing.T) {
+
+	hdr := []string{"sub_id", "dim0", "param_value"}
+	toRow := func(row []string) (interface{}, error) {
+		return strings.Join(row, "|"), nil
+	}
+
+	// leading comment lines skipped, including utf-8 BOM and comment line longer than read buffer
+	src := string(Utf8bom) + "# model: modelOne\n# command: " + strings.Repeat("x", 5000) + "\r\n" + "sub_id,dim0,param_value\n0,#F,1.5\n"
+
+	from, err := CsvFrom(NewCsvReader(strings.NewReader(src), CsvOptions{Comment: '#'}), "test.csv", hdr, toRow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, err := from(); err != nil || c != "0|#F|1.5" {
+		t.Error("Fail csv row after comments:", c, err)
+	}
+
+	// if comment character not specified then comment line is a header
+	_, err = CsvFrom(NewCsvReader(strings.NewReader("# model: modelOne\nsub_id,dim0,param_value\n"), CsvOptions{}), "test.csv", hdr, toRow)
+	if err == nil {
+		t.Error("Fail: comment line must be invalid csv header")
+	}
+
+	// other comment character
+	from, err = CsvFrom(NewCsvReader(strings.NewReader(";model\nsub_id\tdim0\tparam_value\n1\tM\t2\n"), CsvOptions{IsTsv: true, Comment: ';'}), "test.tsv", hdr, toRow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, err := from(); err != nil || c != "1|M|2" {
+		t.Error("Fail tsv row after comments:", c, err)
+	}
+}
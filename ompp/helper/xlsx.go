@@ -0,0 +1,274 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package helper
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// limits of xlsx workbook size
+const (
+	xlsxMaxColumns  = 16384             // max number of sheet columns: A to XFD
+	xlsxMaxRows     = 1048576           // max number of sheet rows
+	xlsxMaxPartSize = 256 * 1024 * 1024 // max uncompressed size of workbook xml part in bytes
+)
+
+// XlsxSheet is a name and cell values of Excel workbook sheet.
+// Rows are in sheet order, empty cells are "" empty strings, trailing empty cells of the row are omitted.
+type XlsxSheet struct {
+	Name string     // sheet name
+	Rows [][]string // cell values of each row
+}
+
+// ReadXlsx read all sheets from Excel .xlsx workbook and return cell values of each sheet as strings.
+// Shared strings, inline strings and numbers are supported: numbers returned as it is stored in workbook.
+// Formulas are not evaluated, cached formula value is returned if it is stored in workbook.
+func ReadXlsx(r io.ReaderAt, size int64) ([]XlsxSheet, error) {
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.New("invalid xlsx workbook: " + err.Error())
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	// workbook: list of sheets and relationship id of each sheet
+	var wb struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RId  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err = xlsxDecode(files, "xl/workbook.xml", true, &wb); err != nil {
+		return nil, err
+	}
+
+	// relationships: map sheet relationship id to sheet xml part
+	var rels struct {
+		Rel []struct {
+			Id     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err = xlsxDecode(files, "xl/_rels/workbook.xml.rels", true, &rels); err != nil {
+		return nil, err
+	}
+	target := map[string]string{}
+	for _, rl := range rels.Rel {
+		t := rl.Target
+		if strings.HasPrefix(t, "/") {
+			t = strings.TrimPrefix(t, "/")
+		} else {
+			t = path.Join("xl", t)
+		}
+		target[rl.Id] = t
+	}
+
+	// shared strings are optional
+	var sst struct {
+		Si []xlsxText `xml:"si"`
+	}
+	if err = xlsxDecode(files, "xl/sharedStrings.xml", false, &sst); err != nil {
+		return nil, err
+	}
+	ss := make([]string, len(sst.Si))
+	for k := range sst.Si {
+		ss[k] = sst.Si[k].String()
+	}
+
+	// read each sheet
+	shLst := make([]XlsxSheet, 0, len(wb.Sheets))
+
+	for _, s := range wb.Sheets {
+
+		p, ok := target[s.RId]
+		if !ok {
+			return nil, errors.New("invalid xlsx workbook, sheet not found: " + s.Name)
+		}
+		var sd struct {
+			Rows []struct {
+				Ref   string `xml:"r,attr"`
+				Cells []struct {
+					Ref  string   `xml:"r,attr"`
+					Type string   `xml:"t,attr"`
+					V    string   `xml:"v"`
+					Is   xlsxText `xml:"is"`
+				} `xml:"c"`
+			} `xml:"sheetData>row"`
+		}
+		if err = xlsxDecode(files, p, true, &sd); err != nil {
+			return nil, errors.New("invalid xlsx sheet: " + s.Name + ": " + err.Error())
+		}
+
+		sh := XlsxSheet{Name: s.Name, Rows: make([][]string, 0, len(sd.Rows))}
+
+		if len(sd.Rows) > xlsxMaxRows {
+			return nil, errors.New("invalid xlsx sheet, too many rows: " + s.Name + ": " + strconv.Itoa(len(sd.Rows)))
+		}
+
+		for _, rw := range sd.Rows {
+
+			if xlsxRowNumber(rw.Ref) > xlsxMaxRows {
+				return nil, errors.New("invalid xlsx sheet row number: " + s.Name + ": " + rw.Ref)
+			}
+
+			row := []string{}
+			for _, c := range rw.Cells {
+
+				nCol := len(row)
+				if c.Ref != "" {
+					if n := xlsxColumnIndex(c.Ref); n >= 0 {
+						nCol = n
+					}
+				}
+				if nCol >= xlsxMaxColumns || xlsxRowNumber(c.Ref) > xlsxMaxRows {
+					return nil, errors.New("invalid xlsx cell reference, sheet size exceeded: " + s.Name + ": " + c.Ref)
+				}
+				for len(row) <= nCol {
+					row = append(row, "")
+				}
+
+				switch c.Type {
+				case "s":
+					n := 0
+					for _, d := range c.V {
+						if d < '0' || d > '9' {
+							return nil, errors.New("invalid xlsx shared string index: " + s.Name + ": " + c.Ref + ": " + c.V)
+						}
+						n = n*10 + int(d-'0')
+					}
+					if n >= len(ss) {
+						return nil, errors.New("invalid xlsx shared string index: " + s.Name + ": " + c.Ref + ": " + c.V)
+					}
+					row[nCol] = ss[n]
+				case "inlineStr":
+					row[nCol] = c.Is.String()
+				case "b":
+					if c.V == "1" {
+						row[nCol] = "true"
+					} else {
+						row[nCol] = "false"
+					}
+				default:
+					row[nCol] = c.V
+				}
+			}
+
+			// remove trailing empty cells
+			for len(row) > 0 && strings.TrimSpace(row[len(row)-1]) == "" {
+				row = row[:len(row)-1]
+			}
+			sh.Rows = append(sh.Rows, row)
+		}
+
+		// remove trailing empty rows
+		for len(sh.Rows) > 0 && len(sh.Rows[len(sh.Rows)-1]) == 0 {
+			sh.Rows = sh.Rows[:len(sh.Rows)-1]
+		}
+		shLst = append(shLst, sh)
+	}
+
+	return shLst, nil
+}
+
+// xlsxText is a rich or plain text of xlsx shared string or inline string
+type xlsxText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// return text value: plain text or concatenation of rich text runs
+func (t *xlsxText) String() string {
+	if len(t.R) <= 0 {
+		return t.T
+	}
+	s := t.T
+	for k := range t.R {
+		s += t.R[k].T
+	}
+	return s
+}
+
+// decode xml part of xlsx workbook, if isRequired is false and part not found then return without error
+func xlsxDecode(files map[string]*zip.File, name string, isRequired bool, dst interface{}) error {
+
+	f, ok := files[name]
+	if !ok {
+		if isRequired {
+			return errors.New("invalid xlsx workbook, part not found: " + name)
+		}
+		return nil
+	}
+	if f.UncompressedSize64 > xlsxMaxPartSize {
+		return errors.New("invalid xlsx workbook, part size exceeded: " + name + ": " + strconv.FormatUint(f.UncompressedSize64, 10))
+	}
+	rd, err := f.Open()
+	if err != nil {
+		return errors.New("invalid xlsx workbook part: " + name + ": " + err.Error())
+	}
+	defer rd.Close()
+
+	// limit size of uncompressed part, zip header size can be invalid
+	if err = xml.NewDecoder(io.LimitReader(rd, xlsxMaxPartSize)).Decode(dst); err != nil {
+		return errors.New("invalid xlsx workbook part: " + name + ": " + err.Error())
+	}
+	return nil
+}
+
+// return zero based column index from cell reference, e.g.: A1 => 0, AB12 => 27, or -1 if reference invalid.
+// If column is after XFD, max sheet column, then return xlsxMaxColumns.
+func xlsxColumnIndex(ref string) int {
+
+	n := 0
+	k := 0
+	for ; k < len(ref); k++ {
+		c := ref[k]
+		if c >= 'a' && c <= 'z' {
+			c = c - 'a' + 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		n = n*26 + int(c-'A'+1)
+		if n > xlsxMaxColumns {
+			return xlsxMaxColumns
+		}
+	}
+	if k == 0 {
+		return -1
+	}
+	return n - 1
+}
+
+// return row number from cell reference or row reference, e.g.: A1 => 1, AB12 => 12, 7 => 7, or zero if reference invalid.
+// If row number is greater than xlsxMaxRows, max sheet rows, then return xlsxMaxRows + 1.
+func xlsxRowNumber(ref string) int {
+
+	k := 0
+	for k < len(ref) && (ref[k] < '0' || ref[k] > '9') {
+		k++
+	}
+	n := 0
+	for ; k < len(ref); k++ {
+		c := ref[k]
+		if c < '0' || c > '9' {
+			return 0
+		}
+		n = n*10 + int(c-'0')
+		if n > xlsxMaxRows {
+			return xlsxMaxRows + 1
+		}
+	}
+	return n
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestReadXlsx(t *testing.T) {
+
+	// make minimal xlsx workbook with two sheets
+	parts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="ageSex" sheetId="1" r:id="rId1"/><sheet name="StartingSeed" sheetId="2" r:id="rId2"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>dim0</t></si><si><t>dim1</t></si><si><t>value</t></si><si><r><t>10-</t></r><r><t>20</t></r></si><si><t>M</t></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2" t="s"><v>4</v></c><c r="C2"><v>0.5</v></c></row>
+<row r="3"><c r="A3" t="inlineStr"><is><t>20-30</t></is></c><c r="C3"><v>1.25</v></c><c r="D3" t="s"><v>4</v></c><c r="E3"/></row>
+</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="str"><v>value</v></c></row>
+<row r="2"><c r="A2"><v>1234</v></c></row>
+<row r="3"/>
+</sheetData></worksheet>`,
+	}
+
+	// return zip archive of workbook parts
+	makeXlsx := func(parts map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, s := range parts {
+			f, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = f.Write([]byte(s)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	bt := makeXlsx(parts)
+	shLst, err := ReadXlsx(bytes.NewReader(bt), int64(len(bt)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shLst) != 2 || shLst[0].Name != "ageSex" || shLst[1].Name != "StartingSeed" {
+		t.Fatalf("invalid sheets: %v", shLst)
+	}
+
+	check := func(sh XlsxSheet, rows [][]string) {
+		if len(sh.Rows) != len(rows) {
+			t.Errorf("%s: invalid rows count: %d expected: %d", sh.Name, len(sh.Rows), len(rows))
+			return
+		}
+		for k := range rows {
+			if len(sh.Rows[k]) != len(rows[k]) {
+				t.Errorf("%s: invalid row %d: %v expected: %v", sh.Name, k, sh.Rows[k], rows[k])
+				continue
+			}
+			for j := range rows[k] {
+				if sh.Rows[k][j] != rows[k][j] {
+					t.Errorf("%s: invalid row %d: %v expected: %v", sh.Name, k, sh.Rows[k], rows[k])
+					break
+				}
+			}
+		}
+	}
+	check(shLst[0], [][]string{{"dim0", "dim1", "value"}, {"10-20", "M", "0.5"}, {"20-30", "", "1.25", "M"}})
+	check(shLst[1], [][]string{{"value"}, {"1234"}})
+
+	// column index from cell reference
+	for ref, n := range map[string]int{"A1": 0, "Z9": 25, "AA1": 26, "AB12": 27, "1": -1, "XFD1": 16383, "XFE1": 16384, "ZZZZZZZZ1": 16384} {
+		if k := xlsxColumnIndex(ref); k != n {
+			t.Errorf("invalid column index: %s: %d expected: %d", ref, k, n)
+		}
+	}
+
+	// row number from cell reference or row reference
+	for ref, n := range map[string]int{"A1": 1, "AB12": 12, "7": 7, "": 0, "A": 0, "A1B": 0, "A1048576": 1048576, "A1048577": 1048577, "99999999999999999999": 1048577} {
+		if k := xlsxRowNumber(ref); k != n {
+			t.Errorf("invalid row number: %s: %d expected: %d", ref, k, n)
+		}
+	}
+
+	// cell reference after last sheet column or after last sheet row
+	for _, sd := range []string{
+		`<row r="1"><c r="XFE1"><v>1</v></c></row>`,
+		`<row r="1048577"><c><v>1</v></c></row>`,
+		`<row><c r="A1048577"><v>1</v></c></row>`,
+	} {
+		parts["xl/worksheets/sheet2.xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + sd + `</sheetData></worksheet>`
+		bt = makeXlsx(parts)
+		if _, err = ReadXlsx(bytes.NewReader(bt), int64(len(bt))); err == nil {
+			t.Error("expected error for sheet size exceeded:", sd)
+		}
+	}
+
+	// not a zip file
+	if _, err = ReadXlsx(bytes.NewReader([]byte("abc")), 3); err == nil {
+		t.Error("expected error for invalid workbook")
+	}
+}
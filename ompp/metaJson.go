@@ -244,7 +244,7 @@ type EntityGroupDescrNote struct {
 func (me *ModelMetaEncoder) New(meta *db.ModelMeta, txtMeta *db.ModelTxtMeta, lc string, lcd string) error {
 
 	if meta == nil || txtMeta == nil {
-		errors.New("Error: invalid (empty) model metadata")
+		return errors.New("Error: invalid (empty) model metadata")
 	}
 	me.preferedLangCode = lc
 	me.defaultLangCode = lcd
@@ -13,6 +13,12 @@ Log can be enabled/disabled for two independent streams:
 "Stamped" file name produced by adding time-stamp and/or pid-stamp, i.e.:
 
 	exeName.log => exeName.2012_08_17_16_04_59_148.123.log
+
+Log level can be used to reduce or increase console output:
+
+	QuietLevel   => console output only errors and warnings, log file is not affected
+	NormalLevel  => default: console output of all messages except of debug
+	VerboseLevel => console and log file output of all messages, including debug
 */
 package omppLog
 
@@ -37,8 +43,32 @@ var (
 	lastMonth     time.Month                           // if daily log then month current daily stamp
 	lastDay       int                                  // if daily log then day current daily stamp
 	logOpts       = config.LogOptions{IsConsole: true} // log options, default is log to console
+	logLevel      = NormalLevel                        // log level, default is normal
+)
+
+// Level is log verbosity level
+type Level int
+
+const (
+	QuietLevel   Level = iota // console output only errors and warnings
+	NormalLevel               // console output of all messages except of debug, default
+	VerboseLevel              // output all messages, including debug
 )
 
+// SetLevel set log verbosity level
+func SetLevel(level Level) {
+	theLock.Lock()
+	defer theLock.Unlock()
+	logLevel = level
+}
+
+// GetLevel return current log verbosity level
+func GetLevel() Level {
+	theLock.Lock()
+	defer theLock.Unlock()
+	return logLevel
+}
+
 // LogIfTime do Log(msg) not more often then every nSeconds.
 // It does nothing if time now < lastT + nSeconds. Time is a Unix time, seconds since epoch.
 func LogIfTime(lastT int64, nSeconds int64, msg ...interface{}) int64 {
@@ -63,16 +93,43 @@ func New(opts *config.LogOptions) {
 	isFileCreated = false
 }
 
-// Log message to console and log file
+// Log message to console and log file.
+// If log level is QuietLevel then message is not written to console.
 func Log(msg ...interface{}) {
+	doLog(NormalLevel, "", msg...)
+}
+
+// Error log error message to console and log file at any log level
+func Error(msg ...interface{}) {
+	doLog(QuietLevel, "", msg...)
+}
+
+// Warning log message with "Warning: " prefix to console and log file at any log level
+func Warning(msg ...interface{}) {
+	doLog(QuietLevel, "Warning: ", msg...)
+}
+
+// Debug log message to console and log file only if log level is VerboseLevel
+func Debug(msg ...interface{}) {
+	theLock.Lock()
+	isVerbose := logLevel >= VerboseLevel
+	theLock.Unlock()
+
+	if isVerbose {
+		doLog(VerboseLevel, "", msg...)
+	}
+}
+
+// log message to console if log level is at least minimum console level and to log file
+func doLog(minConsoleLevel Level, prefix string, msg ...interface{}) {
 	theLock.Lock()
 	defer theLock.Unlock()
 
 	// make message string and log to console
 	var m string
 	now := time.Now()
-	m = helper.MakeDateTime(now) + " " + fmt.Sprint(msg...)
-	if logOpts.IsConsole {
+	m = helper.MakeDateTime(now) + " " + prefix + fmt.Sprint(msg...)
+	if logOpts.IsConsole && logLevel >= minConsoleLevel {
 		fmt.Println(m)
 	}
 
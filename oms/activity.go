@@ -0,0 +1,163 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// max number of activity events to keep in memory
+const activityLogSize = 1000
+
+// kinds of activity events
+const (
+	runStartActivity      = "run-start"      // model run started
+	runDoneActivity       = "run-done"       // model run completed
+	worksetUpdateActivity = "workset-update" // workset created or modified
+	worksetDeleteActivity = "workset-delete" // workset deleted
+	uploadActivity        = "upload"         // run or workset uploaded
+	downloadActivity      = "download"       // model, run or workset download initiated
+)
+
+// ActivityEvent is a server activity event: model run started or completed, workset modified, upload or download
+type ActivityEvent struct {
+	Seq         int64  // event sequence number, zero if event is restored from jobs history
+	Kind        string // kind of event: run-start, run-done, workset-update, workset-delete, upload, download
+	DateTime    string // event date-time
+	ModelName   string // if not empty then model name
+	ModelDigest string // if not empty then model digest or model digest-or-name from request
+	Name        string // run stamp, workset name or file name
+	Status      string // if not empty then model run status: s=success, e=error, x=exit
+	Msg         string // if not empty then event details, e.g.: parameter name
+}
+
+// ActivityPage is a page of activity events, most recent events first
+type ActivityPage struct {
+	Start     int             // zero-based page start position
+	Count     int             // page size, if <= 0 then all events
+	TotalSize int             // total number of events
+	Events    []ActivityEvent // page of events
+}
+
+// in-memory activity log: most recent events of this oms instance
+var theActivity = struct {
+	sync.Mutex
+	events  []ActivityEvent // ring buffer of events
+	start   int             // index of oldest event in the buffer
+	nextSeq int64           // sequence number of next event
+}{nextSeq: 1}
+
+// append event to activity log, if log is full then replace oldest event
+func logActivity(ev ActivityEvent) {
+
+	theActivity.Lock()
+	defer theActivity.Unlock()
+
+	ev.Seq = theActivity.nextSeq
+	theActivity.nextSeq++
+	if ev.DateTime == "" {
+		ev.DateTime = helper.MakeDateTime(time.Now())
+	}
+
+	if len(theActivity.events) < activityLogSize {
+		theActivity.events = append(theActivity.events, ev)
+	} else {
+		theActivity.events[theActivity.start] = ev
+		theActivity.start = (theActivity.start + 1) % len(theActivity.events)
+	}
+}
+
+// log model run started or model run completed event
+func logRunActivity(kind string, rs *RunState, status string) {
+	logActivity(ActivityEvent{
+		Kind:        kind,
+		ModelName:   rs.ModelName,
+		ModelDigest: rs.ModelDigest,
+		Name:        rs.RunStamp,
+		Status:      status,
+		Msg:         rs.SubmitStamp,
+	})
+}
+
+// log workset created, modified or deleted event
+func logWorksetActivity(kind string, dn, wsn string, msg string) {
+	logActivity(ActivityEvent{Kind: kind, ModelDigest: dn, Name: wsn, Msg: msg})
+}
+
+// getActivityPage return page of activity events, most recent events first.
+// Events assembled from in-memory activity log and from model run jobs history:
+// completed model runs which are not in activity log restored from job history files,
+// for example, runs completed before oms restart or by other oms instance.
+func getActivityPage(start, count int) ActivityPage {
+
+	// copy in-memory activity log and collect submit stamps of completed runs
+	theActivity.Lock()
+	evLst := make([]ActivityEvent, 0, len(theActivity.events))
+	for k := range theActivity.events {
+		evLst = append(evLst, theActivity.events[(theActivity.start+k)%len(theActivity.events)])
+	}
+	theActivity.Unlock()
+
+	done := map[string]bool{}
+	for k := range evLst {
+		if evLst[k].Kind == runDoneActivity {
+			done[evLst[k].Msg] = true
+		}
+	}
+
+	// append completed runs from jobs history, use job file modification time as run completion time
+	theRunCatalog.rscLock.Lock()
+	hLst := make([]historyJobFile, 0, len(theRunCatalog.historyJobs))
+	for _, hj := range theRunCatalog.historyJobs {
+		if !hj.isError && !done[hj.SubmitStamp] {
+			hLst = append(hLst, hj)
+		}
+	}
+	theRunCatalog.rscLock.Unlock()
+
+	for _, hj := range hLst {
+
+		fi, err := os.Stat(hj.filePath)
+		if err != nil {
+			continue // job file deleted
+		}
+		evLst = append(evLst, ActivityEvent{
+			Kind:        runDoneActivity,
+			DateTime:    helper.MakeDateTime(fi.ModTime()),
+			ModelName:   hj.ModelName,
+			ModelDigest: hj.ModelDigest,
+			Name:        hj.RunStamp,
+			Status:      hj.JobStatus,
+			Msg:         hj.SubmitStamp,
+		})
+	}
+
+	// sort events: most recent first
+	slices.SortStableFunc(evLst, func(a, b ActivityEvent) int {
+		if a.DateTime != b.DateTime {
+			if a.DateTime > b.DateTime {
+				return -1
+			}
+			return 1
+		}
+		return int(b.Seq - a.Seq)
+	})
+
+	// return page of events
+	ap := ActivityPage{Start: start, Count: count, TotalSize: len(evLst), Events: []ActivityEvent{}}
+
+	if start < len(evLst) {
+		n := len(evLst)
+		if count > 0 && start+count < n {
+			n = start + count
+		}
+		ap.Events = evLst[start:n]
+	}
+	return ap
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// web-service API versions:
+//
+//	/api/v1/... is current API version
+//	/api/v2/... is next API version, it is the same as v1 until handlers with breaking changes added
+//	/api/...    unversioned routes are the same as current version and deprecated
+//
+// Versioned route is served by the same handler as unversioned route: /api/v2/model-list => /api/model-list.
+// If handler behavior depends on API version then handler must use apiVersionOf(r) to get request API version.
+const (
+	apiCurrentVersion = "v1" // current API version, it is used for unversioned /api/ routes
+	apiLatestVersion  = "v2" // latest API version
+)
+
+// ApiVersion is a description of web-service API version
+type ApiVersion struct {
+	Version      string // API version, e.g.: v1
+	Prefix       string // API routes prefix, e.g.: /api/v1/
+	IsCurrent    bool   // if true then it is current API version
+	IsDeprecated bool   // if true then API version is deprecated and client should migrate to successor version
+	Successor    string // successor version, if API version is deprecated
+}
+
+// list of supported API versions, unversioned /api/ routes are the last
+var theApiVersions = []ApiVersion{
+	{Version: "v1", Prefix: "/api/v1/", IsCurrent: true},
+	{Version: "v2", Prefix: "/api/v2/"},
+	{Version: "", Prefix: "/api/", IsDeprecated: true, Successor: apiCurrentVersion},
+}
+
+// request context key type of API version
+type apiVersionKey struct{}
+
+// apiVersionRoutes map versioned /api/v1/ and /api/v2/ routes to /api/ routes and set API version of the request.
+// Unversioned /api/ routes are served as current API version with deprecation headers:
+//
+//	Deprecation: true
+//	Link: </api/v1/model-list>; rel="successor-version"
+//
+// All /api/ responses contain Api-Version header, e.g.: Api-Version: v1
+func apiVersionRoutes(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r) // it is not an API route
+			return
+		}
+
+		// find API version by route prefix: /api/v1/ or /api/v2/ or unversioned /api/
+		var av *ApiVersion
+		for k := range theApiVersions {
+			if strings.HasPrefix(r.URL.Path, theApiVersions[k].Prefix) {
+				av = &theApiVersions[k]
+				break
+			}
+		}
+		ver := av.Version
+
+		if ver == "" { // unversioned route: use current version and add deprecation headers
+
+			ver = apiCurrentVersion
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", "</api/"+av.Successor+"/"+strings.TrimPrefix(r.URL.EscapedPath(), "/api/")+">; rel=\"successor-version\"")
+		}
+		w.Header().Set("Api-Version", ver)
+
+		// map versioned route to unversioned: /api/v1/model-list => /api/model-list
+		r2 := r.WithContext(context.WithValue(r.Context(), apiVersionKey{}, ver))
+
+		if av.Version != "" {
+			u := *r.URL
+			u.Path = "/api/" + strings.TrimPrefix(r.URL.Path, av.Prefix)
+			if u.RawPath != "" {
+				u.RawPath = "/api/" + strings.TrimPrefix(r.URL.RawPath, av.Prefix)
+			}
+			r2.URL = &u
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// return API version of the request: v1 or v2, if request is not an API request then return current API version
+func apiVersionOf(r *http.Request) string {
+	if v, ok := r.Context().Value(apiVersionKey{}).(string); ok && v != "" {
+		return v
+	}
+	return apiCurrentVersion
+}
+
+// apiVersionListHandler return list of supported API versions:
+// GET /api/version-list
+// GET /api/v1/version-list
+// GET /api/v2/version-list
+func apiVersionListHandler(w http.ResponseWriter, r *http.Request) {
+
+	vl := struct {
+		Current string       // current API version, it is used for unversioned /api/ routes
+		Latest  string       // latest API version
+		Request string       // API version of this request
+		Version []ApiVersion // list of API versions
+	}{
+		Current: apiCurrentVersion,
+		Latest:  apiLatestVersion,
+		Request: apiVersionOf(r),
+		Version: theApiVersions,
+	}
+	jsonResponse(w, r, vl)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/husobee/vestigo"
 	"golang.org/x/text/language"
 
+	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
@@ -34,10 +35,10 @@ func logRequest(next http.HandlerFunc) http.HandlerFunc {
 	return next
 }
 
-// match request language with UI supported languages and return canonic language name
+// match request language with UI supported languages and return canonic language name.
+// Session language cookie, if present, is preferred over browser languages.
 func matchRequestToUiLang(r *http.Request) string {
-	rqLangTags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
-	tag, _, _ := uiLangMatcher.Match(rqLangTags...)
+	tag, _, _ := uiLangMatcher.Match(getRequestLang(r, "")...)
 	return tag.String()
 }
 
@@ -83,6 +84,20 @@ func getIntRequestParam(r *http.Request, name string, defaultVal int) (int, bool
 	return defaultVal, false // value is not integer
 }
 
+// get sub-value id filter from url parameter ?sub-id or router parameter /:sub-id
+// return false if sub-value id is not a non-negative integer
+func getSubIdRequestParam(r *http.Request) (db.ReadSubIdLayout, bool) {
+
+	if getRequestParam(r, "sub-id") == "" {
+		return db.ReadSubIdLayout{}, true // no sub-value id filter
+	}
+	nSub, ok := getIntRequestParam(r, "sub-id", 0)
+	if !ok || nSub < 0 {
+		return db.ReadSubIdLayout{}, false
+	}
+	return db.ReadSubIdLayout{IsSubId: true, SubId: nSub}, true
+}
+
 // get int64 value of url parameter ?name or router parameter /:name
 func getInt64RequestParam(r *http.Request, name string, defaultVal int64) (int64, bool) {
 
@@ -100,16 +115,26 @@ func getInt64RequestParam(r *http.Request, name string, defaultVal int64) (int64
 }
 
 // get languages accepted by browser and by optional language request parameter, for example: ..../lang:EN
-// if language parameter specified then return it as a first element of result (it a preferred language)
+// if language parameter specified then return it as a first element of result (it a preferred language).
+// Languages order of preference is: language parameter, session language cookie,
+// browser languages sorted by quality values from Accept-Language header.
 func getRequestLang(r *http.Request, name string) []language.Tag {
 
-	// browser languages
-	rqLangTags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	// browser languages, sorted by q-values
+	rqLangTags := parseAcceptLanguage(r.Header.Get("Accept-Language"))
+
+	// session language override, if cookie exist
+	if ln := getLangCookie(r); ln != "" {
+		rqLangTags = append([]language.Tag{language.Make(ln)}, rqLangTags...)
+	}
 
 	// if optional url parameter ?lang or router parameter /:lang specified
-	ln := r.URL.Query().Get(name)
-	if ln == "" {
-		ln = vestigo.Param(r, name)
+	ln := ""
+	if name != "" {
+		ln = r.URL.Query().Get(name)
+		if ln == "" {
+			ln = vestigo.Param(r, name)
+		}
 	}
 
 	// add lang parameter as top language
@@ -152,6 +177,23 @@ func csvSetHeaders(w http.ResponseWriter, name string) {
 
 }
 
+// return http status code by kind of db error: 404 if model, run, workset or task not found, 503 if database is locked.
+// If it is not one of db errors then return default status code.
+func dbErrorStatus(err error, defaultStatus int) int {
+	switch {
+	case err == nil:
+		return defaultStatus
+	case errors.Is(err, db.ErrModelNotFound) || errors.Is(err, db.ErrRunNotFound) ||
+		errors.Is(err, db.ErrWorksetNotFound) || errors.Is(err, db.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrLocked):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, db.ErrNotOmppSchema) || errors.Is(err, db.ErrSchemaVersion):
+		return http.StatusInternalServerError
+	}
+	return defaultStatus
+}
+
 // dirExist return error if directory does not exist or not accessible
 func dirExist(dirPath string) bool {
 	if dirPath == "" {
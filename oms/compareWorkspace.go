@@ -0,0 +1,292 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// ComparePub is a named comparison of model runs: base run and variant run of the same model
+type ComparePub struct {
+	Name             string // comparison name
+	ModelName        string // model name
+	ModelDigest      string // model digest
+	BaseRunDigest    string // base run digest
+	BaseRunName      string // base run name
+	VariantRunDigest string // variant run digest
+	VariantRunName   string // variant run name
+	CreateDateTime   string // comparison created date-time
+}
+
+// compare request: model and runs can be specified by name
+type compareRequest struct {
+	Name       string // comparison name
+	Model      string // model digest or name
+	BaseRun    string // base run digest, stamp or name
+	VariantRun string // variant run digest, stamp or name
+}
+
+// CompareRowPub is a row of aligned output table comparison: expression value of base and variant runs for the same dimension items
+type CompareRowPub struct {
+	Dims    []string // dimension items as enum codes
+	Expr    string   // output table expression name
+	Base    *float64 // base run expression value, null if value is NULL
+	Variant *float64 // variant run expression value, null if value is NULL
+	Diff    *float64 // difference: variant - base, null if any of values is NULL
+	PctDiff *float64 // percent difference: 100 * (variant - base) / base, null if any of values is NULL or base value is zero
+}
+
+// max number of comparisons in workspace
+const maxCompareCount = 100
+
+// comparison workspace: comparisons by name, it is kept in memory until oms restarted
+var theCompare = struct {
+	sync.Mutex
+	items map[string]ComparePub
+}{items: map[string]ComparePub{}}
+
+// CreateCompare create or replace named comparison of model runs.
+// Both runs must be completed successfully and must be different runs of the same model.
+// Return comparison and http status code: 200 on success, 404 if model or runs not found, 400 or 409 on error.
+func (mc *ModelCatalog) CreateCompare(cq *compareRequest) (*ComparePub, int, error) {
+
+	if cq.Name == "" || cq.Model == "" || cq.BaseRun == "" || cq.VariantRun == "" {
+		return nil, 400, errors.New("Invalid (empty) comparison name, model, base run or variant run")
+	}
+
+	meta, err := mc.ModelMetaByDigestOrName(cq.Model)
+	if err != nil || meta == nil {
+		return nil, 404, errors.New("Error: model not found " + cq.Model)
+	}
+	bRow, ok := mc.CompletedRunByDigestOrStampOrName(meta.Model.Digest, cq.BaseRun)
+	if !ok || bRow == nil || bRow.Status != db.DoneRunStatus {
+		return nil, 404, errors.New("Error: base run not found or not completed successfully " + cq.BaseRun)
+	}
+	vRow, ok := mc.CompletedRunByDigestOrStampOrName(meta.Model.Digest, cq.VariantRun)
+	if !ok || vRow == nil || vRow.Status != db.DoneRunStatus {
+		return nil, 404, errors.New("Error: variant run not found or not completed successfully " + cq.VariantRun)
+	}
+	if bRow.RunId == vRow.RunId {
+		return nil, 400, errors.New("Error: variant run must be different from base run " + cq.VariantRun)
+	}
+
+	cmp := ComparePub{
+		Name:             cq.Name,
+		ModelName:        meta.Model.Name,
+		ModelDigest:      meta.Model.Digest,
+		BaseRunDigest:    bRow.RunDigest,
+		BaseRunName:      bRow.Name,
+		VariantRunDigest: vRow.RunDigest,
+		VariantRunName:   vRow.Name,
+		CreateDateTime:   helper.MakeDateTime(time.Now()),
+	}
+
+	theCompare.Lock()
+	defer theCompare.Unlock()
+
+	if _, ok = theCompare.items[cmp.Name]; !ok && len(theCompare.items) >= maxCompareCount {
+		return nil, 409, errors.New("Error: too many comparisons, delete unused comparisons")
+	}
+	theCompare.items[cmp.Name] = cmp
+
+	return &cmp, 200, nil
+}
+
+// compareByName return comparison by name
+func compareByName(name string) (*ComparePub, bool) {
+
+	theCompare.Lock()
+	defer theCompare.Unlock()
+
+	cmp, ok := theCompare.items[name]
+	if !ok {
+		return nil, false
+	}
+	return &cmp, true
+}
+
+// compareList return list of all comparisons, sorted by create date-time
+func compareList() []ComparePub {
+
+	theCompare.Lock()
+	cLst := make([]ComparePub, 0, len(theCompare.items))
+	for _, cmp := range theCompare.items {
+		cLst = append(cLst, cmp)
+	}
+	theCompare.Unlock()
+
+	sort.SliceStable(cLst, func(i, j int) bool {
+		if cLst[i].CreateDateTime != cLst[j].CreateDateTime {
+			return cLst[i].CreateDateTime < cLst[j].CreateDateTime
+		}
+		return cLst[i].Name < cLst[j].Name
+	})
+	return cLst
+}
+
+// deleteCompare delete comparison by name, it is not an error if comparison not exists
+func deleteCompare(name string) {
+	theCompare.Lock()
+	defer theCompare.Unlock()
+	delete(theCompare.items, name)
+}
+
+// ReadCompareTableTo read "page" of aligned output table comparison rows and pass each row into cvtWr().
+//
+// For each output table expression and each combination of dimension items it is
+// base run value, variant run value, difference and percent difference of values.
+// Values calculated by the same calculation as comparison of the runs: diff and percent, see TableExprCompareLayout().
+// Rows are ordered by dimension items and by expression id.
+// Page is defined by zero-based "start" row number and row count, if row count <= 0 then all rows returned.
+func (mc *ModelCatalog) ReadCompareTableTo(cmp *ComparePub, name string, start, count int64, cvtWr func(src interface{}) (bool, error)) bool {
+
+	// calculate output table expressions, difference and percent difference:
+	// Expr0, diff_Expr0, percent_Expr0 with calculation id: Expr0 id + 2 * calculated id offset
+	diffLt, ok := mc.TableExprCompareLayout(cmp.ModelDigest, name, "diff")
+	if !ok {
+		return false
+	}
+	pctLt, ok := mc.TableExprCompareLayout(cmp.ModelDigest, name, "percent")
+	if !ok {
+		return false
+	}
+	calcLt := diffLt
+	for _, c := range pctLt {
+		if c.CalcId >= db.CALCULATED_ID_OFFSET {
+			c.CalcId += db.CALCULATED_ID_OFFSET
+			calcLt = append(calcLt, c)
+		}
+	}
+
+	// get converter from id's cell into code cell, it is used to convert dimension items, base and variant runs must be completed
+	cvtCell, baseRunId, runIds, ok := mc.TableToCodeCalcCellConverter(cmp.ModelDigest, cmp.BaseRunDigest, name, calcLt, []string{cmp.VariantRunDigest})
+	if !ok || len(runIds) != 1 {
+		return false
+	}
+
+	meta, _, ok := mc.modelMeta(cmp.ModelDigest)
+	if !ok {
+		return false
+	}
+	idx, ok := meta.OutTableByName(name)
+	if !ok {
+		return false
+	}
+	table := &meta.Table[idx]
+
+	// expression index by expression id
+	exprIdx := map[int]int{}
+	for k := range table.Expr {
+		exprIdx[table.Expr[k].ExprId] = k
+	}
+
+	// order by dimensions, calculation id and run id: all values of the same dimension items are consecutive rows
+	tableLt := db.ReadTableLayout{
+		ReadLayout: db.ReadLayout{Name: name},
+	}
+	for k := 0; k < table.Rank; k++ {
+		tableLt.OrderBy = append(tableLt.OrderBy, db.OrderByColumn{IndexOne: k + 3})
+	}
+	tableLt.OrderBy = append(tableLt.OrderBy, db.OrderByColumn{IndexOne: 2}, db.OrderByColumn{IndexOne: 1})
+
+	// aligned rows of current dimension items, one row for each expression
+	rows := make([]CompareRowPub, len(table.Expr))
+	curDims := []int{}
+	isCur := false
+	var nRow, nPage int64
+
+	// write aligned rows of current dimension items, return false if page is completed
+	flush := func() (bool, error) {
+
+		for k := range rows {
+
+			nRow++
+			if nRow <= start {
+				continue
+			}
+			if count > 0 && nPage >= count {
+				return false, nil
+			}
+			if isNext, err := cvtWr(&rows[k]); err != nil || !isNext {
+				return false, err
+			}
+			nPage++
+		}
+		return count <= 0 || nPage < count, nil
+	}
+
+	cvtRd := func(src interface{}) (bool, error) {
+
+		c, ok := src.(db.CellTableCalc)
+		if !ok {
+			return false, errors.New("invalid type, expected: output table calculated cell (internal error): " + name)
+		}
+
+		// if dimension items changed then write aligned rows and start new dimension items
+		if !isCur || !slices.Equal(curDims, c.DimIds) {
+
+			if isCur {
+				if isNext, err := flush(); err != nil || !isNext {
+					return false, err
+				}
+			}
+			cc, err := cvtCell(c)
+			if err != nil {
+				return false, err
+			}
+			dims := cc.(db.CellCodeTableCalc).Dims
+
+			for k := range rows {
+				rows[k] = CompareRowPub{Dims: dims, Expr: table.Expr[k].Name}
+			}
+			curDims = slices.Clone(c.DimIds)
+			isCur = true
+		}
+
+		// find expression and value kind by calculation id
+		eId := c.CalcId % db.CALCULATED_ID_OFFSET
+		nKind := c.CalcId / db.CALCULATED_ID_OFFSET
+
+		k, ok := exprIdx[eId]
+		if !ok {
+			return true, nil // skip unknown calculation
+		}
+		var v *float64
+		if fv, ok := c.Value.(float64); ok && !c.IsNull {
+			v = &fv
+		}
+
+		switch {
+		case nKind == 0 && c.RunId == baseRunId:
+			rows[k].Base = v
+		case nKind == 0 && c.RunId == runIds[0]:
+			rows[k].Variant = v
+		case nKind == 1 && c.RunId == runIds[0]:
+			rows[k].Diff = v
+		case nKind == 2 && c.RunId == runIds[0]:
+			rows[k].PctDiff = v
+		}
+		return true, nil
+	}
+
+	_, ok = mc.ReadOutTableCalculateTo(cmp.ModelDigest, cmp.BaseRunDigest, &tableLt, calcLt, runIds, cvtRd)
+	if !ok {
+		return false
+	}
+
+	// write aligned rows of last dimension items
+	if isCur && (count <= 0 || nPage < count) {
+		if _, err := flush(); err != nil {
+			return false
+		}
+	}
+	return true
+}
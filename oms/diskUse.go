@@ -102,7 +102,7 @@ func scanDisk(doneC <-chan bool, refreshC <-chan bool) {
 		nOtherSize = 0
 		if theCfg.isJobControl {
 
-			diskUseFiles := filesByPattern(diskUsePtrn, "Error at disk use files search")
+			diskUseFiles := jobFilesByPattern(diskUsePtrn, "Error at disk use files search")
 
 			for _, fp := range diskUseFiles {
 
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// isModelNotModified set ETag and Cache-Control response headers for model metadata response
+// and return true if client already has the same response: If-None-Match request header match to ETag.
+// If it is true then 304 Not Modified status written and response must be completed by caller.
+//
+// ETag is made from model digest, time when model metadata or text loaded into catalog and request url and languages:
+// W/"model-digest-load-time-crc32" where crc32 is a checksum of request url, Accept-Language header and language cookie.
+// If model not found in catalog then headers are not set and it return false.
+func isModelNotModified(w http.ResponseWriter, r *http.Request, dn string) bool {
+
+	if dn == "" {
+		return false
+	}
+	mdRow, ok := theCatalog.ModelDicByDigestOrName(dn)
+	if !ok {
+		return false
+	}
+	ts, ok := theCatalog.modelLoadTime(mdRow.Digest)
+	if !ok {
+		return false
+	}
+
+	// response depends on request url, browser languages and language cookie
+	h := crc32.NewIEEE()
+	h.Write([]byte(r.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write([]byte(r.Header.Get("Accept-Language")))
+	h.Write([]byte{0})
+	h.Write([]byte(getLangCookie(r)))
+
+	etag := `W/"` + mdRow.Digest + "-" + strconv.FormatInt(ts, 36) + "-" + strconv.FormatUint(uint64(h.Sum32()), 16) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Accept-Language, Cookie")
+	if theCfg.cacheControl != "" {
+		w.Header().Set("Cache-Control", theCfg.cacheControl)
+	}
+
+	if !isETagMatch(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// return true if If-None-Match header value match to ETag: it is * or one of comma separated list of ETags.
+// Weak comparison is used: W/ prefix ignored.
+func isETagMatch(ifNoneMatch string, etag string) bool {
+
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, s := range strings.Split(ifNoneMatch, ",") {
+		s = strings.TrimSpace(s)
+		if s == "*" || strings.TrimPrefix(s, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// return time when model metadata or text loaded into catalog as unix nanoseconds, return false if model digest not found
+func (mc *ModelCatalog) modelLoadTime(digest string) (int64, bool) {
+
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	idx, ok := mc.indexByDigest(digest)
+	if !ok {
+		return 0, false // model not found, empty result
+	}
+	return mc.modelLst[idx].loadTime, true
+}
@@ -244,28 +244,30 @@ func (mc *ModelCatalog) RunRowListByModel(dn string) ([]db.RunRow, bool) {
 	return rl, true
 }
 
-// RunPubList return list of run_lst db rows in "public" format by model digest-or-name.
+// RunPubList return page of run_lst db rows in "public" format by model digest-or-name,
+// actual page layout and total count of model runs.
 // No text info returned (no description and notes).
-func (mc *ModelCatalog) RunPubList(dn string) ([]db.RunPub, bool) {
+// Runs are sorted and paged by layout, if layout page size <= 0 then all model runs returned.
+func (mc *ModelCatalog) RunPubList(dn string, layout db.RunListLayout) ([]db.RunPub, *db.ReadPageLayout, int, bool) {
 
 	// if model digest-or-name is empty then return empty results
 	if dn == "" {
 		omppLog.Log("Warning: invalid (empty) model digest and name")
-		return []db.RunPub{}, false
+		return []db.RunPub{}, nil, 0, false
 	}
 	meta, dbConn, ok := mc.modelMeta(dn)
 	if !ok {
 		omppLog.Log("Warning: model digest or name not found: ", dn)
-		return []db.RunPub{}, false
+		return []db.RunPub{}, nil, 0, false
 	}
 
-	rl, err := db.GetRunList(dbConn, meta.Model.ModelId)
+	rl, lt, nTotal, err := db.GetRunListPage(dbConn, meta.Model.ModelId, layout)
 	if err != nil {
 		omppLog.Log("Error at get run list: ", dn, ": ", err.Error())
-		return []db.RunPub{}, false // return empty result: run select error
+		return []db.RunPub{}, nil, 0, false // return empty result: run select error
 	}
 	if len(rl) <= 0 {
-		return []db.RunPub{}, true // return empty result: run_lst rows not found for that model
+		return []db.RunPub{}, lt, nTotal, true // return empty result: run_lst rows not found for that model
 	}
 
 	// for each run_lst convert it to "public" run format
@@ -276,82 +278,82 @@ func (mc *ModelCatalog) RunPubList(dn string) ([]db.RunPub, bool) {
 		p, err := (&db.RunMeta{Run: rl[ni]}).ToPublic(meta)
 		if err != nil {
 			omppLog.Log("Error at run conversion: ", dn, ": ", err.Error())
-			return []db.RunPub{}, false // return empty result: conversion error
+			return []db.RunPub{}, nil, 0, false // return empty result: conversion error
 		}
 		if p != nil {
 			rpl[ni] = *p
 		}
 	}
 
-	return rpl, true
+	return rpl, lt, nTotal, true
 }
 
-// RunListText return list of run_lst and run_txt db rows by model digest-or-name.
+// RunListText return page of run_lst and run_txt db rows by model digest-or-name,
+// actual page layout and total count of model runs.
 // Text (description and notes) are in preferred language if text in such language exists.
-func (mc *ModelCatalog) RunListText(dn string, preferredLang []language.Tag) ([]db.RunPub, bool) {
+// Runs are sorted and paged by layout, if layout page size <= 0 then all model runs returned.
+func (mc *ModelCatalog) RunListText(dn string, preferredLang []language.Tag, layout db.RunListLayout) ([]db.RunPub, *db.ReadPageLayout, int, bool) {
 
 	// if model digest-or-name is empty then return empty results
 	if dn == "" {
 		omppLog.Log("Warning: invalid (empty) model digest and name")
-		return []db.RunPub{}, false
+		return []db.RunPub{}, nil, 0, false
 	}
 	meta, dbConn, ok := mc.modelMeta(dn)
 	if !ok {
 		omppLog.Log("Warning: model digest or name not found: ", dn)
-		return []db.RunPub{}, false
+		return []db.RunPub{}, nil, 0, false
 	}
 
 	// match request preferred language
 	lc := mc.languageTagMatch(dn, preferredLang)
 	if lc == "" {
 		omppLog.Log("Warning: invalid (empty) model default language or model not found: ", dn)
-		return []db.RunPub{}, false // return empty result: model default language cannot be empty
+		return []db.RunPub{}, nil, 0, false // return empty result: model default language cannot be empty
 	}
 
 	// get run_txt db row for each run_lst using matched preferred language
-	rl, rt, err := db.GetRunListText(dbConn, meta.Model.ModelId, lc)
+	rl, rt, lt, nTotal, err := db.GetRunListTextPage(dbConn, meta.Model.ModelId, lc, layout)
 	if err != nil {
 		omppLog.Log("Error at get run list: ", dn, ": ", err.Error())
-		return []db.RunPub{}, false // return empty result: run select error
+		return []db.RunPub{}, nil, 0, false // return empty result: run select error
 	}
 	if len(rl) <= 0 {
-		return []db.RunPub{}, true // return empty result: run_lst rows not found for that model
+		return []db.RunPub{}, lt, nTotal, true // return empty result: run_lst rows not found for that model
+	}
+
+	// index of text row by run id: run list can be sorted not by run id
+	txtIdx := make(map[int]int, len(rt))
+	for k := range rt {
+		if _, ok := txtIdx[rt[k].RunId]; !ok {
+			txtIdx[rt[k].RunId] = k
+		}
 	}
 
 	// for each run_lst find run_txt row if exist and convert to "public" run format
 	rpl := make([]db.RunPub, len(rl))
 
-	nt := 0
 	for ni := range rl {
 
-		// find text row for current master row by run id
-		isFound := false
-		for ; nt < len(rt); nt++ {
-			isFound = rt[nt].RunId == rl[ni].RunId
-			if rt[nt].RunId >= rl[ni].RunId {
-				break // text found or text missing: text run id ahead of master run id
-			}
-		}
-
 		// convert to "public" format
 		var p *db.RunPub
 		var err error
 
-		if isFound && nt < len(rt) {
+		if nt, isFound := txtIdx[rl[ni].RunId]; isFound {
 			p, err = (&db.RunMeta{Run: rl[ni], Txt: []db.RunTxtRow{rt[nt]}}).ToPublic(meta)
 		} else {
 			p, err = (&db.RunMeta{Run: rl[ni]}).ToPublic(meta)
 		}
 		if err != nil {
 			omppLog.Log("Error at run conversion: ", dn, ": ", err.Error())
-			return []db.RunPub{}, false // return empty result: conversion error
+			return []db.RunPub{}, nil, 0, false // return empty result: conversion error
 		}
 		if p != nil {
 			rpl[ni] = *p
 		}
 	}
 
-	return rpl, true
+	return rpl, lt, nTotal, true
 }
 
 // RunFull return full run metadata (without text) by model digest-or-name and run digest-or-stamp-or-name.
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"slices"
+
+	"github.com/openmpp/go/ompp"
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+	"golang.org/x/text/language"
+)
+
+// GroupTreePub is a tree of parameter groups or output table groups, ready to render by UI
+type GroupTreePub struct {
+	ModelName   string          // model name
+	ModelDigest string          // model digest
+	LangCode    string          // language of labels
+	IsParam     bool            // if true then it is parameters tree else output tables tree
+	Tree        []GroupTreeNode // top level groups and parameters (or output tables) which are not included in any group
+}
+
+// GroupTreeNode is a node of groups tree: group, parameter or output table
+type GroupTreeNode struct {
+	IsGroup  bool            // if true then node is a group else it is parameter or output table
+	Id       int             // group id, parameter id or output table id
+	Name     string          // group name, parameter name or output table name
+	Descr    string          // label in request language or in model default language
+	IsHidden bool            // if true then node is hidden or it is a child of hidden group
+	Children []GroupTreeNode // group children: groups, parameters or output tables in order of group_pc child_pos
+}
+
+// cached groups tree and model load time, groups tree rebuilt if model metadata or text reloaded
+type groupTreeCache struct {
+	loadTime int64         // model load time when tree created
+	tree     *GroupTreePub // groups tree
+}
+
+// GroupTree return parameters or output tables groups tree in preferred language.
+// Tree is assembled from group_lst and group_pc rows and cached for each model and language.
+// Hidden flags are resolved: node is hidden if node itself is hidden or if any of parent groups is hidden.
+// Model text metadata must be loaded before the call.
+func (mc *ModelCatalog) GroupTree(dn string, isParam bool, preferredLang []language.Tag) (*GroupTreePub, bool) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return nil, false
+	}
+	lc := mc.languageTagMatch(dn, preferredLang)
+
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	idx, ok := mc.indexByDigestOrName(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return nil, false
+	}
+	md := &mc.modelLst[idx]
+
+	// return cached tree if model not reloaded since tree created
+	key := lc + "\x00table"
+	if isParam {
+		key = lc + "\x00param"
+	}
+	if gc, ok := md.groupTree[key]; ok && gc.loadTime == md.loadTime {
+		return gc.tree, true
+	}
+
+	// get model text in preferred language or in model default language
+	var me ompp.ModelMetaEncoder
+	if e := me.New(md.meta, md.txtMeta, lc, md.meta.Model.DefaultLangCode); e != nil {
+		omppLog.Log("Error: invalid (empty) model metadata: ", dn)
+		return nil, false
+	}
+	mt := &me.MetaDescrNote
+
+	descrOf := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	// index of groups by group id and list of groups and leaves included in any group
+	gIdx := map[int]int{}
+	isChild := map[int]bool{}
+	isInGroup := map[int]bool{}
+
+	for k := range md.meta.Group {
+		if md.meta.Group[k].IsParam != isParam {
+			continue
+		}
+		gIdx[md.meta.Group[k].GroupId] = k
+
+		for _, pc := range md.meta.Group[k].GroupPc {
+			if pc.ChildGroupId >= 0 {
+				isChild[pc.ChildGroupId] = true
+			}
+			if pc.ChildLeafId >= 0 {
+				isInGroup[pc.ChildLeafId] = true
+			}
+		}
+	}
+
+	// make parameter or output table node
+	leafNode := func(leafId int, isParentHidden bool) (GroupTreeNode, bool) {
+
+		if isParam {
+			if j, ok := md.meta.ParamByKey(leafId); ok {
+				p := &md.meta.Param[j]
+				return GroupTreeNode{
+					Id:       p.ParamId,
+					Name:     p.Name,
+					Descr:    descrOf(mt.ParamTxt[j].DescrNote.Descr),
+					IsHidden: isParentHidden || p.IsHidden,
+					Children: []GroupTreeNode{},
+				}, true
+			}
+			return GroupTreeNode{}, false
+		}
+		if j, ok := md.meta.OutTableByKey(leafId); ok {
+			t := &md.meta.Table[j]
+			return GroupTreeNode{
+				Id:       t.TableId,
+				Name:     t.Name,
+				Descr:    descrOf(mt.TableTxt[j].TableDescr),
+				IsHidden: isParentHidden || t.IsHidden,
+				Children: []GroupTreeNode{},
+			}, true
+		}
+		return GroupTreeNode{}, false
+	}
+
+	// make group node with all children, skip child group if it is already a parent in the current path
+	path := map[int]bool{}
+
+	var groupNode func(k int, isParentHidden bool) GroupTreeNode
+	groupNode = func(k int, isParentHidden bool) GroupTreeNode {
+
+		g := &md.meta.Group[k]
+		gn := GroupTreeNode{
+			IsGroup:  true,
+			Id:       g.GroupId,
+			Name:     g.Name,
+			Descr:    descrOf(mt.GroupTxt[k].DescrNote.Descr),
+			IsHidden: isParentHidden || g.IsHidden,
+			Children: []GroupTreeNode{},
+		}
+		path[g.GroupId] = true
+		defer delete(path, g.GroupId)
+
+		pcLst := slices.Clone(g.GroupPc)
+		slices.SortStableFunc(pcLst, func(a, b db.GroupPcRow) int { return a.ChildPos - b.ChildPos })
+
+		for _, pc := range pcLst {
+
+			if pc.ChildGroupId >= 0 {
+				if j, ok := gIdx[pc.ChildGroupId]; ok && !path[pc.ChildGroupId] {
+					gn.Children = append(gn.Children, groupNode(j, gn.IsHidden))
+				}
+			}
+			if pc.ChildLeafId >= 0 {
+				if c, ok := leafNode(pc.ChildLeafId, gn.IsHidden); ok {
+					gn.Children = append(gn.Children, c)
+				}
+			}
+		}
+		return gn
+	}
+
+	// top level groups and parameters or output tables which are not included in any group
+	gt := &GroupTreePub{
+		ModelName:   md.meta.Model.Name,
+		ModelDigest: md.meta.Model.Digest,
+		LangCode:    lc,
+		IsParam:     isParam,
+		Tree:        []GroupTreeNode{},
+	}
+	if gt.LangCode == "" {
+		gt.LangCode = md.meta.Model.DefaultLangCode
+	}
+
+	for k := range md.meta.Group {
+		if md.meta.Group[k].IsParam == isParam && !isChild[md.meta.Group[k].GroupId] {
+			gt.Tree = append(gt.Tree, groupNode(k, false))
+		}
+	}
+	if isParam {
+		for k := range md.meta.Param {
+			if !isInGroup[md.meta.Param[k].ParamId] {
+				if c, ok := leafNode(md.meta.Param[k].ParamId, false); ok {
+					gt.Tree = append(gt.Tree, c)
+				}
+			}
+		}
+	} else {
+		for k := range md.meta.Table {
+			if !isInGroup[md.meta.Table[k].TableId] {
+				if c, ok := leafNode(md.meta.Table[k].TableId, false); ok {
+					gt.Tree = append(gt.Tree, c)
+				}
+			}
+		}
+	}
+
+	// cache the tree
+	if md.groupTree == nil {
+		md.groupTree = map[string]groupTreeCache{}
+	}
+	md.groupTree[key] = groupTreeCache{loadTime: md.loadTime, tree: gt}
+
+	return gt, true
+}
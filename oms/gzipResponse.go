@@ -0,0 +1,172 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// gzipResponse compress JSON and CSV responses by gzip if client accepts gzip encoding
+// and response size is at least minSize bytes.
+// If minSize <= 0 then compression disabled and next handler returned as is.
+//
+// Response body is buffered until minSize bytes written, smaller responses are sent uncompressed.
+// Responses with Content-Encoding or Content-Range headers are never compressed.
+func gzipResponse(next http.Handler, minSize int) http.Handler {
+
+	if minSize <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method == http.MethodHead || !isAcceptGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r) // client does not accept gzip
+			return
+		}
+
+		gw := &gzipWriter{ResponseWriter: w, minSize: minSize}
+		defer gw.close()
+
+		next.ServeHTTP(gw, r) // invoke next handler
+	})
+}
+
+// return true if Accept-Encoding header value include gzip and it is not disabled by q=0
+func isAcceptGzip(acceptEncoding string) bool {
+
+	for _, s := range strings.Split(acceptEncoding, ",") {
+
+		enc, q, _ := strings.Cut(strings.TrimSpace(s), ";")
+		enc = strings.TrimSpace(enc)
+
+		if !strings.EqualFold(enc, "gzip") && enc != "*" {
+			continue
+		}
+		q = strings.ReplaceAll(q, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
+
+// gzipWriter is http response writer to compress JSON and CSV responses.
+// Status code and body are buffered until it is decided to compress response or not.
+type gzipWriter struct {
+	http.ResponseWriter
+	minSize   int          // min response size to compress
+	status    int          // delayed response status code
+	buf       []byte       // buffered response body
+	isDecided bool         // if true then headers written and it is decided to compress response or not
+	gz        *gzip.Writer // gzip writer, nil if response not compressed
+}
+
+// WriteHeader delay status code until it is decided to compress response or not.
+func (gw *gzipWriter) WriteHeader(code int) {
+	if gw.status == 0 && !gw.isDecided {
+		gw.status = code
+	}
+}
+
+// Write buffer response body until min size reached if response is JSON or CSV, else write it as is.
+func (gw *gzipWriter) Write(p []byte) (int, error) {
+
+	if !gw.isDecided {
+
+		if !gw.isCompressible() {
+			if err := gw.decide(false); err != nil {
+				return 0, err
+			}
+		} else {
+			gw.buf = append(gw.buf, p...)
+
+			if len(gw.buf) < gw.minSize {
+				return len(p), nil // response is not large enough: keep buffering
+			}
+			if err := gw.decide(true); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		}
+	}
+
+	if gw.gz != nil {
+		return gw.gz.Write(p)
+	}
+	return gw.ResponseWriter.Write(p)
+}
+
+// Flush write buffered response: compress it if response is JSON or CSV, streaming response is most likely large.
+func (gw *gzipWriter) Flush() {
+
+	if !gw.isDecided {
+		if err := gw.decide(gw.isCompressible() && len(gw.buf) > 0); err != nil {
+			return
+		}
+	}
+	if gw.gz != nil {
+		gw.gz.Flush()
+	}
+	if f, ok := gw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close send response if it is still buffered and complete gzip output.
+func (gw *gzipWriter) close() {
+
+	if !gw.isDecided {
+		gw.decide(false) // response is smaller than min size
+	}
+	if gw.gz != nil {
+		gw.gz.Close()
+	}
+}
+
+// return true if response can be compressed: it is JSON or CSV and not encoded or partial content
+func (gw *gzipWriter) isCompressible() bool {
+
+	if gw.status != 0 && gw.status != http.StatusOK && gw.status != http.StatusCreated {
+		return false
+	}
+	h := gw.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	ct := strings.ToLower(h.Get("Content-Type"))
+
+	return strings.HasPrefix(ct, "application/json") || strings.HasPrefix(ct, "text/csv")
+}
+
+// write response headers and buffered body, use gzip if isGzip is true
+func (gw *gzipWriter) decide(isGzip bool) error {
+
+	gw.isDecided = true
+
+	if gw.isCompressible() {
+		gw.Header().Add("Vary", "Accept-Encoding")
+	}
+	if isGzip {
+		gw.Header().Del("Content-Length")
+		gw.Header().Set("Content-Encoding", "gzip")
+		gw.gz = gzip.NewWriter(gw.ResponseWriter)
+	}
+	if gw.status != 0 {
+		gw.ResponseWriter.WriteHeader(gw.status)
+	}
+
+	if len(gw.buf) > 0 {
+		b := gw.buf
+		gw.buf = nil
+
+		var err error
+		if gw.gz != nil {
+			_, err = gw.gz.Write(b)
+		} else {
+			_, err = gw.ResponseWriter.Write(b)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+)
+
+// default page size of activity events
+const activityPageSize = 100
+
+// activityHandler return page of recent server activity events, most recent events first:
+//
+//	GET /api/activity
+//	GET /api/activity/start/:start
+//	GET /api/activity/start/:start/count/:count
+//	GET /api/activity?start=0&count=100
+//
+// Events are: model run started or completed, workset created, modified or deleted, run or workset uploaded,
+// model, run or workset download initiated.
+// Events are kept in memory of oms instance, completed model runs also restored from jobs history files.
+// Page defined by zero-based "start" position and page size "count", default count is 100.
+// If count < 0 then all events returned.
+func activityHandler(w http.ResponseWriter, r *http.Request) {
+
+	start, ok := getIntRequestParam(r, "start", 0)
+	if !ok || start < 0 {
+		http.Error(w, "Invalid value of activity page start", http.StatusBadRequest)
+		return
+	}
+	count, ok := getIntRequestParam(r, "count", activityPageSize)
+	if !ok {
+		http.Error(w, "Invalid value of activity page size", http.StatusBadRequest)
+		return
+	}
+	if count == 0 {
+		count = activityPageSize
+	}
+
+	jsonResponse(w, r, getActivityPage(start, count))
+}
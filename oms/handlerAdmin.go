@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"io"
 	"net/http"
+	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
@@ -166,6 +168,155 @@ func modelOpenDbFileHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 }
 
+// upload model SQLite database file or zip archive with model.sqlite into models directory and add models to catalog.
+//
+//	POST /api/admin/model-upload
+//	POST /api/admin/model-upload?dir=sub/folder
+//
+// Multipart form expected with single part: model.sqlite or model.zip file attached.
+// Zip archive must contain only one .sqlite file, any other files in archive are ignored.
+// Database file is placed into models/bin root or into dir sub-folder, it must not overwrite existing file.
+// Database must be openM++ database of current schema version and models from that database must not already exist in catalog.
+// If validation failed then uploaded file is removed and models catalog is not changed.
+func modelUploadHandler(w http.ResponseWriter, r *http.Request) {
+
+	// model directory required to store model database
+	mbinDir, isDir := theCatalog.getModelDir()
+	if !isDir || mbinDir == "" {
+		http.Error(w, "Error: model directory not exist or not accesible", http.StatusBadRequest)
+		return
+	}
+
+	// block upload if disk space usage exceed the limits
+	if isOver, _ := theRunCatalog.getDiskUseStatus(); isOver {
+		http.Error(w, "Disk space usage exceeds quota, upload disabled", http.StatusBadRequest)
+		return
+	}
+
+	// optional sub-folder must be relative to models/bin root
+	subDir := filepath.FromSlash(getRequestParam(r, "dir"))
+	if subDir != "" && !filepath.IsLocal(subDir) {
+		http.Error(w, "Error: invalid model sub-folder: "+subDir, http.StatusBadRequest)
+		return
+	}
+
+	// parse multipart form: only single part expected with model.sqlite or model.zip file attached
+	mr, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Error at multipart form open ", http.StatusBadRequest)
+		return
+	}
+
+	part, err := mr.NextPart()
+	if err == io.EOF {
+		http.Error(w, "Invalid (empty) next part of multipart form", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get next part of multipart form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer part.Close()
+
+	// check file name: it should be model.sqlite or model.zip
+	fName := part.FileName()
+	ext := strings.ToLower(filepath.Ext(fName))
+	baseName := strings.TrimSuffix(fName, filepath.Ext(fName))
+
+	if baseName == "" || baseName == "." || baseName == ".." || fName != helper.CleanFileName(fName) {
+		http.Error(w, "Error: invalid (or empty) file name: "+fName, http.StatusBadRequest)
+		return
+	}
+	if ext != ".sqlite" && ext != ".zip" {
+		http.Error(w, "Error: file name must be: "+baseName+".sqlite or "+baseName+".zip", http.StatusBadRequest)
+		return
+	}
+	omppLog.Log("Upload of model database: ", fName)
+
+	// save uploaded file into temporary folder inside of models directory
+	tmpDir, err := os.MkdirTemp(mbinDir, "upload-model-")
+	if err != nil {
+		omppLog.Log("Error: unable to create temporary folder in: ", mbinDir, ": ", err)
+		http.Error(w, "Error: unable to upload model database: "+fName, http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		if e := os.RemoveAll(tmpDir); e != nil {
+			omppLog.Log("Error: unable to remove temporary folder: ", tmpDir, ": ", e)
+		}
+	}()
+
+	srcPath := filepath.Join(tmpDir, fName)
+
+	if err = helper.SaveTo(srcPath, part); err != nil {
+		omppLog.Log("Error: unable to write into ", srcPath, err)
+		http.Error(w, "Error: unable to write into "+fName, http.StatusInternalServerError)
+		return
+	}
+
+	// unpack zip archive and find model.sqlite
+	if ext == ".zip" {
+
+		zipDir := filepath.Join(tmpDir, baseName)
+		if err = helper.UnpackZip(srcPath, false, zipDir); err != nil {
+			omppLog.Log("Error: unable to unzip ", srcPath, err)
+			http.Error(w, "Error: unable to unzip "+fName, http.StatusBadRequest)
+			return
+		}
+
+		dbLst := []string{}
+		err = filepath.WalkDir(zipDir, func(src string, de os.DirEntry, e error) error {
+			if e != nil {
+				return e
+			}
+			if !de.IsDir() && strings.EqualFold(filepath.Ext(src), ".sqlite") {
+				dbLst = append(dbLst, src)
+			}
+			return nil
+		})
+		if err != nil {
+			omppLog.Log("Error: unable to read unzipped files: ", zipDir, ": ", err)
+			http.Error(w, "Error: unable to read unzipped files: "+fName, http.StatusBadRequest)
+			return
+		}
+		if len(dbLst) != 1 {
+			http.Error(w, "Error: zip archive must contain one model .sqlite file: "+fName+" ("+strconv.Itoa(len(dbLst))+")", http.StatusBadRequest)
+			return
+		}
+		srcPath = dbLst[0]
+	}
+
+	// validate database, move it into models directory and add models to catalog
+	dbRel := filepath.Join(subDir, filepath.Base(srcPath))
+
+	mLst, err := theCatalog.uploadModelDbFile(srcPath, filepath.Join(mbinDir, dbRel))
+	if err != nil {
+		omppLog.Log(err)
+		http.Error(w, "Failed to upload model database: "+fName+": "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// return list of uploaded models
+	type modelItem struct {
+		Name   string // model name
+		Digest string // model digest
+	}
+	res := struct {
+		DbPath string      // path to model database file relative to models/bin root
+		Models []modelItem // uploaded models
+	}{
+		DbPath: filepath.ToSlash(dbRel),
+		Models: make([]modelItem, len(mLst)),
+	}
+	for k := range mLst {
+		res.Models[k] = modelItem{Name: mLst[k].Name, Digest: mLst[k].Digest}
+		omppLog.Log("Model uploaded: ", mLst[k].Name, " ", mLst[k].Digest, " ", res.DbPath)
+	}
+
+	w.Header().Set("Content-Location", "/api/admin/model-upload/"+res.DbPath)
+	jsonResponse(w, r, res)
+}
+
 // pause or resume jobs queue processing by this oms instance
 //
 //	POST /api/admin/jobs-pause/:pause
@@ -173,6 +324,46 @@ func jobsPauseHandler(w http.ResponseWriter, r *http.Request) {
 	doJobsPause(jobQueuePausedPath(theCfg.omsName), "/api/admin/jobs-pause/", w, r)
 }
 
+// force cancel active job: kill model process group, mark job as failed and optionally requeue it.
+//
+//	POST /api/admin/job-cancel/:job
+//	POST /api/admin/job-cancel/:job?reason=node%20failure&requeue=false
+//
+// Job is a submission stamp of active job, job must be running by this oms instance.
+// Optional reason is stored in job history file and model run log.
+// Job is requeued if requeue is not false and number of retries is less than model MaxRetries from job.ini.
+func jobForceCancelHandler(w http.ResponseWriter, r *http.Request) {
+
+	// url or query parameters: job submission stamp, cancel reason and requeue flag
+	submitStamp := getRequestParam(r, "job")
+	if submitStamp == "" {
+		http.Error(w, "Invalid (empty) job submission stamp", http.StatusBadRequest)
+		return
+	}
+	reason := getRequestParam(r, "reason")
+
+	isRequeue, ok := getBoolRequestParam(r, "requeue")
+	if !ok {
+		http.Error(w, "Invalid value of requeue flag, expected true or false", http.StatusBadRequest)
+		return
+	}
+	if getRequestParam(r, "requeue") == "" {
+		isRequeue = true // by default requeue job if retries limit not exceeded
+	}
+
+	res, err := theRunCatalog.forceCancelJob(submitStamp, reason, isRequeue)
+	if err != nil {
+		omppLog.Log(err)
+		if res == nil {
+			http.Error(w, "Job force cancel failed: "+submitStamp, http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Location", "/api/admin/job-cancel/"+submitStamp)
+	jsonResponse(w, r, res)
+}
+
 // pause or resume jobs queue processing by all oms instances
 //
 //	POST /api/admin-all/jobs-pause/:pause
@@ -197,9 +388,9 @@ func doJobsPause(filePath, msgPath string, w http.ResponseWriter, r *http.Reques
 	// create jobs paused state file or remove it to resume queue processing
 	isOk := false
 	if isPause {
-		isOk = fileCreateEmpty(false, filePath)
+		isOk = jobFileCreateEmpty(false, filePath)
 	} else {
-		isOk = fileDeleteAndLog(false, filePath)
+		isOk = jobFileDelete(false, filePath)
 	}
 	if !isOk {
 		isPause = !isPause // operation failed
@@ -464,3 +655,152 @@ func dbCleanupFileLogGetHandler(w http.ResponseWriter, r *http.Request) {
 
 	jsonResponse(w, r, st)
 }
+
+// return webhooks configuration: list of URLs to notify on model run completion.
+//
+//	GET /api/admin/webhooks
+func webhooksGetHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, getWebhookConfig())
+}
+
+// replace list of URLs to notify on model run completion and return new webhooks configuration.
+// Json body expected: {"Urls": ["https://ci.example.com/hook"]}, empty list disables global webhooks.
+//
+//	POST /api/admin/webhooks
+func webhooksPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	var src struct {
+		Urls []string // list of URLs to notify on model run completion
+	}
+	if !jsonRequestDecode(w, r, true, &src) {
+		return // error at json decode, response done with http error
+	}
+	for _, u := range src.Urls {
+		if !isWebhookUrl(u) {
+			http.Error(w, "Invalid webhook URL, expected http:// or https:// "+u, http.StatusBadRequest)
+			return
+		}
+	}
+
+	wh := replaceWebhookUrls(src.Urls)
+	omppLog.Log("Webhooks: ", strings.Join(wh.Urls, ", "))
+
+	w.Header().Set("Content-Location", "/api/admin/webhooks")
+	jsonResponse(w, r, wh)
+}
+
+// return list of parameter, output table and microdata values db tables not referenced by any model and table row counts.
+//
+//	GET /api/admin/model/:model/orphan-tables
+//
+// Model identified by digest-or-name and used to find model database, orphan tables are searched in entire database.
+// If multiple models with same name exist then result is undefined.
+func orphanTablesGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+
+	otLst, _, err := theCatalog.OrphanTables(dn)
+	if err != nil {
+		http.Error(w, "Failed to find orphan tables: "+dn, http.StatusBadRequest)
+		return
+	}
+	jsonResponse(w, r, otLst)
+}
+
+// delete parameter, output table and microdata values db tables not referenced by any model.
+//
+//	POST /api/admin/model/:model/orphan-tables/drop
+//	POST /api/admin/model/:model/orphan-tables/drop?dry-run=true
+//
+// Model identified by digest-or-name and used to find model database.
+// Optional json body is a list of table names to delete, for example: ["ageSex_p12345678"],
+// if table names list is empty then all orphan tables are deleted.
+// If dry-run is true then nothing deleted and response is a list of tables to be deleted.
+// Response is a list of deleted orphan tables.
+func orphanTablesDropHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+
+	isDryRun, ok := getBoolRequestParam(r, "dry-run")
+	if !ok {
+		http.Error(w, "Invalid dry-run parameter, expected true or false", http.StatusBadRequest)
+		return
+	}
+
+	names := []string{}
+	if r.ContentLength != 0 && !jsonRequestDecode(w, r, false, &names) {
+		return // error at json decode, response done with http error
+	}
+
+	otLst, ok, err := theCatalog.DropOrphanTables(dn, names, isDryRun)
+	if err != nil || !ok {
+		http.Error(w, "Failed to delete orphan tables: "+dn, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/admin/model/"+dn+"/orphan-tables/drop")
+	jsonResponse(w, r, otLst)
+}
+
+// add new language into model database and copy language words from source language.
+//
+//	POST /api/admin/model/:model/lang/:lang/add
+//	POST /api/admin/model/:model/lang/:lang/add?name=Deutsch&from=EN
+//
+// Model identified by digest-or-name and used to find model database, language is added to entire database.
+// Language words (lang_word rows) are copied from source language, by default from the first language in database.
+// If source language specified then all translation strings are also copied from that language as placeholders.
+func langAddHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	lang := getRequestParam(r, "lang")
+	name := getRequestParam(r, "name")
+	from := getRequestParam(r, "from")
+
+	ok, err := theCatalog.AddLanguage(dn, lang, name, from, from != "")
+	if err != nil || !ok {
+		http.Error(w, "Failed to add language: "+dn+": "+lang, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/admin/model/"+dn+"/lang/"+lang)
+	w.Header().Set("Content-Type", "text/plain")
+}
+
+// copy translation strings from source language, existing translations are not changed.
+//
+//	POST /api/admin/model/:model/lang/:lang/copy-from/:from
+//
+// Model identified by digest-or-name and used to find model database, strings are copied for all models in database.
+func langCopyHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	lang := getRequestParam(r, "lang")
+	from := getRequestParam(r, "from")
+
+	ok, err := theCatalog.CopyLanguageText(dn, from, lang)
+	if err != nil || !ok {
+		http.Error(w, "Failed to copy language: "+dn+": "+from+" into: "+lang, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/admin/model/"+dn+"/lang/"+lang)
+	w.Header().Set("Content-Type", "text/plain")
+}
+
+// delete language and all translation strings of that language from model database.
+//
+//	POST /api/admin/model/:model/lang/:lang/delete
+//
+// Model identified by digest-or-name and used to find model database, language is deleted from entire database.
+// Language cannot be deleted if it is a default language of any model in database.
+func langDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	lang := getRequestParam(r, "lang")
+
+	ok, err := theCatalog.DeleteLanguage(dn, lang)
+	if err != nil || !ok {
+		http.Error(w, "Failed to delete language: "+dn+": "+lang, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/admin/model/"+dn+"/lang/"+lang)
+	w.Header().Set("Content-Type", "text/plain")
+}
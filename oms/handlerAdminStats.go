@@ -0,0 +1,150 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+)
+
+// AdminStats is a lightweight summary of oms process state: goroutines, heap, database connections and catalog sizes.
+type AdminStats struct {
+	Uptime     string          // time since oms started, e.g.: 1h2m3s
+	Goroutines int             // number of goroutines
+	Heap       AdminHeapStats  // heap memory usage
+	Db         AdminDbStats    // database connections of all models
+	Catalog    AdminCatalogUse // model catalog and run catalog sizes
+}
+
+// AdminHeapStats is a summary of memory usage, see runtime.MemStats
+type AdminHeapStats struct {
+	Alloc       uint64 // bytes of allocated heap objects
+	Sys         uint64 // total bytes of memory obtained from the OS
+	HeapInuse   uint64 // bytes in in-use heap spans
+	HeapIdle    uint64 // bytes in idle (unused) heap spans
+	HeapObjects uint64 // number of allocated heap objects
+	NumGC       uint32 // number of completed GC cycles
+}
+
+// AdminDbStats is a summary of database connections of all models in catalog, see sql.DBStats
+type AdminDbStats struct {
+	DbCount         int   // number of model databases
+	OpenConnections int   // number of established connections both in use and idle
+	InUse           int   // number of connections currently in use
+	Idle            int   // number of idle connections
+	WaitCount       int64 // total number of connections waited for
+}
+
+// AdminCatalogUse is a model catalog and run catalog sizes
+type AdminCatalogUse struct {
+	ModelCount     int // number of models
+	TextLoaded     int // number of models where language-specific metadata loaded
+	TextFullLoaded int // number of models where all language-specific metadata loaded
+	RunStateCount  int // number of model run states and logs
+	QueueJobs      int // number of model run jobs in the queue
+	ActiveJobs     int // number of active (currently running) model run jobs
+	HistoryJobs    int // number of model run jobs in the history
+}
+
+// time when oms started
+var omsStartTime = time.Now()
+
+// return summary of oms process state: goroutines, heap, open db connections and catalog sizes.
+//
+//	GET /api/admin/stats
+func adminStatsHandler(w http.ResponseWriter, r *http.Request) {
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	st := AdminStats{
+		Uptime:     time.Since(omsStartTime).Round(time.Second).String(),
+		Goroutines: runtime.NumGoroutine(),
+		Heap: AdminHeapStats{
+			Alloc:       ms.Alloc,
+			Sys:         ms.Sys,
+			HeapInuse:   ms.HeapInuse,
+			HeapIdle:    ms.HeapIdle,
+			HeapObjects: ms.HeapObjects,
+			NumGC:       ms.NumGC,
+		},
+	}
+	st.Db, st.Catalog = theCatalog.catalogStats()
+	theRunCatalog.runCatalogStats(&st.Catalog)
+
+	jsonResponse(w, r, st)
+}
+
+// return summary of database connections and model catalog sizes
+func (mc *ModelCatalog) catalogStats() (AdminDbStats, AdminCatalogUse) {
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	ds := AdminDbStats{}
+	cs := AdminCatalogUse{ModelCount: len(mc.modelLst)}
+
+	for k := range mc.modelLst {
+
+		if mc.modelLst[k].txtMeta != nil {
+			cs.TextLoaded++
+			if mc.modelLst[k].isTxtMetaFull {
+				cs.TextFullLoaded++
+			}
+		}
+		if mc.modelLst[k].dbConn == nil {
+			continue
+		}
+		s := mc.modelLst[k].dbConn.Stats()
+		ds.DbCount++
+		ds.OpenConnections += s.OpenConnections
+		ds.InUse += s.InUse
+		ds.Idle += s.Idle
+		ds.WaitCount += s.WaitCount
+	}
+	return ds, cs
+}
+
+// update run catalog sizes: number of model run states and number of jobs
+func (rsc *RunCatalog) runCatalogStats(cs *AdminCatalogUse) {
+	rsc.rscLock.Lock()
+	defer rsc.rscLock.Unlock()
+
+	for _, rm := range rsc.modelRuns {
+		cs.RunStateCount += len(rm)
+	}
+	cs.QueueJobs = len(rsc.queueJobs)
+	cs.ActiveJobs = len(rsc.activeJobs)
+	cs.HistoryJobs = len(rsc.historyJobs)
+}
+
+// serve pprof profiles, enabled by -oms.AllowPprof run option:
+//
+//	GET /api/admin/pprof/
+//	GET /api/admin/pprof/:name
+//	GET /api/admin/pprof/heap
+//	GET /api/admin/pprof/profile?seconds=30
+//
+// Name is a profile name, e.g.: heap allocs goroutine block mutex threadcreate or cmdline profile symbol trace.
+// If name is empty then return index page with list of all profiles.
+func pprofHandler(w http.ResponseWriter, r *http.Request) {
+
+	name := getRequestParam(r, "name")
+
+	switch name {
+	case "":
+		pprof.Index(w, r)
+	case "cmdline":
+		pprof.Cmdline(w, r)
+	case "profile":
+		pprof.Profile(w, r)
+	case "symbol":
+		pprof.Symbol(w, r)
+	case "trace":
+		pprof.Trace(w, r)
+	default:
+		pprof.Handler(name).ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,519 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// runArtifact is a file attached to model run, e.g. plot or report produced by calibration script.
+type runArtifact struct {
+	Name           string // file name
+	Size           int64  // file size in bytes
+	ContentType    string // content type, e.g.: image/png
+	UploadDateTime string // upload date-time
+}
+
+// lock to update artifact index files
+var theArtifactLock sync.Mutex
+
+// return artifacts directory of model run: home/artifact/run-digest
+func artifactDir(runDigest string) string {
+	return filepath.Join(theCfg.homeDir, "artifact", runDigest)
+}
+
+// return artifact index file path of model run: home/artifact/run-digest.index.json
+func artifactIndexPath(runDigest string) string {
+	return filepath.Join(theCfg.homeDir, "artifact", runDigest+".index.json")
+}
+
+// return true if artifact file name is valid: not empty and does not contain any path or special characters
+func isArtifactName(name string) bool {
+	return name != "" && name != "." && name != ".." && name == helper.CleanFileName(name) && name == filepath.Base(name)
+}
+
+// read artifact index of model run, return empty list if there is no artifacts.
+// Caller must hold theArtifactLock.
+func readArtifactIndex(runDigest string) ([]runArtifact, error) {
+
+	aLst := []runArtifact{}
+	if _, err := helper.FromJsonFile(artifactIndexPath(runDigest), &aLst); err != nil {
+		return []runArtifact{}, err
+	}
+	return aLst, nil
+}
+
+// find model run for artifacts request: run must be completed, return run digest.
+// On error write http error response and return false.
+func artifactRun(w http.ResponseWriter, r *http.Request) (string, bool) {
+
+	if !theCfg.isHome {
+		http.Error(w, "Forbidden: run artifacts disabled on the server", http.StatusForbidden)
+		return "", false
+	}
+
+	dn := getRequestParam(r, "model")
+	rdsn := getRequestParam(r, "run")
+
+	runRow, ok := theCatalog.CompletedRunByDigestOrStampOrName(dn, rdsn)
+	if !ok || runRow == nil || runRow.RunDigest == "" {
+		http.Error(w, "Error: model run not found or not completed "+dn+": "+rdsn, http.StatusNotFound)
+		return "", false
+	}
+	return runRow.RunDigest, true
+}
+
+// runArtifactPostHandler upload files and attach it to model run:
+// POST /api/model/:model/run/:run/artifact
+// Multipart form is expected with one or more file parts, model run must be completed.
+// Files are stored in home/artifact/run-digest directory, if file with the same name exist then it is replaced.
+// Total size of run artifacts is limited by -oms.ArtifactQuota megabytes, if quota exceeded then file is rejected.
+// Files are saved in temporary upload directory and moved into artifacts directory after all files uploaded:
+// if any file rejected or failed to upload then none of files saved.
+func runArtifactPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	runDigest, ok := artifactRun(w, r)
+	if !ok {
+		return // error at run search, response done with http error
+	}
+
+	// block upload if disk space usage exceed the limits
+	if isOver, _ := theRunCatalog.getDiskUseStatus(); isOver {
+		http.Error(w, "Disk space usage exceeds quota, upload disabled", http.StatusBadRequest)
+		return
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Error at multipart form open ", http.StatusBadRequest)
+		return
+	}
+
+	theArtifactLock.Lock()
+	defer theArtifactLock.Unlock()
+
+	aLst, err := readArtifactIndex(runDigest)
+	if err != nil {
+		omppLog.Log("Error: unable to read run artifacts index ", runDigest, err)
+		http.Error(w, "Error: unable to read run artifacts", http.StatusInternalServerError)
+		return
+	}
+	aDir := artifactDir(runDigest)
+	if err = os.MkdirAll(aDir, 0750); err != nil {
+		omppLog.Log("Error: unable to create run artifacts directory ", aDir, err)
+		http.Error(w, "Error: unable to save run artifacts", http.StatusInternalServerError)
+		return
+	}
+
+	// save each file part into temporary upload directory
+	upDir, err := os.MkdirTemp(aDir, "upload-*")
+	if err != nil {
+		omppLog.Log("Error: unable to create run artifacts upload directory ", aDir, err)
+		http.Error(w, "Error: unable to save run artifacts", http.StatusInternalServerError)
+		return
+	}
+	defer os.RemoveAll(upDir)
+
+	upLst := []runArtifact{}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			http.Error(w, "Failed to get next part of multipart form: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		name := part.FileName()
+		if name == "" {
+			part.Close()
+			continue // skip parts which are not files
+		}
+		if !isArtifactName(name) {
+			part.Close()
+			http.Error(w, "Error: invalid (or empty) file name: "+name, http.StatusBadRequest)
+			return
+		}
+
+		// size of all other artifacts and other uploaded files: file with the same name replaced
+		k := -1
+		var nTotal int64
+		for j := range aLst {
+			if aLst[j].Name != name && !slices.ContainsFunc(upLst, func(a runArtifact) bool { return a.Name == aLst[j].Name }) {
+				nTotal += aLst[j].Size
+			}
+		}
+		for j := range upLst {
+			if upLst[j].Name == name {
+				k = j
+			} else {
+				nTotal += upLst[j].Size
+			}
+		}
+
+		sz, isOver, err := saveArtifactPart(upDir, name, part, nTotal)
+		part.Close()
+		if err != nil {
+			omppLog.Log("Error: unable to save run artifact ", runDigest, ": ", name, err)
+			http.Error(w, "Error: unable to save run artifact "+name, http.StatusInternalServerError)
+			return
+		}
+		if isOver {
+			http.Error(w, "Error: run artifacts size exceeds quota, file rejected: "+name, http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		ct := mime.TypeByExtension(path.Ext(name))
+		if ct == "" {
+			ct = part.Header.Get("Content-Type")
+		}
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+		a := runArtifact{Name: name, Size: sz, ContentType: ct, UploadDateTime: helper.MakeDateTime(time.Now())}
+
+		if k >= 0 {
+			upLst[k] = a
+		} else {
+			upLst = append(upLst, a)
+		}
+	}
+	if len(upLst) <= 0 {
+		http.Error(w, "Invalid (empty) multipart form, expected one or more files", http.StatusBadRequest)
+		return
+	}
+
+	// all files uploaded: move files into artifacts directory and update index
+	for _, a := range upLst {
+
+		if err = os.Rename(filepath.Join(upDir, a.Name), filepath.Join(aDir, a.Name)); err != nil {
+			omppLog.Log("Error: unable to save run artifact ", runDigest, ": ", a.Name, err)
+			http.Error(w, "Error: unable to save run artifact "+a.Name, http.StatusInternalServerError)
+			return
+		}
+		k := slices.IndexFunc(aLst, func(e runArtifact) bool { return e.Name == a.Name })
+		if k >= 0 {
+			aLst[k] = a
+		} else {
+			aLst = append(aLst, a)
+		}
+	}
+	sort.SliceStable(aLst, func(i, j int) bool { return aLst[i].Name < aLst[j].Name })
+
+	if err = helper.ToJsonIndentFile(artifactIndexPath(runDigest), aLst); err != nil {
+		omppLog.Log("Error: unable to write run artifacts index ", runDigest, err)
+		http.Error(w, "Error: unable to save run artifacts", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Location", "/api/model/"+getRequestParam(r, "model")+"/run/"+runDigest+"/artifact-list")
+	jsonResponse(w, r, aLst)
+}
+
+// save artifact file from the reader into artifact directory, return file size.
+// If total size of artifacts exceed the quota then file is not saved and return true.
+func saveArtifactPart(aDir, name string, rd io.Reader, nTotal int64) (int64, bool, error) {
+
+	f, err := os.CreateTemp(aDir, "upload-*.tmp")
+	if err != nil {
+		return 0, false, err
+	}
+	tmpPath := f.Name()
+
+	// copy not more than quota allows
+	src := rd
+	if theCfg.artifactMax > 0 {
+		src = io.LimitReader(rd, theCfg.artifactMax-nTotal+1)
+	}
+	sz, err := io.Copy(f, src)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return 0, false, err
+	}
+	if theCfg.artifactMax > 0 && nTotal+sz > theCfg.artifactMax {
+		os.Remove(tmpPath)
+		return 0, true, nil
+	}
+
+	if err = os.Rename(tmpPath, filepath.Join(aDir, name)); err != nil {
+		os.Remove(tmpPath)
+		return 0, false, err
+	}
+	return sz, false, nil
+}
+
+// runArtifactListGetHandler return list of model run artifacts, sorted by file name:
+// GET /api/model/:model/run/:run/artifact-list
+// If there are no artifacts then response is empty [] json array.
+func runArtifactListGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	runDigest, ok := artifactRun(w, r)
+	if !ok {
+		return // error at run search, response done with http error
+	}
+
+	theArtifactLock.Lock()
+	aLst, err := readArtifactIndex(runDigest)
+	theArtifactLock.Unlock()
+
+	if err != nil {
+		omppLog.Log("Error: unable to read run artifacts index ", runDigest, err)
+		http.Error(w, "Error: unable to read run artifacts", http.StatusInternalServerError)
+		return
+	}
+	jsonResponse(w, r, aLst)
+}
+
+// runArtifactGetHandler download model run artifact file:
+// GET /api/model/:model/run/:run/artifact/:name
+func runArtifactGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	runDigest, ok := artifactRun(w, r)
+	if !ok {
+		return // error at run search, response done with http error
+	}
+	name := getRequestParam(r, "name")
+	if !isArtifactName(name) {
+		http.Error(w, "Error: invalid artifact name "+name, http.StatusBadRequest)
+		return
+	}
+
+	// find artifact in the index
+	theArtifactLock.Lock()
+	aLst, err := readArtifactIndex(runDigest)
+	theArtifactLock.Unlock()
+
+	if err != nil {
+		omppLog.Log("Error: unable to read run artifacts index ", runDigest, err)
+		http.Error(w, "Error: unable to read run artifacts", http.StatusInternalServerError)
+		return
+	}
+	k := -1
+	for j := range aLst {
+		if aLst[j].Name == name {
+			k = j
+			break
+		}
+	}
+	if k < 0 {
+		http.Error(w, "Error: run artifact not found "+name, http.StatusNotFound)
+		return
+	}
+
+	f, err := os.Open(filepath.Join(artifactDir(runDigest), name))
+	if err != nil {
+		omppLog.Log("Error: unable to open run artifact ", runDigest, ": ", name, err)
+		http.Error(w, "Error: run artifact not found "+name, http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, "Error: run artifact not found "+name, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", aLst[k].ContentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+`"`+url.QueryEscape(name)+`"`)
+	http.ServeContent(w, r, name, fi.ModTime(), f)
+}
+
+// runArtifactDeleteHandler delete model run artifact file:
+// DELETE /api/model/:model/run/:run/artifact/:name
+// If artifact not exists then it is not an error.
+func runArtifactDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	runDigest, ok := artifactRun(w, r)
+	if !ok {
+		return // error at run search, response done with http error
+	}
+	name := getRequestParam(r, "name")
+	if !isArtifactName(name) {
+		http.Error(w, "Error: invalid artifact name "+name, http.StatusBadRequest)
+		return
+	}
+
+	theArtifactLock.Lock()
+	defer theArtifactLock.Unlock()
+
+	aLst, err := readArtifactIndex(runDigest)
+	if err != nil {
+		omppLog.Log("Error: unable to read run artifacts index ", runDigest, err)
+		http.Error(w, "Error: unable to delete run artifact "+name, http.StatusInternalServerError)
+		return
+	}
+
+	for k := range aLst {
+		if aLst[k].Name != name {
+			continue
+		}
+		aLst = append(aLst[:k], aLst[k+1:]...)
+
+		if err = helper.ToJsonIndentFile(artifactIndexPath(runDigest), aLst); err != nil {
+			omppLog.Log("Error: unable to write run artifacts index ", runDigest, err)
+			http.Error(w, "Error: unable to delete run artifact "+name, http.StatusInternalServerError)
+			return
+		}
+		break
+	}
+
+	err = os.Remove(filepath.Join(artifactDir(runDigest), name))
+	if err != nil && !os.IsNotExist(err) {
+		omppLog.Log("Error: unable to delete run artifact ", runDigest, ": ", name, err)
+		http.Error(w, "Error: unable to delete run artifact "+name, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/model/"+getRequestParam(r, "model")+"/run/"+runDigest+"/artifact/"+name)
+	w.Header().Set("Content-Type", "text/plain")
+}
+
+// deleteRunArtifacts delete all artifacts of model run: artifacts directory and index file.
+// If there are no artifacts then do nothing.
+func deleteRunArtifacts(runDigest string) error {
+	if !theCfg.isHome || !isArtifactName(runDigest) {
+		return nil // run artifacts disabled or invalid run digest
+	}
+
+	theArtifactLock.Lock()
+	defer theArtifactLock.Unlock()
+
+	if err := os.RemoveAll(artifactDir(runDigest)); err != nil {
+		return err
+	}
+	if err := os.Remove(artifactIndexPath(runDigest)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// appendRunArtifacts copy model run artifacts into download directory and append it into download .zip file:
+// model.run.Name/artifact/file.name
+// If there are no artifacts then do nothing.
+func appendRunArtifacts(runDigest string, basePath string) error {
+
+	theArtifactLock.Lock()
+	aLst, err := readArtifactIndex(runDigest)
+	theArtifactLock.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if len(aLst) <= 0 {
+		return nil
+	}
+	srcDir := artifactDir(runDigest)
+	dstDir := filepath.Join(basePath, "artifact")
+
+	if err = os.MkdirAll(dstDir, 0750); err != nil {
+		return err
+	}
+	for k := range aLst {
+		if !fileCopy(false, filepath.Join(srcDir, aLst[k].Name), filepath.Join(dstDir, aLst[k].Name)) {
+			return errors.New("failed to copy run artifact: " + aLst[k].Name)
+		}
+	}
+
+	// re-create zip: copy all existing zip entries and append artifacts
+	zipPath := basePath + ".zip"
+	tmpPath := zipPath + ".tmp"
+
+	if err = appendZipDir(zipPath, tmpPath, dstDir, path.Join(filepath.Base(basePath), "artifact")); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, zipPath)
+}
+
+// copy source zip into destination zip and append all files from directory into zip under zipDir folder
+func appendZipDir(srcZip, dstZip, dir, zipDir string) error {
+
+	zr, err := zip.OpenReader(srcZip)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	f, err := os.Create(dstZip)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+
+	for _, zf := range zr.File {
+		if err = zw.Copy(zf); err != nil {
+			return err
+		}
+	}
+
+	dLst, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, d := range dLst {
+		if d.IsDir() {
+			continue
+		}
+		if err = appendZipFile(zw, filepath.Join(dir, d.Name()), zipDir+"/"+d.Name()); err != nil {
+			return err
+		}
+	}
+
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// append file into zip archive
+func appendZipFile(zw *zip.Writer, srcPath, name string) error {
+
+	fi, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	hdr, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	hdr.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if _, err = io.Copy(w, src); err != nil {
+		return errors.New("failed to append into zip: " + name + ": " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// bookmark is a named view of output table: model, run, table and view specification, e.g. filters and pivot layout.
+type bookmark struct {
+	Id             string          // bookmark id, short hex string, e.g.: 3fa0b9e27c
+	Name           string          // bookmark name
+	ModelName      string          // model name
+	ModelDigest    string          // model digest
+	RunDigest      string          // model run digest
+	RunName        string          // model run name
+	Table          string          // output table name
+	View           json.RawMessage // table view: filters, pivot layout and other UI settings, stored as is
+	CreateDateTime string          // bookmark created date-time
+}
+
+// bookmark request: model, run and table can be specified by name
+type bookmarkRequest struct {
+	Name  string          // bookmark name
+	Model string          // model digest or name
+	Run   string          // model run digest, stamp or name
+	Table string          // output table name
+	View  json.RawMessage // table view: filters, pivot layout and other UI settings
+}
+
+// size of bookmark id in bytes, id is a hex string of that bytes
+const bookmarkIdSize = 5
+
+// return directory to store bookmarks: home/bookmark
+func bookmarkDir() string {
+	return filepath.Join(theCfg.homeDir, "bookmark")
+}
+
+// return true if bookmark id is valid: hex string of bookmarkIdSize bytes
+func isBookmarkId(id string) bool {
+	if len(id) != 2*bookmarkIdSize {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// bookmarkPostHandler save named view of output table and return bookmark with short shareable id:
+// POST /api/bookmark
+// Json body is: model digest or name, run digest or stamp or name, output table name and view specification.
+// Model run must be completed and output table must exist in the model.
+// Bookmark is saved into home/bookmark/id.json file and it can be retrieved by GET /api/bookmark/:id
+func bookmarkPostHandler(w http.ResponseWriter, r *http.Request) {
+
+	if !theCfg.isHome {
+		http.Error(w, "Forbidden: bookmarks disabled on the server", http.StatusForbidden)
+		return
+	}
+
+	var bq bookmarkRequest
+	if !jsonRequestDecode(w, r, true, &bq) {
+		return // error at json decode, response done with http error
+	}
+	if bq.Model == "" || bq.Run == "" || bq.Table == "" {
+		http.Error(w, "Invalid (empty) model, run or table name", http.StatusBadRequest)
+		return
+	}
+
+	// find model, completed model run and output table
+	meta, err := theCatalog.ModelMetaByDigestOrName(bq.Model)
+	if err != nil || meta == nil {
+		http.Error(w, "Error: model not found "+bq.Model, http.StatusNotFound)
+		return
+	}
+	runRow, ok := theCatalog.CompletedRunByDigestOrStampOrName(meta.Model.Digest, bq.Run)
+	if !ok || runRow == nil {
+		http.Error(w, "Error: model run not found or not completed "+bq.Run, http.StatusNotFound)
+		return
+	}
+	if _, ok = meta.OutTableByName(bq.Table); !ok {
+		http.Error(w, "Error: output table not found "+bq.Table, http.StatusNotFound)
+		return
+	}
+
+	bm := bookmark{
+		Name:           bq.Name,
+		ModelName:      meta.Model.Name,
+		ModelDigest:    meta.Model.Digest,
+		RunDigest:      runRow.RunDigest,
+		RunName:        runRow.Name,
+		Table:          bq.Table,
+		View:           bq.View,
+		CreateDateTime: helper.MakeDateTime(time.Now()),
+	}
+
+	// make unique bookmark id and save bookmark into home/bookmark/id.json
+	if err = os.MkdirAll(bookmarkDir(), 0750); err != nil {
+		omppLog.Log("Error: unable to create bookmark directory ", bookmarkDir(), err)
+		http.Error(w, "Error: unable to save bookmark", http.StatusInternalServerError)
+		return
+	}
+
+	for n := 0; n < 8; n++ {
+
+		bt := make([]byte, bookmarkIdSize)
+		if _, err = rand.Read(bt); err != nil {
+			break
+		}
+		bm.Id = hex.EncodeToString(bt)
+
+		var f *os.File
+		f, err = os.OpenFile(filepath.Join(bookmarkDir(), bm.Id+".json"), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+		if os.IsExist(err) {
+			continue // id already used, try next id
+		}
+		if err != nil {
+			break
+		}
+		err = json.NewEncoder(f).Encode(&bm)
+		if e := f.Close(); err == nil {
+			err = e
+		}
+		break
+	}
+	if err != nil {
+		omppLog.Log("Error: unable to save bookmark ", bm.Id, err)
+		http.Error(w, "Error: unable to save bookmark", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Location", "/api/bookmark/"+bm.Id)
+	jsonResponse(w, r, &bm)
+}
+
+// bookmarkGetHandler return bookmark by id:
+// GET /api/bookmark/:id
+func bookmarkGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	if !theCfg.isHome {
+		http.Error(w, "Forbidden: bookmarks disabled on the server", http.StatusForbidden)
+		return
+	}
+
+	id := getRequestParam(r, "id")
+	if !isBookmarkId(id) {
+		http.Error(w, "Error: invalid bookmark id "+id, http.StatusBadRequest)
+		return
+	}
+
+	bt, err := os.ReadFile(filepath.Join(bookmarkDir(), id+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, "Error: bookmark not found "+id, http.StatusNotFound)
+			return
+		}
+		omppLog.Log("Error: unable to read bookmark ", id, err)
+		http.Error(w, "Error: unable to read bookmark "+id, http.StatusInternalServerError)
+		return
+	}
+	jsonResponseBytes(w, r, bt)
+}
+
+// bookmarkListGetHandler return list of all bookmarks, sorted by create date-time:
+// GET /api/bookmark-list
+// If there are no bookmarks then response is empty [] json array.
+func bookmarkListGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	if !theCfg.isHome {
+		http.Error(w, "Forbidden: bookmarks disabled on the server", http.StatusForbidden)
+		return
+	}
+
+	bmLst := []bookmark{}
+
+	pLst, err := filepath.Glob(filepath.Join(bookmarkDir(), "*.json"))
+	if err != nil {
+		omppLog.Log("Error: unable to list bookmarks ", err)
+		http.Error(w, "Error: unable to list bookmarks", http.StatusInternalServerError)
+		return
+	}
+	for _, p := range pLst {
+
+		if !isBookmarkId(strings.TrimSuffix(filepath.Base(p), ".json")) {
+			continue // skip files which are not bookmarks
+		}
+		bt, err := os.ReadFile(p)
+		if err != nil {
+			omppLog.Log("Error: unable to read bookmark ", p, err)
+			continue
+		}
+		var bm bookmark
+		if err = json.Unmarshal(bt, &bm); err != nil {
+			omppLog.Log("Error: invalid bookmark ", p, err)
+			continue
+		}
+		bmLst = append(bmLst, bm)
+	}
+	sort.SliceStable(bmLst, func(i, j int) bool { return bmLst[i].CreateDateTime < bmLst[j].CreateDateTime })
+
+	jsonResponse(w, r, bmLst)
+}
+
+// bookmarkDeleteHandler delete bookmark by id:
+// DELETE /api/bookmark/:id
+// If bookmark not exists then it is not an error.
+func bookmarkDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	if !theCfg.isHome {
+		http.Error(w, "Forbidden: bookmarks disabled on the server", http.StatusForbidden)
+		return
+	}
+
+	id := getRequestParam(r, "id")
+	if !isBookmarkId(id) {
+		http.Error(w, "Error: invalid bookmark id "+id, http.StatusBadRequest)
+		return
+	}
+
+	err := os.Remove(filepath.Join(bookmarkDir(), id+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		omppLog.Log("Error: unable to delete bookmark ", id, err)
+		http.Error(w, "Error: unable to delete bookmark "+id, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/bookmark/"+id)
+	w.Header().Set("Content-Type", "text/plain")
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/openmpp/go/ompp/db"
+)
+
+// CalcCheckResult is a result of output table calculation expression validation.
+type CalcCheckResult struct {
+	Calculate string        // source expression
+	IsAggr    bool          // if true then it is accumulators aggregation else expressions calculation
+	IsOk      bool          // if true then expression is valid
+	Error     *db.ExprError // expression error, including error position in source expression, if known
+	Sql       string        // if requested and expression is valid then sql query, using zero run id
+}
+
+// tableCalcCheckHandler validate output table calculation expressions without reading any output table values:
+//
+//	POST /api/model/:model/table/:name/calc-check
+//
+// Json array of calculations is posted, e.g.: [{"Calculate": "Expr0[variant] - Expr0[base]"}, {"Calculate": "OM_AVG(acc0)", "IsAggr": true}]
+// Response is json array of validation results in the same order.
+// If expression is invalid then Error contains error message, zero based error position, offending fragment and expected symbols.
+// If error position is unknown then Error.Pos is -1.
+func tableCalcCheckHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")  // model digest-or-name
+	name := getRequestParam(r, "name") // output table name
+
+	var calcLt []db.CalculateTableLayout
+	if !jsonRequestDecode(w, r, true, &calcLt) {
+		return // error at json decode, response done with http error
+	}
+
+	// find model metadata in catalog
+	m, err := theCatalog.ModelMetaByDigestOrName(dn)
+	if err != nil || m == nil || m.Model.Digest == "" {
+		http.Error(w, "Model digest or name not found"+": "+dn, http.StatusBadRequest)
+		return
+	}
+	if _, ok := m.OutTableByName(name); !ok {
+		http.Error(w, "Model output table not found: "+dn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	jsonResponse(w, r, tableCalcCheck(m, name, calcLt, false))
+}
+
+// tableCalcValidateHandler validate output table calculation expressions and optionally return sql queries,
+// it does not read any output table values:
+//
+//	POST /api/model/:model/table/:name/calc/validate
+//
+// Json is posted with array of calculations and optional IsSql flag, e.g.:
+//
+//	{"Calculation": [{"Calculate": "Expr0[variant] - Expr0[base]"}, {"Calculate": "OM_AVG(acc0)", "IsAggr": true}], "IsSql": true}
+//
+// Response is json array of validation results in the same order, same as calc-check response.
+// If IsSql is true then result of valid expression contains generated sql query where zero run id is used.
+func tableCalcValidateHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")  // model digest-or-name
+	name := getRequestParam(r, "name") // output table name
+
+	var req struct {
+		Calculation []db.CalculateTableLayout // calculation expressions
+		IsSql       bool                      // if true then return sql query for each valid expression
+	}
+	if !jsonRequestDecode(w, r, true, &req) {
+		return // error at json decode, response done with http error
+	}
+
+	// find model metadata in catalog
+	m, err := theCatalog.ModelMetaByDigestOrName(dn)
+	if err != nil || m == nil || m.Model.Digest == "" {
+		http.Error(w, "Model digest or name not found"+": "+dn, http.StatusBadRequest)
+		return
+	}
+	if _, ok := m.OutTableByName(name); !ok {
+		http.Error(w, "Model output table not found: "+dn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	jsonResponse(w, r, tableCalcCheck(m, name, req.Calculation, req.IsSql))
+}
+
+// validate each output table calculation expression and return validation results, including sql query if isSql is true
+func tableCalcCheck(m *db.ModelMeta, name string, calcLt []db.CalculateTableLayout, isSql bool) []CalcCheckResult {
+
+	rLst := make([]CalcCheckResult, len(calcLt))
+
+	for k := range calcLt {
+
+		rLst[k] = CalcCheckResult{Calculate: calcLt[k].Calculate, IsAggr: calcLt[k].IsAggr, IsOk: true}
+
+		q, e := db.TranslateTableCalculate(m, name, &calcLt[k])
+		if e == nil {
+			if isSql {
+				rLst[k].Sql = q
+			}
+			continue
+		}
+		rLst[k].IsOk = false
+		rLst[k].Error = calcCheckError(e, calcLt[k].Calculate)
+	}
+	return rLst
+}
+
+// microCalcValidateHandler validate microdata aggregation expressions and optionally return sql queries,
+// it does not read any microdata values:
+//
+//	POST /api/model/:model/microdata/:name/calc/validate
+//
+// Json is posted with array of aggregations, group by attributes and optional IsSql flag, e.g.:
+//
+//	{"Calculation": [{"Calculate": "OM_AVG(Income)"}, {"Calculate": "OM_AVG(Income[variant] - Income[base])"}], "GroupBy": ["AgeGroup"], "IsSql": true}
+//
+// Model run is not known and expressions validated against all entity attributes.
+// Response is json array of validation results in the same order.
+// If IsSql is true then result of valid expression contains generated sql query where zero run id is used.
+func microCalcValidateHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")  // model digest-or-name
+	name := getRequestParam(r, "name") // entity name
+
+	var req struct {
+		Calculation []db.CalculateLayout // aggregation expressions
+		GroupBy     []string             // attributes to group by
+		IsSql       bool                 // if true then return sql query for each valid expression
+	}
+	if !jsonRequestDecode(w, r, true, &req) {
+		return // error at json decode, response done with http error
+	}
+
+	// find model metadata in catalog
+	m, err := theCatalog.ModelMetaByDigestOrName(dn)
+	if err != nil || m == nil || m.Model.Digest == "" {
+		http.Error(w, "Model digest or name not found"+": "+dn, http.StatusBadRequest)
+		return
+	}
+	if _, ok := m.EntityByName(name); !ok {
+		http.Error(w, "Model entity not found: "+dn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	// validate each expression
+	rLst := make([]CalcCheckResult, len(req.Calculation))
+
+	for k := range req.Calculation {
+
+		rLst[k] = CalcCheckResult{Calculate: req.Calculation[k].Calculate, IsAggr: true, IsOk: true}
+
+		q, e := db.TranslateMicroCalculate(m, name, &req.Calculation[k], req.GroupBy)
+		if e == nil {
+			if req.IsSql {
+				rLst[k].Sql = q
+			}
+			continue
+		}
+		rLst[k].IsOk = false
+		rLst[k].Error = calcCheckError(e, req.Calculation[k].Calculate)
+	}
+
+	jsonResponse(w, r, rLst)
+}
+
+// return expression error, if error position is unknown then Error.Pos is -1
+func calcCheckError(err error, expr string) *db.ExprError {
+	if ee, ok := db.AsExprError(err); ok {
+		return ee
+	}
+	return &db.ExprError{Msg: err.Error(), Expr: expr, Pos: -1}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// comparePostHandler create or replace named comparison of model runs: base run and variant run.
+// POST /api/compare
+// Json body is: comparison name, model digest or name, base run and variant run digest or stamp or name.
+// Both runs must be completed successfully, comparison is kept in memory until oms restarted.
+// Aligned output table rows can be retrieved by GET /api/compare/:compare/table/:name
+func comparePostHandler(w http.ResponseWriter, r *http.Request) {
+
+	var cq compareRequest
+	if !jsonRequestDecode(w, r, true, &cq) {
+		return // error at json decode, response done with http error
+	}
+
+	cmp, code, err := theCatalog.CreateCompare(&cq)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Location", "/api/compare/"+url.PathEscape(cmp.Name))
+	jsonResponse(w, r, cmp)
+}
+
+// compareGetHandler return comparison by name:
+// GET /api/compare/:compare
+func compareGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	name := getRequestParam(r, "compare")
+
+	cmp, ok := compareByName(name)
+	if !ok {
+		http.Error(w, "Error: comparison not found "+name, http.StatusNotFound)
+		return
+	}
+	jsonResponse(w, r, cmp)
+}
+
+// compareListGetHandler return list of all comparisons, sorted by create date-time:
+// GET /api/compare-list
+// If there are no comparisons then response is empty [] json array.
+func compareListGetHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, compareList())
+}
+
+// compareDeleteHandler delete comparison by name:
+// DELETE /api/compare/:compare
+// If comparison not exists then it is not an error.
+func compareDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	name := getRequestParam(r, "compare")
+
+	deleteCompare(name)
+
+	w.Header().Set("Content-Location", "/api/compare/"+url.PathEscape(name))
+	w.Header().Set("Content-Type", "text/plain")
+}
+
+// compareTablePageGetHandler return a "page" of aligned output table rows of the comparison.
+//
+// Each row is output table expression for the same dimension items: base run value, variant run value,
+// difference: variant - base and percent difference: 100 * (variant - base) / base.
+//
+// GET /api/compare/:compare/table/:name
+// GET /api/compare/:compare/table/:name/start/:start
+// GET /api/compare/:compare/table/:name/start/:start/count/:count
+//
+// Page is part of aligned rows defined by zero-based "start" row number and row count.
+// If row count <= 0 then all rows returned.
+// Enum-based dimension items returned as enum codes.
+func compareTablePageGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	// url or query parameters
+	cn := getRequestParam(r, "compare") // comparison name
+	name := getRequestParam(r, "name")  // output table name
+
+	start, ok := getInt64RequestParam(r, "start", 0)
+	if !ok {
+		http.Error(w, "Invalid value of start row number to read "+name, http.StatusBadRequest)
+		return
+	}
+	count, ok := getInt64RequestParam(r, "count", 0)
+	if !ok {
+		http.Error(w, "Invalid value of max row count to read "+name, http.StatusBadRequest)
+		return
+	}
+
+	cmp, ok := compareByName(cn)
+	if !ok {
+		http.Error(w, "Error: comparison not found "+cn, http.StatusNotFound)
+		return
+	}
+	meta, err := theCatalog.ModelMetaByDigestOrName(cmp.ModelDigest)
+	if err != nil || meta == nil {
+		http.Error(w, "Error: model not found "+cmp.ModelName+" "+cmp.ModelDigest, http.StatusNotFound)
+		return
+	}
+	if _, ok = meta.OutTableByName(name); !ok {
+		http.Error(w, "Error: output table not found "+name, http.StatusNotFound)
+		return
+	}
+
+	// write to response: page of aligned rows
+	jsonSetHeaders(w, r) // start response with set json headers, i.e. content type
+
+	w.Write([]byte{'['}) // start of json output array
+
+	enc := json.NewEncoder(w)
+	cvtWr := jsonCellWriter(w, enc, nil)
+
+	if !theCatalog.ReadCompareTableTo(cmp, name, start, count, cvtWr) {
+		http.Error(w, "Error at comparison output table read "+cn+": "+name, http.StatusBadRequest)
+		return
+	}
+	w.Write([]byte{']'}) // end of json output array
+}
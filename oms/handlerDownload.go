@@ -100,6 +100,7 @@ func modelDownloadPostHandler(w http.ResponseWriter, r *http.Request) {
 	go makeDownload(baseName, cmd, cmdMsg, logPath)
 
 	// report to the client results location
+	logActivity(ActivityEvent{Kind: downloadActivity, ModelDigest: dn, Name: baseName, Msg: "model"})
 	w.Header().Set("Content-Location", "/api/download/model/"+dn+"/"+baseName)
 }
 
@@ -125,6 +126,7 @@ func runDownloadPostHandler(w http.ResponseWriter, r *http.Request) {
 		NoMicrodata       bool
 		Utf8BomIntoCsv    bool
 		IdCsv             bool
+		NoArtifacts       bool
 	}{}
 	if !jsonRequestDecode(w, r, false, &opts) {
 		return // error at json decode, response done with http error
@@ -207,9 +209,14 @@ func runDownloadPostHandler(w http.ResponseWriter, r *http.Request) {
 	// create model run download files on separate thread
 	cmd, cmdMsg := makeRunDownloadCommand(mb, r0.RunId, logPath, opts.NoAccumulatorsCsv, opts.NoMicrodata, opts.Utf8BomIntoCsv, opts.IdCsv)
 
-	go makeDownload(baseName, cmd, cmdMsg, logPath)
+	if opts.NoArtifacts || !theCfg.isHome {
+		go makeDownload(baseName, cmd, cmdMsg, logPath)
+	} else {
+		go makeRunDownload(baseName, r0.RunDigest, cmd, cmdMsg, logPath)
+	}
 
 	// report to the client results location
+	logActivity(ActivityEvent{Kind: downloadActivity, ModelDigest: dn, Name: baseName, Msg: "run: " + rdsn})
 	w.Header().Set("Content-Location", "/api/download/model/"+dn+"/run/"+rdsn+"/"+baseName)
 }
 
@@ -306,5 +313,6 @@ func worksetDownloadPostHandler(w http.ResponseWriter, r *http.Request) {
 	go makeDownload(baseName, cmd, cmdMsg, logPath)
 
 	// report to the client results location
+	logActivity(ActivityEvent{Kind: downloadActivity, ModelDigest: dn, Name: baseName, Msg: "workset: " + wsn})
 	w.Header().Set("Content-Location", "/api/download/model/"+dn+"/workset/"+wsn+"/"+baseName)
 }
@@ -6,6 +6,8 @@ package main
 import (
 	"net/http"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/openmpp/go/ompp"
 	"github.com/openmpp/go/ompp/db"
@@ -92,6 +94,7 @@ func modelTextListHandler(w http.ResponseWriter, r *http.Request) {
 //	GET /api/model/:model
 //
 // If multiple models with same name exist only one is returned.
+// Optional ?fields=Model.Name,Param.Name url parameter can be used to select only those fields of response.
 func modelMetaHandler(w http.ResponseWriter, r *http.Request) {
 	doModelMetaHandler(w, r, false)
 }
@@ -101,6 +104,7 @@ func modelMetaHandler(w http.ResponseWriter, r *http.Request) {
 //	GET /api/model/:model/pack
 //
 // If multiple models with same name exist only one is returned.
+// Optional ?fields=Model.Name,Param.Name url parameter can be used to select only those fields of response.
 func modelMetaPackHandler(w http.ResponseWriter, r *http.Request) {
 	doModelMetaHandler(w, r, true)
 }
@@ -111,6 +115,7 @@ func modelMetaPackHandler(w http.ResponseWriter, r *http.Request) {
 func doModelMetaHandler(w http.ResponseWriter, r *http.Request, isPack bool) {
 
 	dn := getRequestParam(r, "model")
+	fields := getFieldsRequestParam(r)
 
 	// if model digest-or-name is empty then return empty results
 	if dn == "" {
@@ -126,16 +131,19 @@ func doModelMetaHandler(w http.ResponseWriter, r *http.Request, isPack bool) {
 		http.Error(w, "Model digest or name not found"+": "+dn, http.StatusBadRequest)
 		return
 	}
+	if isModelNotModified(w, r, m.Model.Digest) {
+		return // client already has the same response
+	}
 
 	// ranges are stored as "packed" [min, max] enum id's
 	if isPack {
-		jsonResponse(w, r, m) // response with "packed" metatada
+		jsonFieldsResponse(w, r, m, fields) // response with "packed" metatada
 		return
 	}
 	// else: "unpack" range types during json marshal
 	mcp := ompp.CopyModelMetaToUnpack(m)
 
-	jsonResponse(w, r, mcp)
+	jsonFieldsResponse(w, r, mcp, fields)
 }
 
 // return language-specific model metadata:
@@ -144,9 +152,11 @@ func doModelMetaHandler(w http.ResponseWriter, r *http.Request, isPack bool) {
 //
 // Model digest-or-name must specified, if multiple models with same name exist only one is returned.
 // Text rows returned in all languages.
+// Optional ?fields=Model.Name,ParamTxt.Param.Name url parameter can be used to select only those fields of response.
 func modelAllTextHandler(w http.ResponseWriter, r *http.Request) {
 
 	dn := getRequestParam(r, "model")
+	fields := getFieldsRequestParam(r)
 
 	// find model metadata in catalog
 	m, err := theCatalog.ModelMetaByDigestOrName(dn)
@@ -163,6 +173,9 @@ func modelAllTextHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if isModelNotModified(w, r, m.Model.Digest) {
+		return // client already has the same response
+	}
 
 	// "unpack" range types during json marshal
 	// copy of ModelMeta, using alias for TypeMeta to do a special range type marshaling
@@ -175,7 +188,7 @@ func modelAllTextHandler(w http.ResponseWriter, r *http.Request) {
 		ModelMetaUnpack: mcp,
 		ModelTxtMeta:    t,
 	}
-	jsonResponse(w, r, mf)
+	jsonFieldsResponse(w, r, mf, fields)
 }
 
 // return list of model langauages:
@@ -187,6 +200,9 @@ func langListHandler(w http.ResponseWriter, r *http.Request) {
 
 	dn := getRequestParam(r, "model")
 
+	if isModelNotModified(w, r, dn) {
+		return // client already has the same response
+	}
 	m, _ := theCatalog.LangListByDigestOrName(dn)
 	jsonResponse(w, r, m)
 }
@@ -203,6 +219,9 @@ func wordListHandler(w http.ResponseWriter, r *http.Request) {
 	dn := getRequestParam(r, "model")
 	rqLangTags := getRequestLang(r, "lang") // get optional language argument and languages accepted by browser
 
+	if isModelNotModified(w, r, dn) {
+		return // client already has the same response
+	}
 	wl, _ := theCatalog.WordListByDigestOrName(dn, rqLangTags)
 	jsonResponse(w, r, wl)
 }
@@ -236,13 +255,32 @@ func modelProfileListHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // runListHandler return list of run_lst db rows by model digest-or-name:
-// GET /api/model/:model/run-list
+//
+//	GET /api/model/:model/run-list
+//	GET /api/model/:model/run-list?offset=100&size=50&order=create&desc=true
+//	GET /api/model/:model/run-list?after-id=1234&size=50
+//
 // If multiple models with same name exist only one is returned.
+// Optional query parameters to return page of model runs, by default all runs returned sorted by run id:
+//
+//	offset:   zero-based offset of the first run in the page
+//	size:     page size, if size <= 0 then all runs returned
+//	order:    sort order: id, create, update, name or status
+//	desc:     if true then descending sort order
+//	after-id: return runs after that run id (keyset paging), it can be used only with run id sort order
+//
+// Total count of model runs returned in X-Total-Count header and last page flag in X-Last-Page header.
 func runListHandler(w http.ResponseWriter, r *http.Request) {
 
 	dn := getRequestParam(r, "model")
 
-	rpl, _ := theCatalog.RunPubList(dn)
+	layout, ok := getRunListLayout(w, r)
+	if !ok {
+		return // error at query parameters
+	}
+
+	rpl, lt, nTotal, _ := theCatalog.RunPubList(dn, layout)
+	setRunListHeaders(w, lt, nTotal)
 	jsonResponse(w, r, rpl)
 }
 
@@ -250,18 +288,73 @@ func runListHandler(w http.ResponseWriter, r *http.Request) {
 //
 //	GET /api/model/:model/run-list/text
 //	GET /api/model/:model/run-list/text/lang/:lang
+//	GET /api/model/:model/run-list/text?offset=100&size=50&order=name
 //
 // If multiple models with same name exist only one is returned.
 // If optional lang specified then result in that language else in browser language.
+// Optional page and sort order query parameters are the same as for run-list: offset, size, order, desc and after-id.
+// Total count of model runs returned in X-Total-Count header and last page flag in X-Last-Page header.
 func runListTextHandler(w http.ResponseWriter, r *http.Request) {
 
 	dn := getRequestParam(r, "model")
 	rqLangTags := getRequestLang(r, "lang") // get optional language argument and languages accepted by browser
 
-	rpl, _ := theCatalog.RunListText(dn, rqLangTags)
+	layout, ok := getRunListLayout(w, r)
+	if !ok {
+		return // error at query parameters
+	}
+
+	rpl, lt, nTotal, _ := theCatalog.RunListText(dn, rqLangTags, layout)
+	setRunListHeaders(w, lt, nTotal)
 	jsonResponse(w, r, rpl)
 }
 
+// get run list page and sort order from query parameters: offset, size, order, desc and after-id.
+// On error it writes http error response and return false.
+func getRunListLayout(w http.ResponseWriter, r *http.Request) (db.RunListLayout, bool) {
+
+	layout := db.RunListLayout{}
+	ok := false
+
+	if layout.Offset, ok = getInt64RequestParam(r, "offset", 0); !ok || layout.Offset < 0 {
+		http.Error(w, "Invalid value of run list page offset", http.StatusBadRequest)
+		return layout, false
+	}
+	if layout.Size, ok = getInt64RequestParam(r, "size", 0); !ok {
+		http.Error(w, "Invalid value of run list page size", http.StatusBadRequest)
+		return layout, false
+	}
+	if layout.AfterId, ok = getIntRequestParam(r, "after-id", 0); !ok || layout.AfterId < 0 {
+		http.Error(w, "Invalid value of run id to select runs after", http.StatusBadRequest)
+		return layout, false
+	}
+	if layout.IsDesc, ok = getBoolRequestParam(r, "desc"); !ok {
+		http.Error(w, "Invalid value of run list descending order flag", http.StatusBadRequest)
+		return layout, false
+	}
+
+	layout.OrderBy = strings.ToLower(getRequestParam(r, "order"))
+	switch layout.OrderBy {
+	case "", "id", "create", "update", "name", "status":
+	default:
+		http.Error(w, "Invalid run list sort order: "+layout.OrderBy, http.StatusBadRequest)
+		return layout, false
+	}
+	if layout.AfterId > 0 && layout.OrderBy != "" && layout.OrderBy != "id" {
+		http.Error(w, "Run list paging after run id can be used only with run id sort order", http.StatusBadRequest)
+		return layout, false
+	}
+	return layout, true
+}
+
+// set run list response headers: total count of model runs and last page flag
+func setRunListHeaders(w http.ResponseWriter, lt *db.ReadPageLayout, nTotal int) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(nTotal))
+	if lt != nil {
+		w.Header().Set("X-Last-Page", strconv.FormatBool(lt.IsLastPage))
+	}
+}
+
 // return run_lst db row by model digest-or-name and run digest-or-stamp-or-name:
 //
 //	GET /api/model/:model/run/:run/status
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 
@@ -17,6 +18,7 @@ import (
 // GET /api/model/:model/text/lang/:lang
 // Model digest-or-name must specified, if multiple models with same name exist only one is returned.
 // If optional lang specified then result in that language else in browser language or model default.
+// Optional ?fields=Model.Name,ParamTxt.Param.Name url parameter can be used to select only those fields of response.
 func modelTextHandler(w http.ResponseWriter, r *http.Request) {
 	doModelTextHandler(w, r, false)
 }
@@ -26,10 +28,71 @@ func modelTextHandler(w http.ResponseWriter, r *http.Request) {
 // GET /api/model/:model/pack/text/lang/:lang
 // Model digest-or-name must specified, if multiple models with same name exist only one is returned.
 // If optional lang specified then result in that language else in browser language or model default.
+// Optional ?fields=Model.Name,ParamTxt.Param.Name url parameter can be used to select only those fields of response.
 func modelTextPackHandler(w http.ResponseWriter, r *http.Request) {
 	doModelTextHandler(w, r, true)
 }
 
+// Get parameters groups tree, including language-specific labels:
+// GET /api/model/:model/param-tree
+// GET /api/model/:model/param-tree/lang/:lang
+// Model digest-or-name must specified, if multiple models with same name exist only one is returned.
+// If optional lang specified then result in that language else in browser language or model default.
+func paramTreeHandler(w http.ResponseWriter, r *http.Request) {
+	doGroupTreeHandler(w, r, true)
+}
+
+// Get output tables groups tree, including language-specific labels:
+// GET /api/model/:model/table-tree
+// GET /api/model/:model/table-tree/lang/:lang
+// Model digest-or-name must specified, if multiple models with same name exist only one is returned.
+// If optional lang specified then result in that language else in browser language or model default.
+func tableTreeHandler(w http.ResponseWriter, r *http.Request) {
+	doGroupTreeHandler(w, r, false)
+}
+
+// Get parameters or output tables groups tree, including language-specific labels.
+// Tree contains groups, child groups and parameters (or output tables) in group order, hidden flags are resolved.
+// Top level of the tree is groups which are not children of other groups
+// and parameters (or output tables) which are not included in any group.
+func doGroupTreeHandler(w http.ResponseWriter, r *http.Request, isParam bool) {
+
+	dn := getRequestParam(r, "model")
+	rqLangTags := getRequestLang(r, "lang") // get optional language argument and languages accepted by browser
+
+	// if model digest-or-name is empty then return empty results
+	if dn == "" {
+		omppLog.Log("Error: invalid (empty) model digest and name")
+		http.Error(w, "Invalid (empty) model digest and name", http.StatusBadRequest)
+		return
+	}
+
+	// find model in catalog
+	mdRow, ok := theCatalog.ModelDicByDigestOrName(dn)
+	if !ok {
+		omppLog.Log("Error: model digest or name not found: ", dn)
+		http.Error(w, "Model digest or name not found"+": "+dn, http.StatusBadRequest)
+		return
+	}
+
+	// if language-specific model metadata not loaded then read it from database
+	if ok := theCatalog.loadModelText(mdRow.Digest); !ok {
+		omppLog.Log("Error: Model text metadata not found: ", dn)
+		http.Error(w, "Model text metadata not found"+": "+dn, http.StatusBadRequest)
+		return
+	}
+	if isModelNotModified(w, r, mdRow.Digest) {
+		return // client already has the same response
+	}
+
+	gt, ok := theCatalog.GroupTree(mdRow.Digest, isParam, rqLangTags)
+	if !ok {
+		http.Error(w, "Model not found"+": "+mdRow.Name+" "+dn, http.StatusBadRequest)
+		return
+	}
+	jsonResponse(w, r, gt)
+}
+
 // Get model metadata, including language-specific text.
 // If isPack is true then return "packed" range types as [min, max] enum id's, not as full enum array.
 // Model digest-or-name must specified, if multiple models with same name exist only one is returned.
@@ -85,6 +148,9 @@ func doModelTextHandler(w http.ResponseWriter, r *http.Request, isPack bool) {
 		http.Error(w, "Model text metadata not found"+": "+dn, http.StatusBadRequest)
 		return
 	}
+	if isModelNotModified(w, r, mdRow.Digest) {
+		return // client already has the same response
+	}
 
 	// match preferred languages and model languages
 	lc := theCatalog.languageTagMatch(mdRow.Digest, rqLangTags)
@@ -102,6 +168,18 @@ func doModelTextHandler(w http.ResponseWriter, r *http.Request, isPack bool) {
 	}
 
 	// write json response
+	// if list of fields specified then encode into buffer and write only selected fields
+	if fields := getFieldsRequestParam(r); len(fields) > 0 {
+
+		var bt bytes.Buffer
+		if err := me.DoEncode(isPack, json.NewEncoder(&bt)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		jsonFieldsResponseBytes(w, r, bt.Bytes(), fields)
+		return
+	}
+
 	jsonSetHeaders(w, r)
 
 	err := me.DoEncode(isPack, json.NewEncoder(w))
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// timeout of model database ping in health check
+const healthDbPingTimeout = 5 * time.Second
+
+// HealthCheck is a result of service dependency probe
+type HealthCheck struct {
+	Name string // dependency name, e.g.: models, model name, job dir
+	Item string // dependency item: directory path or model digest
+	IsOk bool   // if true then dependency is healthy
+	Msg  string // error message if dependency is not healthy
+}
+
+// model database connection to check
+type dbHealthProbe struct {
+	name   string  // model name
+	digest string  // model digest
+	dbConn *sql.DB // model database connection
+}
+
+// healthHandler return service health status and status of each dependency:
+// GET /api/health
+// Response status is 200 OK if all checks passed, else it is 503 Service Unavailable.
+// It is different from readiness: if instance is unhealthy then it should be restarted,
+// e.g.: models directory is not readable, model database can not be opened or job directory is not writable.
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+
+	hs := struct {
+		IsHealthy bool          // if true then all checks passed
+		Check     []HealthCheck // status of each dependency
+	}{
+		IsHealthy: true,
+		Check:     []HealthCheck{},
+	}
+
+	add := func(name, item string, err error) {
+		c := HealthCheck{Name: name, Item: item, IsOk: err == nil}
+		if err != nil {
+			c.Msg = err.Error()
+			hs.IsHealthy = false
+		}
+		hs.Check = append(hs.Check, c)
+	}
+
+	// models directory must be readable
+	mDir, _ := theCatalog.getModelDir()
+	_, err := os.ReadDir(mDir)
+	add("models", mDir, err)
+
+	// each model database must be available
+	for _, p := range theCatalog.dbHealthProbes() {
+
+		ctx, cancel := context.WithTimeout(r.Context(), healthDbPingTimeout)
+		err = p.dbConn.PingContext(ctx)
+		cancel()
+
+		add(p.name, p.digest, err)
+	}
+
+	// job control directories must be writable or job control database must be available
+	if js, ok := theJobStore.(*dbJobStore); ok && theCfg.isJobControl {
+		add("job database", jobDbTable, js.dbConn.Ping())
+	}
+	if _, ok := theJobStore.(fileJobStore); ok && theCfg.isJobControl {
+		for _, d := range []string{"active", "state", "queue", "history"} {
+			p := filepath.Join(theCfg.jobDir, d)
+			add("job "+d, p, dirWritable(p))
+		}
+	}
+
+	// storage use must be below the limit
+	if theCfg.isDiskUse {
+		duState, _ := theRunCatalog.getDiskUse()
+
+		err = nil
+		if duState.IsOver {
+			err = errors.New("storage use limit reached: " +
+				strconv.FormatInt(duState.TotalSize, 10) + " of " + strconv.FormatInt(duState.Limit, 10) + " bytes, all instances: " +
+				strconv.FormatInt(duState.AllSize, 10) + " of " + strconv.FormatInt(duState.AllLimit, 10) + " bytes")
+		}
+		add("disk use", theCfg.rootDir, err)
+	}
+
+	if !hs.IsHealthy {
+		jsonSetHeaders(w, r)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	jsonResponse(w, r, hs)
+}
+
+// return model name, digest and database connection of all models in catalog
+func (mc *ModelCatalog) dbHealthProbes() []dbHealthProbe {
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	ps := make([]dbHealthProbe, 0, len(mc.modelLst))
+	for k := range mc.modelLst {
+		if mc.modelLst[k].dbConn == nil {
+			continue
+		}
+		ps = append(ps, dbHealthProbe{
+			name:   mc.modelLst[k].meta.Model.Name,
+			digest: mc.modelLst[k].meta.Model.Digest,
+			dbConn: mc.modelLst[k].dbConn,
+		})
+	}
+	return ps
+}
+
+// check if directory is writable: create and remove temporary file
+func dirWritable(dirPath string) error {
+
+	f, err := os.CreateTemp(dirPath, "health-*.tmp")
+	if err != nil {
+		return err
+	}
+	fp := f.Name()
+	f.Close()
+	return os.Remove(fp)
+}
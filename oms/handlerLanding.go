@@ -0,0 +1,62 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// modelLandingHandler return model landing page content from model directory, e.g.: models/bin/doc/README.md
+//
+//	GET /api/model/:model/landing
+//	GET /api/model/:model/landing/lang/:lang
+//	GET /api/model/:model/landing?html=true
+//
+// Model can be identified by digest or by model name.
+// Landing file is language-specific, e.g.: doc/README.FR.md, if there is no such file then default doc/README.md returned.
+// Language is matched using optional lang parameter, language cookie and browser Accept-Language header.
+// If optional html parameter is true then markdown rendered as sanitized HTML fragment else returned as text/markdown.
+func modelLandingHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	rqLangTags := getRequestLang(r, "lang") // get optional language argument and languages accepted by browser
+
+	isHtml, ok := getBoolRequestParam(r, "html")
+	if !ok {
+		http.Error(w, "Invalid value of html parameter, expected true or false", http.StatusBadRequest)
+		return
+	}
+
+	fp, ok := theCatalog.LandingFileByDigestOrName(dn, rqLangTags)
+	if !ok {
+		http.Error(w, "Model landing page not found: "+dn, http.StatusNotFound)
+		return
+	}
+
+	bt, modTs, err := landingContent(fp, isHtml)
+	if err != nil {
+		omppLog.Log("Error at reading model landing file: ", fp, ": ", err.Error())
+		http.Error(w, "Model landing page not found: "+dn, http.StatusNotFound)
+		return
+	}
+
+	// markdown or html content, response is cached by client until file modified
+	name := filepath.Base(fp)
+	if isHtml {
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + ".html"
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src 'self'")
+	} else {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	}
+	w.Header().Set("Cache-Control", theCfg.cacheControl)
+	w.Header().Set("Vary", "Accept-Language, Cookie")
+
+	http.ServeContent(w, r, name, modTs, bytes.NewReader(bt))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// runLineageHandler return lineage graph of model run:
+//
+//	GET /api/model/:model/run/:run/lineage
+//
+// Graph nodes are model runs and worksets (input scenarios), graph edges are
+// "base-run" if workset based on model run and "input-set" if model run used workset as input.
+// Model digest-or-name and run digest-or-stamp-or-name are used to find starting node of the graph.
+func runLineageHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	rdsn := getRequestParam(r, "run")
+
+	lg, ok := theCatalog.Lineage(dn, rdsn, true)
+	if !ok {
+		http.Error(w, "Model run lineage not found: "+dn+": "+rdsn, http.StatusBadRequest)
+		return
+	}
+	jsonResponse(w, r, lg)
+}
+
+// worksetLineageHandler return lineage graph of workset (set of input parameters):
+//
+//	GET /api/model/:model/workset/:set/lineage
+//
+// Graph nodes are model runs and worksets (input scenarios), graph edges are
+// "base-run" if workset based on model run and "input-set" if model run used workset as input.
+// Model digest-or-name and workset name are used to find starting node of the graph.
+func worksetLineageHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	wsn := getRequestParam(r, "set")
+
+	lg, ok := theCatalog.Lineage(dn, wsn, false)
+	if !ok {
+		http.Error(w, "Workset lineage not found: "+dn+": "+wsn, http.StatusBadRequest)
+		return
+	}
+	jsonResponse(w, r, lg)
+}
+
+// Lineage return lineage graph of model run or workset by model digest-or-name and run digest-or-stamp-or-name or workset name.
+func (mc *ModelCatalog) Lineage(dn, src string, isRun bool) (*db.Lineage, bool) {
+
+	// if model digest-or-name or run or workset name is empty then return empty results
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return nil, false
+	}
+	if src == "" {
+		omppLog.Log("Warning: invalid (empty) run digest or stamp or name or workset name")
+		return nil, false
+	}
+	meta, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return nil, false // return empty result: model not found or error
+	}
+
+	// find starting node: model run or workset
+	id := 0
+	if isRun {
+		r, err := db.GetRunByDigestStampName(dbConn, meta.Model.ModelId, src)
+		if err != nil {
+			omppLog.Log("Error at get run status: ", dn, ": ", src, ": ", err.Error())
+			return nil, false // return empty result: run select error
+		}
+		if r == nil {
+			omppLog.Log("Warning: run not found: ", dn, ": ", src)
+			return nil, false // return empty result: run_lst row not found
+		}
+		id = r.RunId
+	} else {
+		ws, err := db.GetWorksetByName(dbConn, meta.Model.ModelId, src)
+		if err != nil {
+			omppLog.Log("Error at get workset status: ", dn, ": ", src, ": ", err.Error())
+			return nil, false // return empty result: workset select error
+		}
+		if ws == nil {
+			omppLog.Log("Warning: workset not found: ", dn, ": ", src)
+			return nil, false // return empty result: workset_lst row not found
+		}
+		id = ws.SetId
+	}
+
+	lg, err := db.GetLineage(dbConn, meta.Model.ModelId, isRun, id)
+	if err != nil {
+		omppLog.Log("Error at get lineage: ", dn, ": ", src, ": ", err.Error())
+		return nil, false
+	}
+	lg.ModelDigest = meta.Model.Digest
+
+	return lg, true
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// MicrodataAggregate is a request to aggregate model run microdata, same as dbget -do micro-compare.
+type MicrodataAggregate struct {
+	GroupBy     []string // group by attributes, e.g.: AgeGroup, Sex
+	Calculation []string // aggregation expressions, e.g.: OM_AVG(Income), OM_AVG(Income[variant] - Income[base])
+	Name        []string // optional names of aggregation expressions, default: ex_12000, ex_12001,...
+	Variant     []string // optional variant runs: digest, stamp or name, required if aggregation contains [base] and [variant]
+	Format      string   // output format: json (default), csv or csv-bom
+	IsIdCsv     bool     // if true then return enum id's instead of enum codes
+}
+
+// runMicrodataAggregateHandler aggregate microdata of model run entity and optionally compare it with variant model runs.
+//
+//	POST /api/model/:model/run/:run/microdata/:name/aggregate
+//
+// Json is posted to specify group by attributes, aggregation expressions and optional variant runs, see MicrodataAggregate.
+// For example:
+//
+//	{"GroupBy": ["AgeGroup", "Sex"], "Calculation": ["OM_AVG(Income)", "OM_AVG(Income[variant] - Income[base])"], "Variant": ["Run 2"]}
+//
+// Response is json array of aggregated microdata cells or csv if Format is csv or csv-bom.
+// Enum-based microdata attributes returned as enum codes or as enum id's if IsIdCsv is true.
+func runMicrodataAggregateHandler(w http.ResponseWriter, r *http.Request) {
+
+	// url parameters
+	dn := getRequestParam(r, "model")  // model digest-or-name
+	rdsn := getRequestParam(r, "run")  // base run digest-or-stamp-or-name
+	name := getRequestParam(r, "name") // entity name
+
+	// return error if microdata disabled
+	if !theCfg.isMicrodata {
+		http.Error(w, "Error: microdata not allowed: "+dn+" "+rdsn, http.StatusBadRequest)
+		return
+	}
+
+	// decode json request body
+	var ma MicrodataAggregate
+	if !jsonRequestDecode(w, r, true, &ma) {
+		return // error at json decode, response done with http error
+	}
+	isCode := !ma.IsIdCsv
+
+	isCsv := false
+	isBom := false
+	switch ma.Format {
+	case "", "json":
+	case "csv":
+		isCsv = true
+	case "csv-bom":
+		isCsv = true
+		isBom = true
+	default:
+		http.Error(w, "Invalid output format, expected json, csv or csv-bom: "+ma.Format, http.StatusBadRequest)
+		return
+	}
+
+	if len(ma.GroupBy) <= 0 {
+		http.Error(w, "Invalid (empty) microdata group by attributes "+name, http.StatusBadRequest)
+		return
+	}
+
+	// set aggregation expressions and names
+	calcLt := db.CalculateMicroLayout{
+		Calculation: []db.CalculateLayout{},
+		GroupBy:     ma.GroupBy,
+	}
+
+	for j := range ma.Calculation {
+
+		if ma.Calculation[j] == "" {
+			continue
+		}
+		cl := db.CalculateLayout{
+			Calculate: ma.Calculation[j],
+			CalcId:    j + db.CALCULATED_ID_OFFSET,
+			Name:      "ex_" + strconv.Itoa(j+db.CALCULATED_ID_OFFSET),
+		}
+		if j < len(ma.Name) && ma.Name[j] != "" {
+			cl.Name = ma.Name[j]
+		}
+		calcLt.Calculation = append(calcLt.Calculation, cl)
+	}
+	if len(calcLt.Calculation) <= 0 {
+		http.Error(w, "Invalid (empty) microdata aggregation(s) "+name, http.StatusBadRequest)
+		return
+	}
+
+	// get base run id, run variants, entity generation digest and microdata cell converter
+	baseRunId, runIds, genDigest, cvtMicro, err := theCatalog.MicrodataCalcToCsvConverter(dn, isCode, rdsn, ma.Variant, name, &calcLt)
+	if err != nil {
+		omppLog.Log("Failed to create microdata csv converter: ", rdsn, ": ", name, ": ", err.Error())
+		http.Error(w, "Failed to create microdata csv converter: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	// read all microdata aggregation rows
+	microLt := db.ReadMicroLayout{
+		ReadLayout: db.ReadLayout{
+			Name:   name,
+			FromId: baseRunId,
+		},
+		GenDigest: genDigest,
+	}
+
+	if isCsv {
+		doMicrodataAggregateCsv(w, dn, rdsn, name, isCode, isBom, &microLt, &calcLt, runIds, cvtMicro)
+		return
+	}
+
+	// get converter from id's cell into code cell
+	var cvtCell func(interface{}) (interface{}, error)
+	if isCode {
+
+		cvtCell, err = cvtMicro.IdToCodeCell(cvtMicro.ModelDef, name)
+		if err != nil {
+			omppLog.Log("Failed to create microdata cell value converter: ", dn, ": ", name, ": ", err.Error())
+			http.Error(w, "Failed to create microdata cell value converter: "+rdsn+": "+name, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// write to response
+	jsonSetHeaders(w, r) // start response with set json headers, i.e. content type
+
+	w.Write([]byte{'['}) // start of json output array
+
+	enc := json.NewEncoder(w)
+	cvtWr := jsonCellWriter(w, enc, cvtCell)
+
+	_, ok := theCatalog.ReadMicrodataCalculateTo(dn, rdsn, &microLt, &calcLt, runIds, cvtWr)
+	if !ok {
+		http.Error(w, "Error at microdata aggregation read "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+	w.Write([]byte{']'}) // end of json output array
+}
+
+// write microdata aggregation into csv response
+func doMicrodataAggregateCsv(
+	w http.ResponseWriter,
+	dn, rdsn, name string,
+	isCode, isBom bool,
+	microLt *db.ReadMicroLayout,
+	calcLt *db.CalculateMicroLayout,
+	runIds []int,
+	cvtMicro *db.CellMicroCalcConverter,
+) {
+
+	// make csv header
+	hdr, err := cvtMicro.CsvHeader()
+	if err != nil {
+		omppLog.Log("Failed to make microdata csv header: ", dn, ": ", name, ": ", err.Error())
+		http.Error(w, "Failed to create microdata csv converter: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	// create converter from db cell into csv row []string
+	var cvtRow func(interface{}, []string) (bool, error)
+
+	if isCode {
+		cvtRow, err = cvtMicro.ToCsvRow()
+	} else {
+		cvtRow, err = cvtMicro.ToCsvIdRow()
+	}
+	if err != nil {
+		omppLog.Log("Failed to create microdata converter to csv: ", dn, ": ", name, ": ", err.Error())
+		http.Error(w, "Failed to create microdata csv converter: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	// set response headers: Content-Disposition: attachment; filename=name.csv
+	csvSetHeaders(w, name)
+
+	// write csv body
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		omppLog.Log("Error at csv write: ", dn, ": ", name, ": ", err.Error())
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	if err := csvWr.Write(hdr); err != nil {
+		omppLog.Log("Error at csv write: ", dn, ": ", name, ": ", err.Error())
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	// convert aggregated microdata cell into []string and write line into csv file
+	cs := make([]string, len(hdr))
+
+	cvtWr := func(c interface{}) (bool, error) {
+
+		// if converter return empty line then skip it
+		isNotEmpty, e := cvtRow(c, cs)
+		if e != nil {
+			return false, e
+		}
+		if isNotEmpty {
+			if e = csvWr.Write(cs); e != nil {
+				return false, e
+			}
+		}
+		return true, nil
+	}
+
+	_, ok := theCatalog.ReadMicrodataCalculateTo(dn, rdsn, microLt, calcLt, runIds, cvtWr)
+	if !ok {
+		http.Error(w, "Error at microdata aggregation read "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+	csvWr.Flush() // flush csv to response
+}
@@ -71,6 +71,17 @@ func doReadParameterPageHandler(w http.ResponseWriter, r *http.Request, srcArg s
 		}
 	}
 
+	// write to response: page data, page layout and total count of rows
+	writeValuePage(w, r, dn, src, &layout, cvtCell, "Error at parameter read "+src+": "+layout.Name)
+}
+
+// writeValuePage read a "page" of parameter, output table or microdata values and write it into response.
+// Layout must be one of: *db.ReadParamLayout, *db.ReadTableLayout or *db.ReadMicroLayout.
+// Response is a json with page of values and page layout: offset, size, last page flag,
+// total count of rows selected by filters, page index and page count.
+// If cvtCell not nil then it is used to convert enum id's into enum codes.
+func writeValuePage(w http.ResponseWriter, r *http.Request, dn, src string, layout interface{}, cvtCell func(interface{}) (interface{}, error), errMsg string) {
+
 	// write to response: page layout and page data
 	jsonSetHeaders(w, r) // start response with set json headers, i.e. content type
 
@@ -79,15 +90,15 @@ func doReadParameterPageHandler(w http.ResponseWriter, r *http.Request, srcArg s
 	enc := json.NewEncoder(w)
 	cvtWr := jsonCellWriter(w, enc, cvtCell)
 
-	// read parameter page into json array response, convert enum id's to code if requested
-	lt, ok := theCatalog.ReadParameterTo(dn, src, &layout, cvtWr)
+	// read values page into json array response, convert enum id's to code if requested
+	lt, ok := theCatalog.ReadValuePage(dn, src, layout, cvtWr)
 	if !ok {
-		http.Error(w, "Error at parameter read "+src+": "+layout.Name, http.StatusBadRequest)
+		http.Error(w, errMsg, http.StatusBadRequest)
 		return
 	}
 	w.Write([]byte{']'}) // end of data page array
 
-	// continue response with output page layout: offset, size, last page flag
+	// continue response with output page layout: offset, size, last page flag, total count
 	w.Write([]byte(",\"Layout\":"))
 
 	err := json.NewEncoder(w).Encode(lt)
@@ -143,30 +154,8 @@ func doReadTablePageHandler(w http.ResponseWriter, r *http.Request, isCode bool)
 		}
 	}
 
-	// write to response: page layout and page data
-	jsonSetHeaders(w, r) // start response with set json headers, i.e. content type
-
-	w.Write([]byte("{\"Page\":[")) // start of data page and start of json output array
-
-	enc := json.NewEncoder(w)
-	cvtWr := jsonCellWriter(w, enc, cvtCell)
-
-	// read output table page into json array response, convert enum id's to code if requested
-	lt, ok := theCatalog.ReadOutTableTo(dn, rdsn, &layout, cvtWr)
-	if !ok {
-		http.Error(w, "Error at run output table read "+rdsn+": "+layout.Name, http.StatusBadRequest)
-		return
-	}
-	w.Write([]byte{']'}) // end of data page array
-
-	// continue response with output page layout: offset, size, last page flag
-	w.Write([]byte(",\"Layout\":"))
-
-	err := json.NewEncoder(w).Encode(lt)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-	w.Write([]byte("}")) // end of data page and end of json
+	// write to response: page data, page layout and total count of rows
+	writeValuePage(w, r, dn, rdsn, &layout, cvtCell, "Error at run output table read "+rdsn+": "+layout.Name)
 }
 
 // runTableCalcPageReadHandler read a "page" of output table expressions and calculate of additional measures.
@@ -476,6 +465,10 @@ func runTableExprPageGetHandler(w http.ResponseWriter, r *http.Request) {
 // runTableAccPageGetHandler read a "page" of output table accumulator(s) values from model run results.
 // GET /api/model/:model/run/:run/table/:name/acc/start/:start
 // GET /api/model/:model/run/:run/table/:name/acc/start/:start/count/:count
+// GET /api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id
+// GET /api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start
+// GET /api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start/count/:count
+// If sub-id specified then only accumulator(s) of that sub-value returned.
 // Enum-based dimension items returned as enum codes.
 func runTableAccPageGetHandler(w http.ResponseWriter, r *http.Request) {
 	doTableGetPageHandler(w, r, true, false, true)
@@ -486,15 +479,83 @@ func runTableAccPageGetHandler(w http.ResponseWriter, r *http.Request) {
 // GET /api/model/:model/run/:run/table/:name/all-acc
 // GET /api/model/:model/run/:run/table/:name/all-acc/start/:start
 // GET /api/model/:model/run/:run/table/:name/all-acc/start/:start/count/:count
+// GET /api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id
+// GET /api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start
+// GET /api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start/count/:count
+// If sub-id specified then only accumulator(s) of that sub-value returned.
 // Enum-based dimension items returned as enum codes.
 func runTableAllAccPageGetHandler(w http.ResponseWriter, r *http.Request) {
 	doTableGetPageHandler(w, r, true, true, true)
 }
 
+// runTablePreviewGetHandler read a sample of output table expression(s) values from model run results to preview big table.
+// GET /api/model/:model/run/:run/table/:name/preview
+// GET /api/model/:model/run/:run/table/:name/preview/count/:count
+// GET /api/model/:model/run/:run/table/:name/preview/count/:count?by=Dim0,Dim1&random=true
+// Sample contains first count cells (by default 100) of each expression or, if ?by dimensions specified,
+// first count cells for each combination of expression and dimension(s) items.
+// If ?random=true then random (reservoir) sample of cells returned instead of first cells.
+// Enum-based dimension items returned as enum codes.
+func runTablePreviewGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	// url or query parameters
+	dn := getRequestParam(r, "model")  // model digest-or-name
+	rdsn := getRequestParam(r, "run")  // run digest-or-stamp-or-name
+	name := getRequestParam(r, "name") // output table name
+
+	// url or query parameters: sample size, sample by dimensions and random sample flag
+	count, ok := getIntRequestParam(r, "count", 100)
+	if !ok || count <= 0 {
+		http.Error(w, "Invalid value of sample size to preview "+name, http.StatusBadRequest)
+		return
+	}
+	isRandom, ok := getBoolRequestParam(r, "random")
+	if !ok {
+		http.Error(w, "Invalid value of random sample flag to preview "+name, http.StatusBadRequest)
+		return
+	}
+	by := []string{}
+	for _, s := range helper.ParseCsvLine(getRequestParam(r, "by"), ',') {
+		if s != "" {
+			by = append(by, s)
+		}
+	}
+
+	// setup read layout
+	layout := db.ReadTableLayout{
+		ReadLayout:       db.ReadLayout{Name: name},
+		ReadSampleLayout: db.ReadSampleLayout{SampleSize: count, SampleBy: by, IsRandom: isRandom},
+	}
+
+	// get converter from id's cell into code cell
+	cvtCell, ok := theCatalog.TableToCodeCellConverter(dn, layout.Name, false, false)
+	if !ok {
+		http.Error(w, "Error at run output table preview: "+name, http.StatusBadRequest)
+		return
+	}
+
+	// write to response: sample data
+	jsonSetHeaders(w, r) // start response with set json headers, i.e. content type
+
+	w.Write([]byte{'['}) // start of json output array
+
+	enc := json.NewEncoder(w)
+	cvtWr := jsonCellWriter(w, enc, cvtCell)
+
+	// read output table sample into json array response, convert enum id's to code
+	_, ok = theCatalog.ReadOutTableTo(dn, rdsn, &layout, cvtWr)
+	if !ok {
+		http.Error(w, "Error at run output table preview "+rdsn+": "+layout.Name, http.StatusBadRequest)
+		return
+	}
+	w.Write([]byte{']'}) // end of json output array
+}
+
 // doTableGetPageHandler read a "page" of values from
 // output table expressions, accumulators or "all-accumulators" views.
 // Page is part of output table values defined by zero-based "start" row number and row count.
 // If row count <= 0 then all rows returned.
+// If sub-id specified for accumulators or "all-accumulators" then only rows of that sub-value returned.
 // Enum-based dimension items returned as enum id or as enum codes.
 func doTableGetPageHandler(w http.ResponseWriter, r *http.Request, isAcc, isAllAcc, isCode bool) {
 
@@ -514,6 +575,11 @@ func doTableGetPageHandler(w http.ResponseWriter, r *http.Request, isAcc, isAllA
 		http.Error(w, "Invalid value of max row count to read "+name, http.StatusBadRequest)
 		return
 	}
+	subLt, ok := getSubIdRequestParam(r)
+	if !ok {
+		http.Error(w, "Invalid value of sub-value id to read "+name, http.StatusBadRequest)
+		return
+	}
 
 	// setup read layout
 	layout := db.ReadTableLayout{
@@ -524,6 +590,9 @@ func doTableGetPageHandler(w http.ResponseWriter, r *http.Request, isAcc, isAllA
 		IsAccum:    isAcc,
 		IsAllAccum: isAllAcc,
 	}
+	if isAcc {
+		layout.ReadSubIdLayout = subLt
+	}
 
 	// if required get converter from id's cell into code cell
 	var cvtCell func(interface{}) (interface{}, error)
@@ -768,30 +837,8 @@ func doReadMicrodataPageHandler(w http.ResponseWriter, r *http.Request, isCode b
 		}
 	}
 
-	// write to response: page layout and page data
-	jsonSetHeaders(w, r) // start response with set json headers, i.e. content type
-
-	w.Write([]byte("{\"Page\":[")) // start of data page and start of json output array
-
-	enc := json.NewEncoder(w)
-	cvtWr := jsonCellWriter(w, enc, cvtCell)
-
-	// read microdata page into json array response, convert enum id's to code if requested
-	lt, ok := theCatalog.ReadMicrodataTo(dn, rdsn, &layout, cvtWr)
-	if !ok {
-		http.Error(w, "Error at run microdata read "+rdsn+": "+layout.Name, http.StatusBadRequest)
-		return
-	}
-	w.Write([]byte{']'}) // end of data page array
-
-	// continue response with output page layout: offset, size, last page flag
-	w.Write([]byte(",\"Layout\":"))
-
-	err := json.NewEncoder(w).Encode(lt)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-	w.Write([]byte("}")) // end of data page and end of json
+	// write to response: page data, page layout and total count of rows
+	writeValuePage(w, r, dn, rdsn, &layout, cvtCell, "Error at run microdata read "+rdsn+": "+layout.Name)
 }
 
 // runMicrodataPageGetHandler read a "page" of microdata values from model run results.
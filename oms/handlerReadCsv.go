@@ -4,7 +4,6 @@
 package main
 
 import (
-	"encoding/csv"
 	"net/http"
 	"strconv"
 
@@ -99,15 +98,12 @@ func doParameterGetCsvHandler(w http.ResponseWriter, r *http.Request, srcArg str
 	csvSetHeaders(w, name)
 
 	// write csv body
-	if isBom {
-		if _, err := w.Write(helper.Utf8bom); err != nil {
-			http.Error(w, "Error at csv write: "+src+": "+name, http.StatusBadRequest)
-			return
-		}
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		http.Error(w, "Error at csv write: "+src+": "+name, http.StatusBadRequest)
+		return
 	}
 
-	csvWr := csv.NewWriter(w)
-
 	if err := csvWr.Write(hdr); err != nil {
 		http.Error(w, "Error at csv write: "+src+": "+name, http.StatusBadRequest)
 		return
@@ -238,6 +234,7 @@ func runTableAllAccIdCsvBomGetHandler(w http.ResponseWriter, r *http.Request) {
 // doTableGetCsvHandler read output table expression, accumulator or "all-accumulator" values
 // from model run and write it as csv response.
 // It does read all output table values, not a "page" of values.
+// If ?sub-id=N specified for accumulators or "all-accumulators" then only rows of that sub-value returned.
 // Dimension(s) and enum-based parameters returned as enum codes or enum id's.
 func doTableGetCsvHandler(w http.ResponseWriter, r *http.Request, isAcc, isAllAcc, isCode, isBom bool) {
 
@@ -246,12 +243,21 @@ func doTableGetCsvHandler(w http.ResponseWriter, r *http.Request, isAcc, isAllAc
 	rdsn := getRequestParam(r, "run")  // run digest-or-stamp-or-name
 	name := getRequestParam(r, "name") // output table name
 
+	subLt, ok := getSubIdRequestParam(r)
+	if !ok {
+		http.Error(w, "Invalid value of sub-value id to read "+name, http.StatusBadRequest)
+		return
+	}
+
 	// read output table values, page size =0: read all values
 	layout := db.ReadTableLayout{
 		ReadLayout: db.ReadLayout{Name: name},
 		IsAccum:    isAcc,
 		IsAllAccum: isAllAcc,
 	}
+	if isAcc {
+		layout.ReadSubIdLayout = subLt
+	}
 
 	// get converter from cell list to csv rows []string
 	hdr, cvtRow, ok := theCatalog.TableToCsvConverter(dn, isCode, name, layout.IsAccum, layout.IsAllAccum)
@@ -272,15 +278,12 @@ func doTableGetCsvHandler(w http.ResponseWriter, r *http.Request, isAcc, isAllAc
 	csvSetHeaders(w, fn)
 
 	// write csv body
-	if isBom {
-		if _, err := w.Write(helper.Utf8bom); err != nil {
-			http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
-			return
-		}
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
 	}
 
-	csvWr := csv.NewWriter(w)
-
 	if err := csvWr.Write(hdr); err != nil {
 		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
 		return
@@ -388,15 +391,12 @@ func doTableCalcGetCsvHandler(w http.ResponseWriter, r *http.Request, isCode, is
 	csvSetHeaders(w, name)
 
 	// write csv body
-	if isBom {
-		if _, err := w.Write(helper.Utf8bom); err != nil {
-			http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
-			return
-		}
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
 	}
 
-	csvWr := csv.NewWriter(w)
-
 	if err := csvWr.Write(hdr); err != nil {
 		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
 		return
@@ -535,14 +535,157 @@ func doTableCompareGetCsvHandler(w http.ResponseWriter, r *http.Request, isCode,
 	csvSetHeaders(w, name)
 
 	// write csv body
-	if isBom {
-		if _, err := w.Write(helper.Utf8bom); err != nil {
-			http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	if err := csvWr.Write(hdr); err != nil {
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+
+	// convert output table cell into []string and write line into csv file
+	cs := make([]string, len(hdr))
+
+	cvtWr := func(c interface{}) (bool, error) {
+
+		// if converter return empty line then skip it
+		isNotEmpty := true
+		var e2 error = nil
+
+		if isNotEmpty, e2 = cvtRow(c, cs); e2 != nil {
+			return false, e2
+		}
+		if isNotEmpty {
+			if e2 = csvWr.Write(cs); e2 != nil {
+				return false, e2
+			}
+		}
+		return true, nil
+	}
+
+	_, ok = theCatalog.ReadOutTableCalculateTo(dn, rdsn, &tableLt, calcLt, runIds, cvtWr)
+	if !ok {
+		http.Error(w, "Error at run output table read "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+	csvWr.Flush() // flush csv to response
+}
+
+// runTableCalcCsvPostHandler write into CSV response output table calculated expressions or aggregations.
+// POST /api/model/:model/run/:run/table/:name/calc/csv
+// Json db.ReadCompareTableLayout is posted to specify calculations, comparison runs and optional filters.
+// Dimension(s) returned as enum codes.
+func runTableCalcCsvPostHandler(w http.ResponseWriter, r *http.Request) {
+	doTableCalcPostCsvHandler(w, r, true, false)
+}
+
+// runTableCalcCsvBomPostHandler write into CSV response output table calculated expressions or aggregations.
+// POST /api/model/:model/run/:run/table/:name/calc/csv-bom
+// Json db.ReadCompareTableLayout is posted to specify calculations, comparison runs and optional filters.
+// Dimension(s) returned as enum codes.
+// Response starts from utf-8 BOM bytes.
+func runTableCalcCsvBomPostHandler(w http.ResponseWriter, r *http.Request) {
+	doTableCalcPostCsvHandler(w, r, true, true)
+}
+
+// runTableCalcIdCsvPostHandler write into CSV response output table calculated expressions or aggregations.
+// POST /api/model/:model/run/:run/table/:name/calc/csv-id
+// Json db.ReadCompareTableLayout is posted to specify calculations, comparison runs and optional filters.
+// Dimension(s) returned as enum id's.
+func runTableCalcIdCsvPostHandler(w http.ResponseWriter, r *http.Request) {
+	doTableCalcPostCsvHandler(w, r, false, false)
+}
+
+// runTableCalcIdCsvBomPostHandler write into CSV response output table calculated expressions or aggregations.
+// POST /api/model/:model/run/:run/table/:name/calc/csv-id-bom
+// Json db.ReadCompareTableLayout is posted to specify calculations, comparison runs and optional filters.
+// Dimension(s) returned as enum id's.
+// Response starts from utf-8 BOM bytes.
+func runTableCalcIdCsvBomPostHandler(w http.ResponseWriter, r *http.Request) {
+	doTableCalcPostCsvHandler(w, r, false, true)
+}
+
+// doTableCalcPostCsvHandler write into CSV response output table calculated expressions or aggregations.
+// Json db.ReadCompareTableLayout is posted to specify calculations, comparison runs and optional filters, for example:
+//
+//	{
+//	  "Calculation": [
+//	    {"Calculate": "Expr0[variant] - Expr0[base]", "CalcId": 12001, "Name": "Diff_Expr0"},
+//	    {"Calculate": "OM_AVG(acc0)", "CalcId": 12002, "Name": "Avg_acc0", "IsAggr": true}
+//	  ],
+//	  "Runs": ["Default-4", "2019_01_17_19_59_52_998"]
+//	}
+//
+// If calculation id is zero then it is 12000, 12001, 12002,... based on calculation index, calculation id must be unique.
+// If calculation name is empty then it is ex_ and calculation id, e.g.: ex_12000, ex_12001, ex_12002,...
+// Runs are comparison runs: list of run digests, stamps or names, it can be empty if there are no [base] and [variant] in calculations.
+// It does read all output table values, not a "page" of values: Offset and Size are ignored.
+// Dimension(s) returned as enum codes or enum id's.
+func doTableCalcPostCsvHandler(w http.ResponseWriter, r *http.Request, isCode, isBom bool) {
+
+	// url or query parameters
+	dn := getRequestParam(r, "model")  // model digest-or-name
+	rdsn := getRequestParam(r, "run")  // base run digest-or-stamp-or-name
+	name := getRequestParam(r, "name") // output table name
+
+	// decode json request body
+	var layout db.ReadCompareTableLayout
+	if !jsonRequestDecode(w, r, true, &layout) {
+		return // error at json decode, response done with http error
+	}
+	if layout.Name != "" && layout.Name != name {
+		http.Error(w, "Invalid output table name: "+layout.Name+", expected: "+name, http.StatusBadRequest)
+		return
+	}
+	if len(layout.Calculation) <= 0 {
+		http.Error(w, "Invalid (empty) calculation expression", http.StatusBadRequest)
+		return
+	}
+	calcIds := map[int]bool{}
+
+	for k := range layout.Calculation {
+		if layout.Calculation[k].Calculate == "" {
+			http.Error(w, "Invalid (empty) calculation expression", http.StatusBadRequest)
+			return
+		}
+		if layout.Calculation[k].CalcId == 0 {
+			layout.Calculation[k].CalcId = k + db.CALCULATED_ID_OFFSET
+		}
+		if layout.Calculation[k].Name == "" {
+			layout.Calculation[k].Name = "ex_" + strconv.Itoa(layout.Calculation[k].CalcId)
+		}
+		if calcIds[layout.Calculation[k].CalcId] {
+			http.Error(w, "Invalid (duplicate) calculation id: "+strconv.Itoa(layout.Calculation[k].CalcId)+": "+layout.Calculation[k].Calculate, http.StatusBadRequest)
 			return
 		}
+		calcIds[layout.Calculation[k].CalcId] = true
+	}
+
+	// setup read layout: page size =0, read all values
+	tableLt := db.ReadTableLayout{ReadLayout: layout.ReadLayout}
+	tableLt.Name = name
+	tableLt.Offset = 0
+	tableLt.Size = 0
+
+	// get converter from cell list to csv rows []string
+	hdr, cvtRow, _, runIds, ok := theCatalog.TableToCalcCsvConverter(dn, rdsn, isCode, name, layout.Calculation, layout.Runs)
+	if !ok {
+		http.Error(w, "Failed to create output table csv converter: "+name, http.StatusBadRequest)
+		return
 	}
 
-	csvWr := csv.NewWriter(w)
+	// set response headers: Content-Disposition: attachment; filename=name.csv
+	csvSetHeaders(w, name)
+
+	// write csv body
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
 
 	if err := csvWr.Write(hdr); err != nil {
 		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
@@ -569,7 +712,7 @@ func doTableCompareGetCsvHandler(w http.ResponseWriter, r *http.Request, isCode,
 		return true, nil
 	}
 
-	_, ok = theCatalog.ReadOutTableCalculateTo(dn, rdsn, &tableLt, calcLt, runIds, cvtWr)
+	_, ok = theCatalog.ReadOutTableCalculateTo(dn, rdsn, &tableLt, layout.Calculation, runIds, cvtWr)
 	if !ok {
 		http.Error(w, "Error at run output table read "+rdsn+": "+name, http.StatusBadRequest)
 		return
@@ -608,8 +751,18 @@ func runMicrodataIdCsvBomGetHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // doMicrodataGetCsvHandler read microdata values from model run and write it as csv response.
-// It does read all microdata values, not a "page" of values.
+// By default it does read all microdata values, not a "page" of values.
 // Enum-based microdata attributes returned as enum codes or enum id's.
+//
+// Optional query parameters:
+//
+//	?attrs=Age,Sex,Income     select only those attributes, csv columns are in the order of entity generation
+//	?filter=Age>=20;Sex=F     select only rows where all conditions are true, see db.ParseFilterText
+//	?limit=1000               select only first 1000 rows
+//
+// Filter conditions are ; semicolon separated, each condition is: attribute name, operator = != > >= < <= and value.
+// If operator is = and value is a comma separated list then it is IN filter, for example: AgeGroup=20-30,30-40.
+// Enum-based attributes in filter conditions must be enum codes, even for csv-id output.
 func doMicrodataGetCsvHandler(w http.ResponseWriter, r *http.Request, isCode, isBom bool) {
 
 	// url or query parameters
@@ -623,35 +776,49 @@ func doMicrodataGetCsvHandler(w http.ResponseWriter, r *http.Request, isCode, is
 		return
 	}
 
+	// optional attributes list, filter conditions and rows limit
+	attrs := helper.ParseCsvLine(getRequestParam(r, "attrs"), ',')
+
+	fltLst, err := db.ParseFilterText(getRequestParam(r, "filter"))
+	if err != nil {
+		http.Error(w, "Invalid microdata filter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, ok := getInt64RequestParam(r, "limit", 0)
+	if !ok || limit < 0 {
+		http.Error(w, "Invalid microdata rows limit: "+getRequestParam(r, "limit"), http.StatusBadRequest)
+		return
+	}
+
 	// get converter from cell list to csv rows []string
-	runId, genDigest, hdr, cvtRow, ok := theCatalog.MicrodataToCsvConverter(dn, isCode, rdsn, name)
+	runId, genDigest, hdr, cvtRow, ok := theCatalog.MicrodataToCsvConverter(dn, isCode, rdsn, name, attrs)
 	if !ok {
 		http.Error(w, "Failed to create microdata csv converter: "+rdsn+": "+name, http.StatusBadRequest)
 		return
 	}
 
-	// read microdata values, page size =0: read all values
+	// read microdata values, if limit is zero then page size =0: read all values
 	layout := db.ReadMicroLayout{
 		ReadLayout: db.ReadLayout{
-			Name:   name,
-			FromId: runId,
+			Name:           name,
+			FromId:         runId,
+			ReadPageLayout: db.ReadPageLayout{Size: limit},
+			Filter:         fltLst,
 		},
 		GenDigest: genDigest,
+		Attrs:     attrs,
 	}
 
 	// set response headers: Content-Disposition: attachment; filename=name.csv
 	csvSetHeaders(w, name)
 
 	// write csv body
-	if isBom {
-		if _, err := w.Write(helper.Utf8bom); err != nil {
-			http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
-			return
-		}
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
 	}
 
-	csvWr := csv.NewWriter(w)
-
 	if err := csvWr.Write(hdr); err != nil {
 		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
 		return
@@ -826,16 +993,13 @@ func doMicrodataCalcGetCsvHandler(w http.ResponseWriter, r *http.Request, isCode
 	csvSetHeaders(w, name)
 
 	// write csv body
-	if isBom {
-		if _, err := w.Write(helper.Utf8bom); err != nil {
-			omppLog.Log("Error at csv write: ", dn, ": ", name, ": ", err.Error())
-			http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
-			return
-		}
+	csvWr, err := helper.NewCsvWriter(w, helper.CsvOptions{IsBom: isBom})
+	if err != nil {
+		omppLog.Log("Error at csv write: ", dn, ": ", name, ": ", err.Error())
+		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
+		return
 	}
 
-	csvWr := csv.NewWriter(w)
-
 	if err := csvWr.Write(hdr); err != nil {
 		omppLog.Log("Error at csv write: ", dn, ": ", name, ": ", err.Error())
 		http.Error(w, "Error at csv write: "+rdsn+": "+name, http.StatusBadRequest)
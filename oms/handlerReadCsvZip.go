@@ -0,0 +1,266 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"archive/zip"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// tablesCsvRequest is a request to download multiple output tables from model run as one zip of csv files
+type tablesCsvRequest struct {
+	Tables   []string // output table names
+	Groups   []string // output table groups names, tables of each group and all child groups included
+	IsAcc    bool     // if true then write accumulators instead of expressions
+	IsAllAcc bool     // if true then write "all-accumulators", it is ignored if IsAcc is false
+}
+
+// runTablesCsvZipHandler read multiple output tables values from model run and write it as zip of csv files.
+// POST /api/model/:model/run/:run/tables/csv
+// Dimension(s) returned as enum codes.
+func runTablesCsvZipHandler(w http.ResponseWriter, r *http.Request) {
+	doTablesCsvZipHandler(w, r, true, false)
+}
+
+// runTablesCsvBomZipHandler read multiple output tables values from model run and write it as zip of csv files.
+// POST /api/model/:model/run/:run/tables/csv-bom
+// Dimension(s) returned as enum codes.
+// Each csv file starts from utf-8 BOM bytes.
+func runTablesCsvBomZipHandler(w http.ResponseWriter, r *http.Request) {
+	doTablesCsvZipHandler(w, r, true, true)
+}
+
+// runTablesIdCsvZipHandler read multiple output tables values from model run and write it as zip of csv files.
+// POST /api/model/:model/run/:run/tables/csv-id
+// Dimension(s) returned as enum id's.
+func runTablesIdCsvZipHandler(w http.ResponseWriter, r *http.Request) {
+	doTablesCsvZipHandler(w, r, false, false)
+}
+
+// runTablesIdCsvBomZipHandler read multiple output tables values from model run and write it as zip of csv files.
+// POST /api/model/:model/run/:run/tables/csv-id-bom
+// Dimension(s) returned as enum id's.
+// Each csv file starts from utf-8 BOM bytes.
+func runTablesIdCsvBomZipHandler(w http.ResponseWriter, r *http.Request) {
+	doTablesCsvZipHandler(w, r, false, true)
+}
+
+// doTablesCsvZipHandler read multiple output tables values from model run and write it as zip response,
+// zip contains one csv file for each output table, it does read all output table values, not a "page" of values.
+// Model identified by digest-or-name, run identified by digest-or-stamp-or-name.
+// Request body is json, for example:
+//
+//	{
+//	  "Tables": ["ageSexIncome", "fullAgeSalary"],
+//	  "Groups": ["AdditionalTables"],
+//	  "IsAcc": false,
+//	  "IsAllAcc": false
+//	}
+//
+// Output tables are the union of Tables and tables of each group, including child groups.
+// Csv files are: ageSexIncome.csv for expressions, ageSexIncome.acc.csv for accumulators or ageSexIncome.acc-all.csv.
+// If multiple models with same name exist then result is undefined.
+func doTablesCsvZipHandler(w http.ResponseWriter, r *http.Request, isCode, isBom bool) {
+
+	dn := getRequestParam(r, "model") // model digest-or-name
+	rdsn := getRequestParam(r, "run") // run digest-or-stamp-or-name
+
+	var req tablesCsvRequest
+	if !jsonRequestDecode(w, r, true, &req) {
+		return // error at json decode, response done with http error
+	}
+	isAllAcc := req.IsAcc && req.IsAllAcc
+
+	// find output tables by name and by groups
+	tblLst, ok := theCatalog.TableNamesByGroups(dn, req.Tables, req.Groups)
+	if !ok {
+		http.Error(w, "Model or output table(s) not found: "+dn, http.StatusBadRequest)
+		return
+	}
+	if len(tblLst) <= 0 {
+		http.Error(w, "Invalid (empty) list of output tables: "+dn+": "+rdsn, http.StatusBadRequest)
+		return
+	}
+
+	// model run must be completed, use run digest to read all tables from the same run
+	run, ok := theCatalog.CompletedRunByDigestOrStampOrName(dn, rdsn)
+	if !ok {
+		http.Error(w, "Model run not found or not completed: "+dn+": "+rdsn, http.StatusBadRequest)
+		return
+	}
+	rdsn = run.RunDigest
+
+	// create csv converters for all tables before starting the response
+	type tblCsv struct {
+		name   string
+		hdr    []string
+		cvtRow func(interface{}, []string) (bool, error)
+	}
+	cvtLst := make([]tblCsv, len(tblLst))
+
+	for k, name := range tblLst {
+
+		hdr, cvtRow, ok := theCatalog.TableToCsvConverter(dn, isCode, name, req.IsAcc, isAllAcc)
+		if !ok {
+			http.Error(w, "Failed to create output table csv converter: "+name, http.StatusBadRequest)
+			return
+		}
+		cvtLst[k] = tblCsv{name: name, hdr: hdr, cvtRow: cvtRow}
+	}
+
+	// set response headers: Content-Disposition: attachment; filename=run.tables.zip
+	// no Content-Length result in Transfer-Encoding: chunked
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment; filename="+`"`+url.QueryEscape(helper.CleanFileName(run.Name)+".tables.zip")+`"`)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	// write zip body: one csv file for each output table
+	// after response started http error status cannot be returned, on error zip is incomplete
+	zw := zip.NewWriter(w)
+	tNow := time.Now()
+
+	for _, tc := range cvtLst {
+
+		fn := tc.name
+		if req.IsAcc {
+			if isAllAcc {
+				fn += ".acc-all"
+			} else {
+				fn += ".acc"
+			}
+		}
+
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: fn + ".csv", Method: zip.Deflate, Modified: tNow})
+		if err != nil {
+			omppLog.Log("Error at zip write: ", rdsn, ": ", tc.name, ": ", err.Error())
+			return
+		}
+
+		csvWr, err := helper.NewCsvWriter(fw, helper.CsvOptions{IsBom: isBom})
+		if err != nil {
+			omppLog.Log("Error at csv write: ", rdsn, ": ", tc.name, ": ", err.Error())
+			return
+		}
+		if err = csvWr.Write(tc.hdr); err != nil {
+			omppLog.Log("Error at csv write: ", rdsn, ": ", tc.name, ": ", err.Error())
+			return
+		}
+
+		// convert output table cell into []string and write line into csv file
+		cs := make([]string, len(tc.hdr))
+
+		cvtWr := func(c interface{}) (bool, error) {
+
+			// if converter return empty line then skip it
+			isNotEmpty, e2 := tc.cvtRow(c, cs)
+			if e2 != nil {
+				return false, e2
+			}
+			if isNotEmpty {
+				if e2 = csvWr.Write(cs); e2 != nil {
+					return false, e2
+				}
+			}
+			return true, nil
+		}
+
+		layout := db.ReadTableLayout{
+			ReadLayout: db.ReadLayout{Name: tc.name},
+			IsAccum:    req.IsAcc,
+			IsAllAccum: isAllAcc,
+		}
+		if _, ok = theCatalog.ReadOutTableTo(dn, rdsn, &layout, cvtWr); !ok {
+			omppLog.Log("Error at run output table read ", rdsn, ": ", tc.name)
+			return
+		}
+		csvWr.Flush()
+		if err = csvWr.Error(); err != nil {
+			omppLog.Log("Error at csv write: ", rdsn, ": ", tc.name, ": ", err.Error())
+			return
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		omppLog.Log("Error at zip write: ", rdsn, ": ", err.Error())
+	}
+}
+
+// TableNamesByGroups return output table names by list of table names and list of output table groups.
+// Tables of each group include tables of all child groups.
+// Result is a union of table names and group tables, in the order of request, without duplicates.
+// Return false if model not found or any of tables or groups not found.
+func (mc *ModelCatalog) TableNamesByGroups(dn string, tables, groups []string) ([]string, bool) {
+
+	meta, _, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return []string{}, false
+	}
+
+	tLst := []string{}
+
+	for _, name := range tables {
+		if _, ok := meta.OutTableByName(name); !ok {
+			omppLog.Log("Warning: model output table not found: ", dn, ": ", name)
+			return []string{}, false
+		}
+		if !slices.Contains(tLst, name) {
+			tLst = append(tLst, name)
+		}
+	}
+
+	// append tables of the group and child groups, skip group if already visited
+	done := map[int]bool{}
+
+	var addGroup func(gIdx int)
+	addGroup = func(gIdx int) {
+
+		g := &meta.Group[gIdx]
+		if done[g.GroupId] {
+			return
+		}
+		done[g.GroupId] = true
+
+		for _, pc := range g.GroupPc {
+			if pc.ChildGroupId >= 0 {
+				for i := range meta.Group {
+					if meta.Group[i].GroupId == pc.ChildGroupId {
+						addGroup(i)
+						break
+					}
+				}
+			}
+			if pc.ChildLeafId >= 0 {
+				if idx, ok := meta.OutTableByKey(pc.ChildLeafId); ok && !slices.Contains(tLst, meta.Table[idx].Name) {
+					tLst = append(tLst, meta.Table[idx].Name)
+				}
+			}
+		}
+	}
+
+	for _, gn := range groups {
+
+		gIdx := -1
+		for k := range meta.Group {
+			if !meta.Group[k].IsParam && meta.Group[k].Name == gn {
+				gIdx = k
+				break
+			}
+		}
+		if gIdx < 0 {
+			omppLog.Log("Warning: model output tables group not found: ", dn, ": ", gn)
+			return []string{}, false
+		}
+		addGroup(gIdx)
+	}
+
+	return tLst, true
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// heartbeat interval of model run console output stream
+const consoleStreamPing = 15 * time.Second
+
+// runConsoleHandler return model run status and console output lines since sequence number:
+// GET /api/run/console/model/:model/stamp/:stamp
+// GET /api/run/console/model/:model/stamp/:stamp/since/:since
+// Model digest-or-name and run stamp or submit stamp must be specified.
+// Use NextSeq value of response as since value of next request to poll for new console output lines.
+// Only most recent console output lines are kept in memory, response IsTruncated is true if some lines are lost.
+func runConsoleHandler(w http.ResponseWriter, r *http.Request) {
+
+	mDigest, stamp, since, ok := runConsoleRequest(w, r)
+	if !ok {
+		return // error: response already written
+	}
+
+	cp, _, ok := theRunCatalog.getRunConsole(mDigest, stamp, since)
+	if !ok {
+		http.Error(w, "Model run not found: "+mDigest+" "+stamp, http.StatusNotFound)
+		return
+	}
+	jsonResponse(w, r, cp)
+}
+
+// runConsoleStreamHandler stream model run console output lines as server-sent events until model run completed:
+// GET /api/run/console-stream/model/:model/stamp/:stamp
+// GET /api/run/console-stream/model/:model/stamp/:stamp/since/:since
+// Each console line is an event with id of line sequence number and "stdout" or "stderr" event type, data is ConsoleLine json.
+// If client reconnects with Last-Event-ID header then stream starts from the next line after that id.
+// At the end of the model run "done" event is sent with RunConsolePage json without lines and stream closed.
+func runConsoleStreamHandler(w http.ResponseWriter, r *http.Request) {
+
+	mDigest, stamp, since, ok := runConsoleRequest(w, r)
+	if !ok {
+		return // error: response already written
+	}
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		if n, err := strconv.ParseInt(id, 10, 64); err == nil && n >= 0 {
+			since = n + 1
+		}
+	}
+
+	fl, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	cp, waitC, ok := theRunCatalog.getRunConsole(mDigest, stamp, since)
+	if !ok {
+		http.Error(w, "Model run not found: "+mDigest+" "+stamp, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	tck := time.NewTicker(consoleStreamPing)
+	defer tck.Stop()
+
+	for {
+		// write new console lines
+		for k := range cp.Lines {
+
+			et := "stdout"
+			if cp.Lines[k].IsErr {
+				et = "stderr"
+			}
+			if !writeSseEvent(w, strconv.FormatInt(cp.Lines[k].Seq, 10), et, cp.Lines[k]) {
+				return // client disconnected
+			}
+		}
+
+		// model run completed: send done event and close the stream
+		if cp.IsFinal {
+			cp.Lines = []ConsoleLine{}
+			writeSseEvent(w, "", "done", cp)
+			fl.Flush()
+			return
+		}
+		fl.Flush()
+
+		// wait for new lines, model run completion, heartbeat or client disconnect
+		select {
+		case <-r.Context().Done():
+			return
+		case <-waitC:
+		case <-tck.C:
+			if _, err := w.Write([]byte(": ping\n\n")); err != nil {
+				return
+			}
+		}
+
+		if cp, waitC, ok = theRunCatalog.getRunConsole(mDigest, stamp, cp.NextSeq); !ok {
+			return // model run removed from catalog
+		}
+	}
+}
+
+// return model digest, run stamp and since sequence number from console request,
+// on error write http error response and return false
+func runConsoleRequest(w http.ResponseWriter, r *http.Request) (string, string, int64, bool) {
+
+	// url or query parameters: model digest-or-name, run stamp or submit stamp, since sequence number
+	dn := getRequestParam(r, "model")
+	stamp := getRequestParam(r, "stamp")
+
+	since, ok := getInt64RequestParam(r, "since", 0)
+	if !ok || since < 0 {
+		http.Error(w, "Invalid value of console line since sequence number "+dn, http.StatusBadRequest)
+		return "", "", 0, false
+	}
+
+	// find model metadata by digest or name
+	m, ok := theCatalog.ModelDicByDigestOrName(dn)
+	if !ok {
+		http.Error(w, "Model not found: "+dn, http.StatusBadRequest)
+		return "", "", 0, false
+	}
+	if stamp == "" {
+		http.Error(w, "Invalid (empty) model run stamp: "+dn, http.StatusBadRequest)
+		return "", "", 0, false
+	}
+	return m.Digest, stamp, since, true
+}
+
+// write server-sent event: optional id, event type and json data, return false on write error
+func writeSseEvent(w http.ResponseWriter, id string, event string, src interface{}) bool {
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		return false
+	}
+	s := ""
+	if id != "" {
+		s = "id: " + id + "\n"
+	}
+	s += "event: " + event + "\n" + "data: " + string(data) + "\n\n"
+
+	_, err = w.Write([]byte(s))
+	return err == nil
+}
@@ -21,6 +21,8 @@ import (
 // Json RunRequest structure is posted to specify model digest-or-name, run stamp and othe run options.
 // If multiple models with same name exist then result is undefined.
 // Model run console output redirected to log file: models/log/modelName.runStamp.console.log
+// If RunRequest Webhooks URL list is not empty then those URLs notified on model run completion, in addition to -oms.Webhooks.
+// Each of RunRequest Webhooks URL must have the same scheme and host as one of -oms.Webhooks URLs and path starting from that URL path.
 func runModelHandler(w http.ResponseWriter, r *http.Request) {
 
 	// decode json request body
@@ -28,19 +30,107 @@ func runModelHandler(w http.ResponseWriter, r *http.Request) {
 	if !jsonRequestDecode(w, r, true, &req) {
 		return // error at json decode, response done with http error
 	}
+	submitRunRequest(w, r, req)
+}
+
+// run the model again using options of existing model run and overrides:
+//
+//	POST /api/run/clone
+//
+// Json RunCloneRequest structure is posted to specify model digest-or-name, source run digest-or-stamp-or-name and overrides.
+// New run request is pre-filled with source run options: workset, sub-values count, threads and microdata.
+// Non-empty RunCloneRequest fields replace source run values, for example: Threads, Opts or Params (scalar parameter values).
+// New run request is submitted same way as POST /api/run does: started immediately or appended to the queue.
+func runCloneHandler(w http.ResponseWriter, r *http.Request) {
+
+	// decode json request body
+	var crq RunCloneRequest
+	if !jsonRequestDecode(w, r, true, &crq) {
+		return // error at json decode, response done with http error
+	}
+
+	req, err := theCatalog.CloneRunRequest(&crq)
+	if err != nil {
+		omppLog.Log(err)
+		http.Error(w, "Model run clone failed: "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+	submitRunRequest(w, r, *req)
+}
+
+// validate model run request before the job is queued:
+//
+//	POST /api/run/validate
+//
+// Json RunRequest structure is posted same as for POST /api/run, model run is not started.
+// It checks if input workset, base run and Parameter.Name run options cover all model parameters,
+// if sub-values count of workset parameters is consistent with OpenM.SubValues
+// and if model run template exists and all template placeholders are valid.
+// Response is RunValidateResult with IsValid flag and list of problems found.
+func runValidateHandler(w http.ResponseWriter, r *http.Request) {
+
+	// decode json request body
+	var req RunRequest
+	if !jsonRequestDecode(w, r, true, &req) {
+		return // error at json decode, response done with http error
+	}
+
+	// find model by digest or name
+	dn := req.ModelDigest
+	if dn == "" {
+		dn = req.ModelName
+	}
+	m, ok := theCatalog.ModelDicByDigestOrName(dn)
+	if !ok {
+		http.Error(w, "Model not found: "+dn, http.StatusBadRequest)
+		return // empty result: model digest not found
+	}
+	req.ModelDigest = m.Digest
+	req.ModelName = m.Name
+
+	if req.Mpi.Np > 0 {
+		req.IsMpi = true
+	}
+
+	vr, err := theCatalog.ValidateRunRequest(&req)
+	if err != nil {
+		omppLog.Log(err)
+		http.Error(w, "Model run validation failed: "+dn, http.StatusBadRequest)
+		return
+	}
+
+	tn, tp := theRunCatalog.validateTemplate(&req)
+	vr.Template = tn
+	vr.Problems = append(vr.Problems, tp...)
+	vr.IsValid = len(vr.Problems) == 0
+
+	jsonResponse(w, r, vr)
+}
+
+// start model run or append it to the queue, response is run state or submit stamp
+func submitRunRequest(w http.ResponseWriter, r *http.Request, req RunRequest) {
+
 	if req.Opts == nil {
 		req.Opts = map[string]string{}
 	}
 	if req.Env == nil {
 		req.Env = map[string]string{}
 	}
+	for _, u := range req.Webhooks {
+		if !isWebhookUrl(u) {
+			http.Error(w, "Invalid webhook URL, expected http:// or https:// "+u, http.StatusBadRequest)
+			return
+		}
+		if strings.TrimSpace(u) != "" && !isAllowedWebhookUrl(u) {
+			http.Error(w, "Webhook URL not allowed by oms webhooks configuration "+u, http.StatusBadRequest)
+			return
+		}
+	}
 
-	// if log messages language not specified then use browser preferred language
+	// if log messages language not specified then use session language or browser preferred language
 	if _, ok := req.Opts["OpenM.MessageLanguage"]; !ok {
-		if rqLangTags, _, e := language.ParseAcceptLanguage(r.Header.Get("Accept-Language")); e == nil {
-			if len(rqLangTags) > 0 && rqLangTags[0] != language.Und {
-				req.Opts["OpenM.MessageLanguage"] = rqLangTags[0].String()
-			}
+		if rqLangTags := getRequestLang(r, ""); len(rqLangTags) > 0 && rqLangTags[0] != language.Und {
+			req.Opts["OpenM.MessageLanguage"] = rqLangTags[0].String()
 		}
 	}
 
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// default and max number of search results
+const (
+	searchDefaultLimit = 100
+	searchMaxLimit     = 1000
+)
+
+// Search models, parameters, output tables and entity attributes by name, description and notes across all models:
+//
+//	GET /api/search?q=fertility
+//	GET /api/search?q=fertility&limit=20
+//	GET /api/search/lang/:lang?q=fertility
+//
+// Search is case-insensitive, if q contains multiple words then each word must be found in name, description or notes.
+// Description and notes are searched in lang language or in browser language or model default language.
+// Result is sorted by rank: name matches first, description matches next and notes matches last.
+// Optional limit is max number of results, default: 100.
+func searchHandler(w http.ResponseWriter, r *http.Request) {
+
+	q := getRequestParam(r, "q")
+	limit, ok := getIntRequestParam(r, "limit", searchDefaultLimit)
+	if !ok || limit <= 0 {
+		http.Error(w, "Invalid search results limit", http.StatusBadRequest)
+		return
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	terms := strings.Fields(strings.ToLower(q))
+	if len(terms) <= 0 {
+		http.Error(w, "Invalid (empty) search text", http.StatusBadRequest)
+		return
+	}
+	rqLangTags := getRequestLang(r, "lang") // get optional language argument and languages accepted by browser
+
+	// search in each model text in preferred language or in model default language
+	hits := []SearchHit{}
+
+	for _, d := range theCatalog.allModelDigests() {
+
+		// if language-specific model metadata not loaded then read it from database
+		if ok := theCatalog.loadModelText(d); !ok {
+			omppLog.Log("Warning: model text metadata not found: ", d)
+			continue
+		}
+		lc := theCatalog.languageTagMatch(d, rqLangTags)
+		lcd, _, _ := theCatalog.modelLangs(d)
+
+		hits = append(hits, theCatalog.searchModelText(d, terms, lc, lcd)...)
+	}
+
+	sortSearchHits(hits)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	jsonResponse(w, r, hits)
+}
@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/openmpp/go/ompp/db"
-	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
@@ -179,10 +178,11 @@ func serviceRefreshDiskUseHandler(w http.ResponseWriter, r *http.Request) {
 
 // job control state, log file content and run progress
 type runJobState struct {
-	JobStatus string      // if not empty then job run status name: success, error, exit
-	RunJob                // job control state: job control file content
-	RunStatus []db.RunPub // if not empty then run_lst and run_progerss from db
-	Lines     []string    // log file content
+	JobStatus string           // if not empty then job run status name: success, error, exit
+	RunJob                     // job control state: job control file content
+	RunStatus []db.RunPub      // if not empty then run_lst and run_progerss from db
+	Lines     []string         // log file content
+	Queue     JobQueueEstimate // queue job only: queue position, required and free resources, estimated start time
 }
 
 // return empty value of job control state
@@ -247,9 +247,11 @@ func jobActiveHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, r, st) // return final result
 }
 
-// return queue job state
+// return queue job state, queue position, required and free resources and estimated start time
 //
 //	GET /api/service/job/queue/:job
+//
+// Estimated start time is naive: it is based on average duration of recent successful runs of the models in the queue.
 func jobQueueHandler(w http.ResponseWriter, r *http.Request) {
 
 	// url or query parameters: submission stamp
@@ -274,6 +276,16 @@ func jobQueueHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// queue position, limits and actual resources are updated in memory, not in job control file
+	st.QueuePos = qj.QueuePos
+	st.IsOverLimit = qj.IsOverLimit
+	st.IsRunLimit = qj.IsRunLimit
+	st.Res = qj.Res
+
+	if est, ok := theRunCatalog.queueJobEstimate(submitStamp); ok {
+		st.Queue = est
+	}
+
 	jsonResponse(w, r, st) // return final result
 }
 
@@ -316,7 +328,7 @@ func getJobState(filePath string) (bool, *runJobState) {
 
 	// read job control file
 	var jc RunJob
-	isOk, err := helper.FromJsonFile(filePath, &jc)
+	isOk, err := jobFromJsonFile(filePath, &jc)
 	if err != nil {
 		omppLog.Log(err)
 	}
@@ -394,7 +406,7 @@ func jobMoveHandler(w http.ResponseWriter, r *http.Request) {
 	isOk, fileMoveLst := theRunCatalog.moveJobInQueue(submitStamp, nPos)
 
 	for _, fm := range fileMoveLst {
-		fileMoveAndLog(false, fm[0], fm[1])
+		jobFileMove(false, fm[0], fm[1])
 	}
 
 	w.Header().Set("Content-Type", "text/plain")
@@ -422,7 +434,7 @@ func jobHistoryDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	hj, isOk := theRunCatalog.getHistoryJobItem(submitStamp)
 	if isOk {
 
-		isOk = fileDeleteAndLog(true, hj.filePath)
+		isOk = jobFileDelete(true, hj.filePath)
 		if !isOk {
 			http.Error(w, "Unable to delete job file", http.StatusInternalServerError)
 			return
@@ -462,7 +474,7 @@ func doJobHistoryAllDelete(isSuccess bool, w http.ResponseWriter) {
 			if isSuccess && hJobs[k].JobStatus != "success" || !isSuccess && hJobs[k].JobStatus == "success" {
 				continue
 			}
-			if isOk := fileDeleteAndLog(true, hJobs[k].filePath); !isOk {
+			if isOk := jobFileDelete(true, hJobs[k].filePath); !isOk {
 				http.Error(w, "Unable to delete job file "+hJobs[k].SubmitStamp, http.StatusInternalServerError)
 				return
 			}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+)
+
+// runTableStatsGetHandler return precomputed statistics of output table expressions: count, min, max and mean.
+// GET /api/model/:model/run/:run/table/:name/stats
+// Model identified by digest-or-name, run identified by digest-or-stamp-or-name, table identified by name.
+// If statistics not calculated for that model run then IsStats is false and list of expressions is empty.
+// If multiple models or runs with same name exist then result is undefined.
+func runTableStatsGetHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	rdsn := getRequestParam(r, "run")
+	name := getRequestParam(r, "name")
+
+	ts, ok := theCatalog.RunTableStats(dn, rdsn, name)
+	if !ok {
+		http.Error(w, "Model run or output table not found: "+dn+": "+rdsn+": "+name, http.StatusBadRequest)
+		return
+	}
+	jsonResponse(w, r, ts)
+}
+
+// runTableStatsUpdateHandler calculate and store statistics of all output table expressions of completed model run.
+// PUT /api/model/:model/run/:run/table-stats
+// Model identified by digest-or-name, run identified by digest-or-stamp-or-name.
+// Existing statistics of model run is replaced.
+// If multiple models or runs with same name exist then result is undefined.
+func runTableStatsUpdateHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	rdsn := getRequestParam(r, "run")
+
+	ok, err := theCatalog.UpdateRunTableStats(dn, rdsn)
+	if err != nil {
+		http.Error(w, "Output tables statistics update failed: "+dn+": "+rdsn, http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		http.Error(w, "Model run not found or not completed: "+dn+": "+rdsn, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/model/"+dn+"/run/"+rdsn+"/table-stats")
+}
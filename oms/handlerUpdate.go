@@ -100,7 +100,7 @@ func profileOptionDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// runDeleteStartHandler start delete model run including output table values, input parameters and microdata
+// runDeleteStartHandler start delete model run including output table values, input parameters, microdata and run artifacts
 // by model digest-or-name and run digest-or-stamp-or-name:
 // DELETE /api/model/:model/run/:run
 // The method starts model run delete in background thread.
@@ -128,7 +128,7 @@ func runDeleteStartHandler(w http.ResponseWriter, r *http.Request) {
 // POST /api/model/:model/delete-runs
 // Request body contains array of digest-or-stamp-or-name runs to delete.
 // Model identified by digest-or-name.
-// The method starts deleting multiple model runs in background thread.
+// The method starts deleting multiple model runs and run artifacts in background thread.
 // If multiple models with same name exist then result is undefined.
 // If multiple runs with same stamp or name exist then all such runs deleted.
 // If no such model runs exist in database then no error, empty operation.
@@ -208,7 +208,7 @@ func runParameterTextMergeHandler(w http.ResponseWriter, r *http.Request) {
 	ok, err := theCatalog.UpdateRunParameterText(dn, rdsn, pvtLst)
 	if err != nil {
 		omppLog.Log(err.Error())
-		http.Error(w, "Run parameter(s) value notes update failed "+dn+": "+rdsn+": "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "Run parameter(s) value notes update failed "+dn+": "+rdsn+": "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 	if ok {
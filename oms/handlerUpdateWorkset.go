@@ -4,14 +4,15 @@
 package main
 
 import (
-	"encoding/csv"
 	"io"
 	"net/http"
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
@@ -38,6 +39,7 @@ func worksetReadonlyUpdateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if ok {
+		logWorksetActivity(worksetUpdateActivity, digest, ws.Name, "readonly: "+strconv.FormatBool(isReadonly))
 		w.Header().Set("Content-Location", "/api/model/"+digest+"/workset/"+ws.Name)
 	} else {
 		ws = &db.WorksetRow{}
@@ -107,7 +109,7 @@ func worksetCreateHandler(w http.ResponseWriter, r *http.Request) {
 
 	ok, _, wsRow, err := theCatalog.UpdateWorkset(true, &newWp)
 	if err != nil {
-		http.Error(w, "Failed create workset metadata "+dn+" : "+wsn+" : "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "Failed create workset metadata "+dn+" : "+wsn+" : "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 	if !ok {
@@ -146,6 +148,8 @@ func worksetCreateHandler(w http.ResponseWriter, r *http.Request) {
 	if wp.IsReadonly {
 		theCatalog.UpdateWorksetReadonly(dn, wsn, wp.IsReadonly)
 	}
+	logWorksetActivity(worksetUpdateActivity, dn, wsn, "create")
+
 	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn) // respond with workset location
 	jsonResponse(w, r, wsRow)
 }
@@ -194,6 +198,8 @@ func worksetMergeHandler(w http.ResponseWriter, r *http.Request) {
 // Json content: workset "public" metadata.
 // If parameter not already exist in workset then parameter values must be supplied.
 // It is an error to add parameter metadata without parameter values.
+// Parameter csv dimension items and enum-based values can be enum codes or enum id's, mixed in the same column.
+// If csv rows are invalid then response is 400 with the list of invalid line numbers and errors.
 func worksetUpdateHandler(isReplace bool, w http.ResponseWriter, r *http.Request) {
 
 	// parse multipart form: first part must be workset metadata
@@ -246,7 +252,7 @@ func worksetUpdateHandler(isReplace bool, w http.ResponseWriter, r *http.Request
 
 	ok, _, wsRow, err := theCatalog.UpdateWorkset(isReplace, &newWp)
 	if err != nil {
-		http.Error(w, "Failed update workset metadata "+dn+" : "+newWp.Name+" : "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "Failed update workset metadata "+dn+" : "+newWp.Name+" : "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 	if !ok {
@@ -296,14 +302,12 @@ func worksetUpdateHandler(isReplace bool, w http.ResponseWriter, r *http.Request
 		}
 
 		// read csv values and update parameter
-		csvRd := csv.NewReader(part)
-		csvRd.TrimLeadingSpace = true
-		csvRd.ReuseRecord = true
+		csvRd := helper.NewCsvReader(part, helper.CsvOptions{})
 
 		_, err = theCatalog.UpdateWorksetParameterCsv(isReplace, &newWp, &newParamLst[np], csvRd)
 		part.Close() // done with csv parameter data
 		if err != nil {
-			http.Error(w, "Failed update workset parameter "+newWp.Name+" : "+name+" : "+err.Error(), http.StatusBadRequest)
+			http.Error(w, "Failed update workset parameter "+newWp.Name+" : "+name+" : "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
 			return
 		}
 		pim[np] = true // parameter metadata and csv values updated
@@ -319,7 +323,7 @@ func worksetUpdateHandler(isReplace bool, w http.ResponseWriter, r *http.Request
 		// update only parameter metadata
 		_, err = theCatalog.UpdateWorksetParameterCsv(isReplace, &newWp, &newParamLst[k], nil)
 		if err != nil {
-			http.Error(w, "Failed update workset parameter "+newWp.Name+" : "+newParamLst[k].Name+" : "+err.Error(), http.StatusBadRequest)
+			http.Error(w, "Failed update workset parameter "+newWp.Name+" : "+newParamLst[k].Name+" : "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
 			return
 		}
 	}
@@ -328,6 +332,11 @@ func worksetUpdateHandler(isReplace bool, w http.ResponseWriter, r *http.Request
 	if isReadonly {
 		theCatalog.UpdateWorksetReadonly(dn, newWp.Name, isReadonly)
 	}
+	if isReplace {
+		logWorksetActivity(worksetUpdateActivity, dn, newWp.Name, "replace")
+	} else {
+		logWorksetActivity(worksetUpdateActivity, dn, newWp.Name, "merge")
+	}
 
 	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+newWp.Name) // respond with workset location
 	jsonResponse(w, r, wsRow)
@@ -349,6 +358,7 @@ func worksetDeleteHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if ok {
+		logWorksetActivity(worksetDeleteActivity, dn, wsn, "")
 		w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn)
 		w.Header().Set("Content-Type", "text/plain")
 	}
@@ -394,6 +404,7 @@ func worksetListDeleteHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		if ok {
 			n++
+			logWorksetActivity(worksetDeleteActivity, dn, name, "")
 		}
 	}
 	omppLog.Log("Deleted multiple worksets: ", n, ": ", dn)
@@ -495,6 +506,8 @@ func doUpdateParameterPageHandler(w http.ResponseWriter, r *http.Request, isCode
 		return
 	}
 
+	logWorksetActivity(worksetUpdateActivity, dn, wsn, "parameter: "+name)
+
 	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/parameter/"+name) // respond with workset parameter location
 	w.Header().Set("Content-Type", "text/plain")
 }
@@ -516,6 +529,7 @@ func worksetParameterDeleteHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if ok {
+		logWorksetActivity(worksetUpdateActivity, dn, wsn, "delete parameter: "+name)
 		w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/parameter/"+name)
 		w.Header().Set("Content-Type", "text/plain")
 	}
@@ -559,6 +573,8 @@ func worksetParameterRunCopy(isReplace bool, w http.ResponseWriter, r *http.Requ
 		http.Error(w, "Workset parameter copy failed "+wsn+": "+name+" from run: "+rdsn, http.StatusBadRequest)
 		return
 	}
+	logWorksetActivity(worksetUpdateActivity, dn, wsn, "parameter: "+name+" from run: "+rdsn)
+
 	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/parameter/"+name)
 	w.Header().Set("Content-Type", "text/plain")
 }
@@ -599,6 +615,8 @@ func worksetParameterCopyFromWs(isReplace bool, w http.ResponseWriter, r *http.R
 		http.Error(w, "Workset parameter copy failed "+dstWsName+": "+name+" from run: "+srcWsName, http.StatusBadRequest)
 		return
 	}
+	logWorksetActivity(worksetUpdateActivity, dn, dstWsName, "parameter: "+name+" from workset: "+srcWsName)
+
 	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+dstWsName+"/parameter/"+name)
 	w.Header().Set("Content-Type", "text/plain")
 }
@@ -627,11 +645,131 @@ func worksetParameterTextMergeHandler(w http.ResponseWriter, r *http.Request) {
 	ok, err := theCatalog.UpdateWorksetParameterText(dn, wsn, pvtLst)
 	if err != nil {
 		omppLog.Log(err.Error())
-		http.Error(w, "Workset parameter(s) value notes update failed "+dn+": "+wsn+": "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "Workset parameter(s) value notes update failed "+dn+": "+wsn+": "+err.Error(), dbErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 	if ok {
+		logWorksetActivity(worksetUpdateActivity, dn, wsn, "parameter value notes")
 		w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/parameter-text")
 		w.Header().Set("Content-Type", "text/plain")
 	}
 }
+
+// worksetLockHandler acquire workset read lock by model digest-or-name, workset name and lock owner:
+// POST /api/model/:model/workset/:set/lock/:owner
+// POST /api/model/:model/workset/:set/lock/:owner?ttl=300
+// Lock duration ttl is in seconds, by default it is 300 seconds.
+// Workset must be read-only, multiple owners can lock the same workset.
+// While workset is locked it cannot be switched to read-write or deleted.
+// If owner already has a lock of that workset then lock is refreshed.
+// If multiple models with same name exist then result is undefined.
+func worksetLockHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	wsn := getRequestParam(r, "set")
+	owner := getRequestParam(r, "owner")
+
+	ttl, ok := getWorksetLockTtl(r)
+	if !ok {
+		http.Error(w, "Invalid value of workset lock duration "+wsn, http.StatusBadRequest)
+		return
+	}
+
+	wl, ok, err := theCatalog.AcquireWorksetLock(dn, wsn, owner, ttl)
+	if err != nil {
+		http.Error(w, "Failed to lock workset "+dn+": "+wsn+": "+err.Error(), http.StatusConflict)
+		return
+	}
+	if !ok {
+		http.Error(w, "Workset not found "+dn+": "+wsn, http.StatusNotFound)
+		return
+	}
+	logWorksetActivity(worksetUpdateActivity, dn, wsn, "lock: "+owner)
+
+	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/lock/"+owner)
+	jsonResponse(w, r, wl)
+}
+
+// worksetLockRefreshHandler extend workset read lock of the owner by model digest-or-name and workset name:
+// POST /api/model/:model/workset/:set/lock/:owner/refresh
+// POST /api/model/:model/workset/:set/lock/:owner/refresh?ttl=300
+// Lock duration ttl is in seconds from now, by default it is 300 seconds.
+// If owner does not have active (not expired) lock of the workset then return error.
+// If multiple models with same name exist then result is undefined.
+func worksetLockRefreshHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	wsn := getRequestParam(r, "set")
+	owner := getRequestParam(r, "owner")
+
+	ttl, ok := getWorksetLockTtl(r)
+	if !ok {
+		http.Error(w, "Invalid value of workset lock duration "+wsn, http.StatusBadRequest)
+		return
+	}
+
+	ok, err := theCatalog.RefreshWorksetLock(dn, wsn, owner, ttl)
+	if err != nil {
+		http.Error(w, "Failed to refresh workset lock "+dn+": "+wsn+": "+owner, http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		http.Error(w, "Workset lock not found "+dn+": "+wsn+": "+owner, http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/lock/"+owner)
+	w.Header().Set("Content-Type", "text/plain")
+}
+
+// worksetLockReleaseHandler release workset read lock of the owner by model digest-or-name and workset name:
+// DELETE /api/model/:model/workset/:set/lock/:owner
+// If owner does not have active (not expired) lock of the workset then no error, empty operation.
+// If multiple models with same name exist then result is undefined.
+func worksetLockReleaseHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	wsn := getRequestParam(r, "set")
+	owner := getRequestParam(r, "owner")
+
+	ok, err := theCatalog.ReleaseWorksetLock(dn, wsn, owner)
+	if err != nil {
+		http.Error(w, "Failed to release workset lock "+dn+": "+wsn+": "+owner, http.StatusBadRequest)
+		return
+	}
+	if ok {
+		logWorksetActivity(worksetUpdateActivity, dn, wsn, "unlock: "+owner)
+		w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn+"/lock/"+owner)
+		w.Header().Set("Content-Type", "text/plain")
+	}
+}
+
+// worksetLockListHandler return active read locks of the workset by model digest-or-name and workset name:
+// GET /api/model/:model/workset/:set/lock-list
+// If there are no locks then return empty list.
+// If multiple models with same name exist then result is undefined.
+func worksetLockListHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	wsn := getRequestParam(r, "set")
+
+	wlLst, ok, err := theCatalog.WorksetLockList(dn, wsn)
+	if err != nil {
+		http.Error(w, "Failed to get workset locks "+dn+": "+wsn, http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		http.Error(w, "Workset not found "+dn+": "+wsn, http.StatusNotFound)
+		return
+	}
+	jsonResponse(w, r, wlLst)
+}
+
+// return workset lock duration from url parameter ?ttl=seconds, return false if it is not a positive integer
+func getWorksetLockTtl(r *http.Request) (time.Duration, bool) {
+
+	n, ok := getIntRequestParam(r, "ttl", int(worksetLockTtlDefault/time.Second))
+	if !ok || n <= 0 {
+		return 0, false
+	}
+	return time.Duration(n) * time.Second, true
+}
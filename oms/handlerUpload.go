@@ -138,6 +138,7 @@ func runUploadPostHandler(w http.ResponseWriter, r *http.Request) {
 	go makeUpload(baseName, cmd, cmdMsg, logPath)
 
 	// report to the client results location
+	logActivity(ActivityEvent{Kind: uploadActivity, ModelDigest: dn, Name: baseName, Msg: "run: " + runName})
 	w.Header().Set("Content-Location", "/api/upload/model/"+dn+"/run/"+runName+"/"+baseName)
 }
 
@@ -295,5 +296,6 @@ func worksetUploadPostHandler(w http.ResponseWriter, r *http.Request) {
 	go makeUpload(baseName, cmd, cmdMsg, logPath)
 
 	// report to the client results location
+	logActivity(ActivityEvent{Kind: uploadActivity, ModelDigest: dn, Name: baseName, Msg: "workset: " + setName})
 	w.Header().Set("Content-Location", "/api/upload/model/"+dn+"/workset/"+setName+"/"+baseName)
 }
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"golang.org/x/text/language"
+
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
@@ -108,3 +110,66 @@ func userViewDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Location", "/api/user/view/model/"+dn)
 	w.Header().Set("Content-Type", "text/plain")
 }
+
+// userLangGetHandler return session language, browser languages in order of preference and UI language:
+// GET /api/user/lang
+// Session language is empty "" if it is not set by PUT /api/user/lang/:lang
+func userLangGetHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, r, makeUserLang(getLangCookie(r), r))
+}
+
+// userLangPutHandler set session language to override browser languages preferences:
+// PUT /api/user/lang/:lang
+// Session language is stored in session cookie and it is used by all text endpoints:
+// model metadata, model word lists, notes and UI language.
+// Language parameter in the request, e.g.: /lang/FR or ?lang=FR, is still preferred over session language.
+func userLangPutHandler(w http.ResponseWriter, r *http.Request) {
+
+	ln := getRequestParam(r, "lang")
+
+	t, err := language.Parse(ln)
+	if err != nil || t == language.Und {
+		http.Error(w, "Error: invalid language "+ln, http.StatusBadRequest)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: langCookieName, Value: t.String(), Path: "/", SameSite: http.SameSiteStrictMode})
+
+	w.Header().Set("Content-Location", "/api/user/lang/"+t.String())
+	jsonResponse(w, r, makeUserLang(t.String(), r))
+}
+
+// userLangDeleteHandler delete session language and restore browser languages preferences:
+// DELETE /api/user/lang
+func userLangDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	http.SetCookie(w, &http.Cookie{Name: langCookieName, Value: "", Path: "/", MaxAge: -1, SameSite: http.SameSiteStrictMode})
+
+	w.Header().Set("Content-Location", "/api/user/lang")
+	w.Header().Set("Content-Type", "text/plain")
+}
+
+// return session language, browser languages and UI language matched to session language or browser languages
+func makeUserLang(langCode string, r *http.Request) interface{} {
+
+	rqLangTags := parseAcceptLanguage(r.Header.Get("Accept-Language"))
+
+	bl := []string{}
+	for _, t := range rqLangTags {
+		bl = append(bl, t.String())
+	}
+	if langCode != "" {
+		rqLangTags = append([]language.Tag{language.Make(langCode)}, rqLangTags...)
+	}
+	ui, _, _ := uiLangMatcher.Match(rqLangTags...)
+
+	return struct {
+		LangCode    string   // session language, empty if not set
+		BrowserLang []string // browser languages from Accept-Language header, in order of preference
+		UiLang      string   // UI language matched to session language or browser languages
+	}{
+		LangCode:    langCode,
+		BrowserLang: bl,
+		UiLang:      ui.String(),
+	}
+}
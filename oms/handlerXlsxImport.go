@@ -0,0 +1,296 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// max size of uploaded Excel workbook
+const maxXlsxSize = 64 * 1024 * 1024
+
+// XlsxImportReport is a validation report of workset parameters import from Excel workbook.
+type XlsxImportReport struct {
+	ModelDigest string            // model digest
+	Workset     string            // workset name
+	IsOk        bool              // if true then all sheets imported successfully
+	Imported    int               // number of imported parameters
+	Sheet       []XlsxSheetReport // import results of each sheet
+}
+
+// XlsxSheetReport is an import result of workbook sheet.
+type XlsxSheetReport struct {
+	Sheet     string   // sheet name
+	Parameter string   // parameter name, empty if sheet name is not a model parameter
+	RowCount  int      // number of value rows in the sheet, excluding header row
+	IsOk      bool     // if true then parameter values imported
+	IsSkip    bool     // if true then sheet skipped, e.g. it is not a model parameter
+	Errors    []string // validation errors, row numbers are sheet row numbers
+}
+
+// worksetXlsxImportHandler import parameter values from Excel workbook into workset:
+//
+//	POST /api/model/:model/workset/:set/import-xlsx
+//
+// Expected multipart form with part workbook-xlsx=fileName.xlsx.
+// Each workbook sheet is a parameter values, sheet name must be a model parameter name, other sheets are skipped.
+// First row of the sheet is a header: dimension names and value column: param_value or value.
+// Optional sub_id column is a sub-value id, if it is not present then all values are sub-value zero.
+// Dimension items and enum-based values can be enum codes or enum id's, mixed in the same column.
+// Workset must exist and must be read-write, each parameter values replaced in separate transaction.
+// Response is validation report with import result and list of errors for each sheet.
+func worksetXlsxImportHandler(w http.ResponseWriter, r *http.Request) {
+
+	dn := getRequestParam(r, "model")
+	wsn := getRequestParam(r, "set")
+
+	// get existing workset metadata, it must be read-write
+	wp, ok, err := theCatalog.WorksetTextFull(dn, wsn, true, nil)
+	if err != nil {
+		http.Error(w, "Failed to get existing workset metadata "+dn+" : "+wsn, http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		http.Error(w, "Workset not found: "+dn+" : "+wsn, http.StatusBadRequest)
+		return
+	}
+	if wp.IsReadonly {
+		http.Error(w, "Failed to import parameters into read-only workset: "+dn+" : "+wsn, http.StatusBadRequest)
+		return
+	}
+
+	meta, err := theCatalog.ModelMetaByDigestOrName(dn)
+	if err != nil || meta == nil || meta.Model.Digest == "" {
+		http.Error(w, "Model digest or name not found"+": "+dn, http.StatusBadRequest)
+		return
+	}
+
+	// read workbook from multipart form
+	mr, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Error at multipart form open ", http.StatusBadRequest)
+		return
+	}
+	var wb []byte
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break // end of posted data
+		}
+		if err != nil {
+			http.Error(w, "Failed to get next part of multipart form "+dn+" : "+wsn, http.StatusBadRequest)
+			return
+		}
+		if part.FormName() != "workbook-xlsx" {
+			part.Close()
+			continue // skip non workbook data
+		}
+		if ext := path.Ext(part.FileName()); !strings.EqualFold(ext, ".xlsx") {
+			part.Close()
+			http.Error(w, "Error: workbook file must have .xlsx extension "+wsn+" : "+part.FileName(), http.StatusBadRequest)
+			return
+		}
+
+		wb, err = io.ReadAll(io.LimitReader(part, maxXlsxSize+1))
+		part.Close()
+		if err != nil {
+			http.Error(w, "Failed to read workbook "+dn+" : "+wsn+" : "+part.FileName(), http.StatusBadRequest)
+			return
+		}
+		if len(wb) > maxXlsxSize {
+			http.Error(w, "Error: workbook file is too large "+wsn+" : "+part.FileName(), http.StatusBadRequest)
+			return
+		}
+		break
+	}
+	if len(wb) <= 0 {
+		http.Error(w, "Error: workbook not found, expected workbook-xlsx part of multipart form "+dn+" : "+wsn, http.StatusBadRequest)
+		return
+	}
+
+	shLst, err := helper.ReadXlsx(bytes.NewReader(wb), int64(len(wb)))
+	if err != nil {
+		http.Error(w, "Error at reading workbook "+dn+" : "+wsn+" : "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// import each sheet as parameter values
+	rpt := XlsxImportReport{
+		ModelDigest: meta.Model.Digest,
+		Workset:     wp.Name,
+		IsOk:        true,
+		Sheet:       make([]XlsxSheetReport, 0, len(shLst)),
+	}
+
+	for k := range shLst {
+
+		sr := importXlsxSheet(meta, wp, &shLst[k])
+
+		if sr.IsOk {
+			rpt.Imported++
+		}
+		if !sr.IsOk && !sr.IsSkip {
+			rpt.IsOk = false
+		}
+		rpt.Sheet = append(rpt.Sheet, sr)
+	}
+	omppLog.Log("Imported parameters from workbook: ", rpt.Imported, " of ", len(shLst), " sheets: ", dn, ": ", wsn)
+	if rpt.Imported > 0 {
+		logWorksetActivity(worksetUpdateActivity, dn, wsn, "import workbook: "+strconv.Itoa(rpt.Imported)+" parameters")
+	}
+
+	w.Header().Set("Content-Location", "/api/model/"+dn+"/workset/"+wsn) // respond with workset location
+	jsonResponse(w, r, rpt)
+}
+
+// import workbook sheet into workset parameter values and return sheet import result
+func importXlsxSheet(meta *db.ModelMeta, wp *db.WorksetPub, sheet *helper.XlsxSheet) XlsxSheetReport {
+
+	sr := XlsxSheetReport{Sheet: sheet.Name, Errors: []string{}}
+	if len(sheet.Rows) > 0 {
+		sr.RowCount = len(sheet.Rows) - 1
+	}
+
+	// find parameter by sheet name, Excel sheet names are case-insensitive
+	idx, ok := meta.ParamByName(sheet.Name)
+	for j := 0; !ok && j < len(meta.Param); j++ {
+		if strings.EqualFold(meta.Param[j].Name, sheet.Name) {
+			idx, ok = j, true
+		}
+	}
+	if !ok {
+		sr.IsSkip = true
+		sr.Errors = append(sr.Errors, "sheet name is not a model parameter: "+sheet.Name)
+		return sr
+	}
+	param := &meta.Param[idx]
+	sr.Parameter = param.Name
+
+	if sr.RowCount <= 0 {
+		sr.Errors = append(sr.Errors, "invalid (empty) parameter values, expected header row and value rows")
+		return sr
+	}
+
+	// map sheet header columns: optional sub_id, dimension names and value column
+	hdr := sheet.Rows[0]
+	colIdx := func(name string) int {
+		for j := range hdr {
+			if strings.EqualFold(strings.TrimSpace(hdr[j]), name) {
+				return j
+			}
+		}
+		return -1
+	}
+
+	nSub := colIdx("sub_id")
+	dimIdx := make([]int, param.Rank)
+	for j := range param.Dim {
+		if dimIdx[j] = colIdx(param.Dim[j].Name); dimIdx[j] < 0 {
+			sr.Errors = append(sr.Errors, "dimension column not found: "+param.Dim[j].Name)
+		}
+	}
+	nValue := colIdx("param_value")
+	if nValue < 0 {
+		nValue = colIdx("value")
+	}
+	if nValue < 0 {
+		sr.Errors = append(sr.Errors, "value column not found, expected: param_value or value")
+	}
+	if len(sr.Errors) > 0 {
+		return sr
+	}
+
+	// convert sheet rows into csv: sub_id,dim0,dim1,param_value
+	// each sheet row is a csv line, line numbers of csv errors are sheet row numbers
+	var buf bytes.Buffer
+	csvWr, err := helper.NewCsvWriter(&buf, helper.CsvOptions{})
+	if err != nil {
+		sr.Errors = append(sr.Errors, "failed to create csv writer: "+err.Error())
+		return sr
+	}
+
+	cell := func(row []string, n int) string {
+		if n >= 0 && n < len(row) {
+			return strings.TrimSpace(row[n])
+		}
+		return ""
+	}
+	subIds := map[int]bool{}
+	cs := make([]string, param.Rank+2)
+
+	for n, row := range sheet.Rows {
+
+		cs[0] = "sub_id"
+		for j := range param.Dim {
+			cs[j+1] = param.Dim[j].Name
+		}
+		cs[param.Rank+1] = "param_value"
+
+		if n > 0 {
+			cs[0] = "0"
+			if nSub >= 0 {
+				cs[0] = cell(row, nSub)
+				if sid, e := strconv.Atoi(cs[0]); e == nil && sid >= 0 {
+					subIds[sid] = true
+				}
+			} else {
+				subIds[0] = true
+			}
+			for j := range dimIdx {
+				cs[j+1] = cell(row, dimIdx[j])
+			}
+			cs[param.Rank+1] = cell(row, nValue)
+		}
+		if err = csvWr.Write(cs); err != nil {
+			sr.Errors = append(sr.Errors, "failed to convert row "+strconv.Itoa(n+1)+": "+err.Error())
+			return sr
+		}
+	}
+	csvWr.Flush()
+
+	// use existing parameter metadata or add new parameter into workset
+	pp := db.ParamRunSetPub{ParamRunSetTxtPub: db.ParamRunSetTxtPub{Name: param.Name, Txt: []db.LangNote{}}}
+	for j := range wp.Param {
+		if wp.Param[j].Name == param.Name {
+			pp = wp.Param[j]
+			break
+		}
+	}
+	pp.SubCount = len(subIds)
+	if pp.SubCount <= 0 {
+		pp.SubCount = 1
+	}
+	if !subIds[pp.DefaultSubId] {
+		isFirst := true
+		for sid := range subIds {
+			if isFirst || sid < pp.DefaultSubId {
+				pp.DefaultSubId = sid
+				isFirst = false
+			}
+		}
+	}
+
+	// replace parameter values in workset
+	csvRd := helper.NewCsvReader(&buf, helper.CsvOptions{})
+
+	if _, err = theCatalog.UpdateWorksetParameterCsv(true, wp, &pp, csvRd); err != nil {
+		for _, s := range strings.Split(err.Error(), "\n") {
+			if s = strings.Replace(s, "line ", "row ", 1); s != "" {
+				sr.Errors = append(sr.Errors, s)
+			}
+		}
+		return sr
+	}
+	sr.IsOk = true
+	return sr
+}
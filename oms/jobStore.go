@@ -0,0 +1,175 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// jobStore is a storage of job control state: queue, active and history job files and job state files.
+//
+// Job control state is encoded in file paths, e.g.: job/queue/2022_07_05_19_55_38_111-#-_4040-#-RiskPaths-#-....json,
+// and file content is a json of model run job or state, which can be empty for state files.
+// File paths are always under job control directory, e.g.: job/queue, job/active, job/history, job/state.
+// Shadow history copy of job/past and job/job.ini are always files and not a part of job store.
+type jobStore interface {
+	Glob(pattern string) ([]string, error)       // return file paths matching the pattern, same as filepath.Glob
+	ReadFile(path string) ([]byte, error)        // return file content, fs.ErrNotExist error means file not exist
+	WriteFile(path string, data []byte) error    // create new or truncate existing file and write content
+	Rename(srcPath string, dstPath string) error // move file to new path, fs.ErrNotExist error means source file not exist
+	Remove(path string) error                    // delete file, fs.ErrNotExist error means file not exist
+	ModTime(path string) (time.Time, error)      // return file modification time
+}
+
+// job control state storage: by default it is job/active, job/queue, job/history and job/state directories
+var theJobStore jobStore = fileJobStore{}
+
+// fileJobStore is job control state stored as files in job control directory
+type fileJobStore struct{}
+
+// Glob return job files matching the pattern, pattern syntax is the same as filepath.Glob
+func (fileJobStore) Glob(pattern string) ([]string, error) {
+	return filepath.Glob(pattern)
+}
+
+// ReadFile return job file content
+func (fileJobStore) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// WriteFile create new or truncate existing job file and write content
+func (fileJobStore) WriteFile(path string, data []byte) error {
+	return os.WriteFile(path, data, 0644)
+}
+
+// Rename move job file to new path
+func (fileJobStore) Rename(srcPath string, dstPath string) error {
+	return os.Rename(srcPath, dstPath)
+}
+
+// Remove delete job file
+func (fileJobStore) Remove(path string) error {
+	return os.Remove(path)
+}
+
+// ModTime return job file modification time
+func (fileJobStore) ModTime(path string) (time.Time, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+// return list of job files by pattern, on error log error message
+func jobFilesByPattern(ptrn string, msg string) []string {
+
+	fLst, err := theJobStore.Glob(ptrn)
+	if err != nil {
+		omppLog.Log(msg, ": ", ptrn)
+		return []string{}
+	}
+	return fLst
+}
+
+// return true if job file exist
+func jobFileExist(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := theJobStore.ModTime(path)
+	return err == nil
+}
+
+// Delete job file and log path if isLog is true, return false on delete error.
+func jobFileDelete(isLog bool, path string) bool {
+	if path == "" {
+		return true
+	}
+	if isLog {
+		omppLog.Log("Delete: ", path)
+	}
+	if e := theJobStore.Remove(path); e != nil && !errors.Is(e, fs.ErrNotExist) {
+		omppLog.Log(e)
+		return false
+	}
+	return true
+}
+
+// Move job file to new location and log it if isLog is true, return false on move error.
+func jobFileMove(isLog bool, srcPath string, dstPath string) bool {
+	if srcPath == "" || dstPath == "" {
+		return false
+	}
+	if isLog {
+		omppLog.Log("Move: ", srcPath, " To: ", dstPath)
+	}
+	if e := theJobStore.Rename(srcPath, dstPath); e != nil && !errors.Is(e, fs.ErrNotExist) {
+		omppLog.Log(e)
+		return false
+	}
+	return true
+}
+
+// Create or truncate existing job file and log path if isLog is true, return false on create error.
+func jobFileCreateEmpty(isLog bool, path string) bool {
+	if isLog {
+		omppLog.Log("Create: ", path)
+	}
+	if e := theJobStore.WriteFile(path, []byte{}); e != nil {
+		omppLog.Log(e)
+		return false
+	}
+	return true
+}
+
+// Copy job file into regular file, e.g. into job/past shadow history, return false on error.
+func jobFileCopyOut(srcPath, dstPath string) bool {
+
+	bt, err := theJobStore.ReadFile(srcPath)
+	if err == nil {
+		err = os.WriteFile(dstPath, bt, 0644)
+	}
+	if err != nil {
+		omppLog.Log(err)
+		return false
+	}
+	return true
+}
+
+// read job file json content into destination pointer. Return false if file not exist or empty.
+func jobFromJsonFile(path string, dst interface{}) (bool, error) {
+
+	bt, err := theJobStore.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil // return: json file not exist
+		}
+		return false, errors.New("json file read error: " + err.Error())
+	}
+	if len(bt) <= 0 {
+		return false, nil // return "not exist" if json file empty
+	}
+	return helper.FromJson(bt, dst)
+}
+
+// convert source to indented json and write into job file.
+func jobToJsonFile(path string, src interface{}) error {
+
+	s, err := helper.ToJsonIndent(src)
+	if err != nil {
+		return err
+	}
+	if err = theJobStore.WriteFile(path, []byte(s+"\n")); err != nil {
+		return errors.New("json file create error: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// dbJobStore is job control state stored in database table oms_job_file: file path, content and modification time.
+//
+// It allows multiple oms instances on different servers to share the same job queue without shared file system.
+// Each file operation is done in a single transaction, e.g.: job file move from queue to history.
+// Move and delete check count of affected rows and return fs.ErrNotExist if job file row already deleted,
+// it allows to claim queue job atomically: only one oms instance can delete queue job row, other instances get an error.
+// File path is stored relative to job control directory, e.g.: queue/2022_07_05_19_55_38_111-#-_4040-#-....json
+type dbJobStore struct {
+	dbConn *sql.DB // job control database connection
+	jobDir string  // job control directory, root of job files path
+}
+
+// job control db table name
+const jobDbTable = "oms_job_file"
+
+// open job control database and create job files table if not exist.
+// Only SQLite and PostgreSQL databases are supported as job control database.
+func openDbJobStore(dbConnStr, dbDriver, jobDir string) (*dbJobStore, error) {
+
+	dbConn, facet, err := db.Open(dbConnStr, dbDriver, true)
+	if err != nil {
+		return nil, errors.New("Error at job control database open: " + err.Error())
+	}
+	if facet != db.SqliteFacet && facet != db.PgSqlFacet {
+		db.Close(dbConn)
+		return nil, errors.New("Error: job control database must be SQLite or PostgreSQL, found: " + facet.String())
+	}
+
+	err = db.Update(dbConn,
+		"CREATE TABLE IF NOT EXISTS "+jobDbTable+" ("+
+			" file_path    VARCHAR(255) NOT NULL,"+
+			" file_content TEXT         NOT NULL,"+
+			" mod_ts       BIGINT       NOT NULL,"+
+			" PRIMARY KEY (file_path))")
+	if err != nil {
+		db.Close(dbConn)
+		return nil, errors.New("Error at create job control table: " + err.Error())
+	}
+	return &dbJobStore{dbConn: dbConn, jobDir: jobDir}, nil
+}
+
+// close job control database connection
+func (js *dbJobStore) close() {
+	if err := db.Close(js.dbConn); err != nil {
+		omppLog.Log("Error at job control database close: ", err.Error())
+	}
+}
+
+// return job file path relative to job control directory and slashed, e.g.: queue/2022_07_05_19_55_38_111-#-....json
+func (js *dbJobStore) relPath(filePath string) (string, error) {
+
+	p, err := filepath.Rel(js.jobDir, filePath)
+	if err != nil || p == "." || strings.HasPrefix(p, "..") {
+		return "", errors.New("invalid job file path: " + filePath)
+	}
+	return filepath.ToSlash(p), nil
+}
+
+// Glob return job files matching the pattern, pattern syntax is the same as filepath.Glob.
+// Result sorted by file path.
+func (js *dbJobStore) Glob(pattern string) ([]string, error) {
+
+	rp, err := js.relPath(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = path.Match(rp, ""); err != nil {
+		return nil, err
+	}
+
+	fLst := []string{}
+	err = db.SelectRows(js.dbConn,
+		"SELECT file_path FROM "+jobDbTable+" WHERE file_path LIKE "+db.ToQuoted(path.Dir(rp)+"/%")+" ORDER BY 1",
+		func(rows *sql.Rows) error {
+			var p string
+			if err := rows.Scan(&p); err != nil {
+				return err
+			}
+			if ok, _ := path.Match(rp, p); ok {
+				fLst = append(fLst, filepath.Join(js.jobDir, filepath.FromSlash(p)))
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return fLst, nil
+}
+
+// ReadFile return job file content, fs.ErrNotExist error returned if file not exist
+func (js *dbJobStore) ReadFile(filePath string) ([]byte, error) {
+
+	rp, err := js.relPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var s string
+	err = db.SelectFirst(js.dbConn,
+		"SELECT file_content FROM "+jobDbTable+" WHERE file_path = "+db.ToQuoted(rp),
+		func(row *sql.Row) error {
+			return row.Scan(&s)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, &fs.PathError{Op: "read", Path: filePath, Err: fs.ErrNotExist}
+	case err != nil:
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// WriteFile create new or replace existing job file content
+func (js *dbJobStore) WriteFile(filePath string, data []byte) error {
+
+	rp, err := js.relPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	trx, err := js.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	err = db.TrxUpdate(trx, "DELETE FROM "+jobDbTable+" WHERE file_path = "+db.ToQuoted(rp))
+	if err == nil {
+		err = db.TrxUpdate(trx,
+			"INSERT INTO "+jobDbTable+" (file_path, file_content, mod_ts) VALUES ("+
+				db.ToQuoted(rp)+", "+
+				db.ToQuoted(string(data))+", "+
+				strconv.FormatInt(time.Now().UnixMilli(), 10)+")")
+	}
+	if err != nil {
+		trx.Rollback()
+		return err
+	}
+	return trx.Commit()
+}
+
+// Rename move job file to new path, fs.ErrNotExist error returned if source file not exist.
+// If destination file exist then it is replaced.
+func (js *dbJobStore) Rename(srcPath string, dstPath string) error {
+
+	src, err := js.relPath(srcPath)
+	if err != nil {
+		return err
+	}
+	dst, err := js.relPath(dstPath)
+	if err != nil {
+		return err
+	}
+
+	trx, err := js.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	err = db.TrxUpdate(trx, "DELETE FROM "+jobDbTable+" WHERE file_path = "+db.ToQuoted(dst))
+
+	n := int64(0)
+	if err == nil {
+		n, err = trxExecCount(trx, "UPDATE "+jobDbTable+" SET file_path = "+db.ToQuoted(dst)+" WHERE file_path = "+db.ToQuoted(src))
+	}
+	if err == nil && n <= 0 {
+		err = &fs.PathError{Op: "rename", Path: srcPath, Err: fs.ErrNotExist}
+	}
+	if err != nil {
+		trx.Rollback()
+		return err
+	}
+	return trx.Commit()
+}
+
+// Remove delete job file, fs.ErrNotExist error returned if file not exist
+func (js *dbJobStore) Remove(filePath string) error {
+
+	rp, err := js.relPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	trx, err := js.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	n, err := trxExecCount(trx, "DELETE FROM "+jobDbTable+" WHERE file_path = "+db.ToQuoted(rp))
+	if err != nil {
+		trx.Rollback()
+		return err
+	}
+	if err = trx.Commit(); err != nil {
+		return err
+	}
+	if n <= 0 {
+		return &fs.PathError{Op: "remove", Path: filePath, Err: fs.ErrNotExist}
+	}
+	return nil
+}
+
+// ModTime return job file modification time, fs.ErrNotExist error returned if file not exist
+func (js *dbJobStore) ModTime(filePath string) (time.Time, error) {
+
+	rp, err := js.relPath(filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ts := int64(0)
+	err = db.SelectFirst(js.dbConn,
+		"SELECT mod_ts FROM "+jobDbTable+" WHERE file_path = "+db.ToQuoted(rp),
+		func(row *sql.Row) error {
+			return row.Scan(&ts)
+		})
+	switch {
+	case err == sql.ErrNoRows:
+		return time.Time{}, &fs.PathError{Op: "stat", Path: filePath, Err: fs.ErrNotExist}
+	case err != nil:
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ts), nil
+}
+
+// execute sql statement in transaction scope and return number of rows affected
+func trxExecCount(trx *sql.Tx, query string) (int64, error) {
+
+	omppLog.LogSql(query)
+
+	rs, err := trx.Exec(query)
+	if err != nil {
+		return 0, err
+	}
+	return rs.RowsAffected()
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// tree of json response fields to select, nil child means select entire field value
+type jsonFieldTree map[string]jsonFieldTree
+
+// get list of json response fields from url parameter ?fields=Model.Name,ParamTxt.Param.Name,ParamTxt.ParamDescr
+//
+// Each field is a dot separated path of json object keys, arrays are transparent:
+// ParamTxt.Param.Name select Name of Param of each element of ParamTxt array.
+// If field is a prefix of other field, e.g.: ParamTxt and ParamTxt.Param.Name, then entire ParamTxt is selected.
+func getFieldsRequestParam(r *http.Request) []string {
+
+	fl := []string{}
+	for _, f := range helper.ParseCsvLine(r.URL.Query().Get("fields"), ',') {
+		if f = strings.Trim(f, ". "); f != "" {
+			fl = append(fl, f)
+		}
+	}
+	return fl
+}
+
+// make tree of json fields from list of dot separated field paths
+func makeJsonFieldTree(fields []string) jsonFieldTree {
+
+	ft := jsonFieldTree{}
+
+	for _, f := range fields {
+
+		t := ft
+		keys := strings.Split(f, ".")
+
+		for k, key := range keys {
+
+			ct, ok := t[key]
+			if ok && ct == nil {
+				break // entire parent field already selected
+			}
+			if k >= len(keys)-1 {
+				t[key] = nil // last key in the path: select entire field value
+				break
+			}
+			if !ok {
+				ct = jsonFieldTree{}
+				t[key] = ct
+			}
+			t = ct
+		}
+	}
+	return ft
+}
+
+// select fields from json value decoded into interface{}: objects are map[string]interface{} and arrays are []interface{}
+func selectJsonFields(src interface{}, ft jsonFieldTree) interface{} {
+
+	switch v := src.(type) {
+	case map[string]interface{}:
+		dst := map[string]interface{}{}
+		for key, ct := range ft {
+			if cv, ok := v[key]; ok {
+				if ct == nil {
+					dst[key] = cv
+				} else {
+					dst[key] = selectJsonFields(cv, ct)
+				}
+			}
+		}
+		return dst
+	case []interface{}:
+		dst := make([]interface{}, len(v))
+		for k := range v {
+			dst[k] = selectJsonFields(v[k], ft)
+		}
+		return dst
+	}
+	return src // scalar or null value: nothing to select
+}
+
+// jsonFieldsResponse set json response headers and writes src as json into w response writer.
+// If fields list is not empty then only those fields of src are written, see getFieldsRequestParam().
+// On error it writes 500 internal server error response.
+func jsonFieldsResponse(w http.ResponseWriter, r *http.Request, src interface{}, fields []string) {
+
+	if len(fields) <= 0 {
+		jsonResponse(w, r, src)
+		return
+	}
+
+	bt, err := json.Marshal(src)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonFieldsResponseBytes(w, r, bt, fields)
+}
+
+// jsonFieldsResponseBytes set json response headers and writes only selected fields of src json bytes into w response writer.
+// On error it writes 500 internal server error response.
+func jsonFieldsResponseBytes(w http.ResponseWriter, r *http.Request, src []byte, fields []string) {
+
+	// decode json numbers as json.Number to write it back without conversion to float64
+	var v interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+
+	if err := dec.Decode(&v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, r, selectJsonFields(v, makeJsonFieldTree(fields)))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// name of session cookie to override browser language preferences, set by PUT /api/user/lang/:lang
+const langCookieName = "om-lang"
+
+// parseAcceptLanguage return list of languages from Accept-Language header sorted by quality values, q=0 languages excluded.
+// Invalid languages are skipped, unlike language.ParseAcceptLanguage() which return empty list.
+func parseAcceptLanguage(hdr string) []language.Tag {
+
+	tags, _, err := language.ParseAcceptLanguage(hdr)
+	if err == nil {
+		return tags
+	}
+
+	// parse each language separately and skip invalid languages
+	tags = []language.Tag{}
+	qs := []float32{}
+
+	for _, s := range strings.Split(hdr, ",") {
+
+		t, q, e := language.ParseAcceptLanguage(s)
+		if e != nil || len(t) <= 0 {
+			continue
+		}
+		n := len(tags)
+		for n > 0 && qs[n-1] < q[0] {
+			n-- // keep languages sorted by quality value, stable for equal quality
+		}
+		tags = append(tags[:n], append([]language.Tag{t[0]}, tags[n:]...)...)
+		qs = append(qs[:n], append([]float32{q[0]}, qs[n:]...)...)
+	}
+	return tags
+}
+
+// return language code from session cookie, set by PUT /api/user/lang/:lang, or empty "" string if there is no cookie
+func getLangCookie(r *http.Request) string {
+	if c, err := r.Cookie(langCookieName); err == nil {
+		if t := language.Make(c.Value); t != language.Und {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// lookupLangCode return index of language code matched to preferred languages using RFC 4647 lookup or -1 if there is no match.
+//
+// Each preferred language is checked in order of preference, from most to least preferred,
+// by progressively truncating language tag: zh-Hant-CN-x-private, zh-Hant-CN, zh-Hant, zh.
+// Language codes compared case-insensitive and as canonical language tags: EN is the same as en.
+func lookupLangCode(preferredLang []language.Tag, langCodes []string) int {
+
+	ct := make([]string, len(langCodes))
+	for k := range langCodes {
+		ct[k] = strings.ToLower(language.Make(langCodes[k]).String())
+	}
+
+	for _, pt := range preferredLang {
+
+		for s := strings.ToLower(pt.String()); s != "" && s != "und"; {
+
+			for k := range langCodes {
+				if s == ct[k] || strings.EqualFold(s, langCodes[k]) {
+					return k
+				}
+			}
+
+			// truncate tag: remove last subtag, if it is single character subtag then remove it as well
+			n := strings.LastIndex(s, "-")
+			if n < 0 {
+				break
+			}
+			s = s[:n]
+			if n = strings.LastIndex(s, "-"); n >= 0 && n == len(s)-2 {
+				s = s[:n]
+			}
+		}
+	}
+	return -1
+}
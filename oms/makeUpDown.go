@@ -299,7 +299,16 @@ func makeRelDbCopyArgs(workDir, targetPath, dbPath string) (string, string, erro
 // 2. start dbcopy to export model data into pack it into .zip file.
 // 3. if dbcopy done OK then rename log file into model......ready.download.log else into model......error.download.log
 func makeDownload(baseName string, cmd *exec.Cmd, cmdMsg string, logPath string) {
-	runUpDownDbcopy("download", theCfg.downloadDir, baseName, cmd, cmdMsg, logPath)
+	runUpDownDbcopy("download", theCfg.downloadDir, baseName, cmd, cmdMsg, logPath, nil)
+}
+
+// makeRunDownload invoke dbcopy to create model run download directory and .zip file,
+// same as makeDownload() and after dbcopy done append model run artifacts into download directory and .zip file.
+func makeRunDownload(baseName string, runDigest string, cmd *exec.Cmd, cmdMsg string, logPath string) {
+
+	runUpDownDbcopy("download", theCfg.downloadDir, baseName, cmd, cmdMsg, logPath, func() error {
+		return appendRunArtifacts(runDigest, filepath.Join(theCfg.downloadDir, baseName))
+	})
 }
 
 // makeUpload invoke dbcopy to create model upload directory and .zip file:
@@ -307,7 +316,7 @@ func makeDownload(baseName string, cmd *exec.Cmd, cmdMsg string, logPath string)
 // 2. start dbcopy to unzip uploaded file and import into it model database.
 // 3. if dbcopy done OK then rename log file into model......ready.upload.log else into model......error.upload.log
 func makeUpload(baseName string, cmd *exec.Cmd, cmdMsg string, logPath string) {
-	runUpDownDbcopy("upload", theCfg.uploadDir, baseName, cmd, cmdMsg, logPath)
+	runUpDownDbcopy("upload", theCfg.uploadDir, baseName, cmd, cmdMsg, logPath, nil)
 }
 
 // runUpDownDbcopy invoke dbcopy to export from dbd into download .zip or import from uploaded .zip into model database.
@@ -315,8 +324,9 @@ func makeUpload(baseName string, cmd *exec.Cmd, cmdMsg string, logPath string) {
 // 2. if download then delete existing model.xyz.zip
 // 3. if download: start dbcopy to export model data into .zip file.
 // 3. if upload: stsrt dbopy to unzip uploaded file and import into it model database.
-// 4. if dbcopy done OK then rename log file into model......ready.up-or-down.log else into model......error.up-or-down.log
-func runUpDownDbcopy(upDown string, upDownDir string, baseName string, cmd *exec.Cmd, cmdMsg string, logPath string) {
+// 4. if dbcopy done OK and afterCopy() is not nil then call afterCopy(), e.g. to append model run artifacts.
+// 5. if dbcopy done OK then rename log file into model......ready.up-or-down.log else into model......error.up-or-down.log
+func runUpDownDbcopy(upDown string, upDownDir string, baseName string, cmd *exec.Cmd, cmdMsg string, logPath string, afterCopy func() error) {
 
 	// delete existing (previous copy) of download or upload data
 	basePath := filepath.Join(upDownDir, baseName)
@@ -411,6 +421,13 @@ func runUpDownDbcopy(upDown string, upDownDir string, baseName string, cmd *exec
 	if !isLogOk {
 		omppLog.Log("Warning: dbcopy log output may be incomplete")
 	}
+	if afterCopy != nil {
+		if e = afterCopy(); e != nil {
+			writeToCmdLog(logPath, true, e.Error())
+			renameToUpDownErrorLog(upDown, logPath, "Error at: "+baseName, e)
+			return
+		}
+	}
 
 	// all done, rename log file on success: model......progress.up-or-down.log into model......ready.up-or-down.log
 	os.Rename(logPath, strings.TrimSuffix(logPath, ".progress."+upDown+".log")+".ready."+upDown+".log")
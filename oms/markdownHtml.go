@@ -0,0 +1,201 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// markdown inline elements: links, bold and italic text
+var (
+	mdLinkRx   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRx   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdItalicRx = regexp.MustCompile(`\*([^*]+)\*`)
+	mdOlRx     = regexp.MustCompile(`^[0-9]+[.)]\s+`)
+)
+
+// markdownToHtml convert markdown text into sanitized HTML fragment.
+//
+// It is a subset of markdown: headings, paragraphs, lists, block quotes, horizontal rules, code blocks,
+// inline code, bold, italic and links. Any HTML in markdown source is escaped and rendered as text,
+// links allowed only to http, https or mailto URLs or relative URLs.
+func markdownToHtml(src string) string {
+
+	var sb strings.Builder
+
+	para := []string{} // lines of current paragraph
+	list := ""         // current list tag: ul or ol, empty if not a list
+	isQuote := false   // if true then inside of block quote
+	isCode := false    // if true then inside of fenced code block
+
+	flushPara := func() {
+		if len(para) > 0 {
+			sb.WriteString("<p>" + mdInline(strings.Join(para, " ")) + "</p>\n")
+			para = para[:0]
+		}
+	}
+	closeBlock := func() {
+		flushPara()
+		if list != "" {
+			sb.WriteString("</" + list + ">\n")
+			list = ""
+		}
+		if isQuote {
+			sb.WriteString("</blockquote>\n")
+			isQuote = false
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+
+		s := strings.TrimSpace(line)
+
+		// fenced code block: content is escaped as is
+		if strings.HasPrefix(s, "```") {
+			if !isCode {
+				closeBlock()
+				sb.WriteString("<pre><code>")
+			} else {
+				sb.WriteString("</code></pre>\n")
+			}
+			isCode = !isCode
+			continue
+		}
+		if isCode {
+			sb.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		// block quote: > text
+		isQ := strings.HasPrefix(s, ">")
+		if isQ {
+			s = strings.TrimSpace(strings.TrimPrefix(s, ">"))
+		}
+		if isQ != isQuote {
+			closeBlock()
+			if isQ {
+				sb.WriteString("<blockquote>\n")
+				isQuote = true
+			}
+		}
+
+		switch {
+		case s == "": // empty line: end of paragraph or list
+			if isQuote {
+				flushPara()
+			} else {
+				closeBlock()
+			}
+
+		case strings.HasPrefix(s, "#"): // heading: # text
+
+			n := len(s) - len(strings.TrimLeft(s, "#"))
+			if n > 6 || len(s) > n && s[n] != ' ' {
+				para = append(para, s) // it is not a heading
+				break
+			}
+			flushPara()
+			h := "h" + string(rune('0'+n))
+			sb.WriteString("<" + h + ">" + mdInline(strings.TrimSpace(s[n:])) + "</" + h + ">\n")
+
+		case s == "---" || s == "***" || s == "___": // horizontal rule
+			closeBlock()
+			sb.WriteString("<hr>\n")
+
+		case strings.HasPrefix(s, "- ") || strings.HasPrefix(s, "* ") || strings.HasPrefix(s, "+ "): // unordered list item
+			mdListItem(&sb, &list, "ul", s[2:], flushPara)
+
+		case mdOlRx.MatchString(s): // ordered list item
+			mdListItem(&sb, &list, "ol", mdOlRx.ReplaceAllString(s, ""), flushPara)
+
+		default:
+			if list != "" && len(para) <= 0 {
+				closeBlock() // paragraph after the list
+			}
+			para = append(para, s)
+		}
+	}
+
+	if isCode {
+		sb.WriteString("</code></pre>\n")
+	}
+	closeBlock()
+
+	return sb.String()
+}
+
+// write list item, start new list if current list tag is different
+func mdListItem(sb *strings.Builder, list *string, tag string, text string, flushPara func()) {
+
+	flushPara()
+	if *list != tag {
+		if *list != "" {
+			sb.WriteString("</" + *list + ">\n")
+		}
+		sb.WriteString("<" + tag + ">\n")
+		*list = tag
+	}
+	sb.WriteString("<li>" + mdInline(strings.TrimSpace(text)) + "</li>\n")
+}
+
+// convert markdown inline elements into HTML: `code`, [link](url), **bold**, *italic*, any other text is escaped
+func mdInline(src string) string {
+
+	var sb strings.Builder
+
+	// split by ` backticks: odd parts are inline code
+	for k, s := range strings.Split(src, "`") {
+
+		if k%2 == 1 {
+			sb.WriteString("<code>" + html.EscapeString(s) + "</code>")
+			continue
+		}
+		s = html.EscapeString(s)
+
+		s = mdLinkRx.ReplaceAllStringFunc(s, func(m string) string {
+			p := mdLinkRx.FindStringSubmatch(m)
+			if !isSafeLink(p[2]) {
+				return p[1] // link is not allowed: use text only
+			}
+			return "<a href=\"" + p[2] + "\">" + p[1] + "</a>"
+		})
+		s = mdBoldRx.ReplaceAllString(s, "<strong>$1</strong>")
+		s = mdItalicRx.ReplaceAllString(s, "<em>$1</em>")
+
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+// return true if link url is relative or it is http, https or mailto url.
+// Link is HTML entity decoded until no entities left, e.g.: javascript&amp;#58; is javascript: scheme.
+func isSafeLink(link string) bool {
+
+	for k := 0; ; k++ {
+		s := html.UnescapeString(link)
+		if s == link {
+			break
+		}
+		if k >= 8 {
+			return false // too many levels of entities
+		}
+		link = s
+	}
+	if strings.IndexFunc(link, func(r rune) bool { return r < ' ' || r == 0x7f }) >= 0 {
+		return false // control characters can hide the scheme, e.g.: java\tscript:
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
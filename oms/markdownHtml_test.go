@@ -0,0 +1,75 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHtml(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"paragraph", "some *text* and **bold**", "<p>some <em>text</em> and <strong>bold</strong></p>\n"},
+		{"heading", "## Title", "<h2>Title</h2>\n"},
+		{"list", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"},
+		{"link", "[site](https://example.com/a?b=1)", "<p><a href=\"https://example.com/a?b=1\">site</a></p>\n"},
+		{"relative link", "[doc](doc/index.html)", "<p><a href=\"doc/index.html\">doc</a></p>\n"},
+		{"raw script", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"escaped attributes", `<img src=x onerror="alert(1)">`, "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>\n"},
+		{"inline code", "`<b>` text", "<p><code>&lt;b&gt;</code> text</p>\n"},
+		{"javascript link", "[x](javascript:alert(1))", "<p>x)</p>\n"},
+		{"mixed case javascript link", "[x](JaVaScRiPt:alert(1))", "<p>x)</p>\n"},
+		{"entity encoded scheme", "[x](javascript&#58;alert(1))", "<p>x)</p>\n"},
+		{"double entity encoded scheme", "[x](javascript&amp;#58;alert(1))", "<p>x)</p>\n"},
+		{"hex entity encoded scheme", "[x](javascript&#x3A;alert(1))", "<p>x)</p>\n"},
+		{"data link", "[x](data:text/html;base64,PHNjcmlwdD4=)", "<p>x</p>\n"},
+		{"quote breaking link", `[x](a"onmouseover=alert(1))`, "<p><a href=\"a&#34;onmouseover=alert(1\">x</a>)</p>\n"},
+		{"code fence", "```\n<b>bold</b>\n```\ntext", "<pre><code>&lt;b&gt;bold&lt;/b&gt;\n</code></pre>\n<p>text</p>\n"},
+		{"unterminated code fence", "```\n<script>alert(1)</script>", "<pre><code>&lt;script&gt;alert(1)&lt;/script&gt;\n</code></pre>\n"},
+	}
+
+	for _, tc := range tests {
+		s := markdownToHtml(tc.src)
+		if s != tc.expected {
+			t.Errorf("%s: INVALID result:\n%s\nexpected:\n%s", tc.name, s, tc.expected)
+		}
+		if strings.Contains(s, "<script") || strings.Contains(s, "<img") || strings.Contains(s, "\"onmouseover") {
+			t.Errorf("%s: unsafe result: %s", tc.name, s)
+		}
+	}
+}
+
+func TestIsSafeLink(t *testing.T) {
+
+	tests := []struct {
+		link   string
+		isSafe bool
+	}{
+		{"https://example.com", true},
+		{"http://example.com/a/b.html", true},
+		{"mailto:user@example.com", true},
+		{"index.html", true},
+		{"/doc/index.html#part", true},
+		{"javascript:alert(1)", false},
+		{"JaVaScRiPt:alert(1)", false},
+		{"javascript&#58;alert(1)", false},
+		{"javascript&amp;#58;alert(1)", false},
+		{"javascript&#x3a;alert(1)", false},
+		{"java\tscript:alert(1)", false},
+		{"vbscript:msgbox(1)", false},
+		{"data:text/html,<script>", false},
+		{"file:///etc/passwd", false},
+	}
+
+	for _, tc := range tests {
+		if isSafe := isSafeLink(tc.link); isSafe != tc.isSafe {
+			t.Errorf("isSafeLink(%q): %v, expected: %v", tc.link, isSafe, tc.isSafe)
+		}
+	}
+}
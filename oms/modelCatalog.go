@@ -36,21 +36,24 @@ type ModelCatalogConfig struct {
 
 // modelDef is database connection and model metadata database rows
 type modelDef struct {
-	dbConn        *sql.DB           // database connection
-	binDir        string            // database and .exe directory: directory part of models/bin/dir/sub/model.sqlite
-	dbPath        string            // absolute path to sqlite database file: /root/models/bin/dir/sub/model.sqlite
-	relPath       string            // relative path to sqlite database file: relative to model root and slashed: dir/sub/model.sqlite
-	logDir        string            // model log directory
-	isLogDir      bool              // if true then use model log directory for model run logs
-	isIni         bool              // if true the default ini file exists: models/bin/dir/sub/modelName.ini
-	meta          *db.ModelMeta     // model metadata, language-neutral part, should not be nil
-	isTxtMetaFull bool              // if true then ModelTxtMeta fully loaded else only []ModelTxtRow
-	txtMeta       *db.ModelTxtMeta  // if not nil then language-specific model metadata
-	langCodes     []string          // language codes, first is default language
-	matcher       language.Matcher  // matcher to search text by language
-	langMeta      *db.LangMeta      // list of languages: one list per db connection, order of languages NOT the same as language codes
-	modelWord     *db.ModelWordMeta // if not nil then list of model words, order of languages NOT the same as language codes
-	extra         string            // if not empty then model extra content from models/bin/dir/model.extra.json
+	dbConn        *sql.DB                   // database connection
+	binDir        string                    // database and .exe directory: directory part of models/bin/dir/sub/model.sqlite
+	dbPath        string                    // absolute path to sqlite database file: /root/models/bin/dir/sub/model.sqlite
+	relPath       string                    // relative path to sqlite database file: relative to model root and slashed: dir/sub/model.sqlite
+	logDir        string                    // model log directory
+	isLogDir      bool                      // if true then use model log directory for model run logs
+	isIni         bool                      // if true the default ini file exists: models/bin/dir/sub/modelName.ini
+	meta          *db.ModelMeta             // model metadata, language-neutral part, should not be nil
+	isTxtMetaFull bool                      // if true then ModelTxtMeta fully loaded else only []ModelTxtRow
+	txtMeta       *db.ModelTxtMeta          // if not nil then language-specific model metadata
+	langCodes     []string                  // language codes, first is default language
+	matcher       language.Matcher          // matcher to search text by language
+	langMeta      *db.LangMeta              // list of languages: one list per db connection, order of languages NOT the same as language codes
+	modelWord     *db.ModelWordMeta         // if not nil then list of model words, order of languages NOT the same as language codes
+	extra         string                    // if not empty then model extra content from models/bin/dir/model.extra.json
+	landing       map[string]string         // model landing files: language code to file path, default landing file has "" code
+	loadTime      int64                     // unix nanoseconds when model metadata or text loaded into catalog, it is a part of ETag
+	groupTree     map[string]groupTreeCache // cached parameters and output tables groups trees, key is language and tree kind
 }
 
 // modelBasic is basic model info: name, digest, files location
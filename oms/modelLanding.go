@@ -0,0 +1,152 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// find model landing files in model directory: default landing file and language-specific files.
+//
+// Landing file path is relative to model directory, by default it is doc/README.md,
+// language-specific files have language code before extension: doc/README.FR.md or doc/README.fr-CA.md
+// Return map of language code to file path, default landing file has empty "" language code.
+// Return nil if there are no landing files.
+func findLandingFiles(binDir string) map[string]string {
+
+	if theCfg.landingFile == "" || binDir == "" {
+		return nil
+	}
+	fp := filepath.Join(binDir, theCfg.landingFile)
+	ext := filepath.Ext(fp)
+	stem := strings.TrimSuffix(fp, ext)
+
+	lm := map[string]string{}
+
+	if fileExist(fp) {
+		lm[""] = fp
+	}
+	if pLst, err := filepath.Glob(globEscape(stem) + ".*" + ext); err == nil {
+
+		for _, p := range pLst {
+
+			lc := strings.TrimSuffix(strings.TrimPrefix(p, stem+"."), ext)
+			if lc == "" || strings.Contains(lc, ".") {
+				continue
+			}
+			if t, e := language.Parse(lc); e != nil || t == language.Und {
+				continue // it is not a language code
+			}
+			lm[lc] = p
+		}
+	}
+	if len(lm) <= 0 {
+		return nil
+	}
+	return lm
+}
+
+// escape glob pattern special characters in file path
+func globEscape(fp string) string {
+	r := strings.NewReplacer("*", "\\*", "?", "\\?", "[", "\\[")
+	if filepath.Separator == '\\' {
+		r = strings.NewReplacer("*", "[*]", "?", "[?]", "[", "[[]")
+	}
+	return r.Replace(fp)
+}
+
+// LandingFileByDigestOrName return model landing file path which is the best match to preferred languages.
+// If there is no language-specific file for preferred languages then return default landing file.
+// Return false if model not found or model does not have landing files.
+func (mc *ModelCatalog) LandingFileByDigestOrName(dn string, preferredLang []language.Tag) (string, bool) {
+
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	idx, ok := mc.indexByDigestOrName(dn)
+	if !ok || len(mc.modelLst[idx].landing) <= 0 {
+		return "", false // model not found or there are no landing files
+	}
+	lm := mc.modelLst[idx].landing
+
+	// match preferred languages to language-specific landing files
+	codes := []string{}
+	for lc := range lm {
+		if lc != "" {
+			codes = append(codes, lc)
+		}
+	}
+	slices.Sort(codes)
+
+	tags := make([]language.Tag, len(codes))
+	for k := range codes {
+		tags[k] = language.Make(codes[k])
+	}
+	if len(tags) > 0 {
+		_, np, conf := language.NewMatcher(tags).Match(preferredLang...)
+		if conf != language.No && np >= 0 && np < len(codes) {
+			return lm[codes[np]], true
+		}
+	}
+
+	// use default landing file or, if there is no default, the first language-specific file
+	if fp, ok := lm[""]; ok {
+		return fp, true
+	}
+	return lm[codes[0]], true
+}
+
+// landing content cache: markdown file content or sanitized HTML rendered from markdown
+var theLandingCache = struct {
+	sync.Mutex
+	items map[string]landingItem // key is file path and content kind: .md or .html
+}{
+	items: map[string]landingItem{},
+}
+
+// landing content cache item
+type landingItem struct {
+	modTime time.Time // file modification time
+	size    int64     // file size
+	content []byte    // file content or rendered HTML
+}
+
+// return landing file content as is or rendered as sanitized HTML if isHtml is true.
+// Content is cached and cache item is valid until file modification time or size changed.
+func landingContent(fp string, isHtml bool) ([]byte, time.Time, error) {
+
+	fi, err := os.Stat(fp)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	key := fp + ":.md"
+	if isHtml {
+		key = fp + ":.html"
+	}
+
+	theLandingCache.Lock()
+	defer theLandingCache.Unlock()
+
+	if c, ok := theLandingCache.items[key]; ok && c.modTime.Equal(fi.ModTime()) && c.size == fi.Size() {
+		return c.content, c.modTime, nil
+	}
+
+	bt, err := os.ReadFile(fp)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if isHtml {
+		bt = []byte(markdownToHtml(string(bt)))
+	}
+	theLandingCache.items[key] = landingItem{modTime: fi.ModTime(), size: fi.Size(), content: bt}
+
+	return bt, fi.ModTime(), nil
+}
@@ -48,6 +48,12 @@ Following arguments are supported by oms:
 	-oms.ModelDocDir models/doc
 	Models documentation directory, default: models/doc (relative to the OMS root directory).
 
+	-oms.ModelLanding doc/README.md
+	Model landing page file, default: doc/README.md (relative to the model directory).
+	Language-specific landing files have language code before extension, e.g.: doc/README.FR.md
+	Landing files are found automatically for each model and served as markdown or as sanitized HTML.
+	Use empty value to disable model landing pages.
+
 	-oms.HtmlDir html
 	The front-end UI directory, default: html (relative to the OMS root directory).
 	Not used if -oms.ApiOnly is specified.
@@ -62,12 +68,31 @@ Following arguments are supported by oms:
 	It allows managing computational resources (CPUs) and organizes a model run queue.
 	The default value is an empty string, which disables job control.
 
+	-oms.JobDb "Database=job/job.sqlite; Timeout=86400; OpenMode=Create;"
+	-oms.JobDbDriver SQLite
+	Job control database connection string and driver name, default driver: SQLite.
+	If this is specified, job queue, active jobs, job history and job state are stored in the database
+	instead of active, queue, history and state subdirectories of job control directory.
+	It allows multiple oms instances on different servers to share the same job queue,
+	without the use of shared file system, e.g. NFS.
+	Only SQLite and PostgreSQL (ODBC driver) databases are supported.
+	Job control directory is still required: it contains job.ini file and, optional, past subdirectory.
+
 	-oms.Name someName
 	An instance name used for job control. If empty, it is derived from the address to listen on.
 
 	-oms.HomeDir models/home
 	A user “home” directory to store files and settings (relative to the OMS root directory).
 	Default is empty, which disables the use of a home directory.
+	Output table bookmarks created by POST /api/bookmark are stored in home/bookmark directory,
+	bookmark short id can be shared to open the same table view by GET /api/bookmark/:id.
+	Model run artifacts, e.g. plots and reports of calibration scripts, are stored in home/artifact directory.
+
+	-oms.ArtifactQuota 100
+	The max total size in megabytes of artifacts attached to one model run, default: 100.
+	If zero or negative, size of run artifacts is not limited.
+	Artifacts can be attached to the model run by POST /api/model/:model/run/:run/artifact
+	and included in model run download zip archive.
 
 	-oms.AllowDownload false
 	If true, allows downloading from the user’s home/io/download directory.
@@ -98,17 +123,82 @@ Following arguments are supported by oms:
 	-oms.AdminAll
 	If true, allows global administrative routes: /admin-all/.
 
+	-oms.AllowPprof
+	If true, allows CPU, heap and other pprof profiles at /api/admin/pprof/.
+	Profiles are served only if local administrative routes /admin/ are enabled.
+	Process summary of goroutines, heap, database connections and catalog sizes is available at /api/admin/stats.
+
 	-oms.Languages en
 	A comma-separated list of supported languages, default: en.
 	Used to match request languages to model languages.
+	Request languages are used in order of preference: lang parameter, session language set by PUT /api/user/lang/:lang,
+	and browser languages from Accept-Language header sorted by quality values, for example: fr-CA;q=0.9, en;q=0.8.
 
 	-oms.DoubleFormat %.15g
 	The format for converting float or double values to strings, default: %.15g.
 
+	-oms.FloatSpecial keep
+	The policy to write special float values NaN, +Inf, -Inf into database: keep, null, error, sentinel or sentinel:value.
+	Default: keep, write values as is, it may fail on some databases, e.g. MSSQL or MySQL.
+	If it is sentinel then NaN is written as sentinel value (default: -1.0e308), +Inf as max float, -Inf as min float
+	and on read such values converted back to NaN, +Inf, -Inf. CSV output is always NaN, +Inf, -Inf.
+
+	-oms.QueryWarnTime 0
+	-oms.QueryMaxTime 0
+	Database queries watchdog thresholds in seconds, default: 0, no limits.
+	If query takes longer than QueryWarnTime then it is logged as slow query with SQL digest and duration.
+	If query takes longer than QueryMaxTime then query is cancelled and request fails.
+	Use -OpenM.LogSql to find query text by SQL digest in the log.
+
+	-oms.TableStats false
+	If true, then on model run completion calculate count, min, max and mean of each output table expression.
+	Statistics can be retrieved by GET /api/model/:model/run/:run/table/:name/stats
+	and (re)calculated for completed model run by PUT /api/model/:model/run/:run/table-stats
+
 	-oms.CodePage
 	A “code page” for converting source files into UTF-8 (e.g., windows-1252).
 	Used primarily for compatibility with older Windows files.
 
+	-oms.Webhooks https://ci.example.com/hook
+	A comma-separated list of URLs to notify on model run completion, default is empty.
+	OMS POSTs a JSON payload (model, run stamp, status, duration, links) on run success or failure.
+	Additional URLs can be specified per model run request or updated at /api/admin/webhooks.
+	Model run request URL must start from one of these URLs, e.g.: https://ci.example.com/hook/run-1234
+
+	-oms.WebhookSecret
+	If not empty, the webhook request body is signed by HMAC-SHA256 with this key.
+	The signature is in the X-Ompp-Signature: sha256=hex header.
+	It is recommended to specify the key in the ini-file rather than on the command line.
+
+	-oms.WebhookRetry 3
+	The number of retries if webhook notification failed, default: 3.
+
+	-oms.GzipMinSize 1024
+	The minimum size in bytes of JSON or CSV response to compress by gzip, default: 1024.
+	Compression is used only if client accepts it: Accept-Encoding: gzip.
+	If zero or negative, response compression is disabled.
+
+	-oms.CacheControl no-cache
+	The Cache-Control header of model metadata, text, words and languages responses, default: no-cache.
+	Those responses have ETag header and client can use If-None-Match to get 304 Not Modified response.
+	ETag is changed if model text loaded from database or model catalog refreshed.
+	Default no-cache allow browser to store response and always check ETag, use e.g. "max-age=600" to reduce requests.
+	If empty, Cache-Control header is not set.
+
+	-oms.WarmUp
+	A comma-separated list of model names or digests to preload at startup, or "all" to preload all models.
+	Model metadata and text in all languages are loaded in background and in parallel.
+	GET /api/ready returns 503 Service Unavailable until warm-up is completed, so load balancer can delay the traffic.
+	By default model text is loaded on first request.
+	GET /api/health is a health check, different from /api/ready: it returns 503 Service Unavailable
+	if models directory is not readable, model database not available, job control directory not writable
+	or storage use limit reached, so orchestration platform can restart unhealthy instance.
+
+Web-service API routes are versioned: /api/v1/ is current API version and /api/v2/ is the next version.
+Unversioned /api/ routes are the same as current version and deprecated,
+responses of unversioned routes contain Deprecation header and Link header to successor version route.
+All API responses contain Api-Version header and GET /api/version-list returns list of supported API versions.
+
 OpenM++ standard log settings (see openM++ wiki):
 
 	-OpenM.LogToConsole If true, logs to standard output (default: true)
@@ -132,12 +222,14 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/husobee/vestigo"
 	_ "github.com/mattn/go-sqlite3"
 	"golang.org/x/text/language"
 
 	"github.com/openmpp/go/ompp/config"
+	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
@@ -153,9 +245,12 @@ const (
 	modelDirArgKey     = "oms.ModelDir"       // models directory
 	modelLogDirArgKey  = "oms.ModelLogDir"    // models log directory
 	modelDocDirArgKey  = "oms.ModelDocDir"    // models doc directory
+	modelLandingArgKey = "oms.ModelLanding"   // model landing file, relative to model directory
 	etcDirArgKey       = "oms.EtcDir"         // config files directory
 	htmlDirArgKey      = "oms.HtmlDir"        // front-end UI directory
 	jobDirArgKey       = "oms.JobDir"         // job control directory
+	jobDbArgKey        = "oms.JobDb"          // job control database connection string
+	jobDbDriverArgKey  = "oms.JobDbDriver"    // job control database driver name, default: SQLite
 	homeDirArgKey      = "oms.HomeDir"        // user home directory
 	isDownloadArgKey   = "oms.AllowDownload"  // if true then allow download
 	isUploadArgKey     = "oms.AllowUpload"    // if true then allow upload
@@ -166,9 +261,21 @@ const (
 	adminAllArgKey     = "oms.AdminAll"       // if true then allow global administrative routes
 	noAdminArgKey      = "oms.NoAdmin"        // if true then disable local admin routes
 	noShutdownArgKey   = "oms.NoShutdown"     // if true then disable shutdown route
+	isPprofArgKey      = "oms.AllowPprof"     // if true then allow pprof profiles under local admin routes
 	uiLangsArgKey      = "oms.Languages"      // list of supported languages
 	encodingArgKey     = "oms.CodePage"       // code page for converting
 	doubleFormatArgKey = "oms.DoubleFormat"   // format to convert float/double
+	floatSpecialArgKey = "oms.FloatSpecial"   // special float values policy: keep, null, error, sentinel or sentinel:value
+	webhooksArgKey     = "oms.Webhooks"       // list of URLs to notify on model run completion
+	whSecretArgKey     = "oms.WebhookSecret"  // HMAC-SHA256 key to sign webhook notifications
+	whRetryArgKey      = "oms.WebhookRetry"   // number of webhook notification retries
+	gzipMinArgKey      = "oms.GzipMinSize"    // min size of JSON or CSV response to compress by gzip
+	cacheCtlArgKey     = "oms.CacheControl"   // Cache-Control header of model metadata responses
+	warmUpArgKey       = "oms.WarmUp"         // list of models to preload at startup or "all"
+	queryWarnArgKey    = "oms.QueryWarnTime"  // if positive then log database queries which take longer than that number of seconds
+	queryMaxArgKey     = "oms.QueryMaxTime"   // if positive then cancel database queries which take longer than that number of seconds
+	artifactArgKey     = "oms.ArtifactQuota"  // max total size in megabytes of artifacts attached to one model run
+	tableStatsArgKey   = "oms.TableStats"     // if true then calculate output tables statistics on model run completion
 )
 
 // server run configuration
@@ -184,6 +291,7 @@ var theCfg = struct {
 	filesDir     string            // user files directory
 	isMicrodata  bool              // if true then allow model-run microdata
 	docDir       string            // models documentation directory
+	landingFile  string            // model landing file, relative to model directory, e.g.: doc/README.md
 	isJobControl bool              // if true then do job control
 	isJobPast    bool              // if true then job history shadow copy
 	isDiskUse    bool              // if true then disk usage control
@@ -194,6 +302,9 @@ var theCfg = struct {
 	codePage     string            // code page for reading model text
 	env          map[string]string // server config environment
 	uiExtra      string            // UI extra config from etc/ui.extra.json
+	cacheControl string            // Cache-Control header of model metadata responses
+	artifactMax  int64             // max total size in bytes of artifacts attached to one model run, if <= 0 then unlimited
+	isTableStats bool              // if true then calculate output tables statistics on model run completion
 }{
 	htmlDir:      "html",
 	etcDir:       "etc",
@@ -208,6 +319,7 @@ var theCfg = struct {
 	omsName:      "",
 	doubleFmt:    "%.15g",
 	env:          map[string]string{},
+	cacheControl: "no-cache",
 }
 
 // if true then log http requests
@@ -240,6 +352,7 @@ func mainBody(args []string) error {
 	_ = flag.String(modelDirArgKey, "models/bin", "models directory")
 	_ = flag.String(modelLogDirArgKey, "models/log", "models log directory")
 	_ = flag.String(modelDocDirArgKey, "models/doc", "models documentation directory")
+	_ = flag.String(modelLandingArgKey, "doc/README.md", "model landing file, relative to model directory")
 	_ = flag.String(etcDirArgKey, theCfg.etcDir, "configuration files directory")
 	_ = flag.String(htmlDirArgKey, theCfg.htmlDir, "front-end UI directory")
 	_ = flag.String(homeDirArgKey, "", "user personal home directory")
@@ -248,16 +361,30 @@ func mainBody(args []string) error {
 	_ = flag.String(filesDirArgKey, "", "user files directory")
 	_ = flag.Bool(isMicrodataArgKey, false, "if true then allow model run microdata")
 	_ = flag.String(jobDirArgKey, "", "job control directory")
+	_ = flag.String(jobDbArgKey, "", "job control database connection string")
+	_ = flag.String(jobDbDriverArgKey, db.SQLiteDbDriver, "job control database driver name, default: SQLite")
 	_ = flag.String(omsNameArgKey, "", "instance name")
 	_ = flag.Bool(logRequestArgKey, false, "if true then log HTTP requests")
 	_ = flag.Bool(apiOnlyArgKey, false, "if true then API only web-service")
 	_ = flag.Bool(adminAllArgKey, false, "if true then allow global administrative routes: /admin-all/")
 	_ = flag.Bool(noAdminArgKey, false, "if true then disable local administrative routes: /admin/")
 	_ = flag.Bool(noShutdownArgKey, false, "if true then disable shutdown route: /shutdown/")
+	_ = flag.Bool(isPprofArgKey, false, "if true then allow pprof profiles: /api/admin/pprof/")
 	_ = flag.String(uiLangsArgKey, "en", "comma-separated list of supported languages")
 	_ = flag.String(encodingArgKey, "", "code page to convert source files into utf-8")
 	_ = flag.String(doubleFormatArgKey, theCfg.doubleFmt, "format to convert float or double value")
+	_ = flag.String(floatSpecialArgKey, "", "special float values NaN, +Inf, -Inf policy: keep, null, error, sentinel or sentinel:value")
 	_ = flag.String(pidFileArgKey, "", "file path to save OMS process ID")
+	_ = flag.String(webhooksArgKey, "", "comma-separated list of URLs to notify on model run completion")
+	_ = flag.String(whSecretArgKey, "", "key to sign webhook notifications by HMAC-SHA256")
+	_ = flag.Int(whRetryArgKey, 3, "number of webhook notification retries")
+	_ = flag.String(warmUpArgKey, "", "comma-separated list of models to preload at startup or \"all\"")
+	_ = flag.Int(queryWarnArgKey, 0, "if positive then log database queries which take longer than that number of seconds")
+	_ = flag.Int(queryMaxArgKey, 0, "if positive then cancel database queries which take longer than that number of seconds")
+	_ = flag.Int(gzipMinArgKey, 1024, "min size in bytes of JSON or CSV response to compress by gzip, if <= 0 then no compression")
+	_ = flag.String(cacheCtlArgKey, theCfg.cacheControl, "Cache-Control header of model metadata responses, if empty then header not set")
+	_ = flag.Int(artifactArgKey, 100, "max total size in megabytes of artifacts attached to one model run, if <= 0 then unlimited")
+	_ = flag.Bool(tableStatsArgKey, false, "if true then calculate output tables statistics on model run completion")
 
 	// pairs of full and short argument names
 	optFs := []config.FullShort{
@@ -272,10 +399,21 @@ func mainBody(args []string) error {
 	isLogRequest = runOpts.Bool(logRequestArgKey)
 	isApiOnly := runOpts.Bool(apiOnlyArgKey)
 	theCfg.isMicrodata = runOpts.Bool(isMicrodataArgKey)
+	theCfg.isTableStats = runOpts.Bool(tableStatsArgKey)
 	isAdminAll := runOpts.Bool(adminAllArgKey)
 	isAdmin := !runOpts.Bool(noAdminArgKey)
 	isShutdown := !runOpts.Bool(noShutdownArgKey)
 	theCfg.doubleFmt = runOpts.String(doubleFormatArgKey)
+	theCfg.cacheControl = runOpts.String(cacheCtlArgKey)
+	fs, err := db.ParseFloatSpecial(runOpts.String(floatSpecialArgKey))
+	if err != nil {
+		return errors.New("Invalid arguments: " + floatSpecialArgKey + ": " + err.Error())
+	}
+	db.SetFloatSpecial(fs)
+	db.SetQueryWatchdog(db.QueryWatchdog{
+		WarnAfter:   time.Duration(runOpts.Int(queryWarnArgKey, 0)) * time.Second,
+		CancelAfter: time.Duration(runOpts.Int(queryMaxArgKey, 0)) * time.Second,
+	})
 	theCfg.codePage = runOpts.String(encodingArgKey)
 
 	// gather OM_CFG_* environment variables
@@ -335,9 +473,21 @@ func mainBody(args []string) error {
 		modelLogDir = ""
 	}
 
+	// model landing file: it must be relative to model directory
+	if lf := runOpts.String(modelLandingArgKey); lf != "" {
+		lf = filepath.Clean(lf)
+		if filepath.IsAbs(lf) || lf == "." || lf == ".." || strings.HasPrefix(lf, ".."+string(filepath.Separator)) {
+			omppLog.Log("Warning: model landing file must be relative to model directory: ", lf)
+		} else {
+			theCfg.landingFile = lf
+			omppLog.Log("Models landing file: ", lf)
+		}
+	}
+
 	if err := theCatalog.refreshSqlite(modelDir, modelLogDir); err != nil {
 		return err
 	}
+	startWarmUp(runOpts.String(warmUpArgKey)) // preload models metadata in background
 
 	// model docs
 	theCfg.docDir = filepath.Clean(runOpts.String(modelDocDirArgKey))
@@ -362,6 +512,10 @@ func mainBody(args []string) error {
 		if theCfg.isHome {
 			omppLog.Log("User Home directory: ", theCfg.homeDir)
 
+			if n := runOpts.Int(artifactArgKey, 100); n > 0 {
+				theCfg.artifactMax = int64(n) * 1024 * 1024
+			}
+
 			theCfg.inOutDir = filepath.Join(theCfg.homeDir, "io")
 			if theCfg.inOutDir == "." || !dirExist(theCfg.inOutDir) {
 				theCfg.inOutDir = ""
@@ -455,7 +609,9 @@ func mainBody(args []string) error {
 
 	// job control
 	theCfg.jobDir = runOpts.String(jobDirArgKey)
-	theCfg.isJobControl, theCfg.isJobPast, err = jobDirValid(theCfg.jobDir)
+	jobDbConn := runOpts.String(jobDbArgKey)
+
+	theCfg.isJobControl, theCfg.isJobPast, err = jobDirValid(theCfg.jobDir, jobDbConn != "")
 	if err != nil {
 		return errors.New("Error: invalid job control directory: " + err.Error())
 	}
@@ -466,6 +622,28 @@ func mainBody(args []string) error {
 		omppLog.Log("Jobs directory: ", theCfg.jobDir)
 	}
 
+	// if job control database specified then store job control state in database instead of job directory files
+	if theCfg.isJobControl && jobDbConn != "" {
+
+		js, err := openDbJobStore(jobDbConn, runOpts.String(jobDbDriverArgKey), theCfg.jobDir)
+		if err != nil {
+			return err
+		}
+		defer js.close()
+
+		theJobStore = js
+		omppLog.Log("Jobs database: ", runOpts.String(jobDbDriverArgKey))
+	}
+
+	// webhooks to notify on model run completion
+	setWebhookConfig(
+		helper.ParseCsvLine(runOpts.String(webhooksArgKey), ','),
+		runOpts.String(whSecretArgKey),
+		runOpts.Int(whRetryArgKey, 3))
+	if wh := getWebhookConfig(); len(wh.Urls) > 0 {
+		omppLog.Log("Webhooks: ", strings.Join(wh.Urls, ", "))
+	}
+
 	// instance name
 	theCfg.omsName = runOpts.String(omsNameArgKey)
 	if theCfg.omsName == "" {
@@ -503,7 +681,7 @@ func mainBody(args []string) error {
 		AllowOrigin:      []string{"*"},
 		AllowCredentials: true,
 		AllowHeaders:     []string{"Content-Type"},
-		ExposeHeaders:    []string{"Content-Type", "Content-Location"},
+		ExposeHeaders:    []string{"Content-Type", "Content-Location", "X-Total-Count", "X-Last-Page", "Api-Version", "Deprecation", "Link"},
 	})
 
 	apiGetRoutes(router)
@@ -526,7 +704,7 @@ func mainBody(args []string) error {
 	apiServiceRoutes(router)
 
 	if isAdmin {
-		apiAdminRoutes(isAdminAll, router)
+		apiAdminRoutes(isAdminAll, runOpts.Bool(isPprofArgKey), router)
 	}
 
 	// serve static content
@@ -549,7 +727,7 @@ func mainBody(args []string) error {
 
 	// initialize server
 	addr := runOpts.String(listenArgKey)
-	srv := http.Server{Addr: addr, Handler: router}
+	srv := http.Server{Addr: addr, Handler: gzipResponse(apiVersionRoutes(router), runOpts.Int(gzipMinArgKey, 1024))}
 
 	// PUT /shutdown
 	ctx, cancel := context.WithCancel(context.Background())
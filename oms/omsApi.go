@@ -92,6 +92,29 @@ func apiGetRoutes(router *vestigo.Router) {
 	// GET /api/model/:model/text-all
 	router.Get("/api/model/:model/text-all", modelAllTextHandler, logRequest)
 
+	// GET /api/model/:model/param-tree
+	// GET /api/model/:model/param-tree/lang/:lang
+	// GET /api/model/:model/table-tree
+	// GET /api/model/:model/table-tree/lang/:lang
+	router.Get("/api/model/:model/param-tree", paramTreeHandler, logRequest)
+	router.Get("/api/model/:model/param-tree/lang/:lang", paramTreeHandler, logRequest)
+	router.Get("/api/model/:model/table-tree", tableTreeHandler, logRequest)
+	router.Get("/api/model/:model/table-tree/lang/:lang", tableTreeHandler, logRequest)
+	router.Get("/api/model/:model/param-tree/lang/", http.NotFound)
+	router.Get("/api/model/:model/table-tree/lang/", http.NotFound)
+
+	// GET /api/model/:model/landing
+	// GET /api/model/:model/landing/lang/:lang
+	router.Get("/api/model/:model/landing", modelLandingHandler, logRequest)
+	router.Get("/api/model/:model/landing/lang/:lang", modelLandingHandler, logRequest)
+	router.Get("/api/model/:model/landing/lang/", http.NotFound)
+
+	// GET /api/search?q=text
+	// GET /api/search/lang/:lang?q=text
+	router.Get("/api/search", searchHandler, logRequest)
+	router.Get("/api/search/lang/:lang", searchHandler, logRequest)
+	router.Get("/api/search/lang/", http.NotFound)
+
 	//
 	// GET model extra: languages, profile(s)
 	//
@@ -156,6 +179,9 @@ func apiGetRoutes(router *vestigo.Router) {
 	// GET /api/model/:model/run/:run/text-all
 	router.Get("/api/model/:model/run/:run/text-all", runAllTextHandler, logRequest)
 
+	// GET /api/model/:model/run/:run/lineage
+	router.Get("/api/model/:model/run/:run/lineage", runLineageHandler, logRequest)
+
 	//
 	// GET model set of input parameters (workset)
 	//
@@ -186,6 +212,12 @@ func apiGetRoutes(router *vestigo.Router) {
 	// GET /api/model/:model/workset/:set/text-all
 	router.Get("/api/model/:model/workset/:set/text-all", worksetAllTextHandler, logRequest)
 
+	// GET /api/model/:model/workset/:set/lock-list
+	router.Get("/api/model/:model/workset/:set/lock-list", worksetLockListHandler, logRequest)
+
+	// GET /api/model/:model/workset/:set/lineage
+	router.Get("/api/model/:model/workset/:set/lineage", worksetLineageHandler, logRequest)
+
 	//
 	// GET modeling tasks and task run history
 	//
@@ -263,6 +295,12 @@ func apiReadRoutes(router *vestigo.Router) {
 	router.Post("/api/model/:model/run/:run/table/compare", runTableComparePageReadHandler, logRequest)
 	router.Post("/api/model/:model/run/:run/table/compare-id", runTableCompareIdPageReadHandler, logRequest)
 
+	// POST /api/model/:model/table/:name/calc-check
+	router.Post("/api/model/:model/table/:name/calc-check", tableCalcCheckHandler, logRequest)
+
+	// POST /api/model/:model/table/:name/calc/validate
+	router.Post("/api/model/:model/table/:name/calc/validate", tableCalcValidateHandler, logRequest)
+
 	if theCfg.isMicrodata {
 
 		// POST /api/model/:model/run/:run/microdata/value
@@ -279,6 +317,12 @@ func apiReadRoutes(router *vestigo.Router) {
 		// POST /api/model/:model/run/:run/microdata/compare-id
 		router.Post("/api/model/:model/run/:run/microdata/compare", runMicrodataComparePageReadHandler, logRequest)
 		router.Post("/api/model/:model/run/:run/microdata/compare-id", runMicrodataCompareIdPageReadHandler, logRequest)
+
+		// POST /api/model/:model/run/:run/microdata/:name/aggregate
+		router.Post("/api/model/:model/run/:run/microdata/:name/aggregate", runMicrodataAggregateHandler, logRequest)
+
+		// POST /api/model/:model/microdata/:name/calc/validate
+		router.Post("/api/model/:model/microdata/:name/calc/validate", microCalcValidateHandler, logRequest)
 	}
 
 	// GET /api/model/:model/workset/:set/parameter/:name/value
@@ -317,28 +361,57 @@ func apiReadRoutes(router *vestigo.Router) {
 	router.Get("/api/model/:model/run/:run/table/:name/expr/start/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/expr/start/:start/count/", http.NotFound)
 
+	// GET /api/model/:model/run/:run/table/:name/stats
+	router.Get("/api/model/:model/run/:run/table/:name/stats", runTableStatsGetHandler, logRequest)
+
 	// GET /api/model/:model/run/:run/table/:name/acc/start/:start
 	// GET /api/model/:model/run/:run/table/:name/acc/start/:start/count/:count
+	// GET /api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id
+	// GET /api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start
+	// GET /api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start/count/:count
 	router.Get("/api/model/:model/run/:run/table/:name/acc", runTableAccPageGetHandler, logRequest)
 	router.Get("/api/model/:model/run/:run/table/:name/acc/start/:start", runTableAccPageGetHandler, logRequest)
 	router.Get("/api/model/:model/run/:run/table/:name/acc/start/:start/count/:count", runTableAccPageGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id", runTableAccPageGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start", runTableAccPageGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start/count/:count", runTableAccPageGetHandler, logRequest)
 	// reject if request ill-formed
 	// router.Get("/api/model/:model/run/:run/table/:name/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/acc/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/acc/start/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/acc/start/:start/count/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/acc/sub-id/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/acc/sub-id/:sub-id/start/:start/count/", http.NotFound)
 
 	// GET /api/model/:model/run/:run/table/:name/all-acc
 	// GET /api/model/:model/run/:run/table/:name/all-acc/start/:start
 	// GET /api/model/:model/run/:run/table/:name/all-acc/start/:start/count/:count
+	// GET /api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id
+	// GET /api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start
+	// GET /api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start/count/:count
 	router.Get("/api/model/:model/run/:run/table/:name/all-acc", runTableAllAccPageGetHandler, logRequest)
 	router.Get("/api/model/:model/run/:run/table/:name/all-acc/start/:start", runTableAllAccPageGetHandler, logRequest)
 	router.Get("/api/model/:model/run/:run/table/:name/all-acc/start/:start/count/:count", runTableAllAccPageGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id", runTableAllAccPageGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start", runTableAllAccPageGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start/count/:count", runTableAllAccPageGetHandler, logRequest)
 	// reject if request ill-formed
 	// router.Get("/api/model/:model/run/:run/table/:name/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/all-acc/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/all-acc/start/", http.NotFound)
 	router.Get("/api/model/:model/run/:run/table/:name/all-acc/start/:start/count/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/all-acc/sub-id/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/all-acc/sub-id/:sub-id/start/:start/count/", http.NotFound)
+
+	// GET /api/model/:model/run/:run/table/:name/preview
+	// GET /api/model/:model/run/:run/table/:name/preview/count/:count
+	router.Get("/api/model/:model/run/:run/table/:name/preview", runTablePreviewGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/table/:name/preview/count/:count", runTablePreviewGetHandler, logRequest)
+	// reject if request ill-formed
+	router.Get("/api/model/:model/run/:run/table/:name/preview/", http.NotFound)
+	router.Get("/api/model/:model/run/:run/table/:name/preview/count/", http.NotFound)
 
 	// GET /api/model/:model/run/:run/table/:name/calc/:calc
 	// GET /api/model/:model/run/:run/table/:name/calc/:calc/start/:start
@@ -469,6 +542,24 @@ func apiReadCsvRoutes(router *vestigo.Router) {
 	router.Get("/api/model/:model/run/:run/table/:name/compare/:compare/variant/:variant/csv-id", runTableCompareIdCsvGetHandler, logRequest)
 	router.Get("/api/model/:model/run/:run/table/:name/compare/:compare/variant/:variant/csv-id-bom", runTableCompareIdCsvBomGetHandler, logRequest)
 
+	// POST /api/model/:model/run/:run/table/:name/calc/csv
+	// POST /api/model/:model/run/:run/table/:name/calc/csv-bom
+	// POST /api/model/:model/run/:run/table/:name/calc/csv-id
+	// POST /api/model/:model/run/:run/table/:name/calc/csv-id-bom
+	router.Post("/api/model/:model/run/:run/table/:name/calc/csv", runTableCalcCsvPostHandler, logRequest)
+	router.Post("/api/model/:model/run/:run/table/:name/calc/csv-bom", runTableCalcCsvBomPostHandler, logRequest)
+	router.Post("/api/model/:model/run/:run/table/:name/calc/csv-id", runTableCalcIdCsvPostHandler, logRequest)
+	router.Post("/api/model/:model/run/:run/table/:name/calc/csv-id-bom", runTableCalcIdCsvBomPostHandler, logRequest)
+
+	// POST /api/model/:model/run/:run/tables/csv
+	// POST /api/model/:model/run/:run/tables/csv-bom
+	// POST /api/model/:model/run/:run/tables/csv-id
+	// POST /api/model/:model/run/:run/tables/csv-id-bom
+	router.Post("/api/model/:model/run/:run/tables/csv", runTablesCsvZipHandler, logRequest)
+	router.Post("/api/model/:model/run/:run/tables/csv-bom", runTablesCsvBomZipHandler, logRequest)
+	router.Post("/api/model/:model/run/:run/tables/csv-id", runTablesIdCsvZipHandler, logRequest)
+	router.Post("/api/model/:model/run/:run/tables/csv-id-bom", runTablesIdCsvBomZipHandler, logRequest)
+
 	if theCfg.isMicrodata {
 
 		// GET /api/model/:model/run/:run/microdata/:name/csv
@@ -531,6 +622,15 @@ func apiUpdateRoutes(router *vestigo.Router) {
 	router.Post("/api/model/:model/workset/:set/readonly/:readonly", worksetReadonlyUpdateHandler, logRequest)
 	router.Post("/api/model/:model/workset/:set/readonly/", http.NotFound)
 
+	// POST   /api/model/:model/workset/:set/lock/:owner
+	// POST   /api/model/:model/workset/:set/lock/:owner/refresh
+	// DELETE /api/model/:model/workset/:set/lock/:owner
+	router.Post("/api/model/:model/workset/:set/lock/:owner", worksetLockHandler, logRequest)
+	router.Post("/api/model/:model/workset/:set/lock/:owner/refresh", worksetLockRefreshHandler, logRequest)
+	router.Delete("/api/model/:model/workset/:set/lock/:owner", worksetLockReleaseHandler, logRequest)
+	router.Post("/api/model/:model/workset/:set/lock/", http.NotFound)
+	router.Delete("/api/model/:model/workset/:set/lock/", http.NotFound)
+
 	// PUT  /api/workset-create
 	router.Put("/api/workset-create", worksetCreateHandler, logRequest)
 
@@ -576,6 +676,9 @@ func apiUpdateRoutes(router *vestigo.Router) {
 	// PATCH /api/model/:model/workset/:set/parameter-text
 	router.Patch("/api/model/:model/workset/:set/parameter-text", worksetParameterTextMergeHandler, logRequest)
 
+	// POST /api/model/:model/workset/:set/import-xlsx
+	router.Post("/api/model/:model/workset/:set/import-xlsx", worksetXlsxImportHandler, logRequest)
+
 	//
 	// update model run
 	//
@@ -593,6 +696,10 @@ func apiUpdateRoutes(router *vestigo.Router) {
 	// PATCH /api/model/:model/run/:run/parameter-text
 	router.Patch("/api/model/:model/run/:run/parameter-text", runParameterTextMergeHandler, logRequest)
 
+	// PUT /api/model/:model/run/:run/table-stats
+	router.Put("/api/model/:model/run/:run/table-stats", runTableStatsUpdateHandler, logRequest)
+	router.Put("/api/model/:model/run/:run/", http.NotFound)
+
 	//
 	// update modeling task and task run history
 	//
@@ -614,6 +721,12 @@ func apiRunModelRoutes(router *vestigo.Router) {
 	// POST /api/run
 	router.Post("/api/run", runModelHandler, logRequest)
 
+	// POST /api/run/clone
+	router.Post("/api/run/clone", runCloneHandler, logRequest)
+
+	// POST /api/run/validate
+	router.Post("/api/run/validate", runValidateHandler, logRequest)
+
 	// GET /api/run/log/model/:model/stamp/:stamp
 	// GET /api/run/log/model/:model/stamp/:stamp/start/:start/count/:count
 	router.Get("/api/run/log/model/:model/stamp/:stamp", runLogPageHandler, logRequest)
@@ -623,12 +736,28 @@ func apiRunModelRoutes(router *vestigo.Router) {
 	router.Get("/api/run/log/model/:model/stamp/:stamp/start/", http.NotFound)
 	router.Get("/api/run/log/model/:model/stamp/:stamp/start/:start/count/", http.NotFound)
 
+	// GET /api/run/console/model/:model/stamp/:stamp
+	// GET /api/run/console/model/:model/stamp/:stamp/since/:since
+	router.Get("/api/run/console/model/:model/stamp/:stamp", runConsoleHandler, logRequest)
+	router.Get("/api/run/console/model/:model/stamp/:stamp/since/:since", runConsoleHandler, logRequest)
+	router.Get("/api/run/console/model/:model/stamp/", http.NotFound)
+	router.Get("/api/run/console/model/:model/stamp/:stamp/since/", http.NotFound)
+
+	// GET /api/run/console-stream/model/:model/stamp/:stamp
+	// GET /api/run/console-stream/model/:model/stamp/:stamp/since/:since
+	router.Get("/api/run/console-stream/model/:model/stamp/:stamp", runConsoleStreamHandler, logRequest)
+	router.Get("/api/run/console-stream/model/:model/stamp/:stamp/since/:since", runConsoleStreamHandler, logRequest)
+	router.Get("/api/run/console-stream/model/:model/stamp/", http.NotFound)
+	router.Get("/api/run/console-stream/model/:model/stamp/:stamp/since/", http.NotFound)
+
 	// PUT /api/run/stop/model/:model/stamp/:stamp
 	router.Put("/api/run/stop/model/:model/stamp/:stamp", stopModelHandler, logRequest)
 	router.Put("/api/run/stop/model/:model/stamp/", http.NotFound)
 
 	// reject run log if request ill-formed
 	router.Get("/api/run/log/model/", http.NotFound)
+	router.Get("/api/run/console/model/", http.NotFound)
+	router.Get("/api/run/console-stream/model/", http.NotFound)
 }
 
 // add http web-service /api routes to download and manage files at home/io/download folder
@@ -779,11 +908,83 @@ func apiUserRoutes(router *vestigo.Router) {
 	// DELETE /api/user/view/model/:model
 	router.Delete("/api/user/view/model/:model", userViewDeleteHandler, logRequest)
 	router.Delete("/api/user/view/model/", http.NotFound)
+
+	// POST /api/bookmark
+	router.Post("/api/bookmark", bookmarkPostHandler, logRequest)
+
+	// GET /api/bookmark/:id
+	router.Get("/api/bookmark/:id", bookmarkGetHandler, logRequest)
+	router.Get("/api/bookmark/", http.NotFound)
+
+	// GET /api/bookmark-list
+	router.Get("/api/bookmark-list", bookmarkListGetHandler, logRequest)
+
+	// DELETE /api/bookmark/:id
+	router.Delete("/api/bookmark/:id", bookmarkDeleteHandler, logRequest)
+	router.Delete("/api/bookmark/", http.NotFound)
+
+	// POST /api/compare
+	router.Post("/api/compare", comparePostHandler, logRequest)
+
+	// GET /api/compare/:compare
+	router.Get("/api/compare/:compare", compareGetHandler, logRequest)
+	router.Get("/api/compare/", http.NotFound)
+
+	// GET /api/compare-list
+	router.Get("/api/compare-list", compareListGetHandler, logRequest)
+
+	// DELETE /api/compare/:compare
+	router.Delete("/api/compare/:compare", compareDeleteHandler, logRequest)
+	router.Delete("/api/compare/", http.NotFound)
+
+	// GET /api/compare/:compare/table/:name
+	// GET /api/compare/:compare/table/:name/start/:start
+	// GET /api/compare/:compare/table/:name/start/:start/count/:count
+	router.Get("/api/compare/:compare/table/:name", compareTablePageGetHandler, logRequest)
+	router.Get("/api/compare/:compare/table/:name/start/:start", compareTablePageGetHandler, logRequest)
+	router.Get("/api/compare/:compare/table/:name/start/:start/count/:count", compareTablePageGetHandler, logRequest)
+	// reject if request ill-formed
+	router.Get("/api/compare/:compare/table/", http.NotFound)
+	router.Get("/api/compare/:compare/table/:name/start/", http.NotFound)
+	router.Get("/api/compare/:compare/table/:name/start/:start/count/", http.NotFound)
+
+	// POST /api/model/:model/run/:run/artifact
+	router.Post("/api/model/:model/run/:run/artifact", runArtifactPostHandler, logRequest)
+
+	// GET /api/model/:model/run/:run/artifact-list
+	router.Get("/api/model/:model/run/:run/artifact-list", runArtifactListGetHandler, logRequest)
+
+	// GET /api/model/:model/run/:run/artifact/:name
+	router.Get("/api/model/:model/run/:run/artifact/:name", runArtifactGetHandler, logRequest)
+	router.Get("/api/model/:model/run/:run/artifact/", http.NotFound)
+
+	// DELETE /api/model/:model/run/:run/artifact/:name
+	router.Delete("/api/model/:model/run/:run/artifact/:name", runArtifactDeleteHandler, logRequest)
+	router.Delete("/api/model/:model/run/:run/artifact/", http.NotFound)
+
+	// GET /api/user/lang
+	router.Get("/api/user/lang", userLangGetHandler, logRequest)
+
+	// PUT /api/user/lang/:lang
+	router.Put("/api/user/lang/:lang", userLangPutHandler, logRequest)
+	router.Put("/api/user/lang/", http.NotFound)
+
+	// DELETE /api/user/lang
+	router.Delete("/api/user/lang", userLangDeleteHandler, logRequest)
 }
 
 // add web-service /api routes service state
 func apiServiceRoutes(router *vestigo.Router) {
 
+	// GET /api/ready
+	router.Get("/api/ready", readyHandler, logRequest)
+
+	// GET /api/version-list
+	router.Get("/api/version-list", apiVersionListHandler, logRequest)
+
+	// GET /api/health
+	router.Get("/api/health", healthHandler, logRequest)
+
 	// GET /api/service/config
 	router.Get("/api/service/config", serviceConfigHandler, logRequest)
 
@@ -796,6 +997,15 @@ func apiServiceRoutes(router *vestigo.Router) {
 	// POST /api/service/disk-use/refresh
 	router.Post("/api/service/disk-use/refresh", serviceRefreshDiskUseHandler, logRequest)
 
+	// GET /api/activity
+	// GET /api/activity/start/:start
+	// GET /api/activity/start/:start/count/:count
+	router.Get("/api/activity", activityHandler, logRequest)
+	router.Get("/api/activity/start/:start", activityHandler, logRequest)
+	router.Get("/api/activity/start/:start/count/:count", activityHandler, logRequest)
+	router.Get("/api/activity/start/", http.NotFound)
+	router.Get("/api/activity/start/:start/count/", http.NotFound)
+
 	// GET /api/service/job/active/:job
 	// GET /api/service/job/queue/:job
 	// GET /api/service/job/history/:job
@@ -821,7 +1031,7 @@ func apiServiceRoutes(router *vestigo.Router) {
 }
 
 // add web-service /api routes for oms instance administrative tasks
-func apiAdminRoutes(isAdminAll bool, router *vestigo.Router) {
+func apiAdminRoutes(isAdminAll, isPprof bool, router *vestigo.Router) {
 
 	// add web-service /admin-all/ routes for global administrative tasks, enabled by -oms.AdminAll run option
 	if isAdminAll {
@@ -840,6 +1050,9 @@ func apiAdminRoutes(isAdminAll bool, router *vestigo.Router) {
 	// POST /api/admin/model/:model/close
 	router.Post("/api/admin/model/:model/close", modelCloseHandler, logRequest)
 
+	// POST /api/admin/model-upload
+	router.Post("/api/admin/model-upload", modelUploadHandler, logRequest)
+
 	//	POST /api/admin/db-file-open/:path
 	router.Post("/api/admin/db-file-open/:path", modelOpenDbFileHandler, logRequest)
 	router.Post("/api/admin/db-file-open/", http.NotFound)
@@ -848,6 +1061,10 @@ func apiAdminRoutes(isAdminAll bool, router *vestigo.Router) {
 	router.Post("/api/admin/jobs-pause/:pause", jobsPauseHandler, logRequest)
 	router.Post("/api/admin/jobs-pause/", http.NotFound)
 
+	// POST /api/admin/job-cancel/:job
+	router.Post("/api/admin/job-cancel/:job", jobForceCancelHandler, logRequest)
+	router.Post("/api/admin/job-cancel/", http.NotFound)
+
 	// POST /api/admin/db-cleanup/:path
 	// POST /api/admin/db-cleanup/:path/name/:name
 	// POST /api/admin/db-cleanup/:path/name/:name/digest/:digest
@@ -867,4 +1084,37 @@ func apiAdminRoutes(isAdminAll bool, router *vestigo.Router) {
 
 	// POST /api/admin/model/:model/delete
 	router.Post("/api/admin/model/:model/delete", modelDeleteHandler, logRequest)
+
+	// GET  /api/admin/webhooks
+	// POST /api/admin/webhooks
+	router.Get("/api/admin/webhooks", webhooksGetHandler, logRequest)
+	router.Post("/api/admin/webhooks", webhooksPostHandler, logRequest)
+
+	// GET  /api/admin/model/:model/orphan-tables
+	// POST /api/admin/model/:model/orphan-tables/drop
+	// POST /api/admin/model/:model/orphan-tables/drop?dry-run=true
+	router.Get("/api/admin/model/:model/orphan-tables", orphanTablesGetHandler, logRequest)
+	router.Post("/api/admin/model/:model/orphan-tables/drop", orphanTablesDropHandler, logRequest)
+
+	// POST /api/admin/model/:model/lang/:lang/add
+	// POST /api/admin/model/:model/lang/:lang/add?name=Deutsch&from=EN
+	// POST /api/admin/model/:model/lang/:lang/copy-from/:from
+	// POST /api/admin/model/:model/lang/:lang/delete
+	router.Post("/api/admin/model/:model/lang/:lang/add", langAddHandler, logRequest)
+	router.Post("/api/admin/model/:model/lang/:lang/copy-from/:from", langCopyHandler, logRequest)
+	router.Post("/api/admin/model/:model/lang/:lang/copy-from/", http.NotFound)
+	router.Post("/api/admin/model/:model/lang/:lang/delete", langDeleteHandler, logRequest)
+
+	// GET /api/admin/stats
+	router.Get("/api/admin/stats", adminStatsHandler, logRequest)
+
+	// add pprof profiles routes, enabled by -oms.AllowPprof run option
+	if isPprof {
+		// GET /api/admin/pprof/
+		// GET /api/admin/pprof/:name
+		// POST /api/admin/pprof/:name
+		router.Get("/api/admin/pprof/", pprofHandler, logRequest)
+		router.Get("/api/admin/pprof/:name", pprofHandler, logRequest)
+		router.Post("/api/admin/pprof/:name", pprofHandler, logRequest)
+	}
 }
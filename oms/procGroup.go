@@ -0,0 +1,29 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// start model process in a new process group,
+// so that model process and all child processes (e.g. mpiexec workers) can be killed together
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// kill process group of the model process, process group id is the same as model process id
+func killProcessGroup(pid int) error {
+	if pid <= 0 {
+		return nil // process not started
+	}
+	return syscall.Kill(-pid, syscall.SIGKILL)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// on Windows model process is started as usual, process tree is killed by taskkill
+func setProcessGroup(cmd *exec.Cmd) {
+}
+
+// kill model process and all child processes (e.g. mpiexec workers)
+func killProcessGroup(pid int) error {
+	if pid <= 0 {
+		return nil // process not started
+	}
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
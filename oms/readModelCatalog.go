@@ -165,7 +165,9 @@ func (mc *ModelCatalog) entityAttrsByDigest(digest string) []db.EntityMeta {
 }
 
 // languageTagMatch return model language code matched to request languages.
-// if there is no such match then return is empty "" language code.
+// Request languages are checked in order of preference by RFC 4647 lookup: en-CA, en, fr-CA, fr,
+// if there is no such model language then closest language returned by language matcher or model default language.
+// if model not found then return is empty "" language code.
 func (mc *ModelCatalog) languageTagMatch(dn string, preferredLang []language.Tag) string {
 	mc.theLock.Lock()
 	defer mc.theLock.Unlock()
@@ -175,6 +177,9 @@ func (mc *ModelCatalog) languageTagMatch(dn string, preferredLang []language.Tag
 		return ""
 	}
 
+	if np := lookupLangCode(preferredLang, mc.modelLst[idx].langCodes); np >= 0 {
+		return mc.modelLst[idx].langCodes[np]
+	}
 	_, np, _ := mc.modelLst[idx].matcher.Match(preferredLang...)
 
 	if np >= 0 && np < len(mc.modelLst[idx].langCodes) {
@@ -194,6 +199,9 @@ func (mc *ModelCatalog) languageCodeMatch(dn string, langCode string) string {
 		return ""
 	}
 
+	if np := lookupLangCode([]language.Tag{language.Make(langCode)}, mc.modelLst[idx].langCodes); np >= 0 {
+		return mc.modelLst[idx].langCodes[np]
+	}
 	_, np, _ := mc.modelLst[idx].matcher.Match(language.Make(langCode))
 
 	if np >= 0 && np < len(mc.modelLst[idx].langCodes) {
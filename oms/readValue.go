@@ -4,6 +4,8 @@
 package main
 
 import (
+	"database/sql"
+
 	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/omppLog"
 )
@@ -16,27 +18,45 @@ import (
 // Rows can be filtered and ordered (see db.ReadParamLayout for details).
 func (mc *ModelCatalog) ReadParameterTo(dn, src string, layout *db.ReadParamLayout, cvtWr func(src interface{}) (bool, error)) (*db.ReadPageLayout, bool) {
 
+	meta, dbConn, ok := mc.paramReadSource(dn, src, layout)
+	if !ok {
+		return nil, false
+	}
+
+	// read parameter page
+	lt, err := db.ReadParameterTo(dbConn, meta, layout, cvtWr)
+	if err != nil {
+		omppLog.Log("Error at read parameter: ", dn, ": ", layout.Name, ": ", err.Error())
+		return nil, false // return empty result: values select error
+	}
+
+	return lt, true
+}
+
+// return model metadata and database connection to read parameter values, set layout source workset id or model run id.
+func (mc *ModelCatalog) paramReadSource(dn, src string, layout *db.ReadParamLayout) (*db.ModelMeta, *sql.DB, bool) {
+
 	// if model digest-or-name is empty then return empty results
 	if dn == "" {
 		omppLog.Log("Error: invalid (empty) model digest and name")
-		return nil, false
+		return nil, nil, false
 	}
 	if layout.Name == "" {
 		omppLog.Log("Error: invalid (empty) output table name")
-		return nil, false
+		return nil, nil, false
 	}
 
 	// get model metadata and database connection
 	meta, dbConn, ok := mc.modelMeta(dn)
 	if !ok {
 		omppLog.Log("Warning: model digest or name not found: ", dn)
-		return nil, false
+		return nil, nil, false
 	}
 
 	// check if parameter name exist in the model
 	if _, ok = meta.ParamByName(layout.Name); !ok {
 		omppLog.Log("Warning: parameter not found: ", layout.Name)
-		return nil, false // return empty result: parameter not found or error
+		return nil, nil, false // return empty result: parameter not found or error
 	}
 
 	// find workset id by name or run id by name-or-digest
@@ -44,7 +64,7 @@ func (mc *ModelCatalog) ReadParameterTo(dn, src string, layout *db.ReadParamLayo
 
 		w, err := db.GetWorksetByName(dbConn, meta.Model.ModelId, src)
 		if err != nil {
-			return nil, false // return empty result: workset select error
+			return nil, nil, false // return empty result: workset select error
 		}
 
 		layout.FromId = w.SetId // source workset id
@@ -55,24 +75,17 @@ func (mc *ModelCatalog) ReadParameterTo(dn, src string, layout *db.ReadParamLayo
 		rst, err := db.GetRunByDigestStampName(dbConn, meta.Model.ModelId, src)
 		if err != nil {
 			omppLog.Log("Error at get run status: ", meta.Model.Name, ": ", src, ": ", err.Error())
-			return nil, false // return empty result: run select error
+			return nil, nil, false // return empty result: run select error
 		}
 		if rst == nil {
 			omppLog.Log("Warning: run not found: ", meta.Model.Name, ": ", src)
-			return nil, false // return empty result: run_lst row not found
+			return nil, nil, false // return empty result: run_lst row not found
 		}
 
 		layout.FromId = rst.RunId // source run id
 	}
 
-	// read parameter page
-	lt, err := db.ReadParameterTo(dbConn, meta, layout, cvtWr)
-	if err != nil {
-		omppLog.Log("Error at read parameter: ", dn, ": ", layout.Name, ": ", err.Error())
-		return nil, false // return empty result: values select error
-	}
-
-	return lt, true
+	return meta, dbConn, true
 }
 
 // ReadOutTableTo select "page" of output table values from model run and pass each row into cvtWr().
@@ -83,48 +96,59 @@ func (mc *ModelCatalog) ReadParameterTo(dn, src string, layout *db.ReadParamLayo
 // Rows can be filtered and ordered (see db.ReadTableLayout for details).
 func (mc *ModelCatalog) ReadOutTableTo(dn, rdsn string, layout *db.ReadTableLayout, cvtWr func(src interface{}) (bool, error)) (*db.ReadPageLayout, bool) {
 
+	meta, dbConn, ok := mc.tableReadSource(dn, rdsn, layout)
+	if !ok {
+		return nil, false
+	}
+
+	// read output table page
+	lt, err := db.ReadOutputTableTo(dbConn, meta, layout, cvtWr)
+	if err != nil {
+		omppLog.Log("Error at read output table: ", dn, ": ", layout.Name, ": ", err.Error())
+		return nil, false // return empty result: values select error
+	}
+
+	return lt, true
+}
+
+// return model metadata and database connection to read output table values, set layout source model run id.
+func (mc *ModelCatalog) tableReadSource(dn, rdsn string, layout *db.ReadTableLayout) (*db.ModelMeta, *sql.DB, bool) {
+
 	// if model digest-or-name is empty then return empty results
 	if dn == "" {
 		omppLog.Log("Error: invalid (empty) model digest and name")
-		return nil, false
+		return nil, nil, false
 	}
 	if layout.Name == "" {
 		omppLog.Log("Error: invalid (empty) output table name")
-		return nil, false
+		return nil, nil, false
 	}
 
 	// get model metadata and database connection
 	meta, dbConn, ok := mc.modelMeta(dn)
 	if !ok {
 		omppLog.Log("Warning: model digest or name not found: ", dn)
-		return nil, false
+		return nil, nil, false
 	}
 
 	// check if output table name exist in the model
 	if _, ok = meta.OutTableByName(layout.Name); !ok {
 		omppLog.Log("Warning: output table not found: ", layout.Name)
-		return nil, false // return empty result: output table not found or error
+		return nil, nil, false // return empty result: output table not found or error
 	}
 
 	// find model run id by digest-or-stamp-or-name
 	r, ok := mc.CompletedRunByDigestOrStampOrName(dn, rdsn)
 	if !ok {
-		return nil, false // return empty result: run select error
+		return nil, nil, false // return empty result: run select error
 	}
 	if r.Status != db.DoneRunStatus {
 		omppLog.Log("Warning: model run not completed successfully: ", rdsn, ": ", r.Status)
-		return nil, false
+		return nil, nil, false
 	}
 	layout.FromId = r.RunId // source run id
 
-	// read output table page
-	lt, err := db.ReadOutputTableTo(dbConn, meta, layout, cvtWr)
-	if err != nil {
-		omppLog.Log("Error at read output table: ", dn, ": ", layout.Name, ": ", err.Error())
-		return nil, false // return empty result: values select error
-	}
-
-	return lt, true
+	return meta, dbConn, true
 }
 
 // ReadOutTableCalculateTo select "page" of calculated output table values from model run(s) and pass each row into cvtWr().
@@ -201,27 +225,45 @@ func (mc *ModelCatalog) ReadOutTableCalculateTo(
 // Rows can be filtered and ordered (see db.ReadMicroLayout for details).
 func (mc *ModelCatalog) ReadMicrodataTo(dn, rdsn string, layout *db.ReadMicroLayout, cvtWr func(src interface{}) (bool, error)) (*db.ReadPageLayout, bool) {
 
+	meta, dbConn, ok := mc.microReadSource(dn, rdsn, layout)
+	if !ok {
+		return nil, false
+	}
+
+	// read microdata values page
+	lt, err := db.ReadMicrodataTo(dbConn, meta, layout, cvtWr)
+	if err != nil {
+		omppLog.Log("Error at read microdata: ", dn, ": ", layout.Name, ": ", layout.GenDigest, ": ", err.Error())
+		return nil, false // return empty result: values select error
+	}
+
+	return lt, true
+}
+
+// return model metadata and database connection to read microdata values, set layout source model run id and entity generation digest.
+func (mc *ModelCatalog) microReadSource(dn, rdsn string, layout *db.ReadMicroLayout) (*db.ModelMeta, *sql.DB, bool) {
+
 	// validate parameters and return empty results on empty input
 	if dn == "" {
 		omppLog.Log("Error: invalid (empty) model digest and name")
-		return nil, false
+		return nil, nil, false
 	}
 	if layout.Name == "" {
 		omppLog.Log("Error: invalid (empty) model entity name")
-		return nil, false
+		return nil, nil, false
 	}
 
 	// get model metadata and database connection
 	meta, dbConn, ok := mc.modelMeta(dn)
 	if !ok {
 		omppLog.Log("Warning: model digest or name not found: ", dn)
-		return nil, false
+		return nil, nil, false
 	}
 
 	// find entity generation by entity name
 	if _, ok := meta.EntityByName(layout.Name); !ok {
 		omppLog.Log("Warning: model entity not found: ", layout.Name)
-		return nil, false
+		return nil, nil, false
 	}
 
 	// if run id not defiened then find model run id by digest-or-stamp-or-name
@@ -229,11 +271,11 @@ func (mc *ModelCatalog) ReadMicrodataTo(dn, rdsn string, layout *db.ReadMicroLay
 
 		r, ok := mc.CompletedRunByDigestOrStampOrName(dn, rdsn)
 		if !ok {
-			return nil, false // return empty result: run select error
+			return nil, nil, false // return empty result: run select error
 		}
 		if r.Status != db.DoneRunStatus {
 			omppLog.Log("Warning: model run not completed successfully: ", rdsn, ": ", r.Status)
-			return nil, false
+			return nil, nil, false
 		}
 		layout.FromId = r.RunId // source run id
 	}
@@ -243,19 +285,12 @@ func (mc *ModelCatalog) ReadMicrodataTo(dn, rdsn string, layout *db.ReadMicroLay
 
 		_, entGen, ok := mc.EntityGenByName(dn, layout.FromId, layout.Name)
 		if !ok {
-			return nil, false // entity generation not found
+			return nil, nil, false // entity generation not found
 		}
 		layout.GenDigest = entGen.GenDigest
 	}
 
-	// read microdata values page
-	lt, err := db.ReadMicrodataTo(dbConn, meta, layout, cvtWr)
-	if err != nil {
-		omppLog.Log("Error at read microdata: ", dn, ": ", layout.Name, ": ", layout.GenDigest, ": ", err.Error())
-		return nil, false // return empty result: values select error
-	}
-
-	return lt, true
+	return meta, dbConn, true
 }
 
 // ReadMicrodataCalculateTo select "page" of aggreagted microdata values from model run(s) and pass each row into cvtWr().
@@ -332,3 +367,41 @@ func (mc *ModelCatalog) ReadMicrodataCalculateTo(
 
 	return lt, true
 }
+
+// ReadValuePage select "page" of parameter, output table or microdata values and pass each row into cvtWr().
+// It also return total number of rows selected by filters and page window: page index and page count.
+// Layout must be one of: *db.ReadParamLayout, *db.ReadTableLayout or *db.ReadMicroLayout,
+// source is a workset name or run digest-or-stamp-or-name, see ReadParameterTo(), ReadOutTableTo() and ReadMicrodataTo().
+func (mc *ModelCatalog) ReadValuePage(dn, src string, layout interface{}, cvtWr func(src interface{}) (bool, error)) (*db.ValuePageLayout, bool) {
+
+	var meta *db.ModelMeta
+	var dbConn *sql.DB
+	ok := false
+	name := ""
+
+	switch rl := layout.(type) {
+	case *db.ReadParamLayout:
+		meta, dbConn, ok = mc.paramReadSource(dn, src, rl)
+		name = rl.Name
+	case *db.ReadTableLayout:
+		meta, dbConn, ok = mc.tableReadSource(dn, src, rl)
+		name = rl.Name
+	case *db.ReadMicroLayout:
+		meta, dbConn, ok = mc.microReadSource(dn, src, rl)
+		name = rl.Name
+	default:
+		omppLog.Log("Error: invalid read layout, expected parameter, output table or microdata layout")
+	}
+	if !ok {
+		return nil, false
+	}
+
+	// read values page and total count of rows
+	lt, err := db.ReadValuePage(dbConn, meta, layout, cvtWr)
+	if err != nil {
+		omppLog.Log("Error at read values page: ", dn, ": ", src, ": ", name, ": ", err.Error())
+		return nil, false // return empty result: values select error
+	}
+
+	return lt, true
+}
@@ -91,22 +91,38 @@ type RunRequest struct {
 		LangCode string // model language code
 		Note     string // run notes
 	}
+	Webhooks []string // if not empty then list of URLs to notify on model run completion
+}
+
+// RunCloneRequest is a request to run the model again using options of existing model run.
+//
+// New run request is pre-filled from source model run options: workset, sub-values count, threads, microdata, etc.
+// Non-empty fields of RunRequest are overrides, each of Opts replace source run option or removes it if value is empty.
+// Params are scalar parameter values to override, it is passed to the model as -Parameter.Name value.
+type RunCloneRequest struct {
+	RunDigest  string            // digest, stamp or name of the source model run
+	SubCount   int               // if positive then number of sub-values, by default same as source run
+	Params     map[string]string // scalar parameter values: name and value
+	RunRequest                   // model digest-or-name, run stamp and run options to override
 }
 
 // RunJob is model run request and run job control: submission stamp and model process id
 type RunJob struct {
-	SubmitStamp string // submission timestamp
-	Pid         int    // process id
-	CmdPath     string // executable path
-	RunRequest         // model run request: model name, digest and run options
-	Res         RunRes // job run resources: CPU cores and memory
-	IsOverLimit bool   // if true then job run resource(s) exceed limit(s)
-	QueuePos    int    // one-based position of MPI job in global queue or any (MPI or non-MPI) job in localhost queue
-	LogFileName string // log file name
-	LogPath     string // log file path: log/dir/modelName.RunStamp.console.log
-	IniPath     string // if not empty then actual ini file path, may be relative to log directory
-	BinDir      string // if not empty then model run bin directory
-	WorkDir     string // if not empty then model run work directory
+	SubmitStamp  string // submission timestamp
+	Pid          int    // process id
+	CmdPath      string // executable path
+	RunRequest          // model run request: model name, digest and run options
+	Res          RunRes // job run resources: CPU cores and memory
+	IsOverLimit  bool   // if true then job run resource(s) exceed limit(s)
+	IsRunLimit   bool   // if true then job is waiting because model or user max concurrent runs limit reached
+	QueuePos     int    // one-based position of MPI job in global queue or any (MPI or non-MPI) job in localhost queue
+	LogFileName  string // log file name
+	LogPath      string // log file path: log/dir/modelName.RunStamp.console.log
+	IniPath      string // if not empty then actual ini file path, may be relative to log directory
+	BinDir       string // if not empty then model run bin directory
+	WorkDir      string // if not empty then model run work directory
+	RetryCount   int    // number of times job was requeued after force cancel
+	CancelReason string // if not empty then reason of job force cancel
 }
 
 // RunRes is model run computational resources
@@ -129,6 +145,8 @@ type modelCfgRes struct {
 	Path         string // model bin directory and model name joined by / slash, ex: 1-Rp/RiskPaths
 	ProcessMemMb int    // if not zero then memory required per proccess in megabytes
 	ThreadMemMb  int    // if not zero then memory required for each thread in megabytes
+	MaxRetries   int    // max number of automatic requeue of the job after force cancel
+	MaxRuns      int    // max number of concurrent runs of the model by all oms instances, zero means unlimited
 }
 
 // run job control file info
@@ -181,9 +199,10 @@ type RunState struct {
 
 // runStateLog is model run state and log file lines.
 type runStateLog struct {
-	RunState            // model run state
-	logUsedTs  int64    // unix seconds, last time when log file lines used
-	logLineLst []string // model run log lines
+	RunState                // model run state
+	logUsedTs  int64        // unix seconds, last time when log file lines used
+	logLineLst []string     // model run log lines
+	console    *consoleRing // model run console output lines captured during the run
 }
 
 // RunStateLogPage is run model status and page of the log lines.
@@ -197,29 +216,44 @@ type RunStateLogPage struct {
 
 // JobServiceState is a service state and job control state, it should NOT have any reference types members
 type JobServiceState struct {
-	IsQueuePaused     bool       // this oms instance: if true then jobs queue is paused, jobs are not selected from queue
-	IsAllQueuePaused  bool       // all oms instances: if true then jobs queue is paused, jobs are not selected from queue
-	JobUpdateDateTime string     // last date-time jobs list updated
-	MpiRes            ComputeRes // MPI total available resources available (CPU cores and memory) as sum of all servers or localhost resources
-	MaxOwnMpiRes      ComputeRes // resources limit (CPU cores and memory) for each oms instance
-	ActiveTotalRes    ComputeRes // MPI active run resources (CPU cores and memory) used by all oms instances
-	ActiveOwnRes      ComputeRes // MPI active run resources (CPU cores and memory) used by this oms instance
-	QueueTotalRes     ComputeRes // MPI queue run resources (CPU cores and memory) requested by all oms instances
-	QueueOwnRes       ComputeRes // MPI queue run resources (CPU cores and memory) requested by this oms instance
-	MpiErrorRes       ComputeRes // MPI computational resources on "error" servers
-	MpiMaxThreads     int        // max number of modelling threads per MPI process, zero means unlimited
-	LocalRes          ComputeRes // localhost non-MPI jobs total resources limits
-	LocalActiveRes    ComputeRes // localhost non-MPI jobs resources used by this instance to run models
-	LocalQueueRes     ComputeRes // localhost non-MPI jobs queue resources for this oms instance
-	isLeader          bool       // if true then this oms instance is a leader
-	maxStartTime      int64      // max time in milliseconds to start compute server or cluster
-	maxStopTime       int64      // max time in milliseconds to stop compute server or cluster
-	maxIdleTime       int64      // max idle in milliseconds time before stopping server or cluster
-	lastStartStopTs   int64      // last time when start or stop of computational servers done
-	maxComputeErrors  int        // errors threshold for compute server or cluster
-	jobLastPosition   int        // last job position in the queue
-	jobFirstPosition  int        // minimal job position in the queue
-	hostFile          hostIni    // MPI jobs hostfile settings
+	IsQueuePaused     bool             // this oms instance: if true then jobs queue is paused, jobs are not selected from queue
+	IsAllQueuePaused  bool             // all oms instances: if true then jobs queue is paused, jobs are not selected from queue
+	JobUpdateDateTime string           // last date-time jobs list updated
+	MpiRes            ComputeRes       // MPI total available resources available (CPU cores and memory) as sum of all servers or localhost resources
+	MaxOwnMpiRes      ComputeRes       // resources limit (CPU cores and memory) for each oms instance
+	ActiveTotalRes    ComputeRes       // MPI active run resources (CPU cores and memory) used by all oms instances
+	ActiveOwnRes      ComputeRes       // MPI active run resources (CPU cores and memory) used by this oms instance
+	QueueTotalRes     ComputeRes       // MPI queue run resources (CPU cores and memory) requested by all oms instances
+	QueueOwnRes       ComputeRes       // MPI queue run resources (CPU cores and memory) requested by this oms instance
+	MpiErrorRes       ComputeRes       // MPI computational resources on "error" servers
+	MpiMaxThreads     int              // max number of modelling threads per MPI process, zero means unlimited
+	LocalRes          ComputeRes       // localhost non-MPI jobs total resources limits
+	LocalActiveRes    ComputeRes       // localhost non-MPI jobs resources used by this instance to run models
+	LocalQueueRes     ComputeRes       // localhost non-MPI jobs queue resources for this oms instance
+	MaxModelRuns      int              // default max number of concurrent runs of each model by all oms instances, zero means unlimited
+	MaxUserRuns       int              // max number of concurrent runs for each oms instance (user), zero means unlimited
+	IsFairShare       bool             // if true then queue is ordered by fair share: jobs of models with less active runs go first
+	ActiveOwnRuns     int              // number of active runs of this oms instance
+	ModelRuns         []ModelRunsState // active and queue runs count of each model
+	isLeader          bool             // if true then this oms instance is a leader
+	maxStartTime      int64            // max time in milliseconds to start compute server or cluster
+	maxStopTime       int64            // max time in milliseconds to stop compute server or cluster
+	maxIdleTime       int64            // max idle in milliseconds time before stopping server or cluster
+	lastStartStopTs   int64            // last time when start or stop of computational servers done
+	maxComputeErrors  int              // errors threshold for compute server or cluster
+	maxRetries        int              // default max number of automatic requeue of the job after force cancel
+	jobLastPosition   int              // last job position in the queue
+	jobFirstPosition  int              // minimal job position in the queue
+	hostFile          hostIni          // MPI jobs hostfile settings
+}
+
+// ModelRunsState is number of active and queue runs of the model by all oms instances and max concurrent runs limit
+type ModelRunsState struct {
+	ModelName   string // model name
+	ModelDigest string // model digest
+	MaxRuns     int    // max number of concurrent runs of the model, zero means unlimited
+	ActiveRuns  int    // number of active runs of the model
+	QueueRuns   int    // number of model runs in the queue
 }
 
 // computational server or cluster state
@@ -456,3 +490,14 @@ func (rsc *RunCatalog) getCfgRes(digest string) modelCfgRes {
 	}
 	return modelCfgRes{}
 }
+
+// return max number of automatic job requeue after force cancel, if model not configured then use default value.
+func (rsc *RunCatalog) getMaxRetries(digest string) int {
+	rsc.rscLock.Lock()
+	defer rsc.rscLock.Unlock()
+
+	if mr, ok := rsc.cfgRes[digest]; ok {
+		return mr.MaxRetries
+	}
+	return rsc.maxRetries
+}
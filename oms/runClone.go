@@ -0,0 +1,199 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+)
+
+// CloneRunRequest return new model run request pre-filled with options of existing model run and with overrides applied.
+//
+// Source model run is found by model digest-or-name and run digest-or-stamp-or-name.
+// Run options are copied from run_option rows, except of run id, run stamp, run name, task, log and ini-file options.
+// Number of threads, sub-values count and microdata options are moved from run options into run request fields.
+func (mc *ModelCatalog) CloneRunRequest(crq *RunCloneRequest) (*RunRequest, error) {
+
+	// find model and source run
+	dn := crq.ModelDigest
+	if dn == "" {
+		dn = crq.ModelName
+	}
+	if dn == "" {
+		return nil, errors.New("invalid (empty) model digest and name")
+	}
+	if crq.RunDigest == "" {
+		return nil, errors.New("invalid (empty) source run digest, stamp and name: " + dn)
+	}
+
+	meta, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", db.ErrModelNotFound, dn)
+	}
+
+	r, err := db.GetRunByDigestStampName(dbConn, meta.Model.ModelId, crq.RunDigest)
+	if err != nil {
+		return nil, errors.New("Error at get model run: " + dn + ": " + crq.RunDigest + ": " + err.Error())
+	}
+	if r == nil {
+		return nil, fmt.Errorf("%w: %s: %s", db.ErrRunNotFound, dn, crq.RunDigest)
+	}
+
+	srcOpts, err := db.GetRunOptions(dbConn, r.RunId)
+	if err != nil {
+		return nil, errors.New("Error at get model run options: " + dn + ": " + crq.RunDigest + ": " + err.Error())
+	}
+
+	// copy source run options into new run request
+	req := RunRequest{
+		ModelName:   meta.Model.Name,
+		ModelDigest: meta.Model.Digest,
+		Opts:        map[string]string{},
+		Env:         map[string]string{},
+	}
+	microdataDot := "Microdata."
+
+	for key, val := range srcOpts {
+
+		k := strings.TrimPrefix(key, "-")
+		kLc := strings.ToLower(k)
+
+		// skip options specific to source run
+		if kLc == "openm.runid" || kLc == "openm.runstamp" || kLc == "openm.runname" ||
+			kLc == "openm.subvalues" || kLc == "openm.inifile" || kLc == "ini" ||
+			strings.HasPrefix(kLc, "openm.task") || strings.HasPrefix(kLc, "openm.log") {
+			continue
+		}
+
+		if kLc == "openm.threads" {
+			if n, e := strconv.Atoi(val); e == nil && n > 0 {
+				req.Threads = n
+			}
+			continue
+		}
+
+		// microdata options: use run request fields instead of run options
+		if strings.HasPrefix(kLc, strings.ToLower(microdataDot)) {
+
+			subKey := k[len(microdataDot):]
+			isVal := val == "" || strings.EqualFold(val, "true") || val == "1"
+
+			switch {
+			case strings.EqualFold(subKey, "ToDb"):
+				req.Microdata.IsToDb = isVal
+				continue
+			case strings.EqualFold(subKey, "UseInternal"):
+				req.Microdata.IsInternal = isVal
+				continue
+			case strings.EqualFold(subKey, "All"):
+				for j := range meta.Entity {
+					req.Microdata.Entity = append(req.Microdata.Entity, struct {
+						Name string
+						Attr []string
+					}{Name: meta.Entity[j].Name, Attr: []string{"All"}})
+				}
+				continue
+			}
+
+			isEntity := false
+			for j := range meta.Entity {
+
+				if isEntity = subKey == meta.Entity[j].Name; isEntity {
+					attrs := []string{}
+					for _, a := range strings.Split(val, ",") {
+						if a = strings.TrimSpace(a); a != "" {
+							attrs = append(attrs, a)
+						}
+					}
+					req.Microdata.Entity = append(req.Microdata.Entity, struct {
+						Name string
+						Attr []string
+					}{Name: subKey, Attr: attrs})
+					break
+				}
+			}
+			if isEntity {
+				continue
+			}
+		}
+
+		req.Opts[k] = val
+	}
+	if r.SubCount > 1 {
+		req.Opts["OpenM.SubValues"] = strconv.Itoa(r.SubCount)
+	}
+
+	// apply overrides from clone request
+	if crq.RunStamp != "" {
+		req.RunStamp = crq.RunStamp
+	}
+	if crq.Dir != "" {
+		req.Dir = crq.Dir
+	}
+	if crq.Template != "" {
+		req.Template = crq.Template
+	}
+	if crq.Threads > 0 {
+		req.Threads = crq.Threads
+	}
+	if crq.IsMpi || crq.Mpi.Np > 0 {
+		req.IsMpi = crq.IsMpi
+		req.Mpi = crq.Mpi
+	}
+	if len(crq.Tables) > 0 {
+		req.Tables = crq.Tables
+	}
+	if crq.Microdata.IsToDb {
+		req.Microdata = crq.Microdata
+	}
+	if len(crq.RunNotes) > 0 {
+		req.RunNotes = crq.RunNotes
+	}
+	req.Webhooks = crq.Webhooks
+
+	for key, val := range crq.Env {
+		req.Env[key] = val
+	}
+	for key, val := range crq.Opts {
+		setCloneOpt(req.Opts, strings.TrimPrefix(key, "-"), val)
+	}
+	if crq.SubCount > 0 {
+		setCloneOpt(req.Opts, "OpenM.SubValues", strconv.Itoa(crq.SubCount))
+	}
+
+	// scalar parameter values: -Parameter.Name value
+	for name, val := range crq.Params {
+
+		idx, ok := meta.ParamByName(name)
+		if !ok {
+			return nil, errors.New("model parameter not found: " + dn + ": " + name)
+		}
+		if meta.Param[idx].Rank != 0 {
+			return nil, errors.New("parameter value can be specified only for scalar parameter: " + dn + ": " + name)
+		}
+		if val == "" {
+			return nil, errors.New("invalid (empty) parameter value: " + dn + ": " + name)
+		}
+		setCloneOpt(req.Opts, "Parameter."+name, val)
+	}
+
+	return &req, nil
+}
+
+// replace run option by key, keys compared case-insensitive, if value is empty then option removed
+func setCloneOpt(opts map[string]string, key, val string) {
+
+	for k := range opts {
+		if strings.EqualFold(k, key) {
+			delete(opts, k)
+		}
+	}
+	if val != "" {
+		opts[key] = val
+	}
+}
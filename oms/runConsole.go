@@ -0,0 +1,149 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"time"
+)
+
+// max number of model run console output lines to keep in memory for each model run
+const consoleRingSize = 4000
+
+// ConsoleLine is a line of model run console output
+type ConsoleLine struct {
+	Seq   int64  // line sequence number, starting from zero
+	IsErr bool   // if true then line is from stderr else from stdout
+	Line  string // console output line
+}
+
+// RunConsolePage is model run state and console output lines since sequence number
+type RunConsolePage struct {
+	ModelDigest string        // model digest
+	RunStamp    string        // model run stamp
+	IsFinal     bool          // final state, model completed
+	NextSeq     int64         // sequence number of next console line, use it to get next page
+	IsTruncated bool          // if true then some of the lines since requested sequence number are lost: ring buffer overflow
+	Lines       []ConsoleLine // console output lines
+}
+
+// ring buffer of model run console output lines
+type consoleRing struct {
+	lines   []ConsoleLine // ring buffer of console lines
+	start   int           // index of oldest line in the buffer
+	nextSeq int64         // sequence number of next line
+	waitC   chan bool     // closed and replaced when new line appended or model run completed
+}
+
+// create new console output ring buffer
+func newConsoleRing() *consoleRing {
+	return &consoleRing{
+		lines: make([]ConsoleLine, 0, 128),
+		waitC: make(chan bool),
+	}
+}
+
+// append line to ring buffer, if buffer is full then replace oldest line, and notify all waiting listeners
+func (cr *consoleRing) append(isErr bool, line string) {
+
+	ln := ConsoleLine{Seq: cr.nextSeq, IsErr: isErr, Line: line}
+	cr.nextSeq++
+
+	if len(cr.lines) < consoleRingSize {
+		cr.lines = append(cr.lines, ln)
+	} else {
+		cr.lines[cr.start] = ln
+		cr.start = (cr.start + 1) % len(cr.lines)
+	}
+	cr.notify()
+}
+
+// notify all waiting listeners about new lines or model run completion
+func (cr *consoleRing) notify() {
+	close(cr.waitC)
+	cr.waitC = make(chan bool)
+}
+
+// return lines since sequence number and true if some of the lines are lost because of ring buffer overflow
+func (cr *consoleRing) since(seq int64) ([]ConsoleLine, bool) {
+
+	n := len(cr.lines)
+	if n <= 0 || seq >= cr.nextSeq {
+		return []ConsoleLine{}, false
+	}
+
+	first := cr.lines[cr.start].Seq
+	isTrunc := seq < first
+	if isTrunc {
+		seq = first
+	}
+
+	k := int(seq - first)
+	ls := make([]ConsoleLine, 0, n-k)
+	for ; k < n; k++ {
+		ls = append(ls, cr.lines[(cr.start+k)%n])
+	}
+	return ls, isTrunc
+}
+
+// appendRunConsole append model run console output line to the ring buffer
+func (rsc *RunCatalog) appendRunConsole(rState *RunState, isErr bool, line string) {
+	if rState == nil || rState.ModelDigest == "" || rState.RunStamp == "" {
+		return // invalid run state
+	}
+
+	rsc.rscLock.Lock()
+	defer rsc.rscLock.Unlock()
+
+	rsl := rsc.findRunStateLog(rState.ModelDigest, rState.RunStamp)
+	if rsl == nil {
+		return // model run not found
+	}
+	if rsl.console == nil {
+		rsl.console = newConsoleRing()
+	}
+	rsl.console.append(isErr, line)
+}
+
+// getRunConsole return model run state and console output lines since sequence number.
+// It also return channel which is closed when new lines appended or model run completed.
+// Return false if model run not found by digest and run stamp or submit stamp.
+func (rsc *RunCatalog) getRunConsole(digest, stamp string, seq int64) (*RunConsolePage, <-chan bool, bool) {
+
+	cp := &RunConsolePage{
+		ModelDigest: digest,
+		Lines:       []ConsoleLine{},
+	}
+	if digest == "" || stamp == "" {
+		return cp, nil, false // empty model digest or stamp (run-or-submit stamp): exit
+	}
+	if seq < 0 {
+		seq = 0
+	}
+
+	rsc.rscLock.Lock()
+	defer rsc.rscLock.Unlock()
+
+	rsl := rsc.findRunStateLog(digest, stamp)
+	if rsl == nil {
+		return cp, nil, false // not found by run stamp or submit stamp
+	}
+	rsl.logUsedTs = time.Now().Unix()
+
+	cp.RunStamp = rsl.RunStamp
+	cp.IsFinal = rsl.IsFinal
+	cp.NextSeq = seq
+
+	if rsl.console == nil {
+		if rsl.IsFinal {
+			return cp, nil, true // model run completed without console output captured by this service instance
+		}
+		rsl.console = newConsoleRing() // model not started yet
+	}
+	cp.Lines, cp.IsTruncated = rsl.console.since(seq)
+
+	if n := len(cp.Lines); n > 0 {
+		cp.NextSeq = cp.Lines[n-1].Seq + 1
+	}
+	return cp, rsl.console.waitC, true
+}
@@ -5,13 +5,10 @@ package main
 
 import (
 	"errors"
-	"path"
-	"path/filepath"
 	"sort"
 	"strconv"
 	"time"
 
-	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 )
 
@@ -265,10 +262,10 @@ func (rsc *RunCatalog) addJobToQueue(job *RunJob) (string, error) {
 		job.SubmitStamp, job.ModelName, job.ModelDigest, job.IsMpi, rsc.nextJobPosition(), job.Res.ProcessCount, job.Res.ThreadCount, job.Res.ProcessMemMb, job.Res.ThreadMemMb,
 	)
 
-	err := helper.ToJsonIndentFile(fp, job)
+	err := jobToJsonFile(fp, job)
 	if err != nil {
 		omppLog.Log(err)
-		fileDeleteAndLog(true, fp) // on error remove file, if any file created
+		jobFileDelete(true, fp) // on error remove file, if any file created
 		return "", err
 	}
 
@@ -678,21 +675,7 @@ func (rsc *RunCatalog) updateRunJobs(
 	}
 
 	// copy model resources requirements
-	clear(rsc.cfgRes)
-	binRoot, _ := theCatalog.getModelDir()
-	br := filepath.ToSlash(binRoot)
-
-	for dgst, mb := range rsc.models {
-
-		sp := filepath.ToSlash(filepath.Join(mb.binDir, mb.name))
-
-		for _, rs := range cfgRes {
-			if sp == path.Join(br, rs.Path) {
-				rsc.cfgRes[dgst] = rs
-				break
-			}
-		}
-	}
+	rsc.cfgRes = rsc.cfgResByDigest(cfgRes)
 
 	// update queue jobs and collect all new submission stamps
 	for stamp := range rsc.queueJobs {
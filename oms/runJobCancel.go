@@ -0,0 +1,227 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// default cancel hook template, model specific template is: "cancel.ModelName.template.txt"
+const defaultCancelTemplate = "cancel.ModelRun.template.txt"
+
+// JobCancelResult is result of active job force cancel
+type JobCancelResult struct {
+	SubmitStamp    string // submission stamp of cancelled job
+	ModelName      string // model name
+	ModelDigest    string // model digest
+	RunStamp       string // model run stamp
+	Reason         string // reason of force cancel
+	IsKill         bool   // if true then model process was running and killed
+	IsRequeue      bool   // if true then job added to the queue again
+	NewSubmitStamp string // if not empty then submission stamp of requeued job
+	RetryCount     int    // number of times job was requeued, including new job
+	MaxRetries     int    // max number of automatic requeue of the job for that model
+}
+
+// forceCancelJob kill active job process group, mark job as failed with reason and optionally requeue it.
+//
+// If model process is running by this oms instance then process group killed and job moved to history by model run wait.
+// If there is no model process, e.g. after oms restart, then active job file moved to history and model run status updated to error.
+// If cancel hook template exist: "cancel.ModelName.template.txt" or "cancel.ModelRun.template.txt"
+// then template processing results executed to cancel job on cluster, e.g.: scancel.
+// Job is added to the queue again if requeue allowed and job retry count is less than model MaxRetries.
+func (rsc *RunCatalog) forceCancelJob(submitStamp, reason string, isRequeue bool) (*JobCancelResult, error) {
+
+	if !theCfg.isJobControl {
+		return nil, errors.New("Error: job control disabled")
+	}
+
+	aj, ok := rsc.getActiveJobItem(submitStamp)
+	if !ok || aj.isError {
+		return nil, errors.New("Error: active job not found: " + submitStamp)
+	}
+	if aj.oms != theCfg.omsName {
+		return nil, errors.New("Error: job " + submitStamp + " is running by other oms instance: " + aj.oms)
+	}
+	if reason == "" {
+		reason = "force cancel"
+	}
+	omppLog.Log("Force cancel job: ", submitStamp, " ", aj.ModelName, " ", aj.ModelDigest, " ", aj.RunStamp, ": ", reason)
+
+	res := JobCancelResult{
+		SubmitStamp: submitStamp,
+		ModelName:   aj.ModelName,
+		ModelDigest: aj.ModelDigest,
+		RunStamp:    aj.RunStamp,
+		Reason:      reason,
+		RetryCount:  aj.RetryCount,
+		MaxRetries:  rsc.getMaxRetries(aj.ModelDigest),
+	}
+
+	// store cancel reason in active job file, it is moved to history on model process exit
+	jc := aj.RunJob
+	jc.CancelReason = reason
+
+	if err := jobToJsonFile(aj.filePath, &jc); err != nil {
+		omppLog.Log(err)
+	}
+
+	// append cancel reason to the model run log
+	if isFound, rs := rsc.getRunStateBySubmitStamp(aj.ModelDigest, submitStamp); isFound {
+		rsc.updateRunStateLog(&rs, false, "Force cancel: "+reason)
+	}
+
+	// send kill to the model run wait and kill process group
+	rsc.rscLock.Lock()
+	pid := 0
+	if rsl := rsc.findRunStateLog(aj.ModelDigest, submitStamp); rsl != nil && rsl.killC != nil {
+		select {
+		case rsl.killC <- true: // model run wait is not blocked by previous kill request
+		default:
+		}
+		rsl.isKill = true
+		pid = rsl.pid
+		res.IsKill = true
+	}
+	rsc.rscLock.Unlock()
+
+	if res.IsKill {
+		if err := killProcessGroup(pid); err != nil {
+			omppLog.Log(err)
+		}
+	} else {
+		// there is no model process: move active job to history and update model run status
+		moveActiveJobToHistory(aj.filePath, db.ErrorRunStatus, true, submitStamp, aj.ModelName, aj.ModelDigest, aj.RunStamp)
+
+		if aj.RunStamp != "" {
+			if _, err := theCatalog.UpdateRunStatus(aj.ModelDigest, aj.RunStamp, db.ErrorRunStatus); err != nil {
+				omppLog.Log(err)
+			}
+		}
+	}
+
+	// run cancel hook, if template exist
+	rsc.runCancelHook(&aj.RunJob)
+
+	// requeue the job if retries limit not exceeded
+	if !isRequeue || aj.RetryCount >= res.MaxRetries {
+		return &res, nil
+	}
+
+	nStamp, _ := theCatalog.getNewTimeStamp()
+
+	job := RunJob{
+		SubmitStamp:  nStamp,
+		RunRequest:   aj.RunRequest,
+		Res:          aj.Res,
+		RetryCount:   aj.RetryCount + 1,
+		CancelReason: reason,
+	}
+	// new model run must have new run stamp: remove run stamp of cancelled job
+	job.RunStamp = ""
+	job.Opts = make(map[string]string, len(aj.Opts))
+	for key, val := range aj.Opts {
+		if !strings.EqualFold(key, "OpenM.RunStamp") && !strings.EqualFold(key, "-OpenM.RunStamp") {
+			job.Opts[key] = val
+		}
+	}
+
+	if _, err := rsc.addJobToQueue(&job); err != nil {
+		return &res, errors.New("Error: failed to requeue job: " + submitStamp + ": " + err.Error())
+	}
+	omppLog.Log("Requeue job: ", submitStamp, " as: ", nStamp, " retry: ", job.RetryCount, " of: ", res.MaxRetries)
+
+	res.IsRequeue = true
+	res.NewSubmitStamp = nStamp
+	res.RetryCount = job.RetryCount
+
+	return &res, nil
+}
+
+// runCancelHook execute cancel template, if template exist: "cancel.ModelName.template.txt" or "cancel.ModelRun.template.txt".
+// Template processing results are command to cancel job, e.g.: scancel, first non-empty line is executable name
+// and all other non-empty lines are command line arguments.
+// Cancel command is executed in background and output is written into oms log.
+func (rsc *RunCatalog) runCancelHook(job *RunJob) {
+
+	tn := filepath.Join(rsc.etcDir, "cancel."+job.ModelName+".template.txt")
+	if !fileExist(tn) {
+		tn = filepath.Join(rsc.etcDir, defaultCancelTemplate)
+		if !fileExist(tn) {
+			return // there is no cancel template
+		}
+	}
+
+	tmpl, err := template.ParseFiles(tn)
+	if err != nil {
+		omppLog.Log("Error at cancel template: ", tn, ": ", err)
+		return
+	}
+
+	d := struct {
+		ModelName   string // model name
+		ModelDigest string // model digest
+		SubmitStamp string // job submission stamp
+		RunStamp    string // model run stamp
+		Pid         int    // model process id
+		IsMpi       bool   // if true then it is MPI model run
+		WorkDir     string // model run work directory
+	}{
+		ModelName:   job.ModelName,
+		ModelDigest: job.ModelDigest,
+		SubmitStamp: job.SubmitStamp,
+		RunStamp:    job.RunStamp,
+		Pid:         job.Pid,
+		IsMpi:       job.IsMpi,
+		WorkDir:     job.WorkDir,
+	}
+
+	var b strings.Builder
+
+	if err = tmpl.Execute(&b, d); err != nil {
+		omppLog.Log("Error at cancel template: ", tn, ": ", err)
+		return
+	}
+	tLines := strings.Split(strings.ReplaceAll(b.String(), "\r", "\n"), "\n")
+
+	cExe := ""
+	cArgs := []string{}
+
+	for k := range tLines {
+
+		cl := strings.TrimSpace(tLines[k])
+		if cl == "" {
+			continue
+		}
+		if cExe == "" {
+			cExe = cl
+		} else {
+			cArgs = append(cArgs, cl)
+		}
+	}
+	if cExe == "" {
+		omppLog.Log("Warning: empty cancel template processing results: ", tn)
+		return
+	}
+
+	go func(stamp string, cmd *exec.Cmd) {
+
+		omppLog.Log("Cancel job: ", stamp, ": ", strings.Join(cmd.Args, " "))
+
+		out, e := cmd.CombinedOutput()
+		if len(out) > 0 {
+			omppLog.Log(string(out))
+		}
+		if e != nil {
+			omppLog.Log("Error at cancel job: ", stamp, ": ", e)
+		}
+	}(job.SubmitStamp, exec.Command(cExe, cArgs...))
+}
@@ -4,6 +4,8 @@
 package main
 
 import (
+	"errors"
+	"io/fs"
 	"math"
 	"os"
 	"path/filepath"
@@ -19,15 +21,19 @@ import (
 // jobDirValid checking job control configuration
 // and return flags: is job control enabled, is past sub-directory exists, is disk usage control enabled.
 // if job control directory is empty then job control disabled.
-// if job control directory not empty then it must have active, state, queue, history subdirectories.
-func jobDirValid(jobDir string) (bool, bool, error) {
+// if job control directory not empty then it must have active, state, queue, history subdirectories,
+// unless job control state is stored in database.
+func jobDirValid(jobDir string, isJobDb bool) (bool, bool, error) {
 
 	if jobDir == "" {
 		return false, false, nil // job control disabled
 	}
-	if !dirExist(jobDir) ||
-		!dirExist(filepath.Join(jobDir, "active")) || !dirExist(filepath.Join(jobDir, "state")) ||
-		!dirExist(filepath.Join(jobDir, "queue")) || !dirExist(filepath.Join(jobDir, "history")) {
+	if !dirExist(jobDir) {
+		return false, false, nil
+	}
+	if !isJobDb &&
+		(!dirExist(filepath.Join(jobDir, "active")) || !dirExist(filepath.Join(jobDir, "state")) ||
+			!dirExist(filepath.Join(jobDir, "queue")) || !dirExist(filepath.Join(jobDir, "history"))) {
 		return false, false, nil
 	}
 	isPast := dirExist(filepath.Join(jobDir, "past"))
@@ -440,21 +446,41 @@ func parseDiskUseStatePath(srcPath string) (string, int64, bool, string, int64)
 	return sp[1], (mb * 1024 * 1024), isOver, sp[6], tickMs
 }
 
-// move run job to active state from queue
-func moveJobToActive(queueJobPath string, rState *RunState, res RunRes, runStamp, iniPath, binDir, workDir string) (string, bool) {
+// claim run job from the queue: read run request and remove job control file from the queue.
+// Job store remove is atomic and only one oms instance can remove the same queue job file.
+// Return false if job file not exist or already removed from the queue, e.g. job claimed by other oms instance.
+func claimQueueJob(queueJobPath string) (*RunJob, bool) {
 	if !theCfg.isJobControl {
-		return "", true // job control disabled
+		return nil, true // job control disabled
 	}
 
 	// read run request from job queue
 	var jc RunJob
-	isOk, err := helper.FromJsonFile(queueJobPath, &jc)
+	isOk, err := jobFromJsonFile(queueJobPath, &jc)
 	if err != nil {
 		omppLog.Log(err)
 	}
 	if !isOk || err != nil {
-		fileDeleteAndLog(true, queueJobPath) // invalid file content: remove job control file from queue
-		return "", false
+		jobFileDelete(true, queueJobPath) // invalid file content: remove job control file from queue
+		return nil, false
+	}
+
+	// remove job control file from queue, it fails if job already claimed by other oms instance
+	if err = theJobStore.Remove(queueJobPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			omppLog.Log("Job already claimed by other oms instance: ", queueJobPath)
+		} else {
+			omppLog.Log("Error at claim job: ", queueJobPath, " ", err)
+		}
+		return nil, false
+	}
+	return &jc, true
+}
+
+// move claimed run job to active state
+func moveJobToActive(jc *RunJob, rState *RunState, res RunRes, runStamp, iniPath, binDir, workDir string) (string, bool) {
+	if !theCfg.isJobControl || jc == nil {
+		return "", true // job control disabled
 	}
 
 	// add run stamp, process info, actual run resources, log file and move job control file into active
@@ -470,12 +496,9 @@ func moveJobToActive(queueJobPath string, rState *RunState, res RunRes, runStamp
 
 	dst := jobActivePath(rState.SubmitStamp, rState.ModelName, rState.ModelDigest, runStamp, jc.IsMpi, rState.pid, jc.Res.Cpu, jc.Res.Mem)
 
-	fileDeleteAndLog(false, queueJobPath) // remove job control file from queue
-
-	err = helper.ToJsonIndentFile(dst, &jc)
-	if err != nil {
+	if err := jobToJsonFile(dst, jc); err != nil {
 		omppLog.Log(err)
-		fileDeleteAndLog(true, dst) // on error remove file, if any file created
+		jobFileDelete(true, dst) // on error remove file, if any file created
 		return "", false
 	}
 
@@ -491,9 +514,9 @@ func moveActiveJobToHistory(activePath, status string, isKill bool, submitStamp,
 	// move active job file to history
 	hst := jobHistoryPath(status, isKill, submitStamp, modelName, modelDigest, runStamp)
 
-	isOk := fileMoveAndLog(false, activePath, hst)
+	isOk := jobFileMove(false, activePath, hst)
 	if !isOk {
-		fileDeleteAndLog(true, activePath) // if move failed then delete job control file from active list
+		jobFileDelete(true, activePath) // if move failed then delete job control file from active list
 	} else {
 		if theCfg.isJobPast { // copy to the shadow history path
 
@@ -501,7 +524,7 @@ func moveActiveJobToHistory(activePath, status string, isKill bool, submitStamp,
 			d := filepath.Join(pastDir, monthDir)
 
 			if os.MkdirAll(d, 0750) == nil {
-				fileCopy(false, hst, filepath.Join(d, fn))
+				jobFileCopyOut(hst, filepath.Join(d, fn))
 			}
 		}
 	}
@@ -510,9 +533,9 @@ func moveActiveJobToHistory(activePath, status string, isKill bool, submitStamp,
 	// for example: job/state/comp-used-#-name-#-2022_07_08_23_03_27_555-#-_4040-#-cpu-#-4-#-mem-#-8
 	ptrn := filepath.Join(theCfg.jobDir, "state") + string(filepath.Separator) + "comp-used-#-*-#-" + submitStamp + "-#-" + theCfg.omsName + "-#-cpu-#-*-#-mem-#-*"
 
-	if fLst, err := filepath.Glob(ptrn); err == nil {
+	if fLst, err := theJobStore.Glob(ptrn); err == nil {
 		for _, f := range fLst {
-			fileDeleteAndLog(false, f)
+			jobFileDelete(false, f)
 		}
 	}
 	return isOk
@@ -529,8 +552,8 @@ func moveJobQueueToFailed(queuePath string, submitStamp, modelName, modelDigest,
 
 	hst := jobHistoryPath(db.ErrorRunStatus, isKill, submitStamp, modelName, modelDigest, runStamp)
 
-	if !fileMoveAndLog(true, queuePath, hst) {
-		fileDeleteAndLog(true, queuePath) // if move failed then delete job control file from queue
+	if !jobFileMove(true, queuePath, hst) {
+		jobFileDelete(true, queuePath) // if move failed then delete job control file from queue
 		return false
 	} else {
 
@@ -540,13 +563,41 @@ func moveJobQueueToFailed(queuePath string, submitStamp, modelName, modelDigest,
 			d := filepath.Join(pastDir, monthDir)
 
 			if os.MkdirAll(d, 0750) == nil {
-				fileCopy(false, hst, filepath.Join(d, fn))
+				jobFileCopyOut(hst, filepath.Join(d, fn))
 			}
 		}
 	}
 	return true
 }
 
+// write claimed model run job control file into history as failed, e.g. if model failed to start
+func moveClaimedJobToFailed(jc *RunJob, submitStamp, modelName, modelDigest, runStamp string) bool {
+	if !theCfg.isJobControl || jc == nil {
+		return true // job control disabled
+	}
+	if !helper.IsUnderscoreTimeStamp(runStamp) {
+		runStamp = submitStamp
+	}
+
+	hst := jobHistoryPath(db.ErrorRunStatus, false, submitStamp, modelName, modelDigest, runStamp)
+
+	if err := jobToJsonFile(hst, jc); err != nil {
+		omppLog.Log(err)
+		jobFileDelete(true, hst) // on error remove file, if any file created
+		return false
+	}
+	if theCfg.isJobPast { // copy to the shadow history path
+
+		pastDir, monthDir, fn := jobPastPath(db.ErrorRunStatus, false, submitStamp, modelName, modelDigest, runStamp)
+		d := filepath.Join(pastDir, monthDir)
+
+		if os.MkdirAll(d, 0750) == nil {
+			jobFileCopyOut(hst, filepath.Join(d, fn))
+		}
+	}
+	return true
+}
+
 // read run title from job json file: return run name or task run name or workset name
 func getJobRunTitle(filePath string) string {
 	if !theCfg.isJobControl {
@@ -555,7 +606,7 @@ func getJobRunTitle(filePath string) string {
 
 	// read run request from job queue
 	var jc RunJob
-	isOk, err := helper.FromJsonFile(filePath, &jc)
+	isOk, err := jobFromJsonFile(filePath, &jc)
 	if err != nil {
 		omppLog.Log(err)
 	}
@@ -609,13 +660,13 @@ func makeOmsTick(lastRunStamp string) (string, bool) {
 	}
 	fnow := p + "-#-" + ts + "-#-" + strconv.FormatInt(tNow.UnixMilli(), 10) + "-#-" + lastRunStamp
 
-	isOk := fileCreateEmpty(false, fnow)
+	isOk := jobFileCreateEmpty(false, fnow)
 
 	// delete existing heart beat files for our oms instance
-	omsFiles := filesByPattern(p+"-#-*-#-*", "Error at oms heart beat files search")
+	omsFiles := jobFilesByPattern(p+"-#-*-#-*", "Error at oms heart beat files search")
 	for _, f := range omsFiles {
 		if f != fnow {
-			fileDeleteAndLog(false, f)
+			jobFileDelete(false, f)
 		}
 	}
 	return fnow, isOk
@@ -634,7 +685,7 @@ func createCompStateFile(name, state string) string {
 		"state",
 		"comp-"+state+"-#-"+name+"-#-"+helper.MakeTimeStamp(ts)+"-#-"+strconv.FormatInt(ts.UnixMilli(), 10))
 
-	if !fileCreateEmpty(false, fp) {
+	if !jobFileCreateEmpty(false, fp) {
 		fp = ""
 	}
 	return fp
@@ -649,9 +700,9 @@ func deleteCompStateFiles(name, state string) bool {
 
 	isNoError := true
 
-	fl := filesByPattern(p+"-#-*-#-*", "Error at server state files search")
+	fl := jobFilesByPattern(p+"-#-*-#-*", "Error at server state files search")
 	for _, f := range fl {
-		isOk := fileDeleteAndLog(false, f)
+		isOk := jobFileDelete(false, f)
 		isNoError = isNoError && isOk
 		if !isOk {
 			createCompStateFile(name, "error")
@@ -664,19 +715,19 @@ func deleteCompStateFiles(name, state string) bool {
 
 // Return true if jobs queue processing is paused for this oms instance
 func isPausedJobQueue() bool {
-	return fileExist(jobQueuePausedPath(theCfg.omsName)) || fileExist(jobAllQueuePausedPath())
+	return jobFileExist(jobQueuePausedPath(theCfg.omsName)) || jobFileExist(jobAllQueuePausedPath())
 }
 
 // Return true if jobs queue processing is paused for all oms instances
 func isPausedJobAllQueue() bool {
-	return fileExist(jobAllQueuePausedPath())
+	return jobFileExist(jobAllQueuePausedPath())
 }
 
 // read job control state from the file, return empty state on error or if state file not exist
 func jobStateRead() (*jobControlState, bool) {
 
 	var jcs jobControlState
-	isOk, err := helper.FromJsonFile(jobStatePath(), &jcs)
+	isOk, err := jobFromJsonFile(jobStatePath(), &jcs)
 	if err != nil {
 		omppLog.Log(err)
 	}
@@ -692,7 +743,7 @@ func jobStateWrite(jsc jobControlState) bool {
 		return false // job control disabled
 	}
 
-	err := helper.ToJsonIndentFile(jobStatePath(), jsc)
+	err := jobToJsonFile(jobStatePath(), jsc)
 	if err != nil {
 		omppLog.Log(err)
 		return false
@@ -716,7 +767,7 @@ func diskUseStateWrite(duState *diskUseState, dbUse []dbDiskUse) bool {
 		DbUse:        dbUse,
 	}
 
-	err := helper.ToJsonIndentFile(
+	err := jobToJsonFile(
 		diskUseStatePath(duState.TotalSize, duState.IsOver, duState.UpdateTs),
 		&ds)
 	if err != nil {
@@ -733,9 +784,9 @@ func deleteDiskUseStateFiles() bool {
 	p := filepath.Join(theCfg.jobDir, "state", "disk-#-"+theCfg.omsName+"-#-size-#-*-#-status-#-*-#-*-#-*.json")
 	isNoError := true
 
-	fl := filesByPattern(p, "Error at disk use state files search")
+	fl := jobFilesByPattern(p, "Error at disk use state files search")
 	for _, f := range fl {
-		isOk := fileDeleteAndLog(false, f)
+		isOk := jobFileDelete(false, f)
 		isNoError = isNoError && isOk
 		if !isOk {
 			// createCompStateFile(name, "error")
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/openmpp/go/ompp/db"
-	"github.com/openmpp/go/ompp/helper"
 	"github.com/openmpp/go/ompp/omppLog"
 
 	ps "github.com/keybase/go-ps"
@@ -59,7 +58,7 @@ func scanOuterJobs(doneC <-chan bool) {
 
 	for {
 		// find active job files
-		fLst := filesByPattern(ptrn, "Error at active job files search")
+		fLst := jobFilesByPattern(ptrn, "Error at active job files search")
 		if len(fLst) <= 0 {
 			if isExitSleep(jobOuterScanInterval, doneC) {
 				return
@@ -88,7 +87,7 @@ func scanOuterJobs(doneC <-chan bool) {
 
 			// run state not found: create run state from active job file
 			var jc RunJob
-			isOk, err := helper.FromJsonFile(fLst[k], &jc)
+			isOk, err := jobFromJsonFile(fLst[k], &jc)
 			if err != nil {
 				omppLog.Log(err)
 			}
@@ -113,7 +112,7 @@ func scanOuterJobs(doneC <-chan bool) {
 			}
 
 			// check if job file not exist then remove it from the outer job list
-			if !fileExist(fp) {
+			if !jobFileExist(fp) {
 				delete(outerJobs, fp)
 				continue
 			}
@@ -234,12 +233,12 @@ func doStartStopCompute(name, state, exe string, args []string, maxTime int64) {
 
 		readyPath := compReadyPath(name)
 		if state == "start" {
-			isOk = fileCreateEmpty(false, readyPath)
+			isOk = jobFileCreateEmpty(false, readyPath)
 			if !isOk {
 				omppLog.Log("FAILED to create server ready file: ", readyPath)
 			}
 		} else {
-			isOk = fileDeleteAndLog(false, readyPath)
+			isOk = jobFileDelete(false, readyPath)
 			if !isOk {
 				omppLog.Log("FAILED to delete server ready file: ", readyPath)
 			}
@@ -280,7 +279,7 @@ func doStartOnceCompute(name string) {
 	omppLog.Log("Start: ", name)
 
 	readyPath := compReadyPath(name)
-	isOk := fileCreateEmpty(false, readyPath)
+	isOk := jobFileCreateEmpty(false, readyPath)
 	if !isOk {
 		omppLog.Log("FAILED to create server ready file: ", readyPath)
 	}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+)
+
+// number of most recent successful model runs to calculate average run duration
+const etaRunCount = 10
+
+// date-time layout of model run create and update date-time
+const runDtLayout = "2006-01-02 15:04:05.000"
+
+// JobQueueEstimate is queue job position, required and free resources and naive estimate of job start time
+type JobQueueEstimate struct {
+	QueuePos         int        // one-based position of the job in the queue, zero if job not in the queue
+	JobsAhead        int        // number of jobs ahead of this job in the same (MPI or localhost) queue
+	ActiveJobs       int        // number of active jobs in the same (MPI or localhost) queue
+	ReqRes           ComputeRes // resources required by the job: CPU cores and memory
+	TotalRes         ComputeRes // total resources of MPI servers or localhost, zero means unlimited
+	FreeRes          ComputeRes // currently free resources: total resources minus resources used by active jobs
+	AvgDuration      int64      // average duration in seconds of recent successful runs of the same model, zero if unknown
+	WaitSeconds      int64      // estimated time in seconds before job start, -1 if unknown
+	EstStartDateTime string     // estimated start date-time, empty if unknown
+}
+
+// queueJobEstimate return queue position, required and free resources and naive estimate of job start time.
+//
+// Estimate is based on average duration of recent successful runs of the same model from model database.
+// It is assumed that job starts after remaining time of active jobs and duration of all jobs ahead in the queue,
+// divided by number of active jobs, e.g. if there are no active jobs then it is a sum of jobs ahead durations.
+// If average run duration unknown for any of the model then estimate is unknown and WaitSeconds = -1.
+func (rsc *RunCatalog) queueJobEstimate(submitStamp string) (JobQueueEstimate, bool) {
+
+	type jobItem struct {
+		modelDigest string
+		filePath    string
+	}
+	est := JobQueueEstimate{WaitSeconds: -1}
+
+	// copy queue jobs ahead of this job and active jobs from the same queue: MPI or localhost
+	var qj queueJobFile
+	ahead := []jobItem{}
+	active := []jobItem{}
+
+	isFound := func() bool {
+		rsc.rscLock.Lock()
+		defer rsc.rscLock.Unlock()
+
+		var ok bool
+		qj, ok = rsc.queueJobs[submitStamp]
+		if !ok || qj.isError {
+			return false
+		}
+		for _, stamp := range rsc.queueKeys {
+			if stamp == submitStamp {
+				break
+			}
+			if j, ok := rsc.queueJobs[stamp]; ok && !j.isError && j.IsMpi == qj.IsMpi {
+				ahead = append(ahead, jobItem{modelDigest: j.ModelDigest})
+			}
+		}
+		for _, j := range rsc.activeJobs {
+			if !j.isError && j.IsMpi == qj.IsMpi {
+				active = append(active, jobItem{modelDigest: j.ModelDigest, filePath: j.filePath})
+			}
+		}
+
+		if qj.IsMpi {
+			est.TotalRes = rsc.MpiRes
+			est.FreeRes = ComputeRes{Cpu: rsc.MpiRes.Cpu - rsc.ActiveTotalRes.Cpu, Mem: rsc.MpiRes.Mem - rsc.ActiveTotalRes.Mem}
+		} else {
+			est.TotalRes = rsc.LocalRes
+			est.FreeRes = ComputeRes{Cpu: rsc.LocalRes.Cpu - rsc.LocalActiveRes.Cpu, Mem: rsc.LocalRes.Mem - rsc.LocalActiveRes.Mem}
+		}
+		return true
+	}()
+	if !isFound {
+		return est, false
+	}
+	if est.FreeRes.Cpu < 0 || est.TotalRes.Cpu <= 0 {
+		est.FreeRes.Cpu = 0
+	}
+	if est.FreeRes.Mem < 0 || est.TotalRes.Mem <= 0 {
+		est.FreeRes.Mem = 0
+	}
+
+	est.QueuePos = qj.QueuePos
+	est.JobsAhead = len(ahead)
+	est.ActiveJobs = len(active)
+	est.ReqRes = qj.Res.ComputeRes
+
+	// average run duration of each model, it is zero if unknown
+	avgDur := map[string]int64{}
+	avgOf := func(digest string) int64 {
+		if d, ok := avgDur[digest]; ok {
+			return d
+		}
+		d := int64(0)
+		if _, ok := theCatalog.ModelDicByDigest(digest); ok { // if model exist
+			if rl, ok := theCatalog.RunRowListByModel(digest); ok {
+				d = avgRunDuration(rl)
+			}
+		}
+		avgDur[digest] = d
+		return d
+	}
+
+	est.AvgDuration = avgOf(qj.ModelDigest)
+
+	// wait time: remaining time of active jobs and duration of jobs ahead, divided by number of active jobs
+	tNow := time.Now()
+	wait := int64(0)
+
+	for _, j := range active {
+
+		d := avgOf(j.modelDigest)
+		if d <= 0 {
+			return est, true // run duration unknown
+		}
+		if mt, err := theJobStore.ModTime(j.filePath); err == nil {
+			d -= int64(tNow.Sub(mt).Seconds()) // active job file created at model run start
+		}
+		if d > 0 {
+			wait += d
+		}
+	}
+	for _, j := range ahead {
+
+		d := avgOf(j.modelDigest)
+		if d <= 0 {
+			return est, true // run duration unknown
+		}
+		wait += d
+	}
+	if len(active) > 1 {
+		wait = wait / int64(len(active))
+	}
+
+	est.WaitSeconds = wait
+	est.EstStartDateTime = helper.MakeDateTime(tNow.Add(time.Duration(wait) * time.Second))
+	return est, true
+}
+
+// return average duration in seconds of most recent successful model runs or zero if there are no such runs.
+// Model run list expected to be sorted by run id.
+func avgRunDuration(runLst []db.RunRow) int64 {
+
+	n := 0
+	sum := 0.0
+
+	for k := len(runLst) - 1; k >= 0 && n < etaRunCount; k-- {
+
+		if runLst[k].Status != db.DoneRunStatus {
+			continue
+		}
+		st, err := time.ParseInLocation(runDtLayout, runLst[k].CreateDateTime, time.Local)
+		if err != nil {
+			continue
+		}
+		et, err := time.ParseInLocation(runDtLayout, runLst[k].UpdateDateTime, time.Local)
+		if err != nil || et.Before(st) {
+			continue
+		}
+		sum += et.Sub(st).Seconds()
+		n++
+	}
+	if n <= 0 {
+		return 0
+	}
+	return int64(sum/float64(n) + 0.5)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// model runs limits and fair share state: max concurrent runs for each model and for each oms instance (user)
+type runLimits struct {
+	maxModelRuns int            // default max number of concurrent runs of each model, zero means unlimited
+	maxUserRuns  int            // max number of concurrent runs for each oms instance, zero means unlimited
+	isFairShare  bool           // if true then queue jobs are ordered by fair share between models
+	maxRuns      map[string]int // max number of concurrent runs by model digest, if model configured in job.ini
+	modelActive  map[string]int // number of active runs by model digest, all oms instances
+	omsActive    map[string]int // number of active runs by oms instance name
+}
+
+// make model runs limits from job.ini settings and count active runs by model and by oms instance.
+// Update job service state with number of active and queue runs of each model.
+func makeRunLimits(jsState *JobServiceState, cfgRes map[string]modelCfgRes, queueFiles, activeFiles []string, omsActive map[string]omsUsage) *runLimits {
+
+	lim := runLimits{
+		maxModelRuns: jsState.MaxModelRuns,
+		maxUserRuns:  jsState.MaxUserRuns,
+		isFairShare:  jsState.IsFairShare,
+		maxRuns:      make(map[string]int, len(cfgRes)),
+		modelActive:  map[string]int{},
+		omsActive:    map[string]int{},
+	}
+	for dgst, cr := range cfgRes {
+		lim.maxRuns[dgst] = cr.MaxRuns
+	}
+
+	// count active runs of alive oms instances and queue runs by model digest
+	mState := map[string]ModelRunsState{}
+
+	for _, f := range activeFiles {
+
+		stamp, oms, mn, dgst, _, _, _, _, _ := parseActivePath(f)
+		if stamp == "" || oms == "" || mn == "" || dgst == "" {
+			continue // file name is not a job file name
+		}
+		if _, ok := omsActive[oms]; !ok {
+			continue // skip: oms instance inactive
+		}
+		lim.modelActive[dgst]++
+		lim.omsActive[oms]++
+
+		ms := mState[dgst]
+		ms.ModelName = mn
+		ms.ModelDigest = dgst
+		ms.ActiveRuns++
+		mState[dgst] = ms
+	}
+
+	for _, f := range queueFiles {
+
+		stamp, oms, mn, dgst, _, _, _, _, _, _ := parseQueuePath(f)
+		if stamp == "" || oms == "" || mn == "" || dgst == "" {
+			continue // file name is not a job file name
+		}
+		ms := mState[dgst]
+		ms.ModelName = mn
+		ms.ModelDigest = dgst
+		ms.QueueRuns++
+		mState[dgst] = ms
+	}
+
+	// update job service state: runs count by model, sorted by model name and digest
+	jsState.ActiveOwnRuns = lim.omsActive[theCfg.omsName]
+	jsState.ModelRuns = make([]ModelRunsState, 0, len(mState))
+
+	for dgst, ms := range mState {
+		ms.MaxRuns = lim.maxRunsOf(dgst)
+		jsState.ModelRuns = append(jsState.ModelRuns, ms)
+	}
+	sort.Slice(jsState.ModelRuns, func(i, j int) bool {
+		return jsState.ModelRuns[i].ModelName < jsState.ModelRuns[j].ModelName ||
+			jsState.ModelRuns[i].ModelName == jsState.ModelRuns[j].ModelName && jsState.ModelRuns[i].ModelDigest < jsState.ModelRuns[j].ModelDigest
+	})
+
+	return &lim
+}
+
+// return max number of concurrent runs of the model, zero means unlimited
+func (lim *runLimits) maxRunsOf(digest string) int {
+	if n, ok := lim.maxRuns[digest]; ok {
+		return n
+	}
+	return lim.maxModelRuns
+}
+
+// return true if model or oms instance (user) max concurrent runs limit reached and new model run cannot be started
+func (lim *runLimits) isLimit(digest, oms string) bool {
+
+	if n := lim.maxRunsOf(digest); n > 0 && lim.modelActive[digest] >= n {
+		return true
+	}
+	return lim.maxUserRuns > 0 && lim.omsActive[oms] >= lim.maxUserRuns
+}
+
+// return order of queue jobs: indices of model digests list.
+// If fair share disabled then order is not changed.
+// If fair share enabled then jobs of models with less active runs go first:
+// job rank is number of model active runs plus number of jobs of the same model ahead in the queue,
+// and queue is stable sorted by job rank, so models take turns.
+func (lim *runLimits) fairShareOrder(digests []string) []int {
+
+	idx := make([]int, len(digests))
+	for k := range idx {
+		idx[k] = k
+	}
+	if !lim.isFairShare || len(digests) <= 1 {
+		return idx
+	}
+
+	rank := make([]int, len(digests))
+	nQueue := map[string]int{}
+
+	for k, dgst := range digests {
+		rank[k] = lim.modelActive[dgst] + nQueue[dgst]
+		nQueue[dgst]++
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return rank[idx[i]] < rank[idx[j]] })
+
+	return idx
+}
+
+// return model resources configuration from job.ini by model digest, search by model bin directory and model name
+func (rsc *RunCatalog) getCfgResByDigest(cfgRes []modelCfgRes) map[string]modelCfgRes {
+	rsc.rscLock.Lock()
+	defer rsc.rscLock.Unlock()
+
+	return rsc.cfgResByDigest(cfgRes)
+}
+
+// return model resources configuration from job.ini by model digest, search by model bin directory and model name.
+// It must be called under the lock.
+func (rsc *RunCatalog) cfgResByDigest(cfgRes []modelCfgRes) map[string]modelCfgRes {
+
+	crm := map[string]modelCfgRes{}
+	binRoot, _ := theCatalog.getModelDir()
+	br := filepath.ToSlash(binRoot)
+
+	for dgst, mb := range rsc.models {
+
+		sp := filepath.ToSlash(filepath.Join(mb.binDir, mb.name))
+
+		for _, rs := range cfgRes {
+			if sp == path.Join(br, rs.Path) {
+				crm[dgst] = rs
+				break
+			}
+		}
+	}
+	return crm
+}
@@ -79,17 +79,17 @@ func scanStateJobs(doneC <-chan bool) {
 
 		jsState, cfgRes := initJobComputeState(jobIniPath, updateTs, computeState)
 
-		queueFiles := filesByPattern(queuePtrn, "Error at queue job files search")
-		activeFiles := filesByPattern(activePtrn, "Error at active job files search")
-		historyFiles := filesByPattern(historyPtrn, "Error at history job files search")
-		omsTickFiles := filesByPattern(omsTickPtrn, "Error at oms heart beat files search")
-		omsPausedFiles := filesByPattern(omsPausedPtrn, "Error at queue paused files search")
-		diskUseFiles := filesByPattern(diskUsePtrn, "Error at disk use files search")
-		compReadyFiles := filesByPattern(compReadyPtrn, "Error at server ready files search")
-		compStartFiles := filesByPattern(compStartPtrn, "Error at server start files search")
-		compStopFiles := filesByPattern(compStopPtrn, "Error at server stop files search")
-		compErrorFiles := filesByPattern(compErrorPtrn, "Error at server errors files search")
-		compUsedFiles := filesByPattern(compUsedPtrn, "Error at server usage files search")
+		queueFiles := jobFilesByPattern(queuePtrn, "Error at queue job files search")
+		activeFiles := jobFilesByPattern(activePtrn, "Error at active job files search")
+		historyFiles := jobFilesByPattern(historyPtrn, "Error at history job files search")
+		omsTickFiles := jobFilesByPattern(omsTickPtrn, "Error at oms heart beat files search")
+		omsPausedFiles := jobFilesByPattern(omsPausedPtrn, "Error at queue paused files search")
+		diskUseFiles := jobFilesByPattern(diskUsePtrn, "Error at disk use files search")
+		compReadyFiles := jobFilesByPattern(compReadyPtrn, "Error at server ready files search")
+		compStartFiles := jobFilesByPattern(compStartPtrn, "Error at server start files search")
+		compStopFiles := jobFilesByPattern(compStopPtrn, "Error at server stop files search")
+		compErrorFiles := jobFilesByPattern(compErrorPtrn, "Error at server errors files search")
+		compUsedFiles := jobFilesByPattern(compUsedPtrn, "Error at server usage files search")
 
 		jsState.jobLastPosition = jobPositionDefault + (1 + len(queueFiles))
 		jsState.jobFirstPosition = jobPositionDefault - (1 + len(queueFiles))
@@ -229,6 +229,9 @@ func scanStateJobs(doneC <-chan bool) {
 		// parse active files, use unlimited resources for already active jobs
 		aKeys, aTotal, aOwn, aLocal := updateActiveJobs(activeFiles, activeJobs, omsActive)
 
+		// count active runs by model and by oms instance to apply max concurrent runs limits
+		lim := makeRunLimits(&jsState, theRunCatalog.getCfgResByDigest(cfgRes), queueFiles, activeFiles, omsActive)
+
 		// parse queue files and re-build model runs queue
 		sort.Strings(queueFiles)
 		qKeys, maxPos, minPos, qTotal, qOwn, qLocal, firstHostUse := updateQueueJobs(
@@ -245,6 +248,7 @@ func scanStateJobs(doneC <-chan bool) {
 			computeState,
 			omsActive,
 			jsState.IsAllQueuePaused,
+			lim,
 		)
 
 		// parse history files list
@@ -334,7 +338,7 @@ func scanStateJobs(doneC <-chan bool) {
 		}
 	}
 
-	fileDeleteAndLog(true, omsTickPath) // try to remove oms heart beat file, this code may never be executed due to race at shutdown
+	jobFileDelete(true, omsTickPath) // try to remove oms heart beat file, this code may never be executed due to race at shutdown
 }
 
 // insert run job into job map: map job file submission stamp to file content (run job).
@@ -406,7 +410,7 @@ func updateActiveJobs(fLst []string, jobMap map[string]runJobFile, omsActive map
 
 		// create run state from job file
 		var jc RunJob
-		isOk, err := helper.FromJsonFile(f, &jc)
+		isOk, err := jobFromJsonFile(f, &jc)
 		if err != nil {
 			omppLog.Log(err)
 			jobMap[stamp] = runJobFile{filePath: f, isError: true, oms: oms}
@@ -436,6 +440,7 @@ func updateQueueJobs(
 	computeState map[string]computeItem,
 	omsActive map[string]omsUsage,
 	isAllPaused bool,
+	lim *runLimits,
 ) (
 	[]string, int, int, ComputeRes, ComputeRes, ComputeRes, jobHostUse) {
 
@@ -448,11 +453,13 @@ func updateQueueJobs(
 		fileIdx  int    // source file index
 		oms      string // instance name
 		stamp    string // submission stamp
+		digest   string // model digest
 		position int    // queue position: file name part
 		allQPos  int    // queue position: one based index in combined queue (queues from all oms instances)
 		res      RunRes // resources required to run the model
 		isPaused bool   // if true then job queue is paused
 		isOver   bool   // if true then resources required are exceeding total resource(s) limit(s)
+		isLimit  bool   // if true then model or oms instance max concurrent runs limit reached
 		isFirst  bool   // if true the it is the first job in global queue
 	}
 	type omsQ struct {
@@ -516,6 +523,7 @@ func updateQueueJobs(
 			fileIdx:  k,
 			oms:      oms,
 			stamp:    stamp,
+			digest:   dgst,
 			position: pos,
 			res: RunRes{
 				ComputeRes: ComputeRes{
@@ -529,6 +537,7 @@ func updateQueueJobs(
 			},
 			isPaused: isAllPaused || u.isPaused,
 			isOver:   isOver,
+			isLimit:  lim.isLimit(dgst, oms),
 		})
 		qAll[oms] = qOms
 	}
@@ -587,57 +596,70 @@ func updateQueueJobs(
 	// inside of each oms instance queue jobs are ordered by:
 	//   position in the queue (position which user can adjust)
 	//   submission stamp
+	// if fair share enabled then combined queue jobs of models with less active runs go first
+	type qIdx struct {
+		iOms int // index of oms instance
+		jq   int // index of job in oms instance queue
+	}
+	qOrder := make([]qIdx, 0, nFiles)
+	qDigest := make([]string, 0, nFiles)
+
+	for iOms := 0; iOms < nOms; iOms++ {
+		for jq, qf := range qAll[omsKeys[iOms]].q {
+			qOrder = append(qOrder, qIdx{iOms: iOms, jq: jq})
+			qDigest = append(qDigest, qf.digest)
+		}
+	}
 
 	totalRes := ComputeRes{} // total resources required to serve all queues
 	nextQueueIdx := 0        // global queue index position
 	isFirstJob := true
 	firstHostUse := jobHostUse{hostUse: []computeUse{}}
 
-	for iOms := 0; iOms < nOms; iOms++ {
+	for _, nq := range lim.fairShareOrder(qDigest) {
 
+		iOms := qOrder[nq].iOms
+		jq := qOrder[nq].jq
 		qOms := qAll[omsKeys[iOms]]
 
-		for jq := 0; jq < len(qOms.q); jq++ {
+		// collect total resource usage
+		// if current top job is not exceeding available resources then assign global queue index position
+		if !qOms.q[jq].isOver {
 
-			// collect total resource usage
-			// if current top job is not exceeding available resources then assign global queue index position
-			if !qOms.q[jq].isOver {
+			totalRes.Cpu = totalRes.Cpu + qOms.q[jq].res.Cpu
+			totalRes.Mem = totalRes.Mem + qOms.q[jq].res.Mem
 
-				totalRes.Cpu = totalRes.Cpu + qOms.q[jq].res.Cpu
-				totalRes.Mem = totalRes.Mem + qOms.q[jq].res.Mem
+			// check if there are any server(s) exists to run the job
+			srcJhu := jobHostUse{oms: omsKeys[iOms], stamp: qOms.q[jq].stamp, res: qOms.q[jq].res, hostUse: []computeUse{}}
 
-				// check if there are any server(s) exists to run the job
-				srcJhu := jobHostUse{oms: omsKeys[iOms], stamp: qOms.q[jq].stamp, res: qOms.q[jq].res, hostUse: []computeUse{}}
+			if qOms.q[jq].res.Mem > 0 {
+				qOms.q[jq].isOver, _ = findComputeRes(srcJhu, false, mpiMaxTh, hostByMem, computeState)
+			} else {
+				qOms.q[jq].isOver, _ = findComputeRes(srcJhu, false, mpiMaxTh, hostByCpu, computeState)
+			}
+
+			// if job queue not paused and runs limit not reached then allocate job to the servers and add servers to startup list
+			if !qOms.q[jq].isOver && !qOms.q[jq].isPaused && !qOms.q[jq].isLimit {
 
+				isOver := false
+				var dst jobHostUse
 				if qOms.q[jq].res.Mem > 0 {
-					qOms.q[jq].isOver, _ = findComputeRes(srcJhu, false, mpiMaxTh, hostByMem, computeState)
+					isOver, dst = findComputeRes(srcJhu, true, mpiMaxTh, hostByMem, computeState)
 				} else {
-					qOms.q[jq].isOver, _ = findComputeRes(srcJhu, false, mpiMaxTh, hostByCpu, computeState)
+					isOver, dst = findComputeRes(srcJhu, true, mpiMaxTh, hostByCpu, computeState)
 				}
 
-				// if job queue not paused then allocate job to the servers and add servers to startup list
-				if !qOms.q[jq].isOver && !qOms.q[jq].isPaused {
-
-					isOver := false
-					var dst jobHostUse
-					if qOms.q[jq].res.Mem > 0 {
-						isOver, dst = findComputeRes(srcJhu, true, mpiMaxTh, hostByMem, computeState)
-					} else {
-						isOver, dst = findComputeRes(srcJhu, true, mpiMaxTh, hostByCpu, computeState)
-					}
-
-					// if this is the first job in global queue then save host ini servers
-					if !isOver && isFirstJob {
-						isFirstJob = false
-						qOms.q[jq].isFirst = true
-						firstHostUse = dst
-					}
+				// if this is the first job in global queue then save host ini servers
+				if !isOver && isFirstJob {
+					isFirstJob = false
+					qOms.q[jq].isFirst = true
+					firstHostUse = dst
 				}
+			}
 
-				if !qOms.q[jq].isOver {
-					nextQueueIdx++
-					qOms.q[jq].allQPos = nextQueueIdx // one based index in global queue
-				}
+			if !qOms.q[jq].isOver {
+				nextQueueIdx++
+				qOms.q[jq].allQPos = nextQueueIdx // one based index in global queue
 			}
 		}
 
@@ -653,20 +675,34 @@ func updateQueueJobs(
 	isOmsDiskOver := omsActive[theCfg.omsName].isDiskOver            // if current oms instance exceded disk quota
 	isFirstJob = true
 
-	for _, f := range fLst {
+	// localhost queue files of current oms instance, if fair share enabled then jobs of models with less active runs go first
+	lIdx := make([]int, 0, nFiles)
+	lDigest := make([]string, 0, nFiles)
 
-		// get submission stamp, oms instance and queue position
-		stamp, oms, mn, dgst, isMpi, procCount, thCount, procMem, thMem, pos := parseQueuePath(f)
+	for k, f := range fLst {
+
+		stamp, oms, mn, dgst, isMpi, _, _, _, _, _ := parseQueuePath(f)
 		if stamp == "" || oms == "" || mn == "" || dgst == "" {
 			continue // file name is not a job file name
 		}
 		if isMpi || oms != theCfg.omsName {
 			continue // skip: this is MPI model run or it is from other oms instance
 		}
+		lIdx = append(lIdx, k)
+		lDigest = append(lDigest, dgst)
+	}
+
+	for _, nq := range lim.fairShareOrder(lDigest) {
+
+		// get submission stamp, oms instance and queue position
+		f := fLst[lIdx[nq]]
+		stamp, oms, _, dgst, _, procCount, thCount, procMem, thMem, pos := parseQueuePath(f)
+
 		cpu := procCount * thCount
 		mem := memoryRunSize(procCount, thCount, procMem, thMem)
 
 		isOver := isOmsDiskOver || (localRes.Cpu > 0 && cpu > localRes.Cpu) || (localRes.Mem > 0 && mem > localRes.Mem)
+		isLimit := lim.isLimit(dgst, oms)
 
 		if !isOver {
 			usedLocal.Cpu = usedLocal.Cpu + cpu
@@ -682,7 +718,8 @@ func updateQueueJobs(
 			jc.QueuePos = len(qKeys)
 			jc.isPaused = isOmsPaused
 			jc.IsOverLimit = isOver
-			jc.isFirst = !isOver && !isOmsPaused && isFirstJob
+			jc.IsRunLimit = isLimit
+			jc.isFirst = !isOver && !isLimit && !isOmsPaused && isFirstJob
 			queueJobs[stamp] = jc // update existing job in the queue with current resources info
 
 			if jc.isFirst {
@@ -693,7 +730,7 @@ func updateQueueJobs(
 		// else create run state from job file and insert into the queue map
 		var jc RunJob
 
-		isOk, err := helper.FromJsonFile(f, &jc)
+		isOk, err := jobFromJsonFile(f, &jc)
 		if err != nil {
 			omppLog.Log(err)
 			queueJobs[stamp] = queueJobFile{runJobFile: runJobFile{filePath: f, isError: true, oms: oms}}
@@ -702,10 +739,11 @@ func updateQueueJobs(
 			continue // file does not exist or invalid
 		}
 		jc.IsOverLimit = isOver
+		jc.IsRunLimit = isLimit
 		jc.QueuePos = len(qKeys) // one-based position in local queue of the current oms instance
 
 		// add new job into queue jobs map
-		isFirst := !isOver && !isOmsPaused && isFirstJob
+		isFirst := !isOver && !isLimit && !isOmsPaused && isFirstJob
 
 		queueJobs[stamp] = queueJobFile{
 			runJobFile: runJobFile{RunJob: jc, filePath: f, oms: oms},
@@ -741,6 +779,7 @@ func updateQueueJobs(
 			jc.position = f.position
 			jc.isPaused = isOmsPaused
 			jc.IsOverLimit = f.isOver
+			jc.IsRunLimit = f.isLimit
 			jc.isFirst = f.isFirst
 			jc.QueuePos = f.allQPos
 			jc.Res = f.res
@@ -750,7 +789,7 @@ func updateQueueJobs(
 		// else create run state from job file and insert into the queue map
 		var jc RunJob
 
-		isOk, err := helper.FromJsonFile(fLst[f.fileIdx], &jc)
+		isOk, err := jobFromJsonFile(fLst[f.fileIdx], &jc)
 		if err != nil {
 			omppLog.Log(err)
 			queueJobs[f.stamp] = queueJobFile{runJobFile: runJobFile{filePath: fLst[f.fileIdx], isError: true, oms: f.oms}}
@@ -759,6 +798,7 @@ func updateQueueJobs(
 			continue // file does not exist or invalid
 		}
 		jc.IsOverLimit = f.isOver
+		jc.IsRunLimit = f.isLimit
 		jc.QueuePos = f.allQPos
 		jc.Res = f.res
 
@@ -943,6 +983,21 @@ func initJobComputeState(jobIniPath string, updateTs time.Time, computeState map
 	jsState.maxStartTime = 1000 * opts.Int64("Common.StartTimeout", serverTimeoutDefault)
 	jsState.maxStopTime = 1000 * opts.Int64("Common.StopTimeout", serverTimeoutDefault)
 	jsState.maxComputeErrors = opts.Int("Common.MaxErrors", maxComputeErrorsDefault)
+	jsState.maxRetries = opts.Int("Common.MaxRetries", 0) // by default do not requeue job after force cancel
+	if jsState.maxRetries < 0 {
+		jsState.maxRetries = 0
+	}
+
+	// max concurrent runs for each model and for each oms instance (user) and fair share ordering of the queue
+	jsState.MaxModelRuns = opts.Int("Common.MaxModelRuns", 0) // unlimited by default
+	if jsState.MaxModelRuns < 0 {
+		jsState.MaxModelRuns = 0
+	}
+	jsState.MaxUserRuns = opts.Int("Common.MaxUserRuns", 0) // unlimited by default
+	if jsState.MaxUserRuns < 0 {
+		jsState.MaxUserRuns = 0
+	}
+	jsState.IsFairShare = opts.Bool("Common.FairShare")
 
 	// MPI jobs process, threads and hostfile config
 	jsState.MpiMaxThreads = opts.Int("Common.MpiMaxThreads", 0) // max number of modelling threads per MPI process, zero means unlimited
@@ -1061,7 +1116,15 @@ func initJobComputeState(jobIniPath string, updateTs time.Time, computeState map
 		if mt < 0 {
 			mt = 0
 		}
-		cfgRes = append(cfgRes, modelCfgRes{Path: p, ProcessMemMb: mp, ThreadMemMb: mt})
+		mr := opts.Int(p+".MaxRetries", jsState.maxRetries) // by default use common retries limit
+		if mr < 0 {
+			mr = 0
+		}
+		mx := opts.Int(p+".MaxRuns", jsState.MaxModelRuns) // by default use common model runs limit
+		if mx < 0 {
+			mx = 0
+		}
+		cfgRes = append(cfgRes, modelCfgRes{Path: p, ProcessMemMb: mp, ThreadMemMb: mt, MaxRetries: mr, MaxRuns: mx})
 	}
 
 	return jsState, cfgRes
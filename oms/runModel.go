@@ -46,6 +46,14 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 		rs.RunStamp = ts
 	}
 
+	// claim the job before any step which can fail: remove job control file from the queue
+	// it fails if job already claimed by other oms instance, failed job is moved from claimed state into the history
+	claimedJob, isClaimed := claimQueueJob(queueJobPath)
+	if !isClaimed {
+		rs.IsFinal = true
+		return rs, errors.New("Error at starting model, job not found in the queue: " + rs.ModelName + " " + rs.ModelDigest + " " + rs.SubmitStamp)
+	}
+
 	// set directories: work directory and bin model.exe directory
 	// if bin directory is relative then it must be relative to oms root directory
 	// re-base it to model work directory
@@ -55,7 +63,7 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	if !ok {
 		err := errors.New("Model not found: " + rs.ModelName + ": " + rs.ModelDigest)
 		omppLog.Log("Model run error: ", err)
-		moveJobQueueToFailed(queueJobPath, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp, false)
+		moveClaimedJobToFailed(claimedJob, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp)
 		rs.IsFinal = true
 		return rs, err // exit with error: model failed to start
 	}
@@ -90,7 +98,7 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	mArgs, iniPath, err := makeRunArgsIni(mb.binDir, wDir, mb.logDir, job, rs)
 	if err != nil {
 		omppLog.Log("Model run error: ", err)
-		moveJobQueueToFailed(queueJobPath, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp, false)
+		moveClaimedJobToFailed(claimedJob, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp)
 		rs.IsFinal = true
 		return rs, err
 	}
@@ -103,7 +111,7 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 
 		if err != nil {
 			omppLog.Log("Model run error: ", err)
-			moveJobQueueToFailed(queueJobPath, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp, false)
+			moveClaimedJobToFailed(claimedJob, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp)
 			rs.IsFinal = true
 			return rs, err
 		}
@@ -113,15 +121,16 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	delComputeUse := func(cuLst []computeUse) {
 		for _, cu := range cuLst {
 			if cu.filePath != "" {
-				fileDeleteAndLog(false, cu.filePath)
+				jobFileDelete(false, cu.filePath)
 			}
 		}
 	}
-	cleanAndReturn := func(e error, rState *RunState, qPath string, cuLst []computeUse) (*RunState, error) {
+	cleanAndReturn := func(e error, rState *RunState, jc *RunJob, cuLst []computeUse) (*RunState, error) {
 		omppLog.Log("Error at starting model: ", e)
 		delComputeUse(cuLst)
-		moveJobQueueToFailed(qPath, rState.SubmitStamp, rState.ModelName, rState.ModelDigest, rState.RunStamp, false)
+		moveClaimedJobToFailed(jc, rState.SubmitStamp, rState.ModelName, rState.ModelDigest, rState.RunStamp)
 		rState.IsFinal = true
+		notifyWebhooks(rState, job.Webhooks, db.ErrorRunStatus, tNow)
 		return rState, errors.New("Error at starting model " + rState.ModelName + ": " + e.Error())
 	}
 
@@ -131,7 +140,7 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	cmd, err := rsc.makeCommand(mExe, binDir, wDir, mb.dbPath, mArgs, job.RunRequest, job.Res.ProcessCount, hfPath)
 	if err != nil {
 		omppLog.Log("Error at starting model: ", err)
-		moveJobQueueToFailed(queueJobPath, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp, false)
+		moveClaimedJobToFailed(claimedJob, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp)
 		rs.IsFinal = true
 		return rs, errors.New("Error at starting model " + rs.ModelName + ": " + err.Error())
 	}
@@ -141,12 +150,12 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	for k := 0; !isErr && k < len(compUse); k++ {
 
 		compUse[k].filePath = compUsedPath(compUse[k].name, rs.SubmitStamp, compUse[k].Cpu, compUse[k].Mem)
-		isErr = !fileCreateEmpty(false, compUse[k].filePath)
+		isErr = !jobFileCreateEmpty(false, compUse[k].filePath)
 	}
 	if isErr {
 		omppLog.Log("Error at starting model: ", rs.ModelName, " ", rs.ModelDigest, " ", rs.SubmitStamp)
 		delComputeUse(compUse)
-		moveJobQueueToFailed(queueJobPath, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp, false)
+		moveClaimedJobToFailed(claimedJob, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp)
 		rs.IsFinal = true
 		return rs, errors.New("Error at starting model " + rs.ModelName + " " + rs.ModelDigest)
 	}
@@ -154,22 +163,23 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	// connect console output to log line array
 	outPipe, err := cmd.StdoutPipe()
 	if err != nil {
-		return cleanAndReturn(err, rs, queueJobPath, compUse)
+		return cleanAndReturn(err, rs, claimedJob, compUse)
 	}
 	errPipe, err := cmd.StderrPipe()
 	if err != nil {
-		return cleanAndReturn(err, rs, queueJobPath, compUse)
+		return cleanAndReturn(err, rs, claimedJob, compUse)
 	}
 	outDoneC := make(chan bool, 1)
 	errDoneC := make(chan bool, 1)
 	rs.killC = make(chan bool, 1)
 	logTck := time.NewTicker(logTickTimeout * time.Millisecond)
 
-	// append console output to log lines array
-	doLog := func(rState *RunState, r io.Reader, done chan<- bool) {
+	// append console output to log lines array and to console output ring buffer
+	doLog := func(rState *RunState, r io.Reader, isErr bool, done chan<- bool) {
 		sc := bufio.NewScanner(r)
 		for sc.Scan() {
 			rsc.updateRunStateLog(rState, false, sc.Text())
+			rsc.appendRunConsole(rState, isErr, sc.Text())
 		}
 		done <- true
 		close(done)
@@ -180,8 +190,8 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	rsc.createRunStateLog(rs)
 
 	// start console output listners
-	go doLog(rs, outPipe, outDoneC)
-	go doLog(rs, errPipe, errDoneC)
+	go doLog(rs, outPipe, false, outDoneC)
+	go doLog(rs, errPipe, true, errDoneC)
 
 	// start the model
 	omppLog.Log("Run model: ", mExe, " in directory: ", wDir)
@@ -192,24 +202,30 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 	rs.cmdPath = cmd.Path
 	rsc.updateRunStateProcess(rs, false)
 
+	// lock input workset while model is running, lock released if model failed to start
+	unlockWs := lockRunWorkset(rs.ModelDigest, job.Opts, "run:"+rs.RunStamp)
+
 	err = cmd.Start()
 	if err != nil {
 		omppLog.Log("Model run error: ", err)
+		unlockWs()
 		delComputeUse(compUse)
-		moveJobQueueToFailed(queueJobPath, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp, false)
+		moveClaimedJobToFailed(claimedJob, rs.SubmitStamp, rs.ModelName, rs.ModelDigest, rs.RunStamp)
 		rsc.updateRunStateLog(rs, true, err.Error())
 		rs.IsFinal = true
+		notifyWebhooks(rs, job.Webhooks, db.ErrorRunStatus, tNow)
 		return rs, err // exit with error: model failed to start
 	}
 	// else model started
 	rs.pid = cmd.Process.Pid
 	rsc.updateRunStateProcess(rs, false)
+	logRunActivity(runStartActivity, rs, db.ProgressRunStatus)
 
-	// move job file form queue to active
-	activeJobPath, _ := moveJobToActive(queueJobPath, rs, job.Res, rs.RunStamp, iniPath, binDir, wDir)
+	// move claimed job to active
+	activeJobPath, _ := moveJobToActive(claimedJob, rs, job.Res, rs.RunStamp, iniPath, binDir, wDir)
 
 	//  wait until run completed or terminated
-	go func(rState *RunState, cmd *exec.Cmd, jobPath string, cuLst []computeUse) {
+	go func(rState *RunState, cmd *exec.Cmd, jobPath string, cuLst []computeUse, whUrls []string, tStart time.Time) {
 
 		// wait until stdout and stderr closed
 		for outDoneC != nil || errDoneC != nil {
@@ -229,8 +245,11 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 				if isKill && ok {
 					omppLog.Log("Kill run: ", rState.ModelName, " ", rState.ModelDigest, " ", rState.RunName, " ", rState.RunStamp)
 					rState.isKill = true
-					if e := cmd.Process.Kill(); e != nil {
+					if e := killProcessGroup(cmd.Process.Pid); e != nil {
 						omppLog.Log(e)
+						if e = cmd.Process.Kill(); e != nil {
+							omppLog.Log(e)
+						}
 					}
 				}
 			case <-logTck.C:
@@ -239,6 +258,7 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 
 		// wait for model run to be completed
 		e := cmd.Wait()
+		unlockWs()
 		if e != nil {
 			omppLog.Log("Model run error: ", e)
 			delComputeUse(cuLst)
@@ -248,18 +268,45 @@ func (rsc *RunCatalog) runModel(job *RunJob, queueJobPath string, hfCfg hostIni,
 			if e != nil {
 				omppLog.Log(e)
 			}
+			if rState.isKill {
+				notifyWebhooks(rState, whUrls, db.ExitRunStatus, tStart)
+				logRunActivity(runDoneActivity, rState, db.ExitRunStatus)
+			} else {
+				notifyWebhooks(rState, whUrls, db.ErrorRunStatus, tStart)
+				logRunActivity(runDoneActivity, rState, db.ErrorRunStatus)
+			}
 			return
 		}
 		// else: completed OK
 		rsc.updateRunStateLog(rState, true, "")
 		delComputeUse(cuLst)
 		moveActiveJobToHistory(jobPath, db.DoneRunStatus, false, rState.SubmitStamp, rState.ModelName, rState.ModelDigest, rState.RunStamp)
+		if theCfg.isTableStats {
+			if _, e = theCatalog.UpdateRunTableStats(rState.ModelDigest, rState.RunStamp); e != nil {
+				omppLog.Log(e)
+			}
+		}
+		notifyWebhooks(rState, whUrls, db.DoneRunStatus, tStart)
+		logRunActivity(runDoneActivity, rState, db.DoneRunStatus)
 
-	}(rs, cmd, activeJobPath, compUse)
+	}(rs, cmd, activeJobPath, compUse, job.Webhooks, tNow)
 
 	return rs, nil
 }
 
+// runTemplateData is a model run template parameters
+type runTemplateData struct {
+	ModelName string            // model name
+	ExeStem   string            // base part of model exe name, usually modelName
+	Dir       string            // work directory to run the model
+	BinDir    string            // bin directory where model.exe is located
+	DbPath    string            // path to sqlite database file: models/bin/model.sqlite
+	MpiNp     int               // number of MPI processes
+	HostFile  string            // if not empty then absolute path to hostfile
+	Args      []string          // model command line arguments
+	Env       map[string]string // environment variables to run the model
+}
+
 // makeCommand return command to run the model.
 // If template file name specified then template processing results used to create command line.
 // If this is MPI model run then tempalate is requred
@@ -316,17 +363,7 @@ func (rsc *RunCatalog) makeCommand(mExe, binDir, workDir, dbPath string, mArgs [
 			np++
 		}
 
-		d := struct {
-			ModelName string            // model name
-			ExeStem   string            // base part of model exe name, usually modelName
-			Dir       string            // work directory to run the model
-			BinDir    string            // bin directory where model.exe is located
-			DbPath    string            // path to sqlite database file: models/bin/model.sqlite
-			MpiNp     int               // number of MPI processes
-			HostFile  string            // if not empty then absolute path to hostfile
-			Args      []string          // model command line arguments
-			Env       map[string]string // environment variables to run the model
-		}{
+		d := runTemplateData{
 			ModelName: req.ModelName,
 			ExeStem:   mExe,
 			Dir:       wd,
@@ -390,6 +427,7 @@ func (rsc *RunCatalog) makeCommand(mExe, binDir, workDir, dbPath string, mArgs [
 			cmd.Env = env
 		}
 	}
+	setProcessGroup(cmd) // kill of model run should also kill all child processes
 
 	return cmd, nil
 }
@@ -209,6 +209,9 @@ func (rsc *RunCatalog) updateRunStateLog(rState *RunState, isFinal bool, msg str
 	if isFinal {
 		rsl.killC = nil
 		rsl.isKill = rsl.isKill || rState.isKill
+		if rsl.console != nil {
+			rsl.console.notify() // model run completed: notify console output listeners
+		}
 	} else {
 		rsl.pid = rState.pid
 		rsl.killC = rState.killC
@@ -409,6 +412,9 @@ func (rsc *RunCatalog) clearModelRunsLog() {
 			if r.logUsedTs < dt && len(r.logLineLst) > 0 {
 				r.logLineLst = []string{} // clear log lines if content was not used recently
 			}
+			if r.logUsedTs < dt && r.IsFinal {
+				r.console = nil // clear console output of completed model run if it was not used recently
+			}
 		}
 	}
 }
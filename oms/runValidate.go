@@ -0,0 +1,279 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/openmpp/go/ompp/db"
+)
+
+// RunValidateResult is a result of model run request pre-flight validation
+type RunValidateResult struct {
+	ModelName     string               // model name
+	ModelDigest   string               // model digest
+	SetName       string               // input workset name, if workset found
+	BaseRunDigest string               // base run digest, if base run found
+	SubCount      int                  // number of sub-values: OpenM.SubValues, default: 1
+	Template      string               // model run template name, if template used
+	IsValid       bool                 // if true then there are no problems found
+	Problems      []RunValidateProblem // list of problems
+}
+
+// RunValidateProblem is a model run request problem found by pre-flight validation
+type RunValidateProblem struct {
+	Kind    string // kind of problem: option, workset, base-run, parameter, sub-count or template
+	Name    string // name of option, workset, base run, parameter or template
+	Message string // problem description
+}
+
+// ValidateRunRequest check model run request before the job is queued.
+//
+// It checks if input workset, base run and Parameter.Name run options cover all model parameters
+// and if sub-values count of workset parameters is consistent with OpenM.SubValues.
+// If workset not specified by OpenM.SetId or OpenM.SetName then model default workset is used.
+// If base run not specified by OpenM.BaseRunId, OpenM.BaseRunDigest or OpenM.BaseRunName then workset base run is used.
+// If OpenM.ParamDir specified then parameters not found in workset or base run are expected in csv files and not reported.
+// Return error if model not found or on database error, all other problems returned as list in validation result.
+func (mc *ModelCatalog) ValidateRunRequest(req *RunRequest) (*RunValidateResult, error) {
+
+	dn := req.ModelDigest
+	if dn == "" {
+		dn = req.ModelName
+	}
+	meta, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		return nil, errors.New("Model not found: " + dn)
+	}
+
+	vr := RunValidateResult{
+		ModelName:   meta.Model.Name,
+		ModelDigest: meta.Model.Digest,
+		SubCount:    1,
+		Problems:    []RunValidateProblem{},
+	}
+	addProblem := func(kind, name, msg string) {
+		vr.Problems = append(vr.Problems, RunValidateProblem{Kind: kind, Name: name, Message: msg})
+	}
+
+	// number of sub-values
+	if s, ok := runOptValue(req.Opts, "OpenM.SubValues"); ok {
+		if n, e := strconv.Atoi(s); e != nil || n <= 0 {
+			addProblem("option", "OpenM.SubValues", "invalid number of sub-values: "+s)
+		} else {
+			vr.SubCount = n
+		}
+	}
+
+	// find input workset by id or by name, if not specified then use model default workset
+	var ws *db.WorksetRow
+	var err error
+
+	if s, ok := runOptValue(req.Opts, "OpenM.SetId"); ok {
+		if id, e := strconv.Atoi(s); e != nil || id <= 0 {
+			addProblem("option", "OpenM.SetId", "invalid workset id: "+s)
+		} else {
+			if ws, err = db.GetWorkset(dbConn, id); err != nil {
+				return nil, err
+			}
+			if ws == nil || ws.ModelId != meta.Model.ModelId {
+				ws = nil
+				addProblem("workset", s, "workset not found by id: "+s)
+			}
+		}
+	} else {
+		if wsn, ok := runOptValue(req.Opts, "OpenM.SetName"); ok {
+			if ws, err = db.GetWorksetByName(dbConn, meta.Model.ModelId, wsn); err != nil {
+				return nil, err
+			}
+			if ws == nil {
+				addProblem("workset", wsn, "workset not found: "+wsn)
+			}
+		} else {
+			if ws, err = db.GetDefaultWorkset(dbConn, meta.Model.ModelId); err != nil {
+				return nil, err
+			}
+			if ws == nil {
+				addProblem("workset", "", "model default workset not found")
+			}
+		}
+	}
+	if ws != nil {
+		vr.SetName = ws.Name
+
+		if !ws.IsReadonly {
+			addProblem("workset", ws.Name, "workset must be read-only to run the model: "+ws.Name)
+		}
+	}
+
+	// find base run by run options or use workset base run
+	var baseRun *db.RunRow
+	bKey, bVal := "", ""
+
+	for _, key := range []string{"OpenM.BaseRunId", "OpenM.BaseRunDigest", "OpenM.BaseRunName"} {
+		if s, ok := runOptValue(req.Opts, key); ok {
+			bKey, bVal = key, s
+			break
+		}
+	}
+	switch {
+	case bKey == "OpenM.BaseRunId":
+		if id, e := strconv.Atoi(bVal); e != nil || id <= 0 {
+			addProblem("option", bKey, "invalid base run id: "+bVal)
+		} else {
+			if baseRun, err = db.GetRun(dbConn, id); err != nil {
+				return nil, err
+			}
+		}
+	case bKey != "":
+		if baseRun, err = db.GetRunByDigestStampName(dbConn, meta.Model.ModelId, bVal); err != nil {
+			return nil, err
+		}
+	case ws != nil && ws.BaseRunId > 0:
+		bVal = strconv.Itoa(ws.BaseRunId)
+		if baseRun, err = db.GetRun(dbConn, ws.BaseRunId); err != nil {
+			return nil, err
+		}
+	}
+	if baseRun != nil && baseRun.ModelId != meta.Model.ModelId {
+		baseRun = nil
+	}
+	if bVal != "" {
+		if baseRun == nil {
+			addProblem("base-run", bVal, "base run not found: "+bVal)
+		} else {
+			if baseRun.Status != db.DoneRunStatus {
+				addProblem("base-run", bVal, "base run is not completed successfully: "+bVal+" "+baseRun.Name)
+				baseRun = nil
+			} else {
+				vr.BaseRunDigest = baseRun.RunDigest
+			}
+		}
+	}
+
+	// parameters specified by run options: -Parameter.Name value
+	isOptParam := map[int]bool{}
+
+	for krq := range req.Opts {
+
+		key := strings.TrimPrefix(krq, "-")
+		if len(key) <= len("Parameter.") || !strings.EqualFold(key[:len("Parameter.")], "Parameter.") {
+			continue
+		}
+		name := key[len("Parameter."):]
+
+		if idx, ok := meta.ParamByName(name); ok {
+			isOptParam[meta.Param[idx].ParamHid] = true
+		} else {
+			addProblem("parameter", name, "model parameter not found: "+name)
+		}
+	}
+
+	// workset parameters and sub-values count
+	wsSubCount := map[int]int{}
+
+	if ws != nil {
+		hLst, nLst, _, err := db.GetWorksetParamList(dbConn, ws.SetId)
+		if err != nil {
+			return nil, err
+		}
+		for k := range hLst {
+			wsSubCount[hLst[k]] = nLst[k]
+		}
+	}
+
+	// each parameter must be in workset, base run or in run options
+	_, isParamDir := runOptValue(req.Opts, "OpenM.ParamDir")
+
+	for k := range meta.Param {
+
+		hId := meta.Param[k].ParamHid
+		name := meta.Param[k].Name
+
+		if n, ok := wsSubCount[hId]; ok {
+			if n > 1 && n != vr.SubCount {
+				addProblem("sub-count", name,
+					"parameter "+name+" has "+strconv.Itoa(n)+" sub-values, expected 1 or "+strconv.Itoa(vr.SubCount)+" (OpenM.SubValues)")
+			}
+			continue
+		}
+		if isOptParam[hId] || baseRun != nil || isParamDir {
+			continue
+		}
+		addProblem("parameter", name, "parameter "+name+" not found in workset and there is no base run")
+	}
+
+	vr.IsValid = len(vr.Problems) == 0
+	return &vr, nil
+}
+
+// validateTemplate check model run template: template must exist, it must be parsed and executed using model run parameters.
+// If this is MPI model run and template not specified then model specific MPI template or default MPI template is used.
+// Return template name, which is empty if template not used, and list of template problems.
+func (rsc *RunCatalog) validateTemplate(req *RunRequest) (string, []RunValidateProblem) {
+
+	rsc.rscLock.Lock()
+	etcDir := rsc.etcDir
+	isModelMpi := slices.Contains(rsc.mpiTemplates, "mpi."+req.ModelName+".template.txt")
+	rsc.rscLock.Unlock()
+
+	tn := req.Template
+	if req.IsMpi && tn == "" {
+		tn = defaultMpiTemplate
+		if isModelMpi {
+			tn = "mpi." + req.ModelName + ".template.txt"
+		}
+	}
+	if tn == "" {
+		return "", []RunValidateProblem{} // template not used to run the model
+	}
+
+	if !fileExist(filepath.Join(etcDir, tn)) {
+		return tn, []RunValidateProblem{{Kind: "template", Name: tn, Message: "model run template not found: " + tn}}
+	}
+
+	// parse template and execute it using model run parameters, unknown template placeholders are errors
+	tmpl, err := template.ParseFiles(filepath.Join(etcDir, tn))
+	if err != nil {
+		return tn, []RunValidateProblem{{Kind: "template", Name: tn, Message: "model run template error: " + err.Error()}}
+	}
+	tmpl.Option("missingkey=error")
+
+	d := runTemplateData{
+		ModelName: req.ModelName,
+		ExeStem:   req.ModelName,
+		Dir:       req.Dir,
+		MpiNp:     req.Mpi.Np,
+		Args:      []string{},
+		Env:       req.Env,
+	}
+	if d.Env == nil {
+		d.Env = map[string]string{}
+	}
+	var b strings.Builder
+
+	if err = tmpl.Execute(&b, d); err != nil {
+		return tn, []RunValidateProblem{{Kind: "template", Name: tn, Message: "model run template error: " + err.Error()}}
+	}
+	if strings.TrimSpace(b.String()) == "" {
+		return tn, []RunValidateProblem{{Kind: "template", Name: tn, Message: "empty model run template processing results: " + tn}}
+	}
+	return tn, []RunValidateProblem{}
+}
+
+// return run option value by key, keys compared case-insensitive and leading "-" of the key is ignored, e.g.: "-OpenM.SetName"
+func runOptValue(opts map[string]string, key string) (string, bool) {
+
+	for krq, val := range opts {
+		if strings.EqualFold(strings.TrimPrefix(krq, "-"), key) {
+			return val, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openmpp/go/ompp/db"
+)
+
+// SearchHit is a search result item: model, parameter, output table or entity attribute
+// where name, description or notes contains search terms.
+type SearchHit struct {
+	Kind        string // hit kind: model, parameter, table or entity-attr
+	ModelName   string // model name
+	ModelDigest string // model digest
+	Name        string // hit name: model name, parameter name, output table name or entity.attribute name
+	LangCode    string // language code of description and notes
+	Descr       string // description
+	Match       string // best match of search terms: name, descr or note
+	Rank        int    // search rank, hits with higher rank go first
+}
+
+// search kinds
+const (
+	searchModel      = "model"       // search hit is a model
+	searchParameter  = "parameter"   // search hit is a model parameter
+	searchTable      = "table"       // search hit is an output table
+	searchEntityAttr = "entity-attr" // search hit is an entity attribute
+)
+
+// search rank of each match, it is summarized by all search terms
+const (
+	rankNameExact  = 100 // name is equal to search term
+	rankNamePrefix = 60  // name starts with search term
+	rankName       = 40  // name contains search term
+	rankDescrWord  = 30  // description contains a word which starts with search term
+	rankDescr      = 20  // description contains search term
+	rankNote       = 5   // notes contains search term
+)
+
+// searchModelText search model name, description and notes, names and description and notes of parameters,
+// output tables and entity attributes.
+// Text is searched in language lc or in model default language lcd if there is no text in lc language.
+// Search terms must be lower case, each search term must be found in name, description or notes of the hit.
+// Model text metadata expected to be fully loaded.
+func (mc *ModelCatalog) searchModelText(digest string, terms []string, lc, lcd string) []SearchHit {
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	idx, ok := mc.indexByDigest(digest)
+	if !ok || mc.modelLst[idx].meta == nil || mc.modelLst[idx].txtMeta == nil {
+		return []SearchHit{} // model not found or text not loaded
+	}
+	meta := mc.modelLst[idx].meta
+	txt := mc.modelLst[idx].txtMeta
+
+	hits := []SearchHit{}
+
+	addHit := func(kind, name string, dn *db.DescrNote) {
+
+		if rank, m := searchRank(terms, name, dn.Descr, dn.Note); rank > 0 {
+			hits = append(hits, SearchHit{
+				Kind:        kind,
+				ModelName:   meta.Model.Name,
+				ModelDigest: meta.Model.Digest,
+				Name:        name,
+				LangCode:    dn.LangCode,
+				Descr:       dn.Descr,
+				Match:       m,
+				Rank:        rank,
+			})
+		}
+	}
+
+	// select description and notes in preferred language or in default language
+	isBetter := func(langCode string, dn *db.DescrNote, isFound bool) bool {
+		return !isFound || langCode == lc || langCode == lcd && dn.LangCode != lc
+	}
+
+	// model
+	mdn := db.DescrNote{}
+	isFound := false
+	for k := range txt.ModelTxt {
+		if isBetter(txt.ModelTxt[k].LangCode, &mdn, isFound) {
+			mdn = db.DescrNote{LangCode: txt.ModelTxt[k].LangCode, Descr: txt.ModelTxt[k].Descr, Note: txt.ModelTxt[k].Note}
+			isFound = true
+		}
+	}
+	addHit(searchModel, meta.Model.Name, &mdn)
+
+	// parameters
+	pTxt := map[int]db.DescrNote{}
+	for k := range txt.ParamTxt {
+		dn, ok := pTxt[txt.ParamTxt[k].ParamId]
+		if isBetter(txt.ParamTxt[k].LangCode, &dn, ok) {
+			pTxt[txt.ParamTxt[k].ParamId] = db.DescrNote{LangCode: txt.ParamTxt[k].LangCode, Descr: txt.ParamTxt[k].Descr, Note: txt.ParamTxt[k].Note}
+		}
+	}
+	for k := range meta.Param {
+		dn := pTxt[meta.Param[k].ParamId]
+		addHit(searchParameter, meta.Param[k].Name, &dn)
+	}
+
+	// output tables
+	tTxt := map[int]db.DescrNote{}
+	for k := range txt.TableTxt {
+		dn, ok := tTxt[txt.TableTxt[k].TableId]
+		if isBetter(txt.TableTxt[k].LangCode, &dn, ok) {
+			tTxt[txt.TableTxt[k].TableId] = db.DescrNote{LangCode: txt.TableTxt[k].LangCode, Descr: txt.TableTxt[k].Descr, Note: txt.TableTxt[k].Note}
+		}
+	}
+	for k := range meta.Table {
+		dn := tTxt[meta.Table[k].TableId]
+		addHit(searchTable, meta.Table[k].Name, &dn)
+	}
+
+	// entity attributes: search by entity.attribute name
+	type entityAttrKey struct {
+		entityId int
+		attrId   int
+	}
+	aTxt := map[entityAttrKey]db.DescrNote{}
+	for k := range txt.EntityAttrTxt {
+		ek := entityAttrKey{entityId: txt.EntityAttrTxt[k].EntityId, attrId: txt.EntityAttrTxt[k].AttrId}
+		dn, ok := aTxt[ek]
+		if isBetter(txt.EntityAttrTxt[k].LangCode, &dn, ok) {
+			aTxt[ek] = db.DescrNote{LangCode: txt.EntityAttrTxt[k].LangCode, Descr: txt.EntityAttrTxt[k].Descr, Note: txt.EntityAttrTxt[k].Note}
+		}
+	}
+	for k := range meta.Entity {
+		for j := range meta.Entity[k].Attr {
+			if meta.Entity[k].Attr[j].IsInternal {
+				continue // skip internal attributes
+			}
+			dn := aTxt[entityAttrKey{entityId: meta.Entity[k].EntityId, attrId: meta.Entity[k].Attr[j].AttrId}]
+			addHit(searchEntityAttr, meta.Entity[k].Name+"."+meta.Entity[k].Attr[j].Name, &dn)
+		}
+	}
+
+	return hits
+}
+
+// searchRank return search rank and best match: name, descr or note.
+// Each search term must be found in name, description or notes else rank is zero.
+// Rank is a sum of best match rank of each search term.
+func searchRank(terms []string, name, descr, note string) (int, string) {
+
+	if len(terms) <= 0 {
+		return 0, ""
+	}
+	ln := strings.ToLower(name)
+	ld := strings.ToLower(descr)
+	lnt := strings.ToLower(note)
+
+	rank := 0
+	bestRank := 0
+	bestMatch := ""
+
+	for _, t := range terms {
+
+		r := 0
+		m := ""
+		switch {
+		case ln == t:
+			r, m = rankNameExact, "name"
+		case strings.HasPrefix(ln, t):
+			r, m = rankNamePrefix, "name"
+		case strings.Contains(ln, t):
+			r, m = rankName, "name"
+		case isWordPrefix(ld, t):
+			r, m = rankDescrWord, "descr"
+		case strings.Contains(ld, t):
+			r, m = rankDescr, "descr"
+		case strings.Contains(lnt, t):
+			r, m = rankNote, "note"
+		}
+		if r <= 0 {
+			return 0, "" // search term not found
+		}
+		rank += r
+
+		if r > bestRank {
+			bestRank = r
+			bestMatch = m
+		}
+	}
+	return rank, bestMatch
+}
+
+// return true if src contains a word which starts with term
+func isWordPrefix(src, term string) bool {
+
+	for _, w := range strings.FieldsFunc(src, func(c rune) bool {
+		return c == ' ' || c == '\t' || c == '\r' || c == '\n' || strings.ContainsRune(".,;:!?()[]{}\"'/-_", c)
+	}) {
+		if strings.HasPrefix(w, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// sort search hits by rank, higher rank first, and by model name, hit kind and hit name
+func sortSearchHits(hits []SearchHit) {
+
+	kindOrder := map[string]int{searchModel: 0, searchParameter: 1, searchTable: 2, searchEntityAttr: 3}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		if hits[i].ModelName != hits[j].ModelName {
+			return hits[i].ModelName < hits[j].ModelName
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return kindOrder[hits[i].Kind] < kindOrder[hits[j].Kind]
+		}
+		return hits[i].Name < hits[j].Name
+	})
+}
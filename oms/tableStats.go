@@ -0,0 +1,102 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// TableStatsPub is precomputed statistics of output table expressions in model run
+type TableStatsPub struct {
+	ModelName   string         // model name
+	ModelDigest string         // model digest
+	RunDigest   string         // model run digest
+	RunStamp    string         // model run stamp
+	TableName   string         // output table name
+	IsStats     bool           // if false then statistics not calculated for that model run
+	Expr        []ExprStatsPub // statistics of each output table expression
+}
+
+// ExprStatsPub is precomputed statistics of output table expression values: count of not NULL values, min, max and mean
+type ExprStatsPub struct {
+	Name   string  // expression name
+	ExprId int     // expression id
+	Count  int64   // count of not NULL values
+	IsNull bool    // if true then there are no values and Min, Max, Mean are zero
+	Min    float64 // min of values
+	Max    float64 // max of values
+	Mean   float64 // mean of values
+}
+
+// UpdateRunTableStats calculate and store statistics of all output table expressions of completed model run.
+// Model identified by digest-or-name, model run identified by digest-or-stamp-or-name.
+// Return false if model or completed model run not found.
+func (mc *ModelCatalog) UpdateRunTableStats(dn, rdsn string) (bool, error) {
+
+	r, ok := mc.CompletedRunByDigestOrStampOrName(dn, rdsn)
+	if !ok {
+		return false, nil // completed model run not found
+	}
+	meta, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		return false, nil // model not found
+	}
+
+	if err := db.UpdateRunTableExprStats(dbConn, meta, r.RunId); err != nil {
+		omppLog.Log("Error at output tables statistics update: ", dn, ": ", rdsn, ": ", err.Error())
+		return false, err
+	}
+	return true, nil
+}
+
+// RunTableStats return statistics of output table expressions of completed model run.
+// Model identified by digest-or-name, model run identified by digest-or-stamp-or-name.
+// If statistics not calculated for that model run then IsStats is false and expressions list is empty.
+// Return false if model, output table or completed model run not found.
+func (mc *ModelCatalog) RunTableStats(dn, rdsn, name string) (*TableStatsPub, bool) {
+
+	r, ok := mc.CompletedRunByDigestOrStampOrName(dn, rdsn)
+	if !ok {
+		return nil, false // completed model run not found
+	}
+	meta, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		return nil, false // model not found
+	}
+	idx, ok := meta.OutTableByName(name)
+	if !ok {
+		omppLog.Log("Warning: output table not found: ", dn, ": ", name)
+		return nil, false
+	}
+	table := &meta.Table[idx]
+
+	stLst, err := db.GetRunTableExprStats(dbConn, r.RunId, table.TableHid)
+	if err != nil {
+		omppLog.Log("Error at get output table statistics: ", dn, ": ", rdsn, ": ", name, ": ", err.Error())
+		return nil, false
+	}
+
+	ts := TableStatsPub{
+		ModelName:   meta.Model.Name,
+		ModelDigest: meta.Model.Digest,
+		RunDigest:   r.RunDigest,
+		RunStamp:    r.RunStamp,
+		TableName:   table.Name,
+		IsStats:     len(stLst) > 0,
+		Expr:        make([]ExprStatsPub, 0, len(stLst)),
+	}
+	for _, st := range stLst {
+
+		es := ExprStatsPub{ExprId: st.ExprId, Count: st.Count, IsNull: st.IsNull, Min: st.Min, Max: st.Max, Mean: st.Mean}
+		for j := range table.Expr {
+			if table.Expr[j].ExprId == st.ExprId {
+				es.Name = table.Expr[j].Name
+				break
+			}
+		}
+		ts.Expr = append(ts.Expr, es)
+	}
+	return &ts, true
+}
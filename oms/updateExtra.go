@@ -4,8 +4,12 @@
 package main
 
 import (
+	"database/sql"
+	"time"
+
 	"github.com/openmpp/go/ompp/db"
 	"github.com/openmpp/go/ompp/omppLog"
+	"golang.org/x/text/language"
 )
 
 // ReplaceProfile replace existing or insert new profile and all profile options.
@@ -129,3 +133,202 @@ func (mc *ModelCatalog) DeleteProfileOption(dn, profile, key string) (bool, erro
 
 	return true, nil
 }
+
+// OrphanTables return list of parameter, output table and microdata values db tables not referenced by any model.
+// Model digest-or-name used to find model database connection, orphan tables are searched in entire database.
+func (mc *ModelCatalog) OrphanTables(dn string) ([]db.OrphanTable, bool, error) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return []db.OrphanTable{}, false, nil
+	}
+	_, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return []db.OrphanTable{}, false, nil
+	}
+
+	otLst, err := db.FindOrphanTables(dbConn)
+	if err != nil {
+		omppLog.Log("Error at search of orphan tables: ", dn, ": ", err.Error())
+		return []db.OrphanTable{}, false, err
+	}
+	return otLst, true, nil
+}
+
+// DropOrphanTables delete parameter, output table and microdata values db tables not referenced by any model.
+// If names list is not empty then only orphan tables from that list are deleted.
+// If isDryRun is true then nothing deleted and it return list of orphan tables to be deleted.
+func (mc *ModelCatalog) DropOrphanTables(dn string, names []string, isDryRun bool) ([]db.OrphanTable, bool, error) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return []db.OrphanTable{}, false, nil
+	}
+	_, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return []db.OrphanTable{}, false, nil
+	}
+
+	otLst, err := db.DropOrphanTables(dbConn, names, isDryRun)
+	if err != nil {
+		omppLog.Log("Error at delete of orphan tables: ", dn, ": ", err.Error())
+		return []db.OrphanTable{}, false, err
+	}
+	if !isDryRun {
+		for k := range otLst {
+			omppLog.Log("Deleted orphan table: ", dn, ": ", otLst[k].Name, " rows: ", otLst[k].RowCount)
+		}
+	}
+	return otLst, true, nil
+}
+
+// AddLanguage insert new language into model database and copy language words from source language.
+// If isCopyText is true then all translation strings also copied from source language as placeholders.
+// Languages of all models in the same database are updated in model catalog.
+func (mc *ModelCatalog) AddLanguage(dn, langCode, langName, fromLang string, isCopyText bool) (bool, error) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return false, nil
+	}
+	if langCode == "" {
+		omppLog.Log("Warning: invalid (empty) language code")
+		return false, nil
+	}
+	_, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return false, nil
+	}
+
+	if err := db.AddLanguage(dbConn, langCode, langName, fromLang, isCopyText); err != nil {
+		omppLog.Log("Error at add language: ", dn, ": ", langCode, ": ", err.Error())
+		return false, err
+	}
+	omppLog.Log("Language added: ", dn, ": ", langCode)
+
+	return true, mc.updateLanguages(dbConn)
+}
+
+// CopyLanguageText copy translation strings from source language into destination language.
+// Only strings which not exist in destination language are copied, existing translations are not changed.
+func (mc *ModelCatalog) CopyLanguageText(dn, fromLang, langCode string) (bool, error) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return false, nil
+	}
+	if fromLang == "" || langCode == "" {
+		omppLog.Log("Warning: invalid (empty) language code")
+		return false, nil
+	}
+	_, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return false, nil
+	}
+
+	if err := db.CopyLanguageText(dbConn, fromLang, langCode); err != nil {
+		omppLog.Log("Error at copy language: ", dn, ": ", fromLang, " into: ", langCode, ": ", err.Error())
+		return false, err
+	}
+	omppLog.Log("Language text copied: ", dn, ": ", fromLang, " into: ", langCode)
+
+	return true, mc.updateLanguages(dbConn)
+}
+
+// DeleteLanguage delete language and all translation strings of that language from model database.
+// Language cannot be deleted if it is a default language of any model.
+// If no such language exist in database then no error, empty operation.
+func (mc *ModelCatalog) DeleteLanguage(dn, langCode string) (bool, error) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return false, nil
+	}
+	if langCode == "" {
+		omppLog.Log("Warning: invalid (empty) language code")
+		return false, nil
+	}
+	_, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return false, nil
+	}
+
+	if err := db.DeleteLanguage(dbConn, langCode); err != nil {
+		omppLog.Log("Error at delete language: ", dn, ": ", langCode, ": ", err.Error())
+		return false, err
+	}
+	omppLog.Log("Language deleted: ", dn, ": ", langCode)
+
+	return true, mc.updateLanguages(dbConn)
+}
+
+// updateLanguages read languages and model words from database and update all models of that database in model catalog.
+// Model text metadata is reloaded from database on next use.
+func (mc *ModelCatalog) updateLanguages(dbConn *sql.DB) error {
+
+	// find models of that database
+	mIds := map[string]int{}
+	func() {
+		mc.theLock.Lock()
+		defer mc.theLock.Unlock()
+
+		for k := range mc.modelLst {
+			if mc.modelLst[k].dbConn == dbConn {
+				mIds[mc.modelLst[k].meta.Model.Digest] = mc.modelLst[k].meta.Model.ModelId
+			}
+		}
+	}()
+
+	// read languages and model words from database
+	ls, err := db.GetLanguages(dbConn)
+	if err != nil {
+		omppLog.Log("Error at get language-specific strings: ", err.Error())
+		return err
+	}
+	mw := map[string]*db.ModelWordMeta{}
+
+	for digest, mId := range mIds {
+		w, err := db.GetModelWord(dbConn, mId, "")
+		if err != nil {
+			omppLog.Log("Error at get model language-specific strings: ", digest, ": ", err.Error())
+			return err
+		}
+		mw[digest] = w
+	}
+
+	// update model languages list, starting from default language
+	mc.theLock.Lock()
+	defer mc.theLock.Unlock()
+
+	for k := range mc.modelLst {
+
+		w, ok := mw[mc.modelLst[k].meta.Model.Digest]
+		if !ok || mc.modelLst[k].dbConn != dbConn {
+			continue
+		}
+		ml := []string{}
+		lt := []language.Tag{}
+
+		for j := range ls.Lang {
+			if ls.Lang[j].LangCode == mc.modelLst[k].meta.Model.DefaultLangCode {
+				ml = append([]string{ls.Lang[j].LangCode}, ml...)
+				lt = append([]language.Tag{language.Make(ls.Lang[j].LangCode)}, lt...)
+			} else {
+				ml = append(ml, ls.Lang[j].LangCode)
+				lt = append(lt, language.Make(ls.Lang[j].LangCode))
+			}
+		}
+		mc.modelLst[k].langCodes = ml
+		mc.modelLst[k].langMeta = ls
+		mc.modelLst[k].matcher = language.NewMatcher(lt)
+		mc.modelLst[k].modelWord = w
+		mc.modelLst[k].isTxtMetaFull = false
+		mc.modelLst[k].loadTime = time.Now().UnixNano()
+	}
+	return nil
+}
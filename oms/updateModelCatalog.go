@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -90,7 +91,7 @@ func (mc *ModelCatalog) refreshSqlite(modelDir, modelLogDir string) error {
 
 	// close existing connections and store updated list of models and db connections
 	for k := range mc.modelLst {
-		if err := mc.modelLst[k].dbConn.Close(); err != nil {
+		if err := db.Close(mc.modelLst[k].dbConn); err != nil {
 			omppLog.Log("Error: close db connection error: " + err.Error())
 		}
 	}
@@ -123,7 +124,7 @@ func (mc *ModelCatalog) loadModelDbFile(srcPath string) (int, error) {
 				err = errors.New("Error: model already exist in catalog" + ": " + mLst[j].meta.Model.Name + " " + mLst[j].meta.Model.Digest)
 				omppLog.Log(err.Error())
 
-				if e := mLst[j].dbConn.Close(); e != nil {
+				if e := db.Close(mLst[j].dbConn); e != nil {
 					omppLog.Log("Error: close db connection error: " + e.Error())
 				}
 				return 0, err
@@ -146,6 +147,74 @@ func (mc *ModelCatalog) loadModelDbFile(srcPath string) (int, error) {
 	return len(mLst), nil
 }
 
+// validate uploaded SQLite db file, move it into models directory as dstPath and add models to catalog.
+// Validation: file must be openM++ database of current schema version, contain at least one model,
+// each model must have name and digest, digests must be unique and must not exist in catalog.
+// If validation failed or models cannot be added to catalog then destination file is removed.
+// Return list of uploaded models.
+func (mc *ModelCatalog) uploadModelDbFile(srcPath, dstPath string) ([]db.ModelDicRow, error) {
+
+	// validate source database
+	dbc, _, err := db.Open(db.MakeSqliteDefault(srcPath), db.SQLiteDbDriver, false)
+	if err != nil {
+		return nil, errors.New("Error: unable to open database: " + err.Error())
+	}
+	if err = db.CheckOpenmppSchemaVersion(dbc); err != nil {
+		db.Close(dbc)
+		return nil, errors.New("Error: invalid database, likely not an openM++ database: " + err.Error())
+	}
+	dicLst, err := db.GetModelList(dbc)
+	db.Close(dbc)
+
+	if err != nil {
+		return nil, errors.New("Error: unable to read list of models: " + err.Error())
+	}
+	if len(dicLst) <= 0 {
+		return nil, errors.New("Error: invalid database, no models found")
+	}
+
+	mbs := mc.allModels()
+
+	for k := range dicLst {
+
+		if dicLst[k].Name == "" || dicLst[k].Digest == "" {
+			return nil, errors.New("Error: invalid (empty) model name or digest, model id: " + strconv.Itoa(dicLst[k].ModelId))
+		}
+		for j := 0; j < k; j++ {
+			if dicLst[j].Digest == dicLst[k].Digest {
+				return nil, errors.New("Error: model digest is not unique: " + dicLst[k].Name + " " + dicLst[k].Digest)
+			}
+		}
+		if slices.IndexFunc(mbs, func(mb modelBasic) bool { return mb.model.Digest == dicLst[k].Digest }) >= 0 {
+			return nil, errors.New("Error: model already exist in catalog: " + dicLst[k].Name + " " + dicLst[k].Digest)
+		}
+	}
+
+	// move database into models directory, it must not overwrite existing file
+	if fileExist(dstPath) {
+		return nil, errors.New("Error: model database file already exist: " + dstPath)
+	}
+	if err = os.MkdirAll(filepath.Dir(dstPath), 0750); err != nil {
+		return nil, errors.New("Error: unable to create model directory: " + err.Error())
+	}
+	if err = os.Rename(srcPath, dstPath); err != nil {
+		return nil, errors.New("Error: unable to move model database file: " + err.Error())
+	}
+
+	// add models to catalog, remove database file on error
+	n, err := mc.loadModelDbFile(dstPath)
+	if err == nil && n <= 0 {
+		err = errors.New("Error: invalid (empty) model db file: " + dstPath)
+	}
+	if err != nil {
+		if e := os.Remove(dstPath); e != nil {
+			omppLog.Log("Error: unable to remove model database file: ", dstPath, ": ", e.Error())
+		}
+		return nil, err
+	}
+	return dicLst, nil
+}
+
 // open SQLite db connection and retrive model or list of models, skip models which are in digest list already
 func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isLogDir bool, modelLogDir string) ([]modelDef, error) {
 
@@ -157,7 +226,7 @@ func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isL
 	}
 	if err := db.CheckOpenmppSchemaVersion(dbc); err != nil {
 		omppLog.Log("Error: invalid database, likely not an openM++ database: ", srcPath)
-		dbc.Close()
+		db.Close(dbc)
 		return nil, err
 	}
 	dbDir := filepath.Dir(srcPath)
@@ -177,24 +246,24 @@ func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isL
 	dicLst, err := db.GetModelList(dbc)
 	if err != nil || len(dicLst) <= 0 {
 		omppLog.Log("Error: ", srcPath, " : ", err.Error())
-		dbc.Close()
+		db.Close(dbc)
 		return nil, err
 	}
 	if len(dicLst) <= 0 {
 		omppLog.Log("Warning: empty database, no models found: ", srcPath)
-		dbc.Close()
+		db.Close(dbc)
 		return nil, nil
 	}
 
 	ls, err := db.GetLanguages(dbc)
 	if err != nil {
 		omppLog.Log("Error: ", srcPath, " : ", err.Error())
-		dbc.Close()
+		db.Close(dbc)
 		return nil, err
 	}
 	if ls == nil {
 		omppLog.Log("Warning: no languages found in database: ", srcPath)
-		dbc.Close()
+		db.Close(dbc)
 		return nil, nil
 	}
 
@@ -220,7 +289,7 @@ func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isL
 		meta, err := db.GetModelById(dbc, dicLst[idx].ModelId)
 		if err != nil {
 			omppLog.Log("Error at get model metadata: ", dicLst[idx].Name, " ", dicLst[idx].Digest, ": ", err.Error())
-			dbc.Close()
+			db.Close(dbc)
 			return nil, err
 		}
 
@@ -228,7 +297,7 @@ func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isL
 		txt, err := db.GetModelTextRowById(dbc, dicLst[idx].ModelId, "")
 		if err != nil {
 			omppLog.Log("Error at get model_dic_txt: ", dicLst[idx].Name, " ", dicLst[idx].Digest, ": ", err.Error())
-			dbc.Close()
+			db.Close(dbc)
 			return nil, err
 		}
 		// partial initialization of model text metadata: only model_dic_txt rows
@@ -241,7 +310,7 @@ func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isL
 		w, err := db.GetModelWord(dbc, dicLst[idx].ModelId, "")
 		if err != nil {
 			omppLog.Log("Error at get model language-specific stirngs: ", dicLst[idx].Name, " ", dicLst[idx].Digest, ": ", err.Error())
-			dbc.Close()
+			db.Close(dbc)
 			return nil, err
 		}
 
@@ -281,12 +350,14 @@ func modelsFromSqliteFile(srcPath string, dgstLst []string, modelDir string, isL
 			langMeta:      ls,
 			matcher:       language.NewMatcher(lt),
 			modelWord:     w,
-			extra:         me})
+			extra:         me,
+			landing:       findLandingFiles(dbDir),
+			loadTime:      time.Now().UnixNano()})
 	}
 
 	// close db connetcion if there models in that database or all models already in the model list
 	if len(mLst) <= 0 {
-		dbc.Close()
+		db.Close(dbc)
 	}
 	return mLst, nil
 }
@@ -301,7 +372,7 @@ func (mc *ModelCatalog) closeAll() error {
 	// close existing db connections
 	var firstErr error
 	for k := range mc.modelLst {
-		if err := mc.modelLst[k].dbConn.Close(); err != nil {
+		if err := db.Close(mc.modelLst[k].dbConn); err != nil {
 			omppLog.Log("Error: close db connection error: " + err.Error())
 			if firstErr == nil {
 				firstErr = err
@@ -333,7 +404,7 @@ func (mc *ModelCatalog) closeModel(dn string) (string, string, error) {
 	for k := range mc.modelLst {
 
 		if !isFound && (mc.modelLst[k].meta.Model.Digest == dn || mc.modelLst[k].meta.Model.Name == dn) {
-			if err := mc.modelLst[k].dbConn.Close(); err != nil {
+			if err := db.Close(mc.modelLst[k].dbConn); err != nil {
 				omppLog.Log("Error: close db connection error" + ": " + dn + " : " + err.Error())
 				return "", "", err
 			}
@@ -439,5 +510,6 @@ func (mc *ModelCatalog) setModelTextMeta(digest string, isFull bool, txtMeta *db
 
 	mc.modelLst[idx].isTxtMetaFull = isFull
 	mc.modelLst[idx].txtMeta = txtMeta
+	mc.modelLst[idx].loadTime = time.Now().UnixNano()
 	return true
 }
@@ -78,15 +78,18 @@ func (mc *ModelCatalog) DeleteRunStart(dn, rdsn string) (bool, error) {
 	}
 
 	// do delete run from database in background
-	go func(dbc *sql.DB, runId int, dn, rdsn string) {
+	go func(dbc *sql.DB, runId int, runDigest, dn, rdsn string) {
 
 		e := db.DeleteRun(dbc, runId)
 		if e != nil {
 			omppLog.Log("Error at delete model run: ", dn, ": ", rdsn, ": ", e.Error())
-		} else {
-			omppLog.Log("Deleted model run: ", dn, ": ", rdsn)
+			return
 		}
-	}(dbConn, r.RunId, dn, rdsn)
+		if e = deleteRunArtifacts(runDigest); e != nil {
+			omppLog.Log("Error at delete model run artifacts: ", dn, ": ", rdsn, ": ", e.Error())
+		}
+		omppLog.Log("Deleted model run: ", dn, ": ", rdsn)
+	}(dbConn, r.RunId, r.RunDigest, dn, rdsn)
 
 	return true, nil
 }
@@ -111,6 +114,7 @@ func (mc *ModelCatalog) DeleteRunListStart(dn string, rdsnLst []string) (bool, e
 	// for each run find run id by digest, stamp or run name
 	rIds := make([]int, 0, len(rdsnLst))
 	rm := make(map[int]string, len(rdsnLst))
+	rdm := make(map[int]string, len(rdsnLst))
 
 	for _, rdsn := range rdsnLst {
 
@@ -123,11 +127,12 @@ func (mc *ModelCatalog) DeleteRunListStart(dn string, rdsnLst []string) (bool, e
 		for k := range rLst {
 			rIds = append(rIds, rLst[k].RunId)
 			rm[rLst[k].RunId] = rdsn
+			rdm[rLst[k].RunId] = rLst[k].RunDigest
 		}
 	}
 
 	// do delete runs from database in background
-	go func(dbc *sql.DB, modelDn string, runIds []int, rdsnMap map[int]string) {
+	go func(dbc *sql.DB, modelDn string, runIds []int, rdsnMap, digestMap map[int]string) {
 
 		slices.Sort(rIds) // delete runs in descending order
 
@@ -143,11 +148,14 @@ func (mc *ModelCatalog) DeleteRunListStart(dn string, rdsnLst []string) (bool, e
 				omppLog.Log("Error at delete model run: ", modelDn, ": ", runIds[k], " ", rdsnMap[runIds[k]], ": ", e.Error())
 				return
 			}
+			if e = deleteRunArtifacts(digestMap[runIds[k]]); e != nil {
+				omppLog.Log("Error at delete model run artifacts: ", modelDn, ": ", runIds[k], " ", rdsnMap[runIds[k]], ": ", e.Error())
+			}
 			n++
 		}
 		omppLog.Log("Deleted multiple model runs: ", n, ": ", modelDn)
 
-	}(dbConn, dn, rIds, rm)
+	}(dbConn, dn, rIds, rm, rdm)
 
 	return true, nil
 }
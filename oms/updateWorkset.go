@@ -8,6 +8,7 @@ import (
 	"errors"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/openmpp/go/ompp/db"
@@ -276,8 +277,14 @@ func (mc *ModelCatalog) UpdateWorksetParameter(
 		return c, nil
 	}
 
+	// validate parameter values against type constraints and collect all invalid rows
+	vf, err := db.ValidateParamFrom(meta, param.Name, from)
+	if err != nil {
+		return false, err
+	}
+
 	// update workset parameter metadata and parameter values
-	hId, err := wm.UpdateWorksetParameterFrom(dbConn, meta, isReplace, param, langMeta, from)
+	hId, err := wm.UpdateWorksetParameterFrom(dbConn, meta, isReplace, param, langMeta, vf)
 	if err != nil {
 		omppLog.Log("Error at update workset: ", dn, ": ", wp.Name, ": ", err.Error())
 		return false, err
@@ -356,7 +363,13 @@ func (mc *ModelCatalog) UpdateWorksetParameterText(dn, wsn string, pvtLst []db.P
 	return true, nil
 }
 
+// maximum number of csv row errors to report, csv processing aborted after that
+const maxCsvRowErrors = 100
+
 // UpdateWorksetParameterCsv replace or merge parameter metadata into workset and replace parameter values from csv reader.
+// Csv header line is optional, it is case-insensitive and may start from utf-8 BOM.
+// Dimension items and enum-based parameter values can be enum codes or enum id's, or mixed in the same column.
+// If any csv row is invalid then error returned with the list of invalid lines.
 func (mc *ModelCatalog) UpdateWorksetParameterCsv(
 	isReplace bool, wp *db.WorksetPub, param *db.ParamRunSetPub, csvRd *csv.Reader,
 ) (
@@ -409,17 +422,24 @@ func (mc *ModelCatalog) UpdateWorksetParameterCsv(
 	if csvRd != nil {
 
 		// converter from csv row []string to db cell
+		// dimension items and enum-based values can be enum codes or enum id's, it can be mixed in the same column
 		csvCvt := db.CellParamConverter{
-			ModelDef:  meta,
-			Name:      param.Name,
-			DoubleFmt: theCfg.doubleFmt,
+			ModelDef:   meta,
+			Name:       param.Name,
+			IsCodeOrId: true,
+			DoubleFmt:  theCfg.doubleFmt,
 		}
 		cvt, err := csvCvt.ToCell()
 		if err != nil {
 			return false, errors.New("invalid converter from csv row: " + err.Error())
 		}
+		chs, err := csvCvt.CsvHeader()
+		if err != nil {
+			return false, errors.New("Error at building csv parameter header " + param.Name)
+		}
+		csvRd.FieldsPerRecord = -1 // number of fields validated by row converter
 
-		// validate header line
+		// validate first line: it must be a header or, if header is omitted, first data row
 		fhs, e := csvRd.Read()
 		switch {
 		case e == io.EOF:
@@ -427,41 +447,72 @@ func (mc *ModelCatalog) UpdateWorksetParameterCsv(
 		case e != nil:
 			return false, errors.New("Failed to read csv parameter values " + param.Name + ": " + e.Error())
 		}
-		if chs, e := csvCvt.CsvHeader(); e != nil {
-			return false, errors.New("Error at building csv parameter header " + param.Name)
-		} else {
-			fh := strings.Join(fhs, ",")
-			if strings.HasPrefix(fh, string(helper.Utf8bom)) {
-				fh = fh[len(helper.Utf8bom):]
-			}
-			ch := strings.Join(chs, ",")
-			if fh != ch {
-				return false, errors.New("Invalid csv parameter header " + param.Name + ": " + fh + " expected: " + ch)
-			}
+		isHdr, err := sniffParamCsvHeader(fhs, chs)
+		if err != nil {
+			return false, errors.New("Invalid csv parameter header " + param.Name + ": " + err.Error())
+		}
+
+		// first row is a data row if there is no header line
+		var firstRow []string
+		if !isHdr {
+			firstRow = append([]string{}, fhs...)
+			omppLog.Log("Warning: csv parameter header not found, first line is a data row: ", param.Name)
 		}
 
 		// convert each line into cell (id cell)
-		from = func() (interface{}, error) {
-			row, err := csvRd.Read()
-			switch {
-			case err == io.EOF:
-				return nil, nil // eof
-			case err != nil:
-				return nil, errors.New("Failed to read csv parameter values " + param.Name)
-			}
+		// collect row errors with line numbers and report it at the end of csv data
+		rowErrs := []string{}
 
-			// convert and append cell to cell list
-			c, err := cvt(row)
-			if err != nil {
-				return nil, errors.New("Failed to convert csv parameter values " + param.Name)
+		from = func() (interface{}, error) {
+			for {
+				var row []string
+				nLine := 0
+
+				if firstRow != nil {
+					row = firstRow
+					firstRow = nil
+					nLine = 1
+				} else {
+					r, err := csvRd.Read()
+					switch {
+					case err == io.EOF:
+						if len(rowErrs) > 0 {
+							return nil, errors.New("Invalid csv parameter values " + param.Name + ":\n" + strings.Join(rowErrs, "\n"))
+						}
+						return nil, nil // eof
+					case err != nil:
+						if pe, ok := err.(*csv.ParseError); ok {
+							rowErrs = append(rowErrs, "line "+strconv.Itoa(pe.Line)+": "+pe.Err.Error())
+						}
+						return nil, errors.New("Failed to read csv parameter values " + param.Name + ": " + strings.Join(rowErrs, "\n"))
+					}
+					row = r
+					nLine, _ = csvRd.FieldPos(0)
+				}
+
+				// convert csv row to cell, if row is invalid then collect error and skip that row
+				c, err := cvt(row)
+				if err == nil {
+					return c, nil
+				}
+				rowErrs = append(rowErrs, "line "+strconv.Itoa(nLine)+": "+err.Error())
+
+				if len(rowErrs) >= maxCsvRowErrors {
+					return nil, errors.New("Invalid csv parameter values " + param.Name + ", too many errors:\n" + strings.Join(rowErrs, "\n"))
+				}
 			}
-			return c, nil
 		}
 
 	}
 
+	// validate parameter values against type constraints and collect all invalid rows
+	vf, err := db.ValidateParamFrom(meta, param.Name, from)
+	if err != nil {
+		return false, err
+	}
+
 	// update workset parameter metadata and parameter values
-	hId, err := wm.UpdateWorksetParameterFrom(dbConn, meta, isReplace, param, langMeta, from)
+	hId, err := wm.UpdateWorksetParameterFrom(dbConn, meta, isReplace, param, langMeta, vf)
 	if err != nil {
 		omppLog.Log("Error at update workset: ", dn, ": ", wp.Name, ": ", err.Error())
 		return false, err
@@ -524,8 +575,12 @@ func (mc *ModelCatalog) UpdateWorksetParameterPage(dn, wsn, name string, from fu
 	}
 	layout.SubCount = nSub
 
-	// write parameter values
-	return db.WriteParameterFrom(dbConn, meta, &layout, from)
+	// validate and write parameter values
+	vf, err := db.ValidateParamFrom(meta, name, from)
+	if err != nil {
+		return err
+	}
+	return db.WriteParameterFrom(dbConn, meta, &layout, vf)
 }
 
 // DeleteWorksetParameter do delete workset parameter metadata and values from database.
@@ -698,3 +753,22 @@ func (mc *ModelCatalog) CopyParameterBetweenWs(dn, dstWsName, name string, isRep
 	}
 	return nil
 }
+
+// sniffParamCsvHeader return true if first csv line is a parameter header: sub_id,dim0,dim1,param_value.
+// Header compared to expected column names case-insensitive, after removing utf-8 BOM and leading and trailing spaces.
+// If first line is not a header but look like a data row, starting with integer sub_id, then return false.
+// It is an error if first line is neither a header nor a data row.
+func sniffParamCsvHeader(fhs []string, chs []string) (bool, error) {
+
+	if helper.IsCsvHeader(fhs, chs, true) {
+		return true, nil
+	}
+
+	// if there is no header then first column must be sub_id integer
+	if len(fhs) == len(chs) {
+		if _, e := strconv.Atoi(strings.TrimSpace(fhs[0])); e == nil {
+			return false, nil
+		}
+	}
+	return false, errors.New(strings.Join(fhs, ",") + " expected: " + strings.Join(chs, ","))
+}
@@ -716,8 +716,9 @@ func (mc *ModelCatalog) TableToCalcCsvConverter(
 
 // MicrodataToCsvConverter return model run id, entity generation digest,
 // csv header as starting array, microdata cell to csv converter and boolean Ok flag.
+// If list of attributes is not empty then csv contains only those attributes.
 func (mc *ModelCatalog) MicrodataToCsvConverter(
-	dn string, isCode bool, rdsn, name string,
+	dn string, isCode bool, rdsn, name string, attrs []string,
 ) (
 	int, string, []string, func(interface{}, []string) (bool, error), bool,
 ) {
@@ -766,6 +767,7 @@ func (mc *ModelCatalog) MicrodataToCsvConverter(
 		EntityGen: entGen,
 		IsIdCsv:   !isCode,
 		DoubleFmt: theCfg.doubleFmt,
+		Attrs:     attrs,
 	}}
 
 	hdr, err := cvtMicro.CsvHeader()
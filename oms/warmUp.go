@@ -0,0 +1,151 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// ModelReady is model warm-up status: model metadata and text loaded from database
+type ModelReady struct {
+	ModelName   string // model name
+	ModelDigest string // model digest
+	IsReady     bool   // if true then model metadata and text loaded
+	IsError     bool   // if true then model warm-up failed, model metadata is loaded on first request
+	Duration    int64  // warm-up time in milliseconds
+}
+
+// state of model warm-up: list of models to preload and status of each model
+var theWarmUp = struct {
+	theLock sync.Mutex   // mutex to lock for warm-up state update
+	isDone  bool         // if true then warm-up completed
+	models  []ModelReady // models warm-up status
+}{
+	isDone: true,
+}
+
+// startWarmUp start background preload of model metadata and text in all languages.
+// Model list is a comma-separated list of model names or digests or "all" to preload all models.
+// Models are loaded in parallel, up to number of CPUs at the same time.
+func startWarmUp(modelList string) {
+
+	if modelList == "" {
+		return // warm-up disabled: models loaded on first request
+	}
+	isAll := strings.EqualFold(modelList, "all")
+	dnLst := helper.ParseCsvLine(modelList, ',')
+
+	// find models in catalog
+	mLst := []ModelReady{}
+	for _, mb := range theCatalog.allModels() {
+
+		isFound := isAll
+		for k := 0; !isFound && k < len(dnLst); k++ {
+			isFound = dnLst[k] == mb.model.Digest || dnLst[k] == mb.model.Name
+		}
+		if isFound {
+			mLst = append(mLst, ModelReady{ModelName: mb.model.Name, ModelDigest: mb.model.Digest})
+		}
+	}
+	if !isAll {
+		for _, dn := range dnLst {
+
+			isFound := false
+			for k := 0; !isFound && k < len(mLst); k++ {
+				isFound = dn == mLst[k].ModelDigest || dn == mLst[k].ModelName
+			}
+			if !isFound {
+				omppLog.Log("Warning: model not found, skip warm-up of: ", dn)
+			}
+		}
+	}
+	if len(mLst) <= 0 {
+		return
+	}
+
+	theWarmUp.theLock.Lock()
+	theWarmUp.isDone = false
+	theWarmUp.models = mLst
+	theWarmUp.theLock.Unlock()
+
+	omppLog.Log("Models warm-up: ", len(mLst))
+
+	go func() {
+
+		nWorker := runtime.NumCPU()
+		if nWorker > len(mLst) {
+			nWorker = len(mLst)
+		}
+		idxC := make(chan int, len(mLst))
+		for k := range mLst {
+			idxC <- k
+		}
+		close(idxC)
+
+		var wg sync.WaitGroup
+		for n := 0; n < nWorker; n++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for k := range idxC {
+
+					tStart := time.Now()
+					ok := theCatalog.loadModelText(mLst[k].ModelDigest)
+					ms := time.Since(tStart).Milliseconds()
+
+					if !ok {
+						omppLog.Log("Warning: model warm-up failed: ", mLst[k].ModelName, " ", mLst[k].ModelDigest)
+					}
+
+					theWarmUp.theLock.Lock()
+					theWarmUp.models[k].IsReady = ok
+					theWarmUp.models[k].IsError = !ok
+					theWarmUp.models[k].Duration = ms
+					theWarmUp.theLock.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		theWarmUp.theLock.Lock()
+		theWarmUp.isDone = true
+		theWarmUp.theLock.Unlock()
+
+		omppLog.Log("Models warm-up completed")
+	}()
+}
+
+// readyHandler return service readiness and warm-up status of each model:
+// GET /api/ready
+// Response status is 200 OK if warm-up completed or disabled, else it is 503 Service Unavailable.
+// Models which failed to load are reported with IsError flag, service is ready and such model is loaded on first request.
+func readyHandler(w http.ResponseWriter, r *http.Request) {
+
+	theWarmUp.theLock.Lock()
+	rs := struct {
+		IsReady bool         // if true then service is ready: warm-up completed or disabled
+		Model   []ModelReady // models warm-up status
+	}{
+		IsReady: theWarmUp.isDone,
+		Model:   make([]ModelReady, len(theWarmUp.models)),
+	}
+	copy(rs.Model, theWarmUp.models)
+	theWarmUp.theLock.Unlock()
+
+	if !rs.IsReady {
+		w.Header().Set("Retry-After", "5")
+		jsonSetHeaders(w, r)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	jsonResponse(w, r, rs)
+}
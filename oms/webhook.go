@@ -0,0 +1,271 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/helper"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// webhook http request timeout in seconds
+const webhookTimeout = 20
+
+// webhook retry delay in seconds, it is doubled on each retry
+const webhookRetryDelay = 2
+
+// global webhooks configuration: list of URLs to notify on model run completion
+var theWebhooks = struct {
+	sync.Mutex
+	urls   []string // URLs to POST model run completion notification
+	secret string   // if not empty then HMAC-SHA256 key to sign notification body
+	retry  int      // number of retries if notification failed
+}{}
+
+// WebhookConfig is public part of webhooks configuration
+type WebhookConfig struct {
+	Urls     []string // URLs to POST model run completion notification
+	IsSigned bool     // if true then notification body signed by HMAC-SHA256, signature is in X-Ompp-Signature header
+	Retry    int      // number of retries if notification failed
+}
+
+// WebhookPayload is a model run completion notification, POST to webhook URL as json body
+type WebhookPayload struct {
+	ModelName      string  // model name
+	ModelDigest    string  // model digest
+	RunStamp       string  // model run stamp
+	SubmitStamp    string  // submission timestamp
+	RunName        string  // if not empty then run name
+	TaskRunName    string  // if not empty then task run name
+	Status         string  // run status: s=success, e=error, x=exit, killed
+	IsSuccess      bool    // if true then model run completed successfully
+	StartDateTime  string  // model run start date-time
+	UpdateDateTime string  // model run completion date-time
+	Duration       float64 // model run duration in seconds
+	Links          struct {
+		Status string // model run status: GET /api/model/:model/run/:run/status
+		Log    string // model run log: GET /api/run/log/model/:model/stamp/:stamp
+	}
+}
+
+// set global webhooks configuration, URLs are trimmed and empty URLs are removed
+func setWebhookConfig(urls []string, secret string, retry int) {
+
+	theWebhooks.Lock()
+	defer theWebhooks.Unlock()
+
+	theWebhooks.urls = cleanWebhookUrls(urls)
+	theWebhooks.secret = secret
+	theWebhooks.retry = max(0, retry)
+}
+
+// replace list of global webhooks URLs and return new webhooks configuration
+func replaceWebhookUrls(urls []string) WebhookConfig {
+
+	theWebhooks.Lock()
+	defer theWebhooks.Unlock()
+
+	theWebhooks.urls = cleanWebhookUrls(urls)
+
+	return WebhookConfig{
+		Urls:     slices.Clone(theWebhooks.urls),
+		IsSigned: theWebhooks.secret != "",
+		Retry:    theWebhooks.retry,
+	}
+}
+
+// return copy of public part of webhooks configuration
+func getWebhookConfig() WebhookConfig {
+
+	theWebhooks.Lock()
+	defer theWebhooks.Unlock()
+
+	return WebhookConfig{
+		Urls:     slices.Clone(theWebhooks.urls),
+		IsSigned: theWebhooks.secret != "",
+		Retry:    theWebhooks.retry,
+	}
+}
+
+// return true if URL is empty or starts from http:// or https://
+func isWebhookUrl(url string) bool {
+	u := strings.TrimSpace(url)
+	return u == "" || strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")
+}
+
+// return true if model run webhook URL allowed by global webhooks configuration.
+// Run URL must have the same scheme and host as one of global URLs and path must start from global URL path,
+// e.g.: https://ci.example.com/hook/run-1234 is allowed by https://ci.example.com/hook
+// It does not allow model run requests to POST notifications to arbitrary servers.
+func isAllowedWebhookUrl(runUrl string) bool {
+
+	theWebhooks.Lock()
+	defer theWebhooks.Unlock()
+
+	return isWebhookUrlIn(runUrl, theWebhooks.urls)
+}
+
+// return true if URL matches one of allowed URLs: the same scheme and host and path starts from allowed URL path
+func isWebhookUrlIn(src string, allowed []string) bool {
+
+	u, err := url.Parse(strings.TrimSpace(src))
+	if err != nil || u.Host == "" || u.User != nil {
+		return false
+	}
+
+	// reject path with .. segments, including escaped, e.g.: /hooks/../admin or /hooks/%2e%2e/admin
+	up, err := url.PathUnescape(u.EscapedPath())
+	if err != nil {
+		return false
+	}
+	for _, seg := range strings.Split(strings.ReplaceAll(up, "\\", "/"), "/") {
+		if seg == ".." {
+			return false
+		}
+	}
+	up = path.Clean("/" + up)
+
+	for _, s := range allowed {
+
+		a, err := url.Parse(s)
+		if err != nil || a.Host == "" {
+			continue
+		}
+		if !strings.EqualFold(u.Scheme, a.Scheme) || !strings.EqualFold(u.Host, a.Host) {
+			continue
+		}
+		ap := strings.TrimSuffix(path.Clean("/"+a.Path), "/")
+		if up == ap || strings.HasPrefix(up, ap+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// trim URLs, remove empty URLs and duplicates
+func cleanWebhookUrls(urls []string) []string {
+
+	ul := []string{}
+	for _, u := range urls {
+		if u = strings.TrimSpace(u); u != "" && !slices.Contains(ul, u) {
+			ul = append(ul, u)
+		}
+	}
+	return ul
+}
+
+// notifyWebhooks POST model run completion notification to global webhooks and to submission webhooks.
+// Submission webhooks must be allowed by global webhooks configuration, other submission URLs are skipped.
+// Each notification is send in a separate goroutine, failed notifications are retried.
+func notifyWebhooks(rs *RunState, runUrls []string, status string, startTime time.Time) {
+
+	theWebhooks.Lock()
+	urls := slices.Clone(theWebhooks.urls)
+	for _, u := range runUrls {
+		if isWebhookUrlIn(u, theWebhooks.urls) {
+			urls = append(urls, u)
+		} else {
+			omppLog.Log("Warning: webhook URL not allowed: ", u)
+		}
+	}
+	urls = cleanWebhookUrls(urls)
+	secret := theWebhooks.secret
+	retry := theWebhooks.retry
+	theWebhooks.Unlock()
+
+	if len(urls) <= 0 {
+		return // no webhooks
+	}
+
+	tNow := time.Now()
+	p := WebhookPayload{
+		ModelName:      rs.ModelName,
+		ModelDigest:    rs.ModelDigest,
+		RunStamp:       rs.RunStamp,
+		SubmitStamp:    rs.SubmitStamp,
+		RunName:        rs.RunName,
+		TaskRunName:    rs.TaskRunName,
+		Status:         status,
+		IsSuccess:      status == db.DoneRunStatus,
+		StartDateTime:  helper.MakeDateTime(startTime),
+		UpdateDateTime: helper.MakeDateTime(tNow),
+		Duration:       tNow.Sub(startTime).Seconds(),
+	}
+	p.Links.Status = "/api/model/" + rs.ModelDigest + "/run/" + rs.RunStamp + "/status"
+	p.Links.Log = "/api/run/log/model/" + rs.ModelDigest + "/stamp/" + rs.RunStamp
+
+	body, err := json.Marshal(&p)
+	if err != nil {
+		omppLog.Log("Error at webhook notification: ", rs.ModelName, " ", rs.RunStamp, ": ", err.Error())
+		return
+	}
+
+	sign := ""
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		sign = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	for _, u := range urls {
+		go postWebhook(u, body, sign, retry)
+	}
+}
+
+// postWebhook POST json body to webhook URL, retry if POST failed or response status is not 2xx.
+// Redirects are not followed: redirect response is a failed notification.
+func postWebhook(url string, body []byte, sign string, retry int) {
+
+	client := http.Client{
+		Timeout: webhookTimeout * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	delay := webhookRetryDelay * time.Second
+
+	for k := 0; k <= retry; k++ {
+
+		if k > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			omppLog.Log("Error at webhook: ", url, ": ", err.Error())
+			return // invalid URL, retry is useless
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if sign != "" {
+			req.Header.Set("X-Ompp-Signature", sign)
+		}
+
+		rsp, err := client.Do(req)
+		if err != nil {
+			omppLog.Log("Error at webhook: ", url, ": ", err.Error())
+			continue
+		}
+		rsp.Body.Close()
+
+		if rsp.StatusCode >= 200 && rsp.StatusCode < 300 {
+			return // notification done
+		}
+		omppLog.Log("Error at webhook: ", url, ": ", rsp.Status)
+	}
+	omppLog.Log("Failed webhook notification: ", url)
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 OpenM++
+// This code is licensed under the MIT license (see LICENSE.txt for details)
+
+package main
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/openmpp/go/ompp/db"
+	"github.com/openmpp/go/ompp/omppLog"
+)
+
+// default workset read lock duration, if lock ttl not specified in request
+const worksetLockTtlDefault = 5 * time.Minute
+
+// model run workset read lock duration, lock refreshed by oms while model is running
+const worksetRunLockTtl = 2 * time.Minute
+
+// AcquireWorksetLock acquire workset read lock by model digest-or-name, workset name, lock owner and lock duration.
+// Return false if model or workset not found.
+func (mc *ModelCatalog) AcquireWorksetLock(dn, wsn, owner string, ttl time.Duration) (*db.WorksetLock, bool, error) {
+
+	dbConn, setId, ok, err := mc.worksetIdByName(dn, wsn)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	wl, err := db.AcquireWorksetLock(dbConn, setId, owner, ttl)
+	if err != nil {
+		omppLog.Log("Error at workset lock: ", dn, ": ", wsn, ": ", owner, ": ", err.Error())
+		return nil, false, err
+	}
+	return wl, true, nil
+}
+
+// RefreshWorksetLock extend workset read lock of the owner by model digest-or-name, workset name and lock duration.
+// Return false if model or workset not found or owner does not have active lock of the workset.
+func (mc *ModelCatalog) RefreshWorksetLock(dn, wsn, owner string, ttl time.Duration) (bool, error) {
+
+	dbConn, setId, ok, err := mc.worksetIdByName(dn, wsn)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	ok, err = db.RefreshWorksetLock(dbConn, setId, owner, ttl)
+	if err != nil {
+		omppLog.Log("Error at workset lock refresh: ", dn, ": ", wsn, ": ", owner, ": ", err.Error())
+		return false, err
+	}
+	return ok, nil
+}
+
+// ReleaseWorksetLock release workset read lock of the owner by model digest-or-name and workset name.
+// Return false if model or workset not found or owner does not have active lock of the workset.
+func (mc *ModelCatalog) ReleaseWorksetLock(dn, wsn, owner string) (bool, error) {
+
+	dbConn, setId, ok, err := mc.worksetIdByName(dn, wsn)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	ok, err = db.ReleaseWorksetLock(dbConn, setId, owner)
+	if err != nil {
+		omppLog.Log("Error at workset lock release: ", dn, ": ", wsn, ": ", owner, ": ", err.Error())
+		return false, err
+	}
+	return ok, nil
+}
+
+// WorksetLockList return active read locks of the workset by model digest-or-name and workset name.
+// Return false if model or workset not found.
+func (mc *ModelCatalog) WorksetLockList(dn, wsn string) ([]db.WorksetLock, bool, error) {
+
+	dbConn, setId, ok, err := mc.worksetIdByName(dn, wsn)
+	if err != nil || !ok {
+		return []db.WorksetLock{}, false, err
+	}
+
+	wlLst, err := db.GetWorksetLocks(dbConn, setId)
+	if err != nil {
+		omppLog.Log("Error at get workset locks: ", dn, ": ", wsn, ": ", err.Error())
+		return []db.WorksetLock{}, false, err
+	}
+	return wlLst, true, nil
+}
+
+// return model database connection and workset id by model digest-or-name and workset name.
+// Return false if model or workset not found.
+func (mc *ModelCatalog) worksetIdByName(dn, wsn string) (*sql.DB, int, bool, error) {
+
+	if dn == "" {
+		omppLog.Log("Warning: invalid (empty) model digest and name")
+		return nil, 0, false, nil
+	}
+	if wsn == "" {
+		omppLog.Log("Warning: invalid (empty) workset name")
+		return nil, 0, false, nil
+	}
+	meta, dbConn, ok := mc.modelMeta(dn)
+	if !ok {
+		omppLog.Log("Warning: model digest or name not found: ", dn)
+		return nil, 0, false, nil
+	}
+
+	w, err := db.GetWorksetByName(dbConn, meta.Model.ModelId, wsn)
+	if err != nil {
+		omppLog.Log("Error at get workset status: ", dn, ": ", wsn, ": ", err.Error())
+		return nil, 0, false, err
+	}
+	if w == nil {
+		omppLog.Log("Warning: workset not found: ", dn, ": ", wsn)
+		return nil, 0, false, nil
+	}
+	return dbConn, w.SetId, true, nil
+}
+
+// lock model run input workset, if workset is read-only.
+// Workset is found by OpenM.SetId or OpenM.SetName run option, if not specified then model default workset is used.
+// Lock is refreshed until returned release function is called.
+// Lock is advisory: if lock cannot be acquired then error is logged and model run continues.
+func lockRunWorkset(digest string, opts map[string]string, owner string) func() {
+
+	meta, dbConn, ok := theCatalog.modelMeta(digest)
+	if !ok {
+		return func() {} // model not found: nothing to lock
+	}
+
+	// find input workset by id or by name, if not specified then use model default workset
+	var w *db.WorksetRow
+	var err error
+
+	if s, ok := runOptValue(opts, "OpenM.SetId"); ok {
+		if id, e := strconv.Atoi(s); e == nil && id > 0 {
+			w, err = db.GetWorkset(dbConn, id)
+		}
+	} else {
+		if wsn, ok := runOptValue(opts, "OpenM.SetName"); ok {
+			w, err = db.GetWorksetByName(dbConn, meta.Model.ModelId, wsn)
+		} else {
+			w, err = db.GetDefaultWorkset(dbConn, meta.Model.ModelId)
+		}
+	}
+	if err != nil {
+		omppLog.Log("Warning: unable to find model run workset: ", digest, ": ", err.Error())
+		return func() {}
+	}
+	if w == nil || w.ModelId != meta.Model.ModelId || !w.IsReadonly {
+		return func() {} // workset not found or not read-only: it cannot be locked
+	}
+	wsn := w.Name
+	setId := w.SetId
+
+	if _, err = db.AcquireWorksetLock(dbConn, setId, owner, worksetRunLockTtl); err != nil {
+		omppLog.Log("Warning: unable to lock workset: ", wsn, ": ", err.Error())
+		return func() {}
+	}
+
+	// refresh the lock until released
+	doneC := make(chan bool)
+	go func() {
+		tck := time.NewTicker(worksetRunLockTtl / 3)
+		defer tck.Stop()
+		for {
+			select {
+			case <-doneC:
+				return
+			case <-tck.C:
+				if ok, e := db.RefreshWorksetLock(dbConn, setId, owner, worksetRunLockTtl); e != nil || !ok {
+					omppLog.Log("Warning: unable to refresh workset lock: ", wsn, ": ", owner)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(doneC)
+		if _, e := db.ReleaseWorksetLock(dbConn, setId, owner); e != nil {
+			omppLog.Log("Warning: unable to release workset lock: ", wsn, ": ", e.Error())
+		}
+	}
+}
@@ -0,0 +1,791 @@
+--
+-- Copyright (c) 2026 OpenM++
+-- This code is licensed under the MIT license (see LICENSE.txt for details)
+--
+-- openM++ database schema: model metadata, model runs, input sets (worksets) and modeling tasks.
+-- Parameter, output table and microdata values are stored in db tables created for each model,
+-- db table names are stored in parameter_dic, table_dic and entity_gen.
+--
+-- Data types are portable: INT, SMALLINT, BIGINT, FLOAT, VARCHAR, use CLOB or TEXT as notes type if required.
+--
+-- Side tables are not part of model metadata and not used by model compiler:
+-- existing database must be upgraded by upgrade_side_tables.sql script to use workset read locks,
+-- workset parameters history, workset parameters value digests and output tables statistics.
+--
+
+--
+-- list of ids: values for primary keys
+--
+CREATE TABLE id_lst
+(
+  id_key   VARCHAR(32) NOT NULL, -- id key: 'openmpp', 'lang_id', 'model_id', 'run_id_set_id',...
+  id_value INT         NOT NULL, -- id value
+  PRIMARY KEY (id_key)
+);
+
+--
+-- list of languages
+--
+CREATE TABLE lang_lst
+(
+  lang_id   INT          NOT NULL, -- unique language id
+  lang_code VARCHAR(32)  NOT NULL, -- language code: EN, FR
+  lang_name VARCHAR(255) NOT NULL, -- language name: English
+  PRIMARY KEY (lang_id),
+  CONSTRAINT lang_un UNIQUE (lang_code)
+);
+
+CREATE TABLE lang_word
+(
+  lang_id    INT          NOT NULL, -- language id
+  word_code  VARCHAR(255) NOT NULL, -- word code: Min
+  word_value VARCHAR(255) NOT NULL, -- word value: Minimum
+  PRIMARY KEY (lang_id, word_code),
+  CONSTRAINT lang_word_mk FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- list of models
+--
+CREATE TABLE model_dic
+(
+  model_id        INT          NOT NULL, -- unique model id
+  model_name      VARCHAR(255) NOT NULL, -- model name: modelOne
+  model_digest    VARCHAR(32)  NOT NULL, -- model digest
+  model_type      INT          NOT NULL, -- 0 = case based, 1 = time based
+  model_ver       VARCHAR(32)  NOT NULL, -- model version
+  create_dt       VARCHAR(32)  NOT NULL, -- create date-time
+  default_lang_id INT          NOT NULL, -- model default language
+  PRIMARY KEY (model_id),
+  CONSTRAINT model_dic_un UNIQUE (model_digest),
+  CONSTRAINT model_dic_lang_fk FOREIGN KEY (default_lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE model_dic_txt
+(
+  model_id INT            NOT NULL, -- model id
+  lang_id  INT            NOT NULL, -- language id
+  descr    VARCHAR(255)   NOT NULL, -- model description
+  note     VARCHAR(32000),          -- model notes
+  PRIMARY KEY (model_id, lang_id),
+  CONSTRAINT model_dic_txt_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT model_dic_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE model_word
+(
+  model_id   INT          NOT NULL, -- model id
+  lang_id    INT          NOT NULL, -- language id
+  word_code  VARCHAR(255) NOT NULL, -- word code
+  word_value VARCHAR(255) NOT NULL, -- word value
+  PRIMARY KEY (model_id, lang_id, word_code),
+  CONSTRAINT model_word_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT model_word_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- model types: built-in types, classifications, ranges, partitions
+--
+CREATE TABLE type_dic
+(
+  type_hid      INT          NOT NULL, -- unique type id
+  type_name     VARCHAR(255) NOT NULL, -- type name: char, int, age, sex
+  type_digest   VARCHAR(32)  NOT NULL, -- type digest
+  dic_id        INT          NOT NULL, -- dictionary id: 0 = simple type, 1 = logical, 2 = classification, 3 = range, 4 = partition, 5 = link
+  total_enum_id INT          NOT NULL, -- if total enabled this is enum_id of total item = max enum id + 1
+  PRIMARY KEY (type_hid),
+  CONSTRAINT type_dic_un UNIQUE (type_digest)
+);
+
+CREATE TABLE model_type_dic
+(
+  model_id      INT NOT NULL, -- model id
+  model_type_id INT NOT NULL, -- model type id
+  type_hid      INT NOT NULL, -- type unique id
+  PRIMARY KEY (model_id, model_type_id),
+  CONSTRAINT model_type_un UNIQUE (model_id, type_hid),
+  CONSTRAINT model_type_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT model_type_hid_fk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid)
+);
+
+CREATE TABLE type_dic_txt
+(
+  type_hid INT            NOT NULL, -- type unique id
+  lang_id  INT            NOT NULL, -- language id
+  descr    VARCHAR(255)   NOT NULL, -- type description
+  note     VARCHAR(32000),          -- type notes
+  PRIMARY KEY (type_hid, lang_id),
+  CONSTRAINT type_dic_txt_mk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid),
+  CONSTRAINT type_dic_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE type_enum_lst
+(
+  type_hid  INT          NOT NULL, -- type unique id
+  enum_id   INT          NOT NULL, -- enum id
+  enum_name VARCHAR(255) NOT NULL, -- enum code: M, F
+  PRIMARY KEY (type_hid, enum_id),
+  CONSTRAINT type_enum_un UNIQUE (type_hid, enum_name),
+  CONSTRAINT type_enum_lst_mk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid)
+);
+
+CREATE TABLE type_enum_txt
+(
+  type_hid INT            NOT NULL, -- type unique id
+  enum_id  INT            NOT NULL, -- enum id
+  lang_id  INT            NOT NULL, -- language id
+  descr    VARCHAR(255)   NOT NULL, -- enum description
+  note     VARCHAR(32000),          -- enum notes
+  PRIMARY KEY (type_hid, enum_id, lang_id),
+  CONSTRAINT type_enum_txt_mk FOREIGN KEY (type_hid, enum_id) REFERENCES type_enum_lst (type_hid, enum_id),
+  CONSTRAINT type_enum_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- model parameters
+--
+CREATE TABLE parameter_dic
+(
+  parameter_hid    INT          NOT NULL, -- unique parameter id
+  parameter_name   VARCHAR(255) NOT NULL, -- parameter name
+  parameter_digest VARCHAR(32)  NOT NULL, -- parameter digest
+  db_run_table     VARCHAR(64)  NOT NULL, -- run values db table name: ageSex_p12345678
+  db_set_table     VARCHAR(64)  NOT NULL, -- workset values db table name: ageSex_w12345678
+  parameter_rank   INT          NOT NULL, -- number of dimensions
+  type_hid         INT          NOT NULL, -- parameter type id
+  is_extendable    SMALLINT     NOT NULL, -- if non-zero then parameter value can be NULL
+  num_cumulated    INT          NOT NULL, -- number of cumulated dimensions
+  import_digest    VARCHAR(32)  NOT NULL, -- import digest to link parameter to output table or microdata
+  PRIMARY KEY (parameter_hid),
+  CONSTRAINT parameter_dic_un UNIQUE (parameter_digest),
+  CONSTRAINT parameter_dic_type_fk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid)
+);
+
+CREATE TABLE model_parameter_dic
+(
+  model_id           INT      NOT NULL, -- model id
+  model_parameter_id INT      NOT NULL, -- model parameter id
+  parameter_hid      INT      NOT NULL, -- parameter unique id
+  is_hidden          SMALLINT NOT NULL, -- if non-zero then parameter is hidden
+  PRIMARY KEY (model_id, model_parameter_id),
+  CONSTRAINT model_param_un UNIQUE (model_id, parameter_hid),
+  CONSTRAINT model_param_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT model_param_hid_fk FOREIGN KEY (parameter_hid) REFERENCES parameter_dic (parameter_hid)
+);
+
+CREATE TABLE model_parameter_import
+(
+  model_id           INT          NOT NULL, -- model id
+  model_parameter_id INT          NOT NULL, -- model parameter id
+  from_name          VARCHAR(255) NOT NULL, -- upstream output table or parameter name
+  from_model_name    VARCHAR(255) NOT NULL, -- upstream model name
+  is_sample_dim      SMALLINT     NOT NULL, -- if non-zero then import from sub-values
+  PRIMARY KEY (model_id, model_parameter_id, from_name, from_model_name),
+  CONSTRAINT model_param_import_mk FOREIGN KEY (model_id, model_parameter_id) REFERENCES model_parameter_dic (model_id, model_parameter_id)
+);
+
+CREATE TABLE parameter_dic_txt
+(
+  parameter_hid INT            NOT NULL, -- parameter unique id
+  lang_id       INT            NOT NULL, -- language id
+  descr         VARCHAR(255)   NOT NULL, -- parameter description
+  note          VARCHAR(32000),          -- parameter notes
+  PRIMARY KEY (parameter_hid, lang_id),
+  CONSTRAINT parameter_dic_txt_mk FOREIGN KEY (parameter_hid) REFERENCES parameter_dic (parameter_hid),
+  CONSTRAINT parameter_dic_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE parameter_dims
+(
+  parameter_hid INT          NOT NULL, -- parameter unique id
+  dim_id        INT          NOT NULL, -- dimension id: 0, 1,...
+  dim_name      VARCHAR(255) NOT NULL, -- dimension name: dim0
+  type_hid      INT          NOT NULL, -- dimension type id
+  PRIMARY KEY (parameter_hid, dim_id),
+  CONSTRAINT parameter_dims_un UNIQUE (parameter_hid, dim_name),
+  CONSTRAINT parameter_dims_mk FOREIGN KEY (parameter_hid) REFERENCES parameter_dic (parameter_hid),
+  CONSTRAINT parameter_dims_type_fk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid)
+);
+
+CREATE TABLE parameter_dims_txt
+(
+  parameter_hid INT            NOT NULL, -- parameter unique id
+  dim_id        INT            NOT NULL, -- dimension id
+  lang_id       INT            NOT NULL, -- language id
+  descr         VARCHAR(255)   NOT NULL, -- dimension description
+  note          VARCHAR(32000),          -- dimension notes
+  PRIMARY KEY (parameter_hid, dim_id, lang_id),
+  CONSTRAINT parameter_dims_txt_mk FOREIGN KEY (parameter_hid, dim_id) REFERENCES parameter_dims (parameter_hid, dim_id),
+  CONSTRAINT parameter_dims_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- model output tables
+--
+CREATE TABLE table_dic
+(
+  table_hid       INT          NOT NULL, -- unique output table id
+  table_name      VARCHAR(255) NOT NULL, -- output table name
+  table_digest    VARCHAR(32)  NOT NULL, -- output table digest
+  table_rank      INT          NOT NULL, -- number of dimensions
+  is_sparse       SMALLINT     NOT NULL, -- if non-zero then table is sparse
+  db_expr_table   VARCHAR(64)  NOT NULL, -- expressions db table name: ageSex_v12345678
+  db_acc_table    VARCHAR(64)  NOT NULL, -- accumulators db table name: ageSex_a12345678
+  db_acc_all_view VARCHAR(64)  NOT NULL, -- all accumulators db view name: ageSex_d12345678
+  import_digest   VARCHAR(32)  NOT NULL, -- import digest to link output table to downstream parameter
+  PRIMARY KEY (table_hid),
+  CONSTRAINT table_dic_un UNIQUE (table_digest)
+);
+
+CREATE TABLE model_table_dic
+(
+  model_id       INT      NOT NULL, -- model id
+  model_table_id INT      NOT NULL, -- model output table id
+  table_hid      INT      NOT NULL, -- output table unique id
+  is_user        SMALLINT NOT NULL, -- if non-zero then table calculated by user function
+  expr_dim_pos   INT      NOT NULL, -- expressions dimension position
+  is_hidden      SMALLINT NOT NULL, -- if non-zero then table is hidden
+  PRIMARY KEY (model_id, model_table_id),
+  CONSTRAINT model_table_un UNIQUE (model_id, table_hid),
+  CONSTRAINT model_table_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT model_table_hid_fk FOREIGN KEY (table_hid) REFERENCES table_dic (table_hid)
+);
+
+CREATE TABLE table_dic_txt
+(
+  table_hid  INT            NOT NULL, -- output table unique id
+  lang_id    INT            NOT NULL, -- language id
+  descr      VARCHAR(255)   NOT NULL, -- output table description
+  note       VARCHAR(32000),          -- output table notes
+  expr_descr VARCHAR(255)   NOT NULL, -- expressions dimension description
+  expr_note  VARCHAR(32000),          -- expressions dimension notes
+  PRIMARY KEY (table_hid, lang_id),
+  CONSTRAINT table_dic_txt_mk FOREIGN KEY (table_hid) REFERENCES table_dic (table_hid),
+  CONSTRAINT table_dic_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE table_dims
+(
+  table_hid INT          NOT NULL, -- output table unique id
+  dim_id    INT          NOT NULL, -- dimension id: 0, 1,...
+  dim_name  VARCHAR(255) NOT NULL, -- dimension name: dim0
+  type_hid  INT          NOT NULL, -- dimension type id
+  is_total  SMALLINT     NOT NULL, -- if non-zero then dimension has total item
+  dim_size  INT          NOT NULL, -- number of dimension items, including total
+  PRIMARY KEY (table_hid, dim_id),
+  CONSTRAINT table_dims_un UNIQUE (table_hid, dim_name),
+  CONSTRAINT table_dims_mk FOREIGN KEY (table_hid) REFERENCES table_dic (table_hid),
+  CONSTRAINT table_dims_type_fk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid)
+);
+
+CREATE TABLE table_dims_txt
+(
+  table_hid INT            NOT NULL, -- output table unique id
+  dim_id    INT            NOT NULL, -- dimension id
+  lang_id   INT            NOT NULL, -- language id
+  descr     VARCHAR(255)   NOT NULL, -- dimension description
+  note      VARCHAR(32000),          -- dimension notes
+  PRIMARY KEY (table_hid, dim_id, lang_id),
+  CONSTRAINT table_dims_txt_mk FOREIGN KEY (table_hid, dim_id) REFERENCES table_dims (table_hid, dim_id),
+  CONSTRAINT table_dims_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE table_acc
+(
+  table_hid  INT            NOT NULL, -- output table unique id
+  acc_id     INT            NOT NULL, -- accumulator id: 0, 1,...
+  acc_name   VARCHAR(255)   NOT NULL, -- accumulator name: acc0
+  is_derived SMALLINT       NOT NULL, -- if non-zero then accumulator is derived
+  acc_src    VARCHAR(255)   NOT NULL, -- accumulator source expression
+  acc_sql    VARCHAR(2048)  NOT NULL, -- accumulator sql expression
+  PRIMARY KEY (table_hid, acc_id),
+  CONSTRAINT table_acc_un UNIQUE (table_hid, acc_name),
+  CONSTRAINT table_acc_mk FOREIGN KEY (table_hid) REFERENCES table_dic (table_hid)
+);
+
+CREATE TABLE table_acc_txt
+(
+  table_hid INT            NOT NULL, -- output table unique id
+  acc_id    INT            NOT NULL, -- accumulator id
+  lang_id   INT            NOT NULL, -- language id
+  descr     VARCHAR(255)   NOT NULL, -- accumulator description
+  note      VARCHAR(32000),          -- accumulator notes
+  PRIMARY KEY (table_hid, acc_id, lang_id),
+  CONSTRAINT table_acc_txt_mk FOREIGN KEY (table_hid, acc_id) REFERENCES table_acc (table_hid, acc_id),
+  CONSTRAINT table_acc_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE table_expr
+(
+  table_hid     INT            NOT NULL, -- output table unique id
+  expr_id       INT            NOT NULL, -- expression id: 0, 1,...
+  expr_name     VARCHAR(255)   NOT NULL, -- expression name: expr0
+  expr_decimals INT            NOT NULL, -- number of decimals, if negative then not specified
+  expr_src      VARCHAR(255)   NOT NULL, -- expression source
+  expr_sql      VARCHAR(2048)  NOT NULL, -- expression sql
+  PRIMARY KEY (table_hid, expr_id),
+  CONSTRAINT table_expr_un UNIQUE (table_hid, expr_name),
+  CONSTRAINT table_expr_mk FOREIGN KEY (table_hid) REFERENCES table_dic (table_hid)
+);
+
+CREATE TABLE table_expr_txt
+(
+  table_hid INT            NOT NULL, -- output table unique id
+  expr_id   INT            NOT NULL, -- expression id
+  lang_id   INT            NOT NULL, -- language id
+  descr     VARCHAR(255)   NOT NULL, -- expression description
+  note      VARCHAR(32000),          -- expression notes
+  PRIMARY KEY (table_hid, expr_id, lang_id),
+  CONSTRAINT table_expr_txt_mk FOREIGN KEY (table_hid, expr_id) REFERENCES table_expr (table_hid, expr_id),
+  CONSTRAINT table_expr_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- model entities and attributes
+--
+CREATE TABLE entity_dic
+(
+  entity_hid    INT          NOT NULL, -- unique entity id
+  entity_name   VARCHAR(255) NOT NULL, -- entity name: Person
+  entity_digest VARCHAR(32)  NOT NULL, -- entity digest
+  PRIMARY KEY (entity_hid),
+  CONSTRAINT entity_dic_un UNIQUE (entity_digest)
+);
+
+CREATE TABLE model_entity_dic
+(
+  model_id        INT NOT NULL, -- model id
+  model_entity_id INT NOT NULL, -- model entity id
+  entity_hid      INT NOT NULL, -- entity unique id
+  PRIMARY KEY (model_id, model_entity_id),
+  CONSTRAINT model_entity_un UNIQUE (model_id, entity_hid),
+  CONSTRAINT model_entity_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT model_entity_hid_fk FOREIGN KEY (entity_hid) REFERENCES entity_dic (entity_hid)
+);
+
+CREATE TABLE entity_dic_txt
+(
+  entity_hid INT            NOT NULL, -- entity unique id
+  lang_id    INT            NOT NULL, -- language id
+  descr      VARCHAR(255)   NOT NULL, -- entity description
+  note       VARCHAR(32000),          -- entity notes
+  PRIMARY KEY (entity_hid, lang_id),
+  CONSTRAINT entity_dic_txt_mk FOREIGN KEY (entity_hid) REFERENCES entity_dic (entity_hid),
+  CONSTRAINT entity_dic_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE entity_attr
+(
+  entity_hid  INT          NOT NULL, -- entity unique id
+  attr_id     INT          NOT NULL, -- attribute id: 0, 1,...
+  attr_name   VARCHAR(255) NOT NULL, -- attribute name: age
+  type_hid    INT          NOT NULL, -- attribute type id
+  is_internal SMALLINT     NOT NULL, -- if non-zero then attribute is internal
+  PRIMARY KEY (entity_hid, attr_id),
+  CONSTRAINT entity_attr_un UNIQUE (entity_hid, attr_name),
+  CONSTRAINT entity_attr_mk FOREIGN KEY (entity_hid) REFERENCES entity_dic (entity_hid),
+  CONSTRAINT entity_attr_type_fk FOREIGN KEY (type_hid) REFERENCES type_dic (type_hid)
+);
+
+CREATE TABLE entity_attr_txt
+(
+  entity_hid INT            NOT NULL, -- entity unique id
+  attr_id    INT            NOT NULL, -- attribute id
+  lang_id    INT            NOT NULL, -- language id
+  descr      VARCHAR(255)   NOT NULL, -- attribute description
+  note       VARCHAR(32000),          -- attribute notes
+  PRIMARY KEY (entity_hid, attr_id, lang_id),
+  CONSTRAINT entity_attr_txt_mk FOREIGN KEY (entity_hid, attr_id) REFERENCES entity_attr (entity_hid, attr_id),
+  CONSTRAINT entity_attr_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- groups of parameters or output tables and groups of entity attributes
+--
+CREATE TABLE group_lst
+(
+  model_id     INT          NOT NULL, -- model id
+  group_id     INT          NOT NULL, -- group id
+  is_parameter SMALLINT     NOT NULL, -- if non-zero then parameters group else output tables group
+  group_name   VARCHAR(255) NOT NULL, -- group name
+  is_hidden    SMALLINT     NOT NULL, -- if non-zero then group is hidden
+  PRIMARY KEY (model_id, group_id),
+  CONSTRAINT group_lst_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id)
+);
+
+CREATE TABLE group_txt
+(
+  model_id INT            NOT NULL, -- model id
+  group_id INT            NOT NULL, -- group id
+  lang_id  INT            NOT NULL, -- language id
+  descr    VARCHAR(255)   NOT NULL, -- group description
+  note     VARCHAR(32000),          -- group notes
+  PRIMARY KEY (model_id, group_id, lang_id),
+  CONSTRAINT group_txt_mk FOREIGN KEY (model_id, group_id) REFERENCES group_lst (model_id, group_id),
+  CONSTRAINT group_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE group_pc
+(
+  model_id       INT NOT NULL, -- model id
+  group_id       INT NOT NULL, -- parent group id
+  child_pos      INT NOT NULL, -- child position in the group
+  child_group_id INT NULL,     -- if not NULL then child group id
+  leaf_id        INT NULL,     -- if not NULL then model parameter id or model output table id
+  PRIMARY KEY (model_id, group_id, child_pos),
+  CONSTRAINT group_pc_mk FOREIGN KEY (model_id, group_id) REFERENCES group_lst (model_id, group_id)
+);
+
+CREATE TABLE entity_group_lst
+(
+  model_id        INT          NOT NULL, -- model id
+  model_entity_id INT          NOT NULL, -- model entity id
+  group_id        INT          NOT NULL, -- group id
+  group_name      VARCHAR(255) NOT NULL, -- group name
+  is_hidden       SMALLINT     NOT NULL, -- if non-zero then group is hidden
+  PRIMARY KEY (model_id, model_entity_id, group_id),
+  CONSTRAINT entity_group_lst_mk FOREIGN KEY (model_id, model_entity_id) REFERENCES model_entity_dic (model_id, model_entity_id)
+);
+
+CREATE TABLE entity_group_txt
+(
+  model_id        INT            NOT NULL, -- model id
+  model_entity_id INT            NOT NULL, -- model entity id
+  group_id        INT            NOT NULL, -- group id
+  lang_id         INT            NOT NULL, -- language id
+  descr           VARCHAR(255)   NOT NULL, -- group description
+  note            VARCHAR(32000),          -- group notes
+  PRIMARY KEY (model_id, model_entity_id, group_id, lang_id),
+  CONSTRAINT entity_group_txt_mk FOREIGN KEY (model_id, model_entity_id, group_id) REFERENCES entity_group_lst (model_id, model_entity_id, group_id),
+  CONSTRAINT entity_group_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE entity_group_pc
+(
+  model_id        INT NOT NULL, -- model id
+  model_entity_id INT NOT NULL, -- model entity id
+  group_id        INT NOT NULL, -- parent group id
+  child_pos       INT NOT NULL, -- child position in the group
+  child_group_id  INT NULL,     -- if not NULL then child group id
+  attr_id         INT NULL,     -- if not NULL then entity attribute id
+  PRIMARY KEY (model_id, model_entity_id, group_id, child_pos),
+  CONSTRAINT entity_group_pc_mk FOREIGN KEY (model_id, model_entity_id, group_id) REFERENCES entity_group_lst (model_id, model_entity_id, group_id)
+);
+
+--
+-- profiles: named sets of model run options
+--
+CREATE TABLE profile_lst
+(
+  profile_name VARCHAR(255) NOT NULL, -- profile name
+  PRIMARY KEY (profile_name)
+);
+
+CREATE TABLE profile_option
+(
+  profile_name VARCHAR(255)   NOT NULL, -- profile name
+  option_key   VARCHAR(255)   NOT NULL, -- option key: Parameter.StartingSeed
+  option_value VARCHAR(32000) NOT NULL, -- option value: 1234
+  PRIMARY KEY (profile_name, option_key),
+  CONSTRAINT profile_option_mk FOREIGN KEY (profile_name) REFERENCES profile_lst (profile_name)
+);
+
+--
+-- model runs
+--
+CREATE TABLE run_lst
+(
+  run_id        INT          NOT NULL, -- unique run id
+  model_id      INT          NOT NULL, -- model id
+  run_name      VARCHAR(255) NOT NULL, -- model run name
+  sub_count     INT          NOT NULL, -- number of sub-values
+  sub_started   INT          NOT NULL, -- number of sub-values started
+  sub_completed INT          NOT NULL, -- number of sub-values completed
+  sub_restart   INT          NOT NULL, -- sub-value to restart from
+  create_dt     VARCHAR(32)  NOT NULL, -- start date-time
+  status        VARCHAR(1)   NOT NULL, -- run status: i=init p=progress s=success x=exit e=error
+  update_dt     VARCHAR(32)  NOT NULL, -- last update date-time
+  run_digest    VARCHAR(32)  NULL,     -- digest of model run metadata
+  value_digest  VARCHAR(32)  NULL,     -- digest of model run values
+  run_stamp     VARCHAR(32)  NOT NULL, -- model run stamp
+  PRIMARY KEY (run_id),
+  CONSTRAINT run_lst_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id)
+);
+
+CREATE TABLE run_txt
+(
+  run_id  INT            NOT NULL, -- run id
+  lang_id INT            NOT NULL, -- language id
+  descr   VARCHAR(255)   NOT NULL, -- model run description
+  note    VARCHAR(32000),          -- model run notes
+  PRIMARY KEY (run_id, lang_id),
+  CONSTRAINT run_txt_mk FOREIGN KEY (run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE run_option
+(
+  run_id       INT            NOT NULL, -- run id
+  option_key   VARCHAR(255)   NOT NULL, -- option key: OpenM.SubValues
+  option_value VARCHAR(32000) NOT NULL, -- option value: 16
+  PRIMARY KEY (run_id, option_key),
+  CONSTRAINT run_option_mk FOREIGN KEY (run_id) REFERENCES run_lst (run_id)
+);
+
+CREATE TABLE run_parameter
+(
+  run_id        INT         NOT NULL, -- run id
+  parameter_hid INT         NOT NULL, -- parameter unique id
+  base_run_id   INT         NOT NULL, -- source run id where parameter values are stored
+  sub_count     INT         NOT NULL, -- number of parameter sub-values
+  value_digest  VARCHAR(32) NULL,     -- digest of parameter values
+  PRIMARY KEY (run_id, parameter_hid),
+  CONSTRAINT run_parameter_mk FOREIGN KEY (run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_parameter_base_fk FOREIGN KEY (base_run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_parameter_hid_fk FOREIGN KEY (parameter_hid) REFERENCES parameter_dic (parameter_hid)
+);
+
+CREATE TABLE run_parameter_txt
+(
+  run_id        INT            NOT NULL, -- run id
+  parameter_hid INT            NOT NULL, -- parameter unique id
+  lang_id       INT            NOT NULL, -- language id
+  note          VARCHAR(32000),          -- parameter value notes
+  PRIMARY KEY (run_id, parameter_hid, lang_id),
+  CONSTRAINT run_parameter_txt_mk FOREIGN KEY (run_id, parameter_hid) REFERENCES run_parameter (run_id, parameter_hid),
+  CONSTRAINT run_parameter_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE run_parameter_import
+(
+  run_id            INT          NOT NULL, -- run id
+  parameter_hid     INT          NOT NULL, -- parameter unique id
+  is_from_parameter SMALLINT     NOT NULL, -- if non-zero then imported from upstream parameter else from output table
+  from_name         VARCHAR(255) NOT NULL, -- upstream parameter or output table name
+  from_model_id     INT          NOT NULL, -- upstream model id
+  is_sample_dim     SMALLINT     NOT NULL, -- if non-zero then imported from sub-values
+  PRIMARY KEY (run_id, parameter_hid),
+  CONSTRAINT run_parameter_import_mk FOREIGN KEY (run_id, parameter_hid) REFERENCES run_parameter (run_id, parameter_hid)
+);
+
+CREATE TABLE run_table
+(
+  run_id       INT         NOT NULL, -- run id
+  table_hid    INT         NOT NULL, -- output table unique id
+  base_run_id  INT         NOT NULL, -- source run id where output table values are stored
+  value_digest VARCHAR(32) NULL,     -- digest of output table values
+  PRIMARY KEY (run_id, table_hid),
+  CONSTRAINT run_table_mk FOREIGN KEY (run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_table_base_fk FOREIGN KEY (base_run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_table_hid_fk FOREIGN KEY (table_hid) REFERENCES table_dic (table_hid)
+);
+
+CREATE TABLE entity_gen
+(
+  entity_gen_hid  INT         NOT NULL, -- unique entity generation id
+  entity_hid      INT         NOT NULL, -- entity unique id
+  db_entity_table VARCHAR(64) NOT NULL, -- microdata db table name: Person_g12345678
+  gen_digest      VARCHAR(32) NOT NULL, -- entity generation digest
+  PRIMARY KEY (entity_gen_hid),
+  CONSTRAINT entity_gen_un UNIQUE (gen_digest),
+  CONSTRAINT entity_gen_fk FOREIGN KEY (entity_hid) REFERENCES entity_dic (entity_hid)
+);
+
+CREATE TABLE entity_gen_attr
+(
+  entity_gen_hid INT NOT NULL, -- entity generation id
+  attr_id        INT NOT NULL, -- attribute id
+  entity_hid     INT NOT NULL, -- entity unique id
+  PRIMARY KEY (entity_gen_hid, attr_id),
+  CONSTRAINT entity_gen_attr_mk FOREIGN KEY (entity_gen_hid) REFERENCES entity_gen (entity_gen_hid),
+  CONSTRAINT entity_gen_attr_fk FOREIGN KEY (entity_hid, attr_id) REFERENCES entity_attr (entity_hid, attr_id)
+);
+
+CREATE TABLE run_entity
+(
+  run_id         INT         NOT NULL, -- run id
+  entity_gen_hid INT         NOT NULL, -- entity generation id
+  base_run_id    INT         NOT NULL, -- source run id where microdata values are stored
+  row_count      BIGINT      NOT NULL, -- number of microdata rows
+  value_digest   VARCHAR(32) NULL,     -- digest of microdata values
+  PRIMARY KEY (run_id, entity_gen_hid),
+  CONSTRAINT run_entity_mk FOREIGN KEY (run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_entity_base_fk FOREIGN KEY (base_run_id) REFERENCES run_lst (run_id),
+  CONSTRAINT run_entity_gen_fk FOREIGN KEY (entity_gen_hid) REFERENCES entity_gen (entity_gen_hid)
+);
+
+CREATE TABLE run_progress
+(
+  run_id         INT         NOT NULL, -- run id
+  sub_id         INT         NOT NULL, -- sub-value id
+  create_dt      VARCHAR(32) NOT NULL, -- start date-time
+  status         VARCHAR(1)  NOT NULL, -- sub-value run status: i=init p=progress s=success x=exit e=error
+  update_dt      VARCHAR(32) NOT NULL, -- last update date-time
+  progress_count INT         NOT NULL, -- progress percent
+  progress_value FLOAT       NOT NULL, -- progress value: number of cases or time
+  PRIMARY KEY (run_id, sub_id),
+  CONSTRAINT run_progress_mk FOREIGN KEY (run_id) REFERENCES run_lst (run_id)
+);
+
+--
+-- input sets of model parameters (worksets)
+--
+CREATE TABLE workset_lst
+(
+  set_id      INT          NOT NULL, -- unique workset id
+  base_run_id INT          NULL,     -- if not NULL then base run id for parameters not included in workset
+  model_id    INT          NOT NULL, -- model id
+  set_name    VARCHAR(255) NOT NULL, -- workset name
+  is_readonly SMALLINT     NOT NULL, -- if non-zero then workset is read-only
+  update_dt   VARCHAR(32)  NOT NULL, -- last update date-time
+  PRIMARY KEY (set_id),
+  CONSTRAINT workset_lst_un UNIQUE (model_id, set_name),
+  CONSTRAINT workset_lst_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id),
+  CONSTRAINT workset_lst_base_fk FOREIGN KEY (base_run_id) REFERENCES run_lst (run_id)
+);
+
+CREATE TABLE workset_txt
+(
+  set_id  INT            NOT NULL, -- workset id
+  lang_id INT            NOT NULL, -- language id
+  descr   VARCHAR(255)   NOT NULL, -- workset description
+  note    VARCHAR(32000),          -- workset notes
+  PRIMARY KEY (set_id, lang_id),
+  CONSTRAINT workset_txt_mk FOREIGN KEY (set_id) REFERENCES workset_lst (set_id),
+  CONSTRAINT workset_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE workset_parameter
+(
+  set_id         INT         NOT NULL, -- workset id
+  parameter_hid  INT         NOT NULL, -- parameter unique id
+  sub_count      INT         NOT NULL, -- number of parameter sub-values
+  default_sub_id INT         NOT NULL, -- default sub-value id
+  value_digest   VARCHAR(32) NULL,     -- digest of parameter values, NULL if not calculated
+  PRIMARY KEY (set_id, parameter_hid),
+  CONSTRAINT workset_parameter_mk FOREIGN KEY (set_id) REFERENCES workset_lst (set_id),
+  CONSTRAINT workset_parameter_hid_fk FOREIGN KEY (parameter_hid) REFERENCES parameter_dic (parameter_hid)
+);
+
+CREATE TABLE workset_parameter_txt
+(
+  set_id        INT            NOT NULL, -- workset id
+  parameter_hid INT            NOT NULL, -- parameter unique id
+  lang_id       INT            NOT NULL, -- language id
+  note          VARCHAR(32000),          -- parameter value notes
+  PRIMARY KEY (set_id, parameter_hid, lang_id),
+  CONSTRAINT workset_parameter_txt_mk FOREIGN KEY (set_id, parameter_hid) REFERENCES workset_parameter (set_id, parameter_hid),
+  CONSTRAINT workset_parameter_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+--
+-- modeling tasks: named sets of worksets and task runs
+--
+CREATE TABLE task_lst
+(
+  task_id   INT          NOT NULL, -- unique task id
+  model_id  INT          NOT NULL, -- model id
+  task_name VARCHAR(255) NOT NULL, -- task name
+  PRIMARY KEY (task_id),
+  CONSTRAINT task_lst_un UNIQUE (model_id, task_name),
+  CONSTRAINT task_lst_mk FOREIGN KEY (model_id) REFERENCES model_dic (model_id)
+);
+
+CREATE TABLE task_txt
+(
+  task_id INT            NOT NULL, -- task id
+  lang_id INT            NOT NULL, -- language id
+  descr   VARCHAR(255)   NOT NULL, -- task description
+  note    VARCHAR(32000),          -- task notes
+  PRIMARY KEY (task_id, lang_id),
+  CONSTRAINT task_txt_mk FOREIGN KEY (task_id) REFERENCES task_lst (task_id),
+  CONSTRAINT task_txt_lang FOREIGN KEY (lang_id) REFERENCES lang_lst (lang_id)
+);
+
+CREATE TABLE task_set
+(
+  task_id INT NOT NULL, -- task id
+  set_id  INT NOT NULL, -- workset id
+  PRIMARY KEY (task_id, set_id),
+  CONSTRAINT task_set_mk FOREIGN KEY (task_id) REFERENCES task_lst (task_id),
+  CONSTRAINT task_set_fk FOREIGN KEY (set_id) REFERENCES workset_lst (set_id)
+);
+
+CREATE TABLE task_run_lst
+(
+  task_run_id INT          NOT NULL, -- unique task run id
+  task_id     INT          NOT NULL, -- task id
+  run_name    VARCHAR(255) NOT NULL, -- task run name
+  sub_count   INT          NOT NULL, -- number of sub-values in each model run
+  create_dt   VARCHAR(32)  NOT NULL, -- start date-time
+  status      VARCHAR(1)   NOT NULL, -- task run status: i=init p=progress w=wait s=success x=exit e=error
+  update_dt   VARCHAR(32)  NOT NULL, -- last update date-time
+  run_stamp   VARCHAR(32)  NOT NULL, -- task run stamp
+  PRIMARY KEY (task_run_id),
+  CONSTRAINT task_run_lst_mk FOREIGN KEY (task_id) REFERENCES task_lst (task_id)
+);
+
+CREATE TABLE task_run_set
+(
+  task_run_id INT NOT NULL, -- task run id
+  run_id      INT NOT NULL, -- model run id
+  set_id      INT NOT NULL, -- workset id
+  task_id     INT NOT NULL, -- task id
+  PRIMARY KEY (task_run_id, run_id),
+  CONSTRAINT task_run_set_un UNIQUE (run_id),
+  CONSTRAINT task_run_set_mk FOREIGN KEY (task_run_id) REFERENCES task_run_lst (task_run_id),
+  CONSTRAINT task_run_set_fk FOREIGN KEY (run_id) REFERENCES run_lst (run_id)
+);
+
+--
+-- side tables: workset read locks, workset parameters history and output tables statistics
+--
+CREATE TABLE workset_lock
+(
+  set_id     INT          NOT NULL, -- workset id
+  owner_name VARCHAR(255) NOT NULL, -- lock owner, e.g.: model run stamp
+  lock_dt    VARCHAR(32)  NOT NULL, -- lock acquired or refreshed date-time
+  expire_ts  BIGINT       NOT NULL, -- lock expiry time: unix milliseconds
+  PRIMARY KEY (set_id, owner_name)
+);
+
+CREATE TABLE workset_param_history
+(
+  max_versions INT NOT NULL, -- if positive then max number of versions of workset parameter
+  max_days     INT NOT NULL  -- if positive then max age in days of workset parameter version
+);
+
+CREATE TABLE workset_param_version
+(
+  set_id         INT         NOT NULL, -- workset id
+  parameter_hid  INT         NOT NULL, -- parameter unique id
+  version_id     INT         NOT NULL, -- version number: 1, 2, 3,...
+  sub_count      INT         NOT NULL, -- number of parameter sub-values
+  default_sub_id INT         NOT NULL, -- default sub-value id
+  version_dt     VARCHAR(32) NOT NULL, -- date-time when values were overwritten
+  PRIMARY KEY (set_id, parameter_hid, version_id)
+);
+
+CREATE TABLE run_table_stats
+(
+  run_id      INT    NOT NULL, -- model run id
+  table_hid   INT    NOT NULL, -- output table unique id
+  expr_id     INT    NOT NULL, -- output table expression id
+  value_count BIGINT NOT NULL, -- count of not NULL values
+  min_value   FLOAT  NULL,     -- min of values, NULL if there are no values
+  max_value   FLOAT  NULL,     -- max of values, NULL if there are no values
+  mean_value  FLOAT  NULL,     -- mean of values, NULL if there are no values
+  PRIMARY KEY (run_id, table_hid, expr_id)
+);
+
+--
+-- schema version and initial values of ids
+--
+INSERT INTO id_lst (id_key, id_value) VALUES ('openmpp',       105);
+INSERT INTO id_lst (id_key, id_value) VALUES ('lang_id',       100);
+INSERT INTO id_lst (id_key, id_value) VALUES ('model_id',      100);
+INSERT INTO id_lst (id_key, id_value) VALUES ('type_hid',      100);
+INSERT INTO id_lst (id_key, id_value) VALUES ('parameter_hid', 100);
+INSERT INTO id_lst (id_key, id_value) VALUES ('table_hid',     100);
+INSERT INTO id_lst (id_key, id_value) VALUES ('entity_hid',    100);
+INSERT INTO id_lst (id_key, id_value) VALUES ('run_id_set_id', 100);
@@ -0,0 +1,61 @@
+--
+-- Copyright (c) 2026 OpenM++
+-- This code is licensed under the MIT license (see LICENSE.txt for details)
+--
+-- Upgrade existing openM++ database: create side tables, which are not part of model metadata,
+-- and add value digest column into workset parameters table.
+-- It is not required if database created by create_db.sql script.
+-- Run it only once: tables and column must not exist.
+--
+
+--
+-- workset read locks
+--
+CREATE TABLE workset_lock
+(
+  set_id     INT          NOT NULL, -- workset id
+  owner_name VARCHAR(255) NOT NULL, -- lock owner, e.g.: model run stamp
+  lock_dt    VARCHAR(32)  NOT NULL, -- lock acquired or refreshed date-time
+  expire_ts  BIGINT       NOT NULL, -- lock expiry time: unix milliseconds
+  PRIMARY KEY (set_id, owner_name)
+);
+
+--
+-- workset parameters history: retention options and versions of workset parameter values
+--
+CREATE TABLE workset_param_history
+(
+  max_versions INT NOT NULL, -- if positive then max number of versions of workset parameter
+  max_days     INT NOT NULL  -- if positive then max age in days of workset parameter version
+);
+
+CREATE TABLE workset_param_version
+(
+  set_id         INT         NOT NULL, -- workset id
+  parameter_hid  INT         NOT NULL, -- parameter unique id
+  version_id     INT         NOT NULL, -- version number: 1, 2, 3,...
+  sub_count      INT         NOT NULL, -- number of parameter sub-values
+  default_sub_id INT         NOT NULL, -- default sub-value id
+  version_dt     VARCHAR(32) NOT NULL, -- date-time when values were overwritten
+  PRIMARY KEY (set_id, parameter_hid, version_id)
+);
+
+--
+-- output tables statistics: count, min, max and mean of output table expression values in model run
+--
+CREATE TABLE run_table_stats
+(
+  run_id      INT    NOT NULL, -- model run id
+  table_hid   INT    NOT NULL, -- output table unique id
+  expr_id     INT    NOT NULL, -- output table expression id
+  value_count BIGINT NOT NULL, -- count of not NULL values
+  min_value   FLOAT  NULL,     -- min of values, NULL if there are no values
+  max_value   FLOAT  NULL,     -- max of values, NULL if there are no values
+  mean_value  FLOAT  NULL,     -- mean of values, NULL if there are no values
+  PRIMARY KEY (run_id, table_hid, expr_id)
+);
+
+--
+-- workset parameters value digest: digest of parameter values, NULL if not calculated
+--
+ALTER TABLE workset_parameter ADD value_digest VARCHAR(32) NULL;